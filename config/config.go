@@ -15,17 +15,67 @@ type Config struct {
 	DatabaseName string
 
 	// GitHub API
-	GitHubToken    string
-	GitHubUsername string
+	GitHubToken             string
+	GitHubAPIURL            string
+	GitHubTokens            string
+	GitHubUsername          string
+	GitHubAccounts          string
+	GitHubAllowedUsers      string
+	GitHubIncludeOrgRepos   bool
+	GitHubLanguagesForks    bool
+	GitHubLanguagesArchived bool
+	GitHubOrganizations     string
+	GitHubReleaseDrafts     bool
+	ProfileReadmeInterval   time.Duration
+	GitHubTrafficInterval   time.Duration
+	PortfolioURL            string
+	SitemapSections         string
+	RobotsDisallow          string
+	GitHubRequestBudget     int
+	GitHubSyncCron          string
+	GitHubSyncJitter        time.Duration
+	GitHubSyncRetries       int
+	GitHubRetries           int
+	GitHubRetryMaxWait      time.Duration
+
+	// GitHub sync concurrency
+	GitHubLanguageConcurrency int
+	GitHubLanguagePacing      time.Duration
+
+	// GitHub throttling of non-urgent calls
+	GitHubThrottleThreshold int
+	GitHubThrottleMaxWait   time.Duration
+
+	// GitHub circuit breaker
+	GitHubBreakerThreshold int
+	GitHubBreakerCooldown  time.Duration
+
+	// Code host behind the /github profile, repository, contribution and
+	// stats endpoints
+	CodeHostProvider     string
+	GitLabURL            string
+	GitLabToken          string
+	BitbucketUsername    string
+	BitbucketAppPassword string
+	CodeHostAccounts     string
 
 	// Server Config
 	Port        string
 	GinMode     string
 	CORSOrigins string
+	GRPCPort    string
+	TestSupport bool
+
+	// Startup
+	StartupTimeout       time.Duration
+	StartupRetryInterval time.Duration
+	ReadinessWaitWarm    bool
+	ReadinessWaitSync    bool
 
 	// Auth
-	JWTSecret string
-	APIToken  string
+	JWTSecret         string
+	APIToken          string
+	RecruiterTokenTTL time.Duration
 
 	// Cache & Performance
 	GitHubCacheTTL  time.Duration
@@ -33,9 +83,83 @@ type Config struct {
 	RateLimitReqs   int
 	RateLimitWindow time.Duration
 
+	// Rate limit for authenticated callers (JWT or API token)
+	RateLimitAuthReqs   int
+	RateLimitAuthWindow time.Duration
+
+	// Cache storage format and limits (bytes)
+	CacheSerialization     string
+	CacheCompression       bool
+	CacheCompressThreshold int
+	CacheChunkSize         int
+	CacheMaxValueSize      int
+
+	// In-process L1 cache in front of MongoDB
+	CacheL1Size int
+	CacheL1TTL  time.Duration
+
 	// Monitoring
 	LogLevel      string
 	EnableMetrics bool
+	ServerTiming  bool
+
+	// Visitor analytics
+	VisitorTracking  bool
+	VisitorHashSalt  string
+	VisitorRetention time.Duration
+
+	// Integrations
+	DeployHookDebounce time.Duration
+	DeployHookRetries  int
+
+	// Outbound notifications (Slack/Discord webhooks, Telegram chat)
+	SlackWebhookURL      string
+	DiscordWebhookURL    string
+	NotifyTelegramToken  string
+	NotifyTelegramChatID string
+	NotifyEvents         string
+
+	// Email
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	OwnerEmail   string
+
+	// Weekly digest
+	DigestEnabled  bool
+	DigestDay      string
+	DigestTime     string
+	DigestSections string
+
+	// Resume served through tracked download links
+	ResumePath    string
+	ResumeLinkTTL time.Duration
+
+	// Availability calendar (Google Calendar free/busy)
+	GoogleCalendarID       string
+	GoogleCalendarAPIKey   string
+	AvailabilitySyncPeriod time.Duration
+
+	// Skill endorsements by visitors signed in with GitHub
+	GitHubOAuthClientID     string
+	GitHubOAuthClientSecret string
+	EndorsementModeration   bool
+
+	// Project feedback comments containing these words are flagged as spam
+	FeedbackBlockedWords string
+
+	// Background jobs
+	JobWorkers     int
+	JobMaxAttempts int
+	JobRetryDelay  time.Duration
+	JobTimeout     time.Duration
+	JobRetention   time.Duration
+
+	// Live updates WebSocket
+	LiveMaxConnections int
+	LiveMessageLimit   int
 }
 
 var AppConfig *Config
@@ -52,17 +176,98 @@ func Load() {
 		DatabaseName: getEnv("DATABASE_NAME", "portfolio"),
 
 		// GitHub API
-		GitHubToken:    getEnv("GITHUB_TOKEN", ""),
+		GitHubToken: getEnv("GITHUB_TOKEN", ""),
+		// REST API root; a GitHub Enterprise Server instance may be given as
+		// https://github.example.com, its /api/v3 prefix is added
+		GitHubAPIURL: getEnv("GITHUB_API_URL", "https://api.github.com"),
+		// Extra tokens (comma-separated) GitHub reads rotate through with
+		// GITHUB_TOKEN, by the rate limit each has left
+		GitHubTokens:   getEnv("GITHUB_TOKENS", ""),
 		GitHubUsername: getEnv("GITHUB_USERNAME", "felipemacedo1"),
+		// Extra accounts (comma-separated) merged into the portfolio
+		GitHubAccounts: getEnv("GITHUB_ACCOUNTS", ""),
+		// Usernames the /github/:username endpoints serve besides the portfolio
+		// accounts (comma-separated); "*" serves any username
+		GitHubAllowedUsers: getEnv("GITHUB_ALLOWED_USERS", ""),
+		// Credit the owner's share of organization repositories in stats
+		GitHubIncludeOrgRepos: parseBool("GITHUB_INCLUDE_ORG_REPOS", false),
+		// Whether forks and archived repositories count towards the language
+		// bytes of stats
+		GitHubLanguagesForks:    parseBool("GITHUB_LANGUAGES_INCLUDE_FORKS", false),
+		GitHubLanguagesArchived: parseBool("GITHUB_LANGUAGES_INCLUDE_ARCHIVED", true),
+		// Organizations (comma-separated) checked for the owner's commits
+		// instead of their public memberships; setting it includes them
+		GitHubOrganizations: getEnv("GITHUB_ORGANIZATIONS", ""),
+		// Draft changelog entries for new releases of the owner's repositories
+		GitHubReleaseDrafts: parseBool("GITHUB_RELEASE_DRAFTS", true),
+		// 0 disables scheduled profile README publishing
+		ProfileReadmeInterval: parseDuration("PROFILE_README_INTERVAL", "0s"),
+		// How often views and clones of the owner's repositories are stored,
+		// as GitHub keeps only 14 days of them; needs GITHUB_TOKEN, 0 disables
+		GitHubTrafficInterval: parseDuration("GITHUB_TRAFFIC_INTERVAL", "24h"),
+		// Public portfolio, linked from the notifications sent to project repositories
+		PortfolioURL: getEnv("PORTFOLIO_URL", ""),
+		// Pages of the portfolio listed in /sitemap.xml besides the projects
+		// (comma-separated paths), and paths /robots.txt keeps crawlers out of
+		SitemapSections: getEnv("SITEMAP_SECTIONS", "/,/projects,/experience,/skills,/education"),
+		RobotsDisallow:  getEnv("ROBOTS_DISALLOW", ""),
+		// Max GitHub calls per API request; 0 disables the budget
+		GitHubRequestBudget: parseInt("GITHUB_REQUEST_BUDGET", 20),
+		// Scheduled sync of the portfolio owner; empty disables it
+		GitHubSyncCron:    getEnv("GITHUB_SYNC_CRON", ""),
+		GitHubSyncJitter:  parseDuration("GITHUB_SYNC_JITTER", "5m"),
+		GitHubSyncRetries: parseInt("GITHUB_SYNC_RETRIES", 3),
+		// Retries of failed or rate limited GitHub calls, and the longest
+		// Retry-After/rate limit reset worth waiting for
+		GitHubRetries:      parseInt("GITHUB_RETRIES", 2),
+		GitHubRetryMaxWait: parseDuration("GITHUB_RETRY_MAX_WAIT", "10s"),
+		// Repository languages fetched in parallel during a sync, and the
+		// minimum interval between starting two of those calls
+		GitHubLanguageConcurrency: parseInt("GITHUB_LANGUAGE_CONCURRENCY", 4),
+		GitHubLanguagePacing:      parseDuration("GITHUB_LANGUAGE_PACING", "50ms"),
+		// Below this many calls left, non-urgent calls (repository languages,
+		// enrichment) are spread until the rate limit resets; 0 disables it.
+		// Calls that would wait longer are left for a later run.
+		GitHubThrottleThreshold: parseInt("GITHUB_THROTTLE_THRESHOLD", 500),
+		GitHubThrottleMaxWait:   parseDuration("GITHUB_THROTTLE_MAX_WAIT", "30s"),
+		// Consecutive failed calls that stop GitHub calls for the cooldown;
+		// 0 disables the circuit breaker
+		GitHubBreakerThreshold: parseInt("GITHUB_BREAKER_THRESHOLD", 5),
+		GitHubBreakerCooldown:  parseDuration("GITHUB_BREAKER_COOLDOWN", "1m"),
+
+		// github, gitlab or bitbucket; GitLab may be self-hosted, and both
+		// authenticate only when credentials are given
+		CodeHostProvider:     getEnv("CODE_HOST_PROVIDER", "github"),
+		GitLabURL:            getEnv("GITLAB_URL", "https://gitlab.com"),
+		GitLabToken:          getEnv("GITLAB_TOKEN", ""),
+		BitbucketUsername:    getEnv("BITBUCKET_USERNAME", ""),
+		BitbucketAppPassword: getEnv("BITBUCKET_APP_PASSWORD", ""),
+		// Accounts on any host summed by /github/stats/aggregate, as
+		// host:username (gitlab:octocat,bitbucket:acme); GitHub when unprefixed
+		CodeHostAccounts: getEnv("CODE_HOST_ACCOUNTS", ""),
 
 		// Server Config
 		Port:        getEnv("PORT", "8080"),
 		GinMode:     getEnv("GIN_MODE", "debug"),
 		CORSOrigins: getEnv("CORS_ORIGINS", "*"),
+		// Port of the gRPC API; empty leaves it off
+		GRPCPort: getEnv("GRPC_PORT", ""),
+		// Fixture seeding API for the frontend's E2E suites; ignored in release mode
+		TestSupport: parseBool("TEST_SUPPORT", false),
+
+		// Startup
+		StartupTimeout:       parseDuration("STARTUP_TIMEOUT", "2m"),
+		StartupRetryInterval: parseDuration("STARTUP_RETRY_INTERVAL", "1s"),
+		// Keep /readiness failing until the content cache is warm and, with
+		// READINESS_WAIT_SYNC, until the owner's GitHub data has been synced
+		ReadinessWaitWarm: parseBool("READINESS_WAIT_WARM", false),
+		ReadinessWaitSync: parseBool("READINESS_WAIT_SYNC", false),
 
 		// Auth
 		JWTSecret: getEnv("JWT_SECRET", "default-secret-change-in-production"),
 		APIToken:  getEnv("API_TOKEN", "default-api-token"),
+		// Default lifetime of recruiter tokens for the private details
+		RecruiterTokenTTL: parseDuration("RECRUITER_TOKEN_TTL", "168h"),
 
 		// Cache & Performance
 		GitHubCacheTTL:  parseDuration("GITHUB_CACHE_TTL", "6h"),
@@ -70,9 +275,91 @@ func Load() {
 		RateLimitReqs:   parseInt("RATE_LIMIT_REQUESTS", 100),
 		RateLimitWindow: parseDuration("RATE_LIMIT_WINDOW", "3600s"),
 
+		// Rate limit for authenticated callers
+		RateLimitAuthReqs:   parseInt("RATE_LIMIT_AUTH_REQUESTS", 1000),
+		RateLimitAuthWindow: parseDuration("RATE_LIMIT_AUTH_WINDOW", "3600s"),
+
+		// Cache storage format and limits
+		CacheSerialization:     getEnv("CACHE_SERIALIZATION", "json"),
+		CacheCompression:       parseBool("CACHE_COMPRESSION", true),
+		CacheCompressThreshold: parseInt("CACHE_COMPRESS_THRESHOLD", 64*1024),
+		CacheChunkSize:         parseInt("CACHE_CHUNK_SIZE", 8*1024*1024),
+		CacheMaxValueSize:      parseInt("CACHE_MAX_VALUE_SIZE", 64*1024*1024),
+
+		// In-process L1 cache; 0 entries disables it
+		CacheL1Size: parseInt("CACHE_L1_SIZE", 1000),
+		CacheL1TTL:  parseDuration("CACHE_L1_TTL", "30s"),
+
 		// Monitoring
 		LogLevel:      getEnv("LOG_LEVEL", "info"),
 		EnableMetrics: parseBool("ENABLE_METRICS", true),
+		ServerTiming:  parseBool("SERVER_TIMING", true),
+
+		// Anonymized page views; IPs are only stored as a keyed hash, keyed
+		// with JWT_SECRET unless a salt is given
+		VisitorTracking:  parseBool("VISITOR_TRACKING", true),
+		VisitorHashSalt:  getEnv("VISITOR_HASH_SALT", ""),
+		VisitorRetention: parseDuration("VISITOR_RETENTION", "2160h"),
+
+		// Integrations
+		DeployHookDebounce: parseDuration("DEPLOY_HOOK_DEBOUNCE", "30s"),
+		// Retries, with backoff, of deploy hooks fired by content changes
+		DeployHookRetries: parseInt("DEPLOY_HOOK_RETRIES", 3),
+
+		// Channels sync failures, contact messages and content publishes are
+		// pushed to; the Telegram chat is used while the admin bot is
+		// disabled. NOTIFY_EVENTS lists the events sent.
+		SlackWebhookURL:      getEnv("SLACK_WEBHOOK_URL", ""),
+		DiscordWebhookURL:    getEnv("DISCORD_WEBHOOK_URL", ""),
+		NotifyTelegramToken:  getEnv("NOTIFY_TELEGRAM_BOT_TOKEN", ""),
+		NotifyTelegramChatID: getEnv("NOTIFY_TELEGRAM_CHAT_ID", ""),
+		NotifyEvents:         getEnv("NOTIFY_EVENTS", "sync_failed,contact_message,content_published"),
+
+		// Email
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     parseInt("SMTP_PORT", 587),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnv("SMTP_FROM", "portfolio@localhost"),
+		OwnerEmail:   getEnv("OWNER_EMAIL", ""),
+
+		// Weekly digest
+		DigestEnabled:  parseBool("DIGEST_ENABLED", false),
+		DigestDay:      getEnv("DIGEST_DAY", "monday"),
+		DigestTime:     getEnv("DIGEST_TIME", "09:00"),
+		DigestSections: getEnv("DIGEST_SECTIONS", "stars,followers,repos,content"),
+
+		// Resume file served through tracked download links
+		ResumePath:    getEnv("RESUME_PATH", "resume.pdf"),
+		ResumeLinkTTL: parseDuration("RESUME_LINK_TTL", "720h"),
+
+		// Availability calendar; an empty calendar id disables the sync
+		GoogleCalendarID:       getEnv("GOOGLE_CALENDAR_ID", ""),
+		GoogleCalendarAPIKey:   getEnv("GOOGLE_CALENDAR_API_KEY", ""),
+		AvailabilitySyncPeriod: parseDuration("AVAILABILITY_SYNC_PERIOD", "15m"),
+
+		// GitHub OAuth app endorsers sign in with; endorsements are disabled
+		// without it. With moderation on, endorsements wait for approval.
+		GitHubOAuthClientID:     getEnv("GITHUB_OAUTH_CLIENT_ID", ""),
+		GitHubOAuthClientSecret: getEnv("GITHUB_OAUTH_CLIENT_SECRET", ""),
+		EndorsementModeration:   parseBool("ENDORSEMENT_MODERATION", false),
+
+		// Comma-separated words flagging project feedback as spam
+		FeedbackBlockedWords: getEnv("FEEDBACK_BLOCKED_WORDS", "casino,viagra,crypto giveaway,seo services"),
+
+		// Background job workers on this instance (0 only enqueues); failed
+		// attempts are retried with exponential backoff, then dead-lettered.
+		// Finished jobs are removed after JOB_RETENTION (0 keeps them).
+		JobWorkers:     parseInt("JOB_WORKERS", 2),
+		JobMaxAttempts: parseInt("JOB_MAX_ATTEMPTS", 3),
+		JobRetryDelay:  parseDuration("JOB_RETRY_DELAY", "30s"),
+		JobTimeout:     parseDuration("JOB_TIMEOUT", "10m"),
+		JobRetention:   parseDuration("JOB_RETENTION", "168h"),
+
+		// Live updates WebSocket connections accepted by this instance (0 is
+		// unlimited) and messages each client may send per minute
+		LiveMaxConnections: parseInt("LIVE_MAX_CONNECTIONS", 1000),
+		LiveMessageLimit:   parseInt("LIVE_MESSAGE_LIMIT", 30),
 	}
 
 	log.Printf("Configuration loaded successfully")
@@ -3,36 +3,50 @@ package controllers
 import (
 	"fmt"
 	"net/http"
-	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/middleware"
 	"portfolio-backend/models"
 	"portfolio-backend/services"
+	"portfolio-backend/utils"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AnalyticsController struct {
-	githubService  *services.GitHubService
-	contentService *services.ContentService
-	cacheService   *services.CacheService
+	githubService   *services.GitHubService
+	contentService  *services.ContentService
+	cacheService    *services.CacheService
+	settingsService *services.SettingsService
+	visitorService  *services.VisitorService
 }
 
 func NewAnalyticsController() *AnalyticsController {
 	return &AnalyticsController{
-		githubService:  services.NewGitHubService(),
-		contentService: services.NewContentService(),
-		cacheService:   services.NewCacheService(),
+		githubService:   services.NewGitHubService(),
+		contentService:  services.NewContentService(),
+		cacheService:    services.NewCacheService(),
+		settingsService: services.NewSettingsService(),
+		visitorService:  services.NewVisitorService(),
 	}
 }
 
+// trafficRanges are the ?range= values accepted by the traffic analytics
+var trafficRanges = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+	"90d": 90 * 24 * time.Hour,
+}
+
 // GetSummary returns analytics summary
 func (ac *AnalyticsController) GetSummary(c *gin.Context) {
-	username := config.AppConfig.GitHubUsername
+	username := ac.settingsService.GetOwner(c.Request.Context())
 
 	// Get GitHub stats
 	githubStats, err := ac.githubService.GetStats(c.Request.Context(), username)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve analytics data",
 			Details:   err.Error(),
@@ -70,29 +84,19 @@ func (ac *AnalyticsController) GetSummary(c *gin.Context) {
 		ContributionData: contributions,
 	}
 
-	// Performance metrics (simulated)
-	performance := models.PerformanceMetrics{
-		AverageResponseTime:  150.5,
-		TotalRequests:       1000,
-		ErrorRate:           0.02,
-		CacheHitRate:        0.85,
-		DatabaseConnections: 5,
+	// Request metrics are collected per route template by middleware.Metrics
+	performance, endpoints := middleware.RequestMetrics()
+	performance.CacheHitRate = services.CacheHitRate()
+	performance.DatabaseConnections = database.OpenConnections()
+	if len(endpoints) > 3 {
+		endpoints = endpoints[:3]
 	}
 
-	// Traffic metrics (simulated)
-	traffic := models.TrafficMetrics{
-		UniqueVisitors: 250,
-		PageViews:      500,
-		TopEndpoints: []models.EndpointStat{
-			{Endpoint: "/api/v1/github/profile", Hits: 150, AvgTime: 120.5},
-			{Endpoint: "/api/v1/content", Hits: 100, AvgTime: 80.2},
-			{Endpoint: "/api/v1/github/repos", Hits: 75, AvgTime: 200.1},
-		},
-		GeographicData: map[string]interface{}{
-			"Brazil": 60,
-			"USA":    25,
-			"Europe": 15,
-		},
+	// Traffic over the last 30 days, from the recorded page views
+	now := time.Now()
+	traffic, err := ac.visitorService.GetTraffic(c.Request.Context(), now.Add(-trafficRanges["30d"]), now)
+	if err != nil {
+		traffic = &models.TrafficMetrics{TopEndpoints: endpoints, GeographicData: map[string]interface{}{}}
 	}
 
 	// Build complete analytics response
@@ -100,27 +104,56 @@ func (ac *AnalyticsController) GetSummary(c *gin.Context) {
 		Summary:     summary,
 		GitHub:      githubAnalytics,
 		Performance: performance,
-		Traffic:     traffic,
+		Traffic:     *traffic,
 		LastUpdated: time.Now(),
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      analytics,
 		Message:   "Analytics summary retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
+	})
+}
+
+// GetCommitInsights returns the top terms, the conventional commit types and
+// the average subject length of the owner's recent commit messages
+func (ac *AnalyticsController) GetCommitInsights(c *gin.Context) {
+	username := ac.settingsService.GetOwner(c.Request.Context())
+
+	insights, err := ac.githubService.GetCommitInsights(c.Request.Context(), username)
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve commit insights",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      insights,
+		Message:   "Commit insights retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
 	})
 }
 
 // GetContributionsByPeriod returns contribution data for a specific period
 func (ac *AnalyticsController) GetContributionsByPeriod(c *gin.Context) {
 	period := c.Param("period")
-	username := config.AppConfig.GitHubUsername
+	username := ac.settingsService.GetOwner(c.Request.Context())
 
 	if period == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
 			Success:   false,
 			Error:     "Period is required",
 			Code:      "MISSING_PERIOD",
@@ -139,7 +172,7 @@ func (ac *AnalyticsController) GetContributionsByPeriod(c *gin.Context) {
 	}
 
 	if !validPeriods[period] {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
 			Success:   false,
 			Error:     "Invalid period. Valid periods are: week, month, year, all",
 			Code:      "INVALID_PERIOD",
@@ -152,7 +185,7 @@ func (ac *AnalyticsController) GetContributionsByPeriod(c *gin.Context) {
 	// Get contributions data
 	contributions, err := ac.githubService.GetContributions(c.Request.Context(), username)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve contribution data",
 			Details:   err.Error(),
@@ -173,19 +206,31 @@ func (ac *AnalyticsController) GetContributionsByPeriod(c *gin.Context) {
 		filteredData = filterContributionsByDays(contributions, 30)
 	case "year":
 		// Return current year
-		filteredData = filterContributionsByYear(contributions, time.Now().Year())
+		year := time.Now().UTC().Year()
+		yearly, err := ac.githubService.GetContributionsForYear(c.Request.Context(), username, year)
+		if err != nil {
+			utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+				Success:   false,
+				Error:     "Failed to retrieve contribution data",
+				Details:   err.Error(),
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			return
+		}
+		filteredData = filterContributionsByYear(yearly, year, contributions.CurrentStreak)
 	case "all":
 		// Return all data
 		filteredData = contributions
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      filteredData,
 		Message:   "Contribution data retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
 }
 
@@ -193,7 +238,7 @@ func (ac *AnalyticsController) GetContributionsByPeriod(c *gin.Context) {
 func (ac *AnalyticsController) GetCacheStats(c *gin.Context) {
 	stats, err := ac.cacheService.GetStats(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve cache statistics",
 			Details:   err.Error(),
@@ -203,73 +248,132 @@ func (ac *AnalyticsController) GetCacheStats(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      stats,
 		Message:   "Cache statistics retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
 }
 
 // GetPerformanceMetrics returns detailed performance metrics
 func (ac *AnalyticsController) GetPerformanceMetrics(c *gin.Context) {
-	// In a real implementation, this would collect actual metrics
-	// from monitoring systems, logs, or metrics collectors
-	
-	metrics := models.PerformanceMetrics{
-		AverageResponseTime:  calculateAverageResponseTime(),
-		TotalRequests:       getTotalRequests(),
-		ErrorRate:           calculateErrorRate(),
-		CacheHitRate:        0.85, // From cache service
-		DatabaseConnections: 5,    // From database pool
-	}
+	metrics, endpoints := middleware.RequestMetrics()
+	metrics.CacheHitRate = services.CacheHitRate()
+	metrics.DatabaseConnections = database.OpenConnections()
+	metrics.Endpoints = endpoints
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      metrics,
 		Message:   "Performance metrics retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
 }
 
-// Helper functions for filtering and calculations
+// GetTraffic returns unique visitors, page views, top endpoints, referrers
+// and countries over ?range= (24h, 7d, 30d or 90d, default 7d), or between
+// ?since= and ?until= (RFC 3339 or YYYY-MM-DD)
+func (ac *AnalyticsController) GetTraffic(c *gin.Context) {
+	since, until, err := parseTrafficRange(c, time.Now())
+	if err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid time range",
+			Details:   err.Error(),
+			Code:      "INVALID_RANGE",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
 
-func filterContributionsByDays(contributions *models.GitHubContributions, days int) interface{} {
-	// Implementation would filter contribution calendar by the specified number of days
-	// This is a simplified version
-	return map[string]interface{}{
-		"period":       fmt.Sprintf("last_%d_days", days),
-		"total_count":  contributions.TotalContributions,
-		"daily_average": contributions.TotalContributions / days,
-		"streak":       contributions.CurrentStreak,
+	traffic, err := ac.visitorService.GetTraffic(c.Request.Context(), since, until)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve traffic analytics",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
 	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      traffic,
+		Message:   "Traffic analytics retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
 }
 
-func filterContributionsByYear(contributions *models.GitHubContributions, year int) interface{} {
-	// Implementation would filter contribution calendar by year
-	return map[string]interface{}{
-		"year":        year,
-		"total_count": contributions.TotalContributions,
-		"calendar":    contributions.ContributionCalendar,
-		"streak":      contributions.CurrentStreak,
+// parseTrafficRange resolves the time range of the traffic analytics
+func parseTrafficRange(c *gin.Context, now time.Time) (time.Time, time.Time, error) {
+	if c.Query("since") == "" && c.Query("until") == "" {
+		name := c.DefaultQuery("range", "7d")
+		window, ok := trafficRanges[name]
+		if !ok {
+			return time.Time{}, time.Time{}, fmt.Errorf("unknown range %q, expected 24h, 7d, 30d or 90d", name)
+		}
+		return now.Add(-window), now, nil
 	}
-}
 
-func calculateAverageResponseTime() float64 {
-	// In a real implementation, this would calculate from actual request logs
-	return 150.5 // milliseconds
+	since, until := now.Add(-trafficRanges["7d"]), now
+	var err error
+	if param := c.Query("since"); param != "" {
+		if since, err = parseSince(param); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid since: %s", param)
+		}
+	}
+	if param := c.Query("until"); param != "" {
+		if until, err = parseSince(param); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid until: %s", param)
+		}
+	}
+	if !since.Before(until) {
+		return time.Time{}, time.Time{}, fmt.Errorf("since must be before until")
+	}
+	return since, until, nil
 }
 
-func getTotalRequests() int64 {
-	// In a real implementation, this would come from request counters
-	return 1000
+// Helper functions for filtering and calculations
+
+func filterContributionsByDays(contributions *models.GitHubContributions, days int) interface{} {
+	since := time.Now().UTC().AddDate(0, 0, -days).Format("2006-01-02")
+
+	total := 0
+	var calendar []models.ContributionDay
+	for _, week := range contributions.ContributionCalendar {
+		for _, day := range week.Days {
+			if day.Date > since {
+				total += day.Count
+				calendar = append(calendar, day)
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"period":        fmt.Sprintf("last_%d_days", days),
+		"total_count":   total,
+		"daily_average": float64(total) / float64(days),
+		"streak":        contributions.CurrentStreak,
+		"days":          calendar,
+	}
 }
 
-func calculateErrorRate() float64 {
-	// In a real implementation, this would calculate error rate from logs
-	return 0.02 // 2%
-}
\ No newline at end of file
+func filterContributionsByYear(yearly *models.GitHubContributions, year, streak int) interface{} {
+	return map[string]interface{}{
+		"year":           year,
+		"total_count":    yearly.TotalContributions,
+		"calendar":       yearly.ContributionCalendar,
+		"streak":         streak,
+		"longest_streak": yearly.LongestStreak,
+	}
+}
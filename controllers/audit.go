@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditController struct {
+	auditService *services.AuditService
+}
+
+func NewAuditController() *AuditController {
+	return &AuditController{
+		auditService: services.NewAuditService(),
+	}
+}
+
+// ListEntries returns a page of the audit log, newest first, filtered by
+// ?actor=, ?action=, ?target=, ?request_id=, ?since= and ?until=
+func (ac *AuditController) ListEntries(c *gin.Context) {
+	page, limit, errs := utils.ValidateQueryParams(c.Query("page"), c.Query("limit"))
+	query := models.AuditQuery{
+		Actor:     c.Query("actor"),
+		Action:    c.Query("action"),
+		Target:    c.Query("target"),
+		RequestID: c.Query("request_id"),
+		Page:      page,
+		Limit:     limit,
+	}
+
+	validator := utils.NewValidator()
+	for _, param := range []string{"since", "until"} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := parseSince(value)
+		if err != nil {
+			validator.AddError(param, "Must be an RFC 3339 time or a date", "INVALID_TIME")
+			continue
+		}
+		if param == "since" {
+			query.Since = parsed
+		} else {
+			query.Until = parsed
+		}
+	}
+	if errs = append(errs, validator.GetErrors()...); len(errs) > 0 {
+		utils.ValidationErrorResponse(c, errs)
+		return
+	}
+
+	entries, total, err := ac.auditService.ListEntries(c.Request.Context(), query)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve audit log",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.PaginatedResponse(c, entries, utils.CalculatePagination(page, limit, total))
+}
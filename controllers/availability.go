@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AvailabilityController struct {
+	availabilityService *services.AvailabilityService
+}
+
+func NewAvailabilityController() *AvailabilityController {
+	return &AvailabilityController{
+		availabilityService: services.NewAvailabilityService(),
+	}
+}
+
+// GetAvailability returns whether the owner is open to new work
+func (ac *AvailabilityController) GetAvailability(c *gin.Context) {
+	status, err := ac.availabilityService.GetStatus(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve availability",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      status,
+		Message:   "Availability retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// UpdateAvailability changes the "hire me" status shown publicly
+func (ac *AvailabilityController) UpdateAvailability(c *gin.Context) {
+	var request models.Availability
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	err := ac.availabilityService.SetAvailability(c.Request.Context(), request, c.GetString("user_type"))
+	if err != nil {
+		status, code := http.StatusInternalServerError, ""
+		if errors.Is(err, services.ErrInvalidAvailability) {
+			status, code = http.StatusBadRequest, "INVALID_AVAILABILITY"
+		}
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to update availability",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      request,
+		Message:   "Availability updated successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
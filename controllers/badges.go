@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// imageStaleFor is how long browsers and CDNs may keep serving a badge or
+// graph while they refresh it, or while the API is failing
+const imageStaleFor = 24 * time.Hour
+
+// GetBadge serves a stat of the owner as a shields-style SVG badge, e.g.
+// /badges/stars.svg?color=green&style=flat-square, for READMEs and the
+// portfolio site
+func (gc *GitHubController) GetBadge(c *gin.Context) {
+	badge, ok := strings.CutSuffix(c.Param("badge"), ".svg")
+	if !ok || !services.BadgeSupported(badge) {
+		utils.JSON(c, http.StatusNotFound, models.ErrorResponse{
+			Success:   false,
+			Error:     "Badge not found",
+			Details:   "Available badges: " + strings.Join(services.Badges(), ", ") + " (as .svg)",
+			Code:      "BADGE_NOT_FOUND",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	color := c.DefaultQuery("color", services.DefaultBadgeColor)
+	style := c.DefaultQuery("style", services.DefaultBadgeStyle)
+
+	validator := utils.NewValidator()
+	if _, ok := services.BadgeColor(color); !ok {
+		validator.AddError("color", "Must be a named color or a hex color such as 4c1 or ff69b4", "INVALID_COLOR")
+	}
+	validator.OneOf("style", style, services.BadgeStyles())
+	if !validator.IsValid() {
+		utils.ValidationErrorResponse(c, validator.GetErrors())
+		return
+	}
+
+	username := gc.settingsService.GetOwner(c.Request.Context())
+	svg, err := gc.githubService.RenderBadge(c.Request.Context(), username, badge, color, style, labeler(c, gc.settingsService))
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to render badge",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	setImageCacheControl(c)
+	c.Data(http.StatusOK, "image/svg+xml; charset=utf-8", svg)
+}
+
+// setImageCacheControl lets browsers and CDNs keep images of GitHub data
+// for as long as the data is cached, and serve them stale for a while
+// longer, since READMEs load them on every view
+func setImageCacheControl(c *gin.Context) {
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d, stale-if-error=%d",
+		int(config.AppConfig.GitHubCacheTTL.Seconds()), int(imageStaleFor.Seconds()), int(imageStaleFor.Seconds())))
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CDNPurgeController struct {
+	cdnPurgeService *services.CDNPurgeService
+}
+
+func NewCDNPurgeController() *CDNPurgeController {
+	return &CDNPurgeController{
+		cdnPurgeService: services.NewCDNPurgeService(),
+	}
+}
+
+// GetIntegrations returns the configured CDN integrations without their API tokens
+func (pc *CDNPurgeController) GetIntegrations(c *gin.Context) {
+	integrations, err := pc.cdnPurgeService.GetIntegrations(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve CDN integrations",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	for i := range integrations {
+		integrations[i].APIToken = ""
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      integrations,
+		Message:   "CDN integrations retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// UpdateIntegrations replaces the configured CDN integrations
+func (pc *CDNPurgeController) UpdateIntegrations(c *gin.Context) {
+	var request models.CDNIntegrationsUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if err := pc.cdnPurgeService.SetIntegrations(c.Request.Context(), request.Integrations, c.GetString("user_type")); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to update CDN integrations",
+			Details:   err.Error(),
+			Code:      "INVALID_CDN_INTEGRATIONS",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Message:   "CDN integrations updated successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// Purge purges every path, or every surrogate key, of all enabled
+// integrations immediately, or of a single one via ?integration=
+func (pc *CDNPurgeController) Purge(c *gin.Context) {
+	runs, err := pc.cdnPurgeService.Purge(c.Request.Context(), "manual", nil, nil, c.Query("integration"))
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to purge CDN caches",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      runs,
+		Message:   "CDN caches purged",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetHistory returns recent CDN purges, optionally filtered by ?integration=
+func (pc *CDNPurgeController) GetHistory(c *gin.Context) {
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	history, err := pc.cdnPurgeService.GetHistory(c.Request.Context(), c.Query("integration"), limit)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve CDN purge history",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      history,
+		Message:   "CDN purge history retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
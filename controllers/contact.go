@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"net/http"
+	"portfolio-backend/middleware"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ContactController struct {
+	contactService *services.ContactService
+}
+
+func NewContactController() *ContactController {
+	return &ContactController{
+		contactService: services.NewContactService(),
+	}
+}
+
+// SubmitMessage stores a message sent through the contact form and emails
+// it to the owner
+func (cc *ContactController) SubmitMessage(c *gin.Context) {
+	var request models.ContactMessageRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	validator := utils.NewValidator()
+	if !validator.ValidateContactMessageRequest(&request).IsValid() {
+		utils.ValidationErrorResponse(c, validator.GetErrors())
+		return
+	}
+
+	message, err := cc.contactService.Submit(c.Request.Context(), middleware.ByClientIP(c), request)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to send message",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusCreated, models.APIResponse{
+		Success:   true,
+		Data:      gin.H{"id": message.ID},
+		Message:   "Thanks for your message",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// ListMessages returns the newest contact messages, from ?since= and up to
+// ?limit=, the ones flagged as spam with ?spam=true
+func (cc *ContactController) ListMessages(c *gin.Context) {
+	var since time.Time
+	if value := c.Query("since"); value != "" {
+		parsed, err := parseSince(value)
+		if err != nil {
+			utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+				Success:   false,
+				Error:     "Invalid since parameter",
+				Details:   "Use an RFC 3339 time or a YYYY-MM-DD date",
+				Code:      "INVALID_SINCE",
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			return
+		}
+		since = parsed
+	}
+	spam, _ := strconv.ParseBool(c.Query("spam"))
+	limit := 50
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 200 {
+		limit = l
+	}
+
+	messages, err := cc.contactService.ListMessages(c.Request.Context(), spam, since, limit)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to list contact messages",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      messages,
+		Message:   "Contact messages retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
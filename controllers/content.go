@@ -1,29 +1,49 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
+	"portfolio-backend/middleware"
 	"portfolio-backend/models"
 	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 type ContentController struct {
-	contentService *services.ContentService
+	contentService     *services.ContentService
+	endorsementService *services.EndorsementService
+	settingsService    *services.SettingsService
 }
 
 func NewContentController() *ContentController {
 	return &ContentController{
-		contentService: services.NewContentService(),
+		contentService:     services.NewContentService(),
+		endorsementService: services.NewEndorsementService(),
+		settingsService:    services.NewSettingsService(),
 	}
 }
 
 // GetContent returns all portfolio content
 func (cc *ContentController) GetContent(c *gin.Context) {
+	expand, ok := cc.parseExpand(c)
+	if !ok {
+		return
+	}
+
 	portfolio, err := cc.contentService.GetPortfolio(c.Request.Context())
+	if err == nil && expand[services.ExpandExperience] {
+		err = cc.contentService.ExpandProjects(c.Request.Context(), portfolio.Projects)
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve portfolio content",
 			Details:   err.Error(),
@@ -32,14 +52,15 @@ func (cc *ContentController) GetContent(c *gin.Context) {
 		})
 		return
 	}
+	labeler(c, cc.settingsService).Skills(&portfolio.Skills)
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      portfolio,
 		Message:   "Portfolio content retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
 }
 
@@ -47,7 +68,7 @@ func (cc *ContentController) GetContent(c *gin.Context) {
 func (cc *ContentController) GetSkills(c *gin.Context) {
 	skills, err := cc.contentService.GetSkills(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve skills",
 			Details:   err.Error(),
@@ -57,13 +78,19 @@ func (cc *ContentController) GetSkills(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	// Skills are still served when endorsements cannot be counted
+	if err := cc.endorsementService.AttachEndorsements(c.Request.Context(), skills); err != nil {
+		log.Printf("Failed to attach skill endorsements: %v", err)
+	}
+	labeler(c, cc.settingsService).Skills(skills)
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      skills,
 		Message:   "Skills retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
 }
 
@@ -71,7 +98,7 @@ func (cc *ContentController) GetSkills(c *gin.Context) {
 func (cc *ContentController) GetExperience(c *gin.Context) {
 	experience, err := cc.contentService.GetExperience(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve experience",
 			Details:   err.Error(),
@@ -81,21 +108,30 @@ func (cc *ContentController) GetExperience(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      experience,
 		Message:   "Experience retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
 }
 
-// GetProjects returns projects information
+// GetProjects returns projects information, with the experience entry each
+// project links to when asked for with ?expand=experience
 func (cc *ContentController) GetProjects(c *gin.Context) {
+	expand, ok := cc.parseExpand(c)
+	if !ok {
+		return
+	}
+
 	projects, err := cc.contentService.GetProjects(c.Request.Context())
+	if err == nil && expand[services.ExpandExperience] {
+		err = cc.contentService.ExpandProjects(c.Request.Context(), projects)
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve projects",
 			Details:   err.Error(),
@@ -105,13 +141,13 @@ func (cc *ContentController) GetProjects(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      projects,
 		Message:   "Projects retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
 }
 
@@ -119,7 +155,7 @@ func (cc *ContentController) GetProjects(c *gin.Context) {
 func (cc *ContentController) GetEducation(c *gin.Context) {
 	education, err := cc.contentService.GetEducation(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve education",
 			Details:   err.Error(),
@@ -129,13 +165,37 @@ func (cc *ContentController) GetEducation(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      education,
 		Message:   "Education retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
+	})
+}
+
+// GetChangelog returns the published changelog
+func (cc *ContentController) GetChangelog(c *gin.Context) {
+	changelog, err := cc.contentService.GetChangelog(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve changelog",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      changelog,
+		Message:   "Changelog retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
 	})
 }
 
@@ -143,7 +203,7 @@ func (cc *ContentController) GetEducation(c *gin.Context) {
 func (cc *ContentController) GetMeta(c *gin.Context) {
 	meta, err := cc.contentService.GetMeta(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve meta information",
 			Details:   err.Error(),
@@ -153,13 +213,13 @@ func (cc *ContentController) GetMeta(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      meta,
 		Message:   "Meta information retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
 }
 
@@ -167,7 +227,7 @@ func (cc *ContentController) GetMeta(c *gin.Context) {
 func (cc *ContentController) UpdateContent(c *gin.Context) {
 	var request models.ContentUpdateRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
 			Success:   false,
 			Error:     "Invalid request body",
 			Details:   err.Error(),
@@ -178,16 +238,67 @@ func (cc *ContentController) UpdateContent(c *gin.Context) {
 		return
 	}
 
+	validator := utils.NewValidator().At("").ValidateContentUpdateRequest(&request)
+	if validator.IsValid() {
+		validator.ValidateContent(request.Type, request.Data)
+	}
+	if !validator.IsValid() {
+		utils.ValidationErrorResponse(c, validator.GetErrors())
+		return
+	}
+
+	// Links to other content must point at entries that exist
+	referenceErrors, err := cc.contentService.CheckReferences(c.Request.Context(), request.Type, request.Data)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to check content references",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	if len(referenceErrors) > 0 {
+		utils.ValidationErrorResponse(c, referenceErrors)
+		return
+	}
+
 	// Get user context
 	userID := "anonymous"
 	if userIDVal, exists := c.Get("user_id"); exists {
 		userID = userIDVal.(string)
 	}
 
+	// Drafts are staged for review and published later
+	if request.State == models.ContentStateDraft {
+		draft, err := cc.contentService.SaveDraft(c.Request.Context(), request.Type, request.Data, userID)
+		if err != nil {
+			utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+				Success:   false,
+				Error:     "Failed to save draft",
+				Details:   err.Error(),
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			return
+		}
+
+		utils.JSON(c, http.StatusOK, models.APIResponse{
+			Success:   true,
+			Data:      draft,
+			Message:   "Draft saved successfully",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+			Version:   models.APIVersion,
+		})
+		return
+	}
+
 	// Update content
-	err := cc.contentService.UpdateContent(c.Request.Context(), request.Type, request.Data, userID)
+	err = cc.contentService.UpdateContent(c.Request.Context(), request.Type, request.Data, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to update content",
 			Details:   err.Error(),
@@ -197,12 +308,140 @@ func (cc *ContentController) UpdateContent(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Message:   "Content updated successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
+	})
+}
+
+// PreviewContent returns the portfolio as it will look once every draft is
+// published (GET /content?state=draft)
+func (cc *ContentController) PreviewContent(c *gin.Context) {
+	expand, ok := cc.parseExpand(c)
+	if !ok {
+		return
+	}
+
+	portfolio, err := cc.contentService.PreviewPortfolio(c.Request.Context(), expand[services.ExpandExperience])
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to preview portfolio content",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	labeler(c, cc.settingsService).Skills(&portfolio.Skills)
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      portfolio,
+		Message:   "Portfolio preview retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// draftErrorStatus maps draft errors to a status and code
+func draftErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, services.ErrDraftNotFound):
+		return http.StatusNotFound, "DRAFT_NOT_FOUND"
+	case errors.Is(err, services.ErrDraftOutdated):
+		return http.StatusConflict, "DRAFT_OUTDATED"
+	}
+	return http.StatusInternalServerError, ""
+}
+
+func (cc *ContentController) failDraft(c *gin.Context, message string, err error) {
+	status, code := draftErrorStatus(err)
+	utils.JSON(c, status, models.ErrorResponse{
+		Success:   false,
+		Error:     message,
+		Details:   err.Error(),
+		Code:      code,
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+	})
+}
+
+// GetDrafts lists the drafts waiting to be published
+func (cc *ContentController) GetDrafts(c *gin.Context) {
+	drafts, err := cc.contentService.GetDrafts(c.Request.Context())
+	if err != nil {
+		cc.failDraft(c, "Failed to retrieve drafts", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      drafts,
+		Message:   "Drafts retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// PublishDraft makes the draft of a content type live. A draft started
+// before the content was last published is refused unless ?force=true.
+func (cc *ContentController) PublishDraft(c *gin.Context) {
+	draft, err := cc.contentService.GetDraft(c.Request.Context(), c.Param("type"))
+	if err != nil {
+		cc.failDraft(c, "Failed to publish draft", err)
+		return
+	}
+
+	// Links to other content must still point at entries that exist
+	referenceErrors, err := cc.contentService.CheckReferences(c.Request.Context(), draft.Type, draft.Data)
+	if err != nil {
+		cc.failDraft(c, "Failed to check content references", err)
+		return
+	}
+	if len(referenceErrors) > 0 {
+		utils.ValidationErrorResponse(c, referenceErrors)
+		return
+	}
+
+	userID := "anonymous"
+	if userIDVal, exists := c.Get("user_id"); exists {
+		userID = userIDVal.(string)
+	}
+
+	force, _ := strconv.ParseBool(c.Query("force"))
+	if err := cc.contentService.PublishDraft(c.Request.Context(), draft, userID, force); err != nil {
+		cc.failDraft(c, "Failed to publish draft", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Message:   "Draft published successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// DiscardDraft deletes the draft of a content type
+func (cc *ContentController) DiscardDraft(c *gin.Context) {
+	if err := cc.contentService.DiscardDraft(c.Request.Context(), c.Param("type")); err != nil {
+		cc.failDraft(c, "Failed to discard draft", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Message:   "Draft discarded successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
 	})
 }
 
@@ -210,7 +449,7 @@ func (cc *ContentController) UpdateContent(c *gin.Context) {
 func (cc *ContentController) GetContentHistory(c *gin.Context) {
 	contentType := c.Param("type")
 	if contentType == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
 			Success:   false,
 			Error:     "Content type is required",
 			Code:      "MISSING_CONTENT_TYPE",
@@ -225,7 +464,7 @@ func (cc *ContentController) GetContentHistory(c *gin.Context) {
 	
 	history, err := cc.contentService.GetContentHistory(c.Request.Context(), contentType, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve content history",
 			Details:   err.Error(),
@@ -235,13 +474,467 @@ func (cc *ContentController) GetContentHistory(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      history,
 		Message:   "Content history retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
+	})
+}
+
+// GetContentDiff returns the fields of a content type's data that changed
+// between the versions in ?from= and ?to=
+func (cc *ContentController) GetContentDiff(c *gin.Context) {
+	contentType := c.Param("type")
+
+	validator := utils.NewValidator()
+	versions := make(map[string]int)
+	for _, field := range []string{"from", "to"} {
+		version, err := strconv.Atoi(c.Query(field))
+		if err != nil || version < 1 {
+			validator.AddError(field, "Must be a version number", "INVALID_VERSION")
+			continue
+		}
+		versions[field] = version
+	}
+	if !validator.IsValid() {
+		utils.ValidationErrorResponse(c, validator.GetErrors())
+		return
+	}
+
+	diff, err := cc.contentService.DiffContentVersions(c.Request.Context(), contentType, versions["from"], versions["to"])
+	if err != nil {
+		status, code := http.StatusInternalServerError, ""
+		if errors.Is(err, services.ErrContentVersionNotFound) {
+			status, code = http.StatusNotFound, "VERSION_NOT_FOUND"
+		}
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to diff content versions",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      diff,
+		Message:   "Content diff retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// parseContentTypes reads the comma-separated content types in ?types=
+func parseContentTypes(c *gin.Context, validator *utils.Validator) []string {
+	var contentTypes []string
+	for _, contentType := range strings.Split(c.Query("types"), ",") {
+		if contentType = strings.TrimSpace(contentType); contentType == "" {
+			continue
+		}
+		validator.OneOf("types", contentType, services.ContentTypes)
+		contentTypes = append(contentTypes, contentType)
+	}
+	return contentTypes
+}
+
+// ExportContent downloads the published content, of the types in ?types= or
+// of all of them, as JSON or, with ?format=yaml, YAML. The file can be sent
+// back as is to POST /admin/content/import.
+func (cc *ContentController) ExportContent(c *gin.Context) {
+	validator := utils.NewValidator()
+	contentTypes := parseContentTypes(c, validator)
+	format := c.DefaultQuery("format", "json")
+	validator.OneOf("format", format, []string{"json", "yaml"})
+	if !validator.IsValid() {
+		utils.ValidationErrorResponse(c, validator.GetErrors())
+		return
+	}
+
+	bundle, err := cc.contentService.ExportContent(c.Request.Context(), contentTypes)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to export content",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	filename := "portfolio-content-" + bundle.ExportedAt.Format("2006-01-02") + "." + format
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	if format == "json" {
+		utils.JSON(c, http.StatusOK, bundle)
+		return
+	}
+
+	// YAML keys follow the JSON field names
+	var document interface{}
+	raw, err := json.Marshal(bundle)
+	if err == nil {
+		err = json.Unmarshal(raw, &document)
+	}
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to export content",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	c.YAML(http.StatusOK, document)
+}
+
+// ExportJSONResume returns the portfolio as a resume in the jsonresume.org
+// schema, served bare so resume renderers can read it as is
+func (cc *ContentController) ExportJSONResume(c *gin.Context) {
+	resume, err := cc.contentService.GetJSONResume(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to export JSON Resume",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, resume)
+}
+
+// ExportResumePDF renders the portfolio as a PDF resume, in the template of
+// ?template= and the language of ?locale=
+func (cc *ContentController) ExportResumePDF(c *gin.Context) {
+	template := c.DefaultQuery("template", services.DefaultResumeTemplate)
+	locale := c.DefaultQuery("locale", services.DefaultResumeLocale)
+
+	validator := utils.NewValidator()
+	validator.OneOf("template", template, services.ResumeTemplates())
+	if !services.ResumeLocaleSupported(locale) {
+		validator.AddError("locale", "Must be in one of: "+strings.Join(services.ResumeLocales(), ", "), "INVALID_CHOICE")
+	}
+	if !validator.IsValid() {
+		utils.ValidationErrorResponse(c, validator.GetErrors())
+		return
+	}
+
+	pdf, err := cc.contentService.RenderResumePDF(c.Request.Context(), template, locale, labeler(c, cc.settingsService))
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to render resume",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="resume-`+template+`-`+strings.ToLower(locale)+`.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// ExportContactVCard returns the meta content as a vCard
+func (cc *ContentController) ExportContactVCard(c *gin.Context) {
+	vcard, err := cc.contentService.GetContactVCard(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to export contact",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="contact.vcf"`)
+	c.Data(http.StatusOK, "text/vcard; charset=utf-8", []byte(vcard))
+}
+
+// ExportTimelineICS returns the experience and education entries as an
+// iCalendar of events
+func (cc *ContentController) ExportTimelineICS(c *gin.Context) {
+	calendar, err := cc.contentService.GetTimelineICS(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to export timeline",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="timeline.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(calendar))
+}
+
+// GetFeed returns the portfolio updates as an RSS feed, or an Atom one with
+// ?format=atom
+func (cc *ContentController) GetFeed(c *gin.Context) {
+	format := c.DefaultQuery("format", services.FeedRSS)
+	validator := utils.NewValidator()
+	validator.OneOf("format", format, []string{services.FeedRSS, services.FeedAtom})
+	if !validator.IsValid() {
+		utils.ValidationErrorResponse(c, validator.GetErrors())
+		return
+	}
+
+	feed, err := cc.contentService.GetFeed(c.Request.Context(), format)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to build feed",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, services.FeedContentTypes[format], feed)
+}
+
+// GetSitemap returns the sitemap of the portfolio, for search engines
+func (cc *ContentController) GetSitemap(c *gin.Context) {
+	sitemap, err := cc.contentService.GetSitemap(c.Request.Context())
+	if errors.Is(err, services.ErrNoPortfolioURL) {
+		utils.JSON(c, http.StatusNotFound, models.ErrorResponse{
+			Success:   false,
+			Error:     "Sitemap not configured",
+			Details:   err.Error(),
+			Code:      "SITEMAP_NOT_CONFIGURED",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to build sitemap",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", sitemap)
+}
+
+// GetRobots returns the robots.txt pointing crawlers at the sitemap
+func (cc *ContentController) GetRobots(c *gin.Context) {
+	host := c.Request.Host
+	if site := services.SiteFromContext(c.Request.Context()); site != nil {
+		host = site.Domain
+	}
+	c.String(http.StatusOK, services.RobotsTxt(middleware.RequestScheme(c)+"://"+host+"/sitemap.xml"))
+}
+
+// GetProjectOGImage returns the social preview card of a project, found by
+// its ID or slug, as a PNG for og:image and twitter:image tags
+func (cc *ContentController) GetProjectOGImage(c *gin.Context) {
+	image, err := cc.contentService.RenderProjectOGImage(c.Request.Context(), c.Param("slug"))
+	if errors.Is(err, services.ErrProjectNotFound) {
+		utils.JSON(c, http.StatusNotFound, models.ErrorResponse{
+			Success:   false,
+			Error:     "Project not found",
+			Details:   err.Error(),
+			Code:      "PROJECT_NOT_FOUND",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to render project image",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", image)
+}
+
+// ImportContent publishes the content of an export, sent as JSON or, with a
+// YAML Content-Type, YAML. Only the types in ?types= are imported when given.
+// Every type is validated before any is published.
+func (cc *ContentController) ImportContent(c *gin.Context) {
+	var bundle models.ContentBundle
+	var err error
+	switch c.ContentType() {
+	case binding.MIMEYAML, binding.MIMEYAML2, "text/yaml":
+		err = c.ShouldBindWith(&bundle, binding.YAML)
+	default:
+		err = c.ShouldBindJSON(&bundle)
+	}
+	if err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	validator := utils.NewValidator()
+	content := bundle.Content
+	if contentTypes := parseContentTypes(c, validator); len(contentTypes) > 0 {
+		content = make(map[string]interface{}, len(contentTypes))
+		for _, contentType := range contentTypes {
+			if data, ok := bundle.Content[contentType]; ok {
+				content[contentType] = data
+			} else {
+				validator.AddError("types", "The import has no "+contentType+" content", "MISSING_CONTENT_TYPE")
+			}
+		}
+	}
+	if validator.IsValid() && len(content) == 0 {
+		validator.At("").AddError("content", "Nothing to import", "EMPTY_IMPORT")
+	}
+	if !validator.IsValid() {
+		utils.ValidationErrorResponse(c, validator.GetErrors())
+		return
+	}
+
+	problems, err := cc.contentService.ValidateImport(c.Request.Context(), content)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to validate import",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	if len(problems) > 0 {
+		utils.ValidationErrorResponse(c, problems)
+		return
+	}
+
+	result, err := cc.contentService.ImportContent(c.Request.Context(), content, c.GetString("user_type"))
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to import content",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      result,
+		Message:   "Content imported successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// BulkUpdate updates every entry of a list content type matching a filter.
+// It must be previewed with X-Dry-Run: true first, and applied with the
+// plan_token of that preview; each changed entry gets its own audit entry.
+func (cc *ContentController) BulkUpdate(c *gin.Context) {
+	var request models.BulkRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if errs := services.ValidateBulkRequest(request); len(errs) > 0 {
+		utils.ValidationErrorResponse(c, errs)
+		return
+	}
+
+	result, problems, err := cc.contentService.BulkUpdate(c.Request.Context(), request, c.GetString("user_type"))
+	switch {
+	case errors.Is(err, services.ErrBulkPlanRequired):
+		utils.JSON(c, http.StatusPreconditionRequired, models.ErrorResponse{
+			Success:   false,
+			Error:     err.Error(),
+			Code:      "DRY_RUN_REQUIRED",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	case errors.Is(err, services.ErrBulkPlanOutdated):
+		utils.JSON(c, http.StatusConflict, models.ErrorResponse{
+			Success:   false,
+			Error:     err.Error(),
+			Code:      "PLAN_OUTDATED",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	case err != nil:
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to apply bulk update",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	case len(problems) > 0:
+		utils.ValidationErrorResponse(c, problems)
+		return
+	}
+
+	if result.Applied {
+		services.RecordBulkAudit(models.AuditEntry{
+			Action:    c.Request.Method + " " + c.FullPath(),
+			Path:      c.Request.URL.Path,
+			Actor:     c.GetString("user_type"),
+			IP:        middleware.ByClientIP(c),
+			RequestID: c.GetString("request_id"),
+			Status:    http.StatusOK,
+			CreatedAt: time.Now(),
+		}, result)
+	}
+
+	message := "Bulk update applied successfully"
+	if !result.Applied {
+		message = "No entry needed changes"
+	}
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      result,
+		Message:   message,
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
 	})
 }
 
@@ -249,7 +942,7 @@ func (cc *ContentController) GetContentHistory(c *gin.Context) {
 func (cc *ContentController) SearchContent(c *gin.Context) {
 	query := c.Query("q")
 	if query == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
 			Success:   false,
 			Error:     "Query parameter 'q' is required",
 			Code:      "MISSING_QUERY",
@@ -267,7 +960,7 @@ func (cc *ContentController) SearchContent(c *gin.Context) {
 
 	results, err := cc.contentService.SearchContent(c.Request.Context(), query, contentTypes)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Search failed",
 			Details:   err.Error(),
@@ -277,12 +970,37 @@ func (cc *ContentController) SearchContent(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      results,
 		Message:   "Search completed successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
-}
\ No newline at end of file
+}
+// parseExpand reads the comma-separated ?expand= flags, answering 400 to
+// unknown ones
+func (cc *ContentController) parseExpand(c *gin.Context) (map[string]bool, bool) {
+	expand := make(map[string]bool)
+	if c.Query("expand") == "" {
+		return expand, true
+	}
+
+	for _, name := range strings.Split(c.Query("expand"), ",") {
+		name = strings.TrimSpace(name)
+		if name != services.ExpandExperience {
+			utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+				Success:   false,
+				Error:     "Invalid expand, expected experience",
+				Details:   name,
+				Code:      "INVALID_EXPAND",
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			return nil, false
+		}
+		expand[name] = true
+	}
+	return expand, true
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contributionGraphTypes are the content types of the graph formats
+var contributionGraphTypes = map[string]string{
+	services.ContributionGraphSVG: "image/svg+xml; charset=utf-8",
+	services.ContributionGraphPNG: "image/png",
+}
+
+// GetContributionGraph serves the contribution calendar of a user as a
+// GitHub-style heatmap, graph.svg or graph.png, with ?theme= and ?year= as
+// in GetContributions
+func (gc *GitHubController) GetContributionGraph(c *gin.Context) {
+	username := c.Param("username")
+	format := strings.TrimPrefix(c.Param("graph"), "graph.")
+	contentType, ok := contributionGraphTypes[format]
+	if !ok {
+		utils.JSON(c, http.StatusNotFound, models.ErrorResponse{
+			Success:   false,
+			Error:     "Graph format not found",
+			Details:   "Available graphs: graph.svg, graph.png",
+			Code:      "GRAPH_NOT_FOUND",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	theme := c.DefaultQuery("theme", services.DefaultContributionGraphTheme)
+	validator := utils.NewValidator()
+	validator.OneOf("theme", theme, services.ContributionGraphThemes())
+	if !validator.IsValid() {
+		utils.ValidationErrorResponse(c, validator.GetErrors())
+		return
+	}
+
+	var year int
+	var err error
+	if param := c.Query("year"); param != "" {
+		if year, err = strconv.Atoi(param); err != nil || year == 0 {
+			err = fmt.Errorf("%w: %s", services.ErrInvalidContributionYear, param)
+		}
+	}
+	var graph []byte
+	if err == nil {
+		graph, err = gc.githubService.RenderContributionGraph(c.Request.Context(), username, year, theme, format)
+	}
+	if errors.Is(err, services.ErrInvalidContributionYear) {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid year",
+			Details:   err.Error(),
+			Code:      "INVALID_YEAR",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to render contribution graph",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	setImageCacheControl(c)
+	c.Data(http.StatusOK, contentType, graph)
+}
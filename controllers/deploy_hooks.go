@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeployHookController struct {
+	deployHookService *services.DeployHookService
+}
+
+func NewDeployHookController() *DeployHookController {
+	return &DeployHookController{
+		deployHookService: services.NewDeployHookService(),
+	}
+}
+
+// GetHooks returns the configured deploy hooks
+func (dc *DeployHookController) GetHooks(c *gin.Context) {
+	hooks, err := dc.deployHookService.GetHooks(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve deploy hooks",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      hooks,
+		Message:   "Deploy hooks retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// UpdateHooks replaces the configured deploy hooks
+func (dc *DeployHookController) UpdateHooks(c *gin.Context) {
+	var request models.DeployHooksUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if err := dc.deployHookService.SetHooks(c.Request.Context(), request.Hooks, c.GetString("user_type")); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to update deploy hooks",
+			Details:   err.Error(),
+			Code:      "INVALID_DEPLOY_HOOKS",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      request.Hooks,
+		Message:   "Deploy hooks updated successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// TriggerHooks fires all enabled hooks immediately, or a single one via ?hook=
+func (dc *DeployHookController) TriggerHooks(c *gin.Context) {
+	runs, err := dc.deployHookService.Trigger(c.Request.Context(), "manual", c.Query("hook"))
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to trigger deploy hooks",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      runs,
+		Message:   "Deploy hooks triggered",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetHistory returns recent deploy hook runs, optionally filtered by ?hook=
+func (dc *DeployHookController) GetHistory(c *gin.Context) {
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	history, err := dc.deployHookService.GetHistory(c.Request.Context(), c.Query("hook"), limit)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve deploy hook history",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      history,
+		Message:   "Deploy hook history retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DigestController struct {
+	digestService *services.DigestService
+}
+
+func NewDigestController() *DigestController {
+	return &DigestController{
+		digestService: services.NewDigestService(),
+	}
+}
+
+// Preview composes the weekly digest without sending it
+func (dc *DigestController) Preview(c *gin.Context) {
+	digest, _, err := dc.digestService.Compose(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to compose weekly digest",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	body, err := dc.digestService.Render(digest)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to render weekly digest",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      gin.H{"digest": digest, "body": body},
+		Message:   "Weekly digest composed successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// Send emails the weekly digest to the owner immediately
+func (dc *DigestController) Send(c *gin.Context) {
+	digest, err := dc.digestService.Send(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusBadGateway, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to send weekly digest",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      digest,
+		Message:   "Weekly digest sent successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
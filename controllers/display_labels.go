@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DisplayLabelsController struct {
+	settingsService *services.SettingsService
+}
+
+func NewDisplayLabelsController() *DisplayLabelsController {
+	return &DisplayLabelsController{
+		settingsService: services.NewSettingsService(),
+	}
+}
+
+// GetLabels returns the display labels of GitHub topics and languages
+func (dc *DisplayLabelsController) GetLabels(c *gin.Context) {
+	labels, err := dc.settingsService.GetDisplayLabels(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve display labels",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      labels,
+		Message:   "Display labels retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// UpdateLabels replaces the display labels
+func (dc *DisplayLabelsController) UpdateLabels(c *gin.Context) {
+	var request models.DisplayLabels
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if err := dc.settingsService.SetDisplayLabels(c.Request.Context(), request, c.GetString("user_type")); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to update display labels",
+			Details:   err.Error(),
+			Code:      "INVALID_LABELS",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      request,
+		Message:   "Display labels updated successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// labeler returns the Labeler of the locale in ?locale=. Names are shown as
+// they are when the labels cannot be read.
+func labeler(c *gin.Context, settingsService *services.SettingsService) services.Labeler {
+	labeler, err := settingsService.GetLabeler(c.Request.Context(), c.Query("locale"))
+	if err != nil {
+		log.Printf("Failed to load display labels: %v", err)
+	}
+	return labeler
+}
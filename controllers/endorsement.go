@@ -0,0 +1,289 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// githubTokenHeader carries the endorser's GitHub access token, kept apart
+// from Authorization, which is reserved for the owner's credentials
+const githubTokenHeader = "X-GitHub-Token"
+
+type EndorsementController struct {
+	endorsementService *services.EndorsementService
+}
+
+func NewEndorsementController() *EndorsementController {
+	return &EndorsementController{
+		endorsementService: services.NewEndorsementService(),
+	}
+}
+
+// endorsementErrorStatus maps endorsement errors to a status and code
+func endorsementErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, services.ErrEndorsementsDisabled):
+		return http.StatusNotImplemented, "ENDORSEMENTS_DISABLED"
+	case errors.Is(err, services.ErrInvalidOAuthCode):
+		return http.StatusBadRequest, "INVALID_OAUTH_CODE"
+	case errors.Is(err, services.ErrInvalidGitHubToken):
+		return http.StatusUnauthorized, "INVALID_GITHUB_TOKEN"
+	case errors.Is(err, services.ErrEndorsementForbidden):
+		return http.StatusForbidden, "ENDORSEMENT_FORBIDDEN"
+	case errors.Is(err, services.ErrSkillNotFound):
+		return http.StatusNotFound, "SKILL_NOT_FOUND"
+	case errors.Is(err, services.ErrEndorsementNotFound):
+		return http.StatusNotFound, "ENDORSEMENT_NOT_FOUND"
+	case errors.Is(err, services.ErrAlreadyEndorsed):
+		return http.StatusConflict, "ALREADY_ENDORSED"
+	case errors.Is(err, services.ErrInvalidEndorsementStatus):
+		return http.StatusBadRequest, "INVALID_STATUS"
+	}
+	return http.StatusInternalServerError, ""
+}
+
+func (ec *EndorsementController) fail(c *gin.Context, message string, err error) {
+	status, code := endorsementErrorStatus(err)
+	utils.JSON(c, status, models.ErrorResponse{
+		Success:   false,
+		Error:     message,
+		Details:   err.Error(),
+		Code:      code,
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+	})
+}
+
+// Authorize returns the GitHub sign-in URL for endorsers
+func (ec *EndorsementController) Authorize(c *gin.Context) {
+	url, state, err := ec.endorsementService.AuthorizeURL(c.Query("redirect_uri"))
+	if err != nil {
+		ec.fail(c, "Failed to start GitHub sign-in", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      gin.H{"url": url, "state": state},
+		Message:   "GitHub sign-in URL created successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// ExchangeCode trades the OAuth code GitHub redirected the endorser back
+// with for the access token endorsements are sent with
+func (ec *EndorsementController) ExchangeCode(c *gin.Context) {
+	var request models.GitHubOAuthRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	session, err := ec.endorsementService.ExchangeCode(c.Request.Context(), request)
+	if err != nil {
+		ec.fail(c, "Failed to sign in with GitHub", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      session,
+		Message:   "Signed in with GitHub successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetEndorsers lists the approved endorsers of a skill
+func (ec *EndorsementController) GetEndorsers(c *gin.Context) {
+	endorsers, err := ec.endorsementService.GetEndorsers(c.Request.Context(), c.Param("skill"))
+	if err != nil {
+		ec.fail(c, "Failed to retrieve endorsements", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      endorsers,
+		Message:   "Endorsements retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// Endorse records an endorsement of a skill by the GitHub account of the
+// X-GitHub-Token header
+func (ec *EndorsementController) Endorse(c *gin.Context) {
+	endorsement, err := ec.endorsementService.Endorse(c.Request.Context(), c.Param("skill"), c.GetHeader(githubTokenHeader))
+	if err != nil {
+		ec.fail(c, "Failed to endorse skill", err)
+		return
+	}
+
+	message := "Skill endorsed successfully"
+	if endorsement.Status == models.EndorsementPending {
+		message = "Endorsement received and awaiting approval"
+	}
+
+	utils.JSON(c, http.StatusCreated, models.APIResponse{
+		Success:   true,
+		Data:      endorsement,
+		Message:   message,
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// Withdraw removes the endorsement of a skill made by the GitHub account of
+// the X-GitHub-Token header
+func (ec *EndorsementController) Withdraw(c *gin.Context) {
+	if err := ec.endorsementService.Withdraw(c.Request.Context(), c.Param("skill"), c.GetHeader(githubTokenHeader)); err != nil {
+		ec.fail(c, "Failed to withdraw endorsement", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Message:   "Endorsement withdrawn successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// ListEndorsements returns endorsements for moderation, optionally filtered
+// by ?status=
+func (ec *EndorsementController) ListEndorsements(c *gin.Context) {
+	endorsements, err := ec.endorsementService.ListEndorsements(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		ec.fail(c, "Failed to list endorsements", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      endorsements,
+		Message:   "Endorsements retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// ModerateEndorsement approves or hides an endorsement
+func (ec *EndorsementController) ModerateEndorsement(c *gin.Context) {
+	var request models.EndorsementStatusRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if err := ec.endorsementService.SetStatus(c.Request.Context(), c.Param("id"), request.Status); err != nil {
+		ec.fail(c, "Failed to moderate endorsement", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      request,
+		Message:   "Endorsement updated successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// DeleteEndorsement removes an endorsement
+func (ec *EndorsementController) DeleteEndorsement(c *gin.Context) {
+	if err := ec.endorsementService.DeleteEndorsement(c.Request.Context(), c.Param("id")); err != nil {
+		ec.fail(c, "Failed to delete endorsement", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Message:   "Endorsement deleted successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetBlocklist returns the GitHub logins that may not endorse skills
+func (ec *EndorsementController) GetBlocklist(c *gin.Context) {
+	blocklist, err := ec.endorsementService.GetBlocklist(c.Request.Context())
+	if err != nil {
+		ec.fail(c, "Failed to retrieve endorsement blocklist", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      blocklist,
+		Message:   "Endorsement blocklist retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// UpdateBlocklist replaces the blocked logins, hiding their endorsements
+func (ec *EndorsementController) UpdateBlocklist(c *gin.Context) {
+	var request models.EndorsementBlocklist
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if err := ec.endorsementService.SetBlocklist(c.Request.Context(), request, c.GetString("user_type")); err != nil {
+		ec.fail(c, "Failed to update endorsement blocklist", err)
+		return
+	}
+
+	blocklist, err := ec.endorsementService.GetBlocklist(c.Request.Context())
+	if err != nil {
+		ec.fail(c, "Failed to retrieve endorsement blocklist", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      blocklist,
+		Message:   "Endorsement blocklist updated successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type ExportController struct {
+	exportService *services.ExportService
+}
+
+func NewExportController() *ExportController {
+	return &ExportController{
+		exportService: services.NewExportService(),
+	}
+}
+
+// ExportCollection streams the documents of a collection as JSON, NDJSON or
+// CSV, gzip-compressed when the client accepts it. Documents come in _id
+// order; an interrupted export is resumed with ?cursor= set to the _id of
+// the last document received.
+func (ec *ExportController) ExportCollection(c *gin.Context) {
+	collection := c.Param("collection")
+
+	format := c.DefaultQuery("format", models.ExportFormatNDJSON)
+	contentType, ok := services.ExportContentTypes[format]
+	if !ok {
+		ec.badRequest(c, "Invalid format, expected json, ndjson or csv", "INVALID_FORMAT")
+		return
+	}
+
+	query := models.ExportQuery{Cursor: c.Query("cursor")}
+	if since := c.Query("since"); since != "" {
+		parsed, err := parseSince(since)
+		if err != nil {
+			ec.badRequest(c, "Invalid since, expected an RFC 3339 time or a YYYY-MM-DD date", "INVALID_SINCE")
+			return
+		}
+		query.Since = parsed
+	}
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.ParseInt(limit, 10, 64)
+		if err != nil || parsed <= 0 {
+			ec.badRequest(c, "Invalid limit", "INVALID_LIMIT")
+			return
+		}
+		query.Limit = parsed
+	}
+
+	var (
+		gz      *gzip.Writer
+		exports services.ExportWriter
+		written int
+	)
+
+	// Headers are only sent once the first document is ready, so failures
+	// before that still get a regular JSON error response
+	start := func() {
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", collection+"."+format))
+		c.Header("Vary", "Accept-Encoding")
+
+		var writer io.Writer = c.Writer
+		if strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") {
+			c.Header("Content-Encoding", "gzip")
+			gz = gzip.NewWriter(c.Writer)
+			writer = gz
+		}
+		c.Status(http.StatusOK)
+		exports, _ = services.NewExportWriter(format, writer)
+	}
+
+	err := ec.exportService.Stream(c.Request.Context(), collection, query, func(doc bson.D) error {
+		if exports == nil {
+			start()
+		}
+		if err := exports.Write(doc); err != nil {
+			return err
+		}
+
+		written++
+		if written%exportFlushEvery == 0 {
+			if gz != nil {
+				gz.Flush()
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+
+	if exports == nil {
+		if err != nil {
+			status, code := http.StatusInternalServerError, ""
+			if errors.Is(err, services.ErrUnknownCollection) {
+				status, code = http.StatusNotFound, "UNKNOWN_COLLECTION"
+			}
+			utils.JSON(c, status, models.ErrorResponse{
+				Success:   false,
+				Error:     "Failed to export collection",
+				Details:   err.Error(),
+				Code:      code,
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			return
+		}
+		start()
+	}
+
+	if err != nil {
+		// The status line is already sent; truncate the stream and log
+		log.Printf("Export of %s aborted after %d documents: %v", collection, written, err)
+	} else if err := exports.Close(); err != nil {
+		log.Printf("Export of %s failed to complete: %v", collection, err)
+	}
+	if gz != nil {
+		gz.Close()
+	}
+}
+
+func (ec *ExportController) badRequest(c *gin.Context, message, code string) {
+	utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+		Success:   false,
+		Error:     message,
+		Code:      code,
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+	})
+}
+
+// parseSince accepts an RFC 3339 time or a date, taken as midnight UTC
+func parseSince(since string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, since); err == nil {
+		return parsed, nil
+	}
+	return time.Parse("2006-01-02", since)
+}
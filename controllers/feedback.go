@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"portfolio-backend/middleware"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedbackController struct {
+	feedbackService *services.FeedbackService
+}
+
+func NewFeedbackController() *FeedbackController {
+	return &FeedbackController{
+		feedbackService: services.NewFeedbackService(),
+	}
+}
+
+// SubmitFeedback records a visitor's emoji rating and optional comment on
+// a project
+func (fc *FeedbackController) SubmitFeedback(c *gin.Context) {
+	var request models.ProjectFeedbackRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	feedback, err := fc.feedbackService.Submit(c.Request.Context(), c.Param("slug"), middleware.ByClientIP(c), request)
+	if err != nil {
+		status, code := http.StatusInternalServerError, ""
+		switch {
+		case errors.Is(err, services.ErrInvalidFeedback):
+			status, code = http.StatusBadRequest, "INVALID_FEEDBACK"
+		case errors.Is(err, services.ErrProjectNotFound):
+			status, code = http.StatusNotFound, "PROJECT_NOT_FOUND"
+		case errors.Is(err, services.ErrFeedbackDisabled):
+			status, code = http.StatusForbidden, "FEEDBACK_DISABLED"
+		}
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to submit feedback",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusCreated, models.APIResponse{
+		Success:   true,
+		Data:      gin.H{"id": feedback.ID, "rating": feedback.Rating},
+		Message:   "Thanks for your feedback",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetSummaries aggregates the feedback per project, optionally for one
+// ?project= and from ?since=
+func (fc *FeedbackController) GetSummaries(c *gin.Context) {
+	var since time.Time
+	if value := c.Query("since"); value != "" {
+		parsed, err := parseSince(value)
+		if err != nil {
+			utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+				Success:   false,
+				Error:     "Invalid since parameter",
+				Details:   "Use an RFC 3339 time or a YYYY-MM-DD date",
+				Code:      "INVALID_SINCE",
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			return
+		}
+		since = parsed
+	}
+
+	summaries, err := fc.feedbackService.GetSummaries(c.Request.Context(), c.Query("project"), since)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to aggregate feedback",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      summaries,
+		Message:   "Project feedback retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// DeleteFeedback removes one piece of feedback
+func (fc *FeedbackController) DeleteFeedback(c *gin.Context) {
+	if err := fc.feedbackService.DeleteFeedback(c.Request.Context(), c.Param("id")); err != nil {
+		status, code := http.StatusInternalServerError, ""
+		if errors.Is(err, services.ErrFeedbackNotFound) {
+			status, code = http.StatusNotFound, "FEEDBACK_NOT_FOUND"
+		}
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to delete feedback",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Message:   "Feedback deleted successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// DeleteVisitorFeedback removes everything sent from the ?ip= of a visitor
+// asking for their data to be erased
+func (fc *FeedbackController) DeleteVisitorFeedback(c *gin.Context) {
+	ip := c.Query("ip")
+	if ip == "" {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "The ip parameter is required",
+			Code:      "MISSING_IP",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	deleted, err := fc.feedbackService.DeleteVisitorFeedback(c.Request.Context(), ip)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to delete feedback",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      gin.H{"deleted": deleted},
+		Message:   "Visitor feedback deleted successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
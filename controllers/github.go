@@ -1,29 +1,56 @@
 package controllers
 
 import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"portfolio-backend/models"
 	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type GitHubController struct {
-	githubService *services.GitHubService
+	githubService   *services.GitHubService
+	provider        services.CodeHostProvider
+	aggregator      *services.CodeHostAggregator
+	settingsService *services.SettingsService
+	jobService      *services.JobService
 }
 
 func NewGitHubController() *GitHubController {
+	githubService := services.NewGitHubService()
 	return &GitHubController{
-		githubService: services.NewGitHubService(),
+		githubService:   githubService,
+		provider:        services.NewCodeHostProvider(),
+		aggregator:      services.NewCodeHostAggregator(githubService),
+		settingsService: services.NewSettingsService(),
+		jobService:      services.NewJobService(),
 	}
 }
 
+// githubErrorStatus answers 503 when GitHub is unavailable and no stored
+// copy could stand in for it
+func githubErrorStatus(err error) (int, string) {
+	if errors.Is(err, services.ErrGitHubUnavailable) {
+		return http.StatusServiceUnavailable, "GITHUB_UNAVAILABLE"
+	}
+	return http.StatusInternalServerError, ""
+}
+
 // GetProfile retrieves GitHub profile information
 func (gc *GitHubController) GetProfile(c *gin.Context) {
 	username := c.Param("username")
 	if username == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
 			Success:   false,
 			Error:     "Username is required",
 			Code:      "MISSING_USERNAME",
@@ -33,25 +60,27 @@ func (gc *GitHubController) GetProfile(c *gin.Context) {
 		return
 	}
 
-	profile, err := gc.githubService.GetProfile(c.Request.Context(), username)
+	profile, err := gc.provider.GetProfile(c.Request.Context(), username)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve GitHub profile",
 			Details:   err.Error(),
+			Code:      code,
 			Timestamp: time.Now(),
 			RequestID: c.GetString("request_id"),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      profile,
 		Message:   "GitHub profile retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
 }
 
@@ -59,7 +88,7 @@ func (gc *GitHubController) GetProfile(c *gin.Context) {
 func (gc *GitHubController) GetRepositories(c *gin.Context) {
 	username := c.Param("username")
 	if username == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
 			Success:   false,
 			Error:     "Username is required",
 			Code:      "MISSING_USERNAME",
@@ -69,33 +98,451 @@ func (gc *GitHubController) GetRepositories(c *gin.Context) {
 		return
 	}
 
-	repos, err := gc.githubService.GetRepositories(c.Request.Context(), username)
+	query, problems := parseRepositoryQuery(c)
+	if len(problems) > 0 {
+		utils.ValidationErrorResponse(c, problems)
+		return
+	}
+
+	repos, err := gc.provider.GetRepositories(c.Request.Context(), username)
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve repositories",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	page, total := services.QueryRepositories(repos, query)
+	labeler(c, gc.settingsService).Repositories(page)
+	utils.PaginatedResponse(c, page, utils.CalculatePagination(query.Page, query.Limit, int64(total)))
+}
+
+// GetStarred retrieves the repositories the user starred, paginated with
+// ?page and ?limit
+func (gc *GitHubController) GetStarred(c *gin.Context) {
+	username := c.Param("username")
+	page, limit, problems := utils.ValidateQueryParams(c.Query("page"), c.Query("limit"))
+	if len(problems) > 0 {
+		utils.ValidationErrorResponse(c, problems)
+		return
+	}
+
+	starred, total, err := gc.githubService.GetStarredRepositories(c.Request.Context(), username, page, limit)
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve starred repositories",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.PaginatedResponse(c, starred, utils.CalculatePagination(page, limit, int64(total)))
+}
+
+// GetGists retrieves the user's public gists, paginated with ?page and
+// ?limit
+func (gc *GitHubController) GetGists(c *gin.Context) {
+	username := c.Param("username")
+	page, limit, problems := utils.ValidateQueryParams(c.Query("page"), c.Query("limit"))
+	if len(problems) > 0 {
+		utils.ValidationErrorResponse(c, problems)
+		return
+	}
+
+	gists, total, err := gc.githubService.GetGists(c.Request.Context(), username, page, limit)
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve gists",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.PaginatedResponse(c, gists, utils.CalculatePagination(page, limit, int64(total)))
+}
+
+// GetRepositoryReadme retrieves a repository's README as sanitized HTML;
+// absolute_images=true points relative images at raw.githubusercontent.com
+func (gc *GitHubController) GetRepositoryReadme(c *gin.Context) {
+	absoluteImages := false
+	if value := c.Query("absolute_images"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.ValidationErrorResponse(c, []utils.ValidationError{{Field: "absolute_images", Message: "Must be true or false", Code: "INVALID_BOOLEAN"}})
+			return
+		}
+		absoluteImages = parsed
+	}
+
+	readme, err := gc.githubService.GetRepositoryReadme(c.Request.Context(), c.Param("username"), c.Param("repo"), absoluteImages)
+	if errors.Is(err, services.ErrReadmeNotFound) {
+		utils.JSON(c, http.StatusNotFound, models.ErrorResponse{
+			Success:   false,
+			Error:     "README not found",
+			Code:      "README_NOT_FOUND",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve README",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      readme,
+		Message:   "README retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetRepositoryReleases retrieves the latest releases of a repository, with
+// their assets and download counts, and its latest tags
+func (gc *GitHubController) GetRepositoryReleases(c *gin.Context) {
+	releases, err := gc.githubService.GetRepositoryReleases(c.Request.Context(), c.Param("username"), c.Param("repo"))
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve releases",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      releases,
+		Message:   "Releases retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetCommitActivity retrieves the last year of weekly commits summed over the
+// user's own repositories
+func (gc *GitHubController) GetCommitActivity(c *gin.Context) {
+	activity, err := gc.githubService.GetCommitActivity(c.Request.Context(), c.Param("username"))
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve commit activity",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      activity,
+		Message:   "Commit activity retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetRepositoryCommitActivity retrieves the last year of weekly commits of a
+// repository
+func (gc *GitHubController) GetRepositoryCommitActivity(c *gin.Context) {
+	activity, err := gc.githubService.GetRepositoryCommitActivity(c.Request.Context(), c.Param("username"), c.Param("repo"))
+	if errors.Is(err, services.ErrStatsPending) {
+		statsPendingResponse(c)
+		return
+	}
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve commit activity",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      activity,
+		Message:   "Commit activity retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetCodeFrequency retrieves the weekly lines added and deleted in a
+// repository
+func (gc *GitHubController) GetCodeFrequency(c *gin.Context) {
+	frequency, err := gc.githubService.GetCodeFrequency(c.Request.Context(), c.Param("username"), c.Param("repo"))
+	if errors.Is(err, services.ErrStatsPending) {
+		statsPendingResponse(c)
+		return
+	}
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve code frequency",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      frequency,
+		Message:   "Code frequency retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// statsPendingResponse answers 202 like GitHub while it is still computing
+// repository statistics, asking the client to come back shortly
+func statsPendingResponse(c *gin.Context) {
+	c.Header("Retry-After", "30")
+	utils.JSON(c, http.StatusAccepted, models.ErrorResponse{
+		Success:   false,
+		Error:     "Statistics are being computed, try again shortly",
+		Code:      "STATS_PENDING",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+	})
+}
+
+// GetTraffic retrieves the stored views and clones of one of the owner's
+// repositories over the last ?days (90 by default, up to 365)
+func (gc *GitHubController) GetTraffic(c *gin.Context) {
+	days := 0
+	if value := c.Query("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 365 {
+			utils.ValidationErrorResponse(c, []utils.ValidationError{{Field: "days", Message: "Days must be between 1 and 365", Code: "INVALID_DAYS"}})
+			return
+		}
+		days = parsed
+	}
+
+	username := gc.settingsService.GetOwner(c.Request.Context())
+	traffic, err := gc.githubService.GetTraffic(c.Request.Context(), username, c.Param("repo"), days)
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve traffic",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      traffic,
+		Message:   "Traffic retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// parseRepositoryQuery reads the page, limit, sort, order, language, topic,
+// fork and archived query parameters of a repository listing
+func parseRepositoryQuery(c *gin.Context) (models.RepositoryQuery, []utils.ValidationError) {
+	page, limit, errs := utils.ValidateQueryParams(c.Query("page"), c.Query("limit"))
+	query := models.RepositoryQuery{
+		Page:     page,
+		Limit:    limit,
+		Sort:     c.Query("sort"),
+		Order:    c.Query("order"),
+		Language: c.Query("language"),
+		Topic:    c.Query("topic"),
+	}
+
+	validator := utils.NewValidator().
+		OneOf("sort", query.Sort, services.RepositorySorts).
+		OneOf("order", query.Order, []string{"asc", "desc"})
+	errs = append(errs, validator.GetErrors()...)
+
+	filters := []struct {
+		field  string
+		target **bool
+	}{{"fork", &query.Fork}, {"archived", &query.Archived}}
+	for _, filter := range filters {
+		if value := c.Query(filter.field); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				errs = append(errs, utils.ValidationError{Field: filter.field, Message: "Must be true or false", Code: "INVALID_BOOLEAN"})
+				continue
+			}
+			*filter.target = &parsed
+		}
+	}
+
+	return query, errs
+}
+
+// GetPortfolioRepositories retrieves the merged repositories of the portfolio
+// owner and any extra accounts
+func (gc *GitHubController) GetPortfolioRepositories(c *gin.Context) {
+	accounts := gc.settingsService.GetAccounts(c.Request.Context())
+
+	portfolio, err := gc.githubService.GetPortfolioRepositories(c.Request.Context(), accounts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve repositories",
 			Details:   err.Error(),
+			Code:      code,
 			Timestamp: time.Now(),
 			RequestID: c.GetString("request_id"),
 		})
 		return
 	}
+	labeler(c, gc.settingsService).Repositories(portfolio.Repositories)
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
-		Data:      repos,
+		Data:      portfolio,
 		Message:   "Repositories retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
+	})
+}
+
+// exportFlushEvery is how many repositories are written between flushes
+const exportFlushEvery = 100
+
+// ExportRepositories streams the user's repositories as NDJSON, one repository
+// per line, gzip-compressed when the client accepts it
+func (gc *GitHubController) ExportRepositories(c *gin.Context) {
+	username := c.Param("username")
+	if username == "" {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Username is required",
+			Code:      "MISSING_USERNAME",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	var (
+		writer  io.Writer
+		gz      *gzip.Writer
+		encoder *json.Encoder
+		written int
+	)
+
+	// Headers are only sent once the first repository is ready, so failures
+	// before that still get a regular JSON error response
+	start := func() {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", username+"-repositories.ndjson"))
+		c.Header("Vary", "Accept-Encoding")
+
+		writer = c.Writer
+		if strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") {
+			c.Header("Content-Encoding", "gzip")
+			gz = gzip.NewWriter(c.Writer)
+			writer = gz
+		}
+		c.Status(http.StatusOK)
+		encoder = json.NewEncoder(writer)
+	}
+
+	err := gc.githubService.StreamRepositories(c.Request.Context(), username, func(repo *models.GitHubRepository) error {
+		if encoder == nil {
+			start()
+		}
+		if err := encoder.Encode(repo); err != nil {
+			return err
+		}
+
+		written++
+		if written%exportFlushEvery == 0 {
+			if gz != nil {
+				gz.Flush()
+			}
+			c.Writer.Flush()
+		}
+		return nil
 	})
+
+	if encoder == nil {
+		if err != nil {
+			utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+				Success:   false,
+				Error:     "Failed to export repositories",
+				Details:   err.Error(),
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			return
+		}
+		start()
+	}
+
+	if err != nil {
+		// The status line is already sent; truncate the stream and log
+		log.Printf("Repository export for %s aborted after %d repositories: %v", username, written, err)
+	}
+	if gz != nil {
+		gz.Close()
+	}
 }
 
 // GetContributions retrieves contribution data
 func (gc *GitHubController) GetContributions(c *gin.Context) {
 	username := c.Param("username")
 	if username == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
 			Success:   false,
 			Error:     "Username is required",
 			Code:      "MISSING_USERNAME",
@@ -105,25 +552,88 @@ func (gc *GitHubController) GetContributions(c *gin.Context) {
 		return
 	}
 
-	contributions, err := gc.githubService.GetContributions(c.Request.Context(), username)
+	// ?year= returns the calendar of a single year instead of the last one
+	var contributions *models.GitHubContributions
+	var err error
+	if param := c.Query("year"); param != "" {
+		if gc.provider.Name() != services.CodeHostGitHub {
+			utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+				Success:   false,
+				Error:     "Calendars of past years are only available from GitHub",
+				Code:      "YEAR_UNSUPPORTED",
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			return
+		}
+		if year, convErr := strconv.Atoi(param); convErr != nil {
+			err = fmt.Errorf("%w: %s", services.ErrInvalidContributionYear, param)
+		} else {
+			contributions, err = gc.githubService.GetContributionsForYear(c.Request.Context(), username, year)
+		}
+	} else {
+		contributions, err = gc.provider.GetContributions(c.Request.Context(), username)
+	}
+	if errors.Is(err, services.ErrInvalidContributionYear) {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid year",
+			Details:   err.Error(),
+			Code:      "INVALID_YEAR",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve contributions",
 			Details:   err.Error(),
+			Code:      code,
 			Timestamp: time.Now(),
 			RequestID: c.GetString("request_id"),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      contributions,
 		Message:   "Contributions retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
+	})
+}
+
+// GetExternalContributions retrieves the merged pull requests the portfolio
+// owner authored in repositories outside the portfolio accounts
+func (gc *GitHubController) GetExternalContributions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	contributions, err := gc.githubService.GetExternalContributions(ctx, gc.settingsService.GetOwner(ctx), gc.settingsService.GetAccounts(ctx))
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve external contributions",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      contributions,
+		Message:   "External contributions retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
 	})
 }
 
@@ -131,7 +641,7 @@ func (gc *GitHubController) GetContributions(c *gin.Context) {
 func (gc *GitHubController) GetStats(c *gin.Context) {
 	username := c.Param("username")
 	if username == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
 			Success:   false,
 			Error:     "Username is required",
 			Code:      "MISSING_USERNAME",
@@ -141,42 +651,122 @@ func (gc *GitHubController) GetStats(c *gin.Context) {
 		return
 	}
 
-	stats, err := gc.githubService.GetStats(c.Request.Context(), username)
+	stats, err := gc.provider.GetStats(c.Request.Context(), username)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to retrieve GitHub statistics",
 			Details:   err.Error(),
+			Code:      code,
 			Timestamp: time.Now(),
 			RequestID: c.GetString("request_id"),
 		})
 		return
 	}
+	stats.MostUsedLanguages = labeler(c, gc.settingsService).Languages(stats.MostUsedLanguages)
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      stats,
 		Message:   "GitHub statistics retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
 }
 
-// SyncData forces a refresh of GitHub data
-func (gc *GitHubController) SyncData(c *gin.Context) {
-	username := c.Param("username")
-	if username == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+// GetPortfolioStats retrieves stats aggregated over the portfolio owner and
+// any extra accounts
+func (gc *GitHubController) GetPortfolioStats(c *gin.Context) {
+	accounts := gc.settingsService.GetAccounts(c.Request.Context())
+
+	stats, err := gc.githubService.GetPortfolioStats(c.Request.Context(), accounts)
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
 			Success:   false,
-			Error:     "Username is required",
-			Code:      "MISSING_USERNAME",
+			Error:     "Failed to retrieve stats",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	stats.MostUsedLanguages = labeler(c, gc.settingsService).Languages(stats.MostUsedLanguages)
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      stats,
+		Message:   "Stats retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetAggregateStats aggregates the stats of the portfolio accounts and of
+// the accounts on other hosts listed in CODE_HOST_ACCOUNTS
+func (gc *GitHubController) GetAggregateStats(c *gin.Context) {
+	accounts := services.CodeHostAccounts(gc.settingsService.GetAccounts(c.Request.Context()))
+
+	stats, err := gc.aggregator.GetAggregateStats(c.Request.Context(), accounts)
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve aggregate stats",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	stats.MostUsedLanguages = labeler(c, gc.settingsService).Languages(stats.MostUsedLanguages)
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      stats,
+		Message:   "Aggregate stats retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetPublicStats serves the owner's stats as bare JSON in the schema of
+// github-readme-stats, for third-party README card generators
+func (gc *GitHubController) GetPublicStats(c *gin.Context) {
+	username := gc.settingsService.GetOwner(c.Request.Context())
+
+	stats, err := gc.githubService.GetPublicStats(c.Request.Context(), username)
+	if err != nil {
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve public statistics",
+			Details:   err.Error(),
+			Code:      code,
 			Timestamp: time.Now(),
 			RequestID: c.GetString("request_id"),
 		})
 		return
 	}
 
+	utils.JSON(c, http.StatusOK, stats)
+}
+
+// SyncData refreshes GitHub data of the given user, or of the portfolio owner
+// when none is given; force=true also refetches unchanged languages.
+// async=true queues the sync as a job and answers 202 right away.
+func (gc *GitHubController) SyncData(c *gin.Context) {
+	username := c.Param("username")
+	if username == "" {
+		username = gc.settingsService.GetOwner(c.Request.Context())
+	}
+
 	// Optional force parameter
 	var request models.GitHubSyncRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -187,9 +777,35 @@ func (gc *GitHubController) SyncData(c *gin.Context) {
 		}
 	}
 
-	err := gc.githubService.SyncData(c.Request.Context(), username)
+	// Allow ?force=true for callers that don't send a body
+	if c.Query("force") == "true" {
+		request.Force = true
+	}
+
+	if c.Query("async") == "true" {
+		job, err := gc.jobService.Enqueue(c.Request.Context(), models.JobRequest{
+			Type:   models.JobGitHubSync,
+			Params: map[string]string{"username": username, "force": strconv.FormatBool(request.Force)},
+		}, c.GetString("user_type"))
+		if err != nil {
+			status, code := jobErrorStatus(err)
+			utils.JSON(c, status, models.ErrorResponse{
+				Success:   false,
+				Error:     "Failed to queue GitHub sync",
+				Details:   err.Error(),
+				Code:      code,
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			return
+		}
+		jobAccepted(c, job, "GitHub sync queued")
+		return
+	}
+
+	status, err := gc.githubService.SyncData(c.Request.Context(), username, request.Force)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to sync GitHub data",
 			Details:   err.Error(),
@@ -199,12 +815,43 @@ func (gc *GitHubController) SyncData(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
+		Data:      status,
 		Message:   "GitHub data synchronized successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
+	})
+}
+
+// GetSyncStatus returns the last sync and the next scheduled one
+func (gc *GitHubController) GetSyncStatus(c *gin.Context) {
+	overview := models.SyncOverview{Schedule: services.GetSyncSchedule()}
+
+	var lastSync models.SyncStatus
+	err := gc.settingsService.Get(c.Request.Context(), services.SettingLastSync, &lastSync)
+	if err != nil && err != services.ErrSettingNotFound {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve sync status",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	if err == nil {
+		overview.LastSync = &lastSync
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      overview,
+		Message:   "Sync status retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
 	})
 }
 
@@ -212,22 +859,37 @@ func (gc *GitHubController) SyncData(c *gin.Context) {
 func (gc *GitHubController) GetRateLimit(c *gin.Context) {
 	rateLimit, err := gc.githubService.CheckRateLimit(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		status, code := githubErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
 			Success:   false,
 			Error:     "Failed to check rate limit",
 			Details:   err.Error(),
+			Code:      code,
 			Timestamp: time.Now(),
 			RequestID: c.GetString("request_id"),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      rateLimit,
 		Message:   "Rate limit status retrieved successfully",
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
-}
\ No newline at end of file
+}
+
+// GetTokenQuotas returns the rate limit GitHub last reported for each pooled
+// token, identified by its last characters
+func (gc *GitHubController) GetTokenQuotas(c *gin.Context) {
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      gc.githubService.GetTokenQuotas(),
+		Message:   "GitHub token quotas retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
@@ -2,9 +2,13 @@ package controllers
 
 import (
 	"net/http"
+	"portfolio-backend/config"
 	"portfolio-backend/database"
 	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
 	"runtime"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +16,33 @@ import (
 
 type HealthController struct{}
 
+// appReady is flipped once startup has connected all dependencies and the
+// full router is serving traffic
+var appReady atomic.Bool
+
+// databaseHealthy pings the database, swapped out by tests
+var databaseHealthy = database.IsHealthy
+
+// SetReady marks the application as ready (or not) to receive traffic
+func SetReady(ready bool) {
+	appReady.Store(ready)
+}
+
+// cacheWarmed and firstSynced gate readiness when READINESS_WAIT_WARM and
+// READINESS_WAIT_SYNC are set
+var cacheWarmed, firstSynced atomic.Bool
+
+// SetCacheWarmed records that the startup content cache warm finished
+func SetCacheWarmed() {
+	cacheWarmed.Store(true)
+}
+
+// SetFirstSynced records that the owner's GitHub data has been synced since
+// startup, or already was
+func SetFirstSynced() {
+	firstSynced.Store(true)
+}
+
 func NewHealthController() *HealthController {
 	return &HealthController{}
 }
@@ -27,7 +58,7 @@ func (hc *HealthController) Health(c *gin.Context) {
 	}
 
 	dbStart := time.Now()
-	if !database.IsHealthy() {
+	if !databaseHealthy() {
 		dbHealth.Status = "unhealthy"
 		dbHealth.Error = "Database connection failed"
 	}
@@ -53,7 +84,7 @@ func (hc *HealthController) Health(c *gin.Context) {
 		Status:    status,
 		Timestamp: time.Now(),
 		Uptime:    time.Since(start).String(),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 		Database:  dbHealth,
 		GitHub: models.HealthCheckStatus{
 			Status:       "healthy",
@@ -73,14 +104,14 @@ func (hc *HealthController) Health(c *gin.Context) {
 		statusCode = http.StatusServiceUnavailable
 	}
 
-	c.JSON(statusCode, response)
+	utils.JSON(c, statusCode, response)
 }
 
 // Info returns information about the API
 func (hc *HealthController) Info(c *gin.Context) {
 	response := models.APIInfoResponse{
 		Name:        "Portfolio Backend API",
-		Version:     "1.0.0",
+		Version:     models.APIVersion,
 		Description: "Backend API for portfolio website with GitHub integration",
 		Uptime:      "0s", // This would be calculated from app start time
 		Timestamp:   time.Now(),
@@ -94,6 +125,7 @@ func (hc *HealthController) Info(c *gin.Context) {
 			"github_stats":         "/api/v1/github/stats/{username}",
 			"analytics":            "/api/v1/analytics/summary",
 		},
+		Features: services.GetFeatures(),
 		Contact: models.ContactInfo{
 			Name:  "Felipe Macedo",
 			Email: "contact@example.com",
@@ -102,45 +134,57 @@ func (hc *HealthController) Info(c *gin.Context) {
 		License: "MIT",
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
+	utils.JSON(c, http.StatusOK, models.APIResponse{
 		Success:   true,
 		Data:      response,
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	})
 }
 
 // Readiness endpoint for Kubernetes readiness probes
 func (hc *HealthController) Readiness(c *gin.Context) {
-	// Check if application is ready to serve traffic
-	ready := true
+	// Check if application has finished starting up
+	ready := appReady.Load()
 	
 	// Check database connection
-	if !database.IsHealthy() {
+	if ready && !databaseHealthy() {
 		ready = false
 	}
 
-	// Add other readiness checks here (cache, external services, etc.)
+	// A cold instance would serve slow or empty responses
+	checks := map[string]bool{}
+	if config.AppConfig.ReadinessWaitWarm || config.AppConfig.ReadinessWaitSync {
+		checks["cache_warm"] = cacheWarmed.Load()
+		ready = ready && checks["cache_warm"]
+	}
+	if config.AppConfig.ReadinessWaitSync {
+		checks["first_sync"] = firstSynced.Load()
+		ready = ready && checks["first_sync"]
+	}
 
 	response := map[string]interface{}{
 		"ready":      ready,
 		"timestamp":  time.Now(),
 		"request_id": c.GetString("request_id"),
 	}
+	if len(checks) > 0 {
+		response["checks"] = checks
+	}
 
 	statusCode := http.StatusOK
 	if !ready {
 		statusCode = http.StatusServiceUnavailable
 	}
 
-	c.JSON(statusCode, response)
+	utils.JSON(c, statusCode, response)
 }
 
 // Liveness endpoint for Kubernetes liveness probes
 func (hc *HealthController) Liveness(c *gin.Context) {
 	// Simple liveness check - if this endpoint responds, the app is alive
-	c.JSON(http.StatusOK, map[string]interface{}{
+	utils.JSON(c, http.StatusOK, map[string]interface{}{
 		"alive":      true,
 		"timestamp":  time.Now(),
 		"request_id": c.GetString("request_id"),
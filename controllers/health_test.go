@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadinessWaitsForWarmUp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	previousConfig, previousHealthy := config.AppConfig, databaseHealthy
+	previousReady, previousWarmed, previousSynced := appReady.Load(), cacheWarmed.Load(), firstSynced.Load()
+	t.Cleanup(func() {
+		config.AppConfig, databaseHealthy = previousConfig, previousHealthy
+		appReady.Store(previousReady)
+		cacheWarmed.Store(previousWarmed)
+		firstSynced.Store(previousSynced)
+	})
+
+	config.AppConfig = &config.Config{ReadinessWaitSync: true}
+	databaseHealthy = func() bool { return true }
+	appReady.Store(true)
+	cacheWarmed.Store(false)
+	firstSynced.Store(false)
+
+	router := gin.New()
+	router.GET("/readiness", NewHealthController().Readiness)
+
+	readiness := func() (int, map[string]bool) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", "/readiness", nil))
+
+		var body struct {
+			Checks map[string]bool `json:"checks"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		return rr.Code, body.Checks
+	}
+
+	code, checks := readiness()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, map[string]bool{"cache_warm": false, "first_sync": false}, checks)
+
+	SetCacheWarmed()
+	code, checks = readiness()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, map[string]bool{"cache_warm": true, "first_sync": false}, checks)
+
+	SetFirstSynced()
+	code, checks = readiness()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]bool{"cache_warm": true, "first_sync": true}, checks)
+}
@@ -0,0 +1,181 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobController struct {
+	jobService *services.JobService
+}
+
+func NewJobController() *JobController {
+	return &JobController{
+		jobService: services.NewJobService(),
+	}
+}
+
+// jobErrorStatus maps job errors to a status and code
+func jobErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, services.ErrUnknownJobType):
+		return http.StatusBadRequest, "UNKNOWN_JOB_TYPE"
+	case errors.Is(err, services.ErrInvalidJobParams):
+		return http.StatusBadRequest, "INVALID_JOB_PARAMS"
+	case errors.Is(err, services.ErrJobNotFound):
+		return http.StatusNotFound, "JOB_NOT_FOUND"
+	case errors.Is(err, services.ErrJobConflict):
+		return http.StatusConflict, "JOB_CONFLICT"
+	case errors.Is(err, services.ErrJobNoExport):
+		return http.StatusNotFound, "NO_EXPORT"
+	}
+	return http.StatusInternalServerError, ""
+}
+
+func (jc *JobController) fail(c *gin.Context, message string, err error) {
+	status, code := jobErrorStatus(err)
+	utils.JSON(c, status, models.ErrorResponse{
+		Success:   false,
+		Error:     message,
+		Details:   err.Error(),
+		Code:      code,
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+	})
+}
+
+// jobAccepted answers 202 with the queued job and where to follow it
+func jobAccepted(c *gin.Context, job *models.Job, message string) {
+	c.Header("Location", fmt.Sprintf("/api/v1/jobs/%s", job.ID.Hex()))
+	utils.JSON(c, http.StatusAccepted, models.APIResponse{
+		Success:   true,
+		Data:      job,
+		Message:   message,
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// CreateJob queues a github_sync, cache_warm, analytics_aggregate, export or
+// commit_insights job
+func (jc *JobController) CreateJob(c *gin.Context) {
+	var request models.JobRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	job, err := jc.jobService.Enqueue(c.Request.Context(), request, c.GetString("user_type"))
+	if err != nil {
+		jc.fail(c, "Failed to queue job", err)
+		return
+	}
+
+	jobAccepted(c, job, "Job queued successfully")
+}
+
+// ListJobs returns the newest jobs, optionally filtered by ?status= and ?type=
+func (jc *JobController) ListJobs(c *gin.Context) {
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	jobs, err := jc.jobService.ListJobs(c.Request.Context(), c.Query("status"), c.Query("type"), limit)
+	if err != nil {
+		jc.fail(c, "Failed to list jobs", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      jobs,
+		Message:   "Jobs retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetJob returns the status of a job, with its result once it succeeded
+func (jc *JobController) GetJob(c *gin.Context) {
+	job, err := jc.jobService.GetJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		jc.fail(c, "Failed to retrieve job", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      job,
+		Message:   "Job retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// RetryJob queues a dead-lettered or canceled job again
+func (jc *JobController) RetryJob(c *gin.Context) {
+	job, err := jc.jobService.RetryJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		jc.fail(c, "Failed to retry job", err)
+		return
+	}
+
+	jobAccepted(c, job, "Job queued for retry")
+}
+
+// CancelJob cancels a job that has not started yet
+func (jc *JobController) CancelJob(c *gin.Context) {
+	job, err := jc.jobService.CancelJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		jc.fail(c, "Failed to cancel job", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      job,
+		Message:   "Job canceled successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// DownloadExport sends the file written by a finished export job
+func (jc *JobController) DownloadExport(c *gin.Context) {
+	download, filename, contentType, err := jc.jobService.OpenExport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		jc.fail(c, "Failed to download export", err)
+		return
+	}
+	defer download.Close()
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Content-Length", strconv.FormatInt(download.GetFile().Length, 10))
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, download); err != nil {
+		log.Printf("Download of export %s aborted: %v", c.Param("id"), err)
+	}
+}
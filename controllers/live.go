@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"portfolio-backend/middleware"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// liveHeartbeatInterval keeps idle connections open through proxies and
+	// detects clients that went away
+	liveHeartbeatInterval = 30 * time.Second
+	// liveWriteTimeout bounds how long a message may take to reach a client
+	liveWriteTimeout = 10 * time.Second
+	// liveMaxMessageBytes caps the messages clients send
+	liveMaxMessageBytes = 4096
+)
+
+type LiveController struct{}
+
+func NewLiveController() *LiveController {
+	return &LiveController{}
+}
+
+// liveErrorStatus maps live updates errors to a status and code
+func liveErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, services.ErrUnknownLiveTopic):
+		return http.StatusBadRequest, "UNKNOWN_TOPIC"
+	case errors.Is(err, services.ErrLiveConnectionLimit):
+		return http.StatusServiceUnavailable, "TOO_MANY_CONNECTIONS"
+	}
+	return http.StatusInternalServerError, ""
+}
+
+// Connect upgrades the request to a WebSocket that pushes content, github
+// and analytics events, for the topics in ?topics= (all by default)
+func (lc *LiveController) Connect(c *gin.Context) {
+	var topics []string
+	if value := c.Query("topics"); value != "" {
+		for _, topic := range strings.Split(value, ",") {
+			topics = append(topics, strings.TrimSpace(topic))
+		}
+	}
+
+	sub, err := services.SubscribeLive(topics)
+	if err != nil {
+		status, code := liveErrorStatus(err)
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to open live updates",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	defer sub.Close()
+
+	server := websocket.Server{
+		// Browsers may only connect from the origins CORS allows
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			if origin := req.Header.Get("Origin"); origin != "" && !middleware.OriginAllowed(c, origin) {
+				return fmt.Errorf("origin %s not allowed", origin)
+			}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			lc.serve(ws, sub)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// serve writes events and replies to the connection until either side
+// closes it. Replies come from the reader, so only this loop writes.
+func (lc *LiveController) serve(ws *websocket.Conn, sub *services.LiveSubscription) {
+	defer ws.Close()
+	ws.MaxPayloadBytes = liveMaxMessageBytes
+	// Clear the server's read timeout; idle clients are fine, and those
+	// that went away fail the next heartbeat
+	ws.SetReadDeadline(time.Time{})
+
+	replies := make(chan models.LiveMessage, 8)
+	done := make(chan struct{})
+	quit := make(chan struct{})
+	defer close(quit)
+	go func() {
+		defer close(done)
+		lc.read(ws, sub, replies, quit)
+	}()
+
+	heartbeat := time.NewTicker(liveHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	if sendLive(ws, models.LiveMessage{Type: models.LiveSubscribed, Topics: sub.Topics(), Timestamp: time.Now()}) != nil {
+		return
+	}
+	for {
+		var message models.LiveMessage
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			message = event
+		case message = <-replies:
+		case <-heartbeat.C:
+			message = models.LiveMessage{Type: models.LiveHeartbeat, Timestamp: time.Now()}
+		case <-done:
+			// Flush what the reader answered before it stopped
+			for {
+				select {
+				case message := <-replies:
+					sendLive(ws, message)
+				default:
+					return
+				}
+			}
+		}
+
+		if sendLive(ws, message) != nil {
+			return
+		}
+	}
+}
+
+// read handles the messages of the client until it disconnects or goes over
+// its rate limit
+func (lc *LiveController) read(ws *websocket.Conn, sub *services.LiveSubscription, replies chan<- models.LiveMessage, quit <-chan struct{}) {
+	reply := func(message models.LiveMessage) {
+		message.Timestamp = time.Now()
+		select {
+		case replies <- message:
+		case <-quit:
+		}
+	}
+
+	for {
+		var raw []byte
+		if err := websocket.Message.Receive(ws, &raw); err != nil {
+			return
+		}
+
+		if !sub.Allow() {
+			reply(models.LiveMessage{Type: models.LiveError, Error: "Too many messages", Code: "RATE_LIMIT_EXCEEDED"})
+			return
+		}
+
+		var request models.LiveMessage
+		if err := json.Unmarshal(raw, &request); err != nil {
+			reply(models.LiveMessage{Type: models.LiveError, Error: "Messages must be JSON", Code: "INVALID_MESSAGE"})
+			continue
+		}
+
+		var err error
+		switch request.Type {
+		case models.LiveSubscribe:
+			err = sub.Subscribe(request.Topics)
+		case models.LiveUnsubscribe:
+			err = sub.Unsubscribe(request.Topics)
+		case models.LivePing:
+			reply(models.LiveMessage{Type: models.LivePong})
+			continue
+		default:
+			reply(models.LiveMessage{Type: models.LiveError, Error: fmt.Sprintf("Unknown message type %q", request.Type), Code: "INVALID_MESSAGE"})
+			continue
+		}
+
+		if err != nil {
+			_, code := liveErrorStatus(err)
+			reply(models.LiveMessage{Type: models.LiveError, Error: err.Error(), Code: code})
+			continue
+		}
+		reply(models.LiveMessage{Type: models.LiveSubscribed, Topics: sub.Topics()})
+	}
+}
+
+func sendLive(ws *websocket.Conn, message models.LiveMessage) error {
+	ws.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+	return websocket.JSON.Send(ws, message)
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"net/http"
+	"portfolio-backend/middleware"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OwnerController struct {
+	settingsService *services.SettingsService
+	cacheService    *services.CacheService
+}
+
+func NewOwnerController() *OwnerController {
+	return &OwnerController{
+		settingsService: services.NewSettingsService(),
+		cacheService:    services.NewCacheService(),
+	}
+}
+
+// GetOwner returns the GitHub account the portfolio showcases and the extra
+// accounts merged into it, on domains not mapped to another owner
+func (oc *OwnerController) GetOwner(c *gin.Context) {
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      oc.settingsService.GetOwnerSettings(services.WithSite(c.Request.Context(), nil)),
+		Message:   "Portfolio owner retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// UpdateOwner switches the showcased GitHub account without a redeploy
+func (oc *OwnerController) UpdateOwner(c *gin.Context) {
+	var request models.OwnerSettings
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	// The default owner, whichever domain the admin API is called through
+	ctx := services.WithSite(c.Request.Context(), nil)
+	previous := oc.settingsService.GetOwner(ctx)
+
+	if err := oc.settingsService.SetOwner(ctx, request, c.GetString("user_type")); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to update portfolio owner",
+			Details:   err.Error(),
+			Code:      "INVALID_OWNER",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	// Data cached for the new owner may predate the switch by a long time
+	if previous != request.GitHubUsername && services.IsDryRun(ctx) {
+		services.AddDryRunEffects(ctx,
+			[]string{"github:" + previous + ":.*", "github:" + request.GitHubUsername + ":.*"},
+			[]string{"enrichment of " + request.GitHubUsername})
+	} else if previous != request.GitHubUsername {
+		oc.cacheService.InvalidateGitHubCache(ctx, previous)
+		oc.cacheService.InvalidateGitHubCache(ctx, request.GitHubUsername)
+		services.EnqueueEnrichment(request.GitHubUsername, true)
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      request,
+		Message:   "Portfolio owner updated successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetDomains returns the domains served with another owner's portfolio
+func (oc *OwnerController) GetDomains(c *gin.Context) {
+	domains, err := oc.settingsService.GetDomains(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve domains",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      domains,
+		Message:   "Domains retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// UpdateDomains replaces the domain to owner mapping and applies it without
+// a restart
+func (oc *OwnerController) UpdateDomains(c *gin.Context) {
+	var request models.SiteDomainsUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+	if request.Domains == nil {
+		request.Domains = []models.SiteDomain{}
+	}
+
+	if err := oc.settingsService.SetDomains(c.Request.Context(), request.Domains, c.GetString("user_type")); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to update domains",
+			Details:   err.Error(),
+			Code:      "INVALID_DOMAINS",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if !services.IsDryRun(c.Request.Context()) {
+		middleware.ApplySiteDomains(request.Domains)
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      request.Domains,
+		Message:   "Domains updated successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
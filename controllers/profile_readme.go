@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProfileReadmeController struct {
+	readmeService *services.ReadmeService
+}
+
+func NewProfileReadmeController() *ProfileReadmeController {
+	return &ProfileReadmeController{
+		readmeService: services.NewReadmeService(),
+	}
+}
+
+// Preview renders the GitHub profile README without publishing it
+func (pc *ProfileReadmeController) Preview(c *gin.Context) {
+	markdown, err := pc.readmeService.Render(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to render profile README",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      gin.H{"markdown": markdown},
+		Message:   "Profile README rendered successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// Publish renders the profile README and commits it to GitHub
+func (pc *ProfileReadmeController) Publish(c *gin.Context) {
+	result, err := pc.readmeService.Publish(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusBadGateway, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to publish profile README",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	message := "Profile README is already up to date"
+	if result.Changed {
+		message = "Profile README published successfully"
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      result,
+		Message:   message,
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
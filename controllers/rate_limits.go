@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"net/http"
+	"portfolio-backend/middleware"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RateLimitController struct {
+	settingsService *services.SettingsService
+}
+
+func NewRateLimitController() *RateLimitController {
+	return &RateLimitController{
+		settingsService: services.NewSettingsService(),
+	}
+}
+
+// GetTiers returns the anonymous and authenticated rate limit tiers
+func (rc *RateLimitController) GetTiers(c *gin.Context) {
+	tiers, err := rc.settingsService.GetRateLimitTiers(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve rate limit tiers",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      tiers,
+		Message:   "Rate limit tiers retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// UpdateTiers stores new rate limit tiers and applies them without a restart
+func (rc *RateLimitController) UpdateTiers(c *gin.Context) {
+	var request models.RateLimitTiers
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if err := rc.settingsService.SetRateLimitTiers(c.Request.Context(), request, c.GetString("user_type")); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to update rate limit tiers",
+			Details:   err.Error(),
+			Code:      "INVALID_RATE_LIMIT_TIERS",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if !services.IsDryRun(c.Request.Context()) {
+		middleware.ApplyRateLimitTiers(&request)
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      request,
+		Message:   "Rate limit tiers updated successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
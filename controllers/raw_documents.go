@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RawDocumentController struct {
+	rawDocumentService *services.RawDocumentService
+}
+
+func NewRawDocumentController() *RawDocumentController {
+	return &RawDocumentController{
+		rawDocumentService: services.NewRawDocumentService(),
+	}
+}
+
+// GetDocument returns a stored document as relaxed extended JSON
+func (rc *RawDocumentController) GetDocument(c *gin.Context) {
+	doc, err := rc.rawDocumentService.Get(c.Request.Context(), c.Param("collection"), c.Param("id"))
+	if err != nil {
+		rc.respondError(c, "Failed to retrieve document", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      json.RawMessage(doc),
+		Message:   "Document retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// ReplaceDocument validates and replaces a stored document. The body is the
+// whole document in extended JSON, as returned by GetDocument.
+func (rc *RawDocumentController) ReplaceDocument(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	doc, err := rc.rawDocumentService.Replace(c.Request.Context(), c.Param("collection"), c.Param("id"), body, c.GetString("user_type"))
+	if err != nil {
+		rc.respondError(c, "Failed to replace document", err)
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      json.RawMessage(doc),
+		Message:   "Document replaced successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+func (rc *RawDocumentController) respondError(c *gin.Context, message string, err error) {
+	status, code := http.StatusInternalServerError, ""
+	switch {
+	case errors.Is(err, services.ErrUnknownCollection):
+		status, code = http.StatusNotFound, "UNKNOWN_COLLECTION"
+	case errors.Is(err, mongo.ErrNoDocuments):
+		status, code = http.StatusNotFound, "DOCUMENT_NOT_FOUND"
+	case errors.Is(err, services.ErrReadOnlyCollection):
+		status, code = http.StatusForbidden, "READ_ONLY_COLLECTION"
+	case errors.Is(err, services.ErrInvalidDocument):
+		status, code = http.StatusBadRequest, "INVALID_DOCUMENT"
+	case errors.Is(err, services.ErrVersionConflict):
+		status, code = http.StatusConflict, "VERSION_CONFLICT"
+	}
+
+	utils.JSON(c, status, models.ErrorResponse{
+		Success:   false,
+		Error:     message,
+		Details:   err.Error(),
+		Code:      code,
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+	})
+}
@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecruiterController struct {
+	recruiterService *services.RecruiterService
+}
+
+func NewRecruiterController() *RecruiterController {
+	return &RecruiterController{
+		recruiterService: services.NewRecruiterService(),
+	}
+}
+
+// GetPrivateDetails returns the rate expectations and visa/relocation details
+// to the owner or to a recruiter holding a valid token
+func (rc *RecruiterController) GetPrivateDetails(c *gin.Context) {
+	details, err := rc.recruiterService.GetPrivateDetails(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve private details",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      details,
+		Message:   "Private details retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// UpdatePrivateDetails replaces the details shared with recruiters
+func (rc *RecruiterController) UpdatePrivateDetails(c *gin.Context) {
+	var request models.PrivateDetails
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if err := rc.recruiterService.SetPrivateDetails(c.Request.Context(), request, c.GetString("user_type")); err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to update private details",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      request,
+		Message:   "Private details updated successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// ListTokens returns the issued recruiter tokens with how often and when
+// they were last used
+func (rc *RecruiterController) ListTokens(c *gin.Context) {
+	tokens, err := rc.recruiterService.ListTokens(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve recruiter tokens",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      tokens,
+		Message:   "Recruiter tokens retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// CreateToken issues a recruiter token; the token itself is only shown in
+// this response
+func (rc *RecruiterController) CreateToken(c *gin.Context) {
+	var request models.RecruiterTokenRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	token, err := rc.recruiterService.CreateToken(c.Request.Context(), request, c.GetString("user_type"))
+	if err != nil {
+		status, code := http.StatusInternalServerError, ""
+		if errors.Is(err, services.ErrInvalidTokenRequest) {
+			status, code = http.StatusBadRequest, "INVALID_TOKEN_REQUEST"
+		}
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to create recruiter token",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusCreated, models.APIResponse{
+		Success:   true,
+		Data:      token,
+		Message:   "Recruiter token created successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// RevokeToken stops a recruiter token from granting access
+func (rc *RecruiterController) RevokeToken(c *gin.Context) {
+	err := rc.recruiterService.RevokeToken(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		status, code := http.StatusInternalServerError, ""
+		if errors.Is(err, services.ErrRecruiterTokenNotFound) {
+			status, code = http.StatusNotFound, "TOKEN_NOT_FOUND"
+		}
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to revoke recruiter token",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Message:   "Recruiter token revoked successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
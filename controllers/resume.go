@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"portfolio-backend/middleware"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ResumeController struct {
+	resumeService *services.ResumeService
+}
+
+func NewResumeController() *ResumeController {
+	return &ResumeController{
+		resumeService: services.NewResumeService(),
+	}
+}
+
+// Download serves the resume through a signed link, logging who opened it
+func (rc *ResumeController) Download(c *gin.Context) {
+	path, err := rc.resumeService.ResumeFile()
+	if err != nil {
+		utils.JSON(c, http.StatusNotFound, models.ErrorResponse{
+			Success:   false,
+			Error:     "Resume is not available",
+			Code:      "RESUME_UNAVAILABLE",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	_, err = rc.resumeService.RecordDownload(c.Request.Context(), c.Param("id"), c.Query("signature"), middleware.ByClientIP(c), c.Request.UserAgent())
+	if err != nil {
+		status, message, code := http.StatusInternalServerError, "Failed to check resume link", ""
+		if errors.Is(err, services.ErrInvalidResumeLink) {
+			status, message, code = http.StatusForbidden, "Invalid, expired or revoked resume link", "INVALID_RESUME_LINK"
+		}
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     message,
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	// Every download has to reach the API to be logged
+	c.Header("Cache-Control", "no-store")
+	c.FileAttachment(path, filepath.Base(path))
+}
+
+// ListLinks returns the issued resume links with their download counts
+func (rc *ResumeController) ListLinks(c *gin.Context) {
+	links, err := rc.resumeService.ListLinks(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve resume links",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      links,
+		Message:   "Resume links retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// CreateLink issues a resume link for one recipient
+func (rc *ResumeController) CreateLink(c *gin.Context) {
+	var request models.ResumeLinkRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	link, err := rc.resumeService.CreateLink(c.Request.Context(), request, c.GetString("user_type"))
+	if err != nil {
+		status, code := http.StatusInternalServerError, ""
+		if errors.Is(err, services.ErrInvalidResumeLinkRequest) {
+			status, code = http.StatusBadRequest, "INVALID_LINK_REQUEST"
+		}
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to create resume link",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusCreated, models.APIResponse{
+		Success:   true,
+		Data:      link,
+		Message:   "Resume link created successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// GetDownloads returns who downloaded the resume through a link, and when
+func (rc *ResumeController) GetDownloads(c *gin.Context) {
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	downloads, err := rc.resumeService.GetDownloads(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		status, code := http.StatusInternalServerError, ""
+		if errors.Is(err, services.ErrResumeLinkNotFound) {
+			status, code = http.StatusNotFound, "LINK_NOT_FOUND"
+		}
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve resume downloads",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      downloads,
+		Message:   "Resume downloads retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// RevokeLink stops a resume link from serving the resume
+func (rc *ResumeController) RevokeLink(c *gin.Context) {
+	err := rc.resumeService.RevokeLink(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		status, code := http.StatusInternalServerError, ""
+		if errors.Is(err, services.ErrResumeLinkNotFound) {
+			status, code = http.StatusNotFound, "LINK_NOT_FOUND"
+		}
+		utils.JSON(c, status, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to revoke resume link",
+			Details:   err.Error(),
+			Code:      code,
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Message:   "Resume link revoked successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
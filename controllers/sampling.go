@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"net/http"
+	"portfolio-backend/middleware"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SamplingController struct {
+	settingsService *services.SettingsService
+}
+
+func NewSamplingController() *SamplingController {
+	return &SamplingController{
+		settingsService: services.NewSettingsService(),
+	}
+}
+
+// GetSampling returns the sampling of the traffic analytics
+func (sc *SamplingController) GetSampling(c *gin.Context) {
+	sampling, err := sc.settingsService.GetAnalyticsSampling(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve analytics sampling",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      sampling,
+		Message:   "Analytics sampling retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// UpdateSampling stores a new analytics sampling and applies it without a
+// restart
+func (sc *SamplingController) UpdateSampling(c *gin.Context) {
+	var request models.AnalyticsSampling
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if err := sc.settingsService.SetAnalyticsSampling(c.Request.Context(), request, c.GetString("user_type")); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to update analytics sampling",
+			Details:   err.Error(),
+			Code:      "INVALID_SAMPLING",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if !services.IsDryRun(c.Request.Context()) {
+		middleware.ApplyAnalyticsSampling(&request)
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      request,
+		Message:   "Analytics sampling updated successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TelegramController struct {
+	telegramService *services.TelegramService
+}
+
+func NewTelegramController() *TelegramController {
+	return &TelegramController{
+		telegramService: services.NewTelegramService(),
+	}
+}
+
+// GetSettings returns the Telegram bot configuration without the bot token
+func (tc *TelegramController) GetSettings(c *gin.Context) {
+	settings, err := tc.telegramService.GetSettings(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to retrieve Telegram settings",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: gin.H{
+			"chat_id":          settings.ChatID,
+			"enabled":          settings.Enabled,
+			"token_configured": settings.BotToken != "",
+		},
+		Message:   "Telegram settings retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// UpdateSettings stores the Telegram bot configuration
+func (tc *TelegramController) UpdateSettings(c *gin.Context) {
+	var request models.TelegramSettings
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid request body",
+			Details:   err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	if err := tc.telegramService.SetSettings(c.Request.Context(), request, c.GetString("user_type")); err != nil {
+		utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to update Telegram settings",
+			Details:   err.Error(),
+			Code:      "INVALID_TELEGRAM_SETTINGS",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Message:   "Telegram settings updated successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// SendTest sends a test message to the configured chat
+func (tc *TelegramController) SendTest(c *gin.Context) {
+	err := tc.telegramService.Notify(c.Request.Context(), "✅ Portfolio backend notifications are working")
+	if err != nil {
+		utils.JSON(c, http.StatusBadGateway, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to send Telegram message",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Message:   "Telegram test message sent",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
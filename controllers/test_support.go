@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TestSupportController struct {
+	testSupportService *services.TestSupportService
+}
+
+func NewTestSupportController() *TestSupportController {
+	return &TestSupportController{
+		testSupportService: services.NewTestSupportService(),
+	}
+}
+
+// ListFixtures returns the fixture sets that can be seeded
+func (tc *TestSupportController) ListFixtures(c *gin.Context) {
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      services.FixtureSets(),
+		Message:   "Fixture sets retrieved successfully",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// Seed resets the database and loads a fixture set, the default one when
+// the body names none
+func (tc *TestSupportController) Seed(c *gin.Context) {
+	var request models.FixtureSeedRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+				Success:   false,
+				Error:     "Invalid request body",
+				Details:   err.Error(),
+				Code:      "INVALID_REQUEST",
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			return
+		}
+	}
+	if request.Fixture == "" {
+		request.Fixture = services.DefaultFixtureSet
+	}
+
+	result, err := tc.testSupportService.Seed(c.Request.Context(), request.Fixture)
+	if errors.Is(err, services.ErrUnknownFixtureSet) {
+		validator := utils.NewValidator()
+		validator.AddError("fixture", "Must be one of: "+strings.Join(services.FixtureSets(), ", "), "UNKNOWN_FIXTURE")
+		utils.ValidationErrorResponse(c, validator.GetErrors())
+		return
+	}
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to seed fixtures",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      result,
+		Message:   "Fixture set " + request.Fixture + " seeded",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// Reset empties the database
+func (tc *TestSupportController) Reset(c *gin.Context) {
+	cleared, err := tc.testSupportService.Reset(c.Request.Context())
+	if err != nil {
+		utils.JSON(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success:   false,
+			Error:     "Failed to reset the database",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return
+	}
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success:   true,
+		Data:      models.FixtureSeedResult{Cleared: cleared, Content: []string{}, GitHub: []string{}},
+		Message:   "Database reset",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
@@ -2,10 +2,12 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"portfolio-backend/config"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -15,12 +17,27 @@ var (
 	Database *mongo.Database
 )
 
+// maxConnectBackoff caps the wait between startup connection attempts
+const maxConnectBackoff = 30 * time.Second
+
+// tryConnect, sleep and now are variables so tests can retry without
+// MongoDB or waiting
+var (
+	tryConnect = Connect
+	sleep      = time.Sleep
+	now        = time.Now
+)
+
 func Connect() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	// Create MongoDB client
-	clientOptions := options.Client().ApplyURI(config.AppConfig.MongoDBURI)
+	clientOptions := options.Client().ApplyURI(config.AppConfig.MongoDBURI).
+		SetMonitor(commandMonitor())
+	if config.AppConfig.EnableMetrics {
+		clientOptions.SetPoolMonitor(poolMonitor())
+	}
 	
 	var err error
 	Client, err = mongo.Connect(ctx, clientOptions)
@@ -47,6 +64,39 @@ func Connect() error {
 	return nil
 }
 
+// ConnectWithRetry keeps calling Connect with exponential backoff until it
+// succeeds or timeout elapses, so the API can boot before MongoDB is reachable
+func ConnectWithRetry(timeout, initialBackoff time.Duration) error {
+	deadline := now().Add(timeout)
+	backoff := initialBackoff
+	attempt := 1
+
+	for {
+		err := tryConnect()
+		if err == nil {
+			return nil
+		}
+
+		// Release the half-initialized client before trying again
+		Disconnect()
+		Client = nil
+		Database = nil
+
+		if now().Add(backoff).After(deadline) {
+			return fmt.Errorf("database not available after %d attempts: %w", attempt, err)
+		}
+
+		log.Printf("Database connection attempt %d failed: %v (retrying in %s)", attempt, err, backoff)
+		sleep(backoff)
+
+		attempt++
+		backoff *= 2
+		if backoff > maxConnectBackoff {
+			backoff = maxConnectBackoff
+		}
+	}
+}
+
 func Disconnect() error {
 	if Client == nil {
 		return nil
@@ -74,6 +124,21 @@ func createIndexes() error {
 		return err
 	}
 
+	// Create TTL and lookup indexes for oversized cache payload chunks
+	chunksCollection := Database.Collection("cache_chunks")
+	_, err = chunksCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    map[string]interface{}{"expires_at": 1},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+		{
+			Keys: bson.D{{Key: "key", Value: 1}, {Key: "chunk_id", Value: 1}, {Key: "index", Value: 1}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
 	// Create index for content collection
 	contentCollection := Database.Collection("content")
 	contentIndexModel := mongo.IndexModel{
@@ -85,6 +150,243 @@ func createIndexes() error {
 		return err
 	}
 
+	// Each content type has at most one draft
+	draftsCollection := Database.Collection("content_drafts")
+	_, err = draftsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "type", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Each published version of a content type is archived once
+	versionsCollection := Database.Collection("content_versions")
+	_, err = versionsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "type", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Stored repositories are exported per owner
+	githubCollection := Database.Collection("github_data")
+	_, err = githubCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "owner", Value: 1}, {Key: "name", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Settings are looked up by key
+	settingsCollection := Database.Collection("settings")
+	_, err = settingsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    map[string]interface{}{"key": 1},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Deploy hook history is listed newest first per hook
+	deployRunsCollection := Database.Collection("deploy_hook_runs")
+	_, err = deployRunsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "hook", Value: 1}, {Key: "triggered_at", Value: -1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// CDN purge history is listed newest first per integration
+	cdnPurgeRunsCollection := Database.Collection("cdn_purge_runs")
+	_, err = cdnPurgeRunsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "integration", Value: 1}, {Key: "purged_at", Value: -1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Digest snapshots indexes
+	snapshotsCollection := Database.Collection("digest_snapshots")
+	_, err = snapshotsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "username", Value: 1}, {Key: "taken_at", Value: -1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Raw document edits are listed per document, newest first
+	auditCollection := Database.Collection("raw_document_audit")
+	_, err = auditCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "collection", Value: 1}, {Key: "document_id", Value: 1}, {Key: "edited_at", Value: -1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Releases are recorded once, by URL
+	releasesCollection := Database.Collection("github_releases")
+	_, err = releasesCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "url", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Repository traffic is stored once per repository and day
+	trafficCollection := Database.Collection("github_traffic")
+	_, err = trafficCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "repository", Value: 1}, {Key: "date", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The audit log is listed newest first, by actor or by request
+	auditLogCollection := Database.Collection("audit_log")
+	_, err = auditLogCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "actor", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "request_id", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Recruiter tokens are looked up by the hash of the presented token
+	recruiterTokensCollection := Database.Collection("recruiter_tokens")
+	_, err = recruiterTokensCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "token_hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Resume downloads are listed per link, newest first
+	resumeDownloadsCollection := Database.Collection("resume_downloads")
+	_, err = resumeDownloadsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "link_id", Value: 1}, {Key: "downloaded_at", Value: -1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// GitHub response validators are looked up by URL and dropped once they
+	// have not been revalidated for a month
+	conditionalCollection := Database.Collection("github_conditional")
+	_, err = conditionalCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "url", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "updated_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32((30 * 24 * time.Hour).Seconds())),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Merged pull requests in other people's repositories are listed per
+	// user, newest first, and upserted by URL
+	externalCollection := Database.Collection("github_external_contributions")
+	_, err = externalCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "username", Value: 1}, {Key: "url", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "username", Value: 1}, {Key: "merged_at", Value: -1}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// One endorsement per GitHub account and skill; endorsements are listed
+	// per skill and for moderation, newest first
+	endorsementsCollection := Database.Collection("skill_endorsements")
+	_, err = endorsementsCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "skill", Value: 1}, {Key: "endorser_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "skill", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// One feedback per visitor and project, aggregated by time range and
+	// erased per visitor
+	feedbackCollection := Database.Collection("project_feedback")
+	_, err = feedbackCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "project", Value: 1}, {Key: "visitor", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "visitor", Value: 1}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Contact messages are listed newest first, apart from the spam
+	contactCollection := Database.Collection("contact_messages")
+	_, err = contactCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "spam", Value: 1}, {Key: "created_at", Value: -1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Workers claim due jobs in run_at order; jobs are listed newest first
+	jobsCollection := Database.Collection("jobs")
+	_, err = jobsCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "run_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "finished_at", Value: 1}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Page views are aggregated by time range and dropped after
+	// VISITOR_RETENTION (0 keeps them)
+	pageViewsIndex := options.Index()
+	if retention := config.AppConfig.VisitorRetention; retention > 0 {
+		pageViewsIndex.SetExpireAfterSeconds(int32(retention.Seconds()))
+	}
+	pageViewsCollection := Database.Collection("page_views")
+	_, err = pageViewsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "timestamp", Value: 1}},
+		Options: pageViewsIndex,
+	})
+	if err != nil {
+		return err
+	}
+
 	log.Println("Database indexes created successfully")
 	return nil
 }
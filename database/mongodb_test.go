@@ -0,0 +1,62 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubConnect makes ConnectWithRetry fail failures times before connecting,
+// on a clock its waits move forward instead of sleeping
+func stubConnect(t *testing.T, failures int) *[]time.Duration {
+	originalConnect, originalSleep, originalNow := tryConnect, sleep, now
+	t.Cleanup(func() { tryConnect, sleep, now = originalConnect, originalSleep, originalNow })
+
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var waits []time.Duration
+	tryConnect = func() error {
+		if failures > 0 {
+			failures--
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	sleep = func(d time.Duration) {
+		waits = append(waits, d)
+		clock = clock.Add(d)
+	}
+	now = func() time.Time { return clock }
+	return &waits
+}
+
+func TestConnectWithRetryBackoff(t *testing.T) {
+	waits := stubConnect(t, 7)
+
+	require.NoError(t, ConnectWithRetry(time.Hour, 5*time.Second))
+	// The wait doubles after each failure, up to maxConnectBackoff
+	assert.Equal(t, []time.Duration{
+		5 * time.Second, 10 * time.Second, 20 * time.Second, 30 * time.Second,
+		30 * time.Second, 30 * time.Second, 30 * time.Second,
+	}, *waits)
+}
+
+func TestConnectWithRetryTimeout(t *testing.T) {
+	waits := stubConnect(t, 100)
+
+	// After 1+2+4+8 seconds, waiting 16 more would pass the deadline
+	err := ConnectWithRetry(20*time.Second, time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "after 5 attempts")
+	assert.Contains(t, err.Error(), "connection refused")
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}, *waits)
+}
+
+func TestConnectWithRetryFirstAttempt(t *testing.T) {
+	waits := stubConnect(t, 0)
+
+	require.NoError(t, ConnectWithRetry(time.Second, time.Millisecond))
+	assert.Empty(t, *waits)
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"portfolio-backend/metrics"
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// Exported on /metrics
+var (
+	mongoCommandDuration = metrics.NewHistogramVec("mongodb_command_duration_seconds",
+		"MongoDB command latency by command name and outcome (ok or error).", metrics.DefaultBuckets, "command", "outcome")
+	mongoConnections = metrics.NewGaugeVec("mongodb_open_connections",
+		"Connections open in the MongoDB driver pool.")
+)
+
+// openConnections tracks the driver pool, see OpenConnections
+var openConnections atomic.Int64
+
+// commandMonitor times every command the driver sends, also adding it to the
+// timings of the request that sent it (see metrics.WithTimings)
+func commandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			mongoCommandDuration.Observe(e.Duration.Seconds(), e.CommandName, "ok")
+			metrics.ObserveLayer(ctx, metrics.LayerDB, e.Duration)
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			mongoCommandDuration.Observe(e.Duration.Seconds(), e.CommandName, "error")
+			metrics.ObserveLayer(ctx, metrics.LayerDB, e.Duration)
+		},
+	}
+}
+
+// poolMonitor counts the connections the driver opens and closes
+func poolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				mongoConnections.Set(float64(openConnections.Add(1)))
+			case event.ConnectionClosed:
+				mongoConnections.Set(float64(openConnections.Add(-1)))
+			}
+		},
+	}
+}
+
+// OpenConnections returns how many connections the MongoDB driver pool
+// holds, or 0 unless ENABLE_METRICS is set
+func OpenConnections() int {
+	return int(openConnections.Load())
+}
@@ -0,0 +1,73 @@
+package grpcserver
+
+import (
+	"context"
+	pb "portfolio-backend/proto/portfolio/v1"
+	"portfolio-backend/services"
+)
+
+type contentServer struct {
+	pb.UnimplementedContentServiceServer
+	contentService *services.ContentService
+}
+
+func newContentServer() *contentServer {
+	return &contentServer{
+		contentService: services.NewContentService(),
+	}
+}
+
+func (s *contentServer) GetMeta(ctx context.Context, _ *pb.GetMetaRequest) (*pb.Meta, error) {
+	meta, err := s.contentService.GetMeta(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toMeta(meta), nil
+}
+
+func (s *contentServer) GetSkills(ctx context.Context, _ *pb.GetSkillsRequest) (*pb.Skills, error) {
+	skills, err := s.contentService.GetSkills(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toSkills(skills), nil
+}
+
+func (s *contentServer) ListExperience(_ *pb.ListExperienceRequest, stream pb.ContentService_ListExperienceServer) error {
+	experience, err := s.contentService.GetExperience(stream.Context())
+	if err != nil {
+		return statusError(err)
+	}
+	for i := range experience {
+		if err := stream.Send(toExperience(&experience[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *contentServer) ListProjects(_ *pb.ListProjectsRequest, stream pb.ContentService_ListProjectsServer) error {
+	projects, err := s.contentService.GetProjects(stream.Context())
+	if err != nil {
+		return statusError(err)
+	}
+	for i := range projects {
+		if err := stream.Send(toProject(&projects[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *contentServer) ListEducation(_ *pb.ListEducationRequest, stream pb.ContentService_ListEducationServer) error {
+	education, err := s.contentService.GetEducation(stream.Context())
+	if err != nil {
+		return statusError(err)
+	}
+	for i := range education {
+		if err := stream.Send(toEducation(&education[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package grpcserver
+
+import (
+	"portfolio-backend/models"
+	pb "portfolio-backend/proto/portfolio/v1"
+	"portfolio-backend/utils"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// timestamp leaves zero times unset
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
+
+// objectID leaves unset IDs empty rather than all zeros
+func objectID(hex string) string {
+	if hex == "000000000000000000000000" {
+		return ""
+	}
+	return hex
+}
+
+func toMeta(meta *models.Meta) *pb.Meta {
+	return &pb.Meta{
+		Name:     meta.Name,
+		Title:    meta.Title,
+		Location: meta.Location,
+		Github:   meta.GitHub,
+		Email:    meta.Email,
+		Linkedin: meta.LinkedIn,
+		Website:  meta.Website,
+		Bio:      meta.Bio,
+	}
+}
+
+func toSkills(skills *models.Skills) *pb.Skills {
+	convert := func(list []models.Skill) []*pb.Skill {
+		converted := make([]*pb.Skill, 0, len(list))
+		for _, skill := range list {
+			converted = append(converted, &pb.Skill{
+				Name:           skill.Name,
+				Level:          int32(skill.Level),
+				Category:       skill.Category,
+				Icon:           skill.Icon,
+				YearsExp:       int32(skill.YearsExp),
+				Certifications: skill.Certifications,
+			})
+		}
+		return converted
+	}
+
+	return &pb.Skills{
+		Backend:   convert(skills.Backend),
+		Frontend:  convert(skills.Frontend),
+		Database:  convert(skills.Database),
+		Devops:    convert(skills.DevOps),
+		Tools:     convert(skills.Tools),
+		Languages: convert(skills.Languages),
+	}
+}
+
+func toExperience(experience *models.Experience) *pb.Experience {
+	return &pb.Experience{
+		Id:           objectID(experience.ID.Hex()),
+		Company:      experience.Company,
+		Position:     experience.Position,
+		Location:     experience.Location,
+		StartDate:    timestamp(experience.StartDate),
+		EndDate:      optionalTimestamp(experience.EndDate),
+		IsCurrent:    experience.IsCurrent,
+		Description:  experience.Description,
+		Achievements: experience.Achievements,
+		Technologies: experience.Technologies,
+		CompanyLogo:  experience.CompanyLogo,
+		CompanyUrl:   experience.CompanyURL,
+	}
+}
+
+func toProject(project *models.Project) *pb.Project {
+	return &pb.Project{
+		Id:              objectID(project.ID.Hex()),
+		Slug:            utils.SlugifyString(project.Name),
+		Name:            project.Name,
+		Description:     project.Description,
+		LongDescription: project.LongDesc,
+		Technologies:    project.Technologies,
+		GithubUrl:       project.GitHubURL,
+		LiveUrl:         project.LiveURL,
+		DemoUrl:         project.DemoURL,
+		Images:          project.Images,
+		Featured:        project.Featured,
+		Status:          project.Status,
+		StartDate:       timestamp(project.StartDate),
+		EndDate:         optionalTimestamp(project.EndDate),
+		Category:        project.Category,
+		Highlights:      project.Highlights,
+		Challenges:      project.Challenges,
+		Stars:           int32(project.Stars),
+		Forks:           int32(project.Forks),
+		Language:        project.Language,
+		ExperienceId:    project.ExperienceID,
+	}
+}
+
+func toEducation(education *models.Education) *pb.Education {
+	return &pb.Education{
+		Id:          objectID(education.ID.Hex()),
+		Institution: education.Institution,
+		Degree:      education.Degree,
+		Field:       education.Field,
+		StartDate:   timestamp(education.StartDate),
+		EndDate:     optionalTimestamp(education.EndDate),
+		Gpa:         education.GPA,
+		Honors:      education.Honors,
+		Courses:     education.Courses,
+		Description: education.Description,
+		Logo:        education.Logo,
+		Url:         education.URL,
+	}
+}
+
+func toProfile(profile *models.GitHubProfile) *pb.Profile {
+	return &pb.Profile{
+		Login:           profile.Login,
+		Name:            profile.Name,
+		AvatarUrl:       profile.AvatarURL,
+		Bio:             profile.Bio,
+		Company:         profile.Company,
+		Location:        profile.Location,
+		Email:           profile.Email,
+		Blog:            profile.Blog,
+		TwitterUsername: profile.TwitterUsername,
+		PublicRepos:     int32(profile.PublicRepos),
+		PublicGists:     int32(profile.PublicGists),
+		Followers:       int32(profile.Followers),
+		Following:       int32(profile.Following),
+		CreatedAt:       timestamp(profile.CreatedAt),
+		LastFetched:     timestamp(profile.LastFetched),
+	}
+}
+
+func toRepository(repo *models.GitHubRepository) *pb.Repository {
+	languages := make(map[string]int64, len(repo.Languages))
+	for language, bytes := range repo.Languages {
+		languages[language] = int64(bytes)
+	}
+
+	return &pb.Repository{
+		GithubId:        repo.GitHubID,
+		Name:            repo.Name,
+		FullName:        repo.FullName,
+		Description:     repo.Description,
+		Fork:            repo.Fork,
+		HtmlUrl:         repo.HTMLURL,
+		Homepage:        repo.Homepage,
+		Language:        repo.Language,
+		Languages:       languages,
+		StargazersCount: int32(repo.StargazersCount),
+		ForksCount:      int32(repo.ForksCount),
+		OpenIssuesCount: int32(repo.OpenIssuesCount),
+		Topics:          repo.Topics,
+		Archived:        repo.Archived,
+		PushedAt:        timestamp(repo.PushedAt),
+		CreatedAt:       timestamp(repo.CreatedAt),
+		UpdatedAt:       timestamp(repo.UpdatedAt),
+		Owner:           repo.Owner,
+	}
+}
+
+func toStats(stats *models.GitHubStats) *pb.Stats {
+	languages := make([]*pb.LanguageStat, 0, len(stats.MostUsedLanguages))
+	for _, language := range stats.MostUsedLanguages {
+		languages = append(languages, &pb.LanguageStat{
+			Name:       language.Name,
+			Bytes:      int64(language.Bytes),
+			Percentage: language.Percentage,
+		})
+	}
+
+	repositories := make([]*pb.RepoStat, 0, len(stats.TopRepositories))
+	for _, repo := range stats.TopRepositories {
+		repositories = append(repositories, &pb.RepoStat{
+			Name:        repo.Name,
+			FullName:    repo.FullName,
+			Stars:       int32(repo.Stars),
+			Forks:       int32(repo.Forks),
+			Language:    repo.Language,
+			Description: repo.Description,
+			HtmlUrl:     repo.HTMLURL,
+		})
+	}
+
+	return &pb.Stats{
+		Username:           stats.Username,
+		TotalRepos:         int32(stats.TotalRepos),
+		TotalStars:         int32(stats.TotalStars),
+		TotalForks:         int32(stats.TotalForks),
+		TotalCommits:       int32(stats.TotalCommits),
+		TotalContributions: int32(stats.TotalContributions),
+		MostUsedLanguages:  languages,
+		TopRepositories:    repositories,
+		ContributionStreak: int32(stats.ContributionStreak),
+		IssuesOpened:       int32(stats.IssuesOpened),
+		PullRequestsOpened: int32(stats.PullRequestsOpened),
+		PullRequestsMerged: int32(stats.PullRequestsMerged),
+		LastFetched:        timestamp(stats.LastFetched),
+	}
+}
+
+func toContributions(contributions *models.GitHubContributions) *pb.Contributions {
+	weeks := make([]*pb.ContributionWeek, 0, len(contributions.ContributionCalendar))
+	for _, week := range contributions.ContributionCalendar {
+		days := make([]*pb.ContributionDay, 0, len(week.Days))
+		for _, day := range week.Days {
+			days = append(days, &pb.ContributionDay{
+				Date:  day.Date,
+				Count: int32(day.Count),
+				Level: int32(day.Level),
+			})
+		}
+		weeks = append(weeks, &pb.ContributionWeek{WeekStart: week.WeekStart, Days: days})
+	}
+
+	years := make([]int32, 0, len(contributions.ContributionYears))
+	for _, year := range contributions.ContributionYears {
+		years = append(years, int32(year))
+	}
+
+	return &pb.Contributions{
+		Username:           contributions.Username,
+		TotalContributions: int32(contributions.TotalContributions),
+		Weeks:              weeks,
+		Years:              years,
+		LongestStreak:      int32(contributions.LongestStreak),
+		CurrentStreak:      int32(contributions.CurrentStreak),
+		LastFetched:        timestamp(contributions.LastFetched),
+	}
+}
@@ -0,0 +1,92 @@
+package grpcserver
+
+import (
+	"context"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	pb "portfolio-backend/proto/portfolio/v1"
+	"portfolio-backend/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type githubServer struct {
+	pb.UnimplementedGitHubServiceServer
+	githubService   *services.GitHubService
+	settingsService *services.SettingsService
+}
+
+func newGitHubServer() *githubServer {
+	return &githubServer{
+		githubService:   services.NewGitHubService(),
+		settingsService: services.NewSettingsService(),
+	}
+}
+
+// budgeted caps the GitHub calls one RPC may make, like the REST routes do
+func budgeted(ctx context.Context) context.Context {
+	return services.WithUpstreamBudget(ctx, config.AppConfig.GitHubRequestBudget)
+}
+
+func (s *githubServer) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.Profile, error) {
+	username, err := resolveUsername(ctx, s.settingsService, req.GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.githubService.GetProfile(budgeted(ctx), username)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProfile(profile), nil
+}
+
+func (s *githubServer) ListRepositories(req *pb.ListRepositoriesRequest, stream pb.GitHubService_ListRepositoriesServer) error {
+	ctx := stream.Context()
+	username, err := resolveUsername(ctx, s.settingsService, req.GetUsername())
+	if err != nil {
+		return err
+	}
+
+	err = s.githubService.StreamRepositories(budgeted(ctx), username, func(repo *models.GitHubRepository) error {
+		return stream.Send(toRepository(repo))
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return statusError(err)
+	}
+	return nil
+}
+
+func (s *githubServer) GetStats(ctx context.Context, req *pb.GetStatsRequest) (*pb.Stats, error) {
+	username, err := resolveUsername(ctx, s.settingsService, req.GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.githubService.GetStats(budgeted(ctx), username)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toStats(stats), nil
+}
+
+func (s *githubServer) GetContributions(ctx context.Context, req *pb.GetContributionsRequest) (*pb.Contributions, error) {
+	if !services.FeatureEnabled(services.FeatureContributions) {
+		return nil, status.Error(codes.FailedPrecondition, "contributions require a configured GitHub token")
+	}
+
+	username, err := resolveUsername(ctx, s.settingsService, req.GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	contributions, err := s.githubService.GetContributions(budgeted(ctx), username)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toContributions(contributions), nil
+}
@@ -0,0 +1,114 @@
+// Package grpcserver serves the portfolio content and GitHub read APIs over
+// gRPC, for internal services and the CLI. The protocol is defined in
+// proto/portfolio/v1/portfolio.proto.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"portfolio-backend/config"
+	pb "portfolio-backend/proto/portfolio/v1"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	serverMu sync.Mutex
+	server   *grpc.Server
+)
+
+// NewServer registers the portfolio services, the standard health service
+// and reflection, so tools such as grpcurl can list the API
+func NewServer() *grpc.Server {
+	s := grpc.NewServer()
+	pb.RegisterContentServiceServer(s, newContentServer())
+	pb.RegisterGitHubServiceServer(s, newGitHubServer())
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	reflection.Register(s)
+	return s
+}
+
+// Start serves gRPC on GRPC_PORT in the background; it does nothing when
+// no port is configured
+func Start() error {
+	if config.AppConfig.GRPCPort == "" {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", ":"+config.AppConfig.GRPCPort)
+	if err != nil {
+		return err
+	}
+
+	serverMu.Lock()
+	server = NewServer()
+	s := server
+	serverMu.Unlock()
+
+	go func() {
+		log.Printf("🛰️  gRPC API listening on port %s", config.AppConfig.GRPCPort)
+		if err := s.Serve(listener); err != nil {
+			log.Printf("gRPC server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop lets in-flight calls finish, then stops the server started by Start
+func Stop() {
+	serverMu.Lock()
+	s := server
+	server = nil
+	serverMu.Unlock()
+
+	if s != nil {
+		s.GracefulStop()
+	}
+}
+
+// statusError maps service errors to gRPC status codes
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrGitHubUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, services.ErrUpstreamBudgetExhausted):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// resolveUsername defaults to the portfolio owner, rejects names GitHub would
+// not accept and, like the REST API, usernames outside the portfolio
+// accounts and GITHUB_ALLOWED_USERS, so callers cannot spend the GitHub quota
+// on any account
+func resolveUsername(ctx context.Context, settingsService *services.SettingsService, username string) (string, error) {
+	if username == "" {
+		return settingsService.GetOwner(ctx), nil
+	}
+	if !utils.IsValidGitHubUsername(username) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid GitHub username %q", username)
+	}
+	if !settingsService.GitHubUserAllowed(ctx, username) {
+		return "", status.Errorf(codes.PermissionDenied, "GitHub username %q is not served by this portfolio", username)
+	}
+	return username, nil
+}
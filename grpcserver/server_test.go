@@ -0,0 +1,104 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	pb "portfolio-backend/proto/portfolio/v1"
+	"portfolio-backend/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	client, err := mongo.NewClient(options.Client().ApplyURI("mongodb://127.0.0.1:27017"))
+	require.NoError(t, err)
+	previousConfig, previousDatabase := config.AppConfig, database.Database
+	t.Cleanup(func() {
+		config.AppConfig, database.Database = previousConfig, previousDatabase
+	})
+	config.AppConfig = &config.Config{}
+	database.Database = client.Database("portfolio_grpc_test")
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestHealth(t *testing.T) {
+	conn := newTestConn(t)
+
+	response, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.GetStatus())
+}
+
+func TestInvalidUsername(t *testing.T) {
+	conn := newTestConn(t)
+
+	_, err := pb.NewGitHubServiceClient(conn).GetProfile(context.Background(), &pb.GetProfileRequest{Username: "-not-valid-"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUsernameNotAllowed(t *testing.T) {
+	conn := newTestConn(t)
+	config.AppConfig.GitHubUsername = "octocat"
+	client := pb.NewGitHubServiceClient(conn)
+
+	_, err := client.GetProfile(context.Background(), &pb.GetProfileRequest{Username: "someone-else"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	stream, err := client.ListRepositories(context.Background(), &pb.ListRepositoriesRequest{Username: "someone-else"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Listed in GITHUB_ALLOWED_USERS, the name gets past the check
+	config.AppConfig.GitHubAllowedUsers = "someone-else"
+	settingsService := services.NewSettingsService()
+	username, err := resolveUsername(context.Background(), settingsService, "someone-else")
+	require.NoError(t, err)
+	assert.Equal(t, "someone-else", username)
+}
+
+func TestStatusError(t *testing.T) {
+	assert.Equal(t, codes.NotFound, status.Code(statusError(mongo.ErrNoDocuments)))
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(statusError(context.DeadlineExceeded)))
+	assert.Equal(t, codes.Internal, status.Code(statusError(errors.New("boom"))))
+}
+
+func TestToProject(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	project := toProject(&models.Project{Name: "Go Portfolio API", StartDate: start, Stars: 12})
+
+	assert.Equal(t, "go-portfolio-api", project.GetSlug())
+	assert.Empty(t, project.GetId())
+	assert.Equal(t, start, project.GetStartDate().AsTime())
+	assert.Nil(t, project.GetEndDate())
+	assert.Equal(t, int32(12), project.GetStars())
+}
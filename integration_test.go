@@ -0,0 +1,766 @@
+//go:build integration
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/middleware"
+	"portfolio-backend/models"
+	"portfolio-backend/routes"
+	"portfolio-backend/services"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// End-to-end tests against a throwaway MongoDB container. Run them with
+//
+//	go test -tags integration -run E2E .
+//
+// Docker must be available, the run fails without it; MONGO_IMAGE overrides the default image.
+
+const (
+	e2eAPIToken  = "e2e-api-token"
+	e2eRateLimit = 50
+)
+
+var (
+	e2eServer *httptest.Server
+	e2eClient uint32
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(runE2E(m))
+}
+
+func runE2E(m *testing.M) int {
+	if _, err := exec.LookPath("docker"); err != nil {
+		fmt.Printf("docker is required by the integration tests: %v\n", err)
+		return 1
+	}
+
+	image := os.Getenv("MONGO_IMAGE")
+	if image == "" {
+		image = "mongo:7"
+	}
+
+	out, err := exec.Command("docker", "run", "-d", "--rm", "-p", "127.0.0.1::27017", image).Output()
+	if err != nil {
+		fmt.Printf("failed to start MongoDB container: %v\n", err)
+		return 1
+	}
+	containerID := strings.TrimSpace(string(out))
+	defer exec.Command("docker", "rm", "-f", containerID).Run()
+
+	out, err = exec.Command("docker", "port", containerID, "27017/tcp").Output()
+	if err != nil {
+		fmt.Printf("failed to resolve MongoDB port: %v\n", err)
+		return 1
+	}
+	address := strings.TrimSpace(strings.Split(string(out), "\n")[0])
+
+	os.Setenv("MONGODB_URI", "mongodb://"+address)
+	os.Setenv("DATABASE_NAME", "portfolio_e2e")
+	os.Setenv("API_TOKEN", e2eAPIToken)
+	os.Setenv("JWT_SECRET", "e2e-jwt-secret")
+	os.Setenv("RATE_LIMIT_REQUESTS", fmt.Sprint(e2eRateLimit))
+	os.Setenv("RATE_LIMIT_WINDOW", "1h")
+	os.Setenv("RATE_LIMIT_AUTH_REQUESTS", fmt.Sprint(e2eRateLimit*2))
+	os.Setenv("RATE_LIMIT_AUTH_WINDOW", "1h")
+	os.Setenv("GITHUB_TOKEN", "")
+	config.Load()
+
+	gin.SetMode(gin.TestMode)
+
+	if err := database.ConnectWithRetry(time.Minute, 500*time.Millisecond); err != nil {
+		fmt.Printf("failed to connect to MongoDB: %v\n", err)
+		return 1
+	}
+	defer database.Disconnect()
+
+	// Seed the same default content the server creates on first boot
+	if err := services.NewContentService().InitializeDefaultContent(context.Background()); err != nil {
+		fmt.Printf("failed to seed content: %v\n", err)
+		return 1
+	}
+
+	r := gin.New()
+	routes.SetupRoutes(r)
+	e2eServer = httptest.NewServer(r)
+	defer e2eServer.Close()
+
+	return m.Run()
+}
+
+// e2eRequest performs a request against the test server. Each call to
+// newE2EClientIP gives a test its own rate limit bucket.
+func e2eRequest(t *testing.T, clientIP, method, path string, body interface{}, headers map[string]string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&payload).Encode(body))
+	}
+
+	req, err := http.NewRequest(method, e2eServer.URL+path, &payload)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Forwarded-For", clientIP)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e2eServer.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var decoded map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp, decoded
+}
+
+func newE2EClientIP() string {
+	n := atomic.AddUint32(&e2eClient, 1)
+	return fmt.Sprintf("10.0.%d.%d", n/256, n%256)
+}
+
+func bearer(token string) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + token}
+}
+
+func TestE2EHealth(t *testing.T) {
+	ip := newE2EClientIP()
+
+	resp, body := e2eRequest(t, ip, "GET", "/health", nil, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "healthy", body["status"])
+
+	resp, _ = e2eRequest(t, ip, "GET", "/api/v1/does-not-exist", nil, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestE2EContentSeeded(t *testing.T) {
+	ip := newE2EClientIP()
+
+	for _, path := range []string{"", "/meta", "/skills", "/experience", "/projects", "/education"} {
+		resp, body := e2eRequest(t, ip, "GET", "/api/v1/content"+path, nil, nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, path)
+		assert.Equal(t, true, body["success"], path)
+		assert.NotNil(t, body["data"], path)
+	}
+}
+
+func TestE2EContentUpdateAndHistory(t *testing.T) {
+	ip := newE2EClientIP()
+
+	skills := models.Skills{
+		Backend: []models.Skill{{Name: "Go", Level: 95, Category: "backend"}},
+	}
+
+	resp, _ := e2eRequest(t, ip, "PUT", "/api/v1/content", models.ContentUpdateRequest{Type: "skills", Data: skills}, bearer(e2eAPIToken))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, body := e2eRequest(t, ip, "GET", "/api/v1/content/skills", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data := body["data"].(map[string]interface{})
+	backend := data["backend"].([]interface{})
+	require.Len(t, backend, 1)
+	assert.Equal(t, "Go", backend[0].(map[string]interface{})["name"])
+
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/content/history/skills", nil, bearer(e2eAPIToken))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	history := body["data"].([]interface{})
+	require.NotEmpty(t, history)
+	assert.Equal(t, "admin", history[0].(map[string]interface{})["updated_by"])
+}
+
+func TestE2EContentRelations(t *testing.T) {
+	ip := newE2EClientIP()
+
+	experience := []models.Experience{{Company: "E2E Corp", Position: "Engineer"}}
+	resp, _ := e2eRequest(t, ip, "PUT", "/api/v1/content", models.ContentUpdateRequest{Type: "experience", Data: experience}, bearer(e2eAPIToken))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, body := e2eRequest(t, ip, "GET", "/api/v1/content/experience", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	experienceID := body["data"].([]interface{})[0].(map[string]interface{})["id"].(string)
+	require.NotEmpty(t, experienceID)
+
+	// Links to experience that does not exist are rejected
+	projects := []models.Project{{Name: "Linked", ExperienceID: "64b7f0c2a1b2c3d4e5f60718"}}
+	resp, body = e2eRequest(t, ip, "PUT", "/api/v1/content", models.ContentUpdateRequest{Type: "projects", Data: projects}, bearer(e2eAPIToken))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	validationError := body["errors"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "/data/0/experience_id", validationError["pointer"])
+	assert.Equal(t, "UNKNOWN_REFERENCE", validationError["code"])
+
+	projects[0].ExperienceID = experienceID
+	resp, _ = e2eRequest(t, ip, "PUT", "/api/v1/content", models.ContentUpdateRequest{Type: "projects", Data: projects}, bearer(e2eAPIToken))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/content/projects?expand=experience", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	project := body["data"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "E2E Corp", project["experience"].(map[string]interface{})["company"])
+
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/content/projects?expand=posts", nil, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "INVALID_EXPAND", body["code"])
+
+	// Linked experience cannot be removed
+	resp, body = e2eRequest(t, ip, "PUT", "/api/v1/content", models.ContentUpdateRequest{Type: "experience", Data: []models.Experience{}}, bearer(e2eAPIToken))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "REFERENCED_ENTRY", body["errors"].([]interface{})[0].(map[string]interface{})["code"])
+
+	resp, _ = e2eRequest(t, ip, "PUT", "/api/v1/content", models.ContentUpdateRequest{Type: "projects", Data: []models.Project{}}, bearer(e2eAPIToken))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestE2EContentCaching(t *testing.T) {
+	ip := newE2EClientIP()
+	ctx := context.Background()
+	cache := services.NewCacheService()
+
+	require.NoError(t, cache.InvalidateContentCache(ctx))
+	assert.False(t, cache.Exists(ctx, "content:meta"))
+
+	// A read populates the cache
+	resp, _ := e2eRequest(t, ip, "GET", "/api/v1/content/meta", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, cache.Exists(ctx, "content:meta"))
+
+	// A write invalidates it and the next read serves the new data
+	meta := models.Meta{Name: "E2E User", Title: "Tester", GitHub: "e2e"}
+	resp, _ = e2eRequest(t, ip, "PUT", "/api/v1/content", models.ContentUpdateRequest{Type: "meta", Data: meta}, bearer(e2eAPIToken))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.False(t, cache.Exists(ctx, "content:meta"))
+
+	resp, body := e2eRequest(t, ip, "GET", "/api/v1/content/meta", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "E2E User", body["data"].(map[string]interface{})["name"])
+}
+
+func TestE2ECacheChunks(t *testing.T) {
+	ctx := context.Background()
+	cache := services.NewCacheService()
+	chunks := database.Database.Collection("cache_chunks")
+
+	// Small uncompressed chunks, read back from MongoDB rather than memory
+	original := *config.AppConfig
+	config.AppConfig.CacheSerialization = "json"
+	config.AppConfig.CacheChunkSize = 1024
+	config.AppConfig.CacheCompression = false
+	config.AppConfig.CacheL1Size = 0
+	t.Cleanup(func() { *config.AppConfig = original })
+
+	key := "e2e:chunks"
+	t.Cleanup(func() { cache.Delete(ctx, key) })
+	stored := func() []models.CacheChunk {
+		cursor, err := chunks.Find(ctx, bson.M{"key": key})
+		require.NoError(t, err)
+		var found []models.CacheChunk
+		require.NoError(t, cursor.All(ctx, &found))
+		return found
+	}
+
+	// 5000 characters encode to 5002 bytes of JSON, five chunks
+	large := strings.Repeat("0123456789", 500)
+	require.NoError(t, cache.Set(ctx, key, large, time.Hour))
+	assert.Len(t, stored(), 5)
+
+	var value string
+	require.NoError(t, cache.Get(ctx, key, &value))
+	assert.Equal(t, large, value)
+
+	// Overwriting leaves only the chunks of the new value
+	smaller := strings.Repeat("abcdefghij", 250)
+	require.NoError(t, cache.Set(ctx, key, smaller, time.Hour))
+	current := stored()
+	require.Len(t, current, 3)
+	for _, chunk := range current {
+		assert.Equal(t, current[0].ChunkID, chunk.ChunkID)
+	}
+	require.NoError(t, cache.Get(ctx, key, &value))
+	assert.Equal(t, smaller, value)
+
+	// A missing chunk is a miss, never a truncated value
+	_, err := chunks.DeleteOne(ctx, bson.M{"key": key, "index": 1})
+	require.NoError(t, err)
+	assert.Error(t, cache.Get(ctx, key, &value))
+}
+
+func TestE2EAuth(t *testing.T) {
+	ip := newE2EClientIP()
+	update := models.ContentUpdateRequest{Type: "meta", Data: models.Meta{Name: "Nope"}}
+
+	resp, body := e2eRequest(t, ip, "PUT", "/api/v1/content", update, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "MISSING_AUTH_HEADER", body["code"])
+
+	resp, body = e2eRequest(t, ip, "PUT", "/api/v1/content", update, map[string]string{"Authorization": e2eAPIToken})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "INVALID_AUTH_FORMAT", body["code"])
+
+	resp, body = e2eRequest(t, ip, "PUT", "/api/v1/content", update, bearer("not-a-valid-token"))
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "INVALID_TOKEN", body["code"])
+
+	// JWTs signed with the configured secret are accepted
+	token, err := middleware.GenerateJWT("e2e-user", time.Minute)
+	require.NoError(t, err)
+	resp, _ = e2eRequest(t, ip, "GET", "/api/v1/content/history/meta", nil, bearer(token))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Admin routes use the API key instead
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/admin/system/stats", nil, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "MISSING_API_KEY", body["code"])
+
+	resp, _ = e2eRequest(t, ip, "GET", "/api/v1/admin/system/stats", nil, map[string]string{"X-API-Key": e2eAPIToken})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestE2ERateLimit(t *testing.T) {
+	ip := newE2EClientIP()
+
+	for i := 0; i < e2eRateLimit; i++ {
+		resp, _ := e2eRequest(t, ip, "GET", "/api/v1/info", nil, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, "request %d", i+1)
+	}
+
+	resp, body := e2eRequest(t, ip, "GET", "/api/v1/info", nil, nil)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "RATE_LIMIT_EXCEEDED", body["code"])
+	assert.Equal(t, "0", resp.Header.Get("X-Rate-Limit-Remaining"))
+
+	// Other clients are unaffected
+	resp, _ = e2eRequest(t, newE2EClientIP(), "GET", "/api/v1/info", nil, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestE2ERateLimitTiers(t *testing.T) {
+	ip := newE2EClientIP()
+
+	resp, _ := e2eRequest(t, ip, "GET", "/api/v1/info", nil, nil)
+	assert.Equal(t, "anonymous", resp.Header.Get("X-Rate-Limit-Tier"))
+	assert.Equal(t, fmt.Sprint(e2eRateLimit), resp.Header.Get("X-Rate-Limit-Limit"))
+
+	// Authenticated callers draw from a separate, larger bucket
+	resp, _ = e2eRequest(t, ip, "GET", "/api/v1/info", nil, bearer(e2eAPIToken))
+	assert.Equal(t, "authenticated", resp.Header.Get("X-Rate-Limit-Tier"))
+	assert.Equal(t, fmt.Sprint(e2eRateLimit*2), resp.Header.Get("X-Rate-Limit-Limit"))
+	assert.Equal(t, fmt.Sprint(e2eRateLimit*2-1), resp.Header.Get("X-Rate-Limit-Remaining"))
+
+	// Tiers can be changed at runtime through the admin API
+	apiKey := map[string]string{"X-API-Key": e2eAPIToken}
+	update := models.RateLimitTiers{
+		Anonymous:     models.RateLimitTier{Requests: e2eRateLimit, Window: "1h"},
+		Authenticated: models.RateLimitTier{Requests: e2eRateLimit * 3, Window: "1h"},
+	}
+	resp, _ = e2eRequest(t, ip, "PUT", "/api/v1/admin/rate-limits", update, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, body := e2eRequest(t, ip, "GET", "/api/v1/admin/rate-limits", nil, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, fmt.Sprint(e2eRateLimit*3), resp.Header.Get("X-Rate-Limit-Limit"))
+	authenticated := body["data"].(map[string]interface{})["authenticated"].(map[string]interface{})
+	assert.Equal(t, float64(e2eRateLimit*3), authenticated["requests"])
+
+	update.Authenticated.Requests = 0
+	resp, body = e2eRequest(t, ip, "PUT", "/api/v1/admin/rate-limits", update, apiKey)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "INVALID_RATE_LIMIT_TIERS", body["code"])
+}
+
+func TestE2EOwner(t *testing.T) {
+	ip := newE2EClientIP()
+	apiKey := map[string]string{"X-API-Key": e2eAPIToken}
+
+	// GITHUB_USERNAME is the owner until one is set at runtime
+	resp, body := e2eRequest(t, ip, "GET", "/api/v1/admin/owner", nil, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	original := body["data"].(map[string]interface{})["github_username"].(string)
+	assert.Equal(t, config.AppConfig.GitHubUsername, original)
+
+	resp, _ = e2eRequest(t, ip, "PUT", "/api/v1/admin/owner", models.OwnerSettings{GitHubUsername: "octocat"}, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	t.Cleanup(func() {
+		e2eRequest(t, ip, "PUT", "/api/v1/admin/owner", models.OwnerSettings{GitHubUsername: original}, apiKey)
+	})
+
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/admin/owner", nil, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "octocat", body["data"].(map[string]interface{})["github_username"])
+
+	resp, body = e2eRequest(t, ip, "PUT", "/api/v1/admin/owner", models.OwnerSettings{GitHubUsername: "-not a user-"}, apiKey)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "INVALID_OWNER", body["code"])
+}
+
+func TestE2EAvailability(t *testing.T) {
+	ip := newE2EClientIP()
+	apiKey := map[string]string{"X-API-Key": e2eAPIToken}
+
+	availability := models.Availability{
+		Status:         "open",
+		PreferredRoles: []string{"Backend Engineer"},
+		TimeZone:       "America/Sao_Paulo",
+	}
+	resp, _ := e2eRequest(t, ip, "PUT", "/api/v1/admin/availability", availability, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The edit invalidates the cached content, so it is public right away
+	resp, body := e2eRequest(t, ip, "GET", "/api/v1/content/availability", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, "open", data["status"])
+	assert.Equal(t, []interface{}{"Backend Engineer"}, data["preferred_roles"])
+	assert.Equal(t, false, data["currently_unavailable"])
+
+	resp, body = e2eRequest(t, ip, "PUT", "/api/v1/admin/availability", models.Availability{Status: "open", TimeZone: "Mars/Olympus_Mons"}, apiKey)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "INVALID_AVAILABILITY", body["code"])
+}
+
+func TestE2ERecruiterTokens(t *testing.T) {
+	ip := newE2EClientIP()
+	apiKey := map[string]string{"X-API-Key": e2eAPIToken}
+
+	details := models.PrivateDetails{RateExpectation: "USD 70-90/h", VisaStatus: "EU work permit", OpenToRelocation: true}
+	resp, _ := e2eRequest(t, ip, "PUT", "/api/v1/admin/private-details", details, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Not public without a token
+	resp, body := e2eRequest(t, ip, "GET", "/api/v1/content/private", nil, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "MISSING_RECRUITER_TOKEN", body["code"])
+
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/content/private", nil, map[string]string{"X-Recruiter-Token": "rt_guess"})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "INVALID_RECRUITER_TOKEN", body["code"])
+
+	resp, body = e2eRequest(t, ip, "POST", "/api/v1/admin/recruiter-tokens", models.RecruiterTokenRequest{Label: "Acme", ExpiresIn: "1h"}, apiKey)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	issued := body["data"].(map[string]interface{})
+	token := issued["token"].(string)
+	id := issued["id"].(string)
+
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/content/private?recruiter_token="+token, nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "USD 70-90/h", body["data"].(map[string]interface{})["rate_expectation"])
+
+	// Uses are tracked and the token is never listed again
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/admin/recruiter-tokens", nil, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var listed map[string]interface{}
+	for _, item := range body["data"].([]interface{}) {
+		if item.(map[string]interface{})["id"] == id {
+			listed = item.(map[string]interface{})
+		}
+	}
+	require.NotNil(t, listed)
+	assert.Equal(t, float64(1), listed["use_count"])
+	assert.Nil(t, listed["token"])
+
+	resp, _ = e2eRequest(t, ip, "DELETE", "/api/v1/admin/recruiter-tokens/"+id, nil, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/content/private", nil, map[string]string{"X-Recruiter-Token": token})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "INVALID_RECRUITER_TOKEN", body["code"])
+}
+
+func TestE2EResumeLinks(t *testing.T) {
+	ip := newE2EClientIP()
+	apiKey := map[string]string{"X-API-Key": e2eAPIToken}
+
+	resume := filepath.Join(t.TempDir(), "resume.pdf")
+	require.NoError(t, os.WriteFile(resume, []byte("%PDF-1.4 e2e"), 0o644))
+	original := config.AppConfig.ResumePath
+	config.AppConfig.ResumePath = resume
+	t.Cleanup(func() { config.AppConfig.ResumePath = original })
+
+	resp, body := e2eRequest(t, ip, "POST", "/api/v1/admin/resume-links", models.ResumeLinkRequest{Recipient: "Acme"}, apiKey)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	link := body["data"].(map[string]interface{})
+	id, url := link["id"].(string), link["url"].(string)
+
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/resume/"+id+"?signature=forged", nil, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, "INVALID_RESUME_LINK", body["code"])
+
+	resp, _ = e2eRequest(t, ip, "GET", url, nil, map[string]string{"User-Agent": "e2e-recruiter"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "resume.pdf")
+
+	// The download is logged against the recipient
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/admin/resume-links/"+id+"/downloads", nil, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	downloads := body["data"].([]interface{})
+	require.Len(t, downloads, 1)
+	download := downloads[0].(map[string]interface{})
+	assert.Equal(t, "Acme", download["recipient"])
+	assert.Equal(t, ip, download["ip"])
+	assert.Equal(t, "e2e-recruiter", download["user_agent"])
+
+	resp, _ = e2eRequest(t, ip, "DELETE", "/api/v1/admin/resume-links/"+id, nil, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, _ = e2eRequest(t, ip, "GET", url, nil, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestE2EDisplayLabelsRefreshCache(t *testing.T) {
+	ip := newE2EClientIP()
+	apiKey := map[string]string{"X-API-Key": e2eAPIToken}
+
+	resumePDF := func() (*http.Response, string) {
+		req, err := http.NewRequest("GET", e2eServer.URL+"/api/v1/export/resume.pdf?locale=en", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Forwarded-For", ip)
+		resp, err := e2eServer.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var body bytes.Buffer
+		body.ReadFrom(resp.Body)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		return resp, body.String()
+	}
+
+	// The second download comes from the route cache
+	resumePDF()
+	resp, pdf := resumePDF()
+	assert.Equal(t, "HIT", resp.Header.Get(middleware.CacheStatusHeader))
+	assert.NotContains(t, pdf, "Golang E2E")
+
+	labels := models.DisplayLabels{Locales: map[string][]models.DisplayLabel{
+		"en": {{From: []string{"go"}, Label: "Golang E2E"}},
+	}}
+	resp, _ = e2eRequest(t, ip, "PUT", "/api/v1/admin/display-labels", labels, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	t.Cleanup(func() {
+		e2eRequest(t, ip, "PUT", "/api/v1/admin/display-labels", models.DisplayLabels{Locales: map[string][]models.DisplayLabel{}}, apiKey)
+	})
+
+	// Saving the labels dropped the cached PDF, which is rebuilt with them
+	resp, pdf = resumePDF()
+	assert.Equal(t, "MISS", resp.Header.Get(middleware.CacheStatusHeader))
+	assert.Contains(t, pdf, "Golang E2E")
+
+	// And the labeled skills are served
+	resp, body := e2eRequest(t, ip, "GET", "/api/v1/content/skills?locale=en", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	skills := body["data"].(map[string]interface{})["backend"].([]interface{})
+	labeled := map[string]interface{}{}
+	for _, skill := range skills {
+		skill := skill.(map[string]interface{})
+		labeled[skill["name"].(string)] = skill["label"]
+	}
+	assert.Equal(t, "Golang E2E", labeled["Go"])
+}
+
+func TestE2ERawDocuments(t *testing.T) {
+	ip := newE2EClientIP()
+	apiKey := map[string]string{"X-API-Key": e2eAPIToken}
+
+	var content models.Content
+	require.NoError(t, database.Database.Collection("content").FindOne(context.Background(), map[string]string{"type": "education"}).Decode(&content))
+	path := "/api/v1/admin/raw/content/" + content.ID.Hex()
+
+	resp, body := e2eRequest(t, ip, "GET", path, nil, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	doc := body["data"].(map[string]interface{})
+	assert.Equal(t, "education", doc["type"])
+
+	// Edits go through schema validation
+	doc["data"] = []interface{}{map[string]interface{}{"institution": "E2E University", "degre": "typo"}}
+	resp, body = e2eRequest(t, ip, "PUT", path, doc, apiKey)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "INVALID_DOCUMENT", body["code"])
+
+	doc["data"] = []interface{}{map[string]interface{}{"institution": "E2E University", "degree": "BSc"}}
+	resp, body = e2eRequest(t, ip, "PUT", path, doc, apiKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, float64(content.Version+1), body["data"].(map[string]interface{})["version"])
+
+	// Resending the version that was read is now a conflict
+	resp, body = e2eRequest(t, ip, "PUT", path, doc, apiKey)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, "VERSION_CONFLICT", body["code"])
+
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/content/education", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "E2E University", body["data"].([]interface{})[0].(map[string]interface{})["institution"])
+
+	audits, err := database.Database.Collection("raw_document_audit").CountDocuments(context.Background(), map[string]string{"document_id": content.ID.Hex()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), audits)
+
+	// System collections are read-only, settings are not exposed at all
+	resp, body = e2eRequest(t, ip, "PUT", "/api/v1/admin/raw/cache/"+content.ID.Hex(), doc, apiKey)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, "READ_ONLY_COLLECTION", body["code"])
+
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/admin/raw/settings/telegram", nil, apiKey)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "UNKNOWN_COLLECTION", body["code"])
+}
+
+func TestE2ERepositoryExport(t *testing.T) {
+	ctx := context.Background()
+	owner := "e2e-exporter"
+
+	var repos []interface{}
+	for i := 0; i < 250; i++ {
+		repos = append(repos, models.GitHubRepository{
+			GitHubID: int64(900000 + i),
+			Name:     fmt.Sprintf("repo-%03d", i),
+			FullName: fmt.Sprintf("%s/repo-%03d", owner, i),
+			Owner:    owner,
+		})
+	}
+	_, err := database.Database.Collection("github_data").InsertMany(ctx, repos)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("GET", e2eServer.URL+"/api/v1/github/repos/"+owner+"/export", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Forwarded-For", newE2EClientIP())
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	// A custom transport keeps Go from transparently decompressing the body
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+
+	body, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+
+	lines := 0
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		var repo models.GitHubRepository
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &repo))
+		assert.Equal(t, fmt.Sprintf("repo-%03d", lines), repo.Name)
+		lines++
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, len(repos), lines)
+}
+
+func TestE2ECollectionExport(t *testing.T) {
+	ctx := context.Background()
+	recipient := "e2e-export-recipient"
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var downloads []interface{}
+	for i := 0; i < 5; i++ {
+		downloads = append(downloads, models.ResumeDownload{
+			Recipient:    recipient,
+			DownloadedAt: start.AddDate(0, 0, i),
+		})
+	}
+	_, err := database.Database.Collection("resume_downloads").InsertMany(ctx, downloads)
+	require.NoError(t, err)
+
+	ip := newE2EClientIP()
+	export := func(query string) []map[string]interface{} {
+		req, err := http.NewRequest("GET", e2eServer.URL+"/api/v1/admin/export/resume_downloads?"+query, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Forwarded-For", ip)
+		req.Header.Set("X-API-Key", e2eAPIToken)
+
+		resp, err := e2eServer.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+		var docs []map[string]interface{}
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			var doc map[string]interface{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &doc))
+			if doc["recipient"] == recipient {
+				docs = append(docs, doc)
+			}
+		}
+		require.NoError(t, scanner.Err())
+		return docs
+	}
+
+	// Only the last three downloads are since the 3rd; read them two at a time
+	first := export("since=2026-01-03&limit=2")
+	require.Len(t, first, 2)
+	assert.Equal(t, map[string]interface{}{"$date": "2026-01-03T00:00:00Z"}, first[0]["downloaded_at"])
+
+	cursor := first[1]["_id"].(map[string]interface{})["$oid"].(string)
+	rest := export("since=2026-01-03&cursor=" + cursor)
+	require.Len(t, rest, 1)
+	assert.Equal(t, map[string]interface{}{"$date": "2026-01-05T00:00:00Z"}, rest[0]["downloaded_at"])
+
+	apiKey := map[string]string{"X-API-Key": e2eAPIToken}
+	resp, body := e2eRequest(t, ip, "GET", "/api/v1/admin/export/settings", nil, apiKey)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "UNKNOWN_COLLECTION", body["code"])
+
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/admin/export/resume_downloads?format=xml", nil, apiKey)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "INVALID_FORMAT", body["code"])
+}
+
+func TestE2ETrafficAnalytics(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	views := []interface{}{
+		models.PageView{Visitor: "a", Endpoint: "GET /api/v1/content", Country: "BR", Referrer: "news.example.com", Timestamp: start},
+		models.PageView{Visitor: "a", Endpoint: "GET /api/v1/content/projects", Country: "BR", Timestamp: start.Add(time.Hour)},
+		models.PageView{Visitor: "b", Endpoint: "GET /api/v1/content", Country: "US", Timestamp: start.Add(2 * time.Hour)},
+		models.PageView{Visitor: "c", Endpoint: "GET /api/v1/content", Timestamp: start.Add(3 * time.Hour)},
+		// Outside the range
+		models.PageView{Visitor: "d", Endpoint: "GET /api/v1/content", Timestamp: start.AddDate(0, 0, 2)},
+	}
+	_, err := database.Database.Collection("page_views").InsertMany(ctx, views)
+	require.NoError(t, err)
+
+	ip := newE2EClientIP()
+	resp, body := e2eRequest(t, ip, "GET", "/api/v1/analytics/traffic?since=2025-03-01&until=2025-03-02", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	traffic := body["data"].(map[string]interface{})
+	assert.Equal(t, float64(4), traffic["page_views"])
+	assert.Equal(t, float64(3), traffic["unique_visitors"])
+	assert.Equal(t, map[string]interface{}{"BR": float64(2), "US": float64(1), "unknown": float64(1)}, traffic["geographic_data"])
+
+	top := traffic["top_endpoints"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "GET /api/v1/content", top["endpoint"])
+	assert.Equal(t, float64(3), top["hits"])
+
+	resp, body = e2eRequest(t, ip, "GET", "/api/v1/analytics/traffic?range=1y", nil, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "INVALID_RANGE", body["code"])
+}
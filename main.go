@@ -8,9 +8,13 @@ import (
 	"os"
 	"os/signal"
 	"portfolio-backend/config"
+	"portfolio-backend/controllers"
 	"portfolio-backend/database"
+	"portfolio-backend/grpcserver"
+	"portfolio-backend/middleware"
 	"portfolio-backend/routes"
 	"portfolio-backend/services"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -30,31 +34,20 @@ func main() {
 	// Set Gin mode
 	gin.SetMode(config.AppConfig.GinMode)
 
-	// Initialize database connection
-	if err := database.Connect(); err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
-	}
-
-	// Initialize default content
-	contentService := services.NewContentService()
-	if err := contentService.InitializeDefaultContent(context.Background()); err != nil {
-		log.Printf("Warning: Failed to initialize default content: %v", err)
-	}
-
-	// Start cache cleanup service
-	cacheService := services.NewCacheService()
-	cacheService.StartCleanupJob()
-
-	// Create Gin engine
-	r := gin.New()
+	// Serve probes immediately; the full router is swapped in once
+	// dependencies are available
+	bootRouter := gin.New()
+	routes.SetupBootRoutes(bootRouter)
 
-	// Setup routes
-	routes.SetupRoutes(r)
+	var activeHandler atomic.Pointer[gin.Engine]
+	activeHandler.Store(bootRouter)
 
 	// Create HTTP server
 	srv := &http.Server{
-		Addr:         ":" + config.AppConfig.Port,
-		Handler:      r,
+		Addr: ":" + config.AppConfig.Port,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			activeHandler.Load().ServeHTTP(w, req)
+		}),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  120 * time.Second,
@@ -73,18 +66,75 @@ func main() {
 		}
 	}()
 
+	// Wait for dependencies, then enable the API
+	go func() {
+		if err := database.ConnectWithRetry(config.AppConfig.StartupTimeout, config.AppConfig.StartupRetryInterval); err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+
+		// Initialize default content
+		contentService := services.NewContentService()
+		if err := contentService.InitializeDefaultContent(context.Background()); err != nil {
+			log.Printf("Warning: Failed to initialize default content: %v", err)
+		}
+
+		// Start cache cleanup service
+		cacheService := services.NewCacheService()
+		cacheService.StartCleanupJob()
+
+		// Detect optional features (GitHub token dependent endpoints)
+		services.DetectCapabilities(context.Background())
+
+		// Keep the GitHub profile README in sync (when PROFILE_README_INTERVAL is set)
+		services.NewReadmeService().StartScheduler()
+		services.NewDigestService().StartScheduler()
+		services.NewTelegramService().StartBot()
+		services.StartNotifier()
+		services.NewGitHubService().StartEnrichment()
+		services.NewGitHubService().StartSyncScheduler()
+		services.NewGitHubService().StartTrafficCollection()
+		services.NewAvailabilityService().StartCalendarSync()
+		services.NewVisitorService().StartTracking()
+		services.NewJobService().StartWorkers()
+
+		// Create Gin engine
+		r := gin.New()
+
+		// Setup routes
+		routes.SetupRoutes(r)
+
+		// Apply rate limit tiers changed at runtime (after RateLimit loaded the env defaults)
+		middleware.WatchRateLimitTiers()
+		middleware.WatchSiteDomains()
+		middleware.WatchAnalyticsSampling()
+
+		if err := grpcserver.Start(); err != nil {
+			log.Printf("❌ Failed to start gRPC server: %v", err)
+		}
+
+		activeHandler.Store(r)
+		controllers.SetReady(true)
+		log.Println("✅ Dependencies ready, serving API")
+
+		warmUp()
+	}()
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("🔄 Shutting down server...")
+	controllers.SetReady(false)
 
 	// Create a deadline for shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Shutdown server gracefully
+	// Shutdown servers gracefully
+	grpcserver.Stop()
+	services.StopJobWorkers()
+	services.CloseLiveSubscriptions()
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Printf("❌ Server forced to shutdown: %v", err)
 	}
@@ -97,6 +147,36 @@ func main() {
 	log.Println("✅ Server exited")
 }
 
+// warmUp fills the content cache and, with READINESS_WAIT_SYNC, makes sure
+// the owner's GitHub data has been synced, then lets /readiness pass. A
+// failed warm is retried; a failed sync is not, so an outage at GitHub
+// cannot keep the instance out of rotation.
+func warmUp() {
+	contentService := services.NewContentService()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := contentService.WarmCache(ctx)
+		cancel()
+		if err == nil {
+			break
+		}
+		log.Printf("Cache warm failed, retrying: %v", err)
+		time.Sleep(config.AppConfig.StartupRetryInterval)
+	}
+	controllers.SetCacheWarmed()
+	log.Println("🔥 Content cache warm")
+
+	if config.AppConfig.ReadinessWaitSync {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		username := services.NewSettingsService().GetOwner(ctx)
+		if err := services.NewGitHubService().EnsureSynced(ctx, username); err != nil {
+			log.Printf("Initial GitHub sync of %s failed: %v", username, err)
+		}
+		cancel()
+	}
+	controllers.SetFirstSynced()
+}
+
 // init function for startup tasks
 func init() {
 	// Set log format
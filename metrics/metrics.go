@@ -0,0 +1,220 @@
+// Package metrics keeps counters, gauges and histograms in memory and writes
+// them in the Prometheus text exposition format, so they can be scraped
+// without pulling in the Prometheus client library.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the content type of the text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets suit request latencies in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var (
+	registryMutex sync.Mutex
+	registry      []*family
+)
+
+// family is a metric name with one series per combination of label values
+type family struct {
+	name    string
+	help    string
+	kind    string // counter, gauge or histogram
+	labels  []string
+	buckets []float64
+
+	mutex  sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64  // counters and gauges
+	counts      []uint64 // histograms, per bucket (not cumulative)
+	count       uint64
+	sum         float64
+}
+
+func register(name, help, kind string, buckets []float64, labels []string) *family {
+	f := &family{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+
+	registryMutex.Lock()
+	registry = append(registry, f)
+	registryMutex.Unlock()
+	return f
+}
+
+// with returns the series for labelValues; the family must be locked
+func (f *family) with(labelValues []string) *series {
+	if len(labelValues) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s takes %d label values, got %d", f.name, len(f.labels), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+		if f.kind == "histogram" {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// CounterVec counts events, partitioned by labels
+type CounterVec struct{ f *family }
+
+// NewCounterVec registers a counter. By convention its name ends in _total.
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{register(name, help, "counter", nil, labels)}
+}
+
+// Inc adds one to the series for labelValues
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds delta, which must not be negative, to the series for labelValues
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	c.f.mutex.Lock()
+	c.f.with(labelValues).value += delta
+	c.f.mutex.Unlock()
+}
+
+// GaugeVec holds values that go up and down, partitioned by labels
+type GaugeVec struct{ f *family }
+
+// NewGaugeVec registers a gauge
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{register(name, help, "gauge", nil, labels)}
+}
+
+// Set sets the series for labelValues to value
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.f.mutex.Lock()
+	g.f.with(labelValues).value = value
+	g.f.mutex.Unlock()
+}
+
+// HistogramVec counts observations in buckets, partitioned by labels
+type HistogramVec struct{ f *family }
+
+// NewHistogramVec registers a histogram with the given upper bounds, in
+// increasing order
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{register(name, help, "histogram", buckets, labels)}
+}
+
+// Observe records value in the series for labelValues
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.f.mutex.Lock()
+	defer h.f.mutex.Unlock()
+
+	s := h.f.with(labelValues)
+	s.count++
+	s.sum += value
+	if i := sort.SearchFloat64s(h.f.buckets, value); i < len(h.f.buckets) {
+		s.counts[i]++
+	}
+}
+
+// WritePrometheus writes every registered metric, in name order
+func WritePrometheus(w io.Writer) error {
+	registryMutex.Lock()
+	families := append([]*family(nil), registry...)
+	registryMutex.Unlock()
+
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	out := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(out)
+	}
+	return out.Flush()
+}
+
+func (f *family) write(out *bufio.Writer) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	fmt.Fprintf(out, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(out, "# TYPE %s %s\n", f.name, f.kind)
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+		labels := f.labelPairs(s.labelValues)
+
+		if f.kind != "histogram" {
+			fmt.Fprintf(out, "%s%s %s\n", f.name, braces(labels), formatFloat(s.value))
+			continue
+		}
+
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += s.counts[i]
+			le := append(labels, `le="`+formatFloat(bound)+`"`)
+			fmt.Fprintf(out, "%s_bucket%s %d\n", f.name, braces(le), cumulative)
+		}
+		fmt.Fprintf(out, "%s_bucket%s %d\n", f.name, braces(append(labels, `le="+Inf"`)), s.count)
+		fmt.Fprintf(out, "%s_sum%s %s\n", f.name, braces(labels), formatFloat(s.sum))
+		fmt.Fprintf(out, "%s_count%s %d\n", f.name, braces(labels), s.count)
+	}
+}
+
+func (f *family) labelPairs(values []string) []string {
+	// Room for the le label of histogram buckets
+	pairs := make([]string, len(values), len(values)+1)
+	for i, value := range values {
+		pairs[i] = f.labels[i] + `="` + escapeLabel(value) + `"`
+	}
+	return pairs
+}
+
+func braces(pairs []string) string {
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(value string) string { return labelEscaper.Replace(value) }
+func escapeHelp(help string) string   { return helpEscaper.Replace(help) }
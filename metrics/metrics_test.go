@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T) string {
+	var out strings.Builder
+	require.NoError(t, WritePrometheus(&out))
+	return out.String()
+}
+
+func TestWritePrometheusCountersAndGauges(t *testing.T) {
+	requests := NewCounterVec("test_requests_total", "Requests served.", "route", "status")
+	requests.Inc("/users/:id", "200")
+	requests.Inc("/users/:id", "200")
+	requests.Add(3, `/say "hi"`, "500")
+
+	remaining := NewGaugeVec("test_remaining", "Calls left.\nPer resource.", "resource")
+	remaining.Set(4999, "core")
+	remaining.Set(4998, "core")
+
+	out := scrape(t)
+	assert.Contains(t, out, `# HELP test_requests_total Requests served.
+# TYPE test_requests_total counter
+test_requests_total{route="/say \"hi\"",status="500"} 3
+test_requests_total{route="/users/:id",status="200"} 2
+`)
+	assert.Contains(t, out, `# HELP test_remaining Calls left.\nPer resource.
+# TYPE test_remaining gauge
+test_remaining{resource="core"} 4998
+`)
+}
+
+func TestWritePrometheusHistogram(t *testing.T) {
+	latency := NewHistogramVec("test_latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+	latency.Observe(0.05, "/a")
+	latency.Observe(0.1, "/a")
+	latency.Observe(0.5, "/a")
+	latency.Observe(3, "/a")
+
+	assert.Contains(t, scrape(t), `# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{route="/a",le="0.1"} 2
+test_latency_seconds_bucket{route="/a",le="1"} 3
+test_latency_seconds_bucket{route="/a",le="+Inf"} 4
+test_latency_seconds_sum{route="/a"} 3.65
+test_latency_seconds_count{route="/a"} 4
+`)
+}
+
+func TestWrongLabelCountPanics(t *testing.T) {
+	counter := NewCounterVec("test_labelled_total", "Labelled.", "route")
+	assert.Panics(t, func() { counter.Inc() })
+}
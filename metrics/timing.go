@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Layers a request spends time in, as timed by Timings
+const (
+	LayerCache  = "cache"
+	LayerDB     = "db"
+	LayerGitHub = "github"
+	LayerRender = "render"
+)
+
+// timingLayers are the layers of the Server-Timing header, in order
+var timingLayers = []string{LayerCache, LayerDB, LayerGitHub, LayerRender}
+
+type timingsKey struct{}
+type timingOwnerKey struct{}
+
+// Timings adds up the time one request spends in each layer
+type Timings struct {
+	mu        sync.Mutex
+	durations map[string]time.Duration
+	calls     map[string]int
+}
+
+// WithTimings starts timing the layers the request of ctx goes through
+func WithTimings(ctx context.Context) (context.Context, *Timings) {
+	timings := &Timings{durations: make(map[string]time.Duration), calls: make(map[string]int)}
+	return context.WithValue(ctx, timingsKey{}, timings), timings
+}
+
+// TimingsFrom returns the timings of the request of ctx, nil when it is not
+// timed
+func TimingsFrom(ctx context.Context) *Timings {
+	timings, _ := ctx.Value(timingsKey{}).(*Timings)
+	return timings
+}
+
+// WithinLayer marks ctx as used by layer, which times itself: database
+// commands sent with it count towards layer rather than db, so the cache
+// backend's own storage is not counted twice
+func WithinLayer(ctx context.Context, layer string) context.Context {
+	if _, ok := ctx.Value(timingsKey{}).(*Timings); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, timingOwnerKey{}, layer)
+}
+
+// ObserveLayer adds a call of duration to layer, when ctx is timed
+func ObserveLayer(ctx context.Context, layer string, duration time.Duration) {
+	timings, ok := ctx.Value(timingsKey{}).(*Timings)
+	if !ok {
+		return
+	}
+	if owner, ok := ctx.Value(timingOwnerKey{}).(string); ok && owner != layer {
+		return
+	}
+
+	timings.mu.Lock()
+	defer timings.mu.Unlock()
+	timings.durations[layer] += duration
+	timings.calls[layer]++
+}
+
+// ServerTiming formats the timings as a Server-Timing header value, with
+// total the time spent on the whole request, e.g.
+// `cache;dur=0.4;desc="2 calls", db;dur=12.1;desc="3 calls", github;dur=0.0;desc="0 calls", render;dur=0.2;desc="1 calls", total;dur=15.2`
+func (t *Timings) ServerTiming(total time.Duration) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	metrics := make([]string, 0, len(timingLayers)+1)
+	for _, layer := range timingLayers {
+		metrics = append(metrics, fmt.Sprintf(`%s;dur=%s;desc="%d calls"`, layer, milliseconds(t.durations[layer]), t.calls[layer]))
+	}
+	metrics = append(metrics, "total;dur="+milliseconds(total))
+	return strings.Join(metrics, ", ")
+}
+
+func milliseconds(duration time.Duration) string {
+	return fmt.Sprintf("%.1f", float64(duration.Microseconds())/1000)
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimings(t *testing.T) {
+	// Untimed requests are left alone
+	ObserveLayer(context.Background(), LayerDB, time.Second)
+	assert.Nil(t, TimingsFrom(context.Background()))
+	assert.Equal(t, context.Background(), WithinLayer(context.Background(), LayerCache))
+
+	ctx, timings := WithTimings(context.Background())
+	assert.True(t, TimingsFrom(ctx) == timings)
+	ObserveLayer(ctx, LayerDB, 1500*time.Microsecond)
+	ObserveLayer(ctx, LayerDB, 500*time.Microsecond)
+
+	// The cache layer times its own storage commands
+	cache := WithinLayer(ctx, LayerCache)
+	ObserveLayer(cache, LayerDB, time.Millisecond)
+	ObserveLayer(cache, LayerCache, 3*time.Millisecond)
+
+	assert.Equal(t,
+		`cache;dur=3.0;desc="1 calls", db;dur=2.0;desc="2 calls", github;dur=0.0;desc="0 calls", render;dur=0.0;desc="0 calls", total;dur=10.0`,
+		timings.ServerTiming(10*time.Millisecond))
+}
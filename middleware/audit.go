@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// recordAuditEntry hands audit entries to the background writer, a variable
+// so tests can capture them
+var recordAuditEntry = services.RecordAuditEntry
+
+// Audit records every write made by an authenticated caller in the audit
+// log: who made it, from where, its outcome and, for the write paths that
+// support it, a before and after snapshot of what changed. Dry runs change
+// nothing and are not recorded.
+func Audit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		ctx := services.WithAudit(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		actor := c.GetString("user_type")
+		if actor == "" || c.FullPath() == "" || dryRunRequested(c) {
+			return
+		}
+
+		var params map[string]string
+		for _, param := range c.Params {
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[param.Key] = param.Value
+		}
+
+		recordAuditEntry(models.AuditEntry{
+			Action:    c.Request.Method + " " + c.FullPath(),
+			Path:      c.Request.URL.Path,
+			Params:    params,
+			Actor:     actor,
+			IP:        getClientIP(c),
+			RequestID: c.GetString("request_id"),
+			Status:    c.Writer.Status(),
+			Changes:   services.AuditChanges(ctx),
+			CreatedAt: time.Now(),
+		})
+	}
+}
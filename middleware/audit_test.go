@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRecordsAuthenticatedWrites(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var entries []models.AuditEntry
+	original := recordAuditEntry
+	recordAuditEntry = func(entry models.AuditEntry) { entries = append(entries, entry) }
+	defer func() { recordAuditEntry = original }()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("request_id", "req-1")
+		c.Next()
+	})
+	r.Use(Audit())
+	authenticated := func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" {
+			c.Set("user_type", "admin")
+		}
+	}
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	r.DELETE("/api/v1/admin/recruiter-tokens/:id", authenticated, ok)
+	r.GET("/api/v1/admin/recruiter-tokens", authenticated, ok)
+
+	request := func(method, path string, headers map[string]string) {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	request(http.MethodDelete, "/api/v1/admin/recruiter-tokens/abc", map[string]string{"Authorization": "Bearer token"})
+	// Not recorded: reads, anonymous callers and dry runs
+	request(http.MethodGet, "/api/v1/admin/recruiter-tokens", map[string]string{"Authorization": "Bearer token"})
+	request(http.MethodDelete, "/api/v1/admin/recruiter-tokens/abc", nil)
+	request(http.MethodDelete, "/api/v1/admin/recruiter-tokens/abc", map[string]string{"Authorization": "Bearer token", DryRunHeader: "true"})
+
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "DELETE /api/v1/admin/recruiter-tokens/:id", entry.Action)
+	assert.Equal(t, "/api/v1/admin/recruiter-tokens/abc", entry.Path)
+	assert.Equal(t, map[string]string{"id": "abc"}, entry.Params)
+	assert.Equal(t, "admin", entry.Actor)
+	assert.Equal(t, "203.0.113.7", entry.IP)
+	assert.Equal(t, "req-1", entry.RequestID)
+	assert.Equal(t, http.StatusNoContent, entry.Status)
+}
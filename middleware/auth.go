@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"portfolio-backend/config"
 	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
 	"strings"
 	"time"
 
@@ -14,60 +17,67 @@ import (
 // Auth middleware for protecting write endpoints
 func Auth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, models.ErrorResponse{
-				Success:   false,
-				Error:     "Authorization header is required",
-				Code:      "MISSING_AUTH_HEADER",
-				Timestamp: time.Now(),
-				RequestID: c.GetString("request_id"),
-			})
+		if !authenticate(c) {
 			c.Abort()
 			return
 		}
+		c.Next()
+	}
+}
 
-		// Check for Bearer token
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, models.ErrorResponse{
-				Success:   false,
-				Error:     "Invalid authorization header format. Use 'Bearer <token>'",
-				Code:      "INVALID_AUTH_FORMAT",
-				Timestamp: time.Now(),
-				RequestID: c.GetString("request_id"),
-			})
-			c.Abort()
-			return
-		}
+// authenticate checks the bearer token of the request, setting user_type
+// and user_id. It answers 401 and returns false when the token is missing or
+// invalid.
+func authenticate(c *gin.Context) bool {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		utils.JSON(c, http.StatusUnauthorized, models.ErrorResponse{
+			Success:   false,
+			Error:     "Authorization header is required",
+			Code:      "MISSING_AUTH_HEADER",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return false
+	}
 
-		token := tokenParts[1]
+	// Check for Bearer token
+	tokenParts := strings.Split(authHeader, " ")
+	if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+		utils.JSON(c, http.StatusUnauthorized, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid authorization header format. Use 'Bearer <token>'",
+			Code:      "INVALID_AUTH_FORMAT",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return false
+	}
 
-		// Simple API token check (for admin operations)
-		if token == config.AppConfig.APIToken {
-			c.Set("user_type", "admin")
-			c.Set("user_id", "admin")
-			c.Next()
-			return
-		}
+	token := tokenParts[1]
 
-		// JWT token validation
-		if err := validateJWT(token); err != nil {
-			c.JSON(http.StatusUnauthorized, models.ErrorResponse{
-				Success:   false,
-				Error:     "Invalid or expired token",
-				Code:      "INVALID_TOKEN",
-				Details:   err.Error(),
-				Timestamp: time.Now(),
-				RequestID: c.GetString("request_id"),
-			})
-			c.Abort()
-			return
-		}
+	// Simple API token check (for admin operations)
+	if token == config.AppConfig.APIToken {
+		c.Set("user_type", "admin")
+		c.Set("user_id", "admin")
+		return true
+	}
 
-		c.Set("user_type", "user")
-		c.Next()
+	// JWT token validation
+	if err := validateJWT(token); err != nil {
+		utils.JSON(c, http.StatusUnauthorized, models.ErrorResponse{
+			Success:   false,
+			Error:     "Invalid or expired token",
+			Code:      "INVALID_TOKEN",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		return false
 	}
+
+	c.Set("user_type", "user")
+	return true
 }
 
 // Optional auth middleware - doesn't fail if no token provided
@@ -149,7 +159,7 @@ func APIKey() gin.HandlerFunc {
 		}
 
 		if apiKey == "" {
-			c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			utils.JSON(c, http.StatusUnauthorized, models.ErrorResponse{
 				Success:   false,
 				Error:     "API key is required",
 				Code:      "MISSING_API_KEY",
@@ -161,7 +171,7 @@ func APIKey() gin.HandlerFunc {
 		}
 
 		if apiKey != config.AppConfig.APIToken {
-			c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			utils.JSON(c, http.StatusUnauthorized, models.ErrorResponse{
 				Success:   false,
 				Error:     "Invalid API key",
 				Code:      "INVALID_API_KEY",
@@ -175,4 +185,48 @@ func APIKey() gin.HandlerFunc {
 		c.Set("user_type", "api")
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+// RecruiterToken admits requests carrying a valid recruiter token, sent in the
+// X-Recruiter-Token header or the recruiter_token query parameter so it can
+// be shared as a link. Every admitted request is counted on the token.
+func RecruiterToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader("X-Recruiter-Token")
+		if token == "" {
+			token = c.Query("recruiter_token")
+		}
+
+		if token == "" {
+			utils.JSON(c, http.StatusUnauthorized, models.ErrorResponse{
+				Success:   false,
+				Error:     "Recruiter token is required",
+				Code:      "MISSING_RECRUITER_TOKEN",
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			c.Abort()
+			return
+		}
+
+		recruiter, err := services.NewRecruiterService().Authenticate(c.Request.Context(), token, getClientIP(c))
+		if err != nil {
+			status, message, code := http.StatusInternalServerError, "Failed to check recruiter token", ""
+			if errors.Is(err, services.ErrInvalidRecruiterToken) {
+				status, message, code = http.StatusUnauthorized, "Invalid, expired or revoked recruiter token", "INVALID_RECRUITER_TOKEN"
+			}
+			utils.JSON(c, status, models.ErrorResponse{
+				Success:   false,
+				Error:     message,
+				Code:      code,
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set("user_type", "recruiter")
+		c.Set("recruiter", recruiter.Label)
+		c.Next()
+	}
+}
@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheStatusHeader tells whether a response came from the route cache
+const CacheStatusHeader = "X-Cache"
+
+// CacheKeyFunc keys the cached response of a request
+type CacheKeyFunc func(c *gin.Context) string
+
+// CacheKey keys responses by path and query under tag, one of the
+// services.ResponseTag* values or a narrower "tag:..." such as
+// "github:octocat", so the events of that data drop them
+func CacheKey(tag string) CacheKeyFunc {
+	return func(c *gin.Context) string {
+		return services.ResponseCacheKey(tag, c.Request)
+	}
+}
+
+// SiteCacheKey keys responses under tag, the site the request was resolved
+// to (its mapped domain, or "default") and the scheme, for routes whose
+// response depends on the domain they are served on. Unmapped hosts share
+// the default entry, so made-up Host headers add no entries.
+func SiteCacheKey(tag string) CacheKeyFunc {
+	return func(c *gin.Context) string {
+		site := "default"
+		if domain := services.SiteFromContext(c.Request.Context()); domain != nil {
+			site = domain.Domain
+		}
+		return services.ResponseCacheKey(tag+":"+site+":"+RequestScheme(c), c.Request)
+	}
+}
+
+// RequestScheme returns the scheme the client used, "https" behind a proxy
+// that terminated TLS and set X-Forwarded-Proto
+func RequestScheme(c *gin.Context) string {
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		return "https"
+	}
+	return "http"
+}
+
+// OwnerCacheKey keys responses about the portfolio owner of the requested
+// domain under github:<owner>, so each site is cached apart and its syncs
+// drop them
+func OwnerCacheKey() CacheKeyFunc {
+	return func(c *gin.Context) string {
+		owner := services.NewSettingsService().GetOwner(c.Request.Context())
+		return services.ResponseCacheKey(services.ResponseTagGitHub+":"+strings.ToLower(owner), c.Request)
+	}
+}
+
+// UsernameCacheKey keys responses about the :username of the route under
+// github:<username>, so its syncs drop them
+func UsernameCacheKey() CacheKeyFunc {
+	return func(c *gin.Context) string {
+		return services.ResponseCacheKey(services.ResponseTagGitHub+":"+strings.ToLower(c.Param("username")), c.Request)
+	}
+}
+
+// cacheWriter keeps a copy of the response it passes through
+type cacheWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *cacheWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Cache serves GET requests from the cache backend for up to ttl, so
+// endpoints need no cache code of their own. The first successful JSON (or
+// export file) response for a key is stored whole, with the headers the
+// handlers set, and replayed with the request_id and timestamp of each later
+// request.
+// Entries are dropped early when an event about their data is published
+// (see services.ResponseCacheKey).
+func Cache(ttl time.Duration, key CacheKeyFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet || ttl <= 0 {
+			c.Next()
+			return
+		}
+
+		cacheService := services.NewCacheService()
+		cacheKey := key(c)
+		bypassed := services.CacheBypassed(c.Request.Context())
+		if cached, err := cacheService.GetResponse(c.Request.Context(), cacheKey); err == nil {
+			for name, values := range cached.Header {
+				c.Writer.Header()[name] = values
+			}
+			c.Header(CacheStatusHeader, "HIT")
+			c.Data(cached.Status, http.Header(cached.Header).Get("Content-Type"), refreshEnvelope(cached.Body, c.GetString("request_id")))
+			c.Abort()
+			return
+		}
+
+		before := c.Writer.Header().Clone()
+		writer := &cacheWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		if bypassed {
+			c.Header(CacheStatusHeader, "BYPASS")
+		} else {
+			c.Header(CacheStatusHeader, "MISS")
+		}
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if writer.Status() != http.StatusOK || !cacheableType(writer.Header().Get("Content-Type")) {
+			return
+		}
+
+		// Only the headers set by the handlers belong to the response
+		header := make(map[string][]string)
+		for name, values := range writer.Header() {
+			if name != CacheStatusHeader && !reflect.DeepEqual(before[name], values) {
+				header[name] = values
+			}
+		}
+		response := &models.CachedResponse{Status: http.StatusOK, Header: header, Body: writer.body.Bytes()}
+		if err := cacheService.SetResponse(c.Request.Context(), cacheKey, response, ttl); err != nil {
+			log.Printf("Failed to cache response %s: %v", cacheKey, err)
+		}
+	}
+}
+
+// cacheableType reports whether responses of contentType are cached
+func cacheableType(contentType string) bool {
+	for _, cacheable := range []string{"application/json", "application/pdf", "text/vcard", "text/calendar", "image/", "application/rss+xml", "application/atom+xml", "application/xml", "text/plain"} {
+		if strings.HasPrefix(contentType, cacheable) {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshEnvelope gives a cached API response the request_id and timestamp
+// of the request it now answers
+func refreshEnvelope(body []byte, requestID string) []byte {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return body
+	}
+
+	if _, ok := envelope["timestamp"]; ok {
+		envelope["timestamp"], _ = json.Marshal(time.Now())
+	}
+	if _, ok := envelope["request_id"]; ok {
+		envelope["request_id"], _ = json.Marshal(requestID)
+	}
+
+	refreshed, err := json.Marshal(envelope)
+	if err != nil {
+		return body
+	}
+	return refreshed
+}
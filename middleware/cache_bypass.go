@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheBypassHeader asks for a response built without any cache, for
+// debugging staleness and latency
+const CacheBypassHeader = "X-Bypass-Cache"
+
+// CacheBypass serves requests sent with X-Bypass-Cache: true by the admin
+// (the API token as X-API-Key or bearer token) without reading any cache:
+// the route cache, the cache backend and the stored GitHub responses are
+// all skipped, while fresh results are still written back. The response
+// reports where its time went in the Server-Timing header (see
+// ServerTiming), whether SERVER_TIMING is on or not. Anyone else sending
+// the header is refused, so caches cannot be bypassed to load the database
+// or spend the GitHub quota.
+func CacheBypass() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		bypass, _ := strconv.ParseBool(c.GetHeader(CacheBypassHeader))
+		if !bypass {
+			c.Next()
+			return
+		}
+
+		if !isAdminRequest(c) {
+			utils.JSON(c, http.StatusForbidden, models.ErrorResponse{
+				Success:   false,
+				Error:     "Cache bypass requires the API token",
+				Code:      "CACHE_BYPASS_FORBIDDEN",
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(services.WithCacheBypass(c.Request.Context()))
+		c.Header(CacheBypassHeader, "true")
+
+		// Timed even with SERVER_TIMING off
+		timeRequest(c)
+	}
+}
+
+// isAdminRequest reports whether the request carries the API token, as
+// X-API-Key or as a bearer token
+func isAdminRequest(c *gin.Context) bool {
+	token := config.AppConfig.APIToken
+	if token == "" {
+		return false
+	}
+	return c.GetHeader("X-API-Key") == token || c.GetHeader("Authorization") == "Bearer "+token
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"portfolio-backend/metrics"
+	"portfolio-backend/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheBypass(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig = &config.Config{APIToken: "secret"} // timed without SERVER_TIMING
+
+	r := gin.New()
+	r.Use(CacheBypass())
+	r.GET("/data", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		metrics.ObserveLayer(ctx, metrics.LayerGitHub, 120*time.Millisecond)
+		metrics.ObserveLayer(metrics.WithinLayer(ctx, metrics.LayerCache), metrics.LayerDB, time.Second)
+		c.JSON(http.StatusOK, gin.H{"bypassed": services.CacheBypassed(ctx)})
+	})
+
+	request := func(headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/data", nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request(nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"bypassed":false`)
+	assert.Empty(t, w.Header().Get(ServerTimingHeader))
+
+	for _, headers := range []map[string]string{
+		{CacheBypassHeader: "true", "X-API-Key": "secret"},
+		{CacheBypassHeader: "true", "Authorization": "Bearer secret"},
+	} {
+		w = request(headers)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"bypassed":true`)
+
+		timing := w.Header().Get(ServerTimingHeader)
+		assert.True(t, strings.HasPrefix(timing, `cache;dur=0.0;desc="0 calls", db;dur=0.0;desc="0 calls", github;dur=120.0;desc="1 calls", render;dur=`), timing)
+	}
+
+	w = request(map[string]string{CacheBypassHeader: "true", "X-API-Key": "wrong"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "CACHE_BYPASS_FORBIDDEN")
+}
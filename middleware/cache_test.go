@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshEnvelope(t *testing.T) {
+	cached := []byte(`{"success":true,"data":{"stars":10},"timestamp":"2025-01-01T00:00:00Z","request_id":"first"}`)
+
+	var envelope map[string]interface{}
+	require.NoError(t, json.Unmarshal(refreshEnvelope(cached, "second"), &envelope))
+	assert.Equal(t, "second", envelope["request_id"])
+	assert.NotEqual(t, "2025-01-01T00:00:00Z", envelope["timestamp"])
+	assert.Equal(t, map[string]interface{}{"stars": float64(10)}, envelope["data"])
+
+	// Bodies that are not an envelope are replayed as they were
+	assert.Equal(t, `[1,2]`, string(refreshEnvelope([]byte(`[1,2]`), "second")))
+	assert.NotContains(t, string(refreshEnvelope([]byte(`{"data":1}`), "second")), "request_id")
+}
+
+func TestSiteCacheKey(t *testing.T) {
+	key := func(site *models.SiteDomain, host, proto string) string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil)
+		c.Request.Host = host
+		if proto != "" {
+			c.Request.Header.Set("X-Forwarded-Proto", proto)
+		}
+		if site != nil {
+			c.Request = c.Request.WithContext(services.WithSite(c.Request.Context(), site))
+		}
+		return SiteCacheKey("content")(c)
+	}
+	alice := &models.SiteDomain{Domain: "alice.dev"}
+
+	assert.Equal(t, "response:content:alice.dev:https:/sitemap.xml?", key(alice, "Alice.dev:443", "https"))
+	assert.NotEqual(t, key(alice, "alice.dev", "https"), key(&models.SiteDomain{Domain: "bob.dev"}, "bob.dev", "https"))
+	assert.NotEqual(t, key(alice, "alice.dev", "https"), key(alice, "alice.dev", ""))
+
+	// Unmapped hosts share the default entry
+	assert.Equal(t, "response:content:default:http:/sitemap.xml?", key(nil, "spoofed.example", ""))
+	assert.Equal(t, key(nil, "spoofed.example", ""), key(nil, "api.example.com", ""))
+}
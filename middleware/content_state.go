@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentState hands ?state=draft requests to preview instead of the rest of
+// the chain, which serves published content and lets it be cached publicly.
+// Previews require authentication and are never cached.
+func ContentState(preview gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Query("state") {
+		case "", models.ContentStatePublished:
+			c.Next()
+			return
+		case models.ContentStateDraft:
+		default:
+			utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+				Success:   false,
+				Error:     "Invalid state, expected published or draft",
+				Details:   c.Query("state"),
+				Code:      "INVALID_STATE",
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			c.Abort()
+			return
+		}
+
+		if authenticate(c) {
+			c.Header("Cache-Control", "private, no-store")
+			preview(c)
+		}
+		c.Abort()
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentState(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig = &config.Config{APIToken: "secret", JWTSecret: "jwt"}
+
+	r := gin.New()
+	r.GET("/content", ContentState(func(c *gin.Context) {
+		c.String(http.StatusOK, "draft")
+	}), ETag(0), func(c *gin.Context) {
+		c.String(http.StatusOK, "published")
+	})
+
+	serve := func(query, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/content"+query, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	assert.Equal(t, "published", serve("", "").Body.String())
+	assert.Equal(t, "published", serve("?state=published", "").Body.String())
+	assert.Equal(t, http.StatusBadRequest, serve("?state=archived", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("?state=draft", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("?state=draft", "wrong").Code)
+
+	preview := serve("?state=draft", "secret")
+	assert.Equal(t, http.StatusOK, preview.Code)
+	assert.Equal(t, "draft", preview.Body.String())
+	assert.Equal(t, "private, no-store", preview.Header().Get("Cache-Control"))
+}
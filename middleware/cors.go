@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"portfolio-backend/config"
+	"portfolio-backend/services"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -11,27 +12,14 @@ import (
 func CORS() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
-		
-		// Parse allowed origins from config
-		allowedOrigins := strings.Split(config.AppConfig.CORSOrigins, ",")
-		
-		// Check if origin is allowed
-		isAllowed := false
-		for _, allowedOrigin := range allowedOrigins {
-			allowedOrigin = strings.TrimSpace(allowedOrigin)
-			if allowedOrigin == "*" || allowedOrigin == origin {
-				isAllowed = true
-				break
-			}
-		}
 
-		if isAllowed {
+		if OriginAllowed(c, origin) {
 			c.Header("Access-Control-Allow-Origin", origin)
 		}
 
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key, X-Request-ID")
-		c.Header("Access-Control-Expose-Headers", "Content-Length, X-Request-ID, X-Rate-Limit-Remaining, X-Rate-Limit-Reset")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key, X-Recruiter-Token, X-GitHub-Token, X-Dry-Run, X-Bypass-Cache, X-Request-ID, If-None-Match")
+		c.Header("Access-Control-Expose-Headers", "Content-Length, ETag, X-Request-ID, X-Rate-Limit-Remaining, X-Rate-Limit-Reset")
 		c.Header("Access-Control-Allow-Credentials", "true")
 		c.Header("Access-Control-Max-Age", "86400") // 24 hours
 
@@ -45,6 +33,34 @@ func CORS() gin.HandlerFunc {
 	}
 }
 
+// OriginAllowed reports whether origin is allowed by CORS_ORIGINS or by
+// the domain the request was sent to
+func OriginAllowed(c *gin.Context, origin string) bool {
+	// Parse allowed origins from config, plus those of the requested domain
+	allowedOrigins := strings.Split(config.AppConfig.CORSOrigins, ",")
+	if site := services.SiteFromContext(c.Request.Context()); site != nil {
+		allowedOrigins = append(allowedOrigins, site.CORSOrigins...)
+	}
+
+	for _, allowedOrigin := range allowedOrigins {
+		allowedOrigin = strings.TrimSpace(allowedOrigin)
+		if allowedOrigin == "*" || allowedOrigin == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// PublicCORS opens a route to every origin, for data meant to be embedded by
+// third parties. Credentials are never sent along with such requests.
+func PublicCORS() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Access-Control-Allow-Origin", "*")
+		c.Writer.Header().Del("Access-Control-Allow-Credentials")
+		c.Next()
+	}
+}
+
 func CORSMiddleware() gin.HandlerFunc {
 	return CORS()
 }
\ No newline at end of file
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"reflect"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DryRunHeader asks a mutation to report what it would change without
+// changing anything
+const DryRunHeader = "X-Dry-Run"
+
+// dryRunName identifies the DryRun middleware in a route's handler chain
+var dryRunName = runtime.FuncForPC(reflect.ValueOf(dryRun).Pointer()).Name()
+
+// dryRunWriter holds the response of a dry run back, to wrap it in the plan
+type dryRunWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func (w *dryRunWriter) WriteHeader(code int) { w.status = code }
+func (w *dryRunWriter) WriteHeaderNow()      {}
+func (w *dryRunWriter) Status() int          { return w.status }
+func (w *dryRunWriter) Size() int            { return w.body.Len() }
+func (w *dryRunWriter) Written() bool        { return w.body.Len() > 0 }
+
+func (w *dryRunWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *dryRunWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// dryRunRequested reports whether a mutation was sent with X-Dry-Run: true
+func dryRunRequested(c *gin.Context) bool {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	dryRun, _ := strconv.ParseBool(c.GetHeader(DryRunHeader))
+	return dryRun
+}
+
+// DryRun lets a mutation be previewed with X-Dry-Run: true. The request is
+// validated and handled as usual, but writes are recorded instead of made;
+// a successful response is replaced by the plan: the changed fields,
+// version bumps, cache invalidations and side effects, along with the
+// response the request would have received. Failures are sent unchanged.
+func DryRun() gin.HandlerFunc {
+	return dryRun
+}
+
+func dryRun(c *gin.Context) {
+	if !dryRunRequested(c) {
+		c.Next()
+		return
+	}
+
+	ctx := services.WithDryRun(c.Request.Context())
+	c.Request = c.Request.WithContext(ctx)
+
+	original := c.Writer
+	writer := &dryRunWriter{ResponseWriter: original, status: http.StatusOK}
+	c.Writer = writer
+	c.Next()
+	c.Writer = original
+	c.Header(DryRunHeader, "true")
+
+	if writer.status < 200 || writer.status >= 300 {
+		original.WriteHeader(writer.status)
+		original.WriteHeaderNow()
+		original.Write(writer.body.Bytes())
+		return
+	}
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	json.Unmarshal(writer.body.Bytes(), &response)
+
+	utils.JSON(c, http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.DryRunPlan{
+			DryRun:   true,
+			Changes:  services.DryRunChanges(ctx),
+			Response: response.Data,
+		},
+		Message:   "Dry run, nothing was changed",
+		Timestamp: time.Now(),
+		RequestID: c.GetString("request_id"),
+		Version:   models.APIVersion,
+	})
+}
+
+// RejectDryRun refuses mutations sent with X-Dry-Run: true by routes that
+// cannot preview them, so a script expecting a preview never makes changes
+func RejectDryRun() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if dryRunRequested(c) && !dryRunSupported(c) {
+			utils.JSON(c, http.StatusBadRequest, models.ErrorResponse{
+				Success:   false,
+				Error:     "This endpoint does not support dry runs",
+				Code:      "DRY_RUN_UNSUPPORTED",
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func dryRunSupported(c *gin.Context) bool {
+	for _, name := range c.HandlerNames() {
+		if name == dryRunName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveDryRun(r *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set(DryRunHeader, "true")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestDryRun(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	written := false
+	r := gin.New()
+	r.Use(RejectDryRun())
+	r.PUT("/settings", DryRun(), func(c *gin.Context) {
+		if services.IsDryRun(c.Request.Context()) {
+			c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{"enabled": true}})
+			return
+		}
+		written = true
+		c.Status(http.StatusNoContent)
+	})
+	r.PUT("/invalid", DryRun(), func(c *gin.Context) {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid request body"})
+	})
+	r.DELETE("/settings", func(c *gin.Context) {
+		written = true
+		c.Status(http.StatusNoContent)
+	})
+
+	plan := serveDryRun(r, "PUT", "/settings")
+	assert.Equal(t, http.StatusOK, plan.Code)
+	assert.Equal(t, "true", plan.Header().Get(DryRunHeader))
+	assert.Contains(t, plan.Body.String(), `"dry_run":true`)
+	assert.Contains(t, plan.Body.String(), `"response":{"enabled":true}`)
+	assert.False(t, written)
+
+	invalid := serveDryRun(r, "PUT", "/invalid")
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	assert.Contains(t, invalid.Body.String(), "Invalid request body")
+
+	rejected := serveDryRun(r, "DELETE", "/settings")
+	assert.Equal(t, http.StatusBadRequest, rejected.Code)
+	assert.Contains(t, rejected.Body.String(), "DRY_RUN_UNSUPPORTED")
+	assert.False(t, written)
+}
+
+func TestDryRunNotRequested(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(RejectDryRun())
+	r.DELETE("/settings", func(c *gin.Context) {
+		c.JSON(http.StatusOK, models.APIResponse{Success: true})
+	})
+
+	req := httptest.NewRequest("DELETE", "/settings", nil)
+	req.Header.Set(DryRunHeader, "false")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveConditional(r *gin.Engine, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestETagNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	stars := 10
+	r := gin.New()
+	r.Use(ETag(6 * time.Hour))
+	r.GET("/stats", func(c *gin.Context) {
+		// The envelope timestamp differs on every response
+		c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{"stars": stars}, "timestamp": time.Now().UnixNano()})
+	})
+
+	first := serveConditional(r, "/stats", "")
+	require.Equal(t, http.StatusOK, first.Code)
+	tag := first.Header().Get("ETag")
+	require.NotEmpty(t, tag)
+	assert.NotContains(t, tag, "W/")
+	assert.Equal(t, "public, max-age=21600", first.Header().Get("Cache-Control"))
+
+	again := serveConditional(r, "/stats", tag)
+	assert.Equal(t, http.StatusNotModified, again.Code)
+	assert.Empty(t, again.Body.String())
+	assert.Equal(t, tag, again.Header().Get("ETag"))
+
+	// Weak and listed validators match too
+	assert.Equal(t, http.StatusNotModified, serveConditional(r, "/stats", `"other", W/`+tag).Code)
+
+	stars = 11
+	changed := serveConditional(r, "/stats", tag)
+	assert.Equal(t, http.StatusOK, changed.Code)
+	assert.NotEqual(t, tag, changed.Header().Get("ETag"))
+	assert.Contains(t, changed.Body.String(), `"stars":11`)
+}
+
+func TestETagSkipsErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(ETag(time.Hour))
+	r.GET("/missing", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false})
+	})
+
+	rr := serveConditional(r, "/missing", "*")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Empty(t, rr.Header().Get("ETag"))
+	assert.JSONEq(t, `{"success":false}`, rr.Body.String())
+}
+
+func TestETagKeepsHandlerCacheControl(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(ETag(time.Hour))
+	r.GET("/badge.svg", func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=3600, stale-while-revalidate=86400")
+		c.Data(http.StatusOK, "image/svg+xml; charset=utf-8", []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`))
+	})
+
+	first := serveConditional(r, "/badge.svg", "")
+	tag := first.Header().Get("ETag")
+	require.NotEmpty(t, tag)
+	assert.Equal(t, "public, max-age=3600, stale-while-revalidate=86400", first.Header().Get("Cache-Control"))
+	assert.Equal(t, http.StatusNotModified, serveConditional(r, "/badge.svg", tag).Code)
+}
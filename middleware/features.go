@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireFeature rejects requests for features this deployment can't serve
+// (e.g. GraphQL-backed endpoints without a GitHub token)
+func RequireFeature(feature string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !services.FeatureEnabled(feature) {
+			utils.JSON(c, http.StatusServiceUnavailable, models.ErrorResponse{
+				Success:   false,
+				Error:     "Feature not available on this deployment",
+				Code:      "FEATURE_DISABLED",
+				Details:   "The '" + feature + "' feature requires a configured GitHub token",
+				Timestamp: time.Now(),
+				RequestID: c.GetString("request_id"),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// githubUserAllowed is replaced in tests, which run without MongoDB
+var githubUserAllowed = func(ctx context.Context, username string) bool {
+	return services.NewSettingsService().GitHubUserAllowed(ctx, username)
+}
+
+// AllowedGitHubUser refuses the GitHub endpoints for usernames other than
+// the portfolio accounts and GITHUB_ALLOWED_USERS, so strangers cannot spend
+// the deployment's GitHub quota proxying arbitrary accounts
+func AllowedGitHubUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		username := c.Param("username")
+		if username == "" || githubUserAllowed(c.Request.Context(), username) {
+			c.Next()
+			return
+		}
+
+		utils.JSON(c, http.StatusForbidden, models.ErrorResponse{
+			Success:   false,
+			Error:     "GitHub username not allowed",
+			Code:      "GITHUB_USER_NOT_ALLOWED",
+			Details:   "This deployment only serves the GitHub accounts of its portfolio",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+		c.Abort()
+	}
+}
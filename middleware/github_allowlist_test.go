@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowedGitHubUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var checked []string
+	original := githubUserAllowed
+	githubUserAllowed = func(ctx context.Context, username string) bool {
+		checked = append(checked, username)
+		return strings.EqualFold(username, "alice")
+	}
+	defer func() { githubUserAllowed = original }()
+
+	r := gin.New()
+	r.GET("/profile/:username", AllowedGitHubUser(), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	r.GET("/stats", AllowedGitHubUser(), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	request := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr
+	}
+
+	assert.Equal(t, http.StatusNoContent, request("/profile/Alice").Code)
+
+	rr := request("/profile/bob")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), "GITHUB_USER_NOT_ALLOWED")
+
+	// Routes without a username are not checked
+	assert.Equal(t, http.StatusNoContent, request("/stats").Code)
+	assert.Equal(t, []string{"Alice", "bob"}, checked)
+}
@@ -5,6 +5,8 @@ import (
 	"io"
 	"log"
 	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -61,6 +63,7 @@ func Logger() gin.HandlerFunc {
 			"request_id":    requestID,
 			"method":        c.Request.Method,
 			"path":          c.Request.URL.Path,
+			"route":         routeLabel(c),
 			"query":         c.Request.URL.RawQuery,
 			"status_code":   c.Writer.Status(),
 			"response_time": duration.String(),
@@ -175,12 +178,12 @@ func Recovery() gin.HandlerFunc {
 		
 		log.Printf("PANIC: %v | RequestID: %s | Path: %s", recovered, requestID, c.Request.URL.Path)
 		
-		c.JSON(500, map[string]interface{}{
-			"success":    false,
-			"error":      "Internal server error",
-			"code":       "INTERNAL_ERROR",
-			"timestamp":  time.Now(),
-			"request_id": requestID,
+		utils.JSON(c, 500, models.ErrorResponse{
+			Success:   false,
+			Error:     "Internal server error",
+			Code:      "INTERNAL_ERROR",
+			Timestamp: time.Now(),
+			RequestID: requestID,
 		})
 	})
 }
\ No newline at end of file
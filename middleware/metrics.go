@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"portfolio-backend/config"
+	"portfolio-backend/metrics"
+	"portfolio-backend/models"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that did not match any route, so 404 scans
+// share one series instead of adding one per path
+const unmatchedRoute = "<unmatched>"
+
+// latencySamples is how many recent latencies percentiles are computed over,
+// per endpoint and overall
+const latencySamples = 1024
+
+type endpointMetrics struct {
+	hits      int64
+	errors    int64
+	totalTime time.Duration
+	latencies latencyRing
+}
+
+// latencyRing keeps the last latencySamples latencies, overwriting the oldest
+type latencyRing struct {
+	samples [latencySamples]time.Duration
+	next    int
+	full    bool
+}
+
+func (r *latencyRing) add(d time.Duration) {
+	r.samples[r.next] = d
+	r.next = (r.next + 1) % latencySamples
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// percentiles returns the nearest-rank p50, p95 and p99 in milliseconds
+func (r *latencyRing) percentiles() (p50, p95, p99 float64) {
+	n := r.next
+	if r.full {
+		n = latencySamples
+	}
+	if n == 0 {
+		return 0, 0, 0
+	}
+
+	sorted := make([]time.Duration, n)
+	copy(sorted, r.samples[:n])
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := func(p int) float64 {
+		return milliseconds(sorted[(p*n+99)/100-1])
+	}
+	return rank(50), rank(95), rank(99)
+}
+
+var (
+	metricsMutex sync.RWMutex
+	endpoints    = make(map[string]*endpointMetrics)
+	allLatencies latencyRing
+)
+
+// Exported on /metrics
+var (
+	httpRequests = metrics.NewCounterVec("http_requests_total",
+		"HTTP requests by method, route template and status.", "method", "route", "status")
+	httpRequestDuration = metrics.NewHistogramVec("http_request_duration_seconds",
+		"HTTP request latency by method and route template.", metrics.DefaultBuckets, "method", "route")
+)
+
+// Metrics records request counts and latency per route template, e.g.
+// "GET /api/v1/github/profile/:username" rather than one entry per username,
+// for the performance analytics and for /metrics
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !config.AppConfig.EnableMetrics {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+		duration := time.Since(start)
+
+		route := routeLabel(c)
+		recordRequest(c.Request.Method+" "+route, c.Writer.Status(), duration)
+		httpRequests.Inc(c.Request.Method, route, strconv.Itoa(c.Writer.Status()))
+		httpRequestDuration.Observe(duration.Seconds(), c.Request.Method, route)
+	}
+}
+
+// routeLabel returns the route template that matched the request
+func routeLabel(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return unmatchedRoute
+}
+
+func recordRequest(endpoint string, status int, duration time.Duration) {
+	metricsMutex.Lock()
+	defer metricsMutex.Unlock()
+
+	metrics, exists := endpoints[endpoint]
+	if !exists {
+		metrics = &endpointMetrics{}
+		endpoints[endpoint] = metrics
+	}
+
+	metrics.hits++
+	metrics.totalTime += duration
+	metrics.latencies.add(duration)
+	allLatencies.add(duration)
+	if status >= 500 {
+		metrics.errors++
+	}
+}
+
+// RequestMetrics returns the totals and per-endpoint stats collected since
+// startup, busiest endpoints first. Percentiles cover the most recent
+// latencySamples requests.
+func RequestMetrics() (models.PerformanceMetrics, []models.EndpointStat) {
+	metricsMutex.RLock()
+	defer metricsMutex.RUnlock()
+
+	var totals models.PerformanceMetrics
+	var totalTime time.Duration
+	var errors int64
+	stats := make([]models.EndpointStat, 0, len(endpoints))
+
+	for endpoint, metrics := range endpoints {
+		totals.TotalRequests += metrics.hits
+		totalTime += metrics.totalTime
+		errors += metrics.errors
+
+		stat := models.EndpointStat{
+			Endpoint:  endpoint,
+			Hits:      int(metrics.hits),
+			Errors:    int(metrics.errors),
+			ErrorRate: float64(metrics.errors) / float64(metrics.hits),
+			AvgTime:   milliseconds(metrics.totalTime) / float64(metrics.hits),
+		}
+		stat.P50Time, stat.P95Time, stat.P99Time = metrics.latencies.percentiles()
+		stats = append(stats, stat)
+	}
+
+	if totals.TotalRequests > 0 {
+		totals.AverageResponseTime = milliseconds(totalTime) / float64(totals.TotalRequests)
+		totals.ErrorRate = float64(errors) / float64(totals.TotalRequests)
+	}
+	totals.P50ResponseTime, totals.P95ResponseTime, totals.P99ResponseTime = allLatencies.percentiles()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Hits != stats[j].Hits {
+			return stats[i].Hits > stats[j].Hits
+		}
+		return stats[i].Endpoint < stats[j].Endpoint
+	})
+
+	return totals, stats
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
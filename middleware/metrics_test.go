@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"portfolio-backend/metrics"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serve(r *gin.Engine, method, path, clientIP string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("X-Forwarded-For", clientIP)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestMetricsUseRouteTemplates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig = &config.Config{EnableMetrics: true}
+
+	metricsMutex.Lock()
+	endpoints = make(map[string]*endpointMetrics)
+	metricsMutex.Unlock()
+
+	r := gin.New()
+	r.Use(Metrics())
+	r.GET("/github/profile/:username", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/fail", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+
+	for _, path := range []string{"/github/profile/alice", "/github/profile/bob", "/fail", "/scan/a", "/scan/b"} {
+		serve(r, "GET", path, "10.0.0.1")
+	}
+
+	totals, stats := RequestMetrics()
+	assert.Equal(t, int64(5), totals.TotalRequests)
+	assert.InDelta(t, 0.2, totals.ErrorRate, 1e-9)
+
+	hits := make(map[string]int)
+	for _, stat := range stats {
+		hits[stat.Endpoint] = stat.Hits
+	}
+	assert.Equal(t, map[string]int{
+		"GET /github/profile/:username": 2,
+		"GET " + unmatchedRoute:         2,
+		"GET /fail":                     1,
+	}, hits)
+	require.Len(t, stats, 3)
+	assert.Equal(t, 2, stats[0].Hits)
+
+	var exported strings.Builder
+	require.NoError(t, metrics.WritePrometheus(&exported))
+	assert.Contains(t, exported.String(), `http_requests_total{method="GET",route="/github/profile/:username",status="200"} 2`)
+	assert.Contains(t, exported.String(), `http_request_duration_seconds_count{method="GET",route="<unmatched>"} 2`)
+}
+
+func TestLatencyRingPercentiles(t *testing.T) {
+	var ring latencyRing
+	p50, p95, p99 := ring.percentiles()
+	assert.Zero(t, p50+p95+p99)
+
+	for i := 1; i <= 100; i++ {
+		ring.add(time.Duration(i) * time.Millisecond)
+	}
+	p50, p95, p99 = ring.percentiles()
+	assert.Equal(t, []float64{50, 95, 99}, []float64{p50, p95, p99})
+
+	// Once full, the oldest samples are overwritten
+	for i := 0; i < latencySamples; i++ {
+		ring.add(time.Second)
+	}
+	p50, _, _ = ring.percentiles()
+	assert.Equal(t, 1000.0, p50)
+}
+
+func TestRequestMetricsPerEndpointErrorRate(t *testing.T) {
+	metricsMutex.Lock()
+	endpoints = make(map[string]*endpointMetrics)
+	allLatencies = latencyRing{}
+	metricsMutex.Unlock()
+
+	recordRequest("GET /a", http.StatusOK, 10*time.Millisecond)
+	recordRequest("GET /a", http.StatusBadGateway, 30*time.Millisecond)
+	recordRequest("GET /b", http.StatusOK, 20*time.Millisecond)
+
+	totals, stats := RequestMetrics()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "GET /a", stats[0].Endpoint)
+	assert.Equal(t, 1, stats[0].Errors)
+	assert.Equal(t, 0.5, stats[0].ErrorRate)
+	assert.Equal(t, 30.0, stats[0].P95Time)
+	assert.Equal(t, 20.0, totals.P50ResponseTime)
+	assert.Equal(t, 30.0, totals.P99ResponseTime)
+}
+
+func TestRateLimitByRouteParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.POST("/sync/:username", CustomRateLimitBy(2, time.Hour, ByRouteParams("username")), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// The bucket is shared by every client syncing the same username
+	assert.Equal(t, http.StatusOK, serve(r, "POST", "/sync/alice", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, serve(r, "POST", "/sync/alice", "10.0.0.2").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve(r, "POST", "/sync/alice", "10.0.0.3").Code)
+
+	// Other usernames have their own budget
+	assert.Equal(t, http.StatusOK, serve(r, "POST", "/sync/bob", "10.0.0.1").Code)
+}
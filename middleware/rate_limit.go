@@ -1,10 +1,14 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"portfolio-backend/config"
 	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,35 +32,56 @@ type RateLimitManager struct {
 
 var rateLimitManager *RateLimitManager
 
+// authRateLimitManager tracks callers that present a JWT or the API token
+var authRateLimitManager *RateLimitManager
+
+// tierRefreshInterval is how often tier changes saved in settings are applied
+const tierRefreshInterval = time.Minute
+
 func init() {
 	rateLimitManager = &RateLimitManager{
 		limiters: make(map[string]*RateLimiter),
 		limit:    100, // Default limit
 		window:   time.Hour, // Default window
 	}
+	authRateLimitManager = &RateLimitManager{
+		limiters: make(map[string]*RateLimiter),
+		limit:    1000,
+		window:   time.Hour,
+	}
 
 	// Start cleanup goroutine
 	go rateLimitManager.cleanup()
+	go authRateLimitManager.cleanup()
 }
 
-// RateLimit middleware with configurable limits
+// RateLimit middleware with separate limits for anonymous and authenticated
+// callers
 func RateLimit() gin.HandlerFunc {
 	// Update rate limiter configuration from config
-	rateLimitManager.limit = config.AppConfig.RateLimitReqs
-	rateLimitManager.window = config.AppConfig.RateLimitWindow
+	rateLimitManager.setLimits(config.AppConfig.RateLimitReqs, config.AppConfig.RateLimitWindow)
+	authRateLimitManager.setLimits(config.AppConfig.RateLimitAuthReqs, config.AppConfig.RateLimitAuthWindow)
 
 	return func(c *gin.Context) {
 		ip := getClientIP(c)
-		
-		if !rateLimitManager.allow(ip) {
-			resetTime := time.Now().Add(rateLimitManager.window)
+
+		tier, manager := models.RateLimitTierAnonymous, rateLimitManager
+		if isAuthenticatedRequest(c) {
+			tier, manager = models.RateLimitTierAuthenticated, authRateLimitManager
+		}
+		limit, window := manager.limits()
+
+		c.Header("X-Rate-Limit-Tier", tier)
+		c.Header("X-Rate-Limit-Limit", strconv.Itoa(limit))
+		c.Header("X-Rate-Limit-Window", window.String())
+
+		if !manager.allow(ip) {
+			resetTime := time.Now().Add(window)
 			
-			c.Header("X-Rate-Limit-Limit", strconv.Itoa(rateLimitManager.limit))
 			c.Header("X-Rate-Limit-Remaining", "0")
 			c.Header("X-Rate-Limit-Reset", strconv.FormatInt(resetTime.Unix(), 10))
-			c.Header("X-Rate-Limit-Window", rateLimitManager.window.String())
 
-			c.JSON(http.StatusTooManyRequests, models.ErrorResponse{
+			utils.JSON(c, http.StatusTooManyRequests, models.ErrorResponse{
 				Success:   false,
 				Error:     "Rate limit exceeded",
 				Code:      "RATE_LIMIT_EXCEEDED",
@@ -69,17 +94,90 @@ func RateLimit() gin.HandlerFunc {
 		}
 
 		// Add rate limit headers
-		remaining := rateLimitManager.getRemaining(ip)
-		c.Header("X-Rate-Limit-Limit", strconv.Itoa(rateLimitManager.limit))
-		c.Header("X-Rate-Limit-Remaining", strconv.Itoa(remaining))
-		c.Header("X-Rate-Limit-Window", rateLimitManager.window.String())
+		c.Header("X-Rate-Limit-Remaining", strconv.Itoa(manager.getRemaining(ip)))
 
 		c.Next()
 	}
 }
 
-// Custom rate limit for specific endpoints
+// ApplyRateLimitTiers switches the global limiters to new tier limits
+func ApplyRateLimitTiers(tiers *models.RateLimitTiers) {
+	for _, t := range []struct {
+		manager *RateLimitManager
+		tier    models.RateLimitTier
+	}{
+		{rateLimitManager, tiers.Anonymous},
+		{authRateLimitManager, tiers.Authenticated},
+	} {
+		window, err := time.ParseDuration(t.tier.Window)
+		if err != nil || window <= 0 || t.tier.Requests <= 0 {
+			continue
+		}
+		t.manager.setLimits(t.tier.Requests, window)
+	}
+}
+
+// WatchRateLimitTiers periodically applies the tiers saved in settings, so
+// changes made on another instance are picked up without a restart
+func WatchRateLimitTiers() {
+	go func() {
+		settingsService := services.NewSettingsService()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if tiers, err := settingsService.GetRateLimitTiers(ctx); err == nil {
+				ApplyRateLimitTiers(tiers)
+			}
+			cancel()
+
+			time.Sleep(tierRefreshInterval)
+		}
+	}()
+}
+
+// isAuthenticatedRequest reports whether the request carries the API token
+// or a valid JWT, without rejecting anything; Auth and APIKey still enforce
+// access on protected routes
+func isAuthenticatedRequest(c *gin.Context) bool {
+	if apiKey := c.GetHeader("X-API-Key"); apiKey != "" && apiKey == config.AppConfig.APIToken {
+		return true
+	}
+
+	tokenParts := strings.Split(c.GetHeader("Authorization"), " ")
+	if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+		return false
+	}
+
+	return tokenParts[1] == config.AppConfig.APIToken || validateJWT(tokenParts[1]) == nil
+}
+
+// RateLimitKeyFunc picks the bucket a request is counted against
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// ByClientIP gives every client its own bucket
+func ByClientIP(c *gin.Context) string {
+	return getClientIP(c)
+}
+
+// ByRouteParams shares one bucket between all clients per route template and
+// value of the given path parameters, e.g. one per username on the sync route.
+// Keys never contain the raw path, so unmatched paths all land in one bucket.
+func ByRouteParams(params ...string) RateLimitKeyFunc {
+	return func(c *gin.Context) string {
+		key := routeLabel(c)
+		for _, param := range params {
+			key += "|" + c.Param(param)
+		}
+		return key
+	}
+}
+
+// Custom rate limit for specific endpoints, counted per client IP
 func CustomRateLimit(limit int, window time.Duration) gin.HandlerFunc {
+	return CustomRateLimitBy(limit, window, ByClientIP)
+}
+
+// CustomRateLimitBy is CustomRateLimit with a custom bucket key
+func CustomRateLimitBy(limit int, window time.Duration, keyFunc RateLimitKeyFunc) gin.HandlerFunc {
 	customManager := &RateLimitManager{
 		limiters: make(map[string]*RateLimiter),
 		limit:    limit,
@@ -89,9 +187,9 @@ func CustomRateLimit(limit int, window time.Duration) gin.HandlerFunc {
 	go customManager.cleanup()
 
 	return func(c *gin.Context) {
-		ip := getClientIP(c)
-		
-		if !customManager.allow(ip) {
+		key := keyFunc(c)
+
+		if !customManager.allow(key) {
 			resetTime := time.Now().Add(window)
 			
 			c.Header("X-Rate-Limit-Limit", strconv.Itoa(limit))
@@ -99,7 +197,7 @@ func CustomRateLimit(limit int, window time.Duration) gin.HandlerFunc {
 			c.Header("X-Rate-Limit-Reset", strconv.FormatInt(resetTime.Unix(), 10))
 			c.Header("X-Rate-Limit-Window", window.String())
 
-			c.JSON(http.StatusTooManyRequests, models.ErrorResponse{
+			utils.JSON(c, http.StatusTooManyRequests, models.ErrorResponse{
 				Success:   false,
 				Error:     "Rate limit exceeded",
 				Code:      "RATE_LIMIT_EXCEEDED",
@@ -112,7 +210,7 @@ func CustomRateLimit(limit int, window time.Duration) gin.HandlerFunc {
 		}
 
 		// Add rate limit headers
-		remaining := customManager.getRemaining(ip)
+		remaining := customManager.getRemaining(key)
 		c.Header("X-Rate-Limit-Limit", strconv.Itoa(limit))
 		c.Header("X-Rate-Limit-Remaining", strconv.Itoa(remaining))
 		c.Header("X-Rate-Limit-Window", window.String())
@@ -126,42 +224,99 @@ func GitHubRateLimit() gin.HandlerFunc {
 	return CustomRateLimit(30, time.Hour) // 30 requests per hour for GitHub endpoints
 }
 
+// SyncRateLimit caps syncs per username across all callers, since every sync
+// spends the same GitHub API quota no matter who triggers it
+func SyncRateLimit() gin.HandlerFunc {
+	return CustomRateLimitBy(10, time.Hour, ByRouteParams("username"))
+}
+
+// FeedbackRateLimit keeps a client from flooding project feedback
+func FeedbackRateLimit() gin.HandlerFunc {
+	return CustomRateLimit(10, time.Hour)
+}
+
+// ContactRateLimit keeps a client from flooding the contact form
+func ContactRateLimit() gin.HandlerFunc {
+	return CustomRateLimit(5, time.Hour)
+}
+
+func (rlm *RateLimitManager) setLimits(limit int, window time.Duration) {
+	rlm.mutex.Lock()
+	defer rlm.mutex.Unlock()
+
+	rlm.limit = limit
+	rlm.window = window
+}
+
+func (rlm *RateLimitManager) limits() (int, time.Duration) {
+	rlm.mutex.RLock()
+	defer rlm.mutex.RUnlock()
+
+	return rlm.limit, rlm.window
+}
+
 func (rlm *RateLimitManager) allow(ip string) bool {
+	return rlm.allowAt(ip, time.Now())
+}
+
+func (rlm *RateLimitManager) allowAt(ip string, now time.Time) bool {
 	rlm.mutex.Lock()
 	defer rlm.mutex.Unlock()
 
 	limiter, exists := rlm.limiters[ip]
 	if !exists {
-		rlm.limiters[ip] = &RateLimiter{
-			tokens:   rlm.limit - 1,
-			lastSeen: time.Now(),
+		limiter = &RateLimiter{
+			tokens:   rlm.limit,
+			lastSeen: now,
 		}
-		return true
+		rlm.limiters[ip] = limiter
 	}
 
 	limiter.mutex.Lock()
 	defer limiter.mutex.Unlock()
 
-	now := time.Now()
-	elapsed := now.Sub(limiter.lastSeen)
+	return limiter.take(now, rlm.limit, rlm.window)
+}
 
-	// Reset tokens if window has passed
-	if elapsed >= rlm.window {
-		limiter.tokens = rlm.limit - 1
-		limiter.lastSeen = now
-		return true
+// take refills the bucket for the time elapsed since lastSeen and consumes a
+// token if one is available
+func (rl *RateLimiter) take(now time.Time, limit int, window time.Duration) bool {
+	if limit <= 0 {
+		return false
 	}
 
-	// Gradual token refill (token bucket algorithm)
-	tokensToAdd := int(elapsed.Seconds() * float64(rlm.limit) / rlm.window.Seconds())
-	limiter.tokens += tokensToAdd
-	if limiter.tokens > rlm.limit {
-		limiter.tokens = rlm.limit
+	elapsed := now.Sub(rl.lastSeen)
+
+	switch {
+	case elapsed < 0:
+		// Clock went backwards; refill nothing and restart from now
+		rl.lastSeen = now
+	case elapsed >= window:
+		// Reset tokens if window has passed
+		rl.tokens = limit
+		rl.lastSeen = now
+	default:
+		// Gradual token refill (token bucket algorithm). Only the time that
+		// turned into whole tokens is consumed, so frequent requests still
+		// accumulate partial refills instead of discarding them.
+		perToken := window / time.Duration(limit)
+		if perToken <= 0 {
+			rl.tokens = limit
+			rl.lastSeen = now
+			break
+		}
+
+		tokensToAdd := elapsed / perToken
+		rl.tokens += int(tokensToAdd)
+		rl.lastSeen = rl.lastSeen.Add(tokensToAdd * perToken)
+		if rl.tokens >= limit {
+			rl.tokens = limit
+			rl.lastSeen = now
+		}
 	}
-	limiter.lastSeen = now
 
-	if limiter.tokens > 0 {
-		limiter.tokens--
+	if rl.tokens > 0 {
+		rl.tokens--
 		return true
 	}
 
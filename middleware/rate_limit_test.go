@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var rateLimitEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// limiterParams maps arbitrary inputs onto a sane limit (1-200) and window (1s-2h)
+func limiterParams(limitSeed uint8, windowSeed uint16) (int, time.Duration) {
+	return int(limitSeed)%200 + 1, time.Duration(int(windowSeed)%7200+1) * time.Second
+}
+
+func newTestManager(limit int, window time.Duration) *RateLimitManager {
+	return &RateLimitManager{
+		limiters: make(map[string]*RateLimiter),
+		limit:    limit,
+		window:   window,
+	}
+}
+
+// Tokens stay within [0, limit] and lastSeen never moves past the current
+// time, even when the clock jumps backwards
+func TestRateLimiterTokensBounded(t *testing.T) {
+	property := func(limitSeed uint8, windowSeed uint16, steps []int32) bool {
+		limit, window := limiterParams(limitSeed, windowSeed)
+		rlm := newTestManager(limit, window)
+
+		now := rateLimitEpoch
+		for _, step := range steps {
+			now = now.Add(time.Duration(step) * time.Millisecond)
+			rlm.allowAt("client", now)
+
+			limiter := rlm.limiters["client"]
+			if limiter.tokens < 0 || limiter.tokens > limit || limiter.lastSeen.After(now) {
+				return false
+			}
+		}
+		return true
+	}
+
+	assert.NoError(t, quick.Check(property, &quick.Config{MaxCount: 2000}))
+}
+
+// Over any monotonic sequence a client gets at most the initial burst plus
+// what refilled during the elapsed time
+func TestRateLimiterNeverExceedsRefillRate(t *testing.T) {
+	property := func(limitSeed uint8, windowSeed uint16, steps []uint32) bool {
+		limit, window := limiterParams(limitSeed, windowSeed)
+		rlm := newTestManager(limit, window)
+		perToken := window / time.Duration(limit)
+
+		now := rateLimitEpoch
+		allowed := 0
+		for _, step := range steps {
+			now = now.Add(time.Duration(step%600000) * time.Millisecond)
+			if rlm.allowAt("client", now) {
+				allowed++
+			}
+
+			if allowed > limit+int(now.Sub(rateLimitEpoch)/perToken) {
+				return false
+			}
+		}
+		return true
+	}
+
+	assert.NoError(t, quick.Check(property, &quick.Config{MaxCount: 2000}))
+}
+
+// Requests arriving faster than one refill interval must still accumulate
+// partial refills rather than being starved forever
+func TestRateLimiterRefillsUnderSteadyTraffic(t *testing.T) {
+	property := func(limitSeed uint8, windowSeed uint16, spacingSeed uint16, countSeed uint16) bool {
+		limit, window := limiterParams(limitSeed, windowSeed)
+		rlm := newTestManager(limit, window)
+		perToken := window / time.Duration(limit)
+
+		spacing := time.Duration(int(spacingSeed)%int(window/time.Millisecond)+1) * time.Millisecond
+		count := int(countSeed)%2000 + 1
+
+		now := rateLimitEpoch
+		allowed := 0
+		for i := 0; i < count; i++ {
+			if rlm.allowAt("client", now) {
+				allowed++
+			}
+			now = now.Add(spacing)
+		}
+
+		// A token is available again at the first request after perToken has
+		// passed; time beyond that is lost whenever the bucket refills to full
+		cycle := (perToken + spacing - 1) / spacing * spacing
+		elapsed := time.Duration(count-1) * spacing
+		expected := limit + int(elapsed/cycle)
+		if expected > count {
+			expected = count
+		}
+		return allowed >= expected-1
+	}
+
+	assert.NoError(t, quick.Check(property, &quick.Config{MaxCount: 2000}))
+}
+
+func TestRateLimiterClientsAreIndependent(t *testing.T) {
+	rlm := newTestManager(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, rlm.allowAt("a", rateLimitEpoch))
+	}
+	assert.False(t, rlm.allowAt("a", rateLimitEpoch))
+	assert.True(t, rlm.allowAt("b", rateLimitEpoch))
+
+	// One token refills every window/limit
+	assert.False(t, rlm.allowAt("a", rateLimitEpoch.Add(19*time.Second)))
+	assert.True(t, rlm.allowAt("a", rateLimitEpoch.Add(20*time.Second)))
+	assert.False(t, rlm.allowAt("a", rateLimitEpoch.Add(21*time.Second)))
+}
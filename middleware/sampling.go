@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"hash/fnv"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// analyticsSampling is the sampling applied by Visitors; nil records every
+// request
+var analyticsSampling atomic.Pointer[models.AnalyticsSampling]
+
+// ApplyAnalyticsSampling switches the sampling used by Visitors
+func ApplyAnalyticsSampling(sampling *models.AnalyticsSampling) {
+	analyticsSampling.Store(sampling)
+}
+
+// WatchAnalyticsSampling periodically applies the sampling saved in
+// settings, so changes made on another instance are picked up without a
+// restart
+func WatchAnalyticsSampling() {
+	go func() {
+		settingsService := services.NewSettingsService()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if sampling, err := settingsService.GetAnalyticsSampling(ctx); err == nil {
+				ApplyAnalyticsSampling(sampling)
+			}
+			cancel()
+
+			time.Sleep(tierRefreshInterval)
+		}
+	}()
+}
+
+// sampleRate returns the share of requests to route recorded in the traffic
+// analytics, 1 for server errors when those are always sampled
+func sampleRate(sampling *models.AnalyticsSampling, route string, status int) float64 {
+	if sampling == nil || (sampling.AlwaysSampleErrors && status >= 500) {
+		return 1
+	}
+
+	rate, longest := sampling.DefaultRate, -1
+	for prefix, groupRate := range sampling.Groups {
+		if len(prefix) > longest && routeInGroup(route, prefix) {
+			rate, longest = groupRate, len(prefix)
+		}
+	}
+	return rate
+}
+
+// routeInGroup matches whole path segments, so "/api/v1/git" does not take
+// in "/api/v1/github"
+func routeInGroup(route, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return route == prefix || strings.HasPrefix(route, prefix+"/")
+}
+
+// sampled decides from the request ID alone, so whether a request was
+// recorded can be told from its ID in the logs on any instance
+func sampled(requestID string, rate float64) bool {
+	switch {
+	case rate >= 1:
+		return true
+	case rate <= 0:
+		return false
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(requestID))
+	return float64(hash.Sum64())/(1<<64) < rate
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleRate(t *testing.T) {
+	sampling := &models.AnalyticsSampling{
+		DefaultRate: 0.5,
+		Groups: map[string]float64{
+			"/api/v1/github":        0.1,
+			"/api/v1/github/stats/": 0.2,
+		},
+		AlwaysSampleErrors: true,
+	}
+
+	assert.Equal(t, 1.0, sampleRate(nil, "/api/v1/content", http.StatusOK))
+	assert.Equal(t, 0.5, sampleRate(sampling, "/api/v1/content", http.StatusOK))
+	assert.Equal(t, 0.1, sampleRate(sampling, "/api/v1/github/profile/:username", http.StatusOK))
+	assert.Equal(t, 0.2, sampleRate(sampling, "/api/v1/github/stats/:username", http.StatusOK))
+	// Prefixes match whole segments
+	assert.Equal(t, 0.5, sampleRate(sampling, "/api/v1/githubber", http.StatusOK))
+	assert.Equal(t, 1.0, sampleRate(sampling, "/api/v1/github/profile/:username", http.StatusBadGateway))
+	assert.Equal(t, 0.1, sampleRate(sampling, "/api/v1/github/profile/:username", http.StatusNotFound))
+
+	sampling.AlwaysSampleErrors = false
+	assert.Equal(t, 0.1, sampleRate(sampling, "/api/v1/github/profile/:username", http.StatusBadGateway))
+}
+
+func TestSampledIsDeterministic(t *testing.T) {
+	kept := 0
+	for i := 0; i < 10000; i++ {
+		id := fmt.Sprintf("request-%d", i)
+		if sampled(id, 0.25) {
+			kept++
+			assert.True(t, sampled(id, 0.25))
+			// A request kept at a rate is kept at every higher rate
+			assert.True(t, sampled(id, 0.5))
+		}
+	}
+	assert.InDelta(t, 2500, kept, 250)
+
+	assert.True(t, sampled("any", 1))
+	assert.False(t, sampled("any", 0))
+}
+
+func TestVisitorsSampleByRouteGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig = &config.Config{VisitorTracking: true, VisitorHashSalt: "salt"}
+
+	var views []models.PageView
+	original := recordPageView
+	recordPageView = func(view models.PageView) { views = append(views, view) }
+	defer func() { recordPageView = original }()
+
+	ApplyAnalyticsSampling(&models.AnalyticsSampling{
+		DefaultRate:        1,
+		Groups:             map[string]float64{"/api/v1/github": 0},
+		AlwaysSampleErrors: true,
+	})
+	defer ApplyAnalyticsSampling(nil)
+
+	r := gin.New()
+	r.Use(RequestID(), Visitors())
+	r.GET("/api/v1/github/profile/:username", func(c *gin.Context) {
+		if c.Param("username") == "broken" {
+			c.Status(http.StatusBadGateway)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	r.GET("/api/v1/content/about", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/api/v1/github/profile/octocat", "/api/v1/github/profile/broken", "/api/v1/content/about"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if assert.Len(t, views, 2) {
+		assert.Equal(t, "/api/v1/github/profile/broken", views[0].Path)
+		assert.Equal(t, 1.0, views[0].Weight)
+		assert.Equal(t, "/api/v1/content/about", views[1].Path)
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"portfolio-backend/config"
+	"portfolio-backend/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ServerTimingHeader carries the per-layer timings of a request
+const ServerTimingHeader = "Server-Timing"
+
+// timingWriter sets the Server-Timing header just before the response
+// headers are sent, once the handlers are done with the layers
+type timingWriter struct {
+	gin.ResponseWriter
+	timings   *metrics.Timings
+	start     time.Time
+	annotated bool
+}
+
+func (w *timingWriter) annotate() {
+	if !w.annotated {
+		w.annotated = true
+		w.Header().Set(ServerTimingHeader, w.timings.ServerTiming(time.Since(w.start)))
+	}
+}
+
+func (w *timingWriter) WriteHeaderNow() {
+	w.annotate()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *timingWriter) Write(b []byte) (int, error) {
+	w.annotate()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *timingWriter) WriteString(s string) (int, error) {
+	w.annotate()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// ServerTiming reports, with SERVER_TIMING, where each request spent its
+// time in a Server-Timing header: the cache backend, MongoDB, GitHub and
+// response rendering, with the number of calls to each, and the total.
+// Browser devtools and synthetic monitors show the breakdown as is.
+func ServerTiming() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !config.AppConfig.ServerTiming {
+			c.Next()
+			return
+		}
+		timeRequest(c)
+	}
+}
+
+// timeRequest runs the rest of the chain with the layers of the request
+// timed, unless ServerTiming already does
+func timeRequest(c *gin.Context) {
+	if metrics.TimingsFrom(c.Request.Context()) != nil {
+		c.Next()
+		return
+	}
+
+	ctx, timings := metrics.WithTimings(c.Request.Context())
+	c.Request = c.Request.WithContext(ctx)
+
+	writer := &timingWriter{ResponseWriter: c.Writer, timings: timings, start: time.Now()}
+	c.Writer = writer
+	c.Next()
+	c.Writer = writer.ResponseWriter
+
+	// Responses with no body have not sent their headers yet
+	writer.annotate()
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"portfolio-backend/utils"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerTiming(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(ServerTiming(), CacheBypass())
+	r.GET("/data", func(c *gin.Context) {
+		utils.JSON(c, http.StatusOK, gin.H{"ok": true})
+	})
+	r.GET("/empty", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	request := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	config.AppConfig = &config.Config{ServerTiming: true, APIToken: "secret"}
+	timing := request("/data", nil).Header().Values(ServerTimingHeader)
+	if assert.Len(t, timing, 1) {
+		assert.Contains(t, timing[0], `render;dur=`)
+		assert.Contains(t, timing[0], `desc="1 calls", total;dur=`, "the JSON encoding is timed")
+	}
+	assert.NotEmpty(t, request("/empty", nil).Header().Get(ServerTimingHeader), "bodiless responses are timed too")
+
+	// A cache bypass is timed once
+	timing = request("/data", map[string]string{CacheBypassHeader: "true", "X-API-Key": "secret"}).Header().Values(ServerTimingHeader)
+	assert.Len(t, timing, 1)
+	assert.Equal(t, 1, strings.Count(timing[0], "total;dur="))
+
+	config.AppConfig = &config.Config{}
+	assert.Empty(t, request("/data", nil).Header().Get(ServerTimingHeader))
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// siteDomains maps normalized host names to the portfolio served on them
+var siteDomains atomic.Pointer[map[string]*models.SiteDomain]
+
+// ApplySiteDomains switches the domain to owner mapping used by Site
+func ApplySiteDomains(domains []models.SiteDomain) {
+	mapping := make(map[string]*models.SiteDomain, len(domains))
+	for i := range domains {
+		mapping[services.NormalizeHost(domains[i].Domain)] = &domains[i]
+	}
+	siteDomains.Store(&mapping)
+}
+
+// WatchSiteDomains periodically applies the domains saved in settings, so
+// changes made on another instance are picked up without a restart
+func WatchSiteDomains() {
+	go func() {
+		settingsService := services.NewSettingsService()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if domains, err := settingsService.GetDomains(ctx); err == nil {
+				ApplySiteDomains(domains)
+			}
+			cancel()
+
+			time.Sleep(tierRefreshInterval)
+		}
+	}()
+}
+
+// Site resolves the Host header against the domain to owner mapping, so one
+// deployment can back several portfolio sites. Hosts that are not mapped are
+// served the default owner.
+func Site() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if mapping := siteDomains.Load(); mapping != nil {
+			if site, ok := (*mapping)[services.NormalizeHost(c.Request.Host)]; ok {
+				c.Request = c.Request.WithContext(services.WithSite(c.Request.Context(), site))
+				c.Set("site", site.Domain)
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteResolvesOwnerAndCORSByHost(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig = &config.Config{GitHubUsername: "default", CORSOrigins: "https://default.dev"}
+
+	ApplySiteDomains([]models.SiteDomain{{
+		Domain:      "alice.dev",
+		Owner:       models.OwnerSettings{GitHubUsername: "alice"},
+		CORSOrigins: []string{"https://www.alice.dev"},
+	}})
+	defer siteDomains.Store(nil)
+
+	r := gin.New()
+	r.Use(Site(), CORS())
+	r.GET("/owner", func(c *gin.Context) {
+		if services.SiteFromContext(c.Request.Context()) == nil {
+			c.String(http.StatusOK, "default")
+			return
+		}
+		// Resolved without reading settings from MongoDB
+		c.String(http.StatusOK, (&services.SettingsService{}).GetOwner(c.Request.Context()))
+	})
+
+	request := func(host, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/owner", nil)
+		req.Host = host
+		req.Header.Set("Origin", origin)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := request("Alice.dev:443", "https://www.alice.dev")
+	assert.Equal(t, "alice", rr.Body.String())
+	assert.Equal(t, "https://www.alice.dev", rr.Header().Get("Access-Control-Allow-Origin"))
+
+	// Origins of one domain are not allowed on another
+	rr = request("bob.dev", "https://www.alice.dev")
+	assert.Equal(t, "default", rr.Body.String())
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+
+	rr = request("alice.dev", "https://default.dev")
+	assert.Equal(t, "https://default.dev", rr.Header().Get("Access-Control-Allow-Origin"))
+}
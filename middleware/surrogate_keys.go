@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SurrogateKeys tags responses with the keys returned by keys, as a
+// space-separated Surrogate-Key (Fastly) and a comma-separated Cache-Tag
+// (Cloudflare), so CDNs can purge them by tag when the data changes
+func SurrogateKeys(keys func(c *gin.Context) []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tags := keys(c); len(tags) > 0 {
+			c.Header("Surrogate-Key", strings.Join(tags, " "))
+			c.Header("Cache-Tag", strings.Join(tags, ","))
+		}
+		c.Next()
+	}
+}
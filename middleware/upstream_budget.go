@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"portfolio-backend/config"
+	"portfolio-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpstreamBudget caps the GitHub API calls a single request may trigger
+// (GITHUB_REQUEST_BUDGET), so a cold cache can't turn one page view into
+// 100+ upstream calls. Work beyond the budget is left to the enrichment pipeline.
+func UpstreamBudget() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := services.WithUpstreamBudget(c.Request.Context(), config.AppConfig.GitHubRequestBudget)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// countryHeaders carry the visitor's country as resolved by the CDN or
+// platform in front of the API, in order of preference
+var countryHeaders = []string{"CF-IPCountry", "CloudFront-Viewer-Country", "X-Vercel-IP-Country", "X-Country-Code"}
+
+// recordPageView hands page views to the batching writer, a variable so
+// tests can capture them
+var recordPageView = services.RecordPageView
+
+// maxUserAgentLength keeps oddly long user agents out of the page views
+const maxUserAgentLength = 256
+
+// Visitors records an anonymized page view of every public API GET for the
+// traffic analytics. Admin routes, authenticated callers and requests not
+// matching a route are left out. Requests are sampled as set through
+// ApplyAnalyticsSampling, each recorded view weighing for the requests
+// skipped alongside it.
+func Visitors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		if !config.AppConfig.VisitorTracking || c.Request.Method != http.MethodGet {
+			return
+		}
+		route := c.FullPath()
+		if !strings.HasPrefix(route, "/api/v1/") || strings.HasPrefix(route, "/api/v1/admin") || isAuthenticatedRequest(c) {
+			return
+		}
+
+		rate := sampleRate(analyticsSampling.Load(), route, c.Writer.Status())
+		if !sampled(c.GetString("request_id"), rate) {
+			return
+		}
+
+		userAgent := c.Request.UserAgent()
+		if len(userAgent) > maxUserAgentLength {
+			userAgent = userAgent[:maxUserAgentLength]
+		}
+
+		recordPageView(models.PageView{
+			Visitor:    services.HashVisitor(getClientIP(c)),
+			Endpoint:   c.Request.Method + " " + route,
+			Path:       c.Request.URL.Path,
+			Referrer:   referrerHost(c.Request.Referer()),
+			UserAgent:  userAgent,
+			Country:    visitorCountry(c),
+			Status:     c.Writer.Status(),
+			DurationMs: milliseconds(time.Since(start)),
+			Weight:     1 / rate,
+			Timestamp:  start,
+		})
+	}
+}
+
+// referrerHost keeps only the host of the referring page, dropping paths
+// and query strings that may identify the visitor
+func referrerHost(referrer string) string {
+	parsed, err := url.Parse(referrer)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Host)
+}
+
+func visitorCountry(c *gin.Context) string {
+	for _, header := range countryHeaders {
+		if country := strings.ToUpper(strings.TrimSpace(c.GetHeader(header))); len(country) == 2 && country != "XX" {
+			return country
+		}
+	}
+	return ""
+}
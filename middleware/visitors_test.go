@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisitorsRecordAnonymizedPageViews(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig = &config.Config{VisitorTracking: true, VisitorHashSalt: "salt", APIToken: "token"}
+
+	var views []models.PageView
+	original := recordPageView
+	recordPageView = func(view models.PageView) { views = append(views, view) }
+	defer func() { recordPageView = original }()
+
+	r := gin.New()
+	r.Use(Visitors())
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/api/v1/github/profile/:username", ok)
+	r.GET("/api/v1/admin/owner", ok)
+
+	request := func(path string, headers map[string]string) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	request("/api/v1/github/profile/octocat", map[string]string{
+		"Referer":      "https://News.example.com/item?id=42",
+		"CF-IPCountry": "br",
+	})
+	// Not recorded: admin routes, the owner's own calls and unknown routes
+	request("/api/v1/admin/owner", nil)
+	request("/api/v1/github/profile/octocat", map[string]string{"X-API-Key": "token"})
+	request("/api/v1/missing", nil)
+
+	require.Len(t, views, 1)
+	view := views[0]
+	assert.Equal(t, "GET /api/v1/github/profile/:username", view.Endpoint)
+	assert.Equal(t, "/api/v1/github/profile/octocat", view.Path)
+	assert.Equal(t, "news.example.com", view.Referrer)
+	assert.Equal(t, "BR", view.Country)
+	assert.Equal(t, services.HashVisitor("203.0.113.7"), view.Visitor)
+	assert.NotContains(t, view.Visitor, "203.0.113.7")
+}
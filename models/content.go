@@ -40,11 +40,13 @@ type Skills struct {
 
 type Skill struct {
 	Name        string   `bson:"name" json:"name" validate:"required"`
+	Label       string   `bson:"-" json:"label,omitempty"` // added when served
 	Level       int      `bson:"level" json:"level" validate:"min=0,max=100"`
 	Category    string   `bson:"category" json:"category"`
 	Icon        string   `bson:"icon" json:"icon"`
 	YearsExp    int      `bson:"years_exp" json:"years_exp"`
 	Certifications []string `bson:"certifications" json:"certifications"`
+	Endorsements *EndorsementSummary `bson:"-" json:"endorsements,omitempty"` // added when served
 }
 
 type Experience struct {
@@ -83,8 +85,24 @@ type Project struct {
 	Forks        int               `bson:"forks" json:"forks"`
 	Language     string            `bson:"language" json:"language"`
 	UpdatedAt    time.Time         `bson:"updated_at" json:"updated_at"`
+	// FeedbackEnabled opens the project to visitor feedback
+	FeedbackEnabled bool           `bson:"feedback_enabled" json:"feedback_enabled"`
+	// RepoNotification tells the linked repository when the project's page
+	// changes: RepoNotifyStatus, RepoNotifyComment or empty for neither
+	RepoNotification string        `bson:"repo_notification,omitempty" json:"repo_notification,omitempty"`
+
+	// ExperienceID links the project to the experience entry it was built at
+	ExperienceID string            `bson:"experience_id,omitempty" json:"experience_id,omitempty"`
+	// Experience is the linked entry, only filled in with ?expand=experience
+	Experience   *Experience       `bson:"-" json:"experience,omitempty"`
 }
 
+// Ways of notifying a project's repository of portfolio updates
+const (
+	RepoNotifyStatus  = "status"
+	RepoNotifyComment = "comment"
+)
+
 type Education struct {
 	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
 	Institution  string            `bson:"institution" json:"institution" validate:"required"`
@@ -100,10 +118,44 @@ type Education struct {
 	URL          string            `bson:"url" json:"url"`
 }
 
+// Availability tells visitors whether the owner is open to new work
+type Availability struct {
+	Status         string     `bson:"status" json:"status" validate:"required"` // "open", "closed"
+	AvailableFrom  *time.Time `bson:"available_from,omitempty" json:"available_from,omitempty"`
+	PreferredRoles []string   `bson:"preferred_roles" json:"preferred_roles"`
+	TimeZone       string     `bson:"time_zone" json:"time_zone"`
+}
+
+// ChangelogEntry is a post of the changelog, such as the notes of a release
+// of one of the owner's repositories
+type ChangelogEntry struct {
+	Title      string    `bson:"title" json:"title" validate:"required"`
+	Body       string    `bson:"body" json:"body"`
+	Repository string    `bson:"repository,omitempty" json:"repository,omitempty"` // owner/name of a release
+	Tag        string    `bson:"tag,omitempty" json:"tag,omitempty"`
+	URL        string    `bson:"url,omitempty" json:"url,omitempty"`
+	Date       time.Time `bson:"date" json:"date"`
+}
+
+// AvailabilityStatus is the public availability, flagged as currently
+// unavailable while the owner's calendar shows them busy
+type AvailabilityStatus struct {
+	Availability
+	CurrentlyUnavailable bool       `json:"currently_unavailable"`
+	BusyUntil            *time.Time `json:"busy_until,omitempty"`
+	CalendarSyncedAt     *time.Time `json:"calendar_synced_at,omitempty"`
+}
+
+// BusyPeriod is a busy block of the owner's calendar
+type BusyPeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
 // Content types for flexible content management
 type Content struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Type      string            `bson:"type" json:"type" validate:"required"` // "skills", "experience", "projects", "education", "meta"
+	Type      string            `bson:"type" json:"type" validate:"required"` // "skills", "experience", "projects", "education", "meta", "availability", "changelog"
 	Data      interface{}       `bson:"data" json:"data"`
 	Version   int               `bson:"version" json:"version"`
 	UpdatedAt time.Time         `bson:"updated_at" json:"updated_at"`
@@ -111,11 +163,234 @@ type Content struct {
 	UpdatedBy string            `bson:"updated_by" json:"updated_by"`
 }
 
+// Content states. Published content is what the public endpoints and the
+// cache serve; drafts are only seen by authenticated previews.
+const (
+	ContentStatePublished = "published"
+	ContentStateDraft     = "draft"
+)
+
+// ContentDraft is an edit of a content type staged for review. The published
+// content is left untouched until the draft is published.
+type ContentDraft struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Type        string             `bson:"type" json:"type"`
+	Data        interface{}        `bson:"data" json:"data"`
+	State       string             `bson:"state" json:"state"`
+	BaseVersion int                `bson:"base_version" json:"base_version"` // published version the draft was started from
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	UpdatedBy   string             `bson:"updated_by" json:"updated_by"`
+}
+
+// ContentVersionRef identifies a published version of a content type
+type ContentVersionRef struct {
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by"`
+}
+
+// ContentDiff lists the fields of a content type's data that changed between
+// two of its versions, keyed by JSON pointer
+type ContentDiff struct {
+	Type    string            `json:"type"`
+	From    ContentVersionRef `json:"from"`
+	To      ContentVersionRef `json:"to"`
+	Changes []FieldDiff       `json:"changes"`
+}
+
+// ContentBundle is the published content of the portfolio as one document,
+// exported and imported by the admin API as JSON or YAML
+type ContentBundle struct {
+	ExportedAt time.Time              `json:"exported_at"`
+	Content    map[string]interface{} `json:"content"` // data by content type
+}
+
+// ContentImportResult reports what an import published
+type ContentImportResult struct {
+	Imported  []string `json:"imported"`  // content types published as a new version
+	Unchanged []string `json:"unchanged"` // content types already published as imported
+}
+
+// BulkRequest updates every entry of a list content type matching Filter
+// with Update. It must be sent with X-Dry-Run: true first; the plan token of
+// that preview is then required to apply it.
+type BulkRequest struct {
+	Type      string                 `json:"type"`   // projects, experience, education or changelog
+	Filter    map[string]interface{} `json:"filter"` // field: value, or field: {"$op": value}
+	Update    BulkUpdate             `json:"update"`
+	PlanToken string                 `json:"plan_token,omitempty"`
+}
+
+// BulkUpdate is applied to each matching entry, field by field
+type BulkUpdate struct {
+	Set    map[string]interface{} `json:"set,omitempty"`
+	Unset  []string               `json:"unset,omitempty"`
+	Add    map[string]interface{} `json:"add,omitempty"`    // appended to list fields, once
+	Remove map[string]interface{} `json:"remove,omitempty"` // removed from list fields
+}
+
+// BulkResult reports the entries a bulk update matched and changed
+type BulkResult struct {
+	Type      string      `json:"type"`
+	Matched   int         `json:"matched"`
+	Modified  int         `json:"modified"`
+	Entries   []BulkEntry `json:"entries"`
+	PlanToken string      `json:"plan_token,omitempty"` // given by the dry run
+	Applied   bool        `json:"applied"`
+}
+
+// BulkEntry is an entry a bulk update changed
+type BulkEntry struct {
+	Index  int         `json:"index"`
+	ID     string      `json:"id,omitempty"`
+	Diff   []FieldDiff `json:"diff"`
+	Before interface{} `json:"-"`
+	After  interface{} `json:"-"`
+}
+
+// FixtureSet is a named state the test-support API seeds for end-to-end
+// tests: the portfolio owner, published content and the GitHub data of
+// some accounts, served from the cache without calling GitHub
+type FixtureSet struct {
+	Description string                   `json:"description"`
+	Owner       *OwnerSettings           `json:"owner,omitempty"`
+	Content     map[string]interface{}   `json:"content,omitempty"` // data by content type
+	GitHub      map[string]FixtureGitHub `json:"github,omitempty"`  // by username
+}
+
+// FixtureGitHub is the GitHub data of an account in a fixture set
+type FixtureGitHub struct {
+	Profile      *GitHubProfile     `json:"profile,omitempty"`
+	Repositories []GitHubRepository `json:"repositories,omitempty"`
+	Stats        *GitHubStats       `json:"stats,omitempty"`
+}
+
+// FixtureSeedRequest names the fixture set to seed
+type FixtureSeedRequest struct {
+	Fixture string `json:"fixture"`
+}
+
+// FixtureSeedResult reports what resetting and seeding did
+type FixtureSeedResult struct {
+	Fixture string   `json:"fixture,omitempty"`
+	Cleared []string `json:"cleared"` // collections emptied
+	Content []string `json:"content"` // content types published
+	GitHub  []string `json:"github"`  // accounts whose GitHub data was cached
+}
+
+// JSONResumeSchema is the version of the jsonresume.org schema JSONResume
+// follows
+const JSONResumeSchema = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// JSONResume is the portfolio content in the jsonresume.org schema. Dates
+// are ISO 8601 dates (2006-01-02), as the schema expects.
+type JSONResume struct {
+	Schema    string                `json:"$schema"`
+	Basics    JSONResumeBasics      `json:"basics"`
+	Work      []JSONResumeWork      `json:"work"`
+	Education []JSONResumeEducation `json:"education"`
+	Skills    []JSONResumeSkill     `json:"skills"`
+	Projects  []JSONResumeProject   `json:"projects"`
+	Meta      JSONResumeMeta        `json:"meta"`
+}
+
+type JSONResumeBasics struct {
+	Name     string              `json:"name"`
+	Label    string              `json:"label,omitempty"`
+	Email    string              `json:"email,omitempty"`
+	URL      string              `json:"url,omitempty"`
+	Summary  string              `json:"summary,omitempty"`
+	Location *JSONResumeLocation `json:"location,omitempty"`
+	Profiles []JSONResumeProfile `json:"profiles"`
+}
+
+type JSONResumeLocation struct {
+	City   string `json:"city,omitempty"`
+	Region string `json:"region,omitempty"`
+}
+
+type JSONResumeProfile struct {
+	Network  string `json:"network"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+type JSONResumeWork struct {
+	Name       string   `json:"name"`
+	Position   string   `json:"position"`
+	Location   string   `json:"location,omitempty"`
+	URL        string   `json:"url,omitempty"`
+	StartDate  string   `json:"startDate,omitempty"`
+	EndDate    string   `json:"endDate,omitempty"` // left out while current
+	Summary    string   `json:"summary,omitempty"`
+	Highlights []string `json:"highlights"`
+}
+
+type JSONResumeEducation struct {
+	Institution string   `json:"institution"`
+	URL         string   `json:"url,omitempty"`
+	Area        string   `json:"area,omitempty"`
+	StudyType   string   `json:"studyType,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Score       string   `json:"score,omitempty"`
+	Courses     []string `json:"courses"`
+}
+
+// JSONResumeSkill is a group of skills, such as Backend, listed as keywords
+type JSONResumeSkill struct {
+	Name     string   `json:"name"`
+	Keywords []string `json:"keywords"`
+}
+
+type JSONResumeProject struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Highlights  []string `json:"highlights"`
+	Keywords    []string `json:"keywords"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Type        string   `json:"type,omitempty"`
+}
+
+type JSONResumeMeta struct {
+	Version      string `json:"version"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// RawDocumentAudit records an edit made through the raw document admin API
+type RawDocumentAudit struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Collection string             `bson:"collection" json:"collection"`
+	DocumentID string             `bson:"document_id" json:"document_id"`
+	Before     interface{}        `bson:"before" json:"before"`
+	After      interface{}        `bson:"after" json:"after"`
+	EditedBy   string             `bson:"edited_by" json:"edited_by"`
+	EditedAt   time.Time          `bson:"edited_at" json:"edited_at"`
+}
+
 // Cache structure for storing temporary data
 type CacheEntry struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
 	Key       string            `bson:"key" json:"key" validate:"required"`
-	Value     interface{}       `bson:"value" json:"value"`
+	Value     interface{}       `bson:"value,omitempty" json:"value,omitempty"`
+	Payload   []byte            `bson:"payload,omitempty" json:"-"`
+	Encoding  string            `bson:"encoding,omitempty" json:"encoding,omitempty"` // codec name, optionally prefixed with "gzip+"
+	Size      int               `bson:"size,omitempty" json:"size,omitempty"`         // encoded payload size in bytes
+	Chunks    int               `bson:"chunks,omitempty" json:"chunks,omitempty"`
+	ChunkID   string            `bson:"chunk_id,omitempty" json:"chunk_id,omitempty"`
 	ExpiresAt time.Time         `bson:"expires_at" json:"expires_at"`
 	CreatedAt time.Time         `bson:"created_at" json:"created_at"`
+}
+
+// CacheChunk holds one slice of a cache payload too large for a single document
+type CacheChunk struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Key       string            `bson:"key" json:"key"`
+	ChunkID   string            `bson:"chunk_id" json:"chunk_id"`
+	Index     int               `bson:"index" json:"index"`
+	Data      []byte            `bson:"data" json:"-"`
+	ExpiresAt time.Time         `bson:"expires_at" json:"expires_at"`
 }
\ No newline at end of file
@@ -39,6 +39,7 @@ type GitHubRepository struct {
 	CloneURL        string            `bson:"clone_url" json:"clone_url"`
 	Homepage        string            `bson:"homepage" json:"homepage"`
 	Language        string            `bson:"language" json:"language"`
+	LanguageLabel   string            `bson:"-" json:"language_label,omitempty"` // added when served
 	Languages       map[string]int    `bson:"languages" json:"languages"`
 	Size            int               `bson:"size" json:"size"`
 	StargazersCount int               `bson:"stargazers_count" json:"stargazers_count"`
@@ -47,6 +48,7 @@ type GitHubRepository struct {
 	OpenIssuesCount int               `bson:"open_issues_count" json:"open_issues_count"`
 	DefaultBranch   string            `bson:"default_branch" json:"default_branch"`
 	Topics          []string          `bson:"topics" json:"topics"`
+	TopicLabels     []string          `bson:"-" json:"topic_labels,omitempty"` // added when served
 	HasWiki         bool              `bson:"has_wiki" json:"has_wiki"`
 	HasPages        bool              `bson:"has_pages" json:"has_pages"`
 	HasDownloads    bool              `bson:"has_downloads" json:"has_downloads"`
@@ -57,12 +59,85 @@ type GitHubRepository struct {
 	UpdatedAt       time.Time         `bson:"updated_at" json:"updated_at"`
 	LastFetched     time.Time         `bson:"last_fetched" json:"last_fetched"`
 	Owner           string            `bson:"owner" json:"owner"`
+
+	// Filled in by the enrichment pipeline after the repository is listed
+	Readme       string            `bson:"readme,omitempty" json:"readme,omitempty"`
+	Contributors []RepoContributor `bson:"contributors,omitempty" json:"contributors,omitempty"`
+	Enrichment   RepoEnrichment    `bson:"enrichment" json:"enrichment"`
+}
+
+// Repository enrichment states
+const (
+	EnrichmentPending  = "pending"
+	EnrichmentPartial  = "partial"
+	EnrichmentComplete = "complete"
+)
+
+// RepoEnrichment records when each enriched field was last fetched. A field
+// fetched before the repository's last push is stale and fetched again.
+type RepoEnrichment struct {
+	Status         string    `bson:"status" json:"status"`
+	LanguagesAt    time.Time `bson:"languages_at" json:"languages_at"`
+	ReadmeAt       time.Time `bson:"readme_at" json:"readme_at"`
+	ContributorsAt time.Time `bson:"contributors_at" json:"contributors_at"`
+}
+
+type RepoContributor struct {
+	Login         string `bson:"login" json:"login"`
+	AvatarURL     string `bson:"avatar_url" json:"avatar_url"`
+	Contributions int    `bson:"contributions" json:"contributions"`
+}
+
+// NeedsLanguages reports whether the language breakdown must be (re)fetched
+func (r *GitHubRepository) NeedsLanguages() bool {
+	return r.Language != "" && !fetchedSincePush(r.Enrichment.LanguagesAt, r.PushedAt)
+}
+
+// NeedsReadme reports whether the README must be (re)fetched
+func (r *GitHubRepository) NeedsReadme() bool {
+	return !fetchedSincePush(r.Enrichment.ReadmeAt, r.PushedAt)
+}
+
+// NeedsContributors reports whether the contributor list must be (re)fetched
+func (r *GitHubRepository) NeedsContributors() bool {
+	return !fetchedSincePush(r.Enrichment.ContributorsAt, r.PushedAt)
+}
+
+// UpdateEnrichmentStatus derives Enrichment.Status from the field timestamps
+func (r *GitHubRepository) UpdateEnrichmentStatus() {
+	needed := []bool{r.NeedsReadme(), r.NeedsContributors()}
+	if r.Language != "" {
+		needed = append(needed, r.NeedsLanguages())
+	}
+
+	missing := 0
+	for _, n := range needed {
+		if n {
+			missing++
+		}
+	}
+
+	switch missing {
+	case 0:
+		r.Enrichment.Status = EnrichmentComplete
+	case len(needed):
+		r.Enrichment.Status = EnrichmentPending
+	default:
+		r.Enrichment.Status = EnrichmentPartial
+	}
+}
+
+func fetchedSincePush(fetchedAt, pushedAt time.Time) bool {
+	return !fetchedAt.IsZero() && !fetchedAt.Before(pushedAt)
 }
 
 type GitHubContributions struct {
 	ID                    primitive.ObjectID    `bson:"_id,omitempty" json:"id,omitempty"`
 	Username              string               `bson:"username" json:"username"`
 	TotalContributions    int                  `bson:"total_contributions" json:"total_contributions"`
+	Commits               int                  `bson:"commits" json:"commits"`
+	PullRequests          int                  `bson:"pull_requests" json:"pull_requests"`
+	Issues                int                  `bson:"issues" json:"issues"`
 	ContributionCalendar  []ContributionWeek   `bson:"contribution_calendar" json:"contribution_calendar"`
 	ContributionYears     []int                `bson:"contribution_years" json:"contribution_years"`
 	LongestStreak         int                  `bson:"longest_streak" json:"longest_streak"`
@@ -96,11 +171,50 @@ type GitHubStats struct {
 	IssuesOpened         int               `bson:"issues_opened" json:"issues_opened"`
 	PullRequestsOpened   int               `bson:"pull_requests_opened" json:"pull_requests_opened"`
 	PullRequestsMerged   int               `bson:"pull_requests_merged" json:"pull_requests_merged"`
+	OrganizationRepos    []OrgRepoContribution `bson:"organization_repos,omitempty" json:"organization_repos,omitempty"`
+	Owned                *RepoTotals       `bson:"owned,omitempty" json:"owned,omitempty"`
+	Contributed          *RepoTotals       `bson:"contributed,omitempty" json:"contributed,omitempty"`
 	LastFetched          time.Time         `bson:"last_fetched" json:"last_fetched"`
 }
 
+// ExternalContribution is a merged pull request the user authored in a
+// repository owned by someone else
+type ExternalContribution struct {
+	Username   string    `bson:"username" json:"-"`
+	Repository string    `bson:"repository" json:"repository"` // owner/name
+	Number     int       `bson:"number" json:"number"`
+	Title      string    `bson:"title" json:"title"`
+	URL        string    `bson:"url" json:"url"`
+	MergedAt   time.Time `bson:"merged_at" json:"merged_at"`
+}
+
+// RepoTotals splits the stats totals between the user's own repositories and
+// the organization repositories the user contributed to, when those are
+// included. Contributed stars and forks are the user's share of them.
+type RepoTotals struct {
+	Repos int `bson:"repos" json:"repos"`
+	Stars int `bson:"stars" json:"stars"`
+	Forks int `bson:"forks" json:"forks"`
+}
+
+// OrgRepoContribution is an organization repository the user committed to.
+// Stats credit the user with Share of its stars and forks.
+type OrgRepoContribution struct {
+	Organization string  `bson:"organization" json:"organization"`
+	Name         string  `bson:"name" json:"name"`
+	FullName     string  `bson:"full_name" json:"full_name"`
+	HTMLURL      string  `bson:"html_url" json:"html_url"`
+	Language     string  `bson:"language" json:"language"`
+	Stars        int     `bson:"stars" json:"stars"`
+	Forks        int     `bson:"forks" json:"forks"`
+	UserCommits  int     `bson:"user_commits" json:"user_commits"`
+	TotalCommits int     `bson:"total_commits" json:"total_commits"`
+	Share        float64 `bson:"share" json:"share"` // UserCommits / TotalCommits
+}
+
 type LanguageStat struct {
 	Name       string  `bson:"name" json:"name"`
+	Label      string  `bson:"-" json:"label,omitempty"` // added when served
 	Bytes      int     `bson:"bytes" json:"bytes"`
 	Percentage float64 `bson:"percentage" json:"percentage"`
 }
@@ -122,6 +236,136 @@ type ActivityStat struct {
 	Description string    `bson:"description" json:"description"`
 }
 
+// RepositoryQuery pages, sorts and filters a repository listing
+type RepositoryQuery struct {
+	Page     int
+	Limit    int
+	Sort     string // "stars", "updated" or "name"
+	Order    string // "asc" or "desc"; empty uses the natural order of Sort
+	Language string
+	Topic    string
+	Fork     *bool // nil keeps both
+	Archived *bool // nil keeps both
+}
+
+// AccountBreakdown is one account's share of a multi-account portfolio.
+// Repositories listed under several accounts count towards each of them.
+// Provider is set on the breakdowns of the aggregate stats, which mix hosts.
+type AccountBreakdown struct {
+	Provider      string `json:"provider,omitempty"`
+	Username      string `json:"username"`
+	Repos         int    `json:"repos"`
+	Stars         int    `json:"stars"`
+	Forks         int    `json:"forks"`
+	Contributions int    `json:"contributions"`
+}
+
+// PortfolioRepositories merges the repositories of every portfolio account
+type PortfolioRepositories struct {
+	Accounts       []string           `json:"accounts"`
+	Repositories   []GitHubRepository `json:"repositories"`
+	DuplicateRepos int                `json:"duplicate_repos"`
+	Breakdown      []AccountBreakdown `json:"breakdown"`
+}
+
+// PortfolioStats aggregates the stats of every portfolio account, counting
+// repositories listed under several accounts once
+type PortfolioStats struct {
+	Accounts           []string           `json:"accounts"`
+	TotalRepos         int                `json:"total_repos"`
+	TotalStars         int                `json:"total_stars"`
+	TotalForks         int                `json:"total_forks"`
+	TotalContributions int                `json:"total_contributions"`
+	DuplicateRepos     int                `json:"duplicate_repos"`
+	MostUsedLanguages  []LanguageStat     `json:"most_used_languages"`
+	TopRepositories    []RepoStat         `json:"top_repositories"`
+	Breakdown          []AccountBreakdown `json:"breakdown"`
+	LastFetched        time.Time          `json:"last_fetched"`
+}
+
+// GitHubRelease is a release published in one of the owner's repositories,
+// stored once seen so each is drafted into the changelog a single time
+type GitHubRelease struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Username    string             `bson:"username" json:"username"`
+	Repository  string             `bson:"repository" json:"repository"` // owner/name
+	Tag         string             `bson:"tag" json:"tag"`
+	Name        string             `bson:"name" json:"name"`
+	Body        string             `bson:"body" json:"body"`
+	URL         string             `bson:"url" json:"url"`
+	PublishedAt time.Time          `bson:"published_at" json:"published_at"`
+	SeenAt      time.Time          `bson:"seen_at" json:"seen_at"`
+}
+
+// PublicStats is the stats.json served to profile README card generators.
+// Its camelCase fields follow github-readme-stats and are kept stable.
+type PublicStats struct {
+	Name               string                    `json:"name"`
+	Username           string                    `json:"username"`
+	TotalStars         int                       `json:"totalStars"`
+	TotalCommits       int                       `json:"totalCommits"`
+	TotalPRs           int                       `json:"totalPRs"`
+	TotalIssues        int                       `json:"totalIssues"`
+	TotalContributions int                       `json:"totalContributions"`
+	Followers          int                       `json:"followers"`
+	TopLangs           map[string]PublicLanguage `json:"topLangs"`
+	UpdatedAt          time.Time                 `json:"updatedAt"`
+}
+
+// PublicLanguage is a language of PublicStats with its size in bytes
+type PublicLanguage struct {
+	Name string `json:"name"`
+	Size int    `json:"size"`
+}
+
+// CommitInsights summarizes the recent commit messages of a user across the
+// repositories they own
+type CommitInsights struct {
+	Username      string            `bson:"username" json:"username"`
+	Since         time.Time         `bson:"since" json:"since"`
+	Repositories  int               `bson:"repositories" json:"repositories"` // with commits since then
+	Commits       int               `bson:"commits" json:"commits"`
+	AverageLength float64           `bson:"average_length" json:"average_length"` // of the subject lines, in characters
+	TopTerms      []CommitTerm      `bson:"top_terms" json:"top_terms"`
+	Types         []CommitTypeShare `bson:"types" json:"types"`
+	LastFetched   time.Time         `bson:"last_fetched" json:"last_fetched"`
+}
+
+// CommitTerm is a word of the commit messages with how many used it
+type CommitTerm struct {
+	Term  string `bson:"term" json:"term"`
+	Count int    `bson:"count" json:"count"`
+}
+
+// CommitTypeShare counts the commits of a conventional commit type, such as
+// feat or fix; "other" counts those not following the convention
+type CommitTypeShare struct {
+	Type       string  `bson:"type" json:"type"`
+	Count      int     `bson:"count" json:"count"`
+	Percentage float64 `bson:"percentage" json:"percentage"`
+}
+
+// DigestSnapshot stores the numbers the next weekly digest is compared against
+type DigestSnapshot struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Username   string             `bson:"username" json:"username"`
+	TotalStars int                `bson:"total_stars" json:"total_stars"`
+	Followers  int                `bson:"followers" json:"followers"`
+	TotalRepos int                `bson:"total_repos" json:"total_repos"`
+	RepoStars  map[string]int     `bson:"repo_stars" json:"repo_stars"`
+	TakenAt    time.Time          `bson:"taken_at" json:"taken_at"`
+}
+
+// ReadmePublishResult describes a profile README publish attempt
+type ReadmePublishResult struct {
+	Repository  string    `json:"repository"`
+	Path        string    `json:"path"`
+	Changed     bool      `json:"changed"`
+	CommitSHA   string    `json:"commit_sha,omitempty"`
+	CommitURL   string    `json:"commit_url,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
 // GitHub API raw responses (for mapping)
 type GitHubAPIProfile struct {
 	Login             string    `json:"login"`
@@ -253,4 +497,157 @@ type GitHubAPIUser struct {
 	ReceivedEventsURL string `json:"received_events_url"`
 	Type              string `json:"type"`
 	SiteAdmin         bool   `json:"site_admin"`
-}
\ No newline at end of file
+}
+
+// StarredRepository is a repository the user starred, most recent first
+type StarredRepository struct {
+	FullName    string    `bson:"full_name" json:"full_name"`
+	Name        string    `bson:"name" json:"name"`
+	Owner       string    `bson:"owner" json:"owner"`
+	Description string    `bson:"description" json:"description"`
+	HTMLURL     string    `bson:"html_url" json:"html_url"`
+	Homepage    string    `bson:"homepage" json:"homepage"`
+	Language    string    `bson:"language" json:"language"`
+	Stars       int       `bson:"stars" json:"stars"`
+	Forks       int       `bson:"forks" json:"forks"`
+	Topics      []string  `bson:"topics" json:"topics"`
+	Archived    bool      `bson:"archived" json:"archived"`
+	PushedAt    time.Time `bson:"pushed_at" json:"pushed_at"`
+}
+
+// Gist is one of the user's public gists, most recently updated first
+type Gist struct {
+	ID          string     `bson:"id" json:"id"`
+	Description string     `bson:"description" json:"description"`
+	HTMLURL     string     `bson:"html_url" json:"html_url"`
+	Files       []GistFile `bson:"files" json:"files"`
+	Comments    int        `bson:"comments" json:"comments"`
+	CreatedAt   time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+// GistFile is a file of a gist; its content is fetched from RawURL
+type GistFile struct {
+	Filename string `bson:"filename" json:"filename"`
+	Language string `bson:"language" json:"language"`
+	Type     string `bson:"type" json:"type"`
+	Size     int    `bson:"size" json:"size"`
+	RawURL   string `bson:"raw_url" json:"raw_url"`
+}
+
+// RepositoryReadme is a repository's README rendered to sanitized HTML.
+// RawURL is where the Markdown source is downloaded from, next to the
+// images it references.
+type RepositoryReadme struct {
+	Repository string    `bson:"repository" json:"repository"` // owner/name
+	Path       string    `bson:"path" json:"path"`
+	HTMLURL    string    `bson:"html_url" json:"html_url"`
+	RawURL     string    `bson:"raw_url" json:"raw_url"`
+	HTML       string    `bson:"html" json:"html"`
+	FetchedAt  time.Time `bson:"fetched_at" json:"fetched_at"`
+}
+
+// RepositoryReleases is the version history of a repository: its latest
+// published releases and tags
+type RepositoryReleases struct {
+	Repository     string              `bson:"repository" json:"repository"` // owner/name
+	Releases       []RepositoryRelease `bson:"releases" json:"releases"`
+	Tags           []RepositoryTag     `bson:"tags" json:"tags"`
+	TotalDownloads int                 `bson:"total_downloads" json:"total_downloads"`
+	LastFetched    time.Time           `bson:"last_fetched" json:"last_fetched"`
+}
+
+// RepositoryRelease is a published release; Downloads sums its assets'
+type RepositoryRelease struct {
+	Tag         string         `bson:"tag" json:"tag"`
+	Name        string         `bson:"name" json:"name"`
+	Notes       string         `bson:"notes" json:"notes"`
+	HTMLURL     string         `bson:"html_url" json:"html_url"`
+	Prerelease  bool           `bson:"prerelease" json:"prerelease"`
+	PublishedAt time.Time      `bson:"published_at" json:"published_at"`
+	Assets      []ReleaseAsset `bson:"assets" json:"assets"`
+	Downloads   int            `bson:"downloads" json:"downloads"`
+}
+
+// ReleaseAsset is a file attached to a release
+type ReleaseAsset struct {
+	Name          string `bson:"name" json:"name"`
+	ContentType   string `bson:"content_type" json:"content_type"`
+	Size          int64  `bson:"size" json:"size"`
+	DownloadCount int    `bson:"download_count" json:"download_count"`
+	DownloadURL   string `bson:"download_url" json:"download_url"`
+}
+
+// RepositoryTag is a git tag, with or without a release
+type RepositoryTag struct {
+	Name string `bson:"name" json:"name"`
+	SHA  string `bson:"sha" json:"sha"`
+}
+
+// TrafficDay is a day of views and clones of a repository, as reported by
+// GitHub's traffic API
+type TrafficDay struct {
+	Repository   string    `bson:"repository" json:"-"` // owner/name
+	Date         string    `bson:"date" json:"date"`     // 2006-01-02, UTC
+	Views        int       `bson:"views" json:"views"`
+	UniqueViews  int       `bson:"unique_views" json:"unique_views"`
+	Clones       int       `bson:"clones" json:"clones"`
+	UniqueClones int       `bson:"unique_clones" json:"unique_clones"`
+	CollectedAt  time.Time `bson:"collected_at" json:"collected_at"`
+}
+
+// RepositoryTraffic is the stored traffic history of a repository, oldest
+// day first
+type RepositoryTraffic struct {
+	Repository string       `json:"repository"`
+	Views      int          `json:"views"`
+	Clones     int          `json:"clones"`
+	Days       []TrafficDay `json:"days"`
+}
+
+// CommitActivity is a year of weekly commit counts, of a repository or
+// summed over a user's own repositories. Pending lists the repositories
+// GitHub was still computing and that are missing from the sums.
+type CommitActivity struct {
+	Repository  string       `bson:"repository,omitempty" json:"repository,omitempty"` // owner/name
+	Username    string       `bson:"username,omitempty" json:"username,omitempty"`
+	Total       int          `bson:"total" json:"total"`
+	Weeks       []CommitWeek `bson:"weeks" json:"weeks"`
+	Pending     []string     `bson:"pending,omitempty" json:"pending,omitempty"`
+	LastFetched time.Time    `bson:"last_fetched" json:"last_fetched"`
+}
+
+// CommitWeek counts the commits of a week starting on Sunday, per day
+type CommitWeek struct {
+	Week  string `bson:"week" json:"week"` // 2006-01-02
+	Total int    `bson:"total" json:"total"`
+	Days  []int  `bson:"days" json:"days"` // Sunday first
+}
+
+// CodeFrequency is the weekly count of lines added and deleted in a
+// repository over its history
+type CodeFrequency struct {
+	Repository  string              `bson:"repository" json:"repository"` // owner/name
+	Additions   int                 `bson:"additions" json:"additions"`
+	Deletions   int                 `bson:"deletions" json:"deletions"`
+	Weeks       []CodeFrequencyWeek `bson:"weeks" json:"weeks"`
+	LastFetched time.Time           `bson:"last_fetched" json:"last_fetched"`
+}
+
+// CodeFrequencyWeek is a week of additions and deletions, both positive
+type CodeFrequencyWeek struct {
+	Week      string `bson:"week" json:"week"` // 2006-01-02
+	Additions int    `bson:"additions" json:"additions"`
+	Deletions int    `bson:"deletions" json:"deletions"`
+}
+
+// GitHubTokenQuota is the rate limit GitHub last reported for a pooled
+// token, identified by its last characters
+type GitHubTokenQuota struct {
+	Token     string     `json:"token"`
+	Primary   bool       `json:"primary"`
+	Remaining *int       `json:"remaining,omitempty"`
+	Limit     int        `json:"limit,omitempty"`
+	Reset     *time.Time `json:"reset,omitempty"`
+	Exhausted bool       `json:"exhausted"`
+}
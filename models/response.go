@@ -4,6 +4,9 @@ import (
 	"time"
 )
 
+// APIVersion is reported in every response envelope
+const APIVersion = "1.0.0"
+
 // Standard API Response structures
 type APIResponse struct {
 	Success   bool        `json:"success"`
@@ -42,6 +45,20 @@ type ErrorResponse struct {
 	RequestID string    `json:"request_id,omitempty"`
 }
 
+// Problem is an RFC 7807 problem details body, sent in place of an
+// ErrorResponse to clients that accept application/problem+json
+type Problem struct {
+	Type      string      `json:"type"`
+	Title     string      `json:"title"`
+	Status    int         `json:"status"`
+	Detail    string      `json:"detail,omitempty"`
+	Instance  string      `json:"instance,omitempty"`
+	Code      string      `json:"code,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+	Errors    interface{} `json:"errors,omitempty"`
+}
+
 // Health check response
 type HealthResponse struct {
 	Status     string                 `json:"status"`
@@ -77,10 +94,19 @@ type APIInfoResponse struct {
 	Uptime      string            `json:"uptime"`
 	Timestamp   time.Time         `json:"timestamp"`
 	Endpoints   map[string]string `json:"endpoints"`
+	Features    FeatureFlags      `json:"features"`
 	Contact     ContactInfo       `json:"contact"`
 	License     string            `json:"license"`
 }
 
+// FeatureFlags lists optional capabilities so clients can hide unsupported sections
+type FeatureFlags struct {
+	Authenticated bool      `json:"authenticated"`
+	Contributions bool      `json:"contributions"`
+	Traffic       bool      `json:"traffic"`
+	DetectedAt    time.Time `json:"detected_at"`
+}
+
 type ContactInfo struct {
 	Name  string `json:"name"`
 	Email string `json:"email"`
@@ -115,29 +141,70 @@ type GitHubAnalytics struct {
 
 type PerformanceMetrics struct {
 	AverageResponseTime  float64 `json:"average_response_time"`
+	P50ResponseTime     float64 `json:"p50_response_time"` // over the most recent requests
+	P95ResponseTime     float64 `json:"p95_response_time"`
+	P99ResponseTime     float64 `json:"p99_response_time"`
 	TotalRequests       int64   `json:"total_requests"`
 	ErrorRate           float64 `json:"error_rate"`
 	CacheHitRate        float64 `json:"cache_hit_rate"`
 	DatabaseConnections int     `json:"database_connections"`
+	Endpoints           []EndpointStat `json:"endpoints,omitempty"` // per route template
+}
+
+// CacheCounters counts cache lookups and writes since the process started
+type CacheCounters struct {
+	Hits      int64   `json:"hits"`
+	Misses    int64   `json:"misses"`
+	Sets      int64   `json:"sets"`
+	Evictions int64   `json:"evictions"` // dropped from memory to make room or expired in MongoDB
+	HitRate   float64 `json:"hit_rate"`
 }
 
 type TrafficMetrics struct {
+	Since          time.Time              `json:"since"`
+	Until          time.Time              `json:"until"`
 	UniqueVisitors int                    `json:"unique_visitors"`
 	PageViews      int                    `json:"page_views"`
 	TopEndpoints   []EndpointStat         `json:"top_endpoints"`
-	GeographicData map[string]interface{} `json:"geographic_data"`
+	TopReferrers   []ReferrerStat         `json:"top_referrers"`
+	GeographicData map[string]interface{} `json:"geographic_data"` // page views per country code
+}
+
+type ReferrerStat struct {
+	Referrer string `bson:"_id" json:"referrer"` // host of the referring page
+	Views    int    `bson:"views" json:"views"`
+}
+
+// PageView is an anonymized API request recorded for the traffic analytics
+type PageView struct {
+	Visitor    string    `bson:"visitor"` // keyed hash of the client IP
+	Endpoint   string    `bson:"endpoint"` // method and route template
+	Path       string    `bson:"path"`
+	Referrer   string    `bson:"referrer,omitempty"` // host only
+	UserAgent  string    `bson:"user_agent,omitempty"`
+	Country    string    `bson:"country,omitempty"` // ISO code reported by the CDN
+	Status     int       `bson:"status"`
+	DurationMs float64   `bson:"duration_ms"`
+	Weight     float64   `bson:"weight,omitempty"` // requests this view stands for when sampled
+	Timestamp  time.Time `bson:"timestamp"`
 }
 
 type EndpointStat struct {
-	Endpoint string  `json:"endpoint"`
-	Hits     int     `json:"hits"`
-	AvgTime  float64 `json:"avg_time"`
+	Endpoint  string  `json:"endpoint"`
+	Hits      int     `json:"hits"`
+	Errors    int     `json:"errors"` // 5xx responses
+	ErrorRate float64 `json:"error_rate"`
+	AvgTime   float64 `json:"avg_time"`
+	P50Time   float64 `json:"p50_time"` // over the endpoint's most recent requests
+	P95Time   float64 `json:"p95_time"`
+	P99Time   float64 `json:"p99_time"`
 }
 
 // Request/Response validation structures
 type ContentUpdateRequest struct {
-	Type string      `json:"type" validate:"required,oneof=meta skills experience projects education"`
-	Data interface{} `json:"data" validate:"required"`
+	Type  string      `json:"type" validate:"required,oneof=meta skills experience projects education"`
+	Data  interface{} `json:"data" validate:"required"`
+	State string      `json:"state,omitempty"` // "draft" stages the edit instead of publishing it
 }
 
 type GitHubSyncRequest struct {
@@ -160,4 +227,40 @@ type RateLimitResponse struct {
 	Remaining int       `json:"remaining"`
 	Reset     time.Time `json:"reset"`
 	Window    string    `json:"window"`
-}
\ No newline at end of file
+}
+// Weekly digest structures
+type Digest struct {
+	Username        string                `json:"username"`
+	PeriodStart     time.Time             `json:"period_start"`
+	PeriodEnd       time.Time             `json:"period_end"`
+	Sections        []string              `json:"sections"`
+	TotalStars      int                   `json:"total_stars"`
+	StarsGained     int                   `json:"stars_gained"`
+	Followers       int                   `json:"followers"`
+	NewFollowers    int                   `json:"new_followers"`
+	TopRepositories []RepoStarDelta       `json:"top_repositories"`
+	ContentChanges  []DigestContentChange `json:"content_changes"`
+}
+
+// Has reports whether the digest includes the named section
+func (d *Digest) Has(section string) bool {
+	for _, s := range d.Sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+type RepoStarDelta struct {
+	Name   string `json:"name"`
+	Stars  int    `json:"stars"`
+	Gained int    `json:"gained"`
+}
+
+type DigestContentChange struct {
+	Type      string    `json:"type"`
+	Version   int       `json:"version"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
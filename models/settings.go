@@ -0,0 +1,562 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Setting is a runtime-editable configuration value stored in MongoDB
+type Setting struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Key       string             `bson:"key" json:"key"`
+	Value     interface{}        `bson:"value" json:"value"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+	UpdatedBy string             `bson:"updated_by" json:"updated_by"`
+}
+
+// DeployHook is an outbound URL POSTed to when published content changes
+type DeployHook struct {
+	Name    string `bson:"name" json:"name" validate:"required"`
+	URL     string `bson:"url" json:"url" validate:"required,url"`
+	Enabled bool   `bson:"enabled" json:"enabled"`
+}
+
+// DeployHookRun records a single delivery attempt of a deploy hook
+type DeployHookRun struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Hook        string             `bson:"hook" json:"hook"`
+	URL         string             `bson:"url" json:"url"`
+	Trigger     string             `bson:"trigger" json:"trigger"` // "manual" or "content:<types>"
+	Success     bool               `bson:"success" json:"success"`
+	StatusCode  int                `bson:"status_code,omitempty" json:"status_code,omitempty"`
+	Error       string             `bson:"error,omitempty" json:"error,omitempty"`
+	DurationMs  int64              `bson:"duration_ms" json:"duration_ms"`
+	Attempts    int                `bson:"attempts,omitempty" json:"attempts,omitempty"`
+	TriggeredAt time.Time          `bson:"triggered_at" json:"triggered_at"`
+}
+
+// DeployHooksUpdateRequest replaces the configured deploy hooks
+type DeployHooksUpdateRequest struct {
+	Hooks []DeployHook `json:"hooks"`
+}
+
+// CDN providers
+const (
+	CDNProviderCloudflare = "cloudflare"
+	CDNProviderFastly     = "fastly"
+)
+
+// CDNIntegration purges the frontend's public URLs from a CDN when published
+// content changes
+type CDNIntegration struct {
+	Name            string              `bson:"name" json:"name"`
+	Provider        string              `bson:"provider" json:"provider"`                   // "cloudflare" or "fastly"
+	BaseURL         string              `bson:"base_url" json:"base_url"`                   // frontend origin, e.g. "https://example.com"
+	ZoneID          string              `bson:"zone_id,omitempty" json:"zone_id,omitempty"` // Cloudflare only
+	APIToken        string              `bson:"api_token" json:"api_token,omitempty"`
+	TokenConfigured bool                `bson:"-" json:"token_configured"`
+	Paths           []string            `bson:"paths" json:"paths"`                                     // purged on every change, defaults to "/" and "/sitemap.xml"
+	ContentPaths    map[string][]string `bson:"content_paths,omitempty" json:"content_paths,omitempty"` // extra paths per content type, e.g. "projects": ["/projects"]
+	PurgeByTag      bool                `bson:"purge_by_tag" json:"purge_by_tag"`                       // purge surrogate keys instead of URLs
+	ServiceID       string              `bson:"service_id,omitempty" json:"service_id,omitempty"`       // Fastly only, required to purge by tag
+	Enabled         bool                `bson:"enabled" json:"enabled"`
+}
+
+// CDNPurgeRun records a single purge request sent to a CDN
+type CDNPurgeRun struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Integration string             `bson:"integration" json:"integration"`
+	Provider    string             `bson:"provider" json:"provider"`
+	URLs        []string           `bson:"urls" json:"urls"`
+	Tags        []string           `bson:"tags,omitempty" json:"tags,omitempty"` // surrogate keys, when purging by tag
+	Trigger     string             `bson:"trigger" json:"trigger"`               // "manual", "content:<types>" and/or "sync:<usernames>"
+	Success     bool               `bson:"success" json:"success"`
+	StatusCode  int                `bson:"status_code,omitempty" json:"status_code,omitempty"`
+	Error       string             `bson:"error,omitempty" json:"error,omitempty"`
+	DurationMs  int64              `bson:"duration_ms" json:"duration_ms"`
+	PurgedAt    time.Time          `bson:"purged_at" json:"purged_at"`
+}
+
+// CDNIntegrationsUpdateRequest replaces the configured CDN integrations
+type CDNIntegrationsUpdateRequest struct {
+	Integrations []CDNIntegration `json:"integrations"`
+}
+
+// OwnerSettings selects the GitHub account the portfolio showcases and any
+// extra accounts, such as an organization, merged into it
+type OwnerSettings struct {
+	GitHubUsername string   `bson:"github_username" json:"github_username"`
+	Accounts       []string `bson:"accounts" json:"accounts"`
+}
+
+// SiteDomain serves another owner's portfolio on one of the domains this
+// deployment backs
+type SiteDomain struct {
+	Domain      string        `bson:"domain" json:"domain"` // host name, e.g. "alice.dev"
+	Owner       OwnerSettings `bson:"owner" json:"owner"`
+	CORSOrigins []string      `bson:"cors_origins,omitempty" json:"cors_origins,omitempty"` // frontend origins allowed on top of CORS_ORIGINS
+}
+
+// SiteDomainsUpdateRequest replaces the domain to owner mapping
+type SiteDomainsUpdateRequest struct {
+	Domains []SiteDomain `json:"domains"`
+}
+
+// DisplayLabels renames raw GitHub topics and language names, and the skills
+// named after them, for display in each locale
+type DisplayLabels struct {
+	DefaultLocale string                    `bson:"default_locale" json:"default_locale"` // used when a request names no locale, or one without labels
+	Locales       map[string][]DisplayLabel `bson:"locales" json:"locales"`               // keyed by locale, e.g. "pt-BR"
+}
+
+// DisplayLabel shows each raw name in From as Label. Names match regardless
+// of case; topics sharing a label are listed once.
+type DisplayLabel struct {
+	From  []string `bson:"from" json:"from"` // e.g. "machine-learning" and "aprendizado-de-maquina"
+	Label string   `bson:"label" json:"label"`
+}
+
+// PrivateDetails holds job-search terms that are only shown to recruiters
+// holding a token issued by the owner
+type PrivateDetails struct {
+	RateExpectation   string   `bson:"rate_expectation" json:"rate_expectation"` // e.g. "USD 70-90/h"
+	SalaryExpectation string   `bson:"salary_expectation" json:"salary_expectation"`
+	ContractTypes     []string `bson:"contract_types" json:"contract_types"` // e.g. "full-time", "contractor"
+	VisaStatus        string   `bson:"visa_status" json:"visa_status"`
+	OpenToRelocation  bool     `bson:"open_to_relocation" json:"open_to_relocation"`
+	RelocationNotes   string   `bson:"relocation_notes" json:"relocation_notes"`
+}
+
+// RecruiterToken grants one recruiter time-limited access to the private
+// details. Only a hash of the token is stored.
+type RecruiterToken struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Label      string             `bson:"label" json:"label"` // who the token was issued to
+	TokenHash  string             `bson:"token_hash" json:"-"`
+	Token      string             `bson:"-" json:"token,omitempty"` // only returned when the token is created
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	CreatedBy  string             `bson:"created_by" json:"created_by"`
+	ExpiresAt  time.Time          `bson:"expires_at" json:"expires_at"`
+	RevokedAt  *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	UseCount   int                `bson:"use_count" json:"use_count"`
+	LastUsedAt *time.Time         `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	LastUsedIP string             `bson:"last_used_ip,omitempty" json:"last_used_ip,omitempty"`
+}
+
+// RecruiterTokenRequest issues a recruiter token
+type RecruiterTokenRequest struct {
+	Label     string `json:"label"`
+	ExpiresIn string `json:"expires_in"` // Go duration, defaults to RECRUITER_TOKEN_TTL
+}
+
+// ResumeLink is a signed resume download URL issued to one recipient, so the
+// owner can tell which recruiters opened the resume
+type ResumeLink struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Recipient      string             `bson:"recipient" json:"recipient"`
+	URL            string             `bson:"-" json:"url"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	CreatedBy      string             `bson:"created_by" json:"created_by"`
+	ExpiresAt      time.Time          `bson:"expires_at" json:"expires_at"`
+	RevokedAt      *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	Downloads      int                `bson:"downloads" json:"downloads"`
+	LastDownloadAt *time.Time         `bson:"last_download_at,omitempty" json:"last_download_at,omitempty"`
+}
+
+// ResumeLinkRequest issues a resume link
+type ResumeLinkRequest struct {
+	Recipient string `json:"recipient"`
+	ExpiresIn string `json:"expires_in"` // Go duration, defaults to RESUME_LINK_TTL
+}
+
+// ResumeDownload records a single download through a resume link
+type ResumeDownload struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	LinkID       primitive.ObjectID `bson:"link_id" json:"link_id"`
+	Recipient    string             `bson:"recipient" json:"recipient"`
+	IP           string             `bson:"ip" json:"ip"`
+	UserAgent    string             `bson:"user_agent" json:"user_agent"`
+	DownloadedAt time.Time          `bson:"downloaded_at" json:"downloaded_at"`
+}
+
+// TelegramSettings configures the Telegram bot used for alerts and queries
+type TelegramSettings struct {
+	BotToken string `bson:"bot_token" json:"bot_token,omitempty"`
+	ChatID   string `bson:"chat_id" json:"chat_id"`
+	Enabled  bool   `bson:"enabled" json:"enabled"`
+}
+
+// Sync modes
+const (
+	SyncModeIncremental = "incremental"
+	SyncModeForce       = "force"
+)
+
+// SyncStatus records the outcome of a GitHub sync; the most recent one is
+// kept in settings
+type SyncStatus struct {
+	Username         string     `bson:"username" json:"username"`
+	Mode             string     `bson:"mode" json:"mode"`
+	Success          bool       `bson:"success" json:"success"`
+	Error            string     `bson:"error,omitempty" json:"error,omitempty"`
+	Steps            []SyncStep `bson:"steps" json:"steps"`
+	Repositories     int        `bson:"repositories" json:"repositories"`
+	LanguagesFetched int        `bson:"languages_fetched" json:"languages_fetched"`
+	StartedAt        time.Time  `bson:"started_at" json:"started_at"`
+	SyncedAt         time.Time  `bson:"synced_at" json:"synced_at"`
+	DurationMs       int64      `bson:"duration_ms" json:"duration_ms"`
+}
+
+// SyncSchedule is the state of the scheduled background sync
+type SyncSchedule struct {
+	Enabled   bool       `json:"enabled"`
+	Cron      string     `json:"cron,omitempty"`
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	Running   bool       `json:"running"`
+	Attempt   int        `json:"attempt,omitempty"` // current try of a failing run, from 1
+	Error     string     `json:"error,omitempty"`   // why the schedule is disabled
+}
+
+// SyncOverview reports the last sync and the upcoming scheduled one
+type SyncOverview struct {
+	LastSync *SyncStatus  `json:"last_sync"`
+	Schedule SyncSchedule `json:"schedule"`
+}
+
+// SyncStep times a single stage of a sync
+type SyncStep struct {
+	Name       string `bson:"name" json:"name"`
+	DurationMs int64  `bson:"duration_ms" json:"duration_ms"`
+	Error      string `bson:"error,omitempty" json:"error,omitempty"`
+}
+
+// Rate limit tiers
+const (
+	RateLimitTierAnonymous     = "anonymous"
+	RateLimitTierAuthenticated = "authenticated"
+)
+
+// RateLimitTier is the request budget of one caller tier
+type RateLimitTier struct {
+	Requests int    `bson:"requests" json:"requests"`
+	Window   string `bson:"window" json:"window"` // Go duration, e.g. "1h"
+}
+
+// RateLimitTiers holds the limits for anonymous and authenticated callers
+type RateLimitTiers struct {
+	Anonymous     RateLimitTier `bson:"anonymous" json:"anonymous"`
+	Authenticated RateLimitTier `bson:"authenticated" json:"authenticated"`
+}
+
+// AnalyticsSampling sets the share of requests recorded in the traffic
+// analytics. Rates go from 0 (none) to 1 (all).
+type AnalyticsSampling struct {
+	DefaultRate float64 `bson:"default_rate" json:"default_rate"`
+	// Groups overrides the default rate for routes under a path prefix,
+	// e.g. "/api/v1/github"; the longest matching prefix wins
+	Groups             map[string]float64 `bson:"groups,omitempty" json:"groups,omitempty"`
+	AlwaysSampleErrors bool               `bson:"always_sample_errors" json:"always_sample_errors"`
+}
+
+// Endorsement statuses; only approved endorsements are shown publicly
+const (
+	EndorsementApproved = "approved"
+	EndorsementPending  = "pending"
+	EndorsementHidden   = "hidden"
+)
+
+// Endorsement is a skill vouched for by a visitor signed in with GitHub,
+// at most one per GitHub account and skill
+type Endorsement struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Skill      string             `bson:"skill" json:"skill"` // lowercased skill name
+	EndorserID int64              `bson:"endorser_id" json:"endorser_id"`
+	Login      string             `bson:"login" json:"login"`
+	AvatarURL  string             `bson:"avatar_url" json:"avatar_url"`
+	Status     string             `bson:"status" json:"status"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// Endorser is the public view of an approved endorsement
+type Endorser struct {
+	Login      string    `bson:"login" json:"login"`
+	AvatarURL  string    `bson:"avatar_url" json:"avatar_url"`
+	EndorsedAt time.Time `bson:"endorsed_at" json:"endorsed_at"`
+}
+
+// EndorsementSummary is served with each skill
+type EndorsementSummary struct {
+	Count  int        `bson:"count" json:"count"`
+	Recent []Endorser `bson:"recent" json:"recent"` // newest first
+}
+
+// EndorsementStatusRequest moderates an endorsement
+type EndorsementStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+// EndorsementBlocklist lists GitHub logins that may not endorse skills
+type EndorsementBlocklist struct {
+	Logins []string `bson:"logins" json:"logins"`
+}
+
+// GitHubOAuthRequest exchanges the code GitHub redirected an endorser back
+// with for an access token
+type GitHubOAuthRequest struct {
+	Code        string `json:"code" binding:"required"`
+	RedirectURI string `json:"redirect_uri"`
+}
+
+// GitHubOAuthSession is the access token endorsements are sent with
+type GitHubOAuthSession struct {
+	AccessToken string `json:"access_token"`
+	Login       string `json:"login"`
+	AvatarURL   string `json:"avatar_url"`
+}
+
+// FeedbackRatings maps the emoji visitors rate projects with to a score
+var FeedbackRatings = map[string]int{
+	"😞": 1,
+	"😐": 2,
+	"🙂": 3,
+	"😀": 4,
+	"😍": 5,
+}
+
+// ProjectFeedback is a visitor's rating of a project, with an optional
+// comment. Feedback flagged as spam is kept out of the summaries.
+type ProjectFeedback struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Project   string             `bson:"project" json:"project"` // project slug
+	Rating    string             `bson:"rating" json:"rating"`
+	Score     int                `bson:"score" json:"score"`
+	Comment   string             `bson:"comment,omitempty" json:"comment,omitempty"`
+	Visitor   string             `bson:"visitor" json:"-"` // keyed hash of the client IP
+	Spam      bool               `bson:"spam" json:"spam"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// ProjectFeedbackRequest submits feedback on a project
+type ProjectFeedbackRequest struct {
+	Rating  string `json:"rating" binding:"required"`
+	Comment string `json:"comment"`
+	// Website is a honeypot: hidden from people, filled in by bots
+	Website string `json:"website"`
+}
+
+// ProjectFeedbackSummary aggregates the feedback on one project
+type ProjectFeedbackSummary struct {
+	Project        string            `json:"project"`
+	Count          int               `json:"count"`
+	AverageScore   float64           `json:"average_score"`
+	Ratings        map[string]int    `json:"ratings"`         // count per emoji
+	RecentComments []ProjectFeedback `json:"recent_comments"` // newest first
+}
+
+// ContactMessage is a message sent through the contact form. Messages
+// flagged as spam are stored but not emailed.
+type ContactMessage struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name      string             `bson:"name" json:"name"`
+	Email     string             `bson:"email" json:"email"`
+	Subject   string             `bson:"subject,omitempty" json:"subject,omitempty"`
+	Message   string             `bson:"message" json:"message"`
+	Visitor   string             `bson:"visitor" json:"-"` // keyed hash of the client IP
+	Spam      bool               `bson:"spam" json:"spam"`
+	Notified  bool               `bson:"notified" json:"notified"` // emailed to the owner
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// ContactMessageRequest sends a message through the contact form
+type ContactMessageRequest struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+	// Website is a honeypot: hidden from people, filled in by bots
+	Website string `json:"website"`
+}
+
+// Export formats
+const (
+	ExportFormatJSON   = "json"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatCSV    = "csv"
+)
+
+// ExportQuery selects the documents streamed by a collection export
+type ExportQuery struct {
+	Since  time.Time // only documents created or updated since then
+	Cursor string    // resume after the document with this _id
+	Limit  int64     // 0 exports every matching document
+}
+
+// Job types run by the background workers
+const (
+	JobGitHubSync         = "github_sync"
+	JobCacheWarm          = "cache_warm"
+	JobAnalyticsAggregate = "analytics_aggregate"
+	JobExport             = "export"
+	JobCommitInsights     = "commit_insights"
+)
+
+// Job statuses. A failed attempt puts the job back in the queue until it runs
+// out of attempts and is dead-lettered.
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobDead      = "dead"
+	JobCanceled  = "canceled"
+)
+
+// Job is a long-running task queued in MongoDB for the workers
+type Job struct {
+	ID          primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	Type        string                 `bson:"type" json:"type"`
+	Params      map[string]string      `bson:"params,omitempty" json:"params,omitempty"`
+	Status      string                 `bson:"status" json:"status"`
+	Attempts    int                    `bson:"attempts" json:"attempts"`
+	MaxAttempts int                    `bson:"max_attempts" json:"max_attempts"`
+	RunAt       time.Time              `bson:"run_at" json:"run_at"` // not picked up before then
+	Worker      string                 `bson:"worker,omitempty" json:"worker,omitempty"`
+	LockedUntil *time.Time             `bson:"locked_until,omitempty" json:"-"`
+	Result      map[string]interface{} `bson:"result,omitempty" json:"result,omitempty"`
+	Error       string                 `bson:"error,omitempty" json:"error,omitempty"` // of the last attempt
+	CreatedBy   string                 `bson:"created_by" json:"created_by"`
+	CreatedAt   time.Time              `bson:"created_at" json:"created_at"`
+	StartedAt   *time.Time             `bson:"started_at,omitempty" json:"started_at,omitempty"`
+	FinishedAt  *time.Time             `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+}
+
+// JobRequest enqueues a job
+type JobRequest struct {
+	Type   string            `json:"type" binding:"required"`
+	Params map[string]string `json:"params"`
+}
+
+// JobExportResult points at the file an export job wrote
+type JobExportResult struct {
+	FileID    primitive.ObjectID `bson:"file_id" json:"file_id"`
+	Filename  string             `bson:"filename" json:"filename"`
+	Documents int                `bson:"documents" json:"documents"`
+}
+
+// Kinds of writes a dry run reports
+const (
+	DryRunSetting  = "setting"
+	DryRunContent  = "content"
+	DryRunDocument = "document"
+)
+
+// DryRunChange is a write a dry-run request would have made
+type DryRunChange struct {
+	Kind          string      `json:"kind"`
+	Target        string      `json:"target"`           // setting key, content type or collection/id
+	Create        bool        `json:"create,omitempty"` // nothing is stored yet
+	VersionFrom   int64       `json:"version_from,omitempty"`
+	VersionTo     int64       `json:"version_to,omitempty"`
+	Diff          []FieldDiff `json:"diff"`
+	Invalidations []string    `json:"invalidations,omitempty"` // cache key patterns dropped
+	SideEffects   []string    `json:"side_effects,omitempty"`
+}
+
+// FieldDiff is a value that would change, keyed by JSON pointer
+type FieldDiff struct {
+	Path   string      `json:"path"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// DryRunPlan is the answer to a dry-run request: what it would have changed
+// and the response it would have received
+type DryRunPlan struct {
+	DryRun   bool            `json:"dry_run"`
+	Changes  []DryRunChange  `json:"changes"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// Topics of the live updates WebSocket
+const (
+	LiveTopicContent   = "content"
+	LiveTopicGitHub    = "github"
+	LiveTopicAnalytics = "analytics"
+)
+
+// LiveTopics lists every live updates topic, the default subscription
+var LiveTopics = []string{LiveTopicContent, LiveTopicGitHub, LiveTopicAnalytics}
+
+// Live update events, one kind per topic
+const (
+	LiveContentUpdated = "content.updated"
+	LiveGitHubSynced   = "github.synced"
+	LiveGitHubRelease  = "github.release"
+	LivePageViews      = "analytics.page_views"
+)
+
+// Messages of the live updates WebSocket besides events. Clients send
+// subscribe, unsubscribe and ping; the server answers with subscribed, pong
+// or error and sends a heartbeat while idle.
+const (
+	LiveSubscribe   = "subscribe"
+	LiveUnsubscribe = "unsubscribe"
+	LivePing        = "ping"
+	LiveSubscribed  = "subscribed"
+	LivePong        = "pong"
+	LiveHeartbeat   = "heartbeat"
+	LiveError       = "error"
+)
+
+// LiveMessage is a message of the live updates WebSocket, in either direction
+type LiveMessage struct {
+	Type      string      `json:"type"`
+	Topic     string      `json:"topic,omitempty"`  // of an event
+	Topics    []string    `json:"topics,omitempty"` // to (un)subscribe, or subscribed to
+	Data      interface{} `json:"data,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	Code      string      `json:"code,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// CachedResponse is a whole response stored by the route cache
+type CachedResponse struct {
+	Status int                 `json:"status"`
+	Header map[string][]string `json:"header"`
+	Body   []byte              `json:"body"`
+}
+
+// AuditEntry records a write made by an authenticated caller
+type AuditEntry struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Action    string             `bson:"action" json:"action"` // method and route template
+	Path      string             `bson:"path" json:"path"`
+	Params    map[string]string  `bson:"params,omitempty" json:"params,omitempty"` // of the route
+	Actor     string             `bson:"actor" json:"actor"`                       // admin, user or api
+	IP        string             `bson:"ip" json:"ip"`
+	RequestID string             `bson:"request_id" json:"request_id"`
+	Status    int                `bson:"status" json:"status"`
+	Changes   []AuditChange      `bson:"changes,omitempty" json:"changes,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// AuditChange is a snapshot of something a write changed, as relaxed
+// Extended JSON
+type AuditChange struct {
+	Target string      `bson:"target" json:"target"` // setting key, content type or collection/id
+	Before interface{} `bson:"before" json:"before"` // null when created
+	After  interface{} `bson:"after" json:"after"`
+}
+
+// AuditQuery filters the audit log
+type AuditQuery struct {
+	Actor     string
+	Action    string // prefix such as "PUT /api/v1/admin", or a route template prefix for any method
+	Target    string
+	RequestID string
+	Since     time.Time
+	Until     time.Time
+	Page      int
+	Limit     int
+}
@@ -0,0 +1,2280 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.28.3
+// source: portfolio/v1/portfolio.proto
+
+// Read-only access to the portfolio content and GitHub data, mirroring the
+// public REST endpoints for internal services and the CLI.
+
+package portfoliov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetMetaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMetaRequest) Reset() {
+	*x = GetMetaRequest{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetaRequest) ProtoMessage() {}
+
+func (x *GetMetaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetaRequest.ProtoReflect.Descriptor instead.
+func (*GetMetaRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{0}
+}
+
+type GetSkillsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSkillsRequest) Reset() {
+	*x = GetSkillsRequest{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSkillsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSkillsRequest) ProtoMessage() {}
+
+func (x *GetSkillsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSkillsRequest.ProtoReflect.Descriptor instead.
+func (*GetSkillsRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{1}
+}
+
+type ListExperienceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExperienceRequest) Reset() {
+	*x = ListExperienceRequest{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExperienceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExperienceRequest) ProtoMessage() {}
+
+func (x *ListExperienceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExperienceRequest.ProtoReflect.Descriptor instead.
+func (*ListExperienceRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{2}
+}
+
+type ListProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{3}
+}
+
+type ListEducationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEducationRequest) Reset() {
+	*x = ListEducationRequest{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEducationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEducationRequest) ProtoMessage() {}
+
+func (x *ListEducationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEducationRequest.ProtoReflect.Descriptor instead.
+func (*ListEducationRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{4}
+}
+
+type Meta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Github        string                 `protobuf:"bytes,4,opt,name=github,proto3" json:"github,omitempty"`
+	Email         string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Linkedin      string                 `protobuf:"bytes,6,opt,name=linkedin,proto3" json:"linkedin,omitempty"`
+	Website       string                 `protobuf:"bytes,7,opt,name=website,proto3" json:"website,omitempty"`
+	Bio           string                 `protobuf:"bytes,8,opt,name=bio,proto3" json:"bio,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Meta) Reset() {
+	*x = Meta{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Meta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Meta) ProtoMessage() {}
+
+func (x *Meta) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Meta.ProtoReflect.Descriptor instead.
+func (*Meta) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Meta) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Meta) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Meta) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Meta) GetGithub() string {
+	if x != nil {
+		return x.Github
+	}
+	return ""
+}
+
+func (x *Meta) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Meta) GetLinkedin() string {
+	if x != nil {
+		return x.Linkedin
+	}
+	return ""
+}
+
+func (x *Meta) GetWebsite() string {
+	if x != nil {
+		return x.Website
+	}
+	return ""
+}
+
+func (x *Meta) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+type Skill struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Level          int32                  `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	Category       string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Icon           string                 `protobuf:"bytes,4,opt,name=icon,proto3" json:"icon,omitempty"`
+	YearsExp       int32                  `protobuf:"varint,5,opt,name=years_exp,json=yearsExp,proto3" json:"years_exp,omitempty"`
+	Certifications []string               `protobuf:"bytes,6,rep,name=certifications,proto3" json:"certifications,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Skill) Reset() {
+	*x = Skill{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Skill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Skill) ProtoMessage() {}
+
+func (x *Skill) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Skill.ProtoReflect.Descriptor instead.
+func (*Skill) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Skill) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Skill) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Skill) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Skill) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *Skill) GetYearsExp() int32 {
+	if x != nil {
+		return x.YearsExp
+	}
+	return 0
+}
+
+func (x *Skill) GetCertifications() []string {
+	if x != nil {
+		return x.Certifications
+	}
+	return nil
+}
+
+type Skills struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backend       []*Skill               `protobuf:"bytes,1,rep,name=backend,proto3" json:"backend,omitempty"`
+	Frontend      []*Skill               `protobuf:"bytes,2,rep,name=frontend,proto3" json:"frontend,omitempty"`
+	Database      []*Skill               `protobuf:"bytes,3,rep,name=database,proto3" json:"database,omitempty"`
+	Devops        []*Skill               `protobuf:"bytes,4,rep,name=devops,proto3" json:"devops,omitempty"`
+	Tools         []*Skill               `protobuf:"bytes,5,rep,name=tools,proto3" json:"tools,omitempty"`
+	Languages     []*Skill               `protobuf:"bytes,6,rep,name=languages,proto3" json:"languages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Skills) Reset() {
+	*x = Skills{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Skills) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Skills) ProtoMessage() {}
+
+func (x *Skills) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Skills.ProtoReflect.Descriptor instead.
+func (*Skills) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Skills) GetBackend() []*Skill {
+	if x != nil {
+		return x.Backend
+	}
+	return nil
+}
+
+func (x *Skills) GetFrontend() []*Skill {
+	if x != nil {
+		return x.Frontend
+	}
+	return nil
+}
+
+func (x *Skills) GetDatabase() []*Skill {
+	if x != nil {
+		return x.Database
+	}
+	return nil
+}
+
+func (x *Skills) GetDevops() []*Skill {
+	if x != nil {
+		return x.Devops
+	}
+	return nil
+}
+
+func (x *Skills) GetTools() []*Skill {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *Skills) GetLanguages() []*Skill {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+type Experience struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Company   string                 `protobuf:"bytes,2,opt,name=company,proto3" json:"company,omitempty"`
+	Position  string                 `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
+	Location  string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Unset while current
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	IsCurrent     bool                   `protobuf:"varint,7,opt,name=is_current,json=isCurrent,proto3" json:"is_current,omitempty"`
+	Description   string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	Achievements  []string               `protobuf:"bytes,9,rep,name=achievements,proto3" json:"achievements,omitempty"`
+	Technologies  []string               `protobuf:"bytes,10,rep,name=technologies,proto3" json:"technologies,omitempty"`
+	CompanyLogo   string                 `protobuf:"bytes,11,opt,name=company_logo,json=companyLogo,proto3" json:"company_logo,omitempty"`
+	CompanyUrl    string                 `protobuf:"bytes,12,opt,name=company_url,json=companyUrl,proto3" json:"company_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Experience) Reset() {
+	*x = Experience{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Experience) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Experience) ProtoMessage() {}
+
+func (x *Experience) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Experience.ProtoReflect.Descriptor instead.
+func (*Experience) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Experience) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Experience) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *Experience) GetPosition() string {
+	if x != nil {
+		return x.Position
+	}
+	return ""
+}
+
+func (x *Experience) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Experience) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *Experience) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *Experience) GetIsCurrent() bool {
+	if x != nil {
+		return x.IsCurrent
+	}
+	return false
+}
+
+func (x *Experience) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Experience) GetAchievements() []string {
+	if x != nil {
+		return x.Achievements
+	}
+	return nil
+}
+
+func (x *Experience) GetTechnologies() []string {
+	if x != nil {
+		return x.Technologies
+	}
+	return nil
+}
+
+func (x *Experience) GetCompanyLogo() string {
+	if x != nil {
+		return x.CompanyLogo
+	}
+	return ""
+}
+
+func (x *Experience) GetCompanyUrl() string {
+	if x != nil {
+		return x.CompanyUrl
+	}
+	return ""
+}
+
+type Project struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug            string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	LongDescription string                 `protobuf:"bytes,5,opt,name=long_description,json=longDescription,proto3" json:"long_description,omitempty"`
+	Technologies    []string               `protobuf:"bytes,6,rep,name=technologies,proto3" json:"technologies,omitempty"`
+	GithubUrl       string                 `protobuf:"bytes,7,opt,name=github_url,json=githubUrl,proto3" json:"github_url,omitempty"`
+	LiveUrl         string                 `protobuf:"bytes,8,opt,name=live_url,json=liveUrl,proto3" json:"live_url,omitempty"`
+	DemoUrl         string                 `protobuf:"bytes,9,opt,name=demo_url,json=demoUrl,proto3" json:"demo_url,omitempty"`
+	Images          []string               `protobuf:"bytes,10,rep,name=images,proto3" json:"images,omitempty"`
+	Featured        bool                   `protobuf:"varint,11,opt,name=featured,proto3" json:"featured,omitempty"`
+	// "completed", "in-progress" or "planned"
+	Status     string                 `protobuf:"bytes,12,opt,name=status,proto3" json:"status,omitempty"`
+	StartDate  *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Category   string                 `protobuf:"bytes,15,opt,name=category,proto3" json:"category,omitempty"`
+	Highlights []string               `protobuf:"bytes,16,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	Challenges []string               `protobuf:"bytes,17,rep,name=challenges,proto3" json:"challenges,omitempty"`
+	Stars      int32                  `protobuf:"varint,18,opt,name=stars,proto3" json:"stars,omitempty"`
+	Forks      int32                  `protobuf:"varint,19,opt,name=forks,proto3" json:"forks,omitempty"`
+	Language   string                 `protobuf:"bytes,20,opt,name=language,proto3" json:"language,omitempty"`
+	// ID of the experience entry the project was built at
+	ExperienceId  string `protobuf:"bytes,21,opt,name=experience_id,json=experienceId,proto3" json:"experience_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Project) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Project) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Project) GetLongDescription() string {
+	if x != nil {
+		return x.LongDescription
+	}
+	return ""
+}
+
+func (x *Project) GetTechnologies() []string {
+	if x != nil {
+		return x.Technologies
+	}
+	return nil
+}
+
+func (x *Project) GetGithubUrl() string {
+	if x != nil {
+		return x.GithubUrl
+	}
+	return ""
+}
+
+func (x *Project) GetLiveUrl() string {
+	if x != nil {
+		return x.LiveUrl
+	}
+	return ""
+}
+
+func (x *Project) GetDemoUrl() string {
+	if x != nil {
+		return x.DemoUrl
+	}
+	return ""
+}
+
+func (x *Project) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *Project) GetFeatured() bool {
+	if x != nil {
+		return x.Featured
+	}
+	return false
+}
+
+func (x *Project) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Project) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *Project) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *Project) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Project) GetHighlights() []string {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+func (x *Project) GetChallenges() []string {
+	if x != nil {
+		return x.Challenges
+	}
+	return nil
+}
+
+func (x *Project) GetStars() int32 {
+	if x != nil {
+		return x.Stars
+	}
+	return 0
+}
+
+func (x *Project) GetForks() int32 {
+	if x != nil {
+		return x.Forks
+	}
+	return 0
+}
+
+func (x *Project) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Project) GetExperienceId() string {
+	if x != nil {
+		return x.ExperienceId
+	}
+	return ""
+}
+
+type Education struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Institution   string                 `protobuf:"bytes,2,opt,name=institution,proto3" json:"institution,omitempty"`
+	Degree        string                 `protobuf:"bytes,3,opt,name=degree,proto3" json:"degree,omitempty"`
+	Field         string                 `protobuf:"bytes,4,opt,name=field,proto3" json:"field,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Gpa           float64                `protobuf:"fixed64,7,opt,name=gpa,proto3" json:"gpa,omitempty"`
+	Honors        []string               `protobuf:"bytes,8,rep,name=honors,proto3" json:"honors,omitempty"`
+	Courses       []string               `protobuf:"bytes,9,rep,name=courses,proto3" json:"courses,omitempty"`
+	Description   string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	Logo          string                 `protobuf:"bytes,11,opt,name=logo,proto3" json:"logo,omitempty"`
+	Url           string                 `protobuf:"bytes,12,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Education) Reset() {
+	*x = Education{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Education) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Education) ProtoMessage() {}
+
+func (x *Education) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Education.ProtoReflect.Descriptor instead.
+func (*Education) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Education) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Education) GetInstitution() string {
+	if x != nil {
+		return x.Institution
+	}
+	return ""
+}
+
+func (x *Education) GetDegree() string {
+	if x != nil {
+		return x.Degree
+	}
+	return ""
+}
+
+func (x *Education) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Education) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *Education) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *Education) GetGpa() float64 {
+	if x != nil {
+		return x.Gpa
+	}
+	return 0
+}
+
+func (x *Education) GetHonors() []string {
+	if x != nil {
+		return x.Honors
+	}
+	return nil
+}
+
+func (x *Education) GetCourses() []string {
+	if x != nil {
+		return x.Courses
+	}
+	return nil
+}
+
+func (x *Education) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Education) GetLogo() string {
+	if x != nil {
+		return x.Logo
+	}
+	return ""
+}
+
+func (x *Education) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type GetProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileRequest) Reset() {
+	*x = GetProfileRequest{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileRequest) ProtoMessage() {}
+
+func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetProfileRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetProfileRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type ListRepositoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRepositoriesRequest) Reset() {
+	*x = ListRepositoriesRequest{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRepositoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRepositoriesRequest) ProtoMessage() {}
+
+func (x *ListRepositoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRepositoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListRepositoriesRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListRepositoriesRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetStatsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type GetContributionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContributionsRequest) Reset() {
+	*x = GetContributionsRequest{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContributionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContributionsRequest) ProtoMessage() {}
+
+func (x *GetContributionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContributionsRequest.ProtoReflect.Descriptor instead.
+func (*GetContributionsRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetContributionsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type Profile struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Login           string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	AvatarUrl       string                 `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Bio             string                 `protobuf:"bytes,4,opt,name=bio,proto3" json:"bio,omitempty"`
+	Company         string                 `protobuf:"bytes,5,opt,name=company,proto3" json:"company,omitempty"`
+	Location        string                 `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	Email           string                 `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	Blog            string                 `protobuf:"bytes,8,opt,name=blog,proto3" json:"blog,omitempty"`
+	TwitterUsername string                 `protobuf:"bytes,9,opt,name=twitter_username,json=twitterUsername,proto3" json:"twitter_username,omitempty"`
+	PublicRepos     int32                  `protobuf:"varint,10,opt,name=public_repos,json=publicRepos,proto3" json:"public_repos,omitempty"`
+	PublicGists     int32                  `protobuf:"varint,11,opt,name=public_gists,json=publicGists,proto3" json:"public_gists,omitempty"`
+	Followers       int32                  `protobuf:"varint,12,opt,name=followers,proto3" json:"followers,omitempty"`
+	Following       int32                  `protobuf:"varint,13,opt,name=following,proto3" json:"following,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastFetched     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=last_fetched,json=lastFetched,proto3" json:"last_fetched,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Profile) Reset() {
+	*x = Profile{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Profile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profile) ProtoMessage() {}
+
+func (x *Profile) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
+func (*Profile) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Profile) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *Profile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Profile) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *Profile) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *Profile) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *Profile) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Profile) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Profile) GetBlog() string {
+	if x != nil {
+		return x.Blog
+	}
+	return ""
+}
+
+func (x *Profile) GetTwitterUsername() string {
+	if x != nil {
+		return x.TwitterUsername
+	}
+	return ""
+}
+
+func (x *Profile) GetPublicRepos() int32 {
+	if x != nil {
+		return x.PublicRepos
+	}
+	return 0
+}
+
+func (x *Profile) GetPublicGists() int32 {
+	if x != nil {
+		return x.PublicGists
+	}
+	return 0
+}
+
+func (x *Profile) GetFollowers() int32 {
+	if x != nil {
+		return x.Followers
+	}
+	return 0
+}
+
+func (x *Profile) GetFollowing() int32 {
+	if x != nil {
+		return x.Following
+	}
+	return 0
+}
+
+func (x *Profile) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Profile) GetLastFetched() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFetched
+	}
+	return nil
+}
+
+type Repository struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	GithubId    int64                  `protobuf:"varint,1,opt,name=github_id,json=githubId,proto3" json:"github_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	FullName    string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Fork        bool                   `protobuf:"varint,5,opt,name=fork,proto3" json:"fork,omitempty"`
+	HtmlUrl     string                 `protobuf:"bytes,6,opt,name=html_url,json=htmlUrl,proto3" json:"html_url,omitempty"`
+	Homepage    string                 `protobuf:"bytes,7,opt,name=homepage,proto3" json:"homepage,omitempty"`
+	Language    string                 `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`
+	// Bytes of code per language
+	Languages       map[string]int64       `protobuf:"bytes,9,rep,name=languages,proto3" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	StargazersCount int32                  `protobuf:"varint,10,opt,name=stargazers_count,json=stargazersCount,proto3" json:"stargazers_count,omitempty"`
+	ForksCount      int32                  `protobuf:"varint,11,opt,name=forks_count,json=forksCount,proto3" json:"forks_count,omitempty"`
+	OpenIssuesCount int32                  `protobuf:"varint,12,opt,name=open_issues_count,json=openIssuesCount,proto3" json:"open_issues_count,omitempty"`
+	Topics          []string               `protobuf:"bytes,13,rep,name=topics,proto3" json:"topics,omitempty"`
+	Archived        bool                   `protobuf:"varint,14,opt,name=archived,proto3" json:"archived,omitempty"`
+	PushedAt        *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=pushed_at,json=pushedAt,proto3" json:"pushed_at,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Owner           string                 `protobuf:"bytes,18,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Repository) Reset() {
+	*x = Repository{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Repository) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Repository) ProtoMessage() {}
+
+func (x *Repository) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Repository.ProtoReflect.Descriptor instead.
+func (*Repository) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Repository) GetGithubId() int64 {
+	if x != nil {
+		return x.GithubId
+	}
+	return 0
+}
+
+func (x *Repository) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Repository) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *Repository) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Repository) GetFork() bool {
+	if x != nil {
+		return x.Fork
+	}
+	return false
+}
+
+func (x *Repository) GetHtmlUrl() string {
+	if x != nil {
+		return x.HtmlUrl
+	}
+	return ""
+}
+
+func (x *Repository) GetHomepage() string {
+	if x != nil {
+		return x.Homepage
+	}
+	return ""
+}
+
+func (x *Repository) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Repository) GetLanguages() map[string]int64 {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *Repository) GetStargazersCount() int32 {
+	if x != nil {
+		return x.StargazersCount
+	}
+	return 0
+}
+
+func (x *Repository) GetForksCount() int32 {
+	if x != nil {
+		return x.ForksCount
+	}
+	return 0
+}
+
+func (x *Repository) GetOpenIssuesCount() int32 {
+	if x != nil {
+		return x.OpenIssuesCount
+	}
+	return 0
+}
+
+func (x *Repository) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *Repository) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Repository) GetPushedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PushedAt
+	}
+	return nil
+}
+
+func (x *Repository) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Repository) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Repository) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type LanguageStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Bytes         int64                  `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Percentage    float64                `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LanguageStat) Reset() {
+	*x = LanguageStat{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LanguageStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LanguageStat) ProtoMessage() {}
+
+func (x *LanguageStat) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LanguageStat.ProtoReflect.Descriptor instead.
+func (*LanguageStat) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LanguageStat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LanguageStat) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *LanguageStat) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+type RepoStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	FullName      string                 `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Stars         int32                  `protobuf:"varint,3,opt,name=stars,proto3" json:"stars,omitempty"`
+	Forks         int32                  `protobuf:"varint,4,opt,name=forks,proto3" json:"forks,omitempty"`
+	Language      string                 `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	HtmlUrl       string                 `protobuf:"bytes,7,opt,name=html_url,json=htmlUrl,proto3" json:"html_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepoStat) Reset() {
+	*x = RepoStat{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepoStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoStat) ProtoMessage() {}
+
+func (x *RepoStat) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoStat.ProtoReflect.Descriptor instead.
+func (*RepoStat) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RepoStat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RepoStat) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *RepoStat) GetStars() int32 {
+	if x != nil {
+		return x.Stars
+	}
+	return 0
+}
+
+func (x *RepoStat) GetForks() int32 {
+	if x != nil {
+		return x.Forks
+	}
+	return 0
+}
+
+func (x *RepoStat) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *RepoStat) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RepoStat) GetHtmlUrl() string {
+	if x != nil {
+		return x.HtmlUrl
+	}
+	return ""
+}
+
+type Stats struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Username           string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	TotalRepos         int32                  `protobuf:"varint,2,opt,name=total_repos,json=totalRepos,proto3" json:"total_repos,omitempty"`
+	TotalStars         int32                  `protobuf:"varint,3,opt,name=total_stars,json=totalStars,proto3" json:"total_stars,omitempty"`
+	TotalForks         int32                  `protobuf:"varint,4,opt,name=total_forks,json=totalForks,proto3" json:"total_forks,omitempty"`
+	TotalCommits       int32                  `protobuf:"varint,5,opt,name=total_commits,json=totalCommits,proto3" json:"total_commits,omitempty"`
+	TotalContributions int32                  `protobuf:"varint,6,opt,name=total_contributions,json=totalContributions,proto3" json:"total_contributions,omitempty"`
+	MostUsedLanguages  []*LanguageStat        `protobuf:"bytes,7,rep,name=most_used_languages,json=mostUsedLanguages,proto3" json:"most_used_languages,omitempty"`
+	TopRepositories    []*RepoStat            `protobuf:"bytes,8,rep,name=top_repositories,json=topRepositories,proto3" json:"top_repositories,omitempty"`
+	ContributionStreak int32                  `protobuf:"varint,9,opt,name=contribution_streak,json=contributionStreak,proto3" json:"contribution_streak,omitempty"`
+	IssuesOpened       int32                  `protobuf:"varint,10,opt,name=issues_opened,json=issuesOpened,proto3" json:"issues_opened,omitempty"`
+	PullRequestsOpened int32                  `protobuf:"varint,11,opt,name=pull_requests_opened,json=pullRequestsOpened,proto3" json:"pull_requests_opened,omitempty"`
+	PullRequestsMerged int32                  `protobuf:"varint,12,opt,name=pull_requests_merged,json=pullRequestsMerged,proto3" json:"pull_requests_merged,omitempty"`
+	LastFetched        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=last_fetched,json=lastFetched,proto3" json:"last_fetched,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Stats) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Stats) GetTotalRepos() int32 {
+	if x != nil {
+		return x.TotalRepos
+	}
+	return 0
+}
+
+func (x *Stats) GetTotalStars() int32 {
+	if x != nil {
+		return x.TotalStars
+	}
+	return 0
+}
+
+func (x *Stats) GetTotalForks() int32 {
+	if x != nil {
+		return x.TotalForks
+	}
+	return 0
+}
+
+func (x *Stats) GetTotalCommits() int32 {
+	if x != nil {
+		return x.TotalCommits
+	}
+	return 0
+}
+
+func (x *Stats) GetTotalContributions() int32 {
+	if x != nil {
+		return x.TotalContributions
+	}
+	return 0
+}
+
+func (x *Stats) GetMostUsedLanguages() []*LanguageStat {
+	if x != nil {
+		return x.MostUsedLanguages
+	}
+	return nil
+}
+
+func (x *Stats) GetTopRepositories() []*RepoStat {
+	if x != nil {
+		return x.TopRepositories
+	}
+	return nil
+}
+
+func (x *Stats) GetContributionStreak() int32 {
+	if x != nil {
+		return x.ContributionStreak
+	}
+	return 0
+}
+
+func (x *Stats) GetIssuesOpened() int32 {
+	if x != nil {
+		return x.IssuesOpened
+	}
+	return 0
+}
+
+func (x *Stats) GetPullRequestsOpened() int32 {
+	if x != nil {
+		return x.PullRequestsOpened
+	}
+	return 0
+}
+
+func (x *Stats) GetPullRequestsMerged() int32 {
+	if x != nil {
+		return x.PullRequestsMerged
+	}
+	return 0
+}
+
+func (x *Stats) GetLastFetched() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFetched
+	}
+	return nil
+}
+
+type ContributionDay struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// YYYY-MM-DD
+	Date  string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Count int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Intensity from 0 to 4
+	Level         int32 `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContributionDay) Reset() {
+	*x = ContributionDay{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContributionDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContributionDay) ProtoMessage() {}
+
+func (x *ContributionDay) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContributionDay.ProtoReflect.Descriptor instead.
+func (*ContributionDay) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ContributionDay) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *ContributionDay) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ContributionDay) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type ContributionWeek struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WeekStart     string                 `protobuf:"bytes,1,opt,name=week_start,json=weekStart,proto3" json:"week_start,omitempty"`
+	Days          []*ContributionDay     `protobuf:"bytes,2,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContributionWeek) Reset() {
+	*x = ContributionWeek{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContributionWeek) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContributionWeek) ProtoMessage() {}
+
+func (x *ContributionWeek) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContributionWeek.ProtoReflect.Descriptor instead.
+func (*ContributionWeek) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ContributionWeek) GetWeekStart() string {
+	if x != nil {
+		return x.WeekStart
+	}
+	return ""
+}
+
+func (x *ContributionWeek) GetDays() []*ContributionDay {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+type Contributions struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Username           string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	TotalContributions int32                  `protobuf:"varint,2,opt,name=total_contributions,json=totalContributions,proto3" json:"total_contributions,omitempty"`
+	Weeks              []*ContributionWeek    `protobuf:"bytes,3,rep,name=weeks,proto3" json:"weeks,omitempty"`
+	Years              []int32                `protobuf:"varint,4,rep,packed,name=years,proto3" json:"years,omitempty"`
+	LongestStreak      int32                  `protobuf:"varint,5,opt,name=longest_streak,json=longestStreak,proto3" json:"longest_streak,omitempty"`
+	CurrentStreak      int32                  `protobuf:"varint,6,opt,name=current_streak,json=currentStreak,proto3" json:"current_streak,omitempty"`
+	LastFetched        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_fetched,json=lastFetched,proto3" json:"last_fetched,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Contributions) Reset() {
+	*x = Contributions{}
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contributions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contributions) ProtoMessage() {}
+
+func (x *Contributions) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_v1_portfolio_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contributions.ProtoReflect.Descriptor instead.
+func (*Contributions) Descriptor() ([]byte, []int) {
+	return file_portfolio_v1_portfolio_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Contributions) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Contributions) GetTotalContributions() int32 {
+	if x != nil {
+		return x.TotalContributions
+	}
+	return 0
+}
+
+func (x *Contributions) GetWeeks() []*ContributionWeek {
+	if x != nil {
+		return x.Weeks
+	}
+	return nil
+}
+
+func (x *Contributions) GetYears() []int32 {
+	if x != nil {
+		return x.Years
+	}
+	return nil
+}
+
+func (x *Contributions) GetLongestStreak() int32 {
+	if x != nil {
+		return x.LongestStreak
+	}
+	return 0
+}
+
+func (x *Contributions) GetCurrentStreak() int32 {
+	if x != nil {
+		return x.CurrentStreak
+	}
+	return 0
+}
+
+func (x *Contributions) GetLastFetched() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFetched
+	}
+	return nil
+}
+
+var File_portfolio_v1_portfolio_proto protoreflect.FileDescriptor
+
+const file_portfolio_v1_portfolio_proto_rawDesc = "" +
+	"\n" +
+	"\x1cportfolio/v1/portfolio.proto\x12\fportfolio.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x10\n" +
+	"\x0eGetMetaRequest\"\x12\n" +
+	"\x10GetSkillsRequest\"\x17\n" +
+	"\x15ListExperienceRequest\"\x15\n" +
+	"\x13ListProjectsRequest\"\x16\n" +
+	"\x14ListEducationRequest\"\xc2\x01\n" +
+	"\x04Meta\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12\x16\n" +
+	"\x06github\x18\x04 \x01(\tR\x06github\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x12\x1a\n" +
+	"\blinkedin\x18\x06 \x01(\tR\blinkedin\x12\x18\n" +
+	"\awebsite\x18\a \x01(\tR\awebsite\x12\x10\n" +
+	"\x03bio\x18\b \x01(\tR\x03bio\"\xa6\x01\n" +
+	"\x05Skill\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\x05R\x05level\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04icon\x18\x04 \x01(\tR\x04icon\x12\x1b\n" +
+	"\tyears_exp\x18\x05 \x01(\x05R\byearsExp\x12&\n" +
+	"\x0ecertifications\x18\x06 \x03(\tR\x0ecertifications\"\xa4\x02\n" +
+	"\x06Skills\x12-\n" +
+	"\abackend\x18\x01 \x03(\v2\x13.portfolio.v1.SkillR\abackend\x12/\n" +
+	"\bfrontend\x18\x02 \x03(\v2\x13.portfolio.v1.SkillR\bfrontend\x12/\n" +
+	"\bdatabase\x18\x03 \x03(\v2\x13.portfolio.v1.SkillR\bdatabase\x12+\n" +
+	"\x06devops\x18\x04 \x03(\v2\x13.portfolio.v1.SkillR\x06devops\x12)\n" +
+	"\x05tools\x18\x05 \x03(\v2\x13.portfolio.v1.SkillR\x05tools\x121\n" +
+	"\tlanguages\x18\x06 \x03(\v2\x13.portfolio.v1.SkillR\tlanguages\"\xad\x03\n" +
+	"\n" +
+	"Experience\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acompany\x18\x02 \x01(\tR\acompany\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\tR\bposition\x12\x1a\n" +
+	"\blocation\x18\x04 \x01(\tR\blocation\x129\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x1d\n" +
+	"\n" +
+	"is_current\x18\a \x01(\bR\tisCurrent\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12\"\n" +
+	"\fachievements\x18\t \x03(\tR\fachievements\x12\"\n" +
+	"\ftechnologies\x18\n" +
+	" \x03(\tR\ftechnologies\x12!\n" +
+	"\fcompany_logo\x18\v \x01(\tR\vcompanyLogo\x12\x1f\n" +
+	"\vcompany_url\x18\f \x01(\tR\n" +
+	"companyUrl\"\x8e\x05\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12)\n" +
+	"\x10long_description\x18\x05 \x01(\tR\x0flongDescription\x12\"\n" +
+	"\ftechnologies\x18\x06 \x03(\tR\ftechnologies\x12\x1d\n" +
+	"\n" +
+	"github_url\x18\a \x01(\tR\tgithubUrl\x12\x19\n" +
+	"\blive_url\x18\b \x01(\tR\aliveUrl\x12\x19\n" +
+	"\bdemo_url\x18\t \x01(\tR\ademoUrl\x12\x16\n" +
+	"\x06images\x18\n" +
+	" \x03(\tR\x06images\x12\x1a\n" +
+	"\bfeatured\x18\v \x01(\bR\bfeatured\x12\x16\n" +
+	"\x06status\x18\f \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"start_date\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x1a\n" +
+	"\bcategory\x18\x0f \x01(\tR\bcategory\x12\x1e\n" +
+	"\n" +
+	"highlights\x18\x10 \x03(\tR\n" +
+	"highlights\x12\x1e\n" +
+	"\n" +
+	"challenges\x18\x11 \x03(\tR\n" +
+	"challenges\x12\x14\n" +
+	"\x05stars\x18\x12 \x01(\x05R\x05stars\x12\x14\n" +
+	"\x05forks\x18\x13 \x01(\x05R\x05forks\x12\x1a\n" +
+	"\blanguage\x18\x14 \x01(\tR\blanguage\x12#\n" +
+	"\rexperience_id\x18\x15 \x01(\tR\fexperienceId\"\xe9\x02\n" +
+	"\tEducation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vinstitution\x18\x02 \x01(\tR\vinstitution\x12\x16\n" +
+	"\x06degree\x18\x03 \x01(\tR\x06degree\x12\x14\n" +
+	"\x05field\x18\x04 \x01(\tR\x05field\x129\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x10\n" +
+	"\x03gpa\x18\a \x01(\x01R\x03gpa\x12\x16\n" +
+	"\x06honors\x18\b \x03(\tR\x06honors\x12\x18\n" +
+	"\acourses\x18\t \x03(\tR\acourses\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\x12\x12\n" +
+	"\x04logo\x18\v \x01(\tR\x04logo\x12\x10\n" +
+	"\x03url\x18\f \x01(\tR\x03url\"/\n" +
+	"\x11GetProfileRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"5\n" +
+	"\x17ListRepositoriesRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"-\n" +
+	"\x0fGetStatsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"5\n" +
+	"\x17GetContributionsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"\xeb\x03\n" +
+	"\aProfile\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\x12\x10\n" +
+	"\x03bio\x18\x04 \x01(\tR\x03bio\x12\x18\n" +
+	"\acompany\x18\x05 \x01(\tR\acompany\x12\x1a\n" +
+	"\blocation\x18\x06 \x01(\tR\blocation\x12\x14\n" +
+	"\x05email\x18\a \x01(\tR\x05email\x12\x12\n" +
+	"\x04blog\x18\b \x01(\tR\x04blog\x12)\n" +
+	"\x10twitter_username\x18\t \x01(\tR\x0ftwitterUsername\x12!\n" +
+	"\fpublic_repos\x18\n" +
+	" \x01(\x05R\vpublicRepos\x12!\n" +
+	"\fpublic_gists\x18\v \x01(\x05R\vpublicGists\x12\x1c\n" +
+	"\tfollowers\x18\f \x01(\x05R\tfollowers\x12\x1c\n" +
+	"\tfollowing\x18\r \x01(\x05R\tfollowing\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\flast_fetched\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vlastFetched\"\xd9\x05\n" +
+	"\n" +
+	"Repository\x12\x1b\n" +
+	"\tgithub_id\x18\x01 \x01(\x03R\bgithubId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tfull_name\x18\x03 \x01(\tR\bfullName\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04fork\x18\x05 \x01(\bR\x04fork\x12\x19\n" +
+	"\bhtml_url\x18\x06 \x01(\tR\ahtmlUrl\x12\x1a\n" +
+	"\bhomepage\x18\a \x01(\tR\bhomepage\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\x12E\n" +
+	"\tlanguages\x18\t \x03(\v2'.portfolio.v1.Repository.LanguagesEntryR\tlanguages\x12)\n" +
+	"\x10stargazers_count\x18\n" +
+	" \x01(\x05R\x0fstargazersCount\x12\x1f\n" +
+	"\vforks_count\x18\v \x01(\x05R\n" +
+	"forksCount\x12*\n" +
+	"\x11open_issues_count\x18\f \x01(\x05R\x0fopenIssuesCount\x12\x16\n" +
+	"\x06topics\x18\r \x03(\tR\x06topics\x12\x1a\n" +
+	"\barchived\x18\x0e \x01(\bR\barchived\x127\n" +
+	"\tpushed_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\bpushedAt\x129\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05owner\x18\x12 \x01(\tR\x05owner\x1a<\n" +
+	"\x0eLanguagesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"X\n" +
+	"\fLanguageStat\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\x01R\n" +
+	"percentage\"\xc0\x01\n" +
+	"\bRepoStat\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tfull_name\x18\x02 \x01(\tR\bfullName\x12\x14\n" +
+	"\x05stars\x18\x03 \x01(\x05R\x05stars\x12\x14\n" +
+	"\x05forks\x18\x04 \x01(\x05R\x05forks\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguage\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x19\n" +
+	"\bhtml_url\x18\a \x01(\tR\ahtmlUrl\"\xe4\x04\n" +
+	"\x05Stats\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1f\n" +
+	"\vtotal_repos\x18\x02 \x01(\x05R\n" +
+	"totalRepos\x12\x1f\n" +
+	"\vtotal_stars\x18\x03 \x01(\x05R\n" +
+	"totalStars\x12\x1f\n" +
+	"\vtotal_forks\x18\x04 \x01(\x05R\n" +
+	"totalForks\x12#\n" +
+	"\rtotal_commits\x18\x05 \x01(\x05R\ftotalCommits\x12/\n" +
+	"\x13total_contributions\x18\x06 \x01(\x05R\x12totalContributions\x12J\n" +
+	"\x13most_used_languages\x18\a \x03(\v2\x1a.portfolio.v1.LanguageStatR\x11mostUsedLanguages\x12A\n" +
+	"\x10top_repositories\x18\b \x03(\v2\x16.portfolio.v1.RepoStatR\x0ftopRepositories\x12/\n" +
+	"\x13contribution_streak\x18\t \x01(\x05R\x12contributionStreak\x12#\n" +
+	"\rissues_opened\x18\n" +
+	" \x01(\x05R\fissuesOpened\x120\n" +
+	"\x14pull_requests_opened\x18\v \x01(\x05R\x12pullRequestsOpened\x120\n" +
+	"\x14pull_requests_merged\x18\f \x01(\x05R\x12pullRequestsMerged\x12=\n" +
+	"\flast_fetched\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vlastFetched\"Q\n" +
+	"\x0fContributionDay\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x14\n" +
+	"\x05level\x18\x03 \x01(\x05R\x05level\"d\n" +
+	"\x10ContributionWeek\x12\x1d\n" +
+	"\n" +
+	"week_start\x18\x01 \x01(\tR\tweekStart\x121\n" +
+	"\x04days\x18\x02 \x03(\v2\x1d.portfolio.v1.ContributionDayR\x04days\"\xb5\x02\n" +
+	"\rContributions\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12/\n" +
+	"\x13total_contributions\x18\x02 \x01(\x05R\x12totalContributions\x124\n" +
+	"\x05weeks\x18\x03 \x03(\v2\x1e.portfolio.v1.ContributionWeekR\x05weeks\x12\x14\n" +
+	"\x05years\x18\x04 \x03(\x05R\x05years\x12%\n" +
+	"\x0elongest_streak\x18\x05 \x01(\x05R\rlongestStreak\x12%\n" +
+	"\x0ecurrent_streak\x18\x06 \x01(\x05R\rcurrentStreak\x12=\n" +
+	"\flast_fetched\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vlastFetched2\xff\x02\n" +
+	"\x0eContentService\x12;\n" +
+	"\aGetMeta\x12\x1c.portfolio.v1.GetMetaRequest\x1a\x12.portfolio.v1.Meta\x12A\n" +
+	"\tGetSkills\x12\x1e.portfolio.v1.GetSkillsRequest\x1a\x14.portfolio.v1.Skills\x12Q\n" +
+	"\x0eListExperience\x12#.portfolio.v1.ListExperienceRequest\x1a\x18.portfolio.v1.Experience0\x01\x12J\n" +
+	"\fListProjects\x12!.portfolio.v1.ListProjectsRequest\x1a\x15.portfolio.v1.Project0\x01\x12N\n" +
+	"\rListEducation\x12\".portfolio.v1.ListEducationRequest\x1a\x17.portfolio.v1.Education0\x012\xc4\x02\n" +
+	"\rGitHubService\x12D\n" +
+	"\n" +
+	"GetProfile\x12\x1f.portfolio.v1.GetProfileRequest\x1a\x15.portfolio.v1.Profile\x12U\n" +
+	"\x10ListRepositories\x12%.portfolio.v1.ListRepositoriesRequest\x1a\x18.portfolio.v1.Repository0\x01\x12>\n" +
+	"\bGetStats\x12\x1d.portfolio.v1.GetStatsRequest\x1a\x13.portfolio.v1.Stats\x12V\n" +
+	"\x10GetContributions\x12%.portfolio.v1.GetContributionsRequest\x1a\x1b.portfolio.v1.ContributionsB2Z0portfolio-backend/proto/portfolio/v1;portfoliov1b\x06proto3"
+
+var (
+	file_portfolio_v1_portfolio_proto_rawDescOnce sync.Once
+	file_portfolio_v1_portfolio_proto_rawDescData []byte
+)
+
+func file_portfolio_v1_portfolio_proto_rawDescGZIP() []byte {
+	file_portfolio_v1_portfolio_proto_rawDescOnce.Do(func() {
+		file_portfolio_v1_portfolio_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_portfolio_v1_portfolio_proto_rawDesc), len(file_portfolio_v1_portfolio_proto_rawDesc)))
+	})
+	return file_portfolio_v1_portfolio_proto_rawDescData
+}
+
+var file_portfolio_v1_portfolio_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_portfolio_v1_portfolio_proto_goTypes = []any{
+	(*GetMetaRequest)(nil),          // 0: portfolio.v1.GetMetaRequest
+	(*GetSkillsRequest)(nil),        // 1: portfolio.v1.GetSkillsRequest
+	(*ListExperienceRequest)(nil),   // 2: portfolio.v1.ListExperienceRequest
+	(*ListProjectsRequest)(nil),     // 3: portfolio.v1.ListProjectsRequest
+	(*ListEducationRequest)(nil),    // 4: portfolio.v1.ListEducationRequest
+	(*Meta)(nil),                    // 5: portfolio.v1.Meta
+	(*Skill)(nil),                   // 6: portfolio.v1.Skill
+	(*Skills)(nil),                  // 7: portfolio.v1.Skills
+	(*Experience)(nil),              // 8: portfolio.v1.Experience
+	(*Project)(nil),                 // 9: portfolio.v1.Project
+	(*Education)(nil),               // 10: portfolio.v1.Education
+	(*GetProfileRequest)(nil),       // 11: portfolio.v1.GetProfileRequest
+	(*ListRepositoriesRequest)(nil), // 12: portfolio.v1.ListRepositoriesRequest
+	(*GetStatsRequest)(nil),         // 13: portfolio.v1.GetStatsRequest
+	(*GetContributionsRequest)(nil), // 14: portfolio.v1.GetContributionsRequest
+	(*Profile)(nil),                 // 15: portfolio.v1.Profile
+	(*Repository)(nil),              // 16: portfolio.v1.Repository
+	(*LanguageStat)(nil),            // 17: portfolio.v1.LanguageStat
+	(*RepoStat)(nil),                // 18: portfolio.v1.RepoStat
+	(*Stats)(nil),                   // 19: portfolio.v1.Stats
+	(*ContributionDay)(nil),         // 20: portfolio.v1.ContributionDay
+	(*ContributionWeek)(nil),        // 21: portfolio.v1.ContributionWeek
+	(*Contributions)(nil),           // 22: portfolio.v1.Contributions
+	nil,                             // 23: portfolio.v1.Repository.LanguagesEntry
+	(*timestamppb.Timestamp)(nil),   // 24: google.protobuf.Timestamp
+}
+var file_portfolio_v1_portfolio_proto_depIdxs = []int32{
+	6,  // 0: portfolio.v1.Skills.backend:type_name -> portfolio.v1.Skill
+	6,  // 1: portfolio.v1.Skills.frontend:type_name -> portfolio.v1.Skill
+	6,  // 2: portfolio.v1.Skills.database:type_name -> portfolio.v1.Skill
+	6,  // 3: portfolio.v1.Skills.devops:type_name -> portfolio.v1.Skill
+	6,  // 4: portfolio.v1.Skills.tools:type_name -> portfolio.v1.Skill
+	6,  // 5: portfolio.v1.Skills.languages:type_name -> portfolio.v1.Skill
+	24, // 6: portfolio.v1.Experience.start_date:type_name -> google.protobuf.Timestamp
+	24, // 7: portfolio.v1.Experience.end_date:type_name -> google.protobuf.Timestamp
+	24, // 8: portfolio.v1.Project.start_date:type_name -> google.protobuf.Timestamp
+	24, // 9: portfolio.v1.Project.end_date:type_name -> google.protobuf.Timestamp
+	24, // 10: portfolio.v1.Education.start_date:type_name -> google.protobuf.Timestamp
+	24, // 11: portfolio.v1.Education.end_date:type_name -> google.protobuf.Timestamp
+	24, // 12: portfolio.v1.Profile.created_at:type_name -> google.protobuf.Timestamp
+	24, // 13: portfolio.v1.Profile.last_fetched:type_name -> google.protobuf.Timestamp
+	23, // 14: portfolio.v1.Repository.languages:type_name -> portfolio.v1.Repository.LanguagesEntry
+	24, // 15: portfolio.v1.Repository.pushed_at:type_name -> google.protobuf.Timestamp
+	24, // 16: portfolio.v1.Repository.created_at:type_name -> google.protobuf.Timestamp
+	24, // 17: portfolio.v1.Repository.updated_at:type_name -> google.protobuf.Timestamp
+	17, // 18: portfolio.v1.Stats.most_used_languages:type_name -> portfolio.v1.LanguageStat
+	18, // 19: portfolio.v1.Stats.top_repositories:type_name -> portfolio.v1.RepoStat
+	24, // 20: portfolio.v1.Stats.last_fetched:type_name -> google.protobuf.Timestamp
+	20, // 21: portfolio.v1.ContributionWeek.days:type_name -> portfolio.v1.ContributionDay
+	21, // 22: portfolio.v1.Contributions.weeks:type_name -> portfolio.v1.ContributionWeek
+	24, // 23: portfolio.v1.Contributions.last_fetched:type_name -> google.protobuf.Timestamp
+	0,  // 24: portfolio.v1.ContentService.GetMeta:input_type -> portfolio.v1.GetMetaRequest
+	1,  // 25: portfolio.v1.ContentService.GetSkills:input_type -> portfolio.v1.GetSkillsRequest
+	2,  // 26: portfolio.v1.ContentService.ListExperience:input_type -> portfolio.v1.ListExperienceRequest
+	3,  // 27: portfolio.v1.ContentService.ListProjects:input_type -> portfolio.v1.ListProjectsRequest
+	4,  // 28: portfolio.v1.ContentService.ListEducation:input_type -> portfolio.v1.ListEducationRequest
+	11, // 29: portfolio.v1.GitHubService.GetProfile:input_type -> portfolio.v1.GetProfileRequest
+	12, // 30: portfolio.v1.GitHubService.ListRepositories:input_type -> portfolio.v1.ListRepositoriesRequest
+	13, // 31: portfolio.v1.GitHubService.GetStats:input_type -> portfolio.v1.GetStatsRequest
+	14, // 32: portfolio.v1.GitHubService.GetContributions:input_type -> portfolio.v1.GetContributionsRequest
+	5,  // 33: portfolio.v1.ContentService.GetMeta:output_type -> portfolio.v1.Meta
+	7,  // 34: portfolio.v1.ContentService.GetSkills:output_type -> portfolio.v1.Skills
+	8,  // 35: portfolio.v1.ContentService.ListExperience:output_type -> portfolio.v1.Experience
+	9,  // 36: portfolio.v1.ContentService.ListProjects:output_type -> portfolio.v1.Project
+	10, // 37: portfolio.v1.ContentService.ListEducation:output_type -> portfolio.v1.Education
+	15, // 38: portfolio.v1.GitHubService.GetProfile:output_type -> portfolio.v1.Profile
+	16, // 39: portfolio.v1.GitHubService.ListRepositories:output_type -> portfolio.v1.Repository
+	19, // 40: portfolio.v1.GitHubService.GetStats:output_type -> portfolio.v1.Stats
+	22, // 41: portfolio.v1.GitHubService.GetContributions:output_type -> portfolio.v1.Contributions
+	33, // [33:42] is the sub-list for method output_type
+	24, // [24:33] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_portfolio_v1_portfolio_proto_init() }
+func file_portfolio_v1_portfolio_proto_init() {
+	if File_portfolio_v1_portfolio_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_portfolio_v1_portfolio_proto_rawDesc), len(file_portfolio_v1_portfolio_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_portfolio_v1_portfolio_proto_goTypes,
+		DependencyIndexes: file_portfolio_v1_portfolio_proto_depIdxs,
+		MessageInfos:      file_portfolio_v1_portfolio_proto_msgTypes,
+	}.Build()
+	File_portfolio_v1_portfolio_proto = out.File
+	file_portfolio_v1_portfolio_proto_goTypes = nil
+	file_portfolio_v1_portfolio_proto_depIdxs = nil
+}
@@ -0,0 +1,516 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: portfolio/v1/portfolio.proto
+
+// Read-only access to the portfolio content and GitHub data, mirroring the
+// public REST endpoints for internal services and the CLI.
+
+package portfoliov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ContentService_GetMeta_FullMethodName        = "/portfolio.v1.ContentService/GetMeta"
+	ContentService_GetSkills_FullMethodName      = "/portfolio.v1.ContentService/GetSkills"
+	ContentService_ListExperience_FullMethodName = "/portfolio.v1.ContentService/ListExperience"
+	ContentService_ListProjects_FullMethodName   = "/portfolio.v1.ContentService/ListProjects"
+	ContentService_ListEducation_FullMethodName  = "/portfolio.v1.ContentService/ListEducation"
+)
+
+// ContentServiceClient is the client API for ContentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ContentService serves the portfolio content edited through the admin API.
+type ContentServiceClient interface {
+	GetMeta(ctx context.Context, in *GetMetaRequest, opts ...grpc.CallOption) (*Meta, error)
+	GetSkills(ctx context.Context, in *GetSkillsRequest, opts ...grpc.CallOption) (*Skills, error)
+	ListExperience(ctx context.Context, in *ListExperienceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Experience], error)
+	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Project], error)
+	ListEducation(ctx context.Context, in *ListEducationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Education], error)
+}
+
+type contentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewContentServiceClient(cc grpc.ClientConnInterface) ContentServiceClient {
+	return &contentServiceClient{cc}
+}
+
+func (c *contentServiceClient) GetMeta(ctx context.Context, in *GetMetaRequest, opts ...grpc.CallOption) (*Meta, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Meta)
+	err := c.cc.Invoke(ctx, ContentService_GetMeta_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentServiceClient) GetSkills(ctx context.Context, in *GetSkillsRequest, opts ...grpc.CallOption) (*Skills, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Skills)
+	err := c.cc.Invoke(ctx, ContentService_GetSkills_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentServiceClient) ListExperience(ctx context.Context, in *ListExperienceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Experience], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ContentService_ServiceDesc.Streams[0], ContentService_ListExperience_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListExperienceRequest, Experience]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContentService_ListExperienceClient = grpc.ServerStreamingClient[Experience]
+
+func (c *contentServiceClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Project], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ContentService_ServiceDesc.Streams[1], ContentService_ListProjects_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListProjectsRequest, Project]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContentService_ListProjectsClient = grpc.ServerStreamingClient[Project]
+
+func (c *contentServiceClient) ListEducation(ctx context.Context, in *ListEducationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Education], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ContentService_ServiceDesc.Streams[2], ContentService_ListEducation_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListEducationRequest, Education]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContentService_ListEducationClient = grpc.ServerStreamingClient[Education]
+
+// ContentServiceServer is the server API for ContentService service.
+// All implementations must embed UnimplementedContentServiceServer
+// for forward compatibility.
+//
+// ContentService serves the portfolio content edited through the admin API.
+type ContentServiceServer interface {
+	GetMeta(context.Context, *GetMetaRequest) (*Meta, error)
+	GetSkills(context.Context, *GetSkillsRequest) (*Skills, error)
+	ListExperience(*ListExperienceRequest, grpc.ServerStreamingServer[Experience]) error
+	ListProjects(*ListProjectsRequest, grpc.ServerStreamingServer[Project]) error
+	ListEducation(*ListEducationRequest, grpc.ServerStreamingServer[Education]) error
+	mustEmbedUnimplementedContentServiceServer()
+}
+
+// UnimplementedContentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedContentServiceServer struct{}
+
+func (UnimplementedContentServiceServer) GetMeta(context.Context, *GetMetaRequest) (*Meta, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMeta not implemented")
+}
+func (UnimplementedContentServiceServer) GetSkills(context.Context, *GetSkillsRequest) (*Skills, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSkills not implemented")
+}
+func (UnimplementedContentServiceServer) ListExperience(*ListExperienceRequest, grpc.ServerStreamingServer[Experience]) error {
+	return status.Errorf(codes.Unimplemented, "method ListExperience not implemented")
+}
+func (UnimplementedContentServiceServer) ListProjects(*ListProjectsRequest, grpc.ServerStreamingServer[Project]) error {
+	return status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+}
+func (UnimplementedContentServiceServer) ListEducation(*ListEducationRequest, grpc.ServerStreamingServer[Education]) error {
+	return status.Errorf(codes.Unimplemented, "method ListEducation not implemented")
+}
+func (UnimplementedContentServiceServer) mustEmbedUnimplementedContentServiceServer() {}
+func (UnimplementedContentServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeContentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ContentServiceServer will
+// result in compilation errors.
+type UnsafeContentServiceServer interface {
+	mustEmbedUnimplementedContentServiceServer()
+}
+
+func RegisterContentServiceServer(s grpc.ServiceRegistrar, srv ContentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedContentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ContentService_ServiceDesc, srv)
+}
+
+func _ContentService_GetMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMetaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServiceServer).GetMeta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContentService_GetMeta_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServiceServer).GetMeta(ctx, req.(*GetMetaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContentService_GetSkills_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSkillsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServiceServer).GetSkills(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContentService_GetSkills_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServiceServer).GetSkills(ctx, req.(*GetSkillsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContentService_ListExperience_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListExperienceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContentServiceServer).ListExperience(m, &grpc.GenericServerStream[ListExperienceRequest, Experience]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContentService_ListExperienceServer = grpc.ServerStreamingServer[Experience]
+
+func _ContentService_ListProjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListProjectsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContentServiceServer).ListProjects(m, &grpc.GenericServerStream[ListProjectsRequest, Project]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContentService_ListProjectsServer = grpc.ServerStreamingServer[Project]
+
+func _ContentService_ListEducation_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListEducationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContentServiceServer).ListEducation(m, &grpc.GenericServerStream[ListEducationRequest, Education]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContentService_ListEducationServer = grpc.ServerStreamingServer[Education]
+
+// ContentService_ServiceDesc is the grpc.ServiceDesc for ContentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ContentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "portfolio.v1.ContentService",
+	HandlerType: (*ContentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMeta",
+			Handler:    _ContentService_GetMeta_Handler,
+		},
+		{
+			MethodName: "GetSkills",
+			Handler:    _ContentService_GetSkills_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListExperience",
+			Handler:       _ContentService_ListExperience_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListProjects",
+			Handler:       _ContentService_ListProjects_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListEducation",
+			Handler:       _ContentService_ListEducation_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "portfolio/v1/portfolio.proto",
+}
+
+const (
+	GitHubService_GetProfile_FullMethodName       = "/portfolio.v1.GitHubService/GetProfile"
+	GitHubService_ListRepositories_FullMethodName = "/portfolio.v1.GitHubService/ListRepositories"
+	GitHubService_GetStats_FullMethodName         = "/portfolio.v1.GitHubService/GetStats"
+	GitHubService_GetContributions_FullMethodName = "/portfolio.v1.GitHubService/GetContributions"
+)
+
+// GitHubServiceClient is the client API for GitHubService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GitHubService serves the synced GitHub data. An empty username stands for
+// the portfolio owner.
+type GitHubServiceClient interface {
+	GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*Profile, error)
+	ListRepositories(ctx context.Context, in *ListRepositoriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Repository], error)
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*Stats, error)
+	GetContributions(ctx context.Context, in *GetContributionsRequest, opts ...grpc.CallOption) (*Contributions, error)
+}
+
+type gitHubServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGitHubServiceClient(cc grpc.ClientConnInterface) GitHubServiceClient {
+	return &gitHubServiceClient{cc}
+}
+
+func (c *gitHubServiceClient) GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*Profile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Profile)
+	err := c.cc.Invoke(ctx, GitHubService_GetProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitHubServiceClient) ListRepositories(ctx context.Context, in *ListRepositoriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Repository], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GitHubService_ServiceDesc.Streams[0], GitHubService_ListRepositories_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListRepositoriesRequest, Repository]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GitHubService_ListRepositoriesClient = grpc.ServerStreamingClient[Repository]
+
+func (c *gitHubServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*Stats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stats)
+	err := c.cc.Invoke(ctx, GitHubService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitHubServiceClient) GetContributions(ctx context.Context, in *GetContributionsRequest, opts ...grpc.CallOption) (*Contributions, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Contributions)
+	err := c.cc.Invoke(ctx, GitHubService_GetContributions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GitHubServiceServer is the server API for GitHubService service.
+// All implementations must embed UnimplementedGitHubServiceServer
+// for forward compatibility.
+//
+// GitHubService serves the synced GitHub data. An empty username stands for
+// the portfolio owner.
+type GitHubServiceServer interface {
+	GetProfile(context.Context, *GetProfileRequest) (*Profile, error)
+	ListRepositories(*ListRepositoriesRequest, grpc.ServerStreamingServer[Repository]) error
+	GetStats(context.Context, *GetStatsRequest) (*Stats, error)
+	GetContributions(context.Context, *GetContributionsRequest) (*Contributions, error)
+	mustEmbedUnimplementedGitHubServiceServer()
+}
+
+// UnimplementedGitHubServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGitHubServiceServer struct{}
+
+func (UnimplementedGitHubServiceServer) GetProfile(context.Context, *GetProfileRequest) (*Profile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProfile not implemented")
+}
+func (UnimplementedGitHubServiceServer) ListRepositories(*ListRepositoriesRequest, grpc.ServerStreamingServer[Repository]) error {
+	return status.Errorf(codes.Unimplemented, "method ListRepositories not implemented")
+}
+func (UnimplementedGitHubServiceServer) GetStats(context.Context, *GetStatsRequest) (*Stats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedGitHubServiceServer) GetContributions(context.Context, *GetContributionsRequest) (*Contributions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContributions not implemented")
+}
+func (UnimplementedGitHubServiceServer) mustEmbedUnimplementedGitHubServiceServer() {}
+func (UnimplementedGitHubServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeGitHubServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GitHubServiceServer will
+// result in compilation errors.
+type UnsafeGitHubServiceServer interface {
+	mustEmbedUnimplementedGitHubServiceServer()
+}
+
+func RegisterGitHubServiceServer(s grpc.ServiceRegistrar, srv GitHubServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGitHubServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GitHubService_ServiceDesc, srv)
+}
+
+func _GitHubService_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitHubServiceServer).GetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GitHubService_GetProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitHubServiceServer).GetProfile(ctx, req.(*GetProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitHubService_ListRepositories_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRepositoriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitHubServiceServer).ListRepositories(m, &grpc.GenericServerStream[ListRepositoriesRequest, Repository]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GitHubService_ListRepositoriesServer = grpc.ServerStreamingServer[Repository]
+
+func _GitHubService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitHubServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GitHubService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitHubServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitHubService_GetContributions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContributionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitHubServiceServer).GetContributions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GitHubService_GetContributions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitHubServiceServer).GetContributions(ctx, req.(*GetContributionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GitHubService_ServiceDesc is the grpc.ServiceDesc for GitHubService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GitHubService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "portfolio.v1.GitHubService",
+	HandlerType: (*GitHubServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProfile",
+			Handler:    _GitHubService_GetProfile_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _GitHubService_GetStats_Handler,
+		},
+		{
+			MethodName: "GetContributions",
+			Handler:    _GitHubService_GetContributions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListRepositories",
+			Handler:       _GitHubService_ListRepositories_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "portfolio/v1/portfolio.proto",
+}
@@ -1,84 +1,264 @@
 package routes
 
 import (
+	"log"
+	"portfolio-backend/config"
 	"portfolio-backend/controllers"
+	"portfolio-backend/metrics"
 	"portfolio-backend/middleware"
+	"portfolio-backend/models"
+	"portfolio-backend/services"
+	"portfolio-backend/utils"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// trafficCacheTTL bounds how long cached traffic analytics may lag: new page
+// views drop them, but views leaving a range like 7d do not
+const trafficCacheTTL = 5 * time.Minute
+
 func SetupRoutes(r *gin.Engine) {
 	// Initialize controllers
 	healthController := controllers.NewHealthController()
 	contentController := controllers.NewContentController()
 	githubController := controllers.NewGitHubController()
 	analyticsController := controllers.NewAnalyticsController()
+	deployHookController := controllers.NewDeployHookController()
+	cdnPurgeController := controllers.NewCDNPurgeController()
+	profileReadmeController := controllers.NewProfileReadmeController()
+	digestController := controllers.NewDigestController()
+	telegramController := controllers.NewTelegramController()
+	rateLimitController := controllers.NewRateLimitController()
+	samplingController := controllers.NewSamplingController()
+	displayLabelsController := controllers.NewDisplayLabelsController()
+	rawDocumentController := controllers.NewRawDocumentController()
+	ownerController := controllers.NewOwnerController()
+	availabilityController := controllers.NewAvailabilityController()
+	recruiterController := controllers.NewRecruiterController()
+	resumeController := controllers.NewResumeController()
+	exportController := controllers.NewExportController()
+	endorsementController := controllers.NewEndorsementController()
+	feedbackController := controllers.NewFeedbackController()
+	contactController := controllers.NewContactController()
+	jobController := controllers.NewJobController()
+	liveController := controllers.NewLiveController()
+	auditController := controllers.NewAuditController()
 
 	// Global middlewares
 	r.Use(middleware.Recovery())
 	r.Use(middleware.RequestID())
 	r.Use(middleware.Logger())
+	r.Use(middleware.ServerTiming())
+	r.Use(middleware.CacheBypass())
+	r.Use(middleware.Metrics())
+	r.Use(middleware.Visitors())
 	r.Use(middleware.SecurityHeaders())
+	r.Use(middleware.Site())
 	r.Use(middleware.CORSMiddleware())
 	r.Use(middleware.RateLimit())
+	r.Use(middleware.RejectDryRun())
+	r.Use(middleware.Audit())
 
 	// Root health check (no rate limiting for health checks)
 	r.GET("/health", healthController.Health)
 	r.GET("/readiness", healthController.Readiness)
 	r.GET("/liveness", healthController.Liveness)
 
+	// Prometheus scrape endpoint
+	if config.AppConfig.EnableMetrics {
+		r.GET("/metrics", middleware.APIKey(), metricsHandler)
+	}
+
+	// Conditional GETs, cacheable for as long as the data is cached server-side
+	contentETag := middleware.ETag(config.AppConfig.ContentCacheTTL)
+	githubETag := middleware.ETag(config.AppConfig.GitHubCacheTTL)
+
+	// Surrogate keys let CDNs purging by tag drop exactly what changed
+	contentKeys := func(contentType string) gin.HandlerFunc {
+		keys := services.ContentSurrogateKeys(contentType)
+		return middleware.SurrogateKeys(func(*gin.Context) []string { return keys })
+	}
+	githubKeys := func(resource string) gin.HandlerFunc {
+		return middleware.SurrogateKeys(func(c *gin.Context) []string {
+			return services.GitHubSurrogateKeys(resource, c.Param("username"))
+		})
+	}
+
+	// Whole responses cached until the data they are built from changes
+	contentCache := middleware.Cache(config.AppConfig.ContentCacheTTL, middleware.CacheKey(services.ResponseTagContent))
+
+	// Mutations that can be previewed with X-Dry-Run: true
+	dryRun := middleware.DryRun()
+
+	// Feed of the portfolio updates, for feed readers
+	r.GET("/feed.xml", contentKeys(""), contentCache, contentController.GetFeed)
+
+	// Sitemap of the portfolio's pages and the robots.txt pointing at it. The
+	// sitemap is cached per mapped domain; robots.txt names the requested
+	// host and is built from the configuration alone, so it is not cached.
+	siteCache := middleware.Cache(config.AppConfig.ContentCacheTTL, middleware.SiteCacheKey(services.ResponseTagContent))
+	r.GET("/sitemap.xml", contentKeys(""), siteCache, contentController.GetSitemap)
+	r.GET("/robots.txt", contentController.GetRobots)
+
 	// API v1 routes
 	v1 := r.Group("/api/v1")
 	{
 		// Info endpoint
 		v1.GET("/info", healthController.Info)
 
+		// Live updates WebSocket
+		v1.GET("/ws", liveController.Connect)
+
 		// Content routes (public)
 		content := v1.Group("/content")
 		{
-			content.GET("", contentController.GetContent)
-			content.GET("/skills", contentController.GetSkills)
-			content.GET("/experience", contentController.GetExperience)
-			content.GET("/projects", contentController.GetProjects)
-			content.GET("/education", contentController.GetEducation)
-			content.GET("/meta", contentController.GetMeta)
-			content.GET("/search", contentController.SearchContent)
+			content.GET("", middleware.ContentState(contentController.PreviewContent), contentKeys(""), contentETag, contentController.GetContent)
+			content.GET("/skills", contentKeys("skills"), contentETag, contentController.GetSkills)
+			content.GET("/experience", contentKeys("experience"), contentETag, contentController.GetExperience)
+			content.GET("/projects", contentKeys("projects"), contentETag, contentController.GetProjects)
+			content.GET("/education", contentKeys("education"), contentETag, contentController.GetEducation)
+			content.GET("/meta", contentKeys("meta"), contentETag, contentController.GetMeta)
+			content.GET("/changelog", contentKeys("changelog"), contentETag, contentController.GetChangelog)
+			content.GET("/availability", contentKeys("availability"), availabilityController.GetAvailability)
+			content.GET("/private", middleware.RecruiterToken(), recruiterController.GetPrivateDetails)
+			content.GET("/search", contentKeys(""), contentETag, contentCache, contentController.SearchContent)
+
+			// Skill endorsements by visitors signed in with GitHub
+			content.GET("/skills/:skill/endorsements", contentKeys("skills"), contentETag, endorsementController.GetEndorsers)
+			content.POST("/skills/:skill/endorsements", endorsementController.Endorse)
+			content.DELETE("/skills/:skill/endorsements", endorsementController.Withdraw)
 			
 			// Content management (protected)
 			protected := content.Group("", middleware.Auth())
 			{
-				protected.PUT("", contentController.UpdateContent)
+				protected.PUT("", dryRun, contentController.UpdateContent)
 				protected.GET("/history/:type", contentController.GetContentHistory)
+				protected.GET("/diff/:type", contentController.GetContentDiff)
+
+				// Drafts staged with "state": "draft", previewed with GET /content?state=draft
+				protected.GET("/drafts", contentController.GetDrafts)
+				protected.POST("/drafts/:type/publish", dryRun, contentController.PublishDraft)
+				protected.DELETE("/drafts/:type", contentController.DiscardDraft)
 			}
 		}
 
+		// The portfolio in the jsonresume.org schema, for resume renderers
+		v1.GET("/export/json-resume", contentKeys(""), contentETag, contentController.ExportJSONResume)
+
+		// The portfolio as a PDF resume, cached until the content changes
+		v1.GET("/export/resume.pdf", contentKeys(""), contentCache, contentController.ExportResumePDF)
+
+		// Contact card and career timeline for contacts and calendar apps
+		v1.GET("/export/contact.vcf", contentKeys("meta"), contentCache, contentController.ExportContactVCard)
+		v1.GET("/export/timeline.ics", contentKeys(""), contentCache, contentController.ExportTimelineICS)
+
+		// Resume downloads through signed, tracked links
+		v1.GET("/resume/:id", resumeController.Download)
+
+		// Visitor feedback on projects
+		v1.POST("/projects/:slug/feedback", middleware.FeedbackRateLimit(), feedbackController.SubmitFeedback)
+
+		// Contact form, emailed to OWNER_EMAIL
+		v1.POST("/contact", middleware.ContactRateLimit(), contactController.SubmitMessage)
+
+		// Social preview cards of projects, by ID or slug, for link previews
+		v1.GET("/projects/:slug/og-image.png", contentKeys(""), contentETag, contentCache, contentController.GetProjectOGImage)
+
+		// GitHub sign-in for skill endorsers
+		v1.GET("/endorsements/oauth/authorize", endorsementController.Authorize)
+		v1.POST("/endorsements/oauth/token", endorsementController.ExchangeCode)
+
 		// GitHub integration routes
 		github := v1.Group("/github")
 		{
 			// Apply GitHub-specific rate limiting
 			github.Use(middleware.GitHubRateLimit())
+			github.Use(middleware.UpstreamBudget())
 			
-			github.GET("/profile/:username", githubController.GetProfile)
-			github.GET("/repos/:username", githubController.GetRepositories)
-			github.GET("/contributions/:username", githubController.GetContributions)
-			github.GET("/stats/:username", githubController.GetStats)
+			// Only the portfolio accounts, unless opened up with GITHUB_ALLOWED_USERS
+			allowedUser := middleware.AllowedGitHubUser()
+
+			github.GET("/profile/:username", allowedUser, githubKeys("profile"), githubETag, githubController.GetProfile)
+			github.GET("/repos", githubKeys("repos"), githubETag, githubController.GetPortfolioRepositories)
+			github.GET("/repos/:username", allowedUser, githubKeys("repos"), githubETag, githubController.GetRepositories)
+			github.GET("/repos/:username/export", allowedUser, githubController.ExportRepositories)
+			github.GET("/readme/:username/:repo", allowedUser, githubKeys("readme"), githubETag, githubController.GetRepositoryReadme)
+			github.GET("/releases/:username/:repo", allowedUser, githubKeys("releases"), githubETag, githubController.GetRepositoryReleases)
+			github.GET("/commit-activity/:username", allowedUser, githubKeys("commit_activity"), githubETag, githubController.GetCommitActivity)
+			github.GET("/commit-activity/:username/:repo", allowedUser, githubKeys("commit_activity"), githubETag, githubController.GetRepositoryCommitActivity)
+			github.GET("/code-frequency/:username/:repo", allowedUser, githubKeys("code_frequency"), githubETag, githubController.GetCodeFrequency)
+			github.GET("/starred/:username", allowedUser, githubKeys("starred"), githubETag, githubController.GetStarred)
+			github.GET("/gists/:username", allowedUser, githubKeys("gists"), githubETag, githubController.GetGists)
+			github.GET("/contributions/external", githubKeys("contributions"), githubETag, githubController.GetExternalContributions)
+			github.GET("/contributions/:username", allowedUser, githubKeys("contributions"), githubETag, middleware.RequireFeature(services.FeatureContributions), githubController.GetContributions)
+			github.GET("/contributions/:username/:graph", allowedUser, githubKeys("contributions"), githubETag, middleware.RequireFeature(services.FeatureContributions),
+				middleware.Cache(config.AppConfig.GitHubCacheTTL, middleware.UsernameCacheKey()), githubController.GetContributionGraph)
+			github.GET("/stats", githubKeys("stats"), githubETag, githubController.GetPortfolioStats)
+			github.GET("/stats/aggregate", githubKeys("stats"), githubETag, githubController.GetAggregateStats)
+			github.GET("/stats/:username", allowedUser, githubKeys("stats"), githubETag, githubController.GetStats)
 			github.GET("/rate-limit", githubController.GetRateLimit)
+			github.GET("/sync/status", githubController.GetSyncStatus)
 			
 			// Sync endpoint (protected)
 			protected := github.Group("", middleware.Auth())
 			{
-				protected.POST("/sync/:username", githubController.SyncData)
+				protected.POST("/sync", middleware.SyncRateLimit(), githubController.SyncData)
+				protected.POST("/sync/:username", middleware.SyncRateLimit(), githubController.SyncData)
+				protected.GET("/traffic/:repo", middleware.RequireFeature(services.FeatureTraffic), githubController.GetTraffic)
+			}
+		}
+
+		ownerKeys := middleware.SurrogateKeys(func(c *gin.Context) []string {
+			return services.GitHubSurrogateKeys("stats", services.NewSettingsService().GetOwner(c.Request.Context()))
+		})
+		ownerCache := middleware.Cache(config.AppConfig.GitHubCacheTTL, middleware.OwnerCacheKey())
+
+		// Stats for third-party README card generators, open to any origin
+		public := v1.Group("/public", middleware.PublicCORS(), middleware.UpstreamBudget())
+		{
+			public.GET("/stats.json", ownerKeys, githubETag, ownerCache, githubController.GetPublicStats)
+		}
+
+		// SVG stats badges (stars, repos, streak, top-language) to embed anywhere
+		badges := v1.Group("/badges", middleware.PublicCORS(), middleware.UpstreamBudget())
+		{
+			badges.GET("/:badge", ownerKeys, githubETag, ownerCache, githubController.GetBadge)
+		}
+
+		// Fixture seeding for the frontend's E2E suites; it wipes the
+		// database, so it is opt-in and never served in release mode
+		if config.AppConfig.TestSupport && config.AppConfig.GinMode != gin.ReleaseMode {
+			log.Printf("⚠️ Test support enabled: /api/v1/test can reset the database")
+			testSupportController := controllers.NewTestSupportController()
+			testSupport := v1.Group("/test")
+			{
+				testSupport.GET("/fixtures", testSupportController.ListFixtures)
+				testSupport.POST("/seed", testSupportController.Seed)
+				testSupport.POST("/reset", testSupportController.Reset)
 			}
 		}
 
+		// Background jobs (protected)
+		jobs := v1.Group("/jobs", middleware.Auth())
+		{
+			jobs.GET("", jobController.ListJobs)
+			jobs.POST("", jobController.CreateJob)
+			jobs.GET("/:id", jobController.GetJob)
+			jobs.POST("/:id/retry", jobController.RetryJob)
+			jobs.DELETE("/:id", jobController.CancelJob)
+			jobs.GET("/:id/download", jobController.DownloadExport)
+		}
+
 		// Analytics routes
-		analytics := v1.Group("/analytics")
+		analytics := v1.Group("/analytics", middleware.UpstreamBudget())
 		{
-			analytics.GET("/summary", analyticsController.GetSummary)
-			analytics.GET("/contributions/:period", analyticsController.GetContributionsByPeriod)
+			analytics.GET("/summary", githubKeys("summary"), githubETag, analyticsController.GetSummary)
+			analytics.GET("/contributions/:period", githubKeys("contributions"), githubETag, middleware.RequireFeature(services.FeatureContributions), analyticsController.GetContributionsByPeriod)
+			analytics.GET("/commit-insights", githubKeys("commit_insights"), githubETag, analyticsController.GetCommitInsights)
 			analytics.GET("/cache-stats", analyticsController.GetCacheStats)
 			analytics.GET("/performance", analyticsController.GetPerformanceMetrics)
+			analytics.GET("/traffic", middleware.Cache(trafficCacheTTL, middleware.CacheKey(services.ResponseTagAnalytics)), analyticsController.GetTraffic)
 		}
 
 		// Admin routes (protected with API key)
@@ -86,33 +266,156 @@ func SetupRoutes(r *gin.Engine) {
 		{
 			admin.POST("/cache/clear", clearCacheHandler)
 			admin.GET("/system/stats", systemStatsHandler)
-			admin.POST("/content/import", importContentHandler)
+			admin.GET("/content/export", contentController.ExportContent)
+			admin.POST("/content/import", dryRun, contentController.ImportContent)
+			admin.POST("/bulk", dryRun, contentController.BulkUpdate)
+
+			// Static frontend rebuild hooks
+			admin.GET("/deploy-hooks", deployHookController.GetHooks)
+			admin.PUT("/deploy-hooks", dryRun, deployHookController.UpdateHooks)
+			admin.POST("/deploy-hooks/trigger", deployHookController.TriggerHooks)
+			admin.GET("/deploy-hooks/history", deployHookController.GetHistory)
+
+			// Frontend CDN cache purges
+			admin.GET("/cdn-purge", cdnPurgeController.GetIntegrations)
+			admin.PUT("/cdn-purge", dryRun, cdnPurgeController.UpdateIntegrations)
+			admin.POST("/cdn-purge/trigger", cdnPurgeController.Purge)
+			admin.GET("/cdn-purge/history", cdnPurgeController.GetHistory)
+
+			// GitHub profile README generator
+			admin.GET("/profile-readme/preview", profileReadmeController.Preview)
+			admin.POST("/profile-readme/publish", profileReadmeController.Publish)
+
+			// Weekly digest email
+			admin.GET("/digest/preview", digestController.Preview)
+			admin.POST("/digest/send", digestController.Send)
+
+			// Telegram bot
+			admin.GET("/telegram", telegramController.GetSettings)
+			admin.PUT("/telegram", dryRun, telegramController.UpdateSettings)
+			admin.POST("/telegram/test", telegramController.SendTest)
+
+			// Quotas of the pooled GitHub tokens
+			admin.GET("/github-tokens", githubController.GetTokenQuotas)
+
+			// Rate limit tiers
+			admin.GET("/rate-limits", rateLimitController.GetTiers)
+			admin.PUT("/rate-limits", dryRun, rateLimitController.UpdateTiers)
+
+			// Traffic analytics sampling
+			admin.GET("/analytics-sampling", samplingController.GetSampling)
+			admin.PUT("/analytics-sampling", dryRun, samplingController.UpdateSampling)
+
+			// Display labels of GitHub topics and languages
+			admin.GET("/display-labels", displayLabelsController.GetLabels)
+			admin.PUT("/display-labels", dryRun, displayLabelsController.UpdateLabels)
+
+			// Showcased GitHub account, by default and per domain
+			admin.GET("/owner", ownerController.GetOwner)
+			admin.PUT("/owner", dryRun, ownerController.UpdateOwner)
+			admin.GET("/domains", ownerController.GetDomains)
+			admin.PUT("/domains", dryRun, ownerController.UpdateDomains)
+
+			// "Hire me" availability
+			admin.PUT("/availability", dryRun, availabilityController.UpdateAvailability)
+
+			// Private details and the recruiter tokens disclosing them
+			admin.GET("/private-details", recruiterController.GetPrivateDetails)
+			admin.PUT("/private-details", dryRun, recruiterController.UpdatePrivateDetails)
+			admin.GET("/recruiter-tokens", recruiterController.ListTokens)
+			admin.POST("/recruiter-tokens", recruiterController.CreateToken)
+			admin.DELETE("/recruiter-tokens/:id", recruiterController.RevokeToken)
+
+			// Skill endorsement moderation
+			admin.GET("/endorsements", endorsementController.ListEndorsements)
+			admin.GET("/endorsements/blocklist", endorsementController.GetBlocklist)
+			admin.PUT("/endorsements/blocklist", dryRun, endorsementController.UpdateBlocklist)
+			admin.PUT("/endorsements/:id", endorsementController.ModerateEndorsement)
+			admin.DELETE("/endorsements/:id", endorsementController.DeleteEndorsement)
+
+			// Project feedback, and its erasure on privacy requests
+			admin.GET("/feedback", feedbackController.GetSummaries)
+			admin.DELETE("/feedback", feedbackController.DeleteVisitorFeedback)
+			admin.DELETE("/feedback/:id", feedbackController.DeleteFeedback)
+
+			// Messages sent through the contact form
+			admin.GET("/contact-messages", contactController.ListMessages)
+
+			// Tracked resume download links
+			admin.GET("/resume-links", resumeController.ListLinks)
+			admin.POST("/resume-links", resumeController.CreateLink)
+			admin.GET("/resume-links/:id/downloads", resumeController.GetDownloads)
+			admin.DELETE("/resume-links/:id", resumeController.RevokeLink)
+
+			// Raw document escape hatch (validated and audited)
+			admin.GET("/raw/:collection/:id", rawDocumentController.GetDocument)
+			admin.PUT("/raw/:collection/:id", dryRun, rawDocumentController.ReplaceDocument)
+
+			// Collection exports for ad-hoc analysis
+			admin.GET("/export/:collection", exportController.ExportCollection)
+
+			// Writes made by authenticated callers
+			admin.GET("/audit", auditController.ListEntries)
 		}
 	}
 
 	// Catch-all route for undefined endpoints
 	r.NoRoute(func(c *gin.Context) {
-		c.JSON(404, gin.H{
-			"success": false,
-			"error":   "Endpoint not found",
-			"code":    "NOT_FOUND",
-			"timestamp": time.Now(),
-			"request_id": c.GetString("request_id"),
+		utils.JSON(c, 404, models.ErrorResponse{
+			Success:   false,
+			Error:     "Endpoint not found",
+			Code:      "NOT_FOUND",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
 		})
 	})
 
 	// Handle method not allowed
 	r.NoMethod(func(c *gin.Context) {
-		c.JSON(405, gin.H{
-			"success": false,
-			"error":   "Method not allowed",
-			"code":    "METHOD_NOT_ALLOWED",
-			"timestamp": time.Now(),
-			"request_id": c.GetString("request_id"),
+		utils.JSON(c, 405, models.ErrorResponse{
+			Success:   false,
+			Error:     "Method not allowed",
+			Code:      "METHOD_NOT_ALLOWED",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
 		})
 	})
 }
 
+// SetupBootRoutes registers the probes served while startup is still waiting
+// for dependencies; every other endpoint answers 503 until the full router
+// from SetupRoutes takes over
+func SetupBootRoutes(r *gin.Engine) {
+	healthController := controllers.NewHealthController()
+
+	r.Use(middleware.Recovery())
+	r.Use(middleware.RequestID())
+
+	r.GET("/health", healthController.Health)
+	r.GET("/readiness", healthController.Readiness)
+	r.GET("/liveness", healthController.Liveness)
+
+	r.NoRoute(func(c *gin.Context) {
+		c.Header("Retry-After", "5")
+		utils.JSON(c, 503, models.ErrorResponse{
+			Success:   false,
+			Error:     "Service is starting",
+			Code:      "SERVICE_STARTING",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		})
+	})
+}
+
+// metricsHandler writes the collected metrics in the Prometheus text format
+func metricsHandler(c *gin.Context) {
+	c.Header("Content-Type", metrics.ContentType)
+	c.Status(200)
+	if err := metrics.WritePrometheus(c.Writer); err != nil {
+		log.Printf("Failed to write metrics: %v", err)
+	}
+}
+
 // Admin endpoint handlers
 func clearCacheHandler(c *gin.Context) {
 	// Implementation would clear cache
@@ -137,14 +440,4 @@ func systemStatsHandler(c *gin.Context) {
 		"timestamp": time.Now(),
 		"request_id": c.GetString("request_id"),
 	})
-}
-
-func importContentHandler(c *gin.Context) {
-	// Implementation would import content from JSON file
-	c.JSON(200, gin.H{
-		"success": true,
-		"message": "Content imported successfully",
-		"timestamp": time.Now(),
-		"request_id": c.GetString("request_id"),
-	})
 }
\ No newline at end of file
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"log"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// auditCollection keeps an entry for every write made by an authenticated
+// caller
+const auditCollection = "audit_log"
+
+type auditKey struct{}
+
+// auditTrail collects the snapshots of the writes made during a request
+type auditTrail struct {
+	mu      sync.Mutex
+	changes []models.AuditChange
+}
+
+// WithAudit marks ctx as audited: the write paths that support it record a
+// before and after snapshot of what they change
+func WithAudit(ctx context.Context) context.Context {
+	return context.WithValue(ctx, auditKey{}, &auditTrail{})
+}
+
+// AuditChanges returns the snapshots recorded during the request of ctx
+func AuditChanges(ctx context.Context) []models.AuditChange {
+	trail, ok := ctx.Value(auditKey{}).(*auditTrail)
+	if !ok {
+		return nil
+	}
+
+	trail.mu.Lock()
+	defer trail.mu.Unlock()
+	return append([]models.AuditChange(nil), trail.changes...)
+}
+
+func auditing(ctx context.Context) bool {
+	_, ok := ctx.Value(auditKey{}).(*auditTrail)
+	return ok
+}
+
+// recordAudit adds a snapshot of target to the audit trail of ctx, if any.
+// Values are stored as relaxed Extended JSON, so typed models and raw BSON
+// documents read back alike, with secrets redacted as in dry runs.
+func recordAudit(ctx context.Context, target string, before, after interface{}) {
+	trail, ok := ctx.Value(auditKey{}).(*auditTrail)
+	if !ok {
+		return
+	}
+
+	change := models.AuditChange{
+		Target: target,
+		Before: redactSecrets(auditSnapshot(before)),
+		After:  redactSecrets(auditSnapshot(after)),
+	}
+	trail.mu.Lock()
+	defer trail.mu.Unlock()
+	trail.changes = append(trail.changes, change)
+}
+
+func auditSnapshot(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	generic, _ := extJSONGeneric(bson.M{"value": value}).(map[string]interface{})
+	return generic["value"]
+}
+
+type AuditService struct {
+	collection *mongo.Collection
+}
+
+func NewAuditService() *AuditService {
+	return &AuditService{
+		collection: database.Database.Collection(auditCollection),
+	}
+}
+
+// RecordAuditEntry stores entry in the background, so the request it is
+// about is not held up by the write
+func RecordAuditEntry(entry models.AuditEntry) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := NewAuditService().Record(ctx, entry); err != nil {
+			log.Printf("Failed to record audit entry for %s: %v", entry.Action, err)
+		}
+	}()
+}
+
+// Record stores an audit entry
+func (as *AuditService) Record(ctx context.Context, entry models.AuditEntry) error {
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	_, err := as.collection.InsertOne(ctx, entry)
+	return err
+}
+
+// ListEntries returns a page of the audit log, newest first, along with the
+// number of entries matching query
+func (as *AuditService) ListEntries(ctx context.Context, query models.AuditQuery) ([]models.AuditEntry, int64, error) {
+	filter := auditFilter(query)
+
+	total, err := as.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((query.Page - 1) * query.Limit)).
+		SetLimit(int64(query.Limit))
+	cursor, err := as.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.AuditEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, 0, err
+	}
+
+	// Snapshots decode as BSON documents; they are served as JSON values
+	for i := range entries {
+		for j := range entries[i].Changes {
+			change := &entries[i].Changes[j]
+			change.Before = auditSnapshot(change.Before)
+			change.After = auditSnapshot(change.After)
+		}
+	}
+	return entries, total, nil
+}
+
+// auditFilter builds the MongoDB filter of query. An action starting with a
+// slash matches route templates whatever the method.
+func auditFilter(query models.AuditQuery) bson.M {
+	filter := bson.M{}
+	if query.Actor != "" {
+		filter["actor"] = query.Actor
+	}
+	if query.Action != "" {
+		pattern := "^" + regexp.QuoteMeta(query.Action)
+		if strings.HasPrefix(query.Action, "/") {
+			pattern = "^[A-Z]+ " + regexp.QuoteMeta(query.Action)
+		}
+		filter["action"] = bson.M{"$regex": pattern}
+	}
+	if query.Target != "" {
+		filter["changes.target"] = query.Target
+	}
+	if query.RequestID != "" {
+		filter["request_id"] = query.RequestID
+	}
+
+	createdAt := bson.M{}
+	if !query.Since.IsZero() {
+		createdAt["$gte"] = query.Since
+	}
+	if !query.Until.IsZero() {
+		createdAt["$lt"] = query.Until
+	}
+	if len(createdAt) > 0 {
+		filter["created_at"] = createdAt
+	}
+	return filter
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestRecordAudit(t *testing.T) {
+	// Writes outside an audited request record nothing
+	recordAudit(context.Background(), "settings/owner", nil, "octocat")
+	assert.Nil(t, AuditChanges(context.Background()))
+
+	ctx := WithAudit(context.Background())
+	recordAudit(ctx, "settings/telegram", nil, models.TelegramSettings{BotToken: "s3cr3t", ChatID: "42"})
+	recordAudit(ctx, "content/meta", bson.D{{Key: "name", Value: "Old"}}, map[string]interface{}{"name": "New"})
+
+	changes := AuditChanges(ctx)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "settings/telegram", changes[0].Target)
+	assert.Nil(t, changes[0].Before)
+	after, ok := changes[0].After.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "42", after["chat_id"])
+	assert.Equal(t, "[redacted]", after["bot_token"])
+
+	assert.Equal(t, map[string]interface{}{"name": "Old"}, changes[1].Before, "BSON documents read as JSON objects")
+	assert.Equal(t, map[string]interface{}{"name": "New"}, changes[1].After)
+}
+
+func TestAuditFilter(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := auditFilter(models.AuditQuery{
+		Actor:  "admin",
+		Action: "/api/v1/admin",
+		Target: "settings/owner",
+		Since:  since,
+	})
+
+	assert.Equal(t, bson.M{
+		"actor":          "admin",
+		"action":         bson.M{"$regex": `^[A-Z]+ /api/v1/admin`},
+		"changes.target": "settings/owner",
+		"created_at":     bson.M{"$gte": since},
+	}, filter)
+
+	assert.Equal(t, bson.M{"action": bson.M{"$regex": `^PUT /api/v1/content`}}, auditFilter(models.AuditQuery{Action: "PUT /api/v1/content"}))
+	assert.Empty(t, auditFilter(models.AuditQuery{}))
+}
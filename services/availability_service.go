@@ -0,0 +1,214 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const googleFreeBusyURL = "https://www.googleapis.com/calendar/v3/freeBusy"
+
+// calendarLookahead is how far ahead busy periods are fetched, so a busy
+// block reported as ending later is still known between syncs
+const calendarLookahead = 7 * 24 * time.Hour
+
+// ErrInvalidAvailability is returned when availability fails validation
+var ErrInvalidAvailability = errors.New("invalid availability")
+
+var (
+	calendarMutex    sync.RWMutex
+	calendarBusy     []models.BusyPeriod
+	calendarSyncedAt time.Time
+)
+
+type AvailabilityService struct {
+	client         *http.Client
+	contentService *ContentService
+}
+
+func NewAvailabilityService() *AvailabilityService {
+	return &AvailabilityService{
+		client: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+		contentService: NewContentService(),
+	}
+}
+
+// GetStatus returns the owner's availability, flagged as currently
+// unavailable while the synced calendar shows them busy
+func (as *AvailabilityService) GetStatus(ctx context.Context) (*models.AvailabilityStatus, error) {
+	availability, err := as.contentService.GetAvailability(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.AvailabilityStatus{Availability: *availability}
+
+	calendarMutex.RLock()
+	defer calendarMutex.RUnlock()
+
+	if calendarSyncedAt.IsZero() {
+		return status, nil
+	}
+
+	syncedAt := calendarSyncedAt
+	status.CalendarSyncedAt = &syncedAt
+	if until, busy := busyUntil(calendarBusy, time.Now()); busy {
+		status.CurrentlyUnavailable = true
+		status.BusyUntil = &until
+	}
+	return status, nil
+}
+
+// SetAvailability validates and stores the owner's availability
+func (as *AvailabilityService) SetAvailability(ctx context.Context, availability models.Availability, updatedBy string) error {
+	validator := utils.NewValidator().ValidateAvailability(&availability)
+	if !validator.IsValid() {
+		problems := make([]string, 0, len(validator.GetErrors()))
+		for _, e := range validator.GetErrors() {
+			problems = append(problems, fmt.Sprintf("%s: %s", e.Field, e.Message))
+		}
+		return fmt.Errorf("%w: %s", ErrInvalidAvailability, strings.Join(problems, "; "))
+	}
+
+	if availability.PreferredRoles == nil {
+		availability.PreferredRoles = []string{}
+	}
+	return as.contentService.UpdateContent(ctx, "availability", availability, updatedBy)
+}
+
+// StartCalendarSync polls the free/busy feed of GOOGLE_CALENDAR_ID every
+// AVAILABILITY_SYNC_PERIOD. On failure the last known busy periods are kept.
+func (as *AvailabilityService) StartCalendarSync() {
+	if config.AppConfig.GoogleCalendarID == "" || config.AppConfig.AvailabilitySyncPeriod <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(config.AppConfig.AvailabilitySyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			if err := as.SyncCalendar(ctx); err != nil {
+				log.Printf("Availability calendar sync error: %v", err)
+			}
+			cancel()
+
+			<-ticker.C
+		}
+	}()
+}
+
+// SyncCalendar fetches the busy periods of the configured calendar
+func (as *AvailabilityService) SyncCalendar(ctx context.Context) error {
+	now := time.Now()
+	periods, err := as.fetchBusyPeriods(ctx, config.AppConfig.GoogleCalendarID, now, now.Add(calendarLookahead))
+	if err != nil {
+		return err
+	}
+
+	calendarMutex.Lock()
+	defer calendarMutex.Unlock()
+	calendarBusy = periods
+	calendarSyncedAt = now
+	return nil
+}
+
+type freeBusyResponse struct {
+	Calendars map[string]struct {
+		Busy   []models.BusyPeriod `json:"busy"`
+		Errors []struct {
+			Reason string `json:"reason"`
+		} `json:"errors"`
+	} `json:"calendars"`
+}
+
+// fetchBusyPeriods queries the Google Calendar free/busy API. The calendar
+// must share its free/busy information publicly for an API key to read it.
+func (as *AvailabilityService) fetchBusyPeriods(ctx context.Context, calendarID string, from, to time.Time) ([]models.BusyPeriod, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"timeMin": from.UTC().Format(time.RFC3339),
+		"timeMax": to.UTC().Format(time.RFC3339),
+		"items":   []map[string]string{{"id": calendarID}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := googleFreeBusyURL
+	if key := config.AppConfig.GoogleCalendarAPIKey; key != "" {
+		endpoint += "?key=" + url.QueryEscape(key)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := as.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Google Calendar API error: %d", resp.StatusCode)
+	}
+
+	var result freeBusyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	calendar, ok := result.Calendars[calendarID]
+	if !ok {
+		return nil, fmt.Errorf("calendar %q missing from free/busy response", calendarID)
+	}
+	if len(calendar.Errors) > 0 {
+		return nil, fmt.Errorf("calendar %q: %s", calendarID, calendar.Errors[0].Reason)
+	}
+	return calendar.Busy, nil
+}
+
+// busyUntil reports whether now falls in a busy period and, if so, when the
+// owner is free again, following back-to-back and overlapping periods
+func busyUntil(periods []models.BusyPeriod, now time.Time) (time.Time, bool) {
+	sorted := append([]models.BusyPeriod(nil), periods...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	var until time.Time
+	busy := false
+	for _, period := range sorted {
+		if !busy {
+			if !period.Start.After(now) && now.Before(period.End) {
+				busy = true
+				until = period.End
+			}
+			continue
+		}
+		if period.Start.After(until) {
+			break
+		}
+		if period.End.After(until) {
+			until = period.End
+		}
+	}
+	return until, busy
+}
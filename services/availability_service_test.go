@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusyUntil(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 10, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		periods []models.BusyPeriod
+		busy    bool
+		until   time.Time
+	}{
+		{"no periods", nil, false, time.Time{}},
+		{"free now", []models.BusyPeriod{{Start: at(9, 0), End: at(10, 0)}, {Start: at(15, 0), End: at(16, 0)}}, false, time.Time{}},
+		{"ends now", []models.BusyPeriod{{Start: at(13, 0), End: at(14, 0)}}, false, time.Time{}},
+		{"starts now", []models.BusyPeriod{{Start: at(14, 0), End: at(15, 0)}}, true, at(15, 0)},
+		{
+			"back to back and overlapping",
+			[]models.BusyPeriod{
+				{Start: at(16, 30), End: at(18, 0)},
+				{Start: at(13, 0), End: at(15, 0)},
+				{Start: at(15, 0), End: at(16, 0)},
+				{Start: at(15, 30), End: at(16, 15)},
+			},
+			true, at(16, 15),
+		},
+	}
+
+	for _, tt := range tests {
+		until, busy := busyUntil(tt.periods, now)
+		assert.Equal(t, tt.busy, busy, tt.name)
+		assert.Equal(t, tt.until, until, tt.name)
+	}
+}
+
+func TestSetAvailabilityRejectsInvalid(t *testing.T) {
+	as := &AvailabilityService{}
+
+	invalid := []models.Availability{
+		{},
+		{Status: "maybe"},
+		{Status: "open", TimeZone: "Mars/Olympus_Mons"},
+	}
+	for _, availability := range invalid {
+		err := as.SetAvailability(context.Background(), availability, "admin")
+		assert.ErrorIs(t, err, ErrInvalidAvailability)
+	}
+}
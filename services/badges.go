@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"math"
+	"portfolio-backend/metrics"
+	"portfolio-backend/models"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Badges served as SVG images, named after the stat they show
+const (
+	BadgeStars       = "stars"
+	BadgeRepos       = "repos"
+	BadgeStreak      = "streak"
+	BadgeTopLanguage = "top-language"
+)
+
+// badgeLabels are the left-hand texts of the badges
+var badgeLabels = map[string]string{
+	BadgeStars:       "stars",
+	BadgeRepos:       "repos",
+	BadgeStreak:      "streak",
+	BadgeTopLanguage: "top language",
+}
+
+// Badges lists the badges, sorted
+func Badges() []string {
+	badges := make([]string, 0, len(badgeLabels))
+	for badge := range badgeLabels {
+		badges = append(badges, badge)
+	}
+	sort.Strings(badges)
+	return badges
+}
+
+// BadgeSupported reports whether badge is one of Badges
+func BadgeSupported(badge string) bool {
+	_, ok := badgeLabels[badge]
+	return ok
+}
+
+// DefaultBadgeStyle and DefaultBadgeColor are used when a request sets none
+const (
+	DefaultBadgeStyle = "flat"
+	DefaultBadgeColor = "blue"
+)
+
+// badgeStyle is the geometry of one of the shields.io badge styles
+type badgeStyle struct {
+	height   float64
+	radius   float64
+	padding  float64 // on each side of a text
+	fontSize float64
+	gradient bool // glossy overlay and text shadow
+	upper    bool
+}
+
+var badgeStyles = map[string]badgeStyle{
+	"flat":          {height: 20, radius: 3, padding: 5, fontSize: 11, gradient: true},
+	"flat-square":   {height: 20, padding: 5, fontSize: 11},
+	"for-the-badge": {height: 28, padding: 12, fontSize: 10, upper: true},
+}
+
+// BadgeStyles lists the badge styles, sorted
+func BadgeStyles() []string {
+	styles := make([]string, 0, len(badgeStyles))
+	for style := range badgeStyles {
+		styles = append(styles, style)
+	}
+	sort.Strings(styles)
+	return styles
+}
+
+// badgeColors are the named colors of shields.io
+var badgeColors = map[string]string{
+	"brightgreen":   "#4c1",
+	"green":         "#97ca00",
+	"yellowgreen":   "#a4a61d",
+	"yellow":        "#dfb317",
+	"orange":        "#fe7d37",
+	"red":           "#e05d44",
+	"blue":          "#007ec6",
+	"blueviolet":    "#8a2be2",
+	"grey":          "#555",
+	"lightgrey":     "#9f9f9f",
+	"success":       "#4c1",
+	"important":     "#fe7d37",
+	"critical":      "#e05d44",
+	"informational": "#007ec6",
+	"inactive":      "#9f9f9f",
+}
+
+var badgeHexColor = regexp.MustCompile(`^([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// BadgeColor resolves a named color or a hex one, with or without the
+// leading #, to the color drawn
+func BadgeColor(color string) (string, bool) {
+	if named, ok := badgeColors[strings.ToLower(color)]; ok {
+		return named, true
+	}
+	if hex := strings.TrimPrefix(color, "#"); badgeHexColor.MatchString(hex) {
+		return "#" + strings.ToLower(hex), true
+	}
+	return "", false
+}
+
+// RenderBadge renders the badge of username's stats as an SVG image in
+// style, with the stat on color, which BadgeColor must accept. Stats that
+// are unavailable, such as the streak with contributions disabled, read
+// "n/a" on grey rather than failing the image.
+func (gs *GitHubService) RenderBadge(ctx context.Context, username, badge, color, style string, labeler Labeler) ([]byte, error) {
+	stats, err := gs.GetStats(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	var contributions *models.GitHubContributions
+	if badge == BadgeStreak && FeatureEnabled(FeatureContributions) {
+		if contributions, err = gs.GetContributions(ctx, username); err != nil {
+			log.Printf("Contributions of %s unavailable: %v", username, err)
+		}
+	}
+
+	message, ok := badgeMessage(badge, stats, contributions, labeler)
+	fill, _ := BadgeColor(color)
+	if !ok {
+		fill = badgeColors["lightgrey"]
+	}
+
+	start := time.Now()
+	defer func() { metrics.ObserveLayer(ctx, metrics.LayerRender, time.Since(start)) }()
+	return renderBadge(badgeLabels[badge], message, fill, style), nil
+}
+
+// badgeMessage is the stat a badge shows, and whether it is known
+func badgeMessage(badge string, stats *models.GitHubStats, contributions *models.GitHubContributions, labeler Labeler) (string, bool) {
+	switch badge {
+	case BadgeStars:
+		return badgeCount(stats.TotalStars), true
+	case BadgeRepos:
+		return badgeCount(stats.TotalRepos), true
+	case BadgeStreak:
+		if contributions == nil {
+			return "n/a", false
+		}
+		if contributions.CurrentStreak == 1 {
+			return "1 day", true
+		}
+		return badgeCount(contributions.CurrentStreak) + " days", true
+	case BadgeTopLanguage:
+		if len(stats.MostUsedLanguages) == 0 {
+			return "n/a", false
+		}
+		return labeler.Label(stats.MostUsedLanguages[0].Name), true
+	}
+	return "n/a", false
+}
+
+// badgeCount abbreviates counts the way shields.io does, e.g. 1.2k
+func badgeCount(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/1_000_000), ".0") + "M"
+	case n >= 1_000:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/1_000), ".0") + "k"
+	}
+	return strconv.Itoa(n)
+}
+
+// renderBadge draws label on grey and message on color, sized to the texts
+// as measured in Helvetica
+func renderBadge(label, message, color, styleName string) []byte {
+	style, ok := badgeStyles[styleName]
+	if !ok {
+		style = badgeStyles[DefaultBadgeStyle]
+	}
+	if style.upper {
+		label, message = strings.ToUpper(label), strings.ToUpper(message)
+	}
+
+	labelWidth := math.Ceil(pdfTextWidth(label, pdfRegular, style.fontSize) + 2*style.padding)
+	messageWidth := math.Ceil(pdfTextWidth(message, pdfRegular, style.fontSize) + 2*style.padding)
+	width := labelWidth + messageWidth
+	baseline := style.height/2 + style.fontSize*0.35
+	title := html.EscapeString(label + ": " + message)
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" role="img" aria-label="%s">`, width, style.height, title)
+	fmt.Fprintf(&svg, `<title>%s</title>`, title)
+	if style.gradient {
+		svg.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	}
+	fmt.Fprintf(&svg, `<clipPath id="r"><rect width="%g" height="%g" rx="%g" fill="#fff"/></clipPath>`, width, style.height, style.radius)
+	svg.WriteString(`<g clip-path="url(#r)">`)
+	fmt.Fprintf(&svg, `<rect width="%g" height="%g" fill="#555"/>`, labelWidth, style.height)
+	fmt.Fprintf(&svg, `<rect x="%g" width="%g" height="%g" fill="%s"/>`, labelWidth, messageWidth, style.height, color)
+	if style.gradient {
+		fmt.Fprintf(&svg, `<rect width="%g" height="%g" fill="url(#s)"/>`, width, style.height)
+	}
+	svg.WriteString(`</g>`)
+	fmt.Fprintf(&svg, `<g fill="#fff" text-anchor="middle" font-family="Helvetica,Arial,sans-serif" font-size="%g">`, style.fontSize)
+	for _, text := range []struct {
+		x float64
+		s string
+	}{{labelWidth / 2, label}, {labelWidth + messageWidth/2, message}} {
+		escaped := html.EscapeString(text.s)
+		if style.gradient {
+			fmt.Fprintf(&svg, `<text x="%g" y="%g" fill="#010101" fill-opacity=".3">%s</text>`, text.x, baseline+1, escaped)
+		}
+		fmt.Fprintf(&svg, `<text x="%g" y="%g">%s</text>`, text.x, baseline, escaped)
+	}
+	svg.WriteString(`</g></svg>`)
+	return []byte(svg.String())
+}
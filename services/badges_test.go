@@ -0,0 +1,103 @@
+package services
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"portfolio-backend/models"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBadgeColor(t *testing.T) {
+	for color, want := range map[string]string{
+		"blue":    "#007ec6",
+		"Green":   "#97ca00",
+		"ff69b4":  "#ff69b4",
+		"#ABC":    "#abc",
+		"magenta": "",
+		"12345":   "",
+		"":        "",
+	} {
+		got, ok := BadgeColor(color)
+		assert.Equal(t, want, got, color)
+		assert.Equal(t, want != "", ok, color)
+	}
+}
+
+func TestBadgeCount(t *testing.T) {
+	assert.Equal(t, "999", badgeCount(999))
+	assert.Equal(t, "1k", badgeCount(1000))
+	assert.Equal(t, "1.2k", badgeCount(1249))
+	assert.Equal(t, "3.5M", badgeCount(3_500_000))
+}
+
+func TestBadgeMessage(t *testing.T) {
+	stats := &models.GitHubStats{
+		TotalStars:        1500,
+		TotalRepos:        42,
+		MostUsedLanguages: []models.LanguageStat{{Name: "cpp"}, {Name: "Go"}},
+	}
+	labeler := Labeler{"cpp": "C++"}
+
+	message := func(badge string, contributions *models.GitHubContributions) string {
+		m, _ := badgeMessage(badge, stats, contributions, labeler)
+		return m
+	}
+	assert.Equal(t, "1.5k", message(BadgeStars, nil))
+	assert.Equal(t, "42", message(BadgeRepos, nil))
+	assert.Equal(t, "C++", message(BadgeTopLanguage, nil))
+	assert.Equal(t, "1 day", message(BadgeStreak, &models.GitHubContributions{CurrentStreak: 1}))
+	assert.Equal(t, "12 days", message(BadgeStreak, &models.GitHubContributions{CurrentStreak: 12}))
+
+	// Contributions disabled or unavailable
+	m, ok := badgeMessage(BadgeStreak, stats, nil, labeler)
+	assert.Equal(t, "n/a", m)
+	assert.False(t, ok)
+}
+
+func TestRenderBadge(t *testing.T) {
+	svg := renderBadge("top language", "C++ & <Go>", "#007ec6", "flat")
+
+	// Well-formed, with the texts escaped
+	decoder := xml.NewDecoder(bytes.NewReader(svg))
+	var texts []string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if data, ok := token.(xml.CharData); ok {
+			texts = append(texts, string(data))
+		}
+	}
+	assert.Contains(t, texts, "C++ & <Go>")
+	assert.Contains(t, string(svg), `fill="#007ec6"`)
+	assert.Contains(t, string(svg), `url(#s)`)
+
+	// Wider texts make wider badges
+	short := renderBadge("stars", "1", "#4c1", "flat")
+	long := renderBadge("stars", "1234567", "#4c1", "flat")
+	assert.Less(t, widthOf(short), widthOf(long))
+
+	square := string(renderBadge("stars", "1", "#4c1", "flat-square"))
+	assert.Contains(t, square, `rx="0"`)
+	assert.NotContains(t, square, `url(#s)`)
+
+	big := string(renderBadge("stars", "1k", "#4c1", "for-the-badge"))
+	assert.Contains(t, big, `height="28"`)
+	assert.Contains(t, big, ">STARS<")
+}
+
+// widthOf returns the width attribute of the svg element
+func widthOf(svg []byte) float64 {
+	_, rest, _ := strings.Cut(string(svg), `width="`)
+	width, _, _ := strings.Cut(rest, `"`)
+	parsed, _ := strconv.ParseFloat(width, 64)
+	return parsed
+}
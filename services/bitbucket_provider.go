@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+const bitbucketAPIURL = "https://api.bitbucket.org/2.0"
+
+// Range of the Bitbucket contribution calendar: the commits of the
+// bitbucketCalendarRepos most recently updated repositories, read a page of
+// 100 at a time up to bitbucketCommitPages pages each
+const (
+	bitbucketCalendarRepos = 20
+	bitbucketCommitPages   = 5
+)
+
+// BitbucketProvider serves a Bitbucket workspace, authenticated with
+// BITBUCKET_USERNAME and BITBUCKET_APP_PASSWORD when they are set.
+// Bitbucket has no stars, followers or languages breakdown, so those stay
+// empty.
+type BitbucketProvider struct {
+	client       *http.Client
+	baseURL      string
+	username     string
+	appPassword  string
+	cacheService *CacheService
+}
+
+func NewBitbucketProvider() *BitbucketProvider {
+	return &BitbucketProvider{
+		client:       &http.Client{Timeout: codeHostTimeout},
+		baseURL:      bitbucketAPIURL,
+		username:     config.AppConfig.BitbucketUsername,
+		appPassword:  config.AppConfig.BitbucketAppPassword,
+		cacheService: NewCacheService(),
+	}
+}
+
+type bitbucketLinks struct {
+	HTML struct {
+		Href string `json:"href"`
+	} `json:"html"`
+	Avatar struct {
+		Href string `json:"href"`
+	} `json:"avatar"`
+	Clone []struct {
+		Name string `json:"name"`
+		Href string `json:"href"`
+	} `json:"clone"`
+}
+
+type bitbucketWorkspace struct {
+	Slug      string         `json:"slug"`
+	Name      string         `json:"name"`
+	CreatedOn time.Time      `json:"created_on"`
+	Links     bitbucketLinks `json:"links"`
+}
+
+type bitbucketRepository struct {
+	UUID        string         `json:"uuid"`
+	Name        string         `json:"name"`
+	FullName    string         `json:"full_name"`
+	Description string         `json:"description"`
+	IsPrivate   bool           `json:"is_private"`
+	Language    string         `json:"language"`
+	Size        int            `json:"size"`
+	Website     string         `json:"website"`
+	HasWiki     bool           `json:"has_wiki"`
+	CreatedOn   time.Time      `json:"created_on"`
+	UpdatedOn   time.Time      `json:"updated_on"`
+	Links       bitbucketLinks `json:"links"`
+	MainBranch  *struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"`
+	Parent *struct {
+		FullName string `json:"full_name"`
+	} `json:"parent"`
+}
+
+type bitbucketCommit struct {
+	Date   time.Time `json:"date"`
+	Author struct {
+		User *struct {
+			Nickname string `json:"nickname"`
+		} `json:"user"`
+	} `json:"author"`
+}
+
+// Pages of Bitbucket listings link to the next one
+type bitbucketRepositoryPage struct {
+	Values []bitbucketRepository `json:"values"`
+	Next   string                `json:"next"`
+}
+
+type bitbucketCommitPage struct {
+	Values []bitbucketCommit `json:"values"`
+	Next   string            `json:"next"`
+}
+
+// Name identifies Bitbucket as a CodeHostProvider
+func (bp *BitbucketProvider) Name() string {
+	return CodeHostBitbucket
+}
+
+// GetProfile returns the workspace named username as a profile
+func (bp *BitbucketProvider) GetProfile(ctx context.Context, username string) (*models.GitHubProfile, error) {
+	var profile models.GitHubProfile
+	if err := bp.cacheService.GetGitHubData(ctx, username, "bitbucket_profile", &profile); err == nil {
+		return &profile, nil
+	}
+
+	var workspace bitbucketWorkspace
+	if err := bp.get(ctx, bp.baseURL+"/workspaces/"+url.PathEscape(username), &workspace); err != nil {
+		return nil, err
+	}
+	repos, err := bp.GetRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	profile = models.GitHubProfile{
+		Login:       workspace.Slug,
+		Name:        workspace.Name,
+		AvatarURL:   workspace.Links.Avatar.Href,
+		Blog:        workspace.Links.HTML.Href,
+		CreatedAt:   workspace.CreatedOn,
+		LastFetched: time.Now(),
+	}
+	for _, repo := range repos {
+		if !repo.Private {
+			profile.PublicRepos++
+		}
+	}
+
+	bp.cacheService.SetGitHubData(ctx, username, "bitbucket_profile", profile)
+	return &profile, nil
+}
+
+// GetRepositories returns the repositories of the workspace
+func (bp *BitbucketProvider) GetRepositories(ctx context.Context, username string) ([]models.GitHubRepository, error) {
+	var repos []models.GitHubRepository
+	if err := bp.cacheService.GetGitHubData(ctx, username, "bitbucket_repositories", &repos); err == nil {
+		return repos, nil
+	}
+
+	repos = []models.GitHubRepository{}
+	next := bp.baseURL + "/repositories/" + url.PathEscape(username) + "?pagelen=100&sort=-updated_on"
+	for next != "" {
+		var page bitbucketRepositoryPage
+		if err := bp.get(ctx, next, &page); err != nil {
+			return nil, err
+		}
+		for _, repo := range page.Values {
+			repos = append(repos, bitbucketRepo(repo, username))
+		}
+		next = page.Next
+	}
+
+	bp.cacheService.SetGitHubData(ctx, username, "bitbucket_repositories", repos)
+	return repos, nil
+}
+
+// GetContributions builds the last year of the calendar from the commits
+// username authored in the workspace's most recently updated repositories
+func (bp *BitbucketProvider) GetContributions(ctx context.Context, username string) (*models.GitHubContributions, error) {
+	var contributions models.GitHubContributions
+	if err := bp.cacheService.GetGitHubData(ctx, username, "bitbucket_contributions", &contributions); err == nil {
+		return &contributions, nil
+	}
+
+	repos, err := bp.GetRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now()
+	since := startOfDay(today).AddDate(0, 0, -codeHostCalendarDays)
+	recent := make([]models.GitHubRepository, 0, len(repos))
+	for _, repo := range repos {
+		if repo.PushedAt.After(since) {
+			recent = append(recent, repo)
+		}
+	}
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].PushedAt.After(recent[j].PushedAt)
+	})
+	if len(recent) > bitbucketCalendarRepos {
+		recent = recent[:bitbucketCalendarRepos]
+	}
+
+	var commits []bitbucketCommit
+	for _, repo := range recent {
+		next := bp.baseURL + "/repositories/" + repo.FullName + "/commits?pagelen=100"
+		for pages := 0; next != "" && pages < bitbucketCommitPages; pages++ {
+			var page bitbucketCommitPage
+			if err := bp.get(ctx, next, &page); err != nil {
+				// Empty repositories have no commits to list
+				break
+			}
+			commits = append(commits, page.Values...)
+			next = page.Next
+			if len(page.Values) > 0 && page.Values[len(page.Values)-1].Date.Before(since) {
+				break
+			}
+		}
+	}
+
+	contributions = bitbucketContributions(username, commits, today)
+	bp.cacheService.SetGitHubData(ctx, username, "bitbucket_contributions", contributions)
+	return &contributions, nil
+}
+
+// GetStats aggregates the workspace's repositories like the GitHub stats
+func (bp *BitbucketProvider) GetStats(ctx context.Context, username string) (*models.GitHubStats, error) {
+	repos, err := bp.GetRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	stats := buildStats(username, repos)
+	return &stats, nil
+}
+
+func (bp *BitbucketProvider) get(ctx context.Context, endpoint string, target interface{}) error {
+	return getHostJSON(ctx, bp.client, endpoint, func(req *http.Request) {
+		if bp.username != "" && bp.appPassword != "" {
+			req.SetBasicAuth(bp.username, bp.appPassword)
+		}
+	}, target)
+}
+
+// bitbucketRepo maps a repository. Bitbucket ids are UUIDs, hashed into the
+// numeric id repositories are keyed by.
+func bitbucketRepo(repo bitbucketRepository, owner string) models.GitHubRepository {
+	id := fnv.New64a()
+	id.Write([]byte(repo.UUID))
+
+	mapped := models.GitHubRepository{
+		GitHubID:    int64(id.Sum64() >> 1),
+		Name:        repo.Name,
+		FullName:    repo.FullName,
+		Description: repo.Description,
+		Private:     repo.IsPrivate,
+		Fork:        repo.Parent != nil,
+		HTMLURL:     repo.Links.HTML.Href,
+		Homepage:    repo.Website,
+		Language:    displayLanguage(repo.Language),
+		Languages:   map[string]int{},
+		Size:        repo.Size / 1024,
+		Topics:      []string{},
+		HasWiki:     repo.HasWiki,
+		PushedAt:    repo.UpdatedOn,
+		CreatedAt:   repo.CreatedOn,
+		UpdatedAt:   repo.UpdatedOn,
+		LastFetched: time.Now(),
+		Owner:       owner,
+	}
+	for _, clone := range repo.Links.Clone {
+		if clone.Name == "https" {
+			mapped.CloneURL = clone.Href
+		}
+	}
+	if repo.MainBranch != nil {
+		mapped.DefaultBranch = repo.MainBranch.Name
+	}
+	return mapped
+}
+
+// displayLanguage capitalizes the lowercase language names of Bitbucket
+// ("go", "javascript") the way GitHub writes them
+func displayLanguage(language string) string {
+	if language == "" {
+		return ""
+	}
+	return strings.ToUpper(language[:1]) + language[1:]
+}
+
+// bitbucketContributions counts the commits username authored per day
+func bitbucketContributions(username string, commits []bitbucketCommit, today time.Time) models.GitHubContributions {
+	counts := make(map[string]int)
+	for _, commit := range commits {
+		if commit.Author.User == nil || !strings.EqualFold(commit.Author.User.Nickname, username) {
+			continue
+		}
+		counts[commit.Date.UTC().Format(contributionDateLayout)]++
+	}
+
+	contributions := buildCalendar(username, counts, today)
+	contributions.Commits = contributions.TotalContributions
+	return contributions
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketRepo(t *testing.T) {
+	var repo bitbucketRepository
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"uuid": "{b7d0d6a1-1111-2222-3333-444455556666}",
+		"name": "api",
+		"full_name": "octocat/api",
+		"is_private": false,
+		"language": "go",
+		"size": 204800,
+		"updated_on": "2026-03-01T10:00:00Z",
+		"mainbranch": {"name": "main"},
+		"links": {
+			"html": {"href": "https://bitbucket.org/octocat/api"},
+			"clone": [
+				{"name": "https", "href": "https://bitbucket.org/octocat/api.git"},
+				{"name": "ssh", "href": "git@bitbucket.org:octocat/api.git"}
+			]
+		}
+	}`), &repo))
+
+	mapped := bitbucketRepo(repo, "octocat")
+	assert.Positive(t, mapped.GitHubID)
+	assert.Equal(t, mapped.GitHubID, bitbucketRepo(repo, "octocat").GitHubID)
+	assert.Equal(t, "Go", mapped.Language)
+	assert.Equal(t, 200, mapped.Size)
+	assert.Equal(t, "main", mapped.DefaultBranch)
+	assert.Equal(t, "https://bitbucket.org/octocat/api.git", mapped.CloneURL)
+	assert.False(t, mapped.Fork)
+	assert.Equal(t, "2026-03-01", mapped.PushedAt.Format(contributionDateLayout))
+}
+
+func TestBitbucketContributions(t *testing.T) {
+	today := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	var commits []bitbucketCommit
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"date": "2026-03-10T09:00:00+00:00", "author": {"user": {"nickname": "OctoCat"}}},
+		{"date": "2026-03-10T08:00:00+00:00", "author": {"user": {"nickname": "octocat"}}},
+		{"date": "2026-03-09T08:00:00+00:00", "author": {"user": {"nickname": "someone"}}},
+		{"date": "2026-03-08T08:00:00+00:00", "author": {"raw": "Unlinked <x@example.com>"}}
+	]`), &commits))
+
+	contributions := bitbucketContributions("octocat", commits, today)
+	assert.Equal(t, 2, contributions.TotalContributions)
+	assert.Equal(t, 2, contributions.Commits)
+	assert.Equal(t, 1, contributions.CurrentStreak)
+}
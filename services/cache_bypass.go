@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/metrics"
+	"time"
+)
+
+type cacheBypassKey struct{}
+
+// WithCacheBypass makes the reads made with ctx skip every cache layer: the
+// route cache, the cache backend and the stored GitHub responses. Fresh
+// results are still written back, so the bypass also refreshes stale
+// entries.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+// CacheBypassed reports whether ctx skips the caches, see WithCacheBypass
+func CacheBypassed(ctx context.Context) bool {
+	bypassed, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypassed
+}
+
+// observeCacheLayer adds the time since start to the cache layer of the
+// request of ctx, if it is timed
+func observeCacheLayer(ctx context.Context, start time.Time) {
+	metrics.ObserveLayer(ctx, metrics.LayerCache, time.Since(start))
+}
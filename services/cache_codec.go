@@ -0,0 +1,148 @@
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ugorji/go/codec"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// gzipEncodingPrefix marks payloads compressed after serialization
+const gzipEncodingPrefix = "gzip+"
+
+// CacheCodec serializes cache values to and from bytes
+type CacheCodec interface {
+	Name() string
+	Marshal(value interface{}) ([]byte, error)
+	Unmarshal(data []byte, target interface{}) error
+}
+
+var cacheCodecs = map[string]CacheCodec{
+	"json":    jsonCacheCodec{},
+	"bson":    bsonCacheCodec{},
+	"msgpack": newMsgpackCacheCodec(),
+}
+
+// GetCacheCodec returns the codec registered under name
+func GetCacheCodec(name string) (CacheCodec, error) {
+	cacheCodec, ok := cacheCodecs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown cache serialization format: %s", name)
+	}
+	return cacheCodec, nil
+}
+
+// jsonCacheCodec is the original format; time values lose their type when
+// decoded into interface{} fields
+type jsonCacheCodec struct{}
+
+func (jsonCacheCodec) Name() string { return "json" }
+
+func (jsonCacheCodec) Marshal(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (jsonCacheCodec) Unmarshal(data []byte, target interface{}) error {
+	return json.Unmarshal(data, target)
+}
+
+// bsonCacheCodec wraps the value in a single-field document so slices and
+// scalars can be stored as raw BSON
+type bsonCacheCodec struct{}
+
+type bsonCacheDocument struct {
+	Value interface{} `bson:"v"`
+}
+
+type bsonCacheRawDocument struct {
+	Value bson.RawValue `bson:"v"`
+}
+
+func (bsonCacheCodec) Name() string { return "bson" }
+
+func (bsonCacheCodec) Marshal(value interface{}) ([]byte, error) {
+	return bson.Marshal(bsonCacheDocument{Value: value})
+}
+
+func (bsonCacheCodec) Unmarshal(data []byte, target interface{}) error {
+	var document bsonCacheRawDocument
+	if err := bson.Unmarshal(data, &document); err != nil {
+		return err
+	}
+	return document.Value.Unmarshal(target)
+}
+
+// msgpackCacheCodec uses the msgpack timestamp extension for time.Time and
+// honours the existing json struct tags
+type msgpackCacheCodec struct {
+	handle *codec.MsgpackHandle
+}
+
+func newMsgpackCacheCodec() msgpackCacheCodec {
+	return msgpackCacheCodec{handle: &codec.MsgpackHandle{WriteExt: true}}
+}
+
+func (msgpackCacheCodec) Name() string { return "msgpack" }
+
+func (mc msgpackCacheCodec) Marshal(value interface{}) ([]byte, error) {
+	var data []byte
+	err := codec.NewEncoderBytes(&data, mc.handle).Encode(value)
+	return data, err
+}
+
+func (mc msgpackCacheCodec) Unmarshal(data []byte, target interface{}) error {
+	return codec.NewDecoderBytes(data, mc.handle).Decode(target)
+}
+
+// encodePayload serializes value with cacheCodec, gzipping the result when it
+// reaches compressThreshold (a negative threshold disables compression)
+func encodePayload(value interface{}, cacheCodec CacheCodec, compressThreshold int) ([]byte, string, error) {
+	payload, err := cacheCodec.Marshal(value)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if compressThreshold < 0 || len(payload) < compressThreshold {
+		return payload, cacheCodec.Name(), nil
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(payload); err != nil {
+		return nil, "", err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), gzipEncodingPrefix + cacheCodec.Name(), nil
+}
+
+// decodePayload reverses encodePayload using the encoding recorded on the entry
+func decodePayload(payload []byte, encoding string, target interface{}) error {
+	name, compressed := strings.CutPrefix(encoding, gzipEncodingPrefix)
+
+	cacheCodec, err := GetCacheCodec(name)
+	if err != nil {
+		return err
+	}
+
+	if compressed {
+		reader, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		if payload, err = io.ReadAll(reader); err != nil {
+			return err
+		}
+	}
+
+	return cacheCodec.Unmarshal(payload, target)
+}
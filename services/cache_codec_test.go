@@ -0,0 +1,130 @@
+package services
+
+import (
+	"fmt"
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleRepositories(n int) []models.GitHubRepository {
+	now := time.Date(2024, 5, 17, 10, 30, 0, 0, time.UTC)
+	repos := make([]models.GitHubRepository, n)
+	for i := range repos {
+		repos[i] = models.GitHubRepository{
+			GitHubID:        int64(1000 + i),
+			Name:            fmt.Sprintf("repo-%d", i),
+			FullName:        fmt.Sprintf("octocat/repo-%d", i),
+			Description:     "A repository used to benchmark cache serialization formats",
+			HTMLURL:         fmt.Sprintf("https://github.com/octocat/repo-%d", i),
+			Language:        "Go",
+			Languages:       map[string]int{"Go": 120000 + i, "Shell": 2300, "Dockerfile": 512},
+			StargazersCount: i * 3,
+			ForksCount:      i,
+			Topics:          []string{"golang", "api", "portfolio"},
+			PushedAt:        now.Add(-time.Duration(i) * time.Hour),
+			CreatedAt:       now.AddDate(-2, 0, 0),
+			UpdatedAt:       now,
+			LastFetched:     now,
+			Owner:           "octocat",
+		}
+	}
+	return repos
+}
+
+func TestCacheCodecsRoundTrip(t *testing.T) {
+	repos := sampleRepositories(3)
+
+	for _, name := range []string{"json", "bson", "msgpack"} {
+		for _, threshold := range []int{-1, 0} {
+			t.Run(fmt.Sprintf("%s/threshold=%d", name, threshold), func(t *testing.T) {
+				cacheCodec, err := GetCacheCodec(name)
+				require.NoError(t, err)
+
+				payload, encoding, err := encodePayload(repos, cacheCodec, threshold)
+				require.NoError(t, err)
+
+				var decoded []models.GitHubRepository
+				require.NoError(t, decodePayload(payload, encoding, &decoded))
+
+				require.Len(t, decoded, len(repos))
+				assert.Equal(t, repos[2].FullName, decoded[2].FullName)
+				assert.Equal(t, repos[2].Languages, decoded[2].Languages)
+				assert.True(t, repos[2].PushedAt.Equal(decoded[2].PushedAt))
+			})
+		}
+	}
+}
+
+func TestCacheCodecPreservesTimeInInterface(t *testing.T) {
+	value := map[string]interface{}{"fetched_at": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	for _, name := range []string{"bson", "msgpack"} {
+		cacheCodec, err := GetCacheCodec(name)
+		require.NoError(t, err)
+
+		payload, encoding, err := encodePayload(value, cacheCodec, -1)
+		require.NoError(t, err)
+
+		var decoded map[string]interface{}
+		require.NoError(t, decodePayload(payload, encoding, &decoded))
+
+		_, isTime := decoded["fetched_at"].(time.Time)
+		if !isTime {
+			// The BSON driver decodes datetimes in interface{} values as primitive.DateTime
+			_, isTime = decoded["fetched_at"].(interface{ Time() time.Time })
+		}
+		assert.True(t, isTime, "%s decoded %T", name, decoded["fetched_at"])
+	}
+}
+
+func TestDecodePayloadUnknownEncoding(t *testing.T) {
+	var target interface{}
+	assert.Error(t, decodePayload([]byte("{}"), "xml", &target))
+}
+
+func BenchmarkCacheCodecs(b *testing.B) {
+	repos := sampleRepositories(200)
+
+	for _, name := range []string{"json", "bson", "msgpack"} {
+		cacheCodec, err := GetCacheCodec(name)
+		require.NoError(b, err)
+
+		for _, compressed := range []bool{false, true} {
+			threshold := -1
+			if compressed {
+				threshold = 0
+			}
+
+			label := name
+			if compressed {
+				label = gzipEncodingPrefix + name
+			}
+
+			b.Run(label+"/encode", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, _, err := encodePayload(repos, cacheCodec, threshold); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+
+			payload, encoding, err := encodePayload(repos, cacheCodec, threshold)
+			require.NoError(b, err)
+			b.Run(label+"/decode", func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(len(payload)))
+				for i := 0; i < b.N; i++ {
+					var decoded []models.GitHubRepository
+					if err := decodePayload(payload, encoding, &decoded); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
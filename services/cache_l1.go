@@ -0,0 +1,160 @@
+package services
+
+import (
+	"container/list"
+	"portfolio-backend/config"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// l1MaxEntrySize keeps large payloads, such as chunked repository listings,
+// out of memory; they are still served from MongoDB
+const l1MaxEntrySize = 1 << 20
+
+// l1 is the in-process LRU layered in front of the MongoDB cache. It is
+// shared by every CacheService, so an invalidation through any of them is
+// seen by all. Other instances only notice after CACHE_L1_TTL.
+var l1 = newMemoryCache()
+
+type memoryEntry struct {
+	key       string
+	payload   []byte
+	encoding  string
+	expiresAt time.Time
+}
+
+// memoryCache is an LRU of encoded cache payloads. Entries are decoded on
+// every read, so callers never share a value.
+type memoryCache struct {
+	mutex  sync.Mutex
+	order  *list.List // most recently used first
+	items  map[string]*list.Element
+	hits   int64
+	misses int64
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (mc *memoryCache) get(key string, now time.Time) (memoryEntry, bool) {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	element, ok := mc.items[key]
+	if !ok {
+		mc.misses++
+		return memoryEntry{}, false
+	}
+
+	entry := element.Value.(memoryEntry)
+	if !now.Before(entry.expiresAt) {
+		mc.remove(element)
+		mc.misses++
+		return memoryEntry{}, false
+	}
+
+	mc.order.MoveToFront(element)
+	mc.hits++
+	return entry, true
+}
+
+// set stores entry, evicting the least recently used entries beyond capacity
+func (mc *memoryCache) set(entry memoryEntry, capacity int) {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	if element, ok := mc.items[entry.key]; ok {
+		element.Value = entry
+		mc.order.MoveToFront(element)
+	} else {
+		mc.items[entry.key] = mc.order.PushFront(entry)
+	}
+
+	for mc.order.Len() > capacity {
+		evicted := mc.order.Back()
+		countersFor(evicted.Value.(memoryEntry).key).evictions.Add(1)
+		mc.remove(evicted)
+	}
+}
+
+func (mc *memoryCache) delete(key string) {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	if element, ok := mc.items[key]; ok {
+		mc.remove(element)
+	}
+}
+
+// deleteMatching drops the keys matching pattern, mirroring the $regex used
+// by DeletePattern in MongoDB
+func (mc *memoryCache) deleteMatching(pattern *regexp.Regexp) {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	for key, element := range mc.items {
+		if pattern.MatchString(key) {
+			mc.remove(element)
+		}
+	}
+}
+
+// clear drops every entry
+func (mc *memoryCache) clear() {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	mc.order.Init()
+	mc.items = make(map[string]*list.Element)
+}
+
+func (mc *memoryCache) stats() map[string]interface{} {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	hitRate := 0.0
+	if total := mc.hits + mc.misses; total > 0 {
+		hitRate = float64(mc.hits) / float64(total)
+	}
+
+	return map[string]interface{}{
+		"entries":  mc.order.Len(),
+		"hits":     mc.hits,
+		"misses":   mc.misses,
+		"hit_rate": hitRate,
+	}
+}
+
+func (mc *memoryCache) remove(element *list.Element) {
+	mc.order.Remove(element)
+	delete(mc.items, element.Value.(memoryEntry).key)
+}
+
+// l1Enabled reports whether CACHE_L1_SIZE allows any entries
+func l1Enabled() bool {
+	return config.AppConfig.CacheL1Size > 0
+}
+
+// l1Store keeps payload in memory for CACHE_L1_TTL, or until the MongoDB
+// entry expires if that is sooner
+func l1Store(key string, payload []byte, encoding string, expiresAt time.Time) {
+	if !l1Enabled() || len(payload) > l1MaxEntrySize {
+		return
+	}
+
+	if limit := time.Now().Add(config.AppConfig.CacheL1TTL); limit.Before(expiresAt) {
+		expiresAt = limit
+	}
+
+	l1.set(memoryEntry{
+		key:       key,
+		payload:   payload,
+		encoding:  encoding,
+		expiresAt: expiresAt,
+	}, config.AppConfig.CacheL1Size)
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newMemoryCache()
+	now := time.Now()
+	expires := now.Add(time.Minute)
+
+	cache.set(memoryEntry{key: "a", expiresAt: expires}, 2)
+	cache.set(memoryEntry{key: "b", expiresAt: expires}, 2)
+
+	// Reading a makes b the eviction candidate
+	_, ok := cache.get("a", now)
+	require.True(t, ok)
+	cache.set(memoryEntry{key: "c", expiresAt: expires}, 2)
+
+	_, ok = cache.get("b", now)
+	assert.False(t, ok)
+	_, ok = cache.get("a", now)
+	assert.True(t, ok)
+	_, ok = cache.get("c", now)
+	assert.True(t, ok)
+
+	// Expired entries are dropped on read
+	_, ok = cache.get("a", expires)
+	assert.False(t, ok)
+	assert.Equal(t, 1, cache.stats()["entries"])
+}
+
+func TestMemoryCacheDeleteMatching(t *testing.T) {
+	cache := newMemoryCache()
+	expires := time.Now().Add(time.Minute)
+	for _, key := range []string{"github:octocat:stats", "github:octocat:profile", "github:octo:stats", "content:portfolio"} {
+		cache.set(memoryEntry{key: key, expiresAt: expires}, 10)
+	}
+
+	// The same pattern InvalidateGitHubCache sends to MongoDB
+	cache.deleteMatching(regexp.MustCompile("github:octocat:.*"))
+
+	assert.Equal(t, 2, cache.stats()["entries"])
+	_, ok := cache.get("github:octo:stats", time.Now())
+	assert.True(t, ok)
+}
+
+func TestCacheServiceServesFromL1(t *testing.T) {
+	config.AppConfig = &config.Config{
+		CacheSerialization: "json",
+		CacheL1Size:        10,
+		CacheL1TTL:         time.Minute,
+	}
+	l1 = newMemoryCache()
+
+	codec, err := GetCacheCodec("json")
+	require.NoError(t, err)
+	payload, encoding, err := encodePayload(models.GitHubStats{Username: "octocat", TotalStars: 42}, codec, -1)
+	require.NoError(t, err)
+	l1Store("github:octocat:stats", payload, encoding, time.Now().Add(time.Hour))
+
+	// No MongoDB collections: a hit must not reach the database
+	cs := &CacheService{}
+	var stats models.GitHubStats
+	require.NoError(t, cs.GetGitHubData(context.Background(), "octocat", "stats", &stats))
+	assert.Equal(t, 42, stats.TotalStars)
+
+	// Every read decodes a fresh copy
+	stats.TotalStars = 0
+	var again models.GitHubStats
+	require.NoError(t, cs.GetGitHubData(context.Background(), "octocat", "stats", &again))
+	assert.Equal(t, 42, again.TotalStars)
+
+	// Entries never outlive CACHE_L1_TTL
+	entry, ok := l1.get("github:octocat:stats", time.Now())
+	require.True(t, ok)
+	assert.True(t, entry.expiresAt.Before(time.Now().Add(time.Minute+time.Second)))
+}
@@ -3,28 +3,58 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"portfolio-backend/config"
 	"portfolio-backend/database"
+	"portfolio-backend/metrics"
 	"portfolio-backend/models"
+	"regexp"
 	"time"
 
+	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ErrCacheValueTooLarge is returned when an encoded value exceeds CACHE_MAX_VALUE_SIZE
+var ErrCacheValueTooLarge = errors.New("cache value exceeds maximum size")
+
+// cacheRequests is exported on /metrics
+var cacheRequests = metrics.NewCounterVec("cache_requests_total",
+	"Cache lookups by layer (l1 or mongodb) and result (hit or miss).", "layer", "result")
+
 type CacheService struct {
 	collection *mongo.Collection
+	chunks     *mongo.Collection
 }
 
 func NewCacheService() *CacheService {
 	return &CacheService{
 		collection: database.Database.Collection("cache"),
+		chunks:     database.Database.Collection("cache_chunks"),
 	}
 }
 
-// Get retrieves a cached value by key
+// Get retrieves a cached value by key, from memory when it was read or
+// written recently (see CACHE_L1_SIZE)
 func (cs *CacheService) Get(ctx context.Context, key string, target interface{}) error {
+	if CacheBypassed(ctx) {
+		return fmt.Errorf("cache bypassed: %s", key)
+	}
+	ctx = metrics.WithinLayer(ctx, metrics.LayerCache)
+	defer observeCacheLayer(ctx, time.Now())
+
+	if l1Enabled() {
+		if entry, ok := l1.get(key, time.Now()); ok {
+			cacheRequests.Inc("l1", "hit")
+			countersFor(key).hits.Add(1)
+			return decodePayload(entry.payload, entry.encoding, target)
+		}
+		cacheRequests.Inc("l1", "miss")
+	}
+
 	var cacheEntry models.CacheEntry
 	
 	filter := bson.M{
@@ -34,55 +64,139 @@ func (cs *CacheService) Get(ctx context.Context, key string, target interface{})
 	
 	err := cs.collection.FindOne(ctx, filter).Decode(&cacheEntry)
 	if err != nil {
+		// Callers fall back to the source either way
+		countersFor(key).misses.Add(1)
 		if err == mongo.ErrNoDocuments {
+			cacheRequests.Inc("mongodb", "miss")
 			return fmt.Errorf("cache miss: %s", key)
 		}
 		return err
 	}
+	cacheRequests.Inc("mongodb", "hit")
+	countersFor(key).hits.Add(1)
+
+	// Legacy entries store the value inline as a BSON document
+	if cacheEntry.Encoding == "" {
+		jsonBytes, err := json.Marshal(cacheEntry.Value)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(jsonBytes, target)
+	}
 
-	// Convert the cached value to the target type
-	jsonBytes, err := json.Marshal(cacheEntry.Value)
-	if err != nil {
-		return err
+	payload := cacheEntry.Payload
+	if cacheEntry.Chunks > 0 {
+		payload, err = cs.readChunks(ctx, cacheEntry)
+		if err != nil {
+			return err
+		}
 	}
 
-	return json.Unmarshal(jsonBytes, target)
+	l1Store(key, payload, cacheEntry.Encoding, cacheEntry.ExpiresAt)
+	return decodePayload(payload, cacheEntry.Encoding, target)
 }
 
-// Set stores a value in cache with TTL
+// Set stores a value in cache with TTL. Values are serialized with the
+// configured CACHE_SERIALIZATION codec, gzipped above CACHE_COMPRESS_THRESHOLD
+// and split across cache_chunks documents when the encoded payload would not
+// fit in a single BSON document.
 func (cs *CacheService) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	ctx = metrics.WithinLayer(ctx, metrics.LayerCache)
+	defer observeCacheLayer(ctx, time.Now())
+
+	cacheCodec, err := GetCacheCodec(config.AppConfig.CacheSerialization)
+	if err != nil {
+		return err
+	}
+
+	compressThreshold := config.AppConfig.CacheCompressThreshold
+	if !config.AppConfig.CacheCompression {
+		compressThreshold = -1
+	}
+
+	payload, encoding, err := encodePayload(value, cacheCodec, compressThreshold)
+	if err != nil {
+		return err
+	}
+
+	// Until the write succeeds, readers must fall through to MongoDB
+	l1.delete(key)
+
+	if len(payload) > config.AppConfig.CacheMaxValueSize {
+		return fmt.Errorf("%w: %s (%d bytes)", ErrCacheValueTooLarge, key, len(payload))
+	}
+
+	now := time.Now()
 	cacheEntry := models.CacheEntry{
 		Key:       key,
-		Value:     value,
-		ExpiresAt: time.Now().Add(ttl),
-		CreatedAt: time.Now(),
+		Encoding:  encoding,
+		Size:      len(payload),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
 	}
 
-	// Use upsert to replace existing entries
+	if len(payload) > config.AppConfig.CacheChunkSize {
+		cacheEntry.ChunkID = uuid.New().String()
+		cacheEntry.Chunks, err = cs.writeChunks(ctx, key, cacheEntry.ChunkID, payload, cacheEntry.ExpiresAt)
+		if err != nil {
+			return err
+		}
+	} else {
+		cacheEntry.Payload = payload
+	}
+
+	// Use a full replacement so fields from a previous encoding don't linger
 	filter := bson.M{"key": key}
-	update := bson.M{"$set": cacheEntry}
-	opts := options.Update().SetUpsert(true)
+	opts := options.Replace().SetUpsert(true)
+
+	if _, err := cs.collection.ReplaceOne(ctx, filter, cacheEntry, opts); err != nil {
+		return err
+	}
+
+	l1Store(key, payload, encoding, cacheEntry.ExpiresAt)
+	countersFor(key).sets.Add(1)
 
-	_, err := cs.collection.UpdateOne(ctx, filter, update, opts)
+	// Drop chunks belonging to previous versions of this key
+	_, err = cs.chunks.DeleteMany(ctx, bson.M{"key": key, "chunk_id": bson.M{"$ne": cacheEntry.ChunkID}})
 	return err
 }
 
 // Delete removes a cached value
 func (cs *CacheService) Delete(ctx context.Context, key string) error {
+	l1.delete(key)
+
 	filter := bson.M{"key": key}
-	_, err := cs.collection.DeleteOne(ctx, filter)
+	if _, err := cs.collection.DeleteOne(ctx, filter); err != nil {
+		return err
+	}
+	_, err := cs.chunks.DeleteMany(ctx, filter)
 	return err
 }
 
 // DeletePattern removes all cache entries matching a pattern
 func (cs *CacheService) DeletePattern(ctx context.Context, pattern string) error {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	l1.deleteMatching(compiled)
+
 	filter := bson.M{"key": bson.M{"$regex": pattern}}
-	_, err := cs.collection.DeleteMany(ctx, filter)
+	if _, err := cs.collection.DeleteMany(ctx, filter); err != nil {
+		return err
+	}
+	_, err = cs.chunks.DeleteMany(ctx, filter)
 	return err
 }
 
 // Exists checks if a key exists in cache and is not expired
 func (cs *CacheService) Exists(ctx context.Context, key string) bool {
+	if l1Enabled() {
+		if _, ok := l1.get(key, time.Now()); ok {
+			return true
+		}
+	}
+
 	filter := bson.M{
 		"key": key,
 		"expires_at": bson.M{"$gt": time.Now()},
@@ -118,10 +232,15 @@ func (cs *CacheService) Cleanup(ctx context.Context) error {
 		return err
 	}
 
+	cacheExpired.Add(result.DeletedCount)
 	if result.DeletedCount > 0 {
 		fmt.Printf("Cleaned up %d expired cache entries\n", result.DeletedCount)
 	}
 
+	if _, err := cs.chunks.DeleteMany(ctx, filter); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -140,12 +259,16 @@ func (cs *CacheService) GetStats(ctx context.Context) (map[string]interface{}, e
 	}
 
 	expiredCount := totalCount - activeCount
+	counts, prefixes := CacheCounts()
 
 	return map[string]interface{}{
 		"total_entries":   totalCount,
 		"active_entries":  activeCount,
 		"expired_entries": expiredCount,
-		"hit_rate":        calculateHitRate(ctx, cs.collection),
+		"hit_rate":        counts.HitRate,
+		"requests":        counts,
+		"prefixes":        prefixes,
+		"l1":              l1.stats(),
 	}, nil
 }
 
@@ -187,17 +310,58 @@ func (cs *CacheService) InvalidateContentCache(ctx context.Context) error {
 	return cs.DeletePattern(ctx, pattern)
 }
 
-// Warm cache with initial data
-func (cs *CacheService) WarmCache(ctx context.Context) error {
-	// This method can be called on startup to pre-populate cache
-	// with frequently accessed data
-	return nil
+// writeChunks splits payload into CACHE_CHUNK_SIZE pieces stored under chunkID
+func (cs *CacheService) writeChunks(ctx context.Context, key, chunkID string, payload []byte, expiresAt time.Time) (int, error) {
+	chunkSize := config.AppConfig.CacheChunkSize
+
+	var documents []interface{}
+	for index := 0; index*chunkSize < len(payload); index++ {
+		end := (index + 1) * chunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		documents = append(documents, models.CacheChunk{
+			Key:       key,
+			ChunkID:   chunkID,
+			Index:     index,
+			Data:      payload[index*chunkSize : end],
+			ExpiresAt: expiresAt,
+		})
+	}
+
+	if _, err := cs.chunks.InsertMany(ctx, documents); err != nil {
+		return 0, err
+	}
+
+	return len(documents), nil
 }
 
-func calculateHitRate(ctx context.Context, collection *mongo.Collection) float64 {
-	// This is a simplified calculation
-	// In a real implementation, you'd want to track hits/misses separately
-	return 0.85 // Placeholder
+// readChunks reassembles a chunked payload, failing if any piece is missing
+func (cs *CacheService) readChunks(ctx context.Context, entry models.CacheEntry) ([]byte, error) {
+	filter := bson.M{"key": entry.Key, "chunk_id": entry.ChunkID}
+	opts := options.Find().SetSort(bson.D{{Key: "index", Value: 1}})
+
+	cursor, err := cs.chunks.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var chunks []models.CacheChunk
+	if err := cursor.All(ctx, &chunks); err != nil {
+		return nil, err
+	}
+
+	if len(chunks) != entry.Chunks {
+		return nil, fmt.Errorf("cache miss: %s (incomplete chunks %d/%d)", entry.Key, len(chunks), entry.Chunks)
+	}
+
+	payload := make([]byte, 0, entry.Size)
+	for _, chunk := range chunks {
+		payload = append(payload, chunk.Data...)
+	}
+
+	return payload, nil
 }
 
 // Background cleanup job
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"portfolio-backend/models"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// cacheCounters counts the outcome of cache calls for one key prefix
+type cacheCounters struct {
+	hits      atomic.Int64
+	misses    atomic.Int64
+	sets      atomic.Int64
+	evictions atomic.Int64
+}
+
+// cacheStats maps key prefixes (github, content, ...) to their counters. It
+// is shared by every CacheService, like l1.
+var cacheStats sync.Map
+
+// cacheExpired counts entries removed by Cleanup, which only knows how many
+// expired and not their keys
+var cacheExpired atomic.Int64
+
+// cacheKeyPrefix is the part of key before the first colon
+func cacheKeyPrefix(key string) string {
+	if i := strings.IndexByte(key, ':'); i > 0 {
+		return key[:i]
+	}
+	return key
+}
+
+func countersFor(key string) *cacheCounters {
+	prefix := cacheKeyPrefix(key)
+	if counters, ok := cacheStats.Load(prefix); ok {
+		return counters.(*cacheCounters)
+	}
+	counters, _ := cacheStats.LoadOrStore(prefix, &cacheCounters{})
+	return counters.(*cacheCounters)
+}
+
+func (cc *cacheCounters) snapshot() models.CacheCounters {
+	counts := models.CacheCounters{
+		Hits:      cc.hits.Load(),
+		Misses:    cc.misses.Load(),
+		Sets:      cc.sets.Load(),
+		Evictions: cc.evictions.Load(),
+	}
+	if total := counts.Hits + counts.Misses; total > 0 {
+		counts.HitRate = float64(counts.Hits) / float64(total)
+	}
+	return counts
+}
+
+// CacheCounts returns the counters of every key prefix and their total
+func CacheCounts() (models.CacheCounters, map[string]models.CacheCounters) {
+	var total cacheCounters
+	total.evictions.Store(cacheExpired.Load())
+
+	prefixes := make(map[string]models.CacheCounters)
+	cacheStats.Range(func(key, value interface{}) bool {
+		counts := value.(*cacheCounters).snapshot()
+		prefixes[key.(string)] = counts
+
+		total.hits.Add(counts.Hits)
+		total.misses.Add(counts.Misses)
+		total.sets.Add(counts.Sets)
+		total.evictions.Add(counts.Evictions)
+		return true
+	})
+
+	return total.snapshot(), prefixes
+}
+
+// CacheHitRate is the share of lookups answered from either cache layer
+func CacheHitRate() float64 {
+	total, _ := CacheCounts()
+	return total.HitRate
+}
+
+// resetCacheCounts is used by tests
+func resetCacheCounts() {
+	cacheStats.Range(func(key, _ interface{}) bool {
+		cacheStats.Delete(key)
+		return true
+	})
+	cacheExpired.Store(0)
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheCountsPerPrefix(t *testing.T) {
+	config.AppConfig = &config.Config{
+		CacheSerialization: "json",
+		CacheL1Size:        1,
+		CacheL1TTL:         time.Minute,
+	}
+	l1 = newMemoryCache()
+	resetCacheCounts()
+	defer resetCacheCounts()
+
+	codec, err := GetCacheCodec("json")
+	require.NoError(t, err)
+	payload, encoding, err := encodePayload(models.GitHubStats{Username: "octocat"}, codec, -1)
+	require.NoError(t, err)
+	l1Store("github:octocat:stats", payload, encoding, time.Now().Add(time.Hour))
+
+	cs := &CacheService{}
+	var stats models.GitHubStats
+	require.NoError(t, cs.GetGitHubData(context.Background(), "octocat", "stats", &stats))
+	require.NoError(t, cs.GetGitHubData(context.Background(), "octocat", "stats", &stats))
+
+	// Room for a single entry: storing another one evicts the first
+	l1Store("content:meta", payload, encoding, time.Now().Add(time.Hour))
+
+	countersFor("content:meta").misses.Add(2)
+	cacheExpired.Add(3)
+
+	total, prefixes := CacheCounts()
+	assert.Equal(t, models.CacheCounters{Hits: 2, Evictions: 1, HitRate: 1}, prefixes["github"])
+	assert.Equal(t, int64(2), prefixes["content"].Misses)
+	assert.Equal(t, int64(2), total.Hits)
+	assert.Equal(t, int64(2), total.Misses)
+	assert.Equal(t, int64(4), total.Evictions)
+	assert.Equal(t, 0.5, total.HitRate)
+	assert.Equal(t, 0.5, CacheHitRate())
+}
+
+func TestCacheKeyPrefix(t *testing.T) {
+	assert.Equal(t, "github", cacheKeyPrefix("github:octocat:stats"))
+	assert.Equal(t, "content", cacheKeyPrefix("content:meta"))
+	assert.Equal(t, "plain", cacheKeyPrefix("plain"))
+}
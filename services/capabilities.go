@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"sync"
+	"time"
+)
+
+// Feature names accepted by FeatureEnabled
+const (
+	FeatureContributions = "contributions"
+	FeatureTraffic       = "traffic"
+)
+
+// unauthenticatedGitHubLimit is the hourly core limit GitHub grants anonymous clients
+const unauthenticatedGitHubLimit = 60
+
+var (
+	featuresMutex sync.RWMutex
+	features      models.FeatureFlags
+)
+
+// DetectCapabilities decides which features this deployment can serve based
+// on the configured GitHub token. Endpoints relying on GraphQL or repository
+// traffic need an authenticated token; everything else degrades to the
+// anonymous rate limit.
+func DetectCapabilities(ctx context.Context) models.FeatureFlags {
+	detected := models.FeatureFlags{DetectedAt: time.Now()}
+
+	if config.AppConfig.GitHubToken == "" {
+		log.Println("GITHUB_TOKEN not set: contributions and traffic features disabled")
+		setFeatures(detected)
+		return detected
+	}
+
+	// Assume the token works unless GitHub tells us otherwise
+	detected.Authenticated = true
+	detected.Contributions = true
+	detected.Traffic = true
+
+	// A rejected token leaves the requests anonymous, like no token at all
+	rateLimit, err := NewGitHubService().CheckRateLimit(ctx)
+	switch {
+	case errors.Is(err, ErrGitHubTokenRejected):
+		log.Println("GITHUB_TOKEN rejected by GitHub: contributions and traffic features disabled")
+		detected = models.FeatureFlags{DetectedAt: detected.DetectedAt}
+	case err != nil:
+		log.Printf("Warning: could not verify GitHub token: %v", err)
+	default:
+		if rate, ok := rateLimit["rate"].(map[string]interface{}); ok {
+			if limit, ok := rate["limit"].(float64); ok && int(limit) <= unauthenticatedGitHubLimit {
+				log.Println("GITHUB_TOKEN rejected by GitHub: contributions and traffic features disabled")
+				detected = models.FeatureFlags{DetectedAt: detected.DetectedAt}
+			}
+		}
+	}
+
+	setFeatures(detected)
+	return detected
+}
+
+// GetFeatures returns the capabilities detected at startup
+func GetFeatures() models.FeatureFlags {
+	featuresMutex.RLock()
+	defer featuresMutex.RUnlock()
+	return features
+}
+
+// FeatureEnabled reports whether the named feature is available
+func FeatureEnabled(name string) bool {
+	current := GetFeatures()
+	switch name {
+	case FeatureContributions:
+		return current.Contributions
+	case FeatureTraffic:
+		return current.Traffic
+	default:
+		return true
+	}
+}
+
+func setFeatures(detected models.FeatureFlags) {
+	featuresMutex.Lock()
+	defer featuresMutex.Unlock()
+	features = detected
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectCapabilitiesRejectedToken(t *testing.T) {
+	status := http.StatusUnauthorized
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status == http.StatusUnauthorized {
+			w.Write([]byte(`{"message":"Bad credentials"}`))
+			return
+		}
+		w.Write([]byte(`{"rate":{"limit":5000,"remaining":4999}}`))
+	}))
+	defer server.Close()
+
+	useOfflineDatabase(t, &config.Config{GitHubToken: "revoked", GitHubAPIURL: server.URL, CacheSerialization: "json"})
+	defer setFeatures(GetFeatures())
+
+	detected := DetectCapabilities(context.Background())
+	assert.False(t, detected.Authenticated)
+	assert.False(t, FeatureEnabled(FeatureTraffic))
+	assert.False(t, FeatureEnabled(FeatureContributions))
+
+	status = http.StatusOK
+	detected = DetectCapabilities(context.Background())
+	assert.True(t, detected.Authenticated)
+	assert.True(t, FeatureEnabled(FeatureTraffic))
+}
@@ -0,0 +1,427 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CDN purge endpoints, variables so tests can point them elsewhere
+var (
+	cloudflareAPIURL = "https://api.cloudflare.com/client/v4"
+	fastlyAPIURL     = "https://api.fastly.com"
+)
+
+// defaultPurgePaths are purged when an integration does not list its own
+var defaultPurgePaths = []string{"/", "/sitemap.xml"}
+
+type CDNPurgeService struct {
+	client          *http.Client
+	settingsService *SettingsService
+	runs            *mongo.Collection
+}
+
+func NewCDNPurgeService() *CDNPurgeService {
+	return &CDNPurgeService{
+		client: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+		settingsService: NewSettingsService(),
+		runs:            database.Database.Collection("cdn_purge_runs"),
+	}
+}
+
+// pendingPurge collects content changes and synced GitHub users until they
+// settle for DEPLOY_HOOK_DEBOUNCE
+var pendingPurge struct {
+	sync.Mutex
+	timer        *time.Timer
+	contentTypes map[string]bool
+	usernames    map[string]bool
+}
+
+// GetIntegrations returns the configured CDN integrations
+func (ps *CDNPurgeService) GetIntegrations(ctx context.Context) ([]models.CDNIntegration, error) {
+	integrations := []models.CDNIntegration{}
+	err := ps.settingsService.Get(ctx, SettingCDNPurge, &integrations)
+	if err != nil && err != ErrSettingNotFound {
+		return nil, err
+	}
+	for i := range integrations {
+		integrations[i].TokenConfigured = integrations[i].APIToken != ""
+	}
+	return integrations, nil
+}
+
+// SetIntegrations replaces the configured CDN integrations, keeping the
+// current API token of an integration when none is given
+func (ps *CDNPurgeService) SetIntegrations(ctx context.Context, integrations []models.CDNIntegration, updatedBy string) error {
+	current, err := ps.GetIntegrations(ctx)
+	if err != nil {
+		return err
+	}
+	tokens := make(map[string]string)
+	for _, integration := range current {
+		tokens[integration.Name] = integration.APIToken
+	}
+
+	seen := make(map[string]bool)
+	for i := range integrations {
+		integration := &integrations[i]
+		if integration.APIToken == "" {
+			integration.APIToken = tokens[integration.Name]
+		}
+		if err := validateCDNIntegration(*integration); err != nil {
+			return err
+		}
+		if seen[integration.Name] {
+			return fmt.Errorf("duplicate CDN integration name: %s", integration.Name)
+		}
+		seen[integration.Name] = true
+		integration.BaseURL = strings.TrimRight(integration.BaseURL, "/")
+		integration.TokenConfigured = false
+	}
+
+	return ps.settingsService.Set(ctx, SettingCDNPurge, integrations, updatedBy)
+}
+
+func validateCDNIntegration(integration models.CDNIntegration) error {
+	if integration.Name == "" {
+		return fmt.Errorf("CDN integrations require a name")
+	}
+	// Integrations purging by tag never build URLs
+	if !integration.PurgeByTag || integration.BaseURL != "" {
+		if integration.BaseURL == "" {
+			return fmt.Errorf("CDN integration %s requires a base_url", integration.Name)
+		}
+		if !strings.HasPrefix(integration.BaseURL, "https://") && !strings.HasPrefix(integration.BaseURL, "http://") {
+			return fmt.Errorf("invalid base_url for %s", integration.Name)
+		}
+	}
+	if integration.APIToken == "" {
+		return fmt.Errorf("CDN integration %s requires an api_token", integration.Name)
+	}
+
+	switch integration.Provider {
+	case models.CDNProviderCloudflare:
+		if integration.ZoneID == "" {
+			return fmt.Errorf("cloudflare integration %s requires a zone_id", integration.Name)
+		}
+	case models.CDNProviderFastly:
+		if integration.PurgeByTag && integration.ServiceID == "" {
+			return fmt.Errorf("fastly integration %s requires a service_id to purge by tag", integration.Name)
+		}
+	default:
+		return fmt.Errorf("unsupported CDN provider for %s: %s", integration.Name, integration.Provider)
+	}
+
+	for _, path := range purgePaths(integration, nil) {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("invalid path for %s: %s", integration.Name, path)
+		}
+	}
+	return nil
+}
+
+// SchedulePurge debounces content changes like the deploy hooks do, so a
+// burst of edits results in a single purge per integration
+func (ps *CDNPurgeService) SchedulePurge(contentType string) {
+	ps.schedule(&pendingPurge.contentTypes, contentType)
+}
+
+// ScheduleSyncPurge purges the responses tagged with username's GitHub data
+// once a sync refreshed it. Only integrations purging by tag are affected,
+// as GitHub data has no paths of its own.
+func (ps *CDNPurgeService) ScheduleSyncPurge(username string) {
+	ps.schedule(&pendingPurge.usernames, username)
+}
+
+func (ps *CDNPurgeService) schedule(pending *map[string]bool, value string) {
+	pendingPurge.Lock()
+	defer pendingPurge.Unlock()
+
+	if *pending == nil {
+		*pending = make(map[string]bool)
+	}
+	(*pending)[value] = true
+
+	if pendingPurge.timer != nil {
+		pendingPurge.timer.Stop()
+	}
+
+	pendingPurge.timer = time.AfterFunc(config.AppConfig.DeployHookDebounce, func() {
+		pendingPurge.Lock()
+		contentTypes := sortedKeys(pendingPurge.contentTypes)
+		usernames := sortedKeys(pendingPurge.usernames)
+		pendingPurge.contentTypes = nil
+		pendingPurge.usernames = nil
+		pendingPurge.timer = nil
+		pendingPurge.Unlock()
+
+		var triggers, keys []string
+		if len(contentTypes) > 0 {
+			triggers = append(triggers, "content:"+strings.Join(contentTypes, ","))
+		}
+		if len(usernames) > 0 {
+			triggers = append(triggers, "sync:"+strings.Join(usernames, ","))
+		}
+		for _, username := range usernames {
+			keys = append(keys, githubSyncKeys(username)...)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		if _, err := ps.Purge(ctx, strings.Join(triggers, " "), contentTypes, keys, ""); err != nil {
+			log.Printf("CDN purge failed: %v", err)
+		}
+	})
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Purge asks every enabled integration (or only the named one) to drop what
+// a change of contentTypes and the surrogate keys in keys affects, and
+// records the outcome. Integrations purge the affected URLs, or surrogate
+// keys when they purge by tag; nil contentTypes and keys purge everything.
+func (ps *CDNPurgeService) Purge(ctx context.Context, trigger string, contentTypes, keys []string, name string) ([]models.CDNPurgeRun, error) {
+	integrations, err := ps.GetIntegrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := []models.CDNPurgeRun{}
+	for _, integration := range integrations {
+		if name != "" && integration.Name != name {
+			continue
+		}
+		// Disabled integrations can still be purged explicitly by name
+		if !integration.Enabled && name == "" {
+			continue
+		}
+
+		var run models.CDNPurgeRun
+		switch {
+		case integration.PurgeByTag:
+			run = ps.purgeTags(ctx, integration, trigger, purgeKeys(contentTypes, keys))
+		case contentTypes != nil && len(contentTypes) == 0:
+			// Only GitHub data changed, which has no paths to purge
+			continue
+		default:
+			run = ps.purge(ctx, integration, trigger, purgeURLs(integration, contentTypes))
+		}
+		if _, err := ps.runs.InsertOne(ctx, run); err != nil {
+			log.Printf("Failed to record CDN purge for %s: %v", integration.Name, err)
+		}
+		runs = append(runs, run)
+	}
+
+	if name != "" && len(runs) == 0 {
+		return nil, fmt.Errorf("CDN integration not found: %s", name)
+	}
+
+	return runs, nil
+}
+
+// GetHistory returns recent purges, optionally for a single integration
+func (ps *CDNPurgeService) GetHistory(ctx context.Context, name string, limit int) ([]models.CDNPurgeRun, error) {
+	filter := bson.M{}
+	if name != "" {
+		filter["integration"] = name
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "purged_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := ps.runs.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	history := []models.CDNPurgeRun{}
+	err = cursor.All(ctx, &history)
+	return history, err
+}
+
+// purgePaths lists the paths affected by a change of contentTypes, in order
+// and without duplicates; nil contentTypes means all of them
+func purgePaths(integration models.CDNIntegration, contentTypes []string) []string {
+	base := integration.Paths
+	if len(base) == 0 {
+		base = defaultPurgePaths
+	}
+
+	seen := make(map[string]bool)
+	paths := []string{}
+	add := func(candidates []string) {
+		for _, path := range candidates {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	add(base)
+	if contentTypes == nil {
+		for contentType := range integration.ContentPaths {
+			contentTypes = append(contentTypes, contentType)
+		}
+		sort.Strings(contentTypes)
+	}
+	for _, contentType := range contentTypes {
+		add(integration.ContentPaths[contentType])
+	}
+	return paths
+}
+
+// purgeURLs turns the affected paths into absolute frontend URLs
+func purgeURLs(integration models.CDNIntegration, contentTypes []string) []string {
+	paths := purgePaths(integration, contentTypes)
+	urls := make([]string, len(paths))
+	for i, path := range paths {
+		urls[i] = integration.BaseURL + path
+	}
+	return urls
+}
+
+func (ps *CDNPurgeService) purge(ctx context.Context, integration models.CDNIntegration, trigger string, urls []string) models.CDNPurgeRun {
+	run := newPurgeRun(integration, trigger)
+	run.URLs = urls
+
+	var err error
+	switch integration.Provider {
+	case models.CDNProviderCloudflare:
+		run.StatusCode, err = ps.purgeCloudflare(ctx, integration, map[string]interface{}{"files": urls})
+	case models.CDNProviderFastly:
+		run.StatusCode, err = ps.purgeFastly(ctx, integration, urls)
+	default:
+		err = fmt.Errorf("unsupported CDN provider: %s", integration.Provider)
+	}
+
+	finishPurgeRun(&run, err)
+	return run
+}
+
+// purgeTags purges the responses tagged with any of the surrogate keys
+func (ps *CDNPurgeService) purgeTags(ctx context.Context, integration models.CDNIntegration, trigger string, keys []string) models.CDNPurgeRun {
+	run := newPurgeRun(integration, trigger)
+	run.Tags = keys
+
+	var err error
+	switch integration.Provider {
+	case models.CDNProviderCloudflare:
+		run.StatusCode, err = ps.purgeCloudflare(ctx, integration, map[string]interface{}{"tags": keys})
+	case models.CDNProviderFastly:
+		run.StatusCode, err = ps.purgeFastlyKeys(ctx, integration, keys)
+	default:
+		err = fmt.Errorf("unsupported CDN provider: %s", integration.Provider)
+	}
+
+	finishPurgeRun(&run, err)
+	return run
+}
+
+func newPurgeRun(integration models.CDNIntegration, trigger string) models.CDNPurgeRun {
+	return models.CDNPurgeRun{
+		ID:          primitive.NewObjectID(),
+		Integration: integration.Name,
+		Provider:    integration.Provider,
+		Trigger:     trigger,
+		PurgedAt:    time.Now(),
+	}
+}
+
+func finishPurgeRun(run *models.CDNPurgeRun, err error) {
+	run.DurationMs = time.Since(run.PurgedAt).Milliseconds()
+	run.Success = err == nil
+	if err != nil {
+		run.Error = err.Error()
+	}
+}
+
+// purgeCloudflare purges every URL ("files") or cache tag ("tags") in one
+// call to the zone's purge_cache endpoint
+func (ps *CDNPurgeService) purgeCloudflare(ctx context.Context, integration models.CDNIntegration, purge map[string]interface{}) (int, error) {
+	body, _ := json.Marshal(purge)
+
+	endpoint := fmt.Sprintf("%s/zones/%s/purge_cache", cloudflareAPIURL, integration.ZoneID)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+integration.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	return ps.send(req)
+}
+
+// purgeFastly purges the URLs one at a time, as Fastly's URL purge takes a
+// single URL, stopping at the first failure
+func (ps *CDNPurgeService) purgeFastly(ctx context.Context, integration models.CDNIntegration, urls []string) (int, error) {
+	status := 0
+	for _, url := range urls {
+		endpoint := fastlyAPIURL + "/purge/" + strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+		req, err := http.NewRequestWithContext(ctx, "POST", endpoint, nil)
+		if err != nil {
+			return status, err
+		}
+		req.Header.Set("Fastly-Key", integration.APIToken)
+
+		status, err = ps.send(req)
+		if err != nil {
+			return status, fmt.Errorf("%s: %w", url, err)
+		}
+	}
+	return status, nil
+}
+
+// purgeFastlyKeys purges every surrogate key of the service in one call
+func (ps *CDNPurgeService) purgeFastlyKeys(ctx context.Context, integration models.CDNIntegration, keys []string) (int, error) {
+	endpoint := fmt.Sprintf("%s/service/%s/purge", fastlyAPIURL, integration.ServiceID)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Fastly-Key", integration.APIToken)
+	req.Header.Set("Surrogate-Key", strings.Join(keys, " "))
+
+	return ps.send(req)
+}
+
+func (ps *CDNPurgeService) send(req *http.Request) (int, error) {
+	resp, err := ps.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeURLs(t *testing.T) {
+	integration := models.CDNIntegration{
+		BaseURL:      "https://example.com",
+		ContentPaths: map[string][]string{"projects": {"/projects", "/"}, "skills": {"/skills"}},
+	}
+
+	assert.Equal(t, []string{"https://example.com/", "https://example.com/sitemap.xml"}, purgeURLs(integration, []string{"profile"}))
+	assert.Equal(t, []string{"https://example.com/", "https://example.com/sitemap.xml", "https://example.com/projects"}, purgeURLs(integration, []string{"projects"}))
+	// Manual purges cover every path
+	assert.Equal(t, []string{"/", "/sitemap.xml", "/projects", "/skills"}, purgePaths(integration, nil))
+
+	integration.Paths = []string{"/snapshot.json"}
+	assert.Equal(t, []string{"/snapshot.json"}, purgePaths(integration, []string{"profile"}))
+}
+
+func TestValidateCDNIntegration(t *testing.T) {
+	valid := models.CDNIntegration{Name: "cf", Provider: models.CDNProviderCloudflare, BaseURL: "https://example.com", ZoneID: "zone", APIToken: "token"}
+	assert.NoError(t, validateCDNIntegration(valid))
+
+	noZone := valid
+	noZone.ZoneID = ""
+	assert.Error(t, validateCDNIntegration(noZone))
+
+	unknown := valid
+	unknown.Provider = "akamai"
+	assert.Error(t, validateCDNIntegration(unknown))
+
+	badPath := valid
+	badPath.ContentPaths = map[string][]string{"projects": {"projects"}}
+	assert.Error(t, validateCDNIntegration(badPath))
+}
+
+func TestPurgeCloudflare(t *testing.T) {
+	var files []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/zone/purge_cache", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		var body struct {
+			Files []string `json:"files"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		files = body.Files
+	}))
+	defer server.Close()
+
+	original := cloudflareAPIURL
+	cloudflareAPIURL = server.URL
+	defer func() { cloudflareAPIURL = original }()
+
+	service := &CDNPurgeService{client: server.Client()}
+	integration := models.CDNIntegration{Name: "cf", Provider: models.CDNProviderCloudflare, BaseURL: "https://example.com", ZoneID: "zone", APIToken: "token"}
+
+	run := service.purge(context.Background(), integration, "manual", purgeURLs(integration, nil))
+	assert.True(t, run.Success)
+	assert.Equal(t, http.StatusOK, run.StatusCode)
+	assert.Equal(t, []string{"https://example.com/", "https://example.com/sitemap.xml"}, files)
+}
+
+func TestPurgeFastlyStopsAtFirstFailure(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("Fastly-Key"))
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/purge/example.com/sitemap.xml" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	original := fastlyAPIURL
+	fastlyAPIURL = server.URL
+	defer func() { fastlyAPIURL = original }()
+
+	service := &CDNPurgeService{client: server.Client()}
+	integration := models.CDNIntegration{
+		Name: "fastly", Provider: models.CDNProviderFastly, BaseURL: "https://example.com", APIToken: "token",
+		Paths: []string{"/", "/sitemap.xml", "/projects"},
+	}
+
+	run := service.purge(context.Background(), integration, "content:projects", purgeURLs(integration, []string{"projects"}))
+	require.False(t, run.Success)
+	assert.Equal(t, http.StatusForbidden, run.StatusCode)
+	assert.Contains(t, run.Error, "https://example.com/sitemap.xml")
+	assert.Equal(t, []string{"/purge/example.com/", "/purge/example.com/sitemap.xml"}, paths)
+}
+
+func TestPurgeKeys(t *testing.T) {
+	// Manual purges drop every tagged response
+	assert.Equal(t, []string{SurrogateKeyContent, SurrogateKeyGitHub}, purgeKeys(nil, nil))
+
+	assert.Equal(t, []string{"content:all", "content:projects"}, purgeKeys([]string{"projects"}, nil))
+	assert.Equal(t, []string{"github:octocat", "github:portfolio"}, purgeKeys([]string{}, githubSyncKeys("OctoCat")))
+
+	// Responses are tagged with the keys a change purges
+	assert.Contains(t, ContentSurrogateKeys(""), "content:all")
+	assert.Contains(t, GitHubSurrogateKeys("repos", "OctoCat"), "github:octocat")
+	assert.Contains(t, GitHubSurrogateKeys("repos", ""), "github:portfolio")
+}
+
+func TestPurgeByTag(t *testing.T) {
+	var cloudflareTags []string
+	var fastlyKeys string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/zones/zone/purge_cache":
+			var body struct {
+				Tags []string `json:"tags"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			cloudflareTags = body.Tags
+		case "/service/svc/purge":
+			fastlyKeys = r.Header.Get("Surrogate-Key")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	originalCloudflare, originalFastly := cloudflareAPIURL, fastlyAPIURL
+	cloudflareAPIURL, fastlyAPIURL = server.URL, server.URL
+	defer func() { cloudflareAPIURL, fastlyAPIURL = originalCloudflare, originalFastly }()
+
+	service := &CDNPurgeService{client: server.Client()}
+	keys := purgeKeys([]string{"projects"}, nil)
+
+	cloudflare := models.CDNIntegration{Name: "cf", Provider: models.CDNProviderCloudflare, ZoneID: "zone", APIToken: "token", PurgeByTag: true}
+	run := service.purgeTags(context.Background(), cloudflare, "content:projects", keys)
+	assert.True(t, run.Success)
+	assert.Equal(t, keys, run.Tags)
+	assert.Equal(t, keys, cloudflareTags)
+
+	fastly := models.CDNIntegration{Name: "fastly", Provider: models.CDNProviderFastly, ServiceID: "svc", APIToken: "token", PurgeByTag: true}
+	run = service.purgeTags(context.Background(), fastly, "content:projects", keys)
+	assert.True(t, run.Success)
+	assert.Equal(t, "content:all content:projects", fastlyKeys)
+
+	// Tag purges need no base_url, but Fastly needs the service to purge
+	assert.NoError(t, validateCDNIntegration(cloudflare))
+	fastly.ServiceID = ""
+	assert.Error(t, validateCDNIntegration(fastly))
+}
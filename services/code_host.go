@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"time"
+)
+
+// Code hosts a portfolio can be powered by, selected with CODE_HOST_PROVIDER
+const (
+	CodeHostGitHub    = "github"
+	CodeHostGitLab    = "gitlab"
+	CodeHostBitbucket = "bitbucket"
+)
+
+// CodeHostProvider serves the profile, repositories, contributions and stats
+// of a user of a code host in the GitHub models, so the /github endpoints
+// keep their shape whichever host powers the portfolio
+type CodeHostProvider interface {
+	Name() string
+	GetProfile(ctx context.Context, username string) (*models.GitHubProfile, error)
+	GetRepositories(ctx context.Context, username string) ([]models.GitHubRepository, error)
+	GetContributions(ctx context.Context, username string) (*models.GitHubContributions, error)
+	GetStats(ctx context.Context, username string) (*models.GitHubStats, error)
+}
+
+// NewCodeHostProvider returns the provider selected with CODE_HOST_PROVIDER,
+// GitHub by default
+func NewCodeHostProvider() CodeHostProvider {
+	switch config.AppConfig.CodeHostProvider {
+	case CodeHostGitLab:
+		return NewGitLabProvider()
+	case CodeHostBitbucket:
+		return NewBitbucketProvider()
+	case CodeHostGitHub, "":
+		return NewGitHubService()
+	default:
+		log.Printf("Unknown CODE_HOST_PROVIDER %q, using GitHub", config.AppConfig.CodeHostProvider)
+		return NewGitHubService()
+	}
+}
+
+// Name identifies GitHub as a CodeHostProvider
+func (gs *GitHubService) Name() string {
+	return CodeHostGitHub
+}
+
+// codeHostTimeout bounds each call to GitLab or Bitbucket
+const codeHostTimeout = 30 * time.Second
+
+// codeHostCalendarDays is how far back the contribution calendar of the
+// hosts without one of their own goes, as GitHub's last-year calendar
+const codeHostCalendarDays = 365
+
+// getHostJSON decodes the response of a GET to a code host API into target,
+// authenticating the request with auth
+func getHostJSON(ctx context.Context, client *http.Client, url string, auth func(*http.Request), target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if auth != nil {
+		auth(req)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", req.URL.Host, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// buildCalendar turns contributions counted per day (by date) into the last
+// year of the contribution calendar. Levels split the busiest day's count
+// in quarters, as GitHub's quartiles would.
+func buildCalendar(username string, counts map[string]int, today time.Time) models.GitHubContributions {
+	today = startOfDay(today)
+	since := today.AddDate(0, 0, -(codeHostCalendarDays - 1))
+
+	busiest := 0
+	for date, count := range counts {
+		if date >= since.Format(contributionDateLayout) && count > busiest {
+			busiest = count
+		}
+	}
+
+	var days []models.ContributionDay
+	total := 0
+	for date := since; !date.After(today); date = date.AddDate(0, 0, 1) {
+		count := counts[date.Format(contributionDateLayout)]
+		total += count
+		days = append(days, models.ContributionDay{
+			Date:  date.Format(contributionDateLayout),
+			Count: count,
+			Level: calendarLevel(count, busiest),
+		})
+	}
+
+	longest, current := contributionStreaks(days, today)
+	contributions := models.GitHubContributions{
+		Username:             username,
+		TotalContributions:   total,
+		ContributionCalendar: groupContributionWeeks(days, since),
+		ContributionYears:    []int{},
+		LongestStreak:        longest,
+		CurrentStreak:        current,
+		LastFetched:          time.Now(),
+	}
+	for year := since.Year(); year <= today.Year(); year++ {
+		contributions.ContributionYears = append([]int{year}, contributions.ContributionYears...)
+	}
+	return contributions
+}
+
+// calendarLevel is the 0-4 intensity of count against the busiest day
+func calendarLevel(count, busiest int) int {
+	if count <= 0 || busiest <= 0 {
+		return 0
+	}
+	level := (count*4 + busiest - 1) / busiest
+	if level > 4 {
+		level = 4
+	}
+	return level
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"strings"
+	"time"
+)
+
+// SourceAccount is an account on one of the code hosts
+type SourceAccount struct {
+	Provider string
+	Username string
+}
+
+// Label names the account as host:username
+func (a SourceAccount) Label() string {
+	return a.Provider + ":" + a.Username
+}
+
+// CodeHostAggregator merges the repositories and stats of accounts spread
+// over GitHub, GitLab and Bitbucket
+type CodeHostAggregator struct {
+	providers map[string]CodeHostProvider
+}
+
+func NewCodeHostAggregator(githubService *GitHubService) *CodeHostAggregator {
+	return &CodeHostAggregator{
+		providers: map[string]CodeHostProvider{
+			CodeHostGitHub:    githubService,
+			CodeHostGitLab:    NewGitLabProvider(),
+			CodeHostBitbucket: NewBitbucketProvider(),
+		},
+	}
+}
+
+// CodeHostAccounts lists the GitHub portfolio accounts followed by the
+// accounts of CODE_HOST_ACCOUNTS, each once
+func CodeHostAccounts(githubAccounts []string) []SourceAccount {
+	var accounts []SourceAccount
+	for _, username := range githubAccounts {
+		accounts = append(accounts, SourceAccount{Provider: CodeHostGitHub, Username: username})
+	}
+	accounts = append(accounts, parseCodeHostAccounts(config.AppConfig.CodeHostAccounts)...)
+
+	seen := make(map[string]bool)
+	unique := accounts[:0]
+	for _, account := range accounts {
+		if key := strings.ToLower(account.Label()); !seen[key] {
+			seen[key] = true
+			unique = append(unique, account)
+		}
+	}
+	return unique
+}
+
+// parseCodeHostAccounts reads a comma-separated list of host:username
+// entries; entries without a host are GitHub accounts
+func parseCodeHostAccounts(spec string) []SourceAccount {
+	var accounts []SourceAccount
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		account := SourceAccount{Provider: CodeHostGitHub, Username: entry}
+		if host, username, found := strings.Cut(entry, ":"); found {
+			account = SourceAccount{Provider: strings.ToLower(strings.TrimSpace(host)), Username: strings.TrimSpace(username)}
+		}
+		switch account.Provider {
+		case CodeHostGitHub, CodeHostGitLab, CodeHostBitbucket:
+			if account.Username != "" {
+				accounts = append(accounts, account)
+			}
+		default:
+			log.Printf("Ignoring code host account %q: unknown host %q", entry, account.Provider)
+		}
+	}
+	return accounts
+}
+
+// GetAggregateStats aggregates stats over the merged repositories of
+// accounts on any host and sums their contributions, with a per-account
+// breakdown
+func (ca *CodeHostAggregator) GetAggregateStats(ctx context.Context, accounts []SourceAccount) (*models.PortfolioStats, error) {
+	listings := make([][]models.GitHubRepository, len(accounts))
+	for i, account := range accounts {
+		repos, err := ca.providers[account.Provider].GetRepositories(ctx, account.Username)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", account.Label(), err)
+		}
+		listings[i] = repos
+	}
+
+	portfolio := mergeSourceRepositories(accounts, listings)
+	merged := buildStats("", portfolio.Repositories)
+	stats := &models.PortfolioStats{
+		Accounts:          portfolio.Accounts,
+		TotalRepos:        merged.TotalRepos,
+		TotalStars:        merged.TotalStars,
+		TotalForks:        merged.TotalForks,
+		DuplicateRepos:    portfolio.DuplicateRepos,
+		MostUsedLanguages: merged.MostUsedLanguages,
+		TopRepositories:   merged.TopRepositories,
+		Breakdown:         portfolio.Breakdown,
+		LastFetched:       time.Now(),
+	}
+
+	// Mirrored repositories count once, but their commits are counted on
+	// every host they were pushed to
+	if FeatureEnabled(FeatureContributions) {
+		for i, account := range accounts {
+			contributions, err := ca.providers[account.Provider].GetContributions(ctx, account.Username)
+			if err != nil {
+				log.Printf("Contributions of %s unavailable: %v", account.Label(), err)
+				continue
+			}
+			stats.Breakdown[i].Contributions = contributions.TotalContributions
+			stats.TotalContributions += contributions.TotalContributions
+		}
+	}
+
+	return stats, nil
+}
+
+// mergeSourceRepositories merges the listing of each account, in order,
+// dropping repositories already listed under an earlier account: the same
+// repository on the same host, or a repository of the same name on another
+// host, taken for a mirror
+func mergeSourceRepositories(accounts []SourceAccount, listings [][]models.GitHubRepository) *models.PortfolioRepositories {
+	portfolio := &models.PortfolioRepositories{
+		Accounts:     []string{},
+		Repositories: []models.GitHubRepository{},
+	}
+
+	seen := make(map[string]bool)
+	hostOf := make(map[string]string)
+	for i, account := range accounts {
+		portfolio.Accounts = append(portfolio.Accounts, account.Label())
+		breakdown := models.AccountBreakdown{Provider: account.Provider, Username: account.Username, Repos: len(listings[i])}
+
+		for _, repo := range listings[i] {
+			if !repo.Fork && !repo.Private {
+				breakdown.Stars += repo.StargazersCount
+				breakdown.Forks += repo.ForksCount
+			}
+
+			// Ids are only unique within a host
+			key := fmt.Sprintf("%s:%d", account.Provider, repo.GitHubID)
+			name := strings.ToLower(repo.Name)
+			if host, listed := hostOf[name]; seen[key] || (listed && host != account.Provider) {
+				portfolio.DuplicateRepos++
+				continue
+			}
+			seen[key] = true
+			if _, listed := hostOf[name]; !listed {
+				hostOf[name] = account.Provider
+			}
+			portfolio.Repositories = append(portfolio.Repositories, repo)
+		}
+
+		portfolio.Breakdown = append(portfolio.Breakdown, breakdown)
+	}
+
+	return portfolio
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeHostAccounts(t *testing.T) {
+	config.AppConfig = &config.Config{CodeHostAccounts: " gitlab:octocat, acme-org ,bitbucket:, svn:old, GitHub:Octocat,Bitbucket:acme"}
+
+	accounts := CodeHostAccounts([]string{"octocat", "acme"})
+	assert.Equal(t, []SourceAccount{
+		{Provider: CodeHostGitHub, Username: "octocat"},
+		{Provider: CodeHostGitHub, Username: "acme"},
+		{Provider: CodeHostGitLab, Username: "octocat"},
+		{Provider: CodeHostGitHub, Username: "acme-org"},
+		{Provider: CodeHostBitbucket, Username: "acme"},
+	}, accounts)
+	assert.Equal(t, "gitlab:octocat", accounts[2].Label())
+}
+
+func TestMergeSourceRepositories(t *testing.T) {
+	accounts := []SourceAccount{
+		{Provider: CodeHostGitHub, Username: "octocat"},
+		{Provider: CodeHostGitHub, Username: "acme"},
+		{Provider: CodeHostGitLab, Username: "octocat"},
+	}
+	shared := models.GitHubRepository{GitHubID: 3, Name: "shared", StargazersCount: 10}
+	listings := [][]models.GitHubRepository{
+		{{GitHubID: 1, Name: "dotfiles", StargazersCount: 2}, shared},
+		{shared, {GitHubID: 4, Name: "dotfiles", StargazersCount: 1}},
+		{
+			// Same id as a GitHub repository, but another project
+			{GitHubID: 1, Name: "infra", StargazersCount: 3},
+			// Mirror of the GitHub repository
+			{GitHubID: 9, Name: "Dotfiles"},
+		},
+	}
+
+	portfolio := mergeSourceRepositories(accounts, listings)
+	assert.Equal(t, []string{"github:octocat", "github:acme", "gitlab:octocat"}, portfolio.Accounts)
+	assert.Len(t, portfolio.Repositories, 4)
+	assert.Equal(t, 2, portfolio.DuplicateRepos)
+	assert.Equal(t, models.AccountBreakdown{Provider: CodeHostGitLab, Username: "octocat", Repos: 2, Stars: 3}, portfolio.Breakdown[2])
+
+	stats := buildStats("", portfolio.Repositories)
+	assert.Equal(t, 16, stats.TotalStars)
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCalendar(t *testing.T) {
+	today := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	counts := map[string]int{
+		"2026-03-10": 8,
+		"2026-03-09": 2,
+		"2026-03-08": 1,
+		"2026-03-01": 4,
+		// Outside the last year
+		"2024-01-01": 50,
+	}
+
+	contributions := buildCalendar("octocat", counts, today)
+	assert.Equal(t, 15, contributions.TotalContributions)
+	assert.Equal(t, 3, contributions.CurrentStreak)
+	assert.Equal(t, 3, contributions.LongestStreak)
+	assert.Equal(t, []int{2026, 2025}, contributions.ContributionYears)
+
+	days := flattenContributionWeeks(contributions.ContributionCalendar)
+	require.Len(t, days, codeHostCalendarDays)
+	assert.Equal(t, "2025-03-11", days[0].Date)
+	last := days[len(days)-1]
+	assert.Equal(t, "2026-03-10", last.Date)
+	assert.Equal(t, 4, last.Level)
+}
+
+func TestCalendarLevel(t *testing.T) {
+	assert.Equal(t, 0, calendarLevel(0, 8))
+	assert.Equal(t, 1, calendarLevel(1, 8))
+	assert.Equal(t, 1, calendarLevel(2, 8))
+	assert.Equal(t, 2, calendarLevel(3, 8))
+	assert.Equal(t, 4, calendarLevel(8, 8))
+	assert.Equal(t, 0, calendarLevel(3, 0))
+}
+
+func TestGetHostJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"username": "octocat"}`))
+	}))
+	defer server.Close()
+
+	var user gitlabUser
+	auth := func(req *http.Request) { req.Header.Set("PRIVATE-TOKEN", "secret") }
+	require.NoError(t, getHostJSON(context.Background(), server.Client(), server.URL, auth, &user))
+	assert.Equal(t, "octocat", user.Username)
+
+	assert.Error(t, getHostJSON(context.Background(), server.Client(), server.URL, nil, &user))
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"net/url"
+	"portfolio-backend/models"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Range of the commit insights: the commits of the last commitInsightsDays
+// in the commitInsightsRepos most recently pushed repositories, one page of
+// commits each
+const (
+	commitInsightsDays  = 90
+	commitInsightsRepos = 20
+	commitInsightsTerms = 30
+)
+
+// conventionalCommit matches the "type(scope)!: " prefix of a conventional
+// commit subject
+var conventionalCommit = regexp.MustCompile(`^([a-zA-Z]+)(\([^)]*\))?!?:\s*`)
+
+// commitOtherType counts the commits not following the convention
+const commitOtherType = "other"
+
+// commitStopWords are left out of the top terms: common English and
+// Portuguese words and verbs every commit log is full of
+var commitStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "into": true, "that": true,
+	"this": true, "when": true, "not": true, "are": true, "was": true, "all": true, "its": true,
+	"use": true, "add": true, "added": true, "adds": true, "update": true, "updated": true,
+	"updates": true, "remove": true, "removed": true, "fix": true, "fixed": true, "fixes": true,
+	"change": true, "changed": true, "changes": true, "make": true, "more": true, "some": true,
+	"para": true, "com": true, "que": true, "dos": true, "das": true, "uma": true, "nos": true,
+	"nas": true, "por": true, "sem": true, "adiciona": true, "atualiza": true, "corrige": true,
+}
+
+type githubCommit struct {
+	Commit struct {
+		Message string `json:"message"`
+	} `json:"commit"`
+}
+
+// GetCommitInsights returns the insights of username's recent commit
+// messages, computed on a cache miss. The commit_insights job refreshes them
+// ahead of time.
+func (gs *GitHubService) GetCommitInsights(ctx context.Context, username string) (*models.CommitInsights, error) {
+	var insights models.CommitInsights
+	if err := gs.cacheService.GetGitHubData(ctx, username, "commit_insights", &insights); err == nil {
+		return &insights, nil
+	}
+	return gs.RefreshCommitInsights(ctx, username)
+}
+
+// RefreshCommitInsights fetches the commits username made in the last
+// commitInsightsDays to the repositories they own, not forks, and caches the
+// insights of their messages. Repositories whose commits cannot be listed,
+// such as empty ones, are skipped.
+func (gs *GitHubService) RefreshCommitInsights(ctx context.Context, username string) (*models.CommitInsights, error) {
+	repos, err := gs.GetRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	since := startOfDay(time.Now()).AddDate(0, 0, -commitInsightsDays)
+	var recent []models.GitHubRepository
+	for _, repo := range repos {
+		if !repo.Fork && !repo.Private && repo.PushedAt.After(since) {
+			recent = append(recent, repo)
+		}
+	}
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].PushedAt.After(recent[j].PushedAt)
+	})
+	if len(recent) > commitInsightsRepos {
+		recent = recent[:commitInsightsRepos]
+	}
+
+	var messages []string
+	active := 0
+	for _, repo := range recent {
+		var commits []githubCommit
+		endpoint := githubAPI("/repos/%s/commits?author=%s&since=%s&per_page=100",
+			repo.FullName, url.QueryEscape(username), url.QueryEscape(since.UTC().Format(time.RFC3339)))
+		if err := gs.getJSON(ctx, endpoint, &commits); err != nil {
+			if errors.Is(err, ErrUpstreamBudgetExhausted) || errors.Is(err, ErrGitHubUnavailable) || ctx.Err() != nil {
+				return nil, err
+			}
+			log.Printf("Commits of %s unavailable: %v", repo.FullName, err)
+			continue
+		}
+		if len(commits) > 0 {
+			active++
+		}
+		for _, commit := range commits {
+			messages = append(messages, commit.Commit.Message)
+		}
+	}
+
+	insights := buildCommitInsights(messages)
+	insights.Username = username
+	insights.Since = since
+	insights.Repositories = active
+	insights.LastFetched = time.Now()
+
+	gs.cacheService.SetGitHubData(ctx, username, "commit_insights", insights)
+	return &insights, nil
+}
+
+// buildCommitInsights counts the terms, the conventional commit types and
+// the length of the subject lines of messages. Merge commits are left out.
+func buildCommitInsights(messages []string) models.CommitInsights {
+	terms := make(map[string]int)
+	types := make(map[string]int)
+	commits, length := 0, 0
+
+	for _, message := range messages {
+		subject, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+		subject = strings.TrimSpace(subject)
+		if subject == "" || strings.HasPrefix(subject, "Merge ") {
+			continue
+		}
+		commits++
+		length += utf8.RuneCountInString(subject)
+
+		commitType := commitOtherType
+		if match := conventionalCommit.FindStringSubmatch(subject); match != nil {
+			commitType = strings.ToLower(match[1])
+			subject = subject[len(match[0]):]
+		}
+		types[commitType]++
+
+		for _, term := range commitTerms(subject) {
+			terms[term]++
+		}
+	}
+
+	insights := models.CommitInsights{
+		Commits:  commits,
+		TopTerms: []models.CommitTerm{},
+		Types:    []models.CommitTypeShare{},
+	}
+	if commits == 0 {
+		return insights
+	}
+	insights.AverageLength = math.Round(float64(length)/float64(commits)*10) / 10
+
+	for term, count := range terms {
+		insights.TopTerms = append(insights.TopTerms, models.CommitTerm{Term: term, Count: count})
+	}
+	sort.Slice(insights.TopTerms, func(i, j int) bool {
+		a, b := insights.TopTerms[i], insights.TopTerms[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Term < b.Term
+	})
+	if len(insights.TopTerms) > commitInsightsTerms {
+		insights.TopTerms = insights.TopTerms[:commitInsightsTerms]
+	}
+
+	for commitType, count := range types {
+		insights.Types = append(insights.Types, models.CommitTypeShare{
+			Type:       commitType,
+			Count:      count,
+			Percentage: math.Round(float64(count)/float64(commits)*1000) / 10,
+		})
+	}
+	sort.Slice(insights.Types, func(i, j int) bool {
+		a, b := insights.Types[i], insights.Types[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Type < b.Type
+	})
+	return insights
+}
+
+// commitTerms splits a subject line into lowercase words of three letters
+// or more, without numbers and stop words
+func commitTerms(subject string) []string {
+	words := strings.FieldsFunc(strings.ToLower(subject), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_'
+	})
+
+	var terms []string
+	for _, word := range words {
+		word = strings.Trim(word, "-_")
+		if utf8.RuneCountInString(word) < 3 || commitStopWords[word] || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+			continue
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCommitInsights(t *testing.T) {
+	insights := buildCommitInsights([]string{
+		"feat(api): add badge endpoint\n\nRenders SVG badges.",
+		"fix: badge width for long labels",
+		"feat!: drop legacy cache",
+		"Merge pull request #12 from felipe/badges",
+		"Tweak README badges",
+		"  ",
+	})
+
+	assert.Equal(t, 4, insights.Commits)
+	assert.InDelta(t, (29+32+24+19)/4.0, insights.AverageLength, 0.05)
+
+	require.Len(t, insights.Types, 3)
+	assert.Equal(t, "feat", insights.Types[0].Type)
+	assert.Equal(t, 2, insights.Types[0].Count)
+	assert.InDelta(t, 50.0, insights.Types[0].Percentage, 0.001)
+	assert.Equal(t, "fix", insights.Types[1].Type)
+	assert.Equal(t, commitOtherType, insights.Types[2].Type)
+
+	require.NotEmpty(t, insights.TopTerms)
+	assert.Equal(t, "badge", insights.TopTerms[0].Term)
+	assert.Equal(t, 2, insights.TopTerms[0].Count)
+	for _, term := range insights.TopTerms {
+		assert.NotEqual(t, "feat", term.Term, "the conventional prefix is not a term")
+		assert.NotEqual(t, "add", term.Term)
+	}
+}
+
+func TestBuildCommitInsightsEmpty(t *testing.T) {
+	insights := buildCommitInsights(nil)
+	assert.Zero(t, insights.Commits)
+	assert.NotNil(t, insights.TopTerms)
+	assert.NotNil(t, insights.Types)
+}
+
+func TestCommitTerms(t *testing.T) {
+	assert.Equal(t, []string{"corrigir", "paginação", "rate-limit"}, commitTerms("Corrigir paginação da v2 (rate-limit, #42) em 2025"))
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"portfolio-backend/models"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// icsDate is the layout of DATE values in iCalendar
+const icsDate = "20060102"
+
+// GetContactVCard returns the meta content as a vCard, for contacts apps
+func (cs *ContentService) GetContactVCard(ctx context.Context) (string, error) {
+	meta, err := cs.GetMeta(ctx)
+	if err != nil {
+		return "", err
+	}
+	return buildVCard(*meta), nil
+}
+
+// GetTimelineICS returns the experience and education entries as an
+// iCalendar of all-day events spanning their dates, for calendar apps
+func (cs *ContentService) GetTimelineICS(ctx context.Context) (string, error) {
+	meta, err := cs.GetMeta(ctx)
+	if err != nil {
+		return "", err
+	}
+	experience, err := cs.GetExperience(ctx)
+	if err != nil {
+		return "", err
+	}
+	education, err := cs.GetEducation(ctx)
+	if err != nil {
+		return "", err
+	}
+	return buildTimelineICS(*meta, experience, education, time.Now()), nil
+}
+
+// buildVCard writes a vCard 3.0, the version contacts apps all import
+func buildVCard(meta models.Meta) string {
+	var card contentLines
+	card.add("BEGIN", "VCARD")
+	card.add("VERSION", "3.0")
+
+	given, family := splitName(meta.Name)
+	card.add("N", escapeContentText(family)+";"+escapeContentText(given)+";;;")
+	card.add("FN", escapeContentText(meta.Name))
+	if meta.Title != "" {
+		card.add("TITLE", escapeContentText(meta.Title))
+	}
+	if meta.Email != "" {
+		card.add("EMAIL;TYPE=INTERNET", escapeContentText(meta.Email))
+	}
+	if city, region := splitLocation(meta.Location); city != "" {
+		card.add("ADR", ";;;"+escapeContentText(city)+";"+escapeContentText(region)+";;")
+	}
+	if meta.Website != "" {
+		card.add("URL", meta.Website)
+	}
+	if meta.GitHub != "" {
+		card.add("X-SOCIALPROFILE;TYPE=github", "https://github.com/"+meta.GitHub)
+	}
+	if meta.LinkedIn != "" {
+		card.add("X-SOCIALPROFILE;TYPE=linkedin", meta.LinkedIn)
+	}
+	if meta.Bio != "" {
+		card.add("NOTE", escapeContentText(meta.Bio))
+	}
+	card.add("END", "VCARD")
+	return card.String()
+}
+
+// buildTimelineICS writes an iCalendar with an all-day event per entry with a
+// start date. Current positions run until now; DTEND is exclusive, so it is
+// the day after the last one.
+func buildTimelineICS(meta models.Meta, experience []models.Experience, education []models.Education, now time.Time) string {
+	var cal contentLines
+	cal.add("BEGIN", "VCALENDAR")
+	cal.add("VERSION", "2.0")
+	cal.add("PRODID", "-//portfolio-backend//timeline//EN")
+	cal.add("CALSCALE", "GREGORIAN")
+	cal.add("X-WR-CALNAME", escapeContentText(strings.TrimSpace(meta.Name+" timeline")))
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	event := func(uid string, start time.Time, end *time.Time, summary, location, description, url, category string) {
+		if start.IsZero() {
+			return
+		}
+		last := now
+		if end != nil && !end.IsZero() {
+			last = *end
+		}
+		if last.Before(start) {
+			last = start
+		}
+
+		cal.add("BEGIN", "VEVENT")
+		cal.add("UID", uid+"@portfolio")
+		cal.add("DTSTAMP", stamp)
+		cal.add("DTSTART;VALUE=DATE", start.Format(icsDate))
+		cal.add("DTEND;VALUE=DATE", last.AddDate(0, 0, 1).Format(icsDate))
+		cal.add("SUMMARY", escapeContentText(summary))
+		if location != "" {
+			cal.add("LOCATION", escapeContentText(location))
+		}
+		if description != "" {
+			cal.add("DESCRIPTION", escapeContentText(description))
+		}
+		if url != "" {
+			cal.add("URL", url)
+		}
+		cal.add("CATEGORIES", category)
+		cal.add("TRANSP", "TRANSPARENT")
+		cal.add("END", "VEVENT")
+	}
+
+	for i, exp := range experience {
+		var end *time.Time
+		if !exp.IsCurrent {
+			end = exp.EndDate
+		}
+		event(timelineUID("experience", exp.ID, i), exp.StartDate, end,
+			exp.Position+" at "+exp.Company, exp.Location, exp.Description, exp.CompanyURL, "Experience")
+	}
+	for i, edu := range education {
+		summary := edu.Degree
+		if edu.Field != "" {
+			summary += ", " + edu.Field
+		}
+		event(timelineUID("education", edu.ID, i), edu.StartDate, edu.EndDate,
+			summary+" at "+edu.Institution, "", edu.Description, edu.URL, "Education")
+	}
+
+	cal.add("END", "VCALENDAR")
+	return cal.String()
+}
+
+// timelineUID identifies an event by its entry's ID, or by its position for
+// entries saved before they had one
+func timelineUID(kind string, id primitive.ObjectID, index int) string {
+	if id.IsZero() {
+		return fmt.Sprintf("%s-%d", kind, index)
+	}
+	return kind + "-" + id.Hex()
+}
+
+// splitName splits a full name into given names and the family name, taken
+// to be the last word
+func splitName(name string) (given, family string) {
+	words := strings.Fields(name)
+	if len(words) < 2 {
+		return strings.TrimSpace(name), ""
+	}
+	return strings.Join(words[:len(words)-1], " "), words[len(words)-1]
+}
+
+// contentLines builds vCard and iCalendar content: CRLF terminated lines,
+// folded at 75 octets
+type contentLines struct {
+	b strings.Builder
+}
+
+func (l *contentLines) add(name, value string) {
+	line := name + ":" + value
+	limit := 75
+	for len(line) > limit {
+		// Fold between characters, never inside one
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		l.b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74 // after the leading space
+	}
+	l.b.WriteString(line + "\r\n")
+}
+
+func (l *contentLines) String() string {
+	return l.b.String()
+}
+
+// escapeContentText escapes a TEXT value of vCard and iCalendar
+func escapeContentText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
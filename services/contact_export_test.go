@@ -0,0 +1,78 @@
+package services
+
+import (
+	"portfolio-backend/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBuildVCard(t *testing.T) {
+	card := buildVCard(models.Meta{
+		Name:     "Ada King Lovelace",
+		Title:    "Engineer; Backend",
+		Email:    "ada@example.com",
+		Location: "São Paulo, SP",
+		Website:  "https://ada.dev",
+		GitHub:   "ada",
+		Bio:      "Builds APIs,\nships often",
+	})
+
+	assert.Equal(t, strings.Join([]string{
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"N:Lovelace;Ada King;;;",
+		"FN:Ada King Lovelace",
+		`TITLE:Engineer\; Backend`,
+		"EMAIL;TYPE=INTERNET:ada@example.com",
+		"ADR:;;;São Paulo;SP;;",
+		"URL:https://ada.dev",
+		"X-SOCIALPROFILE;TYPE=github:https://github.com/ada",
+		`NOTE:Builds APIs\,\nships often`,
+		"END:VCARD",
+		"",
+	}, "\r\n"), card)
+}
+
+func TestBuildTimelineICS(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+	id := primitive.NewObjectID()
+
+	ics := buildTimelineICS(models.Meta{Name: "Ada"}, []models.Experience{
+		{ID: id, Company: "Acme", Position: "Engineer", StartDate: start, EndDate: &end},
+		{Company: "Initech", Position: "Lead", StartDate: end, EndDate: &end, IsCurrent: true},
+		{Company: "Undated", Position: "Intern"},
+	}, []models.Education{
+		{Institution: "USP", Degree: "BSc", Field: "Computer Science", StartDate: start, EndDate: &end},
+	}, now)
+
+	assert.Contains(t, ics, "X-WR-CALNAME:Ada timeline\r\n")
+	assert.Equal(t, 3, strings.Count(ics, "BEGIN:VEVENT"), "entries without a start date are left out")
+	assert.Contains(t, ics, "UID:experience-"+id.Hex()+"@portfolio\r\nDTSTAMP:20240510T120000Z\r\nDTSTART;VALUE=DATE:20210301\r\nDTEND;VALUE=DATE:20230701\r\nSUMMARY:Engineer at Acme\r\n")
+	assert.Contains(t, ics, "UID:experience-1@portfolio\r\n", "entries without an ID are numbered")
+	assert.Contains(t, ics, "DTSTART;VALUE=DATE:20230630\r\nDTEND;VALUE=DATE:20240511\r\n", "current positions run until today")
+	assert.Contains(t, ics, `SUMMARY:BSc\, Computer Science at USP`)
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+}
+
+func TestContentLinesFolding(t *testing.T) {
+	var lines contentLines
+	lines.add("NOTE", strings.Repeat("é", 80))
+
+	folded := strings.Split(strings.TrimSuffix(lines.String(), "\r\n"), "\r\n")
+	assert.Greater(t, len(folded), 1)
+	for i, line := range folded {
+		assert.True(t, len(line) <= 75, "line %d is %d octets", i, len(line))
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, " "))
+		}
+	}
+
+	unfolded := strings.ReplaceAll(strings.TrimSuffix(lines.String(), "\r\n"), "\r\n ", "")
+	assert.Equal(t, "NOTE:"+strings.Repeat("é", 80), unfolded, "characters are never split")
+}
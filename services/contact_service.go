@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// contactNotifyTimeout bounds the email sent for a contact message, which
+// is sent after the visitor got their answer
+const contactNotifyTimeout = 30 * time.Second
+
+type ContactService struct {
+	collection *mongo.Collection
+	mailer     Mailer
+}
+
+func NewContactService() *ContactService {
+	return &ContactService{
+		collection: database.Database.Collection("contact_messages"),
+		mailer:     NewMailer(),
+	}
+}
+
+// Submit stores a message sent through the contact form, which must have
+// passed ValidateContactMessageRequest, then emails it to OWNER_EMAIL and
+// announces it on the notification channels in the background. Spam, caught
+// by the honeypot or the words and links that flag feedback, is stored
+// flagged rather than rejected, so bots are not told apart.
+func (cs *ContactService) Submit(ctx context.Context, clientIP string, request models.ContactMessageRequest) (*models.ContactMessage, error) {
+	message := models.ContactMessage{
+		Name:      strings.Join(strings.Fields(utils.SanitizeString(request.Name)), " "),
+		Email:     strings.TrimSpace(request.Email),
+		Subject:   strings.Join(strings.Fields(utils.SanitizeString(request.Subject)), " "),
+		Message:   utils.SanitizeString(request.Message),
+		Visitor:   HashVisitor(clientIP),
+		CreatedAt: time.Now(),
+	}
+	message.Spam = request.Website != "" || isFeedbackSpam(message.Subject+" "+message.Message)
+
+	result, err := cs.collection.InsertOne(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+	message.ID = result.InsertedID.(primitive.ObjectID)
+
+	if !message.Spam {
+		Notify(NotifyContactMessage, contactMessageText(message))
+		if config.AppConfig.OwnerEmail != "" {
+			go cs.notify(message)
+		}
+	}
+	return &message, nil
+}
+
+// notify emails a contact message to the owner, replying to the sender, and
+// records that it was sent
+func (cs *ContactService) notify(message models.ContactMessage) {
+	ctx, cancel := context.WithTimeout(context.Background(), contactNotifyTimeout)
+	defer cancel()
+
+	if err := cs.mailer.Send(ctx, contactMail(message)); err != nil {
+		log.Printf("Failed to email contact message %s: %v", message.ID.Hex(), err)
+		return
+	}
+	if _, err := cs.collection.UpdateOne(ctx, bson.M{"_id": message.ID}, bson.M{"$set": bson.M{"notified": true}}); err != nil {
+		log.Printf("Failed to mark contact message %s as emailed: %v", message.ID.Hex(), err)
+	}
+}
+
+// contactMail is the email a contact message is forwarded in
+func contactMail(message models.ContactMessage) MailMessage {
+	subject := "Portfolio contact from " + message.Name
+	if message.Subject != "" {
+		subject += ": " + message.Subject
+	}
+	return MailMessage{
+		To:      []string{config.AppConfig.OwnerEmail},
+		Subject: subject,
+		Body: fmt.Sprintf("From: %s <%s>\nSent: %s\n\n%s\n",
+			message.Name, message.Email, message.CreatedAt.UTC().Format(time.RFC1123), message.Message),
+		ReplyTo: message.Email,
+	}
+}
+
+// ListMessages returns the newest contact messages, the spam ones with
+// spam, optionally only those sent since the given time
+func (cs *ContactService) ListMessages(ctx context.Context, spam bool, since time.Time, limit int) ([]models.ContactMessage, error) {
+	filter := bson.M{"spam": spam}
+	if !since.IsZero() {
+		filter["created_at"] = bson.M{"$gte": since}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := cs.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	messages := []models.ContactMessage{}
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
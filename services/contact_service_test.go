@@ -0,0 +1,46 @@
+package services
+
+import (
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContactMail(t *testing.T) {
+	config.AppConfig = &config.Config{OwnerEmail: "owner@example.com"}
+	message := models.ContactMessage{
+		Name:      "Ada Lovelace",
+		Email:     "ada@example.com",
+		Subject:   "Freelance project",
+		Message:   "Hi! Are you available in May?",
+		CreatedAt: time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	mail := contactMail(message)
+	assert.Equal(t, []string{"owner@example.com"}, mail.To)
+	assert.Equal(t, "ada@example.com", mail.ReplyTo)
+	assert.Equal(t, "Portfolio contact from Ada Lovelace: Freelance project", mail.Subject)
+	assert.Contains(t, mail.Body, "From: Ada Lovelace <ada@example.com>")
+	assert.Contains(t, mail.Body, "Hi! Are you available in May?")
+
+	message.Subject = ""
+	assert.Equal(t, "Portfolio contact from Ada Lovelace", contactMail(message).Subject)
+}
+
+func TestValidateContactMessageRequest(t *testing.T) {
+	valid := models.ContactMessageRequest{Name: "Ada", Email: "ada@example.com", Message: "Let's build something."}
+	assert.True(t, utils.NewValidator().ValidateContactMessageRequest(&valid).IsValid())
+
+	for _, request := range []models.ContactMessageRequest{
+		{Name: " ", Email: "ada@example.com", Message: "Let's build something."},
+		{Name: "Ada", Email: "ada@example", Message: "Let's build something."},
+		{Name: "Ada", Email: "ada@example.com\r\nBcc: spam@example.com", Message: "Let's build something."},
+		{Name: "Ada", Email: "ada@example.com", Message: "Hi"},
+	} {
+		assert.False(t, utils.NewValidator().ValidateContactMessageRequest(&request).IsValid(), "%+v", request)
+	}
+}
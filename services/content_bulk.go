@@ -0,0 +1,366 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrBulkPlanRequired = errors.New("bulk updates must be previewed with X-Dry-Run: true first")
+	ErrBulkPlanOutdated = errors.New("the content or the bulk update changed since it was previewed")
+)
+
+// bulkContentTypes are the content types holding a list of entries, which
+// bulk updates apply to
+var bulkContentTypes = []string{"projects", "experience", "education", "changelog"}
+
+// bulkOperators are the only operators a bulk filter accepts. As in MongoDB,
+// a list field matches when any of its items does.
+var bulkOperators = map[string]bool{
+	"$eq": true, "$ne": true, "$in": true, "$nin": true, "$exists": true,
+	"$gt": true, "$gte": true, "$lt": true, "$lte": true,
+}
+
+// recordBulkAuditEntry hands audit entries to the background writer, a
+// variable so tests can capture them
+var recordBulkAuditEntry = RecordAuditEntry
+
+// ValidateBulkRequest checks that req targets a list content type, filters
+// on known fields with allowed operators only and updates known fields.
+// Errors point into the request body, e.g. at /filter/status/$regex.
+func ValidateBulkRequest(req models.BulkRequest) []utils.ValidationError {
+	validator := utils.NewValidator().At("")
+	validator.Required("type", req.Type).OneOf("type", req.Type, bulkContentTypes)
+	if !validator.IsValid() {
+		return validator.GetErrors()
+	}
+	fields := bulkFields(req.Type)
+
+	for field, condition := range req.Filter {
+		validator.At("/filter")
+		if _, ok := fields[field]; !ok {
+			validator.AddError(field, "Unknown field", "UNKNOWN_FIELD")
+			continue
+		}
+		operators, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		validator.At(utils.JSONPointer("filter", field))
+		for operator, operand := range operators {
+			switch {
+			case !bulkOperators[operator]:
+				validator.AddError(operator, "Unsupported operator", "UNSUPPORTED_OPERATOR")
+			case operator == "$in" || operator == "$nin":
+				if _, ok := operand.([]interface{}); !ok {
+					validator.AddError(operator, "Must be a list", "INVALID_OPERAND")
+				}
+			case operator == "$exists":
+				if _, ok := operand.(bool); !ok {
+					validator.AddError(operator, "Must be true or false", "INVALID_OPERAND")
+				}
+			}
+		}
+	}
+
+	update := req.Update
+	if len(update.Set) == 0 && len(update.Unset) == 0 && len(update.Add) == 0 && len(update.Remove) == 0 {
+		validator.At("").AddError("update", "Nothing to update", "EMPTY_UPDATE")
+	}
+	checkField := func(section, field string, list bool) {
+		validator.At(utils.JSONPointer("update", section))
+		kind, ok := fields[field]
+		switch {
+		case !ok:
+			validator.AddError(field, "Unknown field", "UNKNOWN_FIELD")
+		case field == "id":
+			validator.AddError(field, "Entry IDs cannot be changed", "READ_ONLY_FIELD")
+		case list && kind != reflect.Slice:
+			validator.AddError(field, "Not a list field", "NOT_A_LIST")
+		}
+	}
+	for field := range update.Set {
+		checkField("set", field, false)
+	}
+	for _, field := range update.Unset {
+		checkField("unset", field, false)
+	}
+	for field := range update.Add {
+		checkField("add", field, true)
+	}
+	for field := range update.Remove {
+		checkField("remove", field, true)
+	}
+	return validator.GetErrors()
+}
+
+// bulkFields maps the JSON fields of the entries of contentType to their kind
+func bulkFields(contentType string) map[string]reflect.Kind {
+	fields := make(map[string]reflect.Kind)
+	entry := reflect.TypeOf(contentModels[contentType]()).Elem().Elem()
+	for i := 0; i < entry.NumField(); i++ {
+		name := strings.Split(entry.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = entry.Field(i).Type.Kind()
+		}
+	}
+	return fields
+}
+
+// BulkUpdate applies req to the published entries of its content type that
+// match its filter, publishing them as UpdateContent does. A dry run returns
+// the plan token that applying the same update requires; it no longer
+// matches once the content or the request changes. Every changed entry is
+// listed, with its diff, and problems with the updated content are returned
+// as validation errors without saving anything.
+func (cs *ContentService) BulkUpdate(ctx context.Context, req models.BulkRequest, updatedBy string) (*models.BulkResult, []utils.ValidationError, error) {
+	published := contentModels[req.Type]()
+	err := cs.findContentData(ctx, req.Type, published)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, nil, err
+	}
+	var entries []map[string]interface{}
+	if err := remarshal(published, &entries); err != nil {
+		return nil, nil, err
+	}
+
+	token, err := bulkPlanToken(req, entries)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !IsDryRun(ctx) {
+		if req.PlanToken == "" {
+			return nil, nil, ErrBulkPlanRequired
+		}
+		if req.PlanToken != token {
+			return nil, nil, ErrBulkPlanOutdated
+		}
+	}
+
+	result := &models.BulkResult{Type: req.Type, Entries: []models.BulkEntry{}}
+	for i, entry := range entries {
+		if !bulkMatches(entry, req.Filter) {
+			continue
+		}
+		result.Matched++
+
+		before := toGeneric(entry)
+		applyBulkUpdate(entry, req.Update)
+		after := toGeneric(entry)
+		diff := diffGeneric("", before, after)
+		if len(diff) == 0 {
+			continue
+		}
+
+		id, _ := entry["id"].(string)
+		result.Entries = append(result.Entries, models.BulkEntry{Index: i, ID: id, Diff: diff, Before: before, After: after})
+	}
+	result.Modified = len(result.Entries)
+	if IsDryRun(ctx) {
+		result.PlanToken = token
+	}
+	if result.Modified == 0 {
+		return result, nil, nil
+	}
+
+	validator := utils.NewValidator().ValidateContent(req.Type, entries)
+	if !validator.IsValid() {
+		return nil, validator.GetErrors(), nil
+	}
+	referenceErrors, err := cs.CheckReferences(ctx, req.Type, entries)
+	if err != nil || len(referenceErrors) > 0 {
+		return nil, referenceErrors, err
+	}
+
+	if err := cs.UpdateContent(ctx, req.Type, entries, updatedBy); err != nil {
+		return nil, nil, err
+	}
+	result.Applied = !IsDryRun(ctx)
+	return result, nil, nil
+}
+
+// bulkPlanToken identifies a bulk update of the given published entries
+func bulkPlanToken(req models.BulkRequest, entries []map[string]interface{}) (string, error) {
+	// Maps encode with sorted keys, so equal requests hash alike
+	raw, err := json.Marshal([]interface{}{req.Type, req.Filter, req.Update, entries})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+// bulkMatches reports whether entry matches every condition of filter
+func bulkMatches(entry map[string]interface{}, filter map[string]interface{}) bool {
+	for field, condition := range filter {
+		value, present := entry[field]
+		operators, ok := condition.(map[string]interface{})
+		if !ok {
+			operators = map[string]interface{}{"$eq": condition}
+		}
+		for operator, operand := range operators {
+			if !bulkCondition(operator, value, present, operand) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func bulkCondition(operator string, value interface{}, present bool, operand interface{}) bool {
+	switch operator {
+	case "$eq":
+		return bulkEqual(value, operand)
+	case "$ne":
+		return !bulkEqual(value, operand)
+	case "$in", "$nin":
+		in := false
+		operands, _ := operand.([]interface{})
+		for _, candidate := range operands {
+			in = in || bulkEqual(value, candidate)
+		}
+		return in == (operator == "$in")
+	case "$exists":
+		exists := present && value != nil
+		return exists == (operand == true)
+	case "$gt", "$gte", "$lt", "$lte":
+		return anyItem(value, func(item interface{}) bool {
+			order, ok := compareBulkValues(item, operand)
+			switch {
+			case !ok:
+				return false
+			case operator == "$gt":
+				return order > 0
+			case operator == "$gte":
+				return order >= 0
+			case operator == "$lt":
+				return order < 0
+			default:
+				return order <= 0
+			}
+		})
+	}
+	return false
+}
+
+// bulkEqual compares decoded JSON values; a list also equals any of its items
+func bulkEqual(value, operand interface{}) bool {
+	if reflect.DeepEqual(value, operand) {
+		return true
+	}
+	if _, ok := value.([]interface{}); ok {
+		return anyItem(value, func(item interface{}) bool { return reflect.DeepEqual(item, operand) })
+	}
+	return false
+}
+
+func anyItem(value interface{}, fn func(interface{}) bool) bool {
+	items, ok := value.([]interface{})
+	if !ok {
+		return fn(value)
+	}
+	for _, item := range items {
+		if fn(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareBulkValues orders two numbers, two strings or two dates
+func compareBulkValues(a, b interface{}) (int, bool) {
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		// Dates are serialized with varying precision, so compare them as times
+		if tx, err := time.Parse(time.RFC3339, x); err == nil {
+			if ty, err := time.Parse(time.RFC3339, y); err == nil {
+				return tx.Compare(ty), true
+			}
+		}
+		return strings.Compare(x, y), true
+	}
+	return 0, false
+}
+
+// applyBulkUpdate changes entry in place. Values added to a list are only
+// appended when missing, and a list operand adds or removes each item.
+func applyBulkUpdate(entry map[string]interface{}, update models.BulkUpdate) {
+	for field, value := range update.Set {
+		entry[field] = toGeneric(value)
+	}
+	for _, field := range update.Unset {
+		delete(entry, field)
+	}
+	for field, value := range update.Add {
+		list, _ := entry[field].([]interface{})
+		for _, item := range bulkOperands(value) {
+			if !anyItem(list, func(existing interface{}) bool { return reflect.DeepEqual(existing, item) }) {
+				list = append(list, item)
+			}
+		}
+		entry[field] = list
+	}
+	for field, value := range update.Remove {
+		list, _ := entry[field].([]interface{})
+		kept := []interface{}{}
+		for _, existing := range list {
+			if !anyItem(bulkOperands(value), func(item interface{}) bool { return reflect.DeepEqual(existing, item) }) {
+				kept = append(kept, existing)
+			}
+		}
+		entry[field] = kept
+	}
+}
+
+func bulkOperands(value interface{}) []interface{} {
+	if items, ok := toGeneric(value).([]interface{}); ok {
+		return items
+	}
+	return []interface{}{toGeneric(value)}
+}
+
+// RecordBulkAudit stores one audit entry per entry a bulk update changed,
+// copying who made it and from where from base
+func RecordBulkAudit(base models.AuditEntry, result *models.BulkResult) {
+	for _, entry := range result.Entries {
+		target := fmt.Sprintf("content/%s/%d", result.Type, entry.Index)
+		if entry.ID != "" {
+			target = fmt.Sprintf("content/%s/%s", result.Type, entry.ID)
+		}
+
+		audit := base
+		audit.Changes = []models.AuditChange{{
+			Target: target,
+			Before: redactSecrets(entry.Before),
+			After:  redactSecrets(entry.After),
+		}}
+		recordBulkAuditEntry(audit)
+	}
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"encoding/json"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBulkRequest(t *testing.T) {
+	var req models.BulkRequest
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "projects",
+		"filter": {"status": {"$regex": "^old"}, "category": {"$in": "web"}, "owner": "me"},
+		"update": {"set": {"id": "x"}, "add": {"name": "legacy"}}
+	}`), &req))
+
+	codes := map[string]string{}
+	for _, err := range ValidateBulkRequest(req) {
+		codes[err.Pointer] = err.Code
+	}
+	assert.Equal(t, map[string]string{
+		"/filter/status/$regex": "UNSUPPORTED_OPERATOR",
+		"/filter/category/$in":  "INVALID_OPERAND",
+		"/filter/owner":         "UNKNOWN_FIELD",
+		"/update/set/id":        "READ_ONLY_FIELD",
+		"/update/add/name":      "NOT_A_LIST",
+	}, codes)
+
+	errs := ValidateBulkRequest(models.BulkRequest{Type: "meta", Update: models.BulkUpdate{Unset: []string{"x"}}})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "/type", errs[0].Pointer)
+
+	errs = ValidateBulkRequest(models.BulkRequest{Type: "projects"})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "EMPTY_UPDATE", errs[0].Code)
+}
+
+func TestBulkMatches(t *testing.T) {
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"name": "api", "status": "completed", "stars": 12,
+		"technologies": ["Go", "MongoDB"], "start_date": "2021-03-01T00:00:00Z"
+	}`), &entry))
+
+	matches := func(filter string) bool {
+		var parsed map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(filter), &parsed))
+		return bulkMatches(entry, parsed)
+	}
+
+	assert.True(t, matches(`{}`))
+	assert.True(t, matches(`{"status": "completed", "technologies": "Go"}`))
+	assert.False(t, matches(`{"status": "planned"}`))
+	assert.True(t, matches(`{"status": {"$in": ["completed", "planned"]}}`))
+	assert.True(t, matches(`{"technologies": {"$nin": ["Rust"]}}`))
+	assert.True(t, matches(`{"stars": {"$gte": 10, "$lt": 20}}`))
+	assert.False(t, matches(`{"stars": {"$gt": 12}}`))
+	assert.True(t, matches(`{"start_date": {"$lt": "2022-01-01T00:00:00.5Z"}}`))
+	assert.True(t, matches(`{"end_date": {"$exists": false}}`))
+	assert.False(t, matches(`{"name": {"$ne": "api"}}`))
+}
+
+func TestApplyBulkUpdate(t *testing.T) {
+	entry := map[string]interface{}{
+		"status":       "completed",
+		"featured":     true,
+		"technologies": []interface{}{"Go", "jQuery"},
+	}
+
+	applyBulkUpdate(entry, models.BulkUpdate{
+		Set:    map[string]interface{}{"status": "archived"},
+		Unset:  []string{"featured"},
+		Add:    map[string]interface{}{"technologies": []interface{}{"Go", "legacy"}, "highlights": "Migrated"},
+		Remove: map[string]interface{}{"technologies": "jQuery"},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"status":       "archived",
+		"technologies": []interface{}{"Go", "legacy"},
+		"highlights":   []interface{}{"Migrated"},
+	}, entry)
+}
+
+func TestBulkPlanToken(t *testing.T) {
+	req := models.BulkRequest{
+		Type:   "projects",
+		Filter: map[string]interface{}{"status": "completed", "featured": false},
+		Update: models.BulkUpdate{Add: map[string]interface{}{"technologies": "legacy"}},
+	}
+	entries := []map[string]interface{}{{"name": "api"}}
+
+	token, err := bulkPlanToken(req, entries)
+	require.NoError(t, err)
+	again, _ := bulkPlanToken(req, entries)
+	assert.Equal(t, token, again)
+
+	// The token sent back does not change the plan
+	req.PlanToken = token
+	withToken, _ := bulkPlanToken(req, entries)
+	assert.Equal(t, token, withToken)
+
+	// Content edited since the preview invalidates it
+	edited, _ := bulkPlanToken(req, []map[string]interface{}{{"name": "api v2"}})
+	assert.NotEqual(t, token, edited)
+}
+
+func TestRecordBulkAudit(t *testing.T) {
+	var recorded []models.AuditEntry
+	recordBulkAuditEntry = func(entry models.AuditEntry) { recorded = append(recorded, entry) }
+	t.Cleanup(func() { recordBulkAuditEntry = RecordAuditEntry })
+
+	RecordBulkAudit(models.AuditEntry{Action: "POST /api/v1/admin/bulk", Actor: "admin"}, &models.BulkResult{
+		Type: "changelog",
+		Entries: []models.BulkEntry{
+			{Index: 0, ID: "64b000000000000000000001", Before: map[string]interface{}{"token": "abc"}},
+			{Index: 3},
+		},
+	})
+
+	require.Len(t, recorded, 2)
+	assert.Equal(t, "admin", recorded[0].Actor)
+	assert.Equal(t, "content/changelog/64b000000000000000000001", recorded[0].Changes[0].Target)
+	assert.Equal(t, map[string]interface{}{"token": "[redacted]"}, recorded[0].Changes[0].Before)
+	assert.Equal(t, "content/changelog/3", recorded[1].Changes[0].Target)
+}
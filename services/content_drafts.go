@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"portfolio-backend/models"
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	// ErrDraftNotFound is returned when a content type has no draft
+	ErrDraftNotFound = errors.New("draft not found")
+	// ErrDraftOutdated is returned when publishing a draft whose content was
+	// published again since the draft was started
+	ErrDraftOutdated = errors.New("content was published since the draft was started")
+)
+
+// SaveDraft stages data as the draft of contentType. The published content,
+// and so the cache, are left untouched until the draft is published.
+func (cs *ContentService) SaveDraft(ctx context.Context, contentType string, data interface{}, updatedBy string) (*models.ContentDraft, error) {
+	now := time.Now()
+
+	// Experience entries get their IDs now, so projects can link to them
+	// in the preview and keep those links once published
+	if contentType == "experience" {
+		experience, err := withExperienceIDs(data)
+		if err != nil {
+			return nil, err
+		}
+		data = experience
+	}
+
+	// A new draft remembers the published version it starts from
+	baseVersion, err := cs.publishedVersion(ctx, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsDryRun(ctx) {
+		if err := cs.planDraft(ctx, contentType, data); err != nil {
+			return nil, err
+		}
+		return &models.ContentDraft{
+			Type:        contentType,
+			Data:        data,
+			State:       models.ContentStateDraft,
+			BaseVersion: baseVersion,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			UpdatedBy:   updatedBy,
+		}, nil
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"data":       data,
+			"state":      models.ContentStateDraft,
+			"updated_at": now,
+			"updated_by": updatedBy,
+		},
+		"$setOnInsert": bson.M{
+			"base_version": baseVersion,
+			"created_at":   now,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var draft models.ContentDraft
+	if err := cs.drafts.FindOneAndUpdate(ctx, bson.M{"type": contentType}, update, opts).Decode(&draft); err != nil {
+		return nil, err
+	}
+	draft.Data = data
+	return &draft, nil
+}
+
+// planDraft records the dry run of SaveDraft: the fields changed against
+// the current draft, or against nothing when there is none yet
+func (cs *ContentService) planDraft(ctx context.Context, contentType string, data interface{}) error {
+	change := models.DryRunChange{
+		Kind:   models.DryRunContent,
+		Target: contentType + " draft",
+	}
+
+	var before interface{}
+	current, err := cs.GetDraft(ctx, contentType)
+	switch {
+	case err == nil:
+		before = current.Data
+	case errors.Is(err, ErrDraftNotFound):
+		change.Create = true
+	default:
+		return err
+	}
+
+	if newModel, ok := contentModels[contentType]; ok {
+		typed := newModel()
+		if err := remarshal(data, typed); err == nil {
+			data = typed
+		}
+	}
+	change.Diff = diffValues(before, data)
+
+	recordDryRun(ctx, change)
+	return nil
+}
+
+// GetDraft returns the draft of contentType
+func (cs *ContentService) GetDraft(ctx context.Context, contentType string) (*models.ContentDraft, error) {
+	var raw bson.Raw
+	err := cs.drafts.FindOne(ctx, bson.M{"type": contentType}).Decode(&raw)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrDraftNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeDraft(raw)
+}
+
+// GetDrafts lists the drafts waiting to be published
+func (cs *ContentService) GetDrafts(ctx context.Context) ([]models.ContentDraft, error) {
+	cursor, err := cs.drafts.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "type", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	drafts := []models.ContentDraft{}
+	for cursor.Next(ctx) {
+		draft, err := decodeDraft(cursor.Current)
+		if err != nil {
+			return nil, err
+		}
+		drafts = append(drafts, *draft)
+	}
+	return drafts, cursor.Err()
+}
+
+// decodeDraft decodes a stored draft, with its data in the model registered
+// for its type
+func decodeDraft(raw bson.Raw) (*models.ContentDraft, error) {
+	var draft models.ContentDraft
+	if err := bson.Unmarshal(raw, &draft); err != nil {
+		return nil, err
+	}
+
+	if newModel, ok := contentModels[draft.Type]; ok {
+		data := newModel()
+		if err := raw.Lookup("data").Unmarshal(data); err != nil {
+			return nil, err
+		}
+		draft.Data = reflect.ValueOf(data).Elem().Interface()
+	}
+	return &draft, nil
+}
+
+// DiscardDraft deletes the draft of contentType
+func (cs *ContentService) DiscardDraft(ctx context.Context, contentType string) error {
+	result, err := cs.drafts.DeleteOne(ctx, bson.M{"type": contentType})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrDraftNotFound
+	}
+	return nil
+}
+
+// PublishDraft makes draft the live content of its type, as UpdateContent
+// does, and deletes it. A draft whose content was published again since it
+// was started is only published with force, as it would undo that change.
+func (cs *ContentService) PublishDraft(ctx context.Context, draft *models.ContentDraft, publishedBy string, force bool) error {
+	version, err := cs.publishedVersion(ctx, draft.Type)
+	if err != nil {
+		return err
+	}
+	if !force && version != draft.BaseVersion {
+		return ErrDraftOutdated
+	}
+
+	if err := cs.UpdateContent(ctx, draft.Type, draft.Data, publishedBy); err != nil {
+		return err
+	}
+
+	if IsDryRun(ctx) {
+		AddDryRunEffects(ctx, nil, []string{"delete " + draft.Type + " draft"})
+		return nil
+	}
+
+	// Edits saved to the draft while it was being published are kept
+	_, err = cs.drafts.DeleteOne(ctx, bson.M{"_id": draft.ID, "updated_at": draft.UpdatedAt})
+	return err
+}
+
+// PreviewPortfolio builds the portfolio as it will look once every draft is
+// published. It is never cached.
+func (cs *ContentService) PreviewPortfolio(ctx context.Context, expandExperience bool) (*models.Portfolio, error) {
+	portfolio := &models.Portfolio{UpdatedAt: time.Now()}
+
+	meta, err := cs.GetMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := cs.GetSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	portfolio.Meta, portfolio.Skills = *meta, *skills
+	if portfolio.Experience, err = cs.GetExperience(ctx); err != nil {
+		return nil, err
+	}
+	if portfolio.Projects, err = cs.GetProjects(ctx); err != nil {
+		return nil, err
+	}
+	if portfolio.Education, err = cs.GetEducation(ctx); err != nil {
+		return nil, err
+	}
+
+	drafts, err := cs.GetDrafts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, draft := range drafts {
+		switch data := draft.Data.(type) {
+		case models.Meta:
+			portfolio.Meta = data
+		case models.Skills:
+			portfolio.Skills = data
+		case []models.Experience:
+			portfolio.Experience = data
+		case []models.Project:
+			portfolio.Projects = data
+		case []models.Education:
+			portfolio.Education = data
+		}
+	}
+
+	if expandExperience {
+		linkExperience(portfolio.Projects, portfolio.Experience)
+	}
+	return portfolio, nil
+}
+
+// publishedVersion returns the version of the published contentType, 0 when
+// it was never published
+func (cs *ContentService) publishedVersion(ctx context.Context, contentType string) (int, error) {
+	var content models.Content
+	opts := options.FindOne().SetProjection(bson.M{"version": 1})
+	err := cs.collection.FindOne(ctx, bson.M{"type": contentType}, opts).Decode(&content)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	return content.Version, err
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestDecodeDraftUsesContentModel(t *testing.T) {
+	raw, err := bson.Marshal(models.ContentDraft{
+		Type:        "projects",
+		Data:        sampleProjects()[:2],
+		State:       models.ContentStateDraft,
+		BaseVersion: 3,
+		UpdatedAt:   time.Now(),
+		UpdatedBy:   "admin",
+	})
+	require.NoError(t, err)
+
+	draft, err := decodeDraft(raw)
+	require.NoError(t, err)
+	assert.Equal(t, 3, draft.BaseVersion)
+	projects, ok := draft.Data.([]models.Project)
+	require.True(t, ok)
+	assert.Equal(t, "project-1", projects[1].Name)
+
+	raw, err = bson.Marshal(models.ContentDraft{Type: "meta", Data: models.Meta{Name: "Felipe"}})
+	require.NoError(t, err)
+	draft, err = decodeDraft(raw)
+	require.NoError(t, err)
+	assert.Equal(t, models.Meta{Name: "Felipe"}, draft.Data)
+}
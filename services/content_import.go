@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ContentTypes lists the content types in the order they are imported, so
+// experience is in place before the projects linking to it
+var ContentTypes = []string{"meta", "skills", "experience", "projects", "education", "availability", "changelog"}
+
+// ExportContent returns the published data of contentTypes, or of every
+// content type when none are given. Types never published are left out.
+func (cs *ContentService) ExportContent(ctx context.Context, contentTypes []string) (*models.ContentBundle, error) {
+	if len(contentTypes) == 0 {
+		contentTypes = ContentTypes
+	}
+
+	bundle := &models.ContentBundle{
+		ExportedAt: time.Now(),
+		Content:    make(map[string]interface{}),
+	}
+	for _, contentType := range contentTypes {
+		data := contentModels[contentType]()
+		err := cs.findContentData(ctx, contentType, data)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		bundle.Content[contentType] = reflect.ValueOf(data).Elem().Interface()
+	}
+	return bundle, nil
+}
+
+// ValidateImport validates the content of an import as PUT /content does,
+// with links checked against the experience and projects being imported
+// along with them. Errors point into the bundle, e.g. at
+// /content/projects/0/name.
+func (cs *ContentService) ValidateImport(ctx context.Context, content map[string]interface{}) ([]utils.ValidationError, error) {
+	contentTypes := make([]string, 0, len(content))
+	for contentType := range content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+
+	var errs []utils.ValidationError
+	for _, contentType := range contentTypes {
+		data := content[contentType]
+		if _, ok := contentModels[contentType]; !ok {
+			validator := utils.NewValidator().At("/content")
+			validator.AddError(contentType, "Unknown content type", "UNKNOWN_CONTENT_TYPE")
+			errs = append(errs, validator.GetErrors()...)
+			continue
+		}
+		validator := utils.NewValidator().ValidateContent(contentType, data)
+		errs = append(errs, importErrors(contentType, validator.GetErrors())...)
+	}
+	if len(errs) > 0 {
+		return errs, nil
+	}
+
+	_, hasExperience := content["experience"]
+	_, hasProjects := content["projects"]
+	var experience []models.Experience
+	var projects []models.Project
+	var err error
+	if hasExperience {
+		err = remarshal(content["experience"], &experience)
+	} else if hasProjects {
+		experience, err = cs.GetExperience(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if hasProjects {
+		err = remarshal(content["projects"], &projects)
+	} else if hasExperience {
+		projects, err = cs.GetProjects(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, contentType := range []string{"experience", "projects"} {
+		if data, ok := content[contentType]; ok {
+			referenceErrors, err := checkReferences(contentType, data, experience, projects)
+			if err != nil {
+				return nil, err
+			}
+			errs = append(errs, importErrors(contentType, referenceErrors)...)
+		}
+	}
+	return errs, nil
+}
+
+// importErrors points errors reported for the data of a content update at
+// that data in an import bundle
+func importErrors(contentType string, errs []utils.ValidationError) []utils.ValidationError {
+	for i := range errs {
+		errs[i].Pointer = utils.JSONPointer("content", contentType) + strings.TrimPrefix(errs[i].Pointer, "/data")
+	}
+	return errs
+}
+
+// ImportContent publishes the validated content of an import, one content
+// type at a time as UpdateContent does. Types whose data is already
+// published as imported keep their version.
+func (cs *ContentService) ImportContent(ctx context.Context, content map[string]interface{}, importedBy string) (*models.ContentImportResult, error) {
+	result := &models.ContentImportResult{Imported: []string{}, Unchanged: []string{}}
+
+	for _, contentType := range ContentTypes {
+		data, ok := content[contentType]
+		if !ok {
+			continue
+		}
+
+		unchanged, err := cs.unchanged(ctx, contentType, data)
+		if err != nil {
+			return nil, err
+		}
+		if unchanged {
+			result.Unchanged = append(result.Unchanged, contentType)
+			continue
+		}
+
+		if err := cs.UpdateContent(ctx, contentType, data, importedBy); err != nil {
+			return nil, fmt.Errorf("%s: %w", contentType, err)
+		}
+		result.Imported = append(result.Imported, contentType)
+	}
+	return result, nil
+}
+
+// unchanged reports whether data is what is published for contentType,
+// compared in the shape of its model
+func (cs *ContentService) unchanged(ctx context.Context, contentType string, data interface{}) (bool, error) {
+	published := contentModels[contentType]()
+	err := cs.findContentData(ctx, contentType, published)
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	imported := contentModels[contentType]()
+	if err := remarshal(data, imported); err != nil {
+		return false, err
+	}
+	return len(diffValues(published, imported)) == 0, nil
+}
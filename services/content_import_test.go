@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateImportPointsIntoBundle(t *testing.T) {
+	cs := &ContentService{}
+	content := map[string]interface{}{
+		"projects": []interface{}{map[string]interface{}{"name": ""}},
+		"skills":   "not skills",
+		"posts":    []interface{}{},
+	}
+
+	errs, err := cs.ValidateImport(context.Background(), content)
+	require.NoError(t, err)
+
+	pointers := make(map[string]string)
+	for _, e := range errs {
+		pointers[e.Pointer] = e.Code
+	}
+	assert.Equal(t, "UNKNOWN_CONTENT_TYPE", pointers["/content/posts"])
+	assert.Equal(t, "INVALID_DATA", pointers["/content/skills"])
+	assert.Contains(t, pointers, "/content/projects/0/name")
+}
+
+func TestValidateImportChecksReferencesWithinBundle(t *testing.T) {
+	cs := &ContentService{}
+	experienceID := "64b7f0c2a1b2c3d4e5f60718"
+	experience := []interface{}{map[string]interface{}{"id": experienceID, "company": "Acme", "position": "Engineer"}}
+
+	// Both sides come from the bundle, so the stored content is not read
+	errs, err := cs.ValidateImport(context.Background(), map[string]interface{}{
+		"experience": experience,
+		"projects":   []interface{}{map[string]interface{}{"name": "API", "experience_id": experienceID}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, errs)
+
+	errs, err = cs.ValidateImport(context.Background(), map[string]interface{}{
+		"experience": []interface{}{},
+		"projects":   []interface{}{map[string]interface{}{"name": "API", "experience_id": experienceID}},
+	})
+	require.NoError(t, err)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "/content/experience", errs[0].Pointer)
+	assert.Equal(t, "REFERENCED_ENTRY", errs[0].Code)
+	assert.Equal(t, "/content/projects/0/experience_id", errs[1].Pointer)
+	assert.Equal(t, "UNKNOWN_REFERENCE", errs[1].Code)
+}
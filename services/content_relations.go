@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Expansions accepted by ?expand= on content read endpoints
+const ExpandExperience = "experience"
+
+// withExperienceIDs decodes experience data into the typed model, giving
+// every entry without one an ID that projects can link to
+func withExperienceIDs(data interface{}) ([]models.Experience, error) {
+	var experience []models.Experience
+	if err := remarshal(data, &experience); err != nil {
+		return nil, err
+	}
+
+	for i := range experience {
+		if experience[i].ID.IsZero() {
+			experience[i].ID = primitive.NewObjectID()
+		}
+	}
+	return experience, nil
+}
+
+// CheckReferences reports links in content about to be saved that would
+// point at missing entries: projects linking to unknown experience, or
+// experience entries removed while projects still link to them. Errors are
+// keyed by JSON pointers into the request body.
+func (cs *ContentService) CheckReferences(ctx context.Context, contentType string, data interface{}) ([]utils.ValidationError, error) {
+	var experience []models.Experience
+	var projects []models.Project
+	var err error
+	switch contentType {
+	case "projects":
+		experience, err = cs.GetExperience(ctx)
+	case "experience":
+		projects, err = cs.GetProjects(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return checkReferences(contentType, data, experience, projects)
+}
+
+// checkReferences checks the links of data against the given experience
+// and projects, as CheckReferences does
+func checkReferences(contentType string, data interface{}, experience []models.Experience, projects []models.Project) ([]utils.ValidationError, error) {
+	validator := utils.NewValidator()
+
+	switch contentType {
+	case "projects":
+		var saved []models.Project
+		if err := remarshal(data, &saved); err != nil {
+			return nil, err
+		}
+
+		known := experienceIDs(experience)
+		for i, project := range saved {
+			if project.ExperienceID != "" && !known[project.ExperienceID] {
+				validator.At(utils.JSONPointer("data", i)).AddError("experience_id",
+					fmt.Sprintf("Experience %s does not exist", project.ExperienceID), "UNKNOWN_REFERENCE")
+			}
+		}
+	case "experience":
+		var saved []models.Experience
+		if err := remarshal(data, &saved); err != nil {
+			return nil, err
+		}
+
+		kept := experienceIDs(saved)
+		for _, project := range projects {
+			if project.ExperienceID != "" && !kept[project.ExperienceID] {
+				validator.At("").AddError("data",
+					fmt.Sprintf("Experience %s is linked from project %s", project.ExperienceID, project.Name), "REFERENCED_ENTRY")
+			}
+		}
+	}
+
+	return validator.GetErrors(), nil
+}
+
+// ExpandProjects fills in the experience entry each project links to
+func (cs *ContentService) ExpandProjects(ctx context.Context, projects []models.Project) error {
+	experience, err := cs.GetExperience(ctx)
+	if err != nil {
+		return err
+	}
+	linkExperience(projects, experience)
+	return nil
+}
+
+// linkExperience points projects at the entries of experience they link to
+func linkExperience(projects []models.Project, experience []models.Experience) {
+	byID := make(map[string]*models.Experience, len(experience))
+	for i := range experience {
+		byID[experience[i].ID.Hex()] = &experience[i]
+	}
+	for i := range projects {
+		if linked, ok := byID[projects[i].ExperienceID]; ok {
+			projects[i].Experience = linked
+		}
+	}
+}
+
+func experienceIDs(experience []models.Experience) map[string]bool {
+	ids := make(map[string]bool, len(experience))
+	for _, entry := range experience {
+		if !entry.ID.IsZero() {
+			ids[entry.ID.Hex()] = true
+		}
+	}
+	return ids
+}
+
+// remarshal decodes request data into a typed model through JSON
+func remarshal(data interface{}, target interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, target)
+}
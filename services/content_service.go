@@ -2,7 +2,7 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"portfolio-backend/config"
 	"portfolio-backend/database"
 	"portfolio-backend/models"
 	"time"
@@ -15,13 +15,23 @@ import (
 
 type ContentService struct {
 	collection   *mongo.Collection
+	drafts       *mongo.Collection
+	versions     *mongo.Collection
 	cacheService *CacheService
+	deployHooks  *DeployHookService
+	cdnPurge     *CDNPurgeService
+	repoNotify   *RepoNotificationService
 }
 
 func NewContentService() *ContentService {
 	return &ContentService{
 		collection:   database.Database.Collection("content"),
+		drafts:       database.Database.Collection("content_drafts"),
+		versions:     database.Database.Collection(contentVersionsCollection),
 		cacheService: NewCacheService(),
+		deployHooks:  NewDeployHookService(),
+		cdnPurge:     NewCDNPurgeService(),
+		repoNotify:   NewRepoNotificationService(),
 	}
 }
 
@@ -78,10 +88,8 @@ func (cs *ContentService) GetMeta(ctx context.Context) (*models.Meta, error) {
 		return &meta, nil
 	}
 
-	// Get from database
-	var content models.Content
-	filter := bson.M{"type": "meta"}
-	err := cs.collection.FindOne(ctx, filter).Decode(&content)
+	// Get from database, decoding straight into the typed model
+	err := cs.findContentData(ctx, "meta", &meta)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			// Return default meta if not found
@@ -95,11 +103,6 @@ func (cs *ContentService) GetMeta(ctx context.Context) (*models.Meta, error) {
 		return nil, err
 	}
 
-	// Convert interface{} to Meta struct
-	if err := convertToStruct(content.Data, &meta); err != nil {
-		return nil, err
-	}
-
 	// Cache the result
 	cs.cacheService.SetContentData(ctx, "meta", meta)
 
@@ -115,10 +118,8 @@ func (cs *ContentService) GetSkills(ctx context.Context) (*models.Skills, error)
 		return &skills, nil
 	}
 
-	// Get from database
-	var content models.Content
-	filter := bson.M{"type": "skills"}
-	err := cs.collection.FindOne(ctx, filter).Decode(&content)
+	// Get from database, decoding straight into the typed model
+	err := cs.findContentData(ctx, "skills", &skills)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return &models.Skills{}, nil
@@ -126,11 +127,6 @@ func (cs *ContentService) GetSkills(ctx context.Context) (*models.Skills, error)
 		return nil, err
 	}
 
-	// Convert interface{} to Skills struct
-	if err := convertToStruct(content.Data, &skills); err != nil {
-		return nil, err
-	}
-
 	// Cache the result
 	cs.cacheService.SetContentData(ctx, "skills", skills)
 
@@ -146,10 +142,8 @@ func (cs *ContentService) GetExperience(ctx context.Context) ([]models.Experienc
 		return experience, nil
 	}
 
-	// Get from database
-	var content models.Content
-	filter := bson.M{"type": "experience"}
-	err := cs.collection.FindOne(ctx, filter).Decode(&content)
+	// Get from database, decoding straight into the typed model
+	err := cs.findContentData(ctx, "experience", &experience)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return []models.Experience{}, nil
@@ -157,11 +151,6 @@ func (cs *ContentService) GetExperience(ctx context.Context) ([]models.Experienc
 		return nil, err
 	}
 
-	// Convert interface{} to Experience slice
-	if err := convertToStruct(content.Data, &experience); err != nil {
-		return nil, err
-	}
-
 	// Cache the result
 	cs.cacheService.SetContentData(ctx, "experience", experience)
 
@@ -177,10 +166,8 @@ func (cs *ContentService) GetProjects(ctx context.Context) ([]models.Project, er
 		return projects, nil
 	}
 
-	// Get from database
-	var content models.Content
-	filter := bson.M{"type": "projects"}
-	err := cs.collection.FindOne(ctx, filter).Decode(&content)
+	// Get from database, decoding straight into the typed model
+	err := cs.findContentData(ctx, "projects", &projects)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return []models.Project{}, nil
@@ -188,11 +175,6 @@ func (cs *ContentService) GetProjects(ctx context.Context) ([]models.Project, er
 		return nil, err
 	}
 
-	// Convert interface{} to Project slice
-	if err := convertToStruct(content.Data, &projects); err != nil {
-		return nil, err
-	}
-
 	// Cache the result
 	cs.cacheService.SetContentData(ctx, "projects", projects)
 
@@ -208,10 +190,8 @@ func (cs *ContentService) GetEducation(ctx context.Context) ([]models.Education,
 		return education, nil
 	}
 
-	// Get from database
-	var content models.Content
-	filter := bson.M{"type": "education"}
-	err := cs.collection.FindOne(ctx, filter).Decode(&content)
+	// Get from database, decoding straight into the typed model
+	err := cs.findContentData(ctx, "education", &education)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return []models.Education{}, nil
@@ -219,20 +199,79 @@ func (cs *ContentService) GetEducation(ctx context.Context) ([]models.Education,
 		return nil, err
 	}
 
-	// Convert interface{} to Education slice
-	if err := convertToStruct(content.Data, &education); err != nil {
+	// Cache the result
+	cs.cacheService.SetContentData(ctx, "education", education)
+
+	return education, nil
+}
+
+// GetAvailability retrieves the owner's availability for new work
+func (cs *ContentService) GetAvailability(ctx context.Context) (*models.Availability, error) {
+	var availability models.Availability
+	
+	// Try cache first
+	if err := cs.cacheService.GetContentData(ctx, "availability", &availability); err == nil {
+		return &availability, nil
+	}
+
+	// Get from database, decoding straight into the typed model
+	err := cs.findContentData(ctx, "availability", &availability)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return &models.Availability{Status: "closed", PreferredRoles: []string{}}, nil
+		}
 		return nil, err
 	}
 
 	// Cache the result
-	cs.cacheService.SetContentData(ctx, "education", education)
+	cs.cacheService.SetContentData(ctx, "availability", availability)
 
-	return education, nil
+	return &availability, nil
+}
+
+// GetChangelog retrieves the published changelog, newest entries first
+func (cs *ContentService) GetChangelog(ctx context.Context) ([]models.ChangelogEntry, error) {
+	var changelog []models.ChangelogEntry
+
+	// Try cache first
+	if err := cs.cacheService.GetContentData(ctx, "changelog", &changelog); err == nil {
+		return changelog, nil
+	}
+
+	// Get from database, decoding straight into the typed model
+	err := cs.findContentData(ctx, "changelog", &changelog)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return []models.ChangelogEntry{}, nil
+		}
+		return nil, err
+	}
+
+	// Cache the result
+	cs.cacheService.SetContentData(ctx, "changelog", changelog)
+
+	return changelog, nil
 }
 
 // UpdateContent updates content by type
 func (cs *ContentService) UpdateContent(ctx context.Context, contentType string, data interface{}, updatedBy string) error {
 	now := time.Now()
+
+	// Experience is stored typed, so its entries keep the IDs projects link to
+	if contentType == "experience" {
+		experience, err := withExperienceIDs(data)
+		if err != nil {
+			return err
+		}
+		data = experience
+	}
+
+	// Projects as they were, to notify the repositories of changed pages
+	var previousProjects []models.Project
+	var previousErr error
+	if contentType == "projects" {
+		previousProjects, previousErr = cs.GetProjects(ctx)
+	}
 	
 	// Get existing content to increment version
 	var existingContent models.Content
@@ -244,6 +283,10 @@ func (cs *ContentService) UpdateContent(ctx context.Context, contentType string,
 		version = existingContent.Version + 1
 	}
 
+	if IsDryRun(ctx) {
+		return cs.planUpdate(ctx, contentType, data, version, err == mongo.ErrNoDocuments, previousProjects)
+	}
+
 	// Create new content document
 	content := models.Content{
 		Type:      contentType,
@@ -253,11 +296,18 @@ func (cs *ContentService) UpdateContent(ctx context.Context, contentType string,
 		UpdatedBy: updatedBy,
 	}
 
+	var before interface{}
 	if err == mongo.ErrNoDocuments {
 		content.CreatedAt = now
 		content.ID = primitive.NewObjectID()
 		_, err = cs.collection.InsertOne(ctx, content)
 	} else {
+		before = existingContent.Data
+
+		// Keep the version being replaced, in case it predates the archive
+		existingContent.ID = primitive.NilObjectID
+		archiveContentVersion(ctx, cs.versions, contentType, existingContent.Version, existingContent)
+
 		content.CreatedAt = existingContent.CreatedAt
 		update := bson.M{"$set": content}
 		_, err = cs.collection.UpdateOne(ctx, filter, update)
@@ -267,9 +317,86 @@ func (cs *ContentService) UpdateContent(ctx context.Context, contentType string,
 		return err
 	}
 
+	archived := content
+	archived.ID = primitive.NilObjectID
+	archiveContentVersion(ctx, cs.versions, contentType, version, archived)
+	recordAudit(ctx, "content/"+contentType, before, data)
+
 	// Invalidate cache
 	cs.cacheService.InvalidateContentCache(ctx)
 
+	// Rebuild static frontends once edits settle
+	cs.deployHooks.ScheduleTrigger(contentType)
+	cs.cdnPurge.SchedulePurge(contentType)
+	publishContentUpdated(contentType, version)
+
+	if contentType == "projects" && previousErr == nil {
+		cs.repoNotify.NotifyChanges(previousProjects, data)
+	}
+
+	return nil
+}
+
+// planUpdate records the dry run of UpdateContent: the changed fields, the
+// version bump and what the save would invalidate and trigger
+func (cs *ContentService) planUpdate(ctx context.Context, contentType string, data interface{}, version int, create bool, previousProjects []models.Project) error {
+	change := models.DryRunChange{
+		Kind:        models.DryRunContent,
+		Target:      contentType,
+		Create:      create,
+		VersionFrom: int64(version - 1),
+		VersionTo:   int64(version),
+	}
+	change.Invalidations, change.SideEffects = contentChangeEffects(ctx, contentType)
+
+	var before interface{}
+	if newModel, ok := contentModels[contentType]; ok && !create {
+		previous := newModel()
+		if err := cs.findContentData(ctx, contentType, previous); err != nil {
+			return err
+		}
+		before = previous
+
+		// Compare the new data in the same shape as the stored one
+		typed := newModel()
+		if err := remarshal(data, typed); err == nil {
+			data = typed
+		}
+	}
+	change.Diff = diffValues(before, data)
+
+	if contentType == "projects" && config.AppConfig.GitHubToken != "" {
+		var after []models.Project
+		if remarshal(data, &after) == nil {
+			for _, project := range changedShowcases(previousProjects, after) {
+				change.SideEffects = append(change.SideEffects, "repository notification: "+project.GitHubURL)
+			}
+		}
+	}
+
+	recordDryRun(ctx, change)
+	return nil
+}
+
+// WarmCache loads every content type and the full portfolio into the cache,
+// so the first visitors after a start are not served from MongoDB queries.
+// Content types never saved are skipped.
+func (cs *ContentService) WarmCache(ctx context.Context) error {
+	loaders := []func() error{
+		func() error { _, err := cs.GetMeta(ctx); return err },
+		func() error { _, err := cs.GetSkills(ctx); return err },
+		func() error { _, err := cs.GetExperience(ctx); return err },
+		func() error { _, err := cs.GetProjects(ctx); return err },
+		func() error { _, err := cs.GetEducation(ctx); return err },
+		func() error { _, err := cs.GetAvailability(ctx); return err },
+		func() error { _, err := cs.GetPortfolio(ctx); return err },
+	}
+
+	for _, load := range loaders {
+		if err := load(); err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -359,13 +486,20 @@ func (cs *ContentService) InitializeDefaultContent(ctx context.Context) error {
 	return err
 }
 
-// convertToStruct converts interface{} to target struct using BSON
-func convertToStruct(source interface{}, target interface{}) error {
-	bytes, err := bson.Marshal(source)
-	if err != nil {
+// contentData holds only the data field of a content document, left as raw
+// BSON until it is decoded into the model registered for its type
+type contentData struct {
+	Data bson.RawValue `bson:"data"`
+}
+
+// findContentData decodes the data of the given content type into target
+func (cs *ContentService) findContentData(ctx context.Context, contentType string, target interface{}) error {
+	var content contentData
+	opts := options.FindOne().SetProjection(bson.M{"data": 1})
+	if err := cs.collection.FindOne(ctx, bson.M{"type": contentType}, opts).Decode(&content); err != nil {
 		return err
 	}
-	return bson.Unmarshal(bytes, target)
+	return content.Data.Unmarshal(target)
 }
 
 // SearchContent performs text search on content
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"portfolio-backend/models"
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// contentVersionsCollection keeps every published version of each content
+// type; the content collection only holds the latest one
+const contentVersionsCollection = "content_versions"
+
+// ErrContentVersionNotFound is returned when a content version was never
+// published or predates the version archive
+var ErrContentVersionNotFound = errors.New("content version not found")
+
+// contentVersion is an archived content document, with its data left as raw
+// BSON until it is decoded into the model registered for its type
+type contentVersion struct {
+	Type      string        `bson:"type"`
+	Data      bson.RawValue `bson:"data"`
+	Version   int           `bson:"version"`
+	UpdatedAt time.Time     `bson:"updated_at"`
+	UpdatedBy string        `bson:"updated_by"`
+}
+
+// archiveContentVersion copies a published content document, without its
+// _id, to the version archive. A version already archived is kept as it was.
+func archiveContentVersion(ctx context.Context, versions *mongo.Collection, contentType string, version int, doc interface{}) {
+	filter := bson.M{"type": contentType, "version": version}
+	update := bson.M{"$setOnInsert": doc}
+	if _, err := versions.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		log.Printf("Failed to archive %s content version %d: %v", contentType, version, err)
+	}
+}
+
+// getContentVersion returns a published version of contentType, from the
+// archive or, for the latest version, from the content itself
+func (cs *ContentService) getContentVersion(ctx context.Context, contentType string, version int) (*contentVersion, error) {
+	filter := bson.M{"type": contentType, "version": version}
+
+	var content contentVersion
+	err := cs.versions.FindOne(ctx, filter).Decode(&content)
+	if err == mongo.ErrNoDocuments {
+		// The latest version may have been published before the archive
+		err = cs.collection.FindOne(ctx, filter).Decode(&content)
+	}
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("%w: %s version %d", ErrContentVersionNotFound, contentType, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// DiffContentVersions lists the fields of the data of contentType that
+// changed from version from to version to
+func (cs *ContentService) DiffContentVersions(ctx context.Context, contentType string, from, to int) (*models.ContentDiff, error) {
+	before, err := cs.getContentVersion(ctx, contentType, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := cs.getContentVersion(ctx, contentType, to)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeData, err := before.decodeData()
+	if err != nil {
+		return nil, err
+	}
+	afterData, err := after.decodeData()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ContentDiff{
+		Type:    contentType,
+		From:    before.ref(),
+		To:      after.ref(),
+		Changes: diffValues(beforeData, afterData),
+	}, nil
+}
+
+// decodeData decodes the data into the model registered for its type, so
+// both sides of a diff share its shape whatever the BSON types they were
+// stored with
+func (cv *contentVersion) decodeData() (interface{}, error) {
+	newModel, ok := contentModels[cv.Type]
+	if !ok {
+		generic, _ := extJSONGeneric(bson.M{"data": cv.Data}).(map[string]interface{})
+		return generic["data"], nil
+	}
+
+	data := newModel()
+	if err := cv.Data.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return reflect.ValueOf(data).Elem().Interface(), nil
+}
+
+func (cv *contentVersion) ref() models.ContentVersionRef {
+	return models.ContentVersionRef{
+		Version:   cv.Version,
+		UpdatedAt: cv.UpdatedAt,
+		UpdatedBy: cv.UpdatedBy,
+	}
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// storedVersion round-trips doc through BSON, as it would be read back
+func storedVersion(t *testing.T, doc bson.M) *contentVersion {
+	raw, err := bson.Marshal(doc)
+	require.NoError(t, err)
+
+	var version contentVersion
+	require.NoError(t, bson.Unmarshal(raw, &version))
+	return &version
+}
+
+func TestContentVersionDecodeData(t *testing.T) {
+	// Raw document edits may store numbers with another BSON type
+	before := storedVersion(t, bson.M{"type": "skills", "version": 1, "data": bson.M{
+		"backend": bson.A{bson.M{"name": "Go", "level": int32(80)}},
+	}})
+	after := storedVersion(t, bson.M{"type": "skills", "version": 2, "data": bson.M{
+		"backend": bson.A{bson.M{"name": "Go", "level": int64(90)}},
+	}})
+
+	beforeData, err := before.decodeData()
+	require.NoError(t, err)
+	afterData, err := after.decodeData()
+	require.NoError(t, err)
+
+	_, typed := beforeData.(models.Skills)
+	assert.True(t, typed)
+	assert.Equal(t, []models.FieldDiff{{Path: "/backend/0/level", Before: float64(80), After: float64(90)}}, diffValues(beforeData, afterData))
+}
+
+func TestContentVersionDecodeDataUnknownType(t *testing.T) {
+	version := storedVersion(t, bson.M{"type": "legacy", "version": 3, "data": bson.M{"title": "Hello"}})
+
+	data, err := version.decodeData()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"title": "Hello"}, data)
+}
+
+func TestContentVersionRef(t *testing.T) {
+	version := storedVersion(t, bson.M{"type": "meta", "version": 4, "updated_by": "admin"})
+	assert.Equal(t, models.ContentVersionRef{Version: 4, UpdatedAt: version.UpdatedAt, UpdatedBy: "admin"}, version.ref())
+}
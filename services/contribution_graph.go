@@ -0,0 +1,223 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"portfolio-backend/metrics"
+	"portfolio-backend/models"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats the contribution graph is rendered in
+const (
+	ContributionGraphSVG = "svg"
+	ContributionGraphPNG = "png"
+)
+
+// DefaultContributionGraphTheme is used when a request sets none
+const DefaultContributionGraphTheme = "light"
+
+// contributionTheme colors the graph: the background, the labels and the
+// cells of each contribution level, 0 to 4
+type contributionTheme struct {
+	background string
+	text       string
+	levels     [5]string
+}
+
+// contributionThemes are the color schemes of GitHub's own calendar
+var contributionThemes = map[string]contributionTheme{
+	"light":     {background: "#ffffff", text: "#57606a", levels: [5]string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}},
+	"dark":      {background: "#0d1117", text: "#8b949e", levels: [5]string{"#161b22", "#0e4429", "#006d32", "#26a641", "#39d353"}},
+	"halloween": {background: "#ffffff", text: "#57606a", levels: [5]string{"#ebedf0", "#ffee4a", "#ffc501", "#fe9600", "#03001c"}},
+	"winter":    {background: "#ffffff", text: "#57606a", levels: [5]string{"#ebedf0", "#b6e3ff", "#54aeff", "#0969da", "#0a3069"}},
+}
+
+// ContributionGraphThemes lists the themes of the contribution graph, sorted
+func ContributionGraphThemes() []string {
+	themes := make([]string, 0, len(contributionThemes))
+	for theme := range contributionThemes {
+		themes = append(themes, theme)
+	}
+	sort.Strings(themes)
+	return themes
+}
+
+// ContributionGraphThemeSupported reports whether theme is one of
+// ContributionGraphThemes
+func ContributionGraphThemeSupported(theme string) bool {
+	_, ok := contributionThemes[theme]
+	return ok
+}
+
+// Layout of the graph, in pixels: a column of cells per week with the
+// weekday labels on its left, the month labels above and the legend below
+const (
+	graphCell   = 10
+	graphStep   = 13 // cell and gap
+	graphLeft   = 30
+	graphTop    = 20
+	graphBottom = 28
+	graphRight  = 10
+)
+
+// RenderContributionGraph renders the contribution calendar of username as
+// a GitHub-style heatmap, of the last year or of year when it is not 0, in
+// theme, as SVG or PNG. PNG images have no labels.
+func (gs *GitHubService) RenderContributionGraph(ctx context.Context, username string, year int, theme, format string) ([]byte, error) {
+	var contributions *models.GitHubContributions
+	var err error
+	if year != 0 {
+		contributions, err = gs.GetContributionsForYear(ctx, username, year)
+	} else {
+		contributions, err = gs.GetContributions(ctx, username)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	defer func() { metrics.ObserveLayer(ctx, metrics.LayerRender, time.Since(start)) }()
+	if format == ContributionGraphPNG {
+		return renderContributionPNG(contributions.ContributionCalendar, contributionThemes[theme])
+	}
+	caption := "in the last year"
+	if year != 0 {
+		caption = "in " + strconv.Itoa(year)
+	}
+	return renderContributionSVG(contributions.ContributionCalendar, contributionThemes[theme], caption), nil
+}
+
+// graphCellAt places a day of the week-th column on the row of its weekday,
+// Sunday first; days whose date does not parse keep their position
+func graphCellAt(week, index int, day models.ContributionDay) (x, y int) {
+	row := index
+	if date, err := time.Parse(contributionDateLayout, day.Date); err == nil {
+		row = int(date.Weekday())
+	}
+	return graphLeft + week*graphStep, graphTop + row*graphStep
+}
+
+// graphSize is the size of the graph of weeks
+func graphSize(weeks []models.ContributionWeek) (width, height int) {
+	return graphLeft + len(weeks)*graphStep + graphRight, graphTop + 7*graphStep + graphBottom
+}
+
+// graphLevel clamps a contribution level to the theme's range
+func graphLevel(level int) int {
+	return min(max(level, 0), 4)
+}
+
+// graphMonths labels each week in which a month starts, skipping labels
+// too close to the previous one to fit
+func graphMonths(weeks []models.ContributionWeek) map[int]string {
+	months := make(map[int]string)
+	last, lastWeek := "", -3
+	for i, week := range weeks {
+		if len(week.Days) == 0 {
+			continue
+		}
+		date, err := time.Parse(contributionDateLayout, week.Days[0].Date)
+		if err != nil {
+			continue
+		}
+		if month := date.Format("Jan"); month != last {
+			if i-lastWeek >= 3 {
+				months[i] = month
+				lastWeek = i
+			}
+			last = month
+		}
+	}
+	return months
+}
+
+func renderContributionSVG(weeks []models.ContributionWeek, theme contributionTheme, caption string) []byte {
+	width, height := graphSize(weeks)
+
+	total := 0
+	for _, week := range weeks {
+		for _, day := range week.Days {
+			total += day.Count
+		}
+	}
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="%d contributions %s">`,
+		width, height, width, height, total, caption)
+	fmt.Fprintf(&svg, `<rect width="100%%" height="100%%" fill="%s"/>`, theme.background)
+	fmt.Fprintf(&svg, `<g font-family="Helvetica,Arial,sans-serif" font-size="9" fill="%s">`, theme.text)
+
+	months := graphMonths(weeks)
+	for i := range weeks {
+		if month, ok := months[i]; ok {
+			fmt.Fprintf(&svg, `<text x="%d" y="%d">%s</text>`, graphLeft+i*graphStep, graphTop-7, month)
+		}
+	}
+	for _, row := range []int{1, 3, 5} {
+		weekday := time.Weekday(row).String()[:3]
+		fmt.Fprintf(&svg, `<text x="0" y="%d">%s</text>`, graphTop+row*graphStep+graphCell-1, weekday)
+	}
+
+	legendY := graphTop + 7*graphStep + 8
+	fmt.Fprintf(&svg, `<text x="%d" y="%d">%d contributions %s</text>`, graphLeft, legendY+graphCell-1, total, caption)
+	legendX := width - graphRight - 5*graphStep - 30
+	fmt.Fprintf(&svg, `<text x="%d" y="%d" text-anchor="end">Less</text>`, legendX-4, legendY+graphCell-1)
+	for level, fill := range theme.levels {
+		fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"/>`, legendX+level*graphStep, legendY, graphCell, graphCell, fill)
+	}
+	fmt.Fprintf(&svg, `<text x="%d" y="%d">More</text>`, legendX+5*graphStep+2, legendY+graphCell-1)
+	svg.WriteString(`</g>`)
+
+	for i, week := range weeks {
+		for j, day := range week.Days {
+			x, y := graphCellAt(i, j, day)
+			plural := "s"
+			if day.Count == 1 {
+				plural = ""
+			}
+			fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%d contribution%s on %s</title></rect>`,
+				x, y, graphCell, graphCell, theme.levels[graphLevel(day.Level)], day.Count, plural, day.Date)
+		}
+	}
+
+	svg.WriteString(`</svg>`)
+	return []byte(svg.String())
+}
+
+func renderContributionPNG(weeks []models.ContributionWeek, theme contributionTheme) ([]byte, error) {
+	width, height := graphSize(weeks)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: parseHexColor(theme.background)}, image.Point{}, draw.Src)
+
+	for i, week := range weeks {
+		for j, day := range week.Days {
+			x, y := graphCellAt(i, j, day)
+			cell := image.Rect(x, y, x+graphCell, y+graphCell)
+			draw.Draw(img, cell, &image.Uniform{C: parseHexColor(theme.levels[graphLevel(day.Level)])}, image.Point{}, draw.Src)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseHexColor parses a #rrggbb color; anything else is black
+func parseHexColor(hex string) color.RGBA {
+	value, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil || len(hex) != 7 {
+		return color.RGBA{A: 0xff}
+	}
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xff}
+}
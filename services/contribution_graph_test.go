@@ -0,0 +1,91 @@
+package services
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"io"
+	"portfolio-backend/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// graphWeeks are three weeks, the first starting on a Wednesday
+var graphWeeks = []models.ContributionWeek{
+	{WeekStart: "2024-01-28", Days: []models.ContributionDay{
+		{Date: "2024-01-31", Count: 1, Level: 1},
+		{Date: "2024-02-01", Count: 0},
+		{Date: "2024-02-02", Count: 0},
+		{Date: "2024-02-03", Count: 4, Level: 3},
+	}},
+	{WeekStart: "2024-02-04", Days: []models.ContributionDay{
+		{Date: "2024-02-04", Count: 9, Level: 7},
+	}},
+	{WeekStart: "2024-02-11", Days: []models.ContributionDay{
+		{Date: "2024-02-11", Count: 2, Level: 2},
+	}},
+}
+
+func TestGraphCellAt(t *testing.T) {
+	x, y := graphCellAt(0, 0, graphWeeks[0].Days[0])
+	assert.Equal(t, graphLeft, x)
+	assert.Equal(t, graphTop+3*graphStep, y, "Wednesdays are on the fourth row")
+
+	x, y = graphCellAt(1, 0, graphWeeks[1].Days[0])
+	assert.Equal(t, graphLeft+graphStep, x)
+	assert.Equal(t, graphTop, y)
+}
+
+func TestGraphMonths(t *testing.T) {
+	months := graphMonths([]models.ContributionWeek{
+		{Days: []models.ContributionDay{{Date: "2024-01-28"}}},
+		{Days: []models.ContributionDay{{Date: "2024-02-04"}}},
+		{Days: []models.ContributionDay{{Date: "2024-02-11"}}},
+		{Days: []models.ContributionDay{{Date: "2024-02-18"}}},
+		{Days: []models.ContributionDay{{Date: "2024-02-25"}}},
+		{Days: []models.ContributionDay{{Date: "2024-03-03"}}},
+	})
+	// February starts too close to January's label to fit
+	assert.Equal(t, map[int]string{0: "Jan", 5: "Mar"}, months)
+}
+
+func TestRenderContributionSVG(t *testing.T) {
+	theme := contributionThemes["dark"]
+	svg := renderContributionSVG(graphWeeks, theme, "in the last year")
+
+	decoder := xml.NewDecoder(bytes.NewReader(svg))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	out := string(svg)
+	assert.Contains(t, out, "16 contributions in the last year")
+	assert.Contains(t, out, "<title>1 contribution on 2024-01-31</title>")
+	assert.Contains(t, out, `fill="`+theme.background+`"`)
+	assert.Contains(t, out, `fill="`+theme.levels[4]+`"><title>9 contributions on 2024-02-04</title>`, "levels are clamped")
+	assert.Equal(t, 6, strings.Count(out, "<title>"))
+	assert.Equal(t, out, string(renderContributionSVG(graphWeeks, theme, "in the last year")), "rendering is deterministic")
+}
+
+func TestRenderContributionPNG(t *testing.T) {
+	theme := contributionThemes["light"]
+	data, err := renderContributionPNG(graphWeeks, theme)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	width, height := graphSize(graphWeeks)
+	assert.Equal(t, width, img.Bounds().Dx())
+	assert.Equal(t, height, img.Bounds().Dy())
+
+	x, y := graphCellAt(0, 3, graphWeeks[0].Days[3])
+	assert.Equal(t, parseHexColor(theme.levels[3]), img.At(x+1, y+1))
+	assert.Equal(t, parseHexColor(theme.background), img.At(0, 0))
+}
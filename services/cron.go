@@ -0,0 +1,146 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros are the shorthand schedules accepted besides five-field specs
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// cronSchedule is a parsed five-field cron spec
+// (minute hour day-of-month month day-of-week), one bit per allowed value
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// Like cron, when both day fields are restricted a day matching either runs
+	domRestricted, dowRestricted bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7}, // Sunday is 0 or 7
+}
+
+// parseCron parses a standard five-field cron spec such as "0 */6 * * *".
+// Fields accept *, values, ranges (1-5), lists (1,15) and steps (*/15, 0-30/10).
+func parseCron(spec string) (*cronSchedule, error) {
+	if expanded, ok := cronMacros[spec]; ok {
+		spec = expanded
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("cron spec %q must have %d fields", spec, len(cronFields))
+	}
+
+	bits := make([]uint64, len(cronFields))
+	for i, field := range cronFields {
+		value, err := parseCronField(parts[i], field)
+		if err != nil {
+			return nil, err
+		}
+		bits[i] = value
+	}
+
+	// Fold Sunday written as 7 into 0
+	bits[4] = bits[4]&^(1<<7) | bits[4]>>7
+
+	return &cronSchedule{
+		minute:        bits[0],
+		hour:          bits[1],
+		dom:           bits[2],
+		month:         bits[3],
+		dow:           bits[4],
+		domRestricted: parts[2] != "*",
+		dowRestricted: parts[4] != "*",
+	}, nil
+}
+
+func parseCronField(text string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(text, ",") {
+		rangeText, stepText, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in cron %s: %q", field.name, item)
+			}
+		}
+
+		low, high := field.min, field.max
+		if rangeText != "*" {
+			lowText, highText, isRange := strings.Cut(rangeText, "-")
+			var err error
+			if low, err = strconv.Atoi(lowText); err != nil {
+				return 0, fmt.Errorf("invalid cron %s: %q", field.name, item)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highText); err != nil {
+					return 0, fmt.Errorf("invalid cron %s: %q", field.name, item)
+				}
+			} else if hasStep {
+				// "5/15" means every 15 starting at 5
+				high = field.max
+			}
+		}
+
+		if low < field.min || high > field.max || low > high {
+			return 0, fmt.Errorf("cron %s out of range %d-%d: %q", field.name, field.min, field.max, item)
+		}
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// next returns the first time strictly after t that matches the schedule,
+// in t's location, or the zero time if none exists within five years
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
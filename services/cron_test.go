@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronNext(t *testing.T) {
+	// A Wednesday
+	from := time.Date(2024, 5, 15, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2024, 5, 15, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 */6 * * *", time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)},
+		{"30 9 * * *", time.Date(2024, 5, 16, 9, 30, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2024, 5, 16, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"5/20 10 * * *", time.Date(2024, 5, 15, 10, 25, 0, 0, time.UTC)},
+		// Both day fields restricted: either one matching is enough
+		{"0 0 1 * 5", time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := parseCron(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, schedule.next(from))
+		})
+	}
+}
+
+func TestCronNextNeverRuns(t *testing.T) {
+	schedule, err := parseCron("0 0 31 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.next(time.Now()).IsZero())
+}
+
+func TestParseCronRejectsInvalidSpecs(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@yearly"} {
+		_, err := parseCron(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DeployHookService struct {
+	client          *http.Client
+	settingsService *SettingsService
+	runs            *mongo.Collection
+}
+
+func NewDeployHookService() *DeployHookService {
+	return &DeployHookService{
+		client: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+		settingsService: NewSettingsService(),
+		runs:            database.Database.Collection("deploy_hook_runs"),
+	}
+}
+
+// deployHookRetryDelay is the wait before retrying a failed delivery; it
+// doubles with every further attempt
+var deployHookRetryDelay = 5 * time.Second
+
+// pendingDeploy collects content changes until they settle for DEPLOY_HOOK_DEBOUNCE
+var pendingDeploy struct {
+	sync.Mutex
+	timer   *time.Timer
+	reasons map[string]bool
+}
+
+// fireScheduledDeploy triggers the hooks once content settles, a variable so
+// tests can see what the debounce coalesced
+var fireScheduledDeploy = func(ds *DeployHookService, trigger string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if _, err := ds.trigger(ctx, trigger, "", config.AppConfig.DeployHookRetries); err != nil {
+		log.Printf("Deploy hook trigger failed: %v", err)
+	}
+}
+
+// GetHooks returns the configured deploy hooks
+func (ds *DeployHookService) GetHooks(ctx context.Context) ([]models.DeployHook, error) {
+	hooks := []models.DeployHook{}
+	err := ds.settingsService.Get(ctx, SettingDeployHooks, &hooks)
+	if err != nil && err != ErrSettingNotFound {
+		return nil, err
+	}
+	return hooks, nil
+}
+
+// SetHooks replaces the configured deploy hooks
+func (ds *DeployHookService) SetHooks(ctx context.Context, hooks []models.DeployHook, updatedBy string) error {
+	seen := make(map[string]bool)
+	for _, hook := range hooks {
+		if hook.Name == "" || hook.URL == "" {
+			return fmt.Errorf("deploy hooks require a name and url")
+		}
+		if !strings.HasPrefix(hook.URL, "https://") && !strings.HasPrefix(hook.URL, "http://") {
+			return fmt.Errorf("invalid deploy hook url for %s", hook.Name)
+		}
+		if seen[hook.Name] {
+			return fmt.Errorf("duplicate deploy hook name: %s", hook.Name)
+		}
+		seen[hook.Name] = true
+	}
+
+	return ds.settingsService.Set(ctx, SettingDeployHooks, hooks, updatedBy)
+}
+
+// ScheduleTrigger debounces content changes so a burst of edits results in
+// a single rebuild once the content settles
+func (ds *DeployHookService) ScheduleTrigger(reason string) {
+	pendingDeploy.Lock()
+	defer pendingDeploy.Unlock()
+
+	if pendingDeploy.reasons == nil {
+		pendingDeploy.reasons = make(map[string]bool)
+	}
+	pendingDeploy.reasons[reason] = true
+
+	if pendingDeploy.timer != nil {
+		pendingDeploy.timer.Stop()
+	}
+
+	pendingDeploy.timer = time.AfterFunc(config.AppConfig.DeployHookDebounce, func() {
+		pendingDeploy.Lock()
+		reasons := make([]string, 0, len(pendingDeploy.reasons))
+		for r := range pendingDeploy.reasons {
+			reasons = append(reasons, r)
+		}
+		pendingDeploy.reasons = nil
+		pendingDeploy.timer = nil
+		pendingDeploy.Unlock()
+
+		sort.Strings(reasons)
+		fireScheduledDeploy(ds, "content:"+strings.Join(reasons, ","))
+	})
+}
+
+// Trigger POSTs to every enabled hook (or only the named one) and records the outcome
+func (ds *DeployHookService) Trigger(ctx context.Context, trigger string, hookName string) ([]models.DeployHookRun, error) {
+	return ds.trigger(ctx, trigger, hookName, 0)
+}
+
+// trigger fires the hooks like Trigger, retrying each failed delivery up to
+// retries times
+func (ds *DeployHookService) trigger(ctx context.Context, trigger string, hookName string, retries int) ([]models.DeployHookRun, error) {
+	hooks, err := ds.GetHooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := []models.DeployHookRun{}
+	for _, hook := range hooks {
+		if hookName != "" && hook.Name != hookName {
+			continue
+		}
+		// Disabled hooks can still be fired explicitly by name
+		if !hook.Enabled && hookName == "" {
+			continue
+		}
+
+		run := ds.deliverWithRetry(ctx, hook, trigger, retries)
+		if _, err := ds.runs.InsertOne(ctx, run); err != nil {
+			log.Printf("Failed to record deploy hook run for %s: %v", hook.Name, err)
+		}
+		runs = append(runs, run)
+	}
+
+	if hookName != "" && len(runs) == 0 {
+		return nil, fmt.Errorf("deploy hook not found: %s", hookName)
+	}
+
+	return runs, nil
+}
+
+// GetHistory returns recent runs, optionally for a single hook
+func (ds *DeployHookService) GetHistory(ctx context.Context, hookName string, limit int) ([]models.DeployHookRun, error) {
+	filter := bson.M{}
+	if hookName != "" {
+		filter["hook"] = hookName
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "triggered_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := ds.runs.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	history := []models.DeployHookRun{}
+	err = cursor.All(ctx, &history)
+	return history, err
+}
+
+// deliverWithRetry delivers trigger to hook, retrying network errors, rate
+// limits and server errors with exponential backoff. Other client errors,
+// such as a revoked build hook URL, are not retried.
+func (ds *DeployHookService) deliverWithRetry(ctx context.Context, hook models.DeployHook, trigger string, retries int) models.DeployHookRun {
+	delay := deployHookRetryDelay
+	for attempt := 1; ; attempt++ {
+		run := ds.deliver(ctx, hook, trigger)
+		run.Attempts = attempt
+		if run.Success || attempt > retries || !retryableDeployStatus(run.StatusCode) {
+			return run
+		}
+
+		log.Printf("Deploy hook %s failed, retrying in %s: %s", hook.Name, delay, run.Error)
+		select {
+		case <-ctx.Done():
+			return run
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// retryableDeployStatus reports whether a delivery that got status, 0 for
+// no response, may succeed when repeated
+func retryableDeployStatus(status int) bool {
+	return status == 0 || status == http.StatusTooManyRequests || status >= 500
+}
+
+func (ds *DeployHookService) deliver(ctx context.Context, hook models.DeployHook, trigger string) models.DeployHookRun {
+	start := time.Now()
+	run := models.DeployHookRun{
+		ID:          primitive.NewObjectID(),
+		Hook:        hook.Name,
+		URL:         hook.URL,
+		Trigger:     trigger,
+		TriggeredAt: start,
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"trigger":      trigger,
+		"triggered_at": start,
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", hook.URL, bytes.NewReader(body))
+	if err != nil {
+		run.Error = err.Error()
+		return run
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ds.client.Do(req)
+	run.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		run.Error = err.Error()
+		return run
+	}
+	resp.Body.Close()
+
+	run.StatusCode = resp.StatusCode
+	run.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !run.Success {
+		run.Error = fmt.Sprintf("unexpected status: %d", resp.StatusCode)
+	}
+
+	return run
+}
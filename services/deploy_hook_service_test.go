@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliverWithRetry(t *testing.T) {
+	deployHookRetryDelay = time.Millisecond
+	defer func() { deployHookRetryDelay = 5 * time.Second }()
+
+	statuses := []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusCreated}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.WriteHeader(statuses[calls])
+		calls++
+	}))
+	defer server.Close()
+
+	ds := &DeployHookService{client: server.Client()}
+	hook := models.DeployHook{Name: "netlify", URL: server.URL, Enabled: true}
+
+	run := ds.deliverWithRetry(context.Background(), hook, "content:projects", 3)
+	assert.True(t, run.Success)
+	assert.Equal(t, http.StatusCreated, run.StatusCode)
+	assert.Equal(t, 3, run.Attempts)
+	assert.Equal(t, 3, calls)
+
+	// Retries run out
+	calls = 0
+	statuses = []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable}
+	run = ds.deliverWithRetry(context.Background(), hook, "content:projects", 1)
+	assert.False(t, run.Success)
+	assert.Equal(t, 2, run.Attempts)
+	assert.NotEmpty(t, run.Error)
+}
+
+func TestDeliverWithRetrySkipsClientErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	ds := &DeployHookService{client: server.Client()}
+	run := ds.deliverWithRetry(context.Background(), models.DeployHook{Name: "vercel", URL: server.URL}, "manual", 3)
+	assert.False(t, run.Success)
+	assert.Equal(t, 1, run.Attempts)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryableDeployStatus(t *testing.T) {
+	assert.True(t, retryableDeployStatus(0))
+	assert.True(t, retryableDeployStatus(http.StatusTooManyRequests))
+	assert.True(t, retryableDeployStatus(http.StatusInternalServerError))
+	assert.False(t, retryableDeployStatus(http.StatusUnauthorized))
+	assert.False(t, retryableDeployStatus(http.StatusNotFound))
+}
+
+func TestScheduleTriggerCoalesces(t *testing.T) {
+	config.AppConfig = &config.Config{DeployHookDebounce: 50 * time.Millisecond}
+	fired := make(chan string, 4)
+	original := fireScheduledDeploy
+	fireScheduledDeploy = func(ds *DeployHookService, trigger string) { fired <- trigger }
+	defer func() { fireScheduledDeploy = original }()
+
+	ds := &DeployHookService{}
+	// Each change restarts the wait, so the burst fires once
+	for _, reason := range []string{"skills", "projects", "skills"} {
+		ds.ScheduleTrigger(reason)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case trigger := <-fired:
+		assert.Equal(t, "content:projects,skills", trigger)
+	case <-time.After(time.Second):
+		t.Fatal("the debounced trigger never fired")
+	}
+	select {
+	case trigger := <-fired:
+		t.Fatalf("unexpected second trigger %q", trigger)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A later change starts a new batch
+	ds.ScheduleTrigger("meta")
+	select {
+	case trigger := <-fired:
+		assert.Equal(t, "content:meta", trigger)
+	case <-time.After(time.Second):
+		t.Fatal("the second batch never fired")
+	}
+}
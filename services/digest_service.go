@@ -0,0 +1,251 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const digestTemplate = `Weekly portfolio digest for {{.Username}}
+{{.PeriodStart.Format "Jan 2"}} – {{.PeriodEnd.Format "Jan 2, 2006"}}
+{{if .Has "stars"}}
+⭐ Stars: {{.TotalStars}} ({{printf "%+d" .StarsGained}} this week)
+{{end}}{{if .Has "followers"}}
+👥 Followers: {{.Followers}} ({{printf "%+d" .NewFollowers}} this week)
+{{end}}{{if and (.Has "repos") .TopRepositories}}
+Repositories that gained stars:
+{{range .TopRepositories}}  - {{.Name}}: {{.Stars}} ({{printf "%+d" .Gained}})
+{{end}}{{end}}{{if and (.Has "content") .ContentChanges}}
+Content updated:
+{{range .ContentChanges}}  - {{.Type}} v{{.Version}} by {{.UpdatedBy}} on {{.UpdatedAt.Format "Mon Jan 2"}}
+{{end}}{{end}}`
+
+type DigestService struct {
+	githubService   *GitHubService
+	settingsService *SettingsService
+	snapshots       *mongo.Collection
+	content         *mongo.Collection
+	mailer          Mailer
+}
+
+func NewDigestService() *DigestService {
+	return &DigestService{
+		githubService:   NewGitHubService(),
+		settingsService: NewSettingsService(),
+		snapshots:       database.Database.Collection("digest_snapshots"),
+		content:         database.Database.Collection("content"),
+		mailer:          NewMailer(),
+	}
+}
+
+// Compose builds the digest comparing current GitHub numbers against the
+// snapshot taken when the previous digest was sent
+func (ds *DigestService) Compose(ctx context.Context) (*models.Digest, *models.DigestSnapshot, error) {
+	username := ds.settingsService.GetOwner(ctx)
+	now := time.Now()
+
+	profile, err := ds.githubService.GetProfile(ctx, username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stats, err := ds.githubService.GetStats(ctx, username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	current := &models.DigestSnapshot{
+		ID:         primitive.NewObjectID(),
+		Username:   username,
+		TotalStars: stats.TotalStars,
+		Followers:  profile.Followers,
+		TotalRepos: stats.TotalRepos,
+		RepoStars:  make(map[string]int),
+		TakenAt:    now,
+	}
+	for _, repo := range stats.TopRepositories {
+		current.RepoStars[repo.Name] = repo.Stars
+	}
+
+	// Without a previous snapshot the digest covers the last week from zero
+	previous := models.DigestSnapshot{
+		TotalStars: current.TotalStars,
+		Followers:  current.Followers,
+		RepoStars:  current.RepoStars,
+		TakenAt:    now.AddDate(0, 0, -7),
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "taken_at", Value: -1}})
+	err = ds.snapshots.FindOne(ctx, bson.M{"username": username}, opts).Decode(&previous)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, nil, err
+	}
+
+	digest := &models.Digest{
+		Username:     username,
+		PeriodStart:  previous.TakenAt,
+		PeriodEnd:    now,
+		Sections:     digestSections(),
+		TotalStars:   current.TotalStars,
+		StarsGained:  current.TotalStars - previous.TotalStars,
+		Followers:    current.Followers,
+		NewFollowers: current.Followers - previous.Followers,
+	}
+
+	for name, stars := range current.RepoStars {
+		if gained := stars - previous.RepoStars[name]; gained > 0 {
+			digest.TopRepositories = append(digest.TopRepositories, models.RepoStarDelta{
+				Name:   name,
+				Stars:  stars,
+				Gained: gained,
+			})
+		}
+	}
+	sort.Slice(digest.TopRepositories, func(i, j int) bool {
+		return digest.TopRepositories[i].Gained > digest.TopRepositories[j].Gained
+	})
+
+	cursor, err := ds.content.Find(ctx, bson.M{"updated_at": bson.M{"$gte": previous.TakenAt}},
+		options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}}))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var changed []models.Content
+	if err := cursor.All(ctx, &changed); err != nil {
+		return nil, nil, err
+	}
+	for _, content := range changed {
+		digest.ContentChanges = append(digest.ContentChanges, models.DigestContentChange{
+			Type:      content.Type,
+			Version:   content.Version,
+			UpdatedBy: content.UpdatedBy,
+			UpdatedAt: content.UpdatedAt,
+		})
+	}
+
+	return digest, current, nil
+}
+
+// Render formats a digest as a plain-text email body
+func (ds *DigestService) Render(digest *models.Digest) (string, error) {
+	tmpl, err := template.New("digest").Parse(digestTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, digest); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// Send composes the digest, emails it to OWNER_EMAIL and stores the snapshot
+// the next digest will be compared against
+func (ds *DigestService) Send(ctx context.Context) (*models.Digest, error) {
+	if config.AppConfig.OwnerEmail == "" {
+		return nil, fmt.Errorf("OWNER_EMAIL is not configured")
+	}
+
+	digest, snapshot, err := ds.Compose(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ds.Render(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ds.mailer.Send(ctx, MailMessage{
+		To:      []string{config.AppConfig.OwnerEmail},
+		Subject: fmt.Sprintf("Portfolio weekly digest: %+d stars, %+d followers", digest.StarsGained, digest.NewFollowers),
+		Body:    body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ds.snapshots.InsertOne(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
+	return digest, nil
+}
+
+// StartScheduler sends the digest every week on DIGEST_DAY at DIGEST_TIME
+func (ds *DigestService) StartScheduler() {
+	if !config.AppConfig.DigestEnabled {
+		return
+	}
+
+	go func() {
+		for {
+			next, err := nextDigestTime(time.Now(), config.AppConfig.DigestDay, config.AppConfig.DigestTime)
+			if err != nil {
+				log.Printf("Weekly digest disabled: %v", err)
+				return
+			}
+
+			time.Sleep(time.Until(next))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			if _, err := ds.Send(ctx); err != nil {
+				log.Printf("Weekly digest error: %v", err)
+			}
+			cancel()
+		}
+	}()
+}
+
+// nextDigestTime returns the next occurrence of weekday at clock ("15:04") after now
+func nextDigestTime(now time.Time, weekday string, clock string) (time.Time, error) {
+	days := map[string]time.Weekday{
+		"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday,
+		"wednesday": time.Wednesday, "thursday": time.Thursday, "friday": time.Friday,
+		"saturday": time.Saturday,
+	}
+
+	day, ok := days[strings.ToLower(weekday)]
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid DIGEST_DAY: %s", weekday)
+	}
+
+	at, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid DIGEST_TIME: %s", clock)
+	}
+
+	next := time.Date(now.Year(), now.Month(), now.Day(), at.Hour(), at.Minute(), 0, 0, now.Location())
+	next = next.AddDate(0, 0, (int(day)-int(now.Weekday())+7)%7)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 7)
+	}
+
+	return next, nil
+}
+
+func digestSections() []string {
+	var sections []string
+	for _, section := range strings.Split(config.AppConfig.DigestSections, ",") {
+		if section = strings.TrimSpace(section); section != "" {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
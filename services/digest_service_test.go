@@ -0,0 +1,76 @@
+package services
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // DST rules without the system zoneinfo
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextDigestTime(t *testing.T) {
+	utc := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		weekday string
+		want    time.Time
+	}{
+		// 2024-03-04 is a Monday
+		{"later the same day", utc(2024, 3, 4, 8, 0), "monday", utc(2024, 3, 4, 9, 0)},
+		{"exactly at the time", utc(2024, 3, 4, 9, 0), "monday", utc(2024, 3, 11, 9, 0)},
+		{"earlier the same day", utc(2024, 3, 4, 10, 0), "Monday", utc(2024, 3, 11, 9, 0)},
+		{"later in the week", utc(2024, 3, 4, 10, 0), "friday", utc(2024, 3, 8, 9, 0)},
+		{"across the weekend", utc(2024, 3, 9, 23, 30), "sunday", utc(2024, 3, 10, 9, 0)},
+		{"into the next week", utc(2024, 3, 8, 10, 0), "tuesday", utc(2024, 3, 12, 9, 0)},
+		{"across the year", utc(2024, 12, 30, 10, 0), "friday", utc(2025, 1, 3, 9, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := nextDigestTime(tt.now, tt.weekday, "09:00")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, next)
+		})
+	}
+}
+
+func TestNextDigestTimeDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// Clocks go forward on Sunday 2024-03-31; the digest keeps its wall time
+	now := time.Date(2024, 3, 30, 10, 0, 0, 0, berlin)
+	next, err := nextDigestTime(now, "monday", "09:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 4, 1, 9, 0, 0, 0, berlin), next)
+	_, offset := next.Zone()
+	assert.Equal(t, 2*60*60, offset)
+	assert.Equal(t, 46*time.Hour, next.Sub(now), "an hour shorter than the wall clock says")
+
+	// And back on Sunday 2024-10-27
+	now = time.Date(2024, 10, 26, 10, 0, 0, 0, berlin)
+	next, err = nextDigestTime(now, "monday", "09:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 10, 28, 9, 0, 0, 0, berlin), next)
+	assert.Equal(t, 48*time.Hour, next.Sub(now), "an hour longer than the wall clock says")
+
+	// A time skipped by the change moves past the gap
+	now = time.Date(2024, 3, 30, 10, 0, 0, 0, berlin)
+	next, err = nextDigestTime(now, "sunday", "02:30")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-31T03:30:00+02:00", next.Format(time.RFC3339))
+}
+
+func TestNextDigestTimeInvalid(t *testing.T) {
+	now := time.Now()
+
+	_, err := nextDigestTime(now, "someday", "09:00")
+	assert.Error(t, err)
+
+	_, err = nextDigestTime(now, "monday", "9am")
+	assert.Error(t, err)
+}
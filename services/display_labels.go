@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"portfolio-backend/models"
+	"regexp"
+	"strings"
+)
+
+// localePattern accepts BCP 47 style locales such as "en" or "pt-BR"
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// GetDisplayLabels returns the display labels, none until they are set
+func (ss *SettingsService) GetDisplayLabels(ctx context.Context) (*models.DisplayLabels, error) {
+	labels := models.DisplayLabels{Locales: map[string][]models.DisplayLabel{}}
+
+	err := ss.Get(ctx, SettingLabels, &labels)
+	if err != nil && err != ErrSettingNotFound {
+		return nil, err
+	}
+	return &labels, nil
+}
+
+// SetDisplayLabels validates and stores the display labels, then drops the
+// cached content and GitHub responses so they are rebuilt with them
+func (ss *SettingsService) SetDisplayLabels(ctx context.Context, labels models.DisplayLabels, updatedBy string) error {
+	if err := validateDisplayLabels(labels); err != nil {
+		return err
+	}
+	if err := ss.Set(ctx, SettingLabels, labels, updatedBy); err != nil {
+		return err
+	}
+	if !IsDryRun(ctx) {
+		dropResponses(ResponseTagContent, ResponseTagGitHub)
+	}
+	return nil
+}
+
+// GetLabeler returns the Labeler of locale
+func (ss *SettingsService) GetLabeler(ctx context.Context, locale string) (Labeler, error) {
+	labels, err := ss.GetDisplayLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewLabeler(labels, locale), nil
+}
+
+func validateDisplayLabels(labels models.DisplayLabels) error {
+	if labels.DefaultLocale != "" {
+		if _, ok := labels.Locales[labels.DefaultLocale]; !ok {
+			return fmt.Errorf("default locale %q has no labels", labels.DefaultLocale)
+		}
+	}
+
+	for locale, entries := range labels.Locales {
+		if !localePattern.MatchString(locale) {
+			return fmt.Errorf("invalid locale: %q", locale)
+		}
+
+		// A name shown as two labels would depend on their order
+		seen := make(map[string]bool)
+		for _, entry := range entries {
+			if strings.TrimSpace(entry.Label) == "" {
+				return fmt.Errorf("%s: label of %v is empty", locale, entry.From)
+			}
+			if len(entry.From) == 0 {
+				return fmt.Errorf("%s: label %q has no names to replace", locale, entry.Label)
+			}
+			for _, name := range entry.From {
+				key := normalizeLabel(name)
+				if key == "" {
+					return fmt.Errorf("%s: label %q replaces an empty name", locale, entry.Label)
+				}
+				if seen[key] {
+					return fmt.Errorf("%s: %q has more than one label", locale, name)
+				}
+				seen[key] = true
+			}
+		}
+	}
+	return nil
+}
+
+// Labeler shows raw GitHub topics and language names, and the skills named
+// after them, as the display labels of one locale. Names without a label,
+// and every name of a nil Labeler, are shown as they are.
+type Labeler map[string]string
+
+// NewLabeler returns the Labeler of locale. A regional locale without labels
+// of its own, e.g. "pt-BR", falls back to its language ("pt"), and then to
+// the default locale.
+func NewLabeler(labels *models.DisplayLabels, locale string) Labeler {
+	entries, ok := findLocale(labels.Locales, locale)
+	if !ok {
+		if language, _, regional := strings.Cut(locale, "-"); regional {
+			entries, ok = findLocale(labels.Locales, language)
+		}
+	}
+	if !ok {
+		entries = labels.Locales[labels.DefaultLocale]
+	}
+
+	labeler := make(Labeler)
+	for _, entry := range entries {
+		for _, name := range entry.From {
+			labeler[normalizeLabel(name)] = entry.Label
+		}
+	}
+	return labeler
+}
+
+// findLocale looks locale up regardless of case, as locales are
+func findLocale(locales map[string][]models.DisplayLabel, locale string) ([]models.DisplayLabel, bool) {
+	if locale == "" {
+		return nil, false
+	}
+	for name, entries := range locales {
+		if strings.EqualFold(name, locale) {
+			return entries, true
+		}
+	}
+	return nil, false
+}
+
+// Label returns the display label of name
+func (l Labeler) Label(name string) string {
+	if label, ok := l[normalizeLabel(name)]; ok {
+		return label
+	}
+	return name
+}
+
+// Topics returns the display labels of topics, each once, in the order of
+// the first topic shown as it
+func (l Labeler) Topics(topics []string) []string {
+	labels := []string{}
+	seen := make(map[string]bool)
+	for _, topic := range topics {
+		if label := l.Label(topic); !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// Repositories sets the language and topic labels of repos in place
+func (l Labeler) Repositories(repos []models.GitHubRepository) {
+	for i := range repos {
+		if repos[i].Language != "" {
+			repos[i].LanguageLabel = l.Label(repos[i].Language)
+		}
+		repos[i].TopicLabels = l.Topics(repos[i].Topics)
+	}
+}
+
+// Languages returns a copy of languages with their labels, leaving the
+// stats they may be shared with untouched
+func (l Labeler) Languages(languages []models.LanguageStat) []models.LanguageStat {
+	labeled := make([]models.LanguageStat, len(languages))
+	for i, language := range languages {
+		language.Label = l.Label(language.Name)
+		labeled[i] = language
+	}
+	return labeled
+}
+
+// Skills sets the labels of skills in place
+func (l Labeler) Skills(skills *models.Skills) {
+	for _, category := range skillCategories(skills) {
+		for i := range category {
+			category[i].Label = l.Label(category[i].Name)
+		}
+	}
+}
+
+func normalizeLabel(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDisplayLabels() *models.DisplayLabels {
+	return &models.DisplayLabels{
+		DefaultLocale: "en",
+		Locales: map[string][]models.DisplayLabel{
+			"en": {
+				{From: []string{"cpp"}, Label: "C++"},
+				{From: []string{"machine-learning", "ml"}, Label: "Machine learning"},
+			},
+			"pt": {
+				{From: []string{"cpp"}, Label: "C++"},
+				{From: []string{"machine-learning", "aprendizado-de-maquina"}, Label: "Aprendizado de máquina"},
+			},
+		},
+	}
+}
+
+func TestNewLabeler(t *testing.T) {
+	labels := testDisplayLabels()
+
+	assert.Equal(t, "Aprendizado de máquina", NewLabeler(labels, "pt").Label("machine-learning"))
+	assert.Equal(t, "Aprendizado de máquina", NewLabeler(labels, "PT-br").Label("Machine-Learning"), "regional locales fall back to their language")
+	assert.Equal(t, "Machine learning", NewLabeler(labels, "fr").Label("ml"), "unknown locales use the default one")
+	assert.Equal(t, "Machine learning", NewLabeler(labels, "").Label("ml"))
+	assert.Equal(t, "golang", NewLabeler(labels, "en").Label("golang"))
+
+	var none Labeler
+	assert.Equal(t, "cpp", none.Label("cpp"))
+}
+
+func TestLabelerTopics(t *testing.T) {
+	labeler := NewLabeler(testDisplayLabels(), "pt")
+
+	topics := labeler.Topics([]string{"aprendizado-de-maquina", "cpp", "machine-learning", "go"})
+	assert.Equal(t, []string{"Aprendizado de máquina", "C++", "go"}, topics)
+	assert.Equal(t, []string{}, labeler.Topics(nil))
+}
+
+func TestLabelerRepositories(t *testing.T) {
+	repos := []models.GitHubRepository{
+		{Name: "engine", Language: "cpp", Topics: []string{"ml", "machine-learning"}},
+		{Name: "notes"},
+	}
+
+	NewLabeler(testDisplayLabels(), "en").Repositories(repos)
+	assert.Equal(t, "C++", repos[0].LanguageLabel)
+	assert.Equal(t, []string{"Machine learning"}, repos[0].TopicLabels)
+	assert.Equal(t, []string{"ml", "machine-learning"}, repos[0].Topics, "raw topics are kept for filtering")
+	assert.Empty(t, repos[1].LanguageLabel)
+}
+
+func TestLabelerLanguages(t *testing.T) {
+	languages := []models.LanguageStat{{Name: "cpp", Bytes: 10, Percentage: 100}}
+
+	labeled := NewLabeler(testDisplayLabels(), "en").Languages(languages)
+	assert.Equal(t, "C++", labeled[0].Label)
+	assert.Empty(t, languages[0].Label, "shared stats are left untouched")
+}
+
+func TestLabelerSkills(t *testing.T) {
+	skills := &models.Skills{Languages: []models.Skill{{Name: "cpp"}, {Name: "Go"}}}
+
+	NewLabeler(testDisplayLabels(), "en").Skills(skills)
+	assert.Equal(t, "C++", skills.Languages[0].Label)
+	assert.Equal(t, "Go", skills.Languages[1].Label)
+}
+
+func TestValidateDisplayLabels(t *testing.T) {
+	assert.NoError(t, validateDisplayLabels(*testDisplayLabels()))
+	assert.NoError(t, validateDisplayLabels(models.DisplayLabels{}))
+
+	tests := map[string]models.DisplayLabels{
+		"unknown default locale": {DefaultLocale: "de", Locales: map[string][]models.DisplayLabel{}},
+		"invalid locale":         {Locales: map[string][]models.DisplayLabel{"pt.BR": nil}},
+		"empty label":            {Locales: map[string][]models.DisplayLabel{"en": {{From: []string{"cpp"}}}}},
+		"no names":               {Locales: map[string][]models.DisplayLabel{"en": {{Label: "C++"}}}},
+		"empty name":             {Locales: map[string][]models.DisplayLabel{"en": {{From: []string{" "}, Label: "C++"}}}},
+		"name labeled twice": {Locales: map[string][]models.DisplayLabel{"en": {
+			{From: []string{"cpp"}, Label: "C++"},
+			{From: []string{"CPP"}, Label: "Cpp"},
+		}}},
+	}
+	for name, labels := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, validateDisplayLabels(labels))
+		})
+	}
+}
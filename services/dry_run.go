@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"portfolio-backend/models"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type dryRunKey struct{}
+
+// dryRunPlan collects the writes skipped during a dry-run request
+type dryRunPlan struct {
+	mu      sync.Mutex
+	changes []models.DryRunChange
+}
+
+// WithDryRun marks ctx as a dry run: the write paths that support it record
+// what they would change instead of changing it
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, &dryRunPlan{})
+}
+
+// IsDryRun reports whether ctx belongs to a dry-run request
+func IsDryRun(ctx context.Context) bool {
+	_, ok := ctx.Value(dryRunKey{}).(*dryRunPlan)
+	return ok
+}
+
+// DryRunChanges returns the writes recorded during the dry run of ctx
+func DryRunChanges(ctx context.Context) []models.DryRunChange {
+	plan, ok := ctx.Value(dryRunKey{}).(*dryRunPlan)
+	if !ok {
+		return nil
+	}
+
+	plan.mu.Lock()
+	defer plan.mu.Unlock()
+	return append([]models.DryRunChange{}, plan.changes...)
+}
+
+func recordDryRun(ctx context.Context, change models.DryRunChange) {
+	plan, ok := ctx.Value(dryRunKey{}).(*dryRunPlan)
+	if !ok {
+		return
+	}
+	if change.Diff == nil {
+		change.Diff = []models.FieldDiff{}
+	}
+
+	plan.mu.Lock()
+	defer plan.mu.Unlock()
+	plan.changes = append(plan.changes, change)
+}
+
+// AddDryRunEffects adds cache invalidations and side effects to the last
+// write recorded during the dry run of ctx
+func AddDryRunEffects(ctx context.Context, invalidations, sideEffects []string) {
+	plan, ok := ctx.Value(dryRunKey{}).(*dryRunPlan)
+	if !ok {
+		return
+	}
+
+	plan.mu.Lock()
+	defer plan.mu.Unlock()
+	if len(plan.changes) == 0 {
+		return
+	}
+	last := &plan.changes[len(plan.changes)-1]
+	last.Invalidations = append(last.Invalidations, invalidations...)
+	last.SideEffects = append(last.SideEffects, sideEffects...)
+}
+
+// contentChangeEffects lists what saving contentType invalidates and
+// schedules, as reported by dry runs
+func contentChangeEffects(ctx context.Context, contentType string) ([]string, []string) {
+	effects := []string{
+		"deploy hooks: content:" + contentType,
+		"CDN purge: " + strings.Join(ContentSurrogateKeys(contentType), ", "),
+		"live event: " + models.LiveContentUpdated,
+	}
+	if notifyEnabled(NotifyContentPublished) {
+		for _, channel := range notificationChannels(ctx) {
+			effects = append(effects, "notification: "+channel.Name)
+		}
+	}
+	return []string{"content:.*"}, effects
+}
+
+// diffValues lists the fields that differ between two values, compared by
+// their JSON encoding
+func diffValues(before, after interface{}) []models.FieldDiff {
+	return diffGeneric("", toGeneric(before), toGeneric(after))
+}
+
+// diffDocuments lists the fields that differ between two BSON documents,
+// compared by their relaxed Extended JSON encoding
+func diffDocuments(before, after bson.M) []models.FieldDiff {
+	return diffGeneric("", extJSONGeneric(before), extJSONGeneric(after))
+}
+
+func toGeneric(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	var generic interface{}
+	json.Unmarshal(raw, &generic)
+	return generic
+}
+
+func extJSONGeneric(doc bson.M) interface{} {
+	if doc == nil {
+		return nil
+	}
+	raw, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return nil
+	}
+	var generic interface{}
+	json.Unmarshal(raw, &generic)
+	return generic
+}
+
+// diffGeneric walks decoded JSON values. Objects are compared key by key and
+// arrays of the same length item by item; anything else is reported whole.
+func diffGeneric(path string, before, after interface{}) []models.FieldDiff {
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+
+	switch b := before.(type) {
+	case map[string]interface{}:
+		a, ok := after.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(b)+len(a))
+		for key := range b {
+			keys = append(keys, key)
+		}
+		for key := range a {
+			if _, seen := b[key]; !seen {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		diffs := []models.FieldDiff{}
+		for _, key := range keys {
+			diffs = append(diffs, diffGeneric(path+"/"+escapePointer(key), b[key], a[key])...)
+		}
+		return diffs
+	case []interface{}:
+		a, ok := after.([]interface{})
+		if !ok || len(a) != len(b) {
+			break
+		}
+		diffs := []models.FieldDiff{}
+		for i := range b {
+			diffs = append(diffs, diffGeneric(fmt.Sprintf("%s/%d", path, i), b[i], a[i])...)
+		}
+		return diffs
+	}
+
+	if path == "" {
+		path = "/"
+	}
+	if secretField(path) {
+		before, after = redact(before), redact(after)
+	}
+	return []models.FieldDiff{{Path: path, Before: redactSecrets(before), After: redactSecrets(after)}}
+}
+
+// dryRunSecretFields are fields whose values dry runs only report as changed
+var dryRunSecretFields = []string{"token", "secret", "password", "api_key"}
+
+func secretField(path string) bool {
+	field := strings.ToLower(path[strings.LastIndex(path, "/")+1:])
+	for _, secret := range dryRunSecretFields {
+		if strings.Contains(field, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+func redact(value interface{}) interface{} {
+	if value == nil || value == "" {
+		return value
+	}
+	return "[redacted]"
+}
+
+// redactSecrets redacts the secret fields nested in a decoded JSON value
+func redactSecrets(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if secretField(key) {
+				redacted[key] = redact(item)
+			} else {
+				redacted[key] = redactSecrets(item)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = redactSecrets(item)
+		}
+		return redacted
+	}
+	return value
+}
+
+// escapePointer escapes a key for use in a JSON pointer
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
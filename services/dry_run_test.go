@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffValues(t *testing.T) {
+	before := map[string]interface{}{
+		"name":   "Felipe",
+		"skills": []string{"Go", "Rust"},
+		"links":  map[string]string{"github/url": "https://github.com/a"},
+	}
+	after := map[string]interface{}{
+		"name":   "Felipe",
+		"skills": []string{"Go", "Zig"},
+		"links":  map[string]string{"github/url": "https://github.com/b"},
+		"email":  "me@example.com",
+	}
+
+	diffs := diffValues(before, after)
+	require.Len(t, diffs, 3)
+	assert.Equal(t, models.FieldDiff{Path: "/email", After: "me@example.com"}, diffs[0])
+	assert.Equal(t, "/links/github~1url", diffs[1].Path)
+	assert.Equal(t, models.FieldDiff{Path: "/skills/1", Before: "Rust", After: "Zig"}, diffs[2])
+
+	assert.Empty(t, diffValues(before, before))
+}
+
+func TestDiffValuesRedactsSecrets(t *testing.T) {
+	before := map[string]interface{}{"bot_token": "old", "hooks": []interface{}{}}
+	after := map[string]interface{}{
+		"bot_token": "new",
+		"hooks":     []interface{}{map[string]interface{}{"url": "https://hooks.example.com", "secret": "s3cr3t"}},
+	}
+
+	diffs := diffValues(before, after)
+	require.Len(t, diffs, 2)
+	assert.Equal(t, models.FieldDiff{Path: "/bot_token", Before: "[redacted]", After: "[redacted]"}, diffs[0])
+	hooks := diffs[1].After.([]interface{})
+	assert.Equal(t, "[redacted]", hooks[0].(map[string]interface{})["secret"])
+	assert.Equal(t, "https://hooks.example.com", hooks[0].(map[string]interface{})["url"])
+}
+
+func TestDryRunChanges(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, IsDryRun(ctx))
+	recordDryRun(ctx, models.DryRunChange{Kind: models.DryRunSetting, Target: "telegram"})
+	assert.Nil(t, DryRunChanges(ctx))
+
+	ctx = WithDryRun(ctx)
+	assert.True(t, IsDryRun(ctx))
+	AddDryRunEffects(ctx, []string{"ignored"}, nil)
+	recordDryRun(ctx, models.DryRunChange{Kind: models.DryRunSetting, Target: "telegram"})
+	AddDryRunEffects(ctx, []string{"settings:telegram"}, []string{"restart bot"})
+
+	changes := DryRunChanges(ctx)
+	require.Len(t, changes, 1)
+	assert.Equal(t, []string{"settings:telegram"}, changes[0].Invalidations)
+	assert.Equal(t, []string{"restart bot"}, changes[0].SideEffects)
+	assert.NotNil(t, changes[0].Diff)
+}
@@ -0,0 +1,539 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// endorsementRecentLimit is how many of the latest endorsers are served
+	// with each skill
+	endorsementRecentLimit = 5
+	// endorsementListLimit caps the endorsements listed at once
+	endorsementListLimit = 100
+	// endorsementsCacheKey holds the summaries of every skill; it is under
+	// content: so content edits drop it as well
+	endorsementsCacheKey = "content:endorsements"
+)
+
+var (
+	// ErrEndorsementsDisabled is returned while no GitHub OAuth app is configured
+	ErrEndorsementsDisabled = errors.New("skill endorsements are not configured")
+	// ErrInvalidOAuthCode is returned when GitHub refuses to exchange a code
+	ErrInvalidOAuthCode = errors.New("invalid GitHub OAuth code")
+	// ErrInvalidGitHubToken is returned when GitHub does not accept the
+	// endorser's access token
+	ErrInvalidGitHubToken = errors.New("invalid GitHub access token")
+	// ErrSkillNotFound is returned for skills the portfolio does not list
+	ErrSkillNotFound = errors.New("skill not found")
+	// ErrAlreadyEndorsed is returned when an account endorses a skill twice
+	ErrAlreadyEndorsed = errors.New("skill already endorsed by this account")
+	// ErrEndorsementNotFound is returned when withdrawing or moderating an
+	// unknown endorsement
+	ErrEndorsementNotFound = errors.New("endorsement not found")
+	// ErrEndorsementForbidden is returned for blocked accounts and for the
+	// owner endorsing their own skills
+	ErrEndorsementForbidden = errors.New("this account may not endorse skills")
+	// ErrInvalidEndorsementStatus is returned for unknown moderation statuses
+	ErrInvalidEndorsementStatus = errors.New("invalid endorsement status")
+)
+
+type EndorsementService struct {
+	client          *http.Client
+	collection      *mongo.Collection
+	cacheService    *CacheService
+	contentService  *ContentService
+	settingsService *SettingsService
+	cdnPurge        *CDNPurgeService
+}
+
+func NewEndorsementService() *EndorsementService {
+	return &EndorsementService{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		collection:      database.Database.Collection("skill_endorsements"),
+		cacheService:    NewCacheService(),
+		contentService:  NewContentService(),
+		settingsService: NewSettingsService(),
+		cdnPurge:        NewCDNPurgeService(),
+	}
+}
+
+// githubUser is the account behind an endorser's access token
+type githubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// AuthorizeURL returns the GitHub page endorsers sign in on, along with the
+// state GitHub hands back to redirectURI, which the caller should check
+func (es *EndorsementService) AuthorizeURL(redirectURI string) (string, string, error) {
+	if config.AppConfig.GitHubOAuthClientID == "" {
+		return "", "", ErrEndorsementsDisabled
+	}
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", err
+	}
+	state := hex.EncodeToString(random)
+
+	query := url.Values{}
+	query.Set("client_id", config.AppConfig.GitHubOAuthClientID)
+	query.Set("state", state)
+	query.Set("allow_signup", "false")
+	if redirectURI != "" {
+		query.Set("redirect_uri", redirectURI)
+	}
+	return githubWebURL() + "/login/oauth/authorize?" + query.Encode(), state, nil
+}
+
+// ExchangeCode trades the code GitHub redirected the endorser back with for
+// an access token. No scope is requested, so the token can only read the
+// endorser's public profile.
+func (es *EndorsementService) ExchangeCode(ctx context.Context, request models.GitHubOAuthRequest) (*models.GitHubOAuthSession, error) {
+	if config.AppConfig.GitHubOAuthClientID == "" {
+		return nil, ErrEndorsementsDisabled
+	}
+
+	form := url.Values{}
+	form.Set("client_id", config.AppConfig.GitHubOAuthClientID)
+	form.Set("client_secret", config.AppConfig.GitHubOAuthClientSecret)
+	form.Set("code", request.Code)
+	if request.RedirectURI != "" {
+		form.Set("redirect_uri", request.RedirectURI)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, githubWebURL()+"/login/oauth/access_token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := es.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// GitHub answers 200 with an error field for bad or expired codes
+	var result struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("GitHub OAuth returned status %d: %w", resp.StatusCode, err)
+	}
+	if result.AccessToken == "" {
+		return nil, fmt.Errorf("%w: %s %s", ErrInvalidOAuthCode, result.Error, result.ErrorDescription)
+	}
+
+	user, err := es.verifyEndorser(ctx, result.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GitHubOAuthSession{
+		AccessToken: result.AccessToken,
+		Login:       user.Login,
+		AvatarURL:   user.AvatarURL,
+	}, nil
+}
+
+// verifyEndorser looks up the GitHub account an access token belongs to
+func (es *EndorsementService) verifyEndorser(ctx context.Context, token string) (*githubUser, error) {
+	if token == "" {
+		return nil, ErrInvalidGitHubToken
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, githubAPI("/user"), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := es.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, ErrInvalidGitHubToken
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+	}
+
+	var user githubUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 || user.Login == "" {
+		return nil, ErrInvalidGitHubToken
+	}
+	return &user, nil
+}
+
+// Endorse records an endorsement of skill by the owner of token. With
+// ENDORSEMENT_MODERATION it stays pending until approved.
+func (es *EndorsementService) Endorse(ctx context.Context, skill, token string) (*models.Endorsement, error) {
+	if config.AppConfig.GitHubOAuthClientID == "" {
+		return nil, ErrEndorsementsDisabled
+	}
+
+	skillKey, err := es.skillKey(ctx, skill)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := es.verifyEndorser(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(user.Login, es.settingsService.GetOwner(ctx)) || es.isBlocked(ctx, user.Login) {
+		return nil, ErrEndorsementForbidden
+	}
+
+	status := models.EndorsementApproved
+	if config.AppConfig.EndorsementModeration {
+		status = models.EndorsementPending
+	}
+
+	endorsement := models.Endorsement{
+		ID:         primitive.NewObjectID(),
+		Skill:      skillKey,
+		EndorserID: user.ID,
+		Login:      user.Login,
+		AvatarURL:  user.AvatarURL,
+		Status:     status,
+		CreatedAt:  time.Now(),
+	}
+	if _, err := es.collection.InsertOne(ctx, endorsement); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrAlreadyEndorsed
+		}
+		return nil, err
+	}
+
+	if status == models.EndorsementApproved {
+		es.changed(ctx)
+	}
+	return &endorsement, nil
+}
+
+// Withdraw removes the endorsement of skill made by the owner of token
+func (es *EndorsementService) Withdraw(ctx context.Context, skill, token string) error {
+	user, err := es.verifyEndorser(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	result, err := es.collection.DeleteOne(ctx, bson.M{"skill": normalizeSkill(skill), "endorser_id": user.ID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrEndorsementNotFound
+	}
+
+	es.changed(ctx)
+	return nil
+}
+
+// GetEndorsers lists the approved endorsers of skill, newest first
+func (es *EndorsementService) GetEndorsers(ctx context.Context, skill string) ([]models.Endorser, error) {
+	filter := bson.M{"skill": normalizeSkill(skill), "status": models.EndorsementApproved}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(endorsementListLimit)
+
+	cursor, err := es.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var endorsements []models.Endorsement
+	if err := cursor.All(ctx, &endorsements); err != nil {
+		return nil, err
+	}
+
+	endorsers := make([]models.Endorser, 0, len(endorsements))
+	for _, endorsement := range endorsements {
+		endorsers = append(endorsers, models.Endorser{
+			Login:      endorsement.Login,
+			AvatarURL:  endorsement.AvatarURL,
+			EndorsedAt: endorsement.CreatedAt,
+		})
+	}
+	return endorsers, nil
+}
+
+// AttachEndorsements sets the endorsement summary of every endorsed skill
+func (es *EndorsementService) AttachEndorsements(ctx context.Context, skills *models.Skills) error {
+	summaries, err := es.getSummaries(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, category := range skillCategories(skills) {
+		for i := range category {
+			if summary, ok := summaries[normalizeSkill(category[i].Name)]; ok {
+				summary := summary
+				category[i].Endorsements = &summary
+			}
+		}
+	}
+	return nil
+}
+
+// getSummaries counts the approved endorsements of every skill and keeps
+// the latest endorsers, cached until endorsements or content change
+func (es *EndorsementService) getSummaries(ctx context.Context) (map[string]models.EndorsementSummary, error) {
+	summaries := map[string]models.EndorsementSummary{}
+	if err := es.cacheService.Get(ctx, endorsementsCacheKey, &summaries); err == nil {
+		return summaries, nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": models.EndorsementApproved}}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$skill",
+			"count": bson.M{"$sum": 1},
+			"recent": bson.M{"$push": bson.M{
+				"login":       "$login",
+				"avatar_url":  "$avatar_url",
+				"endorsed_at": "$created_at",
+			}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"count":  1,
+			"recent": bson.M{"$slice": bson.A{"$recent", endorsementRecentLimit}},
+		}}},
+	}
+
+	cursor, err := es.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Skill                     string `bson:"_id"`
+		models.EndorsementSummary `bson:",inline"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		summaries[result.Skill] = result.EndorsementSummary
+	}
+
+	es.cacheService.Set(ctx, endorsementsCacheKey, summaries, config.AppConfig.ContentCacheTTL)
+	return summaries, nil
+}
+
+// ListEndorsements returns endorsements for moderation, newest first,
+// optionally only those with status
+func (es *EndorsementService) ListEndorsements(ctx context.Context, status string) ([]models.Endorsement, error) {
+	filter := bson.M{}
+	if status != "" {
+		if !validEndorsementStatus(status) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidEndorsementStatus, status)
+		}
+		filter["status"] = status
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(endorsementListLimit)
+
+	cursor, err := es.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	endorsements := []models.Endorsement{}
+	if err := cursor.All(ctx, &endorsements); err != nil {
+		return nil, err
+	}
+	return endorsements, nil
+}
+
+// SetStatus approves, hides or sends back to pending an endorsement
+func (es *EndorsementService) SetStatus(ctx context.Context, id, status string) error {
+	if !validEndorsementStatus(status) {
+		return fmt.Errorf("%w: %q", ErrInvalidEndorsementStatus, status)
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrEndorsementNotFound
+	}
+
+	result, err := es.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": bson.M{"status": status}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrEndorsementNotFound
+	}
+
+	es.changed(ctx)
+	return nil
+}
+
+// DeleteEndorsement removes an endorsement for good
+func (es *EndorsementService) DeleteEndorsement(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrEndorsementNotFound
+	}
+
+	result, err := es.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrEndorsementNotFound
+	}
+
+	es.changed(ctx)
+	return nil
+}
+
+// GetBlocklist returns the logins that may not endorse skills
+func (es *EndorsementService) GetBlocklist(ctx context.Context) (*models.EndorsementBlocklist, error) {
+	blocklist := models.EndorsementBlocklist{Logins: []string{}}
+	err := es.settingsService.Get(ctx, SettingBlocklist, &blocklist)
+	if err != nil && err != ErrSettingNotFound {
+		return nil, err
+	}
+	return &blocklist, nil
+}
+
+// SetBlocklist stores the blocked logins and hides the endorsements they
+// already made
+func (es *EndorsementService) SetBlocklist(ctx context.Context, blocklist models.EndorsementBlocklist, updatedBy string) error {
+	logins := []string{}
+	seen := map[string]bool{}
+	for _, login := range blocklist.Logins {
+		login = strings.ToLower(strings.TrimSpace(login))
+		if login != "" && !seen[login] {
+			seen[login] = true
+			logins = append(logins, login)
+		}
+	}
+	blocklist.Logins = logins
+
+	if err := es.settingsService.Set(ctx, SettingBlocklist, blocklist, updatedBy); err != nil {
+		return err
+	}
+	if len(logins) == 0 {
+		return nil
+	}
+
+	// GitHub logins are case-insensitive
+	collation := &options.Collation{Locale: "en", Strength: 2}
+	filter := bson.M{"login": bson.M{"$in": logins}, "status": bson.M{"$ne": models.EndorsementHidden}}
+	if IsDryRun(ctx) {
+		count, err := es.collection.CountDocuments(ctx, filter, options.Count().SetCollation(collation))
+		if err != nil {
+			return err
+		}
+		AddDryRunEffects(ctx, []string{endorsementsCacheKey}, []string{
+			fmt.Sprintf("hide %d endorsements", count),
+			"CDN purge: " + strings.Join(ContentSurrogateKeys("skills"), ", "),
+		})
+		return nil
+	}
+
+	_, err := es.collection.UpdateMany(ctx, filter,
+		bson.M{"$set": bson.M{"status": models.EndorsementHidden}},
+		options.Update().SetCollation(collation))
+	if err != nil {
+		return err
+	}
+
+	es.changed(ctx)
+	return nil
+}
+
+func (es *EndorsementService) isBlocked(ctx context.Context, login string) bool {
+	blocklist, err := es.GetBlocklist(ctx)
+	if err != nil {
+		return false
+	}
+	for _, blocked := range blocklist.Logins {
+		if strings.EqualFold(blocked, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// skillKey matches skill against the portfolio's skills, so only listed
+// skills can be endorsed
+func (es *EndorsementService) skillKey(ctx context.Context, skill string) (string, error) {
+	skills, err := es.contentService.GetSkills(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	key := normalizeSkill(skill)
+	for _, category := range skillCategories(skills) {
+		for _, listed := range category {
+			if normalizeSkill(listed.Name) == key {
+				return key, nil
+			}
+		}
+	}
+	return "", ErrSkillNotFound
+}
+
+// changed drops the cached summaries and refreshes the skills on the CDNs
+func (es *EndorsementService) changed(ctx context.Context) {
+	es.cacheService.Delete(ctx, endorsementsCacheKey)
+	es.cdnPurge.SchedulePurge("skills")
+}
+
+func normalizeSkill(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func skillCategories(skills *models.Skills) [][]models.Skill {
+	return [][]models.Skill{skills.Backend, skills.Frontend, skills.Database, skills.DevOps, skills.Tools, skills.Languages}
+}
+
+func validEndorsementStatus(status string) bool {
+	switch status {
+	case models.EndorsementApproved, models.EndorsementPending, models.EndorsementHidden:
+		return true
+	}
+	return false
+}
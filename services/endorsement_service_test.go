@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEndorsementService(t *testing.T, cfg *config.Config, transport roundTripFunc) *EndorsementService {
+	t.Helper()
+	useOfflineDatabase(t, cfg)
+
+	service := NewEndorsementService()
+	service.client = &http.Client{Transport: transport}
+	return service
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestAuthorizeURL(t *testing.T) {
+	service := newTestEndorsementService(t, &config.Config{}, nil)
+
+	_, _, err := service.AuthorizeURL("")
+	assert.ErrorIs(t, err, ErrEndorsementsDisabled)
+
+	config.AppConfig.GitHubOAuthClientID = "client"
+	authorizeURL, state, err := service.AuthorizeURL("https://portfolio.dev/endorse")
+	require.NoError(t, err)
+	assert.Len(t, state, 32)
+
+	parsed, err := url.Parse(authorizeURL)
+	require.NoError(t, err)
+	assert.Equal(t, "github.com", parsed.Host)
+	assert.Equal(t, "client", parsed.Query().Get("client_id"))
+	assert.Equal(t, state, parsed.Query().Get("state"))
+	assert.Equal(t, "https://portfolio.dev/endorse", parsed.Query().Get("redirect_uri"))
+	assert.Empty(t, parsed.Query().Get("scope"))
+}
+
+func TestExchangeCodeVerifiesEndorser(t *testing.T) {
+	cfg := &config.Config{GitHubOAuthClientID: "client", GitHubOAuthClientSecret: "secret"}
+	service := newTestEndorsementService(t, cfg, func(req *http.Request) (*http.Response, error) {
+		switch req.URL.String() {
+		case "https://github.com/login/oauth/access_token":
+			body, _ := io.ReadAll(req.Body)
+			form, _ := url.ParseQuery(string(body))
+			assert.Equal(t, "secret", form.Get("client_secret"))
+			if form.Get("code") != "good" {
+				return jsonResponse(http.StatusOK, `{"error":"bad_verification_code","error_description":"The code passed is incorrect or expired."}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"access_token":"gho_token","token_type":"bearer","scope":""}`), nil
+		case "https://api.github.com/user":
+			if req.Header.Get("Authorization") != "Bearer gho_token" {
+				return jsonResponse(http.StatusUnauthorized, `{"message":"Bad credentials"}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"id":42,"login":"Octocat","avatar_url":"https://avatars.githubusercontent.com/u/42"}`), nil
+		}
+		t.Fatalf("unexpected request to %s", req.URL)
+		return nil, nil
+	})
+
+	session, err := service.ExchangeCode(context.Background(), models.GitHubOAuthRequest{Code: "good"})
+	require.NoError(t, err)
+	assert.Equal(t, "gho_token", session.AccessToken)
+	assert.Equal(t, "Octocat", session.Login)
+
+	_, err = service.ExchangeCode(context.Background(), models.GitHubOAuthRequest{Code: "stale"})
+	assert.ErrorIs(t, err, ErrInvalidOAuthCode)
+
+	_, err = service.verifyEndorser(context.Background(), "forged")
+	assert.ErrorIs(t, err, ErrInvalidGitHubToken)
+	_, err = service.verifyEndorser(context.Background(), "")
+	assert.ErrorIs(t, err, ErrInvalidGitHubToken)
+}
+
+func TestEndorseRequiresOAuthApp(t *testing.T) {
+	service := newTestEndorsementService(t, &config.Config{}, nil)
+
+	_, err := service.Endorse(context.Background(), "Go", "gho_token")
+	assert.ErrorIs(t, err, ErrEndorsementsDisabled)
+}
+
+func TestEndorsementStatuses(t *testing.T) {
+	for _, status := range []string{models.EndorsementApproved, models.EndorsementPending, models.EndorsementHidden} {
+		assert.True(t, validEndorsementStatus(status), status)
+	}
+	assert.False(t, validEndorsementStatus("deleted"))
+	assert.Equal(t, "node.js", normalizeSkill("  Node.js "))
+}
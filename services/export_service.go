@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrUnknownExportFormat = errors.New("unknown export format")
+
+// exportCollection describes how a collection is exported
+type exportCollection struct {
+	timeField string   // field filtered by since
+	omit      []string // fields never exported
+}
+
+// exportCollections lists the collections that can be exported. Settings
+// hold credentials and the cache collections only hold encoded payloads, so
+// they are left out.
+var exportCollections = map[string]exportCollection{
+	"content":          {timeField: "updated_at"},
+	"github_data":      {timeField: "last_fetched"},
+	"deploy_hook_runs": {timeField: "triggered_at"},
+	"cdn_purge_runs":   {timeField: "purged_at"},
+	"digest_snapshots": {timeField: "taken_at"},
+	"recruiter_tokens": {timeField: "created_at", omit: []string{"token_hash"}},
+	"resume_links":     {timeField: "created_at"},
+	"resume_downloads": {timeField: "downloaded_at"},
+	rawAuditCollection: {timeField: "edited_at"},
+	auditCollection:    {timeField: "created_at"},
+}
+
+// ExportContentTypes maps export formats to the content type they are sent as
+var ExportContentTypes = map[string]string{
+	models.ExportFormatJSON:   "application/json",
+	models.ExportFormatNDJSON: "application/x-ndjson",
+	models.ExportFormatCSV:    "text/csv",
+}
+
+type ExportService struct{}
+
+func NewExportService() *ExportService {
+	return &ExportService{}
+}
+
+// Stream calls fn for each document of collection selected by query, in _id
+// order, reading from a MongoDB cursor. An interrupted export is resumed by
+// passing the _id of the last document received as the cursor.
+func (es *ExportService) Stream(ctx context.Context, collection string, query models.ExportQuery, fn func(doc bson.D) error) error {
+	policy, ok := exportCollections[collection]
+	if !ok {
+		return ErrUnknownCollection
+	}
+
+	filter := bson.M{}
+	if !query.Since.IsZero() {
+		filter[policy.timeField] = bson.M{"$gte": query.Since}
+	}
+	if query.Cursor != "" {
+		filter["_id"] = bson.M{"$gt": parseRawID(query.Cursor)}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetBatchSize(200)
+	if query.Limit > 0 {
+		opts.SetLimit(query.Limit)
+	}
+	if len(policy.omit) > 0 {
+		projection := bson.M{}
+		for _, field := range policy.omit {
+			projection[field] = 0
+		}
+		opts.SetProjection(projection)
+	}
+
+	cursor, err := database.Database.Collection(collection).Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc bson.D
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// ExportWriter writes exported documents in one of the export formats
+type ExportWriter interface {
+	Write(doc bson.D) error
+	// Close completes the output; the underlying writer is left open
+	Close() error
+}
+
+// NewExportWriter returns a writer for format. Documents are written as
+// relaxed extended JSON, so ObjectIDs and dates stay recognizable.
+func NewExportWriter(format string, w io.Writer) (ExportWriter, error) {
+	switch format {
+	case models.ExportFormatJSON:
+		return &jsonExportWriter{w: w}, nil
+	case models.ExportFormatNDJSON:
+		return &ndjsonExportWriter{w: w}, nil
+	case models.ExportFormatCSV:
+		return &csvExportWriter{w: csv.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownExportFormat, format)
+}
+
+// jsonExportWriter writes a single JSON array
+type jsonExportWriter struct {
+	w       io.Writer
+	written int
+}
+
+func (jw *jsonExportWriter) Write(doc bson.D) error {
+	data, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return err
+	}
+
+	separator := ","
+	if jw.written == 0 {
+		separator = "["
+	}
+	jw.written++
+
+	if _, err := io.WriteString(jw.w, separator); err != nil {
+		return err
+	}
+	_, err = jw.w.Write(data)
+	return err
+}
+
+func (jw *jsonExportWriter) Close() error {
+	end := "]\n"
+	if jw.written == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(jw.w, end)
+	return err
+}
+
+// ndjsonExportWriter writes one document per line
+type ndjsonExportWriter struct {
+	w io.Writer
+}
+
+func (nw *ndjsonExportWriter) Write(doc bson.D) error {
+	data, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return err
+	}
+	_, err = nw.w.Write(append(data, '\n'))
+	return err
+}
+
+func (nw *ndjsonExportWriter) Close() error {
+	return nil
+}
+
+// csvExportWriter writes one row per document. The columns are the fields
+// of the first document; fields other documents add are left out, and
+// embedded documents and arrays are written as extended JSON.
+type csvExportWriter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func (cw *csvExportWriter) Write(doc bson.D) error {
+	if cw.columns == nil {
+		for _, field := range doc {
+			cw.columns = append(cw.columns, field.Key)
+		}
+		if err := cw.w.Write(cw.columns); err != nil {
+			return err
+		}
+	}
+
+	values := doc.Map()
+	row := make([]string, len(cw.columns))
+	for i, column := range cw.columns {
+		cell, err := csvCell(values[column])
+		if err != nil {
+			return err
+		}
+		row[i] = cell
+	}
+	return cw.w.Write(row)
+}
+
+func (cw *csvExportWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func csvCell(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case primitive.ObjectID:
+		return v.Hex(), nil
+	case primitive.DateTime:
+		return v.Time().UTC().Format(time.RFC3339Nano), nil
+	}
+
+	// Anything else is written as its extended JSON value
+	data, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: value}}, false, false)
+	if err != nil {
+		return "", err
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return "", err
+	}
+	return string(wrapper["v"]), nil
+}
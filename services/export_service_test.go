@@ -0,0 +1,76 @@
+package services
+
+import (
+	"bytes"
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func exportDocs() []bson.D {
+	id, _ := primitive.ObjectIDFromHex("64b7f0c2a1b2c3d4e5f60718")
+	downloadedAt := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	return []bson.D{
+		{
+			{Key: "_id", Value: id},
+			{Key: "recipient", Value: "Acme, Inc."},
+			{Key: "downloads", Value: int32(3)},
+			{Key: "downloaded_at", Value: primitive.NewDateTimeFromTime(downloadedAt)},
+			{Key: "tags", Value: bson.A{"go", "remote"}},
+		},
+		{
+			{Key: "_id", Value: "second"},
+			{Key: "recipient", Value: "Globex"},
+			{Key: "extra", Value: true},
+		},
+	}
+}
+
+func writeExport(t *testing.T, format string, docs []bson.D) string {
+	var out bytes.Buffer
+	writer, err := NewExportWriter(format, &out)
+	require.NoError(t, err)
+	for _, doc := range docs {
+		require.NoError(t, writer.Write(doc))
+	}
+	require.NoError(t, writer.Close())
+	return out.String()
+}
+
+func TestExportWriterJSON(t *testing.T) {
+	assert.JSONEq(t, `[
+		{"_id": {"$oid": "64b7f0c2a1b2c3d4e5f60718"}, "recipient": "Acme, Inc.", "downloads": 3,
+		 "downloaded_at": {"$date": "2026-03-01T12:30:00Z"}, "tags": ["go", "remote"]},
+		{"_id": "second", "recipient": "Globex", "extra": true}
+	]`, writeExport(t, models.ExportFormatJSON, exportDocs()))
+
+	assert.Equal(t, "[]\n", writeExport(t, models.ExportFormatJSON, nil))
+}
+
+func TestExportWriterNDJSON(t *testing.T) {
+	out := writeExport(t, models.ExportFormatNDJSON, exportDocs())
+
+	assert.Equal(t, `{"_id":{"$oid":"64b7f0c2a1b2c3d4e5f60718"},"recipient":"Acme, Inc.","downloads":3,"downloaded_at":{"$date":"2026-03-01T12:30:00Z"},"tags":["go","remote"]}
+{"_id":"second","recipient":"Globex","extra":true}
+`, out)
+}
+
+func TestExportWriterCSV(t *testing.T) {
+	out := writeExport(t, models.ExportFormatCSV, exportDocs())
+
+	// Columns come from the first document
+	assert.Equal(t, `_id,recipient,downloads,downloaded_at,tags
+64b7f0c2a1b2c3d4e5f60718,"Acme, Inc.",3,2026-03-01T12:30:00Z,"[""go"",""remote""]"
+second,Globex,,,
+`, out)
+}
+
+func TestExportWriterUnknownFormat(t *testing.T) {
+	_, err := NewExportWriter("xml", &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrUnknownExportFormat)
+}
@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Formats the feed is served in
+const (
+	FeedRSS  = "rss"
+	FeedAtom = "atom"
+)
+
+// FeedContentTypes are the content types of the feed formats
+var FeedContentTypes = map[string]string{
+	FeedRSS:  "application/rss+xml; charset=utf-8",
+	FeedAtom: "application/atom+xml; charset=utf-8",
+}
+
+// feedItems is how many entries the feed holds, newest first
+const feedItems = 30
+
+// feedEntry is an update of the portfolio: a project, a changelog entry or
+// a published version of another content type
+type feedEntry struct {
+	ID       string
+	Title    string
+	Link     string
+	Summary  string
+	Category string
+	Updated  time.Time
+}
+
+// GetFeed returns the recently updated projects, the changelog and the
+// published content changes as an RSS 2.0 or Atom feed, titled and linked
+// after the meta content
+func (cs *ContentService) GetFeed(ctx context.Context, format string) ([]byte, error) {
+	meta, err := cs.GetMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := cs.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changelog, err := cs.GetChangelog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Projects and the changelog have entries of their own
+	filter := bson.M{"type": bson.M{"$nin": bson.A{"projects", "changelog"}}}
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}}).SetLimit(feedItems)
+	cursor, err := cs.versions.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var versions []contentVersion
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+
+	entries := feedEntries(*meta, projects, changelog, versions)
+	if format == FeedAtom {
+		return renderAtomFeed(*meta, entries, time.Now())
+	}
+	return renderRSSFeed(*meta, entries, time.Now())
+}
+
+// feedEntries merges the updates into entries, newest first. Each update of
+// a project is a new entry, so followers see projects as they change.
+func feedEntries(meta models.Meta, projects []models.Project, changelog []models.ChangelogEntry, versions []contentVersion) []feedEntry {
+	home := feedHome(meta)
+	var entries []feedEntry
+
+	for _, project := range projects {
+		updated := project.UpdatedAt
+		if updated.IsZero() {
+			updated = project.StartDate
+		}
+		if updated.IsZero() {
+			continue
+		}
+		link := home
+		for _, url := range []string{project.LiveURL, project.GitHubURL, project.DemoURL} {
+			if url != "" {
+				link = url
+				break
+			}
+		}
+		id := project.ID.Hex()
+		if project.ID.IsZero() {
+			id = utils.SlugifyString(project.Name)
+		}
+		entries = append(entries, feedEntry{
+			ID:       fmt.Sprintf("project:%s:%d", id, updated.Unix()),
+			Title:    project.Name,
+			Link:     link,
+			Summary:  project.Description,
+			Category: "Projects",
+			Updated:  updated,
+		})
+	}
+
+	for _, entry := range changelog {
+		if entry.Date.IsZero() {
+			continue
+		}
+		link := entry.URL
+		if link == "" {
+			link = home
+		}
+		entries = append(entries, feedEntry{
+			ID:       fmt.Sprintf("changelog:%d:%s", entry.Date.Unix(), utils.SlugifyString(entry.Title)),
+			Title:    entry.Title,
+			Link:     link,
+			Summary:  entry.Body,
+			Category: "Changelog",
+			Updated:  entry.Date,
+		})
+	}
+
+	for _, version := range versions {
+		if version.Type == "" || version.UpdatedAt.IsZero() {
+			continue
+		}
+		label := strings.ToUpper(version.Type[:1]) + version.Type[1:]
+		entries = append(entries, feedEntry{
+			ID:       fmt.Sprintf("content:%s:%d", version.Type, version.Version),
+			Title:    label + " updated",
+			Link:     home,
+			Summary:  fmt.Sprintf("Version %d of %s was published.", version.Version, version.Type),
+			Category: label,
+			Updated:  version.UpdatedAt,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Updated.After(entries[j].Updated)
+	})
+	if len(entries) > feedItems {
+		entries = entries[:feedItems]
+	}
+	return entries
+}
+
+// feedHome is the page the feed and its entries without a link point at
+func feedHome(meta models.Meta) string {
+	if meta.Website != "" {
+		return meta.Website
+	}
+	if meta.GitHub != "" {
+		return "https://github.com/" + meta.GitHub
+	}
+	return ""
+}
+
+// feedTitle names the feed after the owner and their title
+func feedTitle(meta models.Meta) string {
+	if meta.Title == "" {
+		return meta.Name
+	}
+	return meta.Name + " - " + meta.Title
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	Description string  `xml:"description,omitempty"`
+	Category    string  `xml:"category,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+func renderRSSFeed(meta models.Meta, entries []feedEntry, now time.Time) ([]byte, error) {
+	description := meta.Bio
+	if description == "" {
+		description = feedTitle(meta)
+	}
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         feedTitle(meta),
+			Link:          feedHome(meta),
+			Description:   description,
+			LastBuildDate: feedUpdated(entries, now).Format(time.RFC1123Z),
+			Items:         []rssItem{},
+		},
+	}
+	for _, entry := range entries {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       entry.Title,
+			Link:        entry.Link,
+			Description: entry.Summary,
+			Category:    entry.Category,
+			GUID:        rssGUID{Value: entry.ID},
+			PubDate:     entry.Updated.Format(time.RFC1123Z),
+		})
+	}
+	return marshalFeed(feed)
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Author  atomAuthor  `xml:"author"`
+	Link    *atomLink   `xml:"link,omitempty"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID       string        `xml:"id"`
+	Title    string        `xml:"title"`
+	Link     *atomLink     `xml:"link,omitempty"`
+	Summary  string        `xml:"summary,omitempty"`
+	Category *atomCategory `xml:"category,omitempty"`
+	Updated  string        `xml:"updated"`
+}
+
+// renderAtomFeed writes an Atom feed. IDs must be URIs: the feed's is the
+// owner's home page and its entries' are tag URIs under the same host.
+func renderAtomFeed(meta models.Meta, entries []feedEntry, now time.Time) ([]byte, error) {
+	home := feedHome(meta)
+	authority := "portfolio"
+	if _, rest, ok := strings.Cut(home, "://"); ok {
+		authority, _, _ = strings.Cut(rest, "/")
+	}
+
+	feed := atomFeed{
+		ID:      home,
+		Title:   feedTitle(meta),
+		Author:  atomAuthor{Name: meta.Name},
+		Updated: feedUpdated(entries, now).Format(time.RFC3339),
+		Entries: []atomEntry{},
+	}
+	if home != "" {
+		feed.Link = &atomLink{Href: home}
+	} else {
+		feed.ID = "urn:portfolio:feed"
+	}
+	for _, entry := range entries {
+		atom := atomEntry{
+			ID:      "tag:" + authority + "," + entry.Updated.UTC().Format("2006-01-02") + ":" + entry.ID,
+			Title:   entry.Title,
+			Summary: entry.Summary,
+			Updated: entry.Updated.Format(time.RFC3339),
+		}
+		if entry.Link != "" {
+			atom.Link = &atomLink{Href: entry.Link}
+		}
+		if entry.Category != "" {
+			atom.Category = &atomCategory{Term: entry.Category}
+		}
+		feed.Entries = append(feed.Entries, atom)
+	}
+	return marshalFeed(feed)
+}
+
+// feedUpdated is when the newest entry was updated, or now for an empty feed
+func feedUpdated(entries []feedEntry, now time.Time) time.Time {
+	if len(entries) == 0 {
+		return now
+	}
+	return entries[0].Updated
+}
+
+func marshalFeed(feed interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
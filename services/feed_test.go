@@ -0,0 +1,69 @@
+package services
+
+import (
+	"encoding/xml"
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var feedMeta = models.Meta{Name: "Felipe Macedo", Title: "Backend Engineer", Website: "https://felipe.dev", Bio: "Go & cloud"}
+
+func TestFeedEntries(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC) }
+	id := primitive.NewObjectID()
+	entries := feedEntries(feedMeta,
+		[]models.Project{
+			{ID: id, Name: "API", GitHubURL: "https://github.com/felipe/api", UpdatedAt: day(3)},
+			{Name: "Draft idea"},
+		},
+		[]models.ChangelogEntry{{Title: "api v1.2.0", Date: day(5), URL: "https://github.com/felipe/api/releases/v1.2.0"}},
+		[]contentVersion{{Type: "skills", Version: 4, UpdatedAt: day(4)}},
+	)
+
+	require.Len(t, entries, 3, "projects without dates are left out")
+	assert.Equal(t, "api v1.2.0", entries[0].Title)
+	assert.Equal(t, "Skills updated", entries[1].Title)
+	assert.Equal(t, "https://felipe.dev", entries[1].Link)
+	assert.Equal(t, "content:skills:4", entries[1].ID)
+	assert.Equal(t, "https://github.com/felipe/api", entries[2].Link)
+	assert.Equal(t, "project:"+id.Hex()+":"+"1741003200", entries[2].ID)
+}
+
+func TestRenderRSSFeed(t *testing.T) {
+	entries := []feedEntry{{ID: "content:meta:2", Title: "Meta updated", Link: "https://felipe.dev", Updated: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)}}
+	body, err := renderRSSFeed(feedMeta, entries, time.Now())
+	require.NoError(t, err)
+
+	var feed rssFeed
+	require.NoError(t, xml.Unmarshal(body, &feed))
+	assert.Equal(t, "2.0", feed.Version)
+	assert.Equal(t, "Felipe Macedo - Backend Engineer", feed.Channel.Title)
+	assert.Equal(t, "Go & cloud", feed.Channel.Description)
+	require.Len(t, feed.Channel.Items, 1)
+	assert.Equal(t, "Tue, 04 Mar 2025 00:00:00 +0000", feed.Channel.Items[0].PubDate)
+	assert.Equal(t, feed.Channel.Items[0].PubDate, feed.Channel.LastBuildDate)
+	assert.Contains(t, string(body), `<guid isPermaLink="false">content:meta:2</guid>`)
+}
+
+func TestRenderAtomFeed(t *testing.T) {
+	entries := []feedEntry{{ID: "project:api:1", Title: "API", Category: "Projects", Updated: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)}}
+	body, err := renderAtomFeed(feedMeta, entries, time.Now())
+	require.NoError(t, err)
+
+	var feed atomFeed
+	require.NoError(t, xml.Unmarshal(body, &feed))
+	assert.Equal(t, "https://felipe.dev", feed.ID)
+	assert.Equal(t, "2025-03-04T00:00:00Z", feed.Updated)
+	require.Len(t, feed.Entries, 1)
+	assert.Equal(t, "tag:felipe.dev,2025-03-04:project:api:1", feed.Entries[0].ID)
+	assert.Nil(t, feed.Entries[0].Link)
+
+	empty, err := renderAtomFeed(models.Meta{Name: "Felipe"}, nil, time.Now())
+	require.NoError(t, err)
+	assert.Contains(t, string(empty), "<id>urn:portfolio:feed</id>")
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// feedbackMaxComment caps comments, in characters
+	feedbackMaxComment = 500
+	// feedbackRecentComments is how many comments each summary lists
+	feedbackRecentComments = 10
+)
+
+// feedbackLinkPattern finds links in comments; more than one is spam
+var feedbackLinkPattern = regexp.MustCompile(`(?i)(https?://|www\.)`)
+
+var (
+	// ErrProjectNotFound is returned for projects the portfolio does not list
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrFeedbackDisabled is returned for projects not open to feedback
+	ErrFeedbackDisabled = errors.New("feedback is not enabled for this project")
+	// ErrInvalidFeedback is returned for unknown ratings and long comments
+	ErrInvalidFeedback = errors.New("invalid feedback")
+	// ErrFeedbackNotFound is returned when deleting unknown feedback
+	ErrFeedbackNotFound = errors.New("feedback not found")
+)
+
+type FeedbackService struct {
+	collection     *mongo.Collection
+	contentService *ContentService
+}
+
+func NewFeedbackService() *FeedbackService {
+	return &FeedbackService{
+		collection:     database.Database.Collection("project_feedback"),
+		contentService: NewContentService(),
+	}
+}
+
+// Submit records a visitor's feedback on the project with slug. A visitor
+// has one feedback per project; submitting again replaces it. Spam is
+// stored flagged rather than rejected, so bots are not told apart.
+func (fs *FeedbackService) Submit(ctx context.Context, slug, clientIP string, request models.ProjectFeedbackRequest) (*models.ProjectFeedback, error) {
+	score, ok := models.FeedbackRatings[request.Rating]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown rating %q", ErrInvalidFeedback, request.Rating)
+	}
+	comment := strings.TrimSpace(utils.SanitizeString(request.Comment))
+	if utf8.RuneCountInString(comment) > feedbackMaxComment {
+		return nil, fmt.Errorf("%w: comment is longer than %d characters", ErrInvalidFeedback, feedbackMaxComment)
+	}
+
+	if err := fs.checkProject(ctx, slug); err != nil {
+		return nil, err
+	}
+
+	feedback := models.ProjectFeedback{
+		Project:   slug,
+		Rating:    request.Rating,
+		Score:     score,
+		Comment:   comment,
+		Visitor:   HashVisitor(clientIP),
+		Spam:      request.Website != "" || isFeedbackSpam(comment),
+		CreatedAt: time.Now(),
+	}
+
+	opts := options.FindOneAndReplace().SetUpsert(true).SetReturnDocument(options.After)
+	err := fs.collection.FindOneAndReplace(ctx, bson.M{"project": slug, "visitor": feedback.Visitor}, feedback, opts).Decode(&feedback)
+	if err != nil {
+		return nil, err
+	}
+	return &feedback, nil
+}
+
+// checkProject makes sure slug names a project open to feedback
+func (fs *FeedbackService) checkProject(ctx context.Context, slug string) error {
+	projects, err := fs.contentService.GetProjects(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		if utils.SlugifyString(project.Name) == slug {
+			if !project.FeedbackEnabled {
+				return ErrFeedbackDisabled
+			}
+			return nil
+		}
+	}
+	return ErrProjectNotFound
+}
+
+// isFeedbackSpam flags comments with several links or blocked words
+func isFeedbackSpam(comment string) bool {
+	if len(feedbackLinkPattern.FindAllStringIndex(comment, 2)) > 1 {
+		return true
+	}
+
+	lower := strings.ToLower(comment)
+	for _, word := range strings.Split(config.AppConfig.FeedbackBlockedWords, ",") {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" && strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSummaries aggregates the feedback received since the given time, per
+// project, busiest first. An empty slug covers every project.
+func (fs *FeedbackService) GetSummaries(ctx context.Context, slug string, since time.Time) ([]models.ProjectFeedbackSummary, error) {
+	match := bson.M{"spam": false, "created_at": bson.M{"$gte": since}}
+	if slug != "" {
+		match["project"] = slug
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$facet", Value: bson.M{
+			"ratings": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":   bson.M{"project": "$project", "rating": "$rating"},
+					"count": bson.M{"$sum": 1},
+					"score": bson.M{"$sum": "$score"},
+				}},
+			},
+			"comments": bson.A{
+				bson.M{"$match": bson.M{"comment": bson.M{"$exists": true, "$ne": ""}}},
+				bson.M{"$sort": bson.D{{Key: "created_at", Value: -1}}},
+				bson.M{"$group": bson.M{"_id": "$project", "comments": bson.M{"$push": "$$ROOT"}}},
+				bson.M{"$project": bson.M{"comments": bson.M{"$slice": bson.A{"$comments", feedbackRecentComments}}}},
+			},
+		}}},
+	}
+
+	cursor, err := fs.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Ratings []struct {
+			Key struct {
+				Project string `bson:"project"`
+				Rating  string `bson:"rating"`
+			} `bson:"_id"`
+			Count int `bson:"count"`
+			Score int `bson:"score"`
+		} `bson:"ratings"`
+		Comments []struct {
+			Project  string                   `bson:"_id"`
+			Comments []models.ProjectFeedback `bson:"comments"`
+		} `bson:"comments"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	summaries := []models.ProjectFeedbackSummary{}
+	if len(results) == 0 {
+		return summaries, nil
+	}
+
+	byProject := map[string]*models.ProjectFeedbackSummary{}
+	scores := map[string]int{}
+	for _, rating := range results[0].Ratings {
+		summary, ok := byProject[rating.Key.Project]
+		if !ok {
+			summary = &models.ProjectFeedbackSummary{
+				Project:        rating.Key.Project,
+				Ratings:        map[string]int{},
+				RecentComments: []models.ProjectFeedback{},
+			}
+			byProject[rating.Key.Project] = summary
+		}
+		summary.Count += rating.Count
+		summary.Ratings[rating.Key.Rating] = rating.Count
+		scores[rating.Key.Project] += rating.Score
+	}
+	for _, comments := range results[0].Comments {
+		if summary, ok := byProject[comments.Project]; ok {
+			summary.RecentComments = comments.Comments
+		}
+	}
+
+	for project, summary := range byProject {
+		summary.AverageScore = float64(scores[project]) / float64(summary.Count)
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		return summaries[i].Project < summaries[j].Project
+	})
+	return summaries, nil
+}
+
+// DeleteFeedback removes one piece of feedback
+func (fs *FeedbackService) DeleteFeedback(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrFeedbackNotFound
+	}
+
+	result, err := fs.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrFeedbackNotFound
+	}
+	return nil
+}
+
+// DeleteVisitorFeedback removes all feedback sent from clientIP, for
+// privacy requests, returning how much was deleted
+func (fs *FeedbackService) DeleteVisitorFeedback(ctx context.Context, clientIP string) (int64, error) {
+	result, err := fs.collection.DeleteMany(ctx, bson.M{"visitor": HashVisitor(clientIP)})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
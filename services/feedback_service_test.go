@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsFeedbackSpam(t *testing.T) {
+	config.AppConfig = &config.Config{FeedbackBlockedWords: "casino, SEO services"}
+
+	assert.False(t, isFeedbackSpam("Great project, the docs at https://example.com helped a lot"))
+	assert.True(t, isFeedbackSpam("visit https://a.example and www.b.example"))
+	assert.True(t, isFeedbackSpam("Cheap seo services here"))
+	assert.True(t, isFeedbackSpam("best CASINO bonus"))
+	assert.False(t, isFeedbackSpam(""))
+}
+
+func TestSubmitFeedbackValidation(t *testing.T) {
+	useOfflineDatabase(t, &config.Config{CacheSerialization: "json"})
+	service := NewFeedbackService()
+
+	_, err := service.Submit(context.Background(), "portfolio", "203.0.113.7", models.ProjectFeedbackRequest{Rating: "👍"})
+	assert.ErrorIs(t, err, ErrInvalidFeedback)
+
+	_, err = service.Submit(context.Background(), "portfolio", "203.0.113.7", models.ProjectFeedbackRequest{
+		Rating:  "😍",
+		Comment: strings.Repeat("é", feedbackMaxComment+1),
+	})
+	assert.ErrorIs(t, err, ErrInvalidFeedback)
+}
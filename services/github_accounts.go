@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"portfolio-backend/models"
+	"time"
+)
+
+// GetPortfolioRepositories merges the repositories of several accounts, such
+// as a personal account and an organization. Repositories listed under more
+// than one account are kept once, under the first account listing them.
+func (gs *GitHubService) GetPortfolioRepositories(ctx context.Context, accounts []string) (*models.PortfolioRepositories, error) {
+	listings := make([][]models.GitHubRepository, len(accounts))
+	for i, account := range accounts {
+		repos, err := gs.GetRepositories(ctx, account)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", account, err)
+		}
+		listings[i] = repos
+	}
+
+	return mergePortfolioRepositories(accounts, listings), nil
+}
+
+// GetPortfolioStats aggregates stats over the merged repositories of accounts
+// and sums their contributions, with a per-account breakdown
+func (gs *GitHubService) GetPortfolioStats(ctx context.Context, accounts []string) (*models.PortfolioStats, error) {
+	portfolio, err := gs.GetPortfolioRepositories(ctx, accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := buildStats("", portfolio.Repositories)
+	stats := &models.PortfolioStats{
+		Accounts:          accounts,
+		TotalRepos:        merged.TotalRepos,
+		TotalStars:        merged.TotalStars,
+		TotalForks:        merged.TotalForks,
+		DuplicateRepos:    portfolio.DuplicateRepos,
+		MostUsedLanguages: merged.MostUsedLanguages,
+		TopRepositories:   merged.TopRepositories,
+		Breakdown:         portfolio.Breakdown,
+		LastFetched:       time.Now(),
+	}
+
+	// GitHub credits each contribution to a single author, so accounts never
+	// double count and the totals can simply be added up
+	if FeatureEnabled(FeatureContributions) {
+		for i := range stats.Breakdown {
+			account := &stats.Breakdown[i]
+			contributions, err := gs.GetContributions(ctx, account.Username)
+			if err != nil {
+				log.Printf("Contributions of %s unavailable: %v", account.Username, err)
+				continue
+			}
+			account.Contributions = contributions.TotalContributions
+			stats.TotalContributions += contributions.TotalContributions
+		}
+	}
+
+	return stats, nil
+}
+
+// mergePortfolioRepositories merges the listing of each account, in order,
+// dropping repositories already listed under an earlier account
+func mergePortfolioRepositories(accounts []string, listings [][]models.GitHubRepository) *models.PortfolioRepositories {
+	portfolio := &models.PortfolioRepositories{
+		Accounts:     accounts,
+		Repositories: []models.GitHubRepository{},
+	}
+
+	seen := make(map[int64]bool)
+	for i, account := range accounts {
+		breakdown := models.AccountBreakdown{Username: account, Repos: len(listings[i])}
+
+		for _, repo := range listings[i] {
+			if !repo.Fork && !repo.Private {
+				breakdown.Stars += repo.StargazersCount
+				breakdown.Forks += repo.ForksCount
+			}
+
+			if seen[repo.GitHubID] {
+				portfolio.DuplicateRepos++
+				continue
+			}
+			seen[repo.GitHubID] = true
+			portfolio.Repositories = append(portfolio.Repositories, repo)
+		}
+
+		portfolio.Breakdown = append(portfolio.Breakdown, breakdown)
+	}
+
+	return portfolio
+}
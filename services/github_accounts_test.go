@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePortfolioRepositories(t *testing.T) {
+	config.AppConfig = &config.Config{}
+	shared := models.GitHubRepository{GitHubID: 3, Name: "shared", Owner: "acme", StargazersCount: 10}
+
+	personal := []models.GitHubRepository{
+		{GitHubID: 1, Name: "dotfiles", Owner: "octocat", StargazersCount: 2, ForksCount: 1},
+		{GitHubID: 2, Name: "linguist", Owner: "octocat", StargazersCount: 50, Fork: true},
+		shared,
+	}
+	org := []models.GitHubRepository{
+		shared,
+		{GitHubID: 4, Name: "api", Owner: "acme", StargazersCount: 5, ForksCount: 2},
+	}
+
+	portfolio := mergePortfolioRepositories([]string{"octocat", "acme"}, [][]models.GitHubRepository{personal, org})
+
+	assert.Len(t, portfolio.Repositories, 4)
+	assert.Equal(t, 1, portfolio.DuplicateRepos)
+	assert.Equal(t, []models.AccountBreakdown{
+		{Username: "octocat", Repos: 3, Stars: 12, Forks: 1},
+		{Username: "acme", Repos: 2, Stars: 15, Forks: 2},
+	}, portfolio.Breakdown)
+
+	// The merged stats count the shared repository once and skip forks
+	stats := buildStats("", portfolio.Repositories)
+	assert.Equal(t, 4, stats.TotalRepos)
+	assert.Equal(t, 17, stats.TotalStars)
+	assert.Equal(t, 3, stats.TotalForks)
+}
+
+func TestGitHubUserAllowed(t *testing.T) {
+	config.AppConfig = &config.Config{GitHubUsername: "default"}
+
+	// The site's owner is used without reading settings from MongoDB
+	ctx := WithSite(context.Background(), &models.SiteDomain{
+		Domain: "alice.dev",
+		Owner:  models.OwnerSettings{GitHubUsername: "alice", Accounts: []string{"alice-org"}},
+	})
+	ss := &SettingsService{}
+
+	assert.True(t, ss.GitHubUserAllowed(ctx, "alice"))
+	assert.True(t, ss.GitHubUserAllowed(ctx, "Alice-Org"))
+	assert.False(t, ss.GitHubUserAllowed(ctx, "bob"))
+	assert.False(t, ss.GitHubUserAllowed(ctx, ""))
+
+	config.AppConfig.GitHubAllowedUsers = " Bob , carol"
+	assert.True(t, ss.GitHubUserAllowed(ctx, "bob"))
+	assert.False(t, ss.GitHubUserAllowed(ctx, "dave"))
+
+	config.AppConfig.GitHubAllowedUsers = "*"
+	assert.True(t, ss.GitHubUserAllowed(ctx, "dave"))
+}
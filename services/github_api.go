@@ -0,0 +1,51 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"portfolio-backend/config"
+	"strings"
+)
+
+const defaultGitHubAPIURL = "https://api.github.com"
+
+// githubAPIBase is the root of the GitHub REST API, GITHUB_API_URL when set.
+// GitHub Enterprise Server serves it under /api/v3 of the instance, which is
+// added when GITHUB_API_URL names the instance without a path.
+func githubAPIBase() string {
+	base := strings.TrimSuffix(strings.TrimSpace(config.AppConfig.GitHubAPIURL), "/")
+	if base == "" {
+		return defaultGitHubAPIURL
+	}
+	if parsed, err := url.Parse(base); err == nil && parsed.Path == "" && parsed.Host != "api.github.com" {
+		base += "/api/v3"
+	}
+	return base
+}
+
+// githubAPI builds the URL of a REST endpoint from its path, formatted with
+// args like fmt.Sprintf
+func githubAPI(path string, args ...interface{}) string {
+	return githubAPIBase() + fmt.Sprintf(path, args...)
+}
+
+// githubGraphQLURL is the GraphQL endpoint matching the REST API: a sibling
+// of /api/v3 on Enterprise Server, /graphql under it elsewhere
+func githubGraphQLURL() string {
+	base := githubAPIBase()
+	if prefix, found := strings.CutSuffix(base, "/api/v3"); found {
+		return prefix + "/api/graphql"
+	}
+	return base + "/graphql"
+}
+
+// githubWebURL is the site the REST API belongs to, where OAuth apps
+// authorize: github.com, or the Enterprise Server instance itself
+func githubWebURL() string {
+	base := githubAPIBase()
+	if base == defaultGitHubAPIURL {
+		return "https://github.com"
+	}
+	prefix, _ := strings.CutSuffix(base, "/api/v3")
+	return prefix
+}
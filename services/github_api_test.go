@@ -0,0 +1,38 @@
+package services
+
+import (
+	"portfolio-backend/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHubAPIURLs(t *testing.T) {
+	cases := []struct {
+		apiURL, rest, graphql, web string
+	}{
+		{"", "https://api.github.com/users/octocat", "https://api.github.com/graphql", "https://github.com"},
+		{"https://api.github.com/", "https://api.github.com/users/octocat", "https://api.github.com/graphql", "https://github.com"},
+		{"https://github.example.com", "https://github.example.com/api/v3/users/octocat", "https://github.example.com/api/graphql", "https://github.example.com"},
+		{"https://github.example.com/api/v3/", "https://github.example.com/api/v3/users/octocat", "https://github.example.com/api/graphql", "https://github.example.com"},
+	}
+
+	for _, tc := range cases {
+		config.AppConfig = &config.Config{GitHubAPIURL: tc.apiURL}
+		assert.Equal(t, tc.rest, githubAPI("/users/%s", "octocat"), tc.apiURL)
+		assert.Equal(t, tc.graphql, githubGraphQLURL(), tc.apiURL)
+		assert.Equal(t, tc.web, githubWebURL(), tc.apiURL)
+	}
+}
+
+func TestParseGitHubRepoOnEnterprise(t *testing.T) {
+	config.AppConfig = &config.Config{GitHubAPIURL: "https://github.example.com"}
+
+	owner, repo, ok := parseGitHubRepo("https://github.example.com/platform/api.git")
+	assert.True(t, ok)
+	assert.Equal(t, "platform", owner)
+	assert.Equal(t, "api", repo)
+
+	_, _, ok = parseGitHubRepo("https://github.com/platform/api")
+	assert.False(t, ok)
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrUpstreamBudgetExhausted is returned instead of calling GitHub once the
+// request's upstream budget has been spent
+var ErrUpstreamBudgetExhausted = errors.New("upstream call budget exhausted")
+
+type upstreamBudgetKey struct{}
+
+type upstreamBudget struct {
+	remaining int64
+	deferred  int32
+}
+
+// WithUpstreamBudget limits how many GitHub API calls may be made with ctx.
+// A limit of 0 or less leaves ctx unbudgeted.
+func WithUpstreamBudget(ctx context.Context, limit int) context.Context {
+	if limit <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, upstreamBudgetKey{}, &upstreamBudget{remaining: int64(limit)})
+}
+
+// withoutUpstreamBudget lifts any budget set on ctx
+func withoutUpstreamBudget(ctx context.Context) context.Context {
+	return context.WithValue(ctx, upstreamBudgetKey{}, (*upstreamBudget)(nil))
+}
+
+// UpstreamWorkDeferred reports whether part of the work for ctx was left to
+// the enrichment pipeline because the budget ran out
+func UpstreamWorkDeferred(ctx context.Context) bool {
+	budget, _ := ctx.Value(upstreamBudgetKey{}).(*upstreamBudget)
+	return budget != nil && atomic.LoadInt32(&budget.deferred) == 1
+}
+
+// takeUpstreamCall spends one call from the budget of ctx, if any
+func takeUpstreamCall(ctx context.Context) bool {
+	budget, _ := ctx.Value(upstreamBudgetKey{}).(*upstreamBudget)
+	if budget == nil {
+		return true
+	}
+	if atomic.AddInt64(&budget.remaining, -1) >= 0 {
+		return true
+	}
+	atomic.StoreInt32(&budget.deferred, 1)
+	return false
+}
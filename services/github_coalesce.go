@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// githubFetchTimeout bounds a coalesced fetch, which no longer ends with the
+// request that started it
+const githubFetchTimeout = time.Minute
+
+// githubFlight is shared by every GitHubService, so concurrent cache misses
+// for the same data make a single round of GitHub calls
+var githubFlight singleflight.Group
+
+// coalesce runs fetch once for all the concurrent callers asking for key and
+// hands each of them its result. The fetch keeps the values of the first
+// caller's ctx, such as its upstream budget, but not its cancellation, so the
+// others are not failed when that caller goes away; every caller still stops
+// waiting when its own ctx is done. Cache bypasses only share fetches with
+// each other, so they never get a result read from the caches.
+func coalesce(ctx context.Context, key string, fetch func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	if CacheBypassed(ctx) {
+		key = "bypass:" + key
+	}
+	results := githubFlight.DoChan(key, func() (interface{}, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), githubFetchTimeout)
+		defer cancel()
+		return fetch(fetchCtx)
+	})
+
+	select {
+	case result := <-results:
+		return result.Val, result.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
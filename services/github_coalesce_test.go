@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"portfolio-backend/config"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProfileCoalescesCacheMisses(t *testing.T) {
+	useOfflineDatabase(t, &config.Config{CacheSerialization: "json"})
+
+	var calls int32
+	release := make(chan struct{})
+	service := NewGitHubService()
+	service.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"login":"octocat","public_repos":8}`)),
+			Request:    req,
+		}, nil
+	})}
+
+	var wg sync.WaitGroup
+	logins := make([]string, 10)
+	for i := range logins {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			profile, err := service.GetProfile(context.Background(), "octocat")
+			if assert.NoError(t, err) {
+				logins[i] = profile.Login
+			}
+		}(i)
+	}
+
+	// Let every caller join the fetch in flight before GitHub answers
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, login := range logins {
+		assert.Equal(t, "octocat", login)
+	}
+}
+
+func TestCoalesceOutlivesTheFirstCaller(t *testing.T) {
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) (interface{}, error) {
+		select {
+		case <-release:
+			return "done", nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	firstCtx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := coalesce(firstCtx, "test:outlive", fetch)
+		first <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	second := make(chan interface{}, 1)
+	go func() {
+		result, _ := coalesce(context.Background(), "test:outlive", fetch)
+		second <- result
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+
+	close(release)
+	assert.Equal(t, "done", <-second)
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrStatsPending is returned while GitHub is still computing the statistics
+// of a repository, which it does in the background on the first request
+var ErrStatsPending = errors.New("GitHub is still computing the repository statistics")
+
+// repoStatsAttempts is how many times a statistics endpoint answering 202 is
+// asked again, waiting repoStatsRetryDelay and then twice as long each time
+const repoStatsAttempts = 4
+
+var repoStatsRetryDelay = 2 * time.Second
+
+type statsNoWaitKey struct{}
+
+// withoutStatsWait makes statistics GitHub is still computing pending on the
+// first 202, for callers such as syncs that list them rather than wait
+func withoutStatsWait(ctx context.Context) context.Context {
+	return context.WithValue(ctx, statsNoWaitKey{}, true)
+}
+
+func waitsForStats(ctx context.Context) bool {
+	noWait, _ := ctx.Value(statsNoWaitKey{}).(bool)
+	return !noWait
+}
+
+// commitActivityRepos caps how many of the user's most recently pushed
+// repositories are summed into their commit activity, one call each
+const commitActivityRepos = 30
+
+type githubCommitActivityWeek struct {
+	Days  []int `json:"days"`
+	Total int   `json:"total"`
+	Week  int64 `json:"week"`
+}
+
+// GetRepositoryCommitActivity returns the last year of weekly commits of a
+// repository, by every author
+func (gs *GitHubService) GetRepositoryCommitActivity(ctx context.Context, owner, repo string) (*models.CommitActivity, error) {
+	// Try cache first
+	kind := "commit_activity:" + strings.ToLower(repo)
+	var activity models.CommitActivity
+	if err := gs.cacheService.GetGitHubData(ctx, owner, kind, &activity); err == nil {
+		return &activity, nil
+	}
+
+	var weeks []githubCommitActivityWeek
+	if err := gs.getRepoStats(ctx, githubAPI("/repos/%s/%s/stats/commit_activity", owner, repo), &weeks); err != nil {
+		return nil, err
+	}
+
+	activity = commitActivity(weeks)
+	activity.Repository = owner + "/" + repo
+
+	// Cache the result
+	gs.cacheService.SetGitHubData(ctx, owner, kind, activity)
+
+	return &activity, nil
+}
+
+// GetCommitActivity returns the last year of weekly commits summed over the
+// user's own repositories, computed on a cache miss. Syncs refresh it, and
+// stats take their total commits from it.
+func (gs *GitHubService) GetCommitActivity(ctx context.Context, username string) (*models.CommitActivity, error) {
+	var activity models.CommitActivity
+	if err := gs.cacheService.GetGitHubData(ctx, username, "commit_activity", &activity); err == nil {
+		return &activity, nil
+	}
+	return gs.RefreshCommitActivity(ctx, username)
+}
+
+// RefreshCommitActivity sums the commit activity of the user's most recently
+// pushed repositories, not forks, pushed to in the last year. Repositories
+// GitHub is still computing are left out and listed as pending until the
+// next refresh.
+func (gs *GitHubService) RefreshCommitActivity(ctx context.Context, username string) (*models.CommitActivity, error) {
+	repos, err := gs.GetRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	since := startOfDay(time.Now()).AddDate(-1, 0, 0)
+	var recent []models.GitHubRepository
+	for _, repo := range repos {
+		if !repo.Fork && repo.PushedAt.After(since) {
+			recent = append(recent, repo)
+		}
+	}
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].PushedAt.After(recent[j].PushedAt)
+	})
+	if len(recent) > commitActivityRepos {
+		recent = recent[:commitActivityRepos]
+	}
+
+	var activities []models.CommitActivity
+	var pending []string
+	for _, repo := range recent {
+		activity, err := gs.GetRepositoryCommitActivity(ctx, username, repo.Name)
+		if errors.Is(err, ErrStatsPending) {
+			pending = append(pending, repo.Name)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", repo.FullName, err)
+		}
+		activities = append(activities, *activity)
+	}
+
+	activity := sumCommitActivity(activities)
+	activity.Username = username
+	activity.Pending = pending
+	gs.cacheService.SetGitHubData(ctx, username, "commit_activity", activity)
+	return &activity, nil
+}
+
+// GetCodeFrequency returns the weekly lines added and deleted in a
+// repository. GitHub does not compute it for repositories of 10,000 commits
+// or more.
+func (gs *GitHubService) GetCodeFrequency(ctx context.Context, owner, repo string) (*models.CodeFrequency, error) {
+	// Try cache first
+	kind := "code_frequency:" + strings.ToLower(repo)
+	var frequency models.CodeFrequency
+	if err := gs.cacheService.GetGitHubData(ctx, owner, kind, &frequency); err == nil {
+		return &frequency, nil
+	}
+
+	// Each week is [timestamp, additions, deletions], deletions negative
+	var weeks [][3]int64
+	if err := gs.getRepoStats(ctx, githubAPI("/repos/%s/%s/stats/code_frequency", owner, repo), &weeks); err != nil {
+		return nil, err
+	}
+
+	frequency = models.CodeFrequency{
+		Repository:  owner + "/" + repo,
+		Weeks:       []models.CodeFrequencyWeek{},
+		LastFetched: time.Now(),
+	}
+	for _, week := range weeks {
+		additions, deletions := int(week[1]), -int(week[2])
+		frequency.Weeks = append(frequency.Weeks, models.CodeFrequencyWeek{
+			Week:      statsWeek(week[0]),
+			Additions: additions,
+			Deletions: deletions,
+		})
+		frequency.Additions += additions
+		frequency.Deletions += deletions
+	}
+
+	// Cache the result
+	gs.cacheService.SetGitHubData(ctx, owner, kind, frequency)
+
+	return &frequency, nil
+}
+
+// getRepoStats decodes a repository statistics endpoint into target. GitHub
+// answers 202 while it computes them, so the request is repeated a few
+// times before giving up with ErrStatsPending, unless ctx comes from
+// withoutStatsWait; 204 means no commits and leaves target empty.
+func (gs *GitHubService) getRepoStats(ctx context.Context, url string, target interface{}) error {
+	delay := repoStatsRetryDelay
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return err
+		}
+
+		if config.AppConfig.GitHubToken != "" {
+			req.Header.Set("Authorization", "token "+config.AppConfig.GitHubToken)
+		}
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+		resp, err := gs.do(req)
+		if err != nil {
+			return err
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			defer resp.Body.Close()
+			return json.NewDecoder(resp.Body).Decode(target)
+		case http.StatusNoContent:
+			resp.Body.Close()
+			return nil
+		case http.StatusAccepted:
+			resp.Body.Close()
+		default:
+			resp.Body.Close()
+			return fmt.Errorf("GitHub API error: %d", resp.StatusCode)
+		}
+
+		if attempt == repoStatsAttempts || !waitsForStats(ctx) {
+			return ErrStatsPending
+		}
+		select {
+		case <-time.After(delay):
+			delay *= 2
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// commitActivity maps GitHub's weekly commit activity
+func commitActivity(weeks []githubCommitActivityWeek) models.CommitActivity {
+	activity := models.CommitActivity{Weeks: []models.CommitWeek{}, LastFetched: time.Now()}
+	for _, week := range weeks {
+		days := week.Days
+		if days == nil {
+			days = make([]int, 7)
+		}
+		activity.Weeks = append(activity.Weeks, models.CommitWeek{Week: statsWeek(week.Week), Total: week.Total, Days: days})
+		activity.Total += week.Total
+	}
+	return activity
+}
+
+// sumCommitActivity adds up the activity of several repositories week by
+// week, oldest week first
+func sumCommitActivity(activities []models.CommitActivity) models.CommitActivity {
+	byWeek := make(map[string]*models.CommitWeek)
+	sum := models.CommitActivity{Weeks: []models.CommitWeek{}, LastFetched: time.Now()}
+	for _, activity := range activities {
+		for _, week := range activity.Weeks {
+			total := byWeek[week.Week]
+			if total == nil {
+				total = &models.CommitWeek{Week: week.Week, Days: make([]int, 7)}
+				byWeek[week.Week] = total
+			}
+			total.Total += week.Total
+			for day, count := range week.Days {
+				if day < len(total.Days) {
+					total.Days[day] += count
+				}
+			}
+			sum.Total += week.Total
+		}
+	}
+
+	for _, week := range byWeek {
+		sum.Weeks = append(sum.Weeks, *week)
+	}
+	sort.Slice(sum.Weeks, func(i, j int) bool {
+		return sum.Weeks[i].Week < sum.Weeks[j].Week
+	})
+	return sum
+}
+
+// statsWeek formats the Unix timestamp statistics endpoints start weeks at
+func statsWeek(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format(contributionDateLayout)
+}
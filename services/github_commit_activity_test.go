@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSumCommitActivity(t *testing.T) {
+	activities := []models.CommitActivity{
+		{Total: 5, Weeks: []models.CommitWeek{
+			{Week: "2024-01-14", Total: 2, Days: []int{0, 1, 1, 0, 0, 0, 0}},
+			{Week: "2024-01-07", Total: 3, Days: []int{0, 0, 0, 3, 0, 0, 0}},
+		}},
+		{Total: 4, Weeks: []models.CommitWeek{
+			{Week: "2024-01-14", Total: 4, Days: []int{1, 1, 1, 1, 0, 0, 0}},
+		}},
+	}
+
+	sum := sumCommitActivity(activities)
+	assert.Equal(t, 9, sum.Total)
+	assert.Equal(t, []models.CommitWeek{
+		{Week: "2024-01-07", Total: 3, Days: []int{0, 0, 0, 3, 0, 0, 0}},
+		{Week: "2024-01-14", Total: 6, Days: []int{1, 2, 2, 1, 0, 0, 0}},
+	}, sum.Weeks)
+
+	assert.Empty(t, sumCommitActivity(nil).Weeks)
+}
+
+func TestCommitActivityWithoutDays(t *testing.T) {
+	activity := commitActivity([]githubCommitActivityWeek{{Total: 0, Week: 1704585600}})
+	assert.Equal(t, []int{0, 0, 0, 0, 0, 0, 0}, activity.Weeks[0].Days)
+	assert.Equal(t, "2024-01-07", activity.Weeks[0].Week)
+}
+
+func TestGetRepoStatsWithoutWait(t *testing.T) {
+	useOfflineDatabase(t, &config.Config{CacheSerialization: "json"})
+
+	original := repoStatsRetryDelay
+	repoStatsRetryDelay = time.Millisecond
+	defer func() { repoStatsRetryDelay = original }()
+
+	calls := 0
+	service := NewGitHubService()
+	service.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+			Request:    req,
+		}, nil
+	})}
+
+	var weeks []githubCommitActivityWeek
+	url := githubAPI("/repos/%s/%s/stats/commit_activity", "octocat", "api")
+	assert.ErrorIs(t, service.getRepoStats(context.Background(), url, &weeks), ErrStatsPending)
+	assert.Equal(t, repoStatsAttempts, calls)
+
+	// Syncs list the repository as pending after the first 202
+	calls = 0
+	assert.ErrorIs(t, service.getRepoStats(withoutStatsWait(context.Background()), url, &weeks), ErrStatsPending)
+	assert.Equal(t, 1, calls)
+}
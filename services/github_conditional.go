@@ -0,0 +1,135 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// conditionalMaxBody keeps very large listings out of the validator store;
+// they are simply downloaded again
+const conditionalMaxBody = 8 << 20
+
+// conditionalEntry is the last 200 response of a GitHub GET, kept with its
+// validators so it can be revalidated with If-None-Match/If-Modified-Since.
+// GitHub does not count 304 responses against the rate limit.
+type conditionalEntry struct {
+	URL          string    `bson:"url"`
+	ETag         string    `bson:"etag,omitempty"`
+	LastModified string    `bson:"last_modified,omitempty"`
+	ContentType  string    `bson:"content_type,omitempty"`
+	Body         []byte    `bson:"body"`
+	UpdatedAt    time.Time `bson:"updated_at"`
+}
+
+// doConditional sends a GET revalidating the stored response for its URL.
+// A 304 is turned back into the stored 200 response, so callers decode and
+// re-cache it as if it had been downloaded, renewing its cache TTL.
+func (gs *GitHubService) doConditional(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	url := req.URL.String()
+
+	// A cache bypass asks GitHub for the full response
+	var entry *conditionalEntry
+	if !CacheBypassed(ctx) {
+		entry = gs.lookupConditional(ctx, url)
+	}
+	applyValidators(req, entry)
+
+	resp, err := gs.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		gs.touchConditional(ctx, url)
+		return notModifiedResponse(resp, entry), nil
+	case resp.StatusCode == http.StatusOK && (resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""):
+		gs.storeConditional(ctx, url, resp)
+	}
+	return resp, nil
+}
+
+// applyValidators makes req conditional on the stored response, if any
+func applyValidators(req *http.Request, entry *conditionalEntry) {
+	if entry == nil {
+		return
+	}
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+}
+
+// notModifiedResponse replays the stored body as a 200, keeping the headers
+// of the 304, such as the rate limit ones
+func notModifiedResponse(resp *http.Response, entry *conditionalEntry) *http.Response {
+	resp.Body.Close()
+
+	header := resp.Header.Clone()
+	if entry.ContentType != "" {
+		header.Set("Content-Type", entry.ContentType)
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         resp.Proto,
+		ProtoMajor:    resp.ProtoMajor,
+		ProtoMinor:    resp.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       resp.Request,
+	}
+}
+
+func (gs *GitHubService) lookupConditional(ctx context.Context, url string) *conditionalEntry {
+	var entry conditionalEntry
+	if err := gs.conditional.FindOne(ctx, bson.M{"url": url}).Decode(&entry); err != nil {
+		return nil
+	}
+	return &entry
+}
+
+// storeConditional keeps the body of resp with its validators, leaving resp
+// readable by the caller
+func (gs *GitHubService) storeConditional(ctx context.Context, url string, resp *http.Response) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, conditionalMaxBody+1))
+	if err != nil || len(body) > conditionalMaxBody {
+		resp.Body = readCloser{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	entry := conditionalEntry{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		ContentType:  resp.Header.Get("Content-Type"),
+		Body:         body,
+		UpdatedAt:    time.Now(),
+	}
+
+	opts := options.Replace().SetUpsert(true)
+	gs.conditional.ReplaceOne(ctx, bson.M{"url": url}, entry, opts)
+}
+
+// touchConditional keeps a revalidated entry from expiring
+func (gs *GitHubService) touchConditional(ctx context.Context, url string) {
+	gs.conditional.UpdateOne(ctx, bson.M{"url": url}, bson.M{"$set": bson.M{"updated_at": time.Now()}})
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
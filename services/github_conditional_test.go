@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyValidators(t *testing.T) {
+	req := httptest.NewRequest("GET", "https://api.github.com/users/octocat", nil)
+	applyValidators(req, nil)
+	assert.Empty(t, req.Header.Get("If-None-Match"))
+
+	applyValidators(req, &conditionalEntry{ETag: `"abc"`, LastModified: "Tue, 10 Mar 2026 14:00:00 GMT"})
+	assert.Equal(t, `"abc"`, req.Header.Get("If-None-Match"))
+	assert.Equal(t, "Tue, 10 Mar 2026 14:00:00 GMT", req.Header.Get("If-Modified-Since"))
+}
+
+func TestNotModifiedResponseReplaysStoredBody(t *testing.T) {
+	notModified := &http.Response{
+		StatusCode: http.StatusNotModified,
+		Header:     http.Header{"X-Ratelimit-Remaining": []string{"4999"}},
+		Body:       io.NopCloser(http.NoBody),
+	}
+	entry := &conditionalEntry{ContentType: "application/json; charset=utf-8", Body: []byte(`{"login":"octocat"}`)}
+
+	resp := notModifiedResponse(notModified, entry)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "4999", resp.Header.Get("X-Ratelimit-Remaining"))
+	assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"login":"octocat"}`, string(body))
+}
+
+func TestDoConditionalKeepsBodyReadable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"login":"octocat"}`))
+	}))
+	defer server.Close()
+
+	useOfflineDatabase(t, &config.Config{CacheSerialization: "json"})
+
+	// MongoDB is disconnected: nothing is stored, but the caller still
+	// reads the full body
+	service := NewGitHubService()
+	service.client = server.Client()
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	require.NoError(t, err)
+	resp, err := service.do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"login":"octocat"}`, string(body))
+}
@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Contract tests replay GitHub API responses recorded under testdata/cassettes
+// and check how they map into internal models. Re-record them against the
+// live API with GITHUB_RECORD=1 (and GITHUB_TOKEN to avoid rate limits).
+
+const contractUsername = "octocat"
+
+// newContractGitHubService returns a GitHubService whose HTTP client reads
+// from the named cassette. MongoDB is left disconnected, so every cache
+// lookup misses and persistence is a no-op.
+func newContractGitHubService(t *testing.T, cassette string) *GitHubService {
+	t.Helper()
+
+	mode := utils.RecorderReplay
+	if os.Getenv("GITHUB_RECORD") == "1" {
+		mode = utils.RecorderRecord
+	}
+
+	recorder, err := utils.NewHTTPRecorder(filepath.Join("testdata", "cassettes", cassette), mode)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, recorder.Stop())
+	})
+
+	useOfflineDatabase(t, &config.Config{
+		GitHubToken:        os.Getenv("GITHUB_TOKEN"),
+		CacheSerialization: "json",
+	})
+
+	service := NewGitHubService()
+	service.client = &http.Client{Transport: recorder, Timeout: 30 * time.Second}
+	service.enqueueEnrichment = func(username string, relist bool) {}
+	return service
+}
+
+func TestGitHubContractProfile(t *testing.T) {
+	service := newContractGitHubService(t, "github_profile.json")
+
+	profile, err := service.GetProfile(context.Background(), contractUsername)
+	require.NoError(t, err)
+
+	assert.Equal(t, "octocat", profile.Login)
+	assert.Equal(t, "The Octocat", profile.Name)
+	assert.Equal(t, "@github", profile.Company)
+	assert.Equal(t, "San Francisco", profile.Location)
+	assert.Equal(t, "https://github.blog", profile.Blog)
+	assert.NotEmpty(t, profile.AvatarURL)
+	assert.Positive(t, profile.Followers)
+	assert.Positive(t, profile.PublicRepos)
+
+	// Nullable fields must decode to zero values rather than fail
+	assert.Empty(t, profile.Email)
+	assert.Empty(t, profile.TwitterUsername)
+
+	assert.Equal(t, time.Date(2011, 1, 25, 18, 44, 36, 0, time.UTC), profile.CreatedAt.UTC())
+	assert.False(t, profile.LastFetched.IsZero())
+}
+
+func TestGitHubContractRepositories(t *testing.T) {
+	service := newContractGitHubService(t, "github_repositories.json")
+
+	repos, err := service.GetRepositories(context.Background(), contractUsername)
+	require.NoError(t, err)
+	require.NotEmpty(t, repos)
+
+	byName := make(map[string]int)
+	for i, repo := range repos {
+		byName[repo.Name] = i
+
+		assert.Positive(t, repo.GitHubID)
+		assert.Equal(t, "octocat/"+repo.Name, repo.FullName)
+		assert.Equal(t, "https://github.com/octocat/"+repo.Name, repo.HTMLURL)
+		assert.Equal(t, contractUsername, repo.Owner)
+		assert.NotEmpty(t, repo.DefaultBranch)
+		assert.False(t, repo.CreatedAt.IsZero())
+		assert.False(t, repo.PushedAt.IsZero())
+
+		// Listing never waits on enrichment
+		assert.Nil(t, repo.Languages, repo.Name)
+		assert.Equal(t, models.EnrichmentPending, repo.Enrichment.Status, repo.Name)
+	}
+
+	if i, ok := byName["Hello-World"]; ok {
+		hello := repos[i]
+		assert.Equal(t, "My first repository on GitHub!", hello.Description)
+		assert.Empty(t, hello.Language)
+		assert.Empty(t, hello.Homepage)
+		assert.False(t, hello.Fork)
+		assert.Positive(t, hello.StargazersCount)
+	}
+
+	if i, ok := byName["linguist"]; ok {
+		assert.True(t, repos[i].Fork)
+	}
+}
+
+func TestGitHubContractStats(t *testing.T) {
+	service := newContractGitHubService(t, "github_repositories.json")
+
+	repos, err := service.GetRepositories(context.Background(), contractUsername)
+	require.NoError(t, err)
+
+	stats, err := service.GetStats(context.Background(), contractUsername)
+	require.NoError(t, err)
+
+	expectedStars := 0
+	for _, repo := range repos {
+		if !repo.Fork && !repo.Private {
+			expectedStars += repo.StargazersCount
+		}
+	}
+
+	assert.Equal(t, contractUsername, stats.Username)
+	assert.Equal(t, len(repos), stats.TotalRepos)
+	assert.Equal(t, expectedStars, stats.TotalStars)
+	for _, repo := range stats.TopRepositories {
+		assert.Positive(t, repo.Stars)
+		assert.NotEqual(t, "linguist", repo.Name, "forks must not count towards stats")
+	}
+}
+
+func TestGitHubContractNotFound(t *testing.T) {
+	service := newContractGitHubService(t, "github_not_found.json")
+
+	_, err := service.GetProfile(context.Background(), "ghost-user-that-does-not-exist")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+}
+
+func TestGitHubContractIncrementalLanguages(t *testing.T) {
+	service := newContractGitHubService(t, "github_repositories.json")
+
+	// Spoon-Knife is unchanged since the stored copy, linguist was pushed to
+	known := map[int64]models.GitHubRepository{
+		1300192: {
+			Name:      "Spoon-Knife",
+			Languages: map[string]int{"HTML": 1},
+			PushedAt:  time.Date(2024, 9, 30, 16, 45, 10, 0, time.UTC),
+		},
+		18221276: {
+			Name:      "linguist",
+			Languages: map[string]int{"Ruby": 1},
+			PushedAt:  time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	repos, fetched, err := service.fetchRepositories(context.Background(), contractUsername, known)
+	require.NoError(t, err)
+
+	// Only linguist and octocat.github.io have their languages refetched
+	assert.Equal(t, 2, fetched)
+	for _, repo := range repos {
+		switch repo.Name {
+		case "Spoon-Knife":
+			assert.Equal(t, map[string]int{"HTML": 1}, repo.Languages)
+		case "linguist":
+			assert.Contains(t, repo.Languages, "Shell")
+		}
+	}
+}
+
+func TestGitHubContractUpstreamBudget(t *testing.T) {
+	service := newContractGitHubService(t, "github_repositories.json")
+
+	var deferred []string
+	service.enqueueEnrichment = func(username string, relist bool) {
+		deferred = append(deferred, username)
+	}
+
+	// One call lists the repositories, leaving one for languages
+	ctx := WithUpstreamBudget(context.Background(), 2)
+	repos, fetched, err := service.fetchRepositories(ctx, contractUsername, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, repos, 4)
+	assert.Equal(t, 1, fetched)
+	assert.True(t, UpstreamWorkDeferred(ctx))
+	assert.Equal(t, []string{contractUsername}, deferred)
+
+	withLanguages := 0
+	for _, repo := range repos {
+		if repo.Languages != nil {
+			withLanguages++
+		}
+	}
+	assert.Equal(t, 1, withLanguages)
+
+	// Without a budget every language is fetched inline
+	_, fetched, err = service.fetchRepositories(context.Background(), contractUsername, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, fetched)
+
+	// An exhausted budget fails the listing itself rather than returning nothing
+	_, _, err = service.fetchRepositories(ctx, contractUsername, nil)
+	assert.ErrorIs(t, err, ErrUpstreamBudgetExhausted)
+}
+
+func TestGitHubContractEnrichment(t *testing.T) {
+	service := newContractGitHubService(t, "github_enrichment.json")
+	ctx := context.Background()
+
+	hello := models.GitHubRepository{
+		Name:     "Hello-World",
+		Owner:    contractUsername,
+		PushedAt: time.Date(2024, 8, 28, 14, 12, 3, 0, time.UTC),
+	}
+	hello.UpdateEnrichmentStatus()
+	assert.Equal(t, models.EnrichmentPending, hello.Enrichment.Status)
+
+	require.NoError(t, service.enrichRepository(ctx, &hello))
+	assert.Equal(t, models.EnrichmentComplete, hello.Enrichment.Status)
+	assert.Equal(t, "# Hello-World\nMy first repository on GitHub.\n", hello.Readme)
+	require.Len(t, hello.Contributors, 1)
+	assert.Equal(t, "octocat", hello.Contributors[0].Login)
+	assert.Nil(t, hello.Languages, "repositories without a language skip the languages call")
+
+	// A missing README is a valid result, not an error to retry
+	spoon := models.GitHubRepository{
+		Name:     "Spoon-Knife",
+		Owner:    contractUsername,
+		Language: "HTML",
+		PushedAt: time.Date(2024, 9, 30, 16, 45, 10, 0, time.UTC),
+	}
+	require.NoError(t, service.enrichRepository(ctx, &spoon))
+	assert.Equal(t, models.EnrichmentComplete, spoon.Enrichment.Status)
+	assert.Empty(t, spoon.Readme)
+	assert.Contains(t, spoon.Languages, "HTML")
+	assert.Len(t, spoon.Contributors, 2)
+
+	// A later push makes every field stale again
+	spoon.PushedAt = time.Now().Add(time.Minute)
+	spoon.UpdateEnrichmentStatus()
+	assert.Equal(t, models.EnrichmentPending, spoon.Enrichment.Status)
+	assert.True(t, spoon.NeedsReadme())
+}
+
+func TestGitHubContractContributions(t *testing.T) {
+	service := newContractGitHubService(t, "github_contributions.json")
+
+	contributions, err := service.GetContributionsForYear(context.Background(), contractUsername, 2023)
+	require.NoError(t, err)
+
+	assert.Equal(t, 52, contributions.TotalContributions)
+	assert.Equal(t, 41, contributions.Commits)
+	assert.Equal(t, 7, contributions.PullRequests)
+	assert.Equal(t, 4, contributions.Issues)
+	assert.Equal(t, []int{2024, 2023, 2022}, contributions.ContributionYears)
+	require.Len(t, contributions.ContributionCalendar, 53)
+	assert.Equal(t, "2023-01-01", contributions.ContributionCalendar[0].WeekStart)
+
+	total := 0
+	for _, week := range contributions.ContributionCalendar {
+		for _, day := range week.Days {
+			total += day.Count
+			if day.Date == "2023-09-05" {
+				assert.Equal(t, 4, day.Level)
+			}
+		}
+	}
+	assert.Equal(t, contributions.TotalContributions, total)
+
+	// Twelve days in March; a finished year has no current streak
+	assert.Equal(t, 12, contributions.LongestStreak)
+	assert.Zero(t, contributions.CurrentStreak)
+
+	// GraphQL reports unknown users in the body of a 200
+	_, err = service.GetContributionsForYear(context.Background(), "ghost-user-that-does-not-exist", 2023)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Could not resolve to a User")
+
+	_, err = service.GetContributionsForYear(context.Background(), contractUsername, time.Now().Year()+1)
+	assert.ErrorIs(t, err, ErrInvalidContributionYear)
+}
+
+func TestGitHubContractOrganizationRepositories(t *testing.T) {
+	service := newContractGitHubService(t, "github_organizations.json")
+
+	repos, err := service.GetOrganizationRepositories(context.Background(), contractUsername)
+	require.NoError(t, err)
+
+	// octo-docs has no commits by the user and forks are skipped
+	require.Len(t, repos, 1)
+	assert.Equal(t, "octo-org", repos[0].Organization)
+	assert.Equal(t, "octo-org/octo-app", repos[0].FullName)
+	assert.Equal(t, 25, repos[0].UserCommits)
+	assert.Equal(t, 100, repos[0].TotalCommits)
+	assert.InDelta(t, 0.25, repos[0].Share, 1e-9)
+
+	// Only the user's share of stars and forks is credited
+	stats := models.GitHubStats{TotalRepos: 2, TotalStars: 3, TotalForks: 1}
+	addOrganizationShare(&stats, repos)
+	assert.Equal(t, 13, stats.TotalStars)
+	assert.Equal(t, 3, stats.TotalForks)
+	assert.Len(t, stats.OrganizationRepos, 1)
+	assert.Equal(t, &models.RepoTotals{Repos: 2, Stars: 3, Forks: 1}, stats.Owned)
+	assert.Equal(t, &models.RepoTotals{Repos: 1, Stars: 10, Forks: 2}, stats.Contributed)
+
+	// Each repository costs a contributors call, so a small budget runs out
+	ctx := WithUpstreamBudget(context.Background(), 3)
+	_, err = service.GetOrganizationRepositories(ctx, contractUsername)
+	assert.ErrorIs(t, err, ErrUpstreamBudgetExhausted)
+}
+
+func TestGitHubContractStarredAndGists(t *testing.T) {
+	service := newContractGitHubService(t, "github_starred.json")
+	ctx := context.Background()
+
+	starred, total, err := service.GetStarredRepositories(ctx, contractUsername, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, starred, 1)
+	assert.Equal(t, "gin-gonic/gin", starred[0].FullName)
+	assert.Equal(t, "gin-gonic", starred[0].Owner)
+	assert.Equal(t, 78000, starred[0].Stars)
+	assert.NotNil(t, starred[0].Topics)
+
+	// Secret gists are left out and files come sorted by name
+	gists, total, err := service.GetGists(ctx, contractUsername, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, gists, 1)
+	assert.Equal(t, 2, gists[0].Comments)
+	require.Len(t, gists[0].Files, 2)
+	assert.Equal(t, "hello_world.go", gists[0].Files[0].Filename)
+	assert.Equal(t, "Go", gists[0].Files[0].Language)
+
+	// Past the last page
+	gists, total, err = service.GetGists(ctx, contractUsername, 3, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Empty(t, gists)
+}
+
+func TestGitHubContractRepositoryReleases(t *testing.T) {
+	service := newContractGitHubService(t, "github_releases.json")
+
+	releases, err := service.GetRepositoryReleases(context.Background(), contractUsername, "hello")
+	require.NoError(t, err)
+
+	// The draft is left out and unnamed releases are named after their tag
+	assert.Equal(t, "octocat/hello", releases.Repository)
+	require.Len(t, releases.Releases, 2)
+	assert.Equal(t, "v1.0.0", releases.Releases[0].Name)
+	assert.Equal(t, "First stable release", releases.Releases[0].Notes)
+	assert.Len(t, releases.Releases[0].Assets, 2)
+	assert.Equal(t, 200, releases.Releases[0].Downloads)
+	assert.True(t, releases.Releases[1].Prerelease)
+	assert.Equal(t, 205, releases.TotalDownloads)
+
+	// Tags without a release are listed too
+	require.Len(t, releases.Tags, 3)
+	assert.Equal(t, "v0.1.0", releases.Tags[2].Name)
+	assert.Equal(t, "762941318ee16e59dabbacb1b4049eec22f0d303", releases.Tags[2].SHA)
+}
+
+func TestGitHubContractRepositoryStats(t *testing.T) {
+	service := newContractGitHubService(t, "github_repo_stats.json")
+	repoStatsRetryDelay = time.Millisecond
+	t.Cleanup(func() { repoStatsRetryDelay = 2 * time.Second })
+
+	activity, err := service.GetRepositoryCommitActivity(context.Background(), contractUsername, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "octocat/hello", activity.Repository)
+	assert.Equal(t, 11, activity.Total)
+	require.Len(t, activity.Weeks, 2)
+	assert.Equal(t, "2024-01-07", activity.Weeks[0].Week)
+	assert.Equal(t, []int{0, 3, 2, 0, 1, 0, 0}, activity.Weeks[0].Days)
+
+	// Deletions are reported negative and served positive
+	frequency, err := service.GetCodeFrequency(context.Background(), contractUsername, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, 135, frequency.Additions)
+	assert.Equal(t, 70, frequency.Deletions)
+	assert.Equal(t, models.CodeFrequencyWeek{Week: "2024-01-14", Additions: 15, Deletions: 40}, frequency.Weeks[1])
+
+	// Statistics still being computed after every retry
+	_, err = service.GetRepositoryCommitActivity(context.Background(), contractUsername, "computing")
+	assert.ErrorIs(t, err, ErrStatsPending)
+}
@@ -0,0 +1,348 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"sort"
+	"time"
+)
+
+// ErrInvalidContributionYear is returned for years GitHub has no calendar for
+var ErrInvalidContributionYear = errors.New("invalid contribution year")
+
+// firstContributionYear is the year GitHub launched
+const firstContributionYear = 2008
+
+// pastYearContributionsTTL is how long the calendar of a finished year is
+// cached. It is kept outside the github:<username>: namespace so syncs do not
+// refetch years that no longer change.
+const pastYearContributionsTTL = 7 * 24 * time.Hour
+
+const contributionDateLayout = "2006-01-02"
+
+// contributionsQuery fetches one year of the contribution calendar, which
+// GitHub only serves through GraphQL and for ranges of at most a year
+const contributionsQuery = `query($login: String!, $from: DateTime!, $to: DateTime!) {
+  user(login: $login) {
+    contributionsCollection(from: $from, to: $to) {
+      contributionYears
+      totalCommitContributions
+      totalPullRequestContributions
+      totalIssueContributions
+      contributionCalendar {
+        totalContributions
+        weeks {
+          firstDay
+          contributionDays {
+            date
+            contributionCount
+            contributionLevel
+          }
+        }
+      }
+    }
+  }
+}`
+
+// contributionLevels maps GitHub's quartiles to the 0-4 intensity levels
+var contributionLevels = map[string]int{
+	"NONE":            0,
+	"FIRST_QUARTILE":  1,
+	"SECOND_QUARTILE": 2,
+	"THIRD_QUARTILE":  3,
+	"FOURTH_QUARTILE": 4,
+}
+
+type contributionsResponse struct {
+	Data struct {
+		User *struct {
+			ContributionsCollection struct {
+				ContributionYears             []int `json:"contributionYears"`
+				TotalCommitContributions      int   `json:"totalCommitContributions"`
+				TotalPullRequestContributions int   `json:"totalPullRequestContributions"`
+				TotalIssueContributions       int   `json:"totalIssueContributions"`
+				ContributionCalendar          struct {
+					TotalContributions int `json:"totalContributions"`
+					Weeks              []struct {
+						FirstDay         string `json:"firstDay"`
+						ContributionDays []struct {
+							Date              string `json:"date"`
+							ContributionCount int    `json:"contributionCount"`
+							ContributionLevel string `json:"contributionLevel"`
+						} `json:"contributionDays"`
+					} `json:"weeks"`
+				} `json:"contributionCalendar"`
+			} `json:"contributionsCollection"`
+		} `json:"user"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GetContributions returns the contribution calendar of the last year along
+// with totals and streaks over every year the user contributed in. Finished
+// years are cached separately, so once warm only the current year is queried.
+func (gs *GitHubService) GetContributions(ctx context.Context, username string) (*models.GitHubContributions, error) {
+	// Try cache first
+	var contributions models.GitHubContributions
+	if err := gs.cacheService.GetGitHubData(ctx, username, "contributions", &contributions); err == nil {
+		return &contributions, nil
+	}
+
+	thisYear := time.Now().UTC().Year()
+	current, err := gs.GetContributionsForYear(ctx, username, thisYear)
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk the years oldest first so streaks can span new year's eve
+	years := append([]int(nil), current.ContributionYears...)
+	sort.Ints(years)
+
+	var total, commits, pullRequests, issues int
+	var days []models.ContributionDay
+	for _, year := range years {
+		if year == thisYear {
+			continue
+		}
+		yearly, err := gs.GetContributionsForYear(ctx, username, year)
+		if err != nil {
+			return nil, err
+		}
+		total += yearly.TotalContributions
+		commits += yearly.Commits
+		pullRequests += yearly.PullRequests
+		issues += yearly.Issues
+		days = append(days, flattenContributionWeeks(yearly.ContributionCalendar)...)
+	}
+	total += current.TotalContributions
+	commits += current.Commits
+	pullRequests += current.PullRequests
+	issues += current.Issues
+	days = append(days, flattenContributionWeeks(current.ContributionCalendar)...)
+
+	today := startOfDay(time.Now())
+	longest, streak := contributionStreaks(days, today)
+
+	contributions = models.GitHubContributions{
+		Username:             username,
+		TotalContributions:   total,
+		Commits:              commits,
+		PullRequests:         pullRequests,
+		Issues:               issues,
+		ContributionCalendar: groupContributionWeeks(days, today.AddDate(-1, 0, 1)),
+		ContributionYears:    current.ContributionYears,
+		LongestStreak:        longest,
+		CurrentStreak:        streak,
+		LastFetched:          time.Now(),
+	}
+
+	// Cache the result
+	gs.cacheService.SetGitHubData(ctx, username, "contributions", contributions)
+
+	return &contributions, nil
+}
+
+// GetContributionsForYear returns the contribution calendar of one calendar
+// year (UTC). Streaks only count days within that year.
+func (gs *GitHubService) GetContributionsForYear(ctx context.Context, username string, year int) (*models.GitHubContributions, error) {
+	thisYear := time.Now().UTC().Year()
+	if year < firstContributionYear || year > thisYear {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidContributionYear, year)
+	}
+
+	// The current year lives with the rest of the user's GitHub cache and is
+	// refreshed with it; finished years get a longer-lived key of their own
+	dataType := fmt.Sprintf("contributions:%d", year)
+	pastKey := fmt.Sprintf("contributions:%s:%d", username, year)
+
+	var contributions models.GitHubContributions
+	if year == thisYear {
+		if err := gs.cacheService.GetGitHubData(ctx, username, dataType, &contributions); err == nil {
+			return &contributions, nil
+		}
+	} else if err := gs.cacheService.Get(ctx, pastKey, &contributions); err == nil {
+		return &contributions, nil
+	}
+
+	fetched, err := gs.fetchContributionYear(ctx, username, year)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	if year == thisYear {
+		gs.cacheService.SetGitHubData(ctx, username, dataType, fetched)
+	} else {
+		gs.cacheService.Set(ctx, pastKey, fetched, pastYearContributionsTTL)
+	}
+
+	return fetched, nil
+}
+
+func (gs *GitHubService) fetchContributionYear(ctx context.Context, username string, year int) (*models.GitHubContributions, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0).Add(-time.Second)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"query": contributionsQuery,
+		"variables": map[string]string{
+			"login": username,
+			"from":  from.Format(time.RFC3339),
+			"to":    to.Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", githubGraphQLURL(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.AppConfig.GitHubToken != "" {
+		req.Header.Set("Authorization", "token "+config.AppConfig.GitHubToken)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := gs.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch contributions: %d", resp.StatusCode)
+	}
+
+	var result contributionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	// GraphQL reports failures such as unknown users with a 200
+	if len(result.Errors) > 0 {
+		return nil, fmt.Errorf("failed to fetch contributions: %s", result.Errors[0].Message)
+	}
+	if result.Data.User == nil {
+		return nil, fmt.Errorf("failed to fetch contributions: user %s not found", username)
+	}
+
+	collection := result.Data.User.ContributionsCollection
+	today := startOfDay(time.Now())
+
+	// The range runs to the end of the year; days still to come are dropped
+	var weeks []models.ContributionWeek
+	for _, week := range collection.ContributionCalendar.Weeks {
+		var days []models.ContributionDay
+		for _, day := range week.ContributionDays {
+			if day.Date > today.Format(contributionDateLayout) {
+				continue
+			}
+			days = append(days, models.ContributionDay{
+				Date:  day.Date,
+				Count: day.ContributionCount,
+				Level: contributionLevels[day.ContributionLevel],
+			})
+		}
+		if len(days) > 0 {
+			weeks = append(weeks, models.ContributionWeek{WeekStart: week.FirstDay, Days: days})
+		}
+	}
+
+	longest, current := contributionStreaks(flattenContributionWeeks(weeks), today)
+	if year != today.Year() {
+		current = 0
+	}
+
+	return &models.GitHubContributions{
+		Username:             username,
+		TotalContributions:   collection.ContributionCalendar.TotalContributions,
+		Commits:              collection.TotalCommitContributions,
+		PullRequests:         collection.TotalPullRequestContributions,
+		Issues:               collection.TotalIssueContributions,
+		ContributionCalendar: weeks,
+		ContributionYears:    collection.ContributionYears,
+		LongestStreak:        longest,
+		CurrentStreak:        current,
+		LastFetched:          time.Now(),
+	}, nil
+}
+
+// contributionStreaks returns the longest run of consecutive days with
+// contributions and the run still going on today. A today without
+// contributions yet does not end the current streak. days must be sorted.
+func contributionStreaks(days []models.ContributionDay, today time.Time) (longest, current int) {
+	var run, lastRun int
+	var previous, lastActive time.Time
+
+	for _, day := range days {
+		date, err := time.Parse(contributionDateLayout, day.Date)
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case day.Count == 0:
+			run = 0
+		case run > 0 && date.Equal(previous.AddDate(0, 0, 1)):
+			run++
+		default:
+			run = 1
+		}
+		previous = date
+
+		if run > 0 {
+			lastRun, lastActive = run, date
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+
+	if lastActive.Equal(today) || lastActive.Equal(today.AddDate(0, 0, -1)) {
+		current = lastRun
+	}
+	return longest, current
+}
+
+func flattenContributionWeeks(weeks []models.ContributionWeek) []models.ContributionDay {
+	var days []models.ContributionDay
+	for _, week := range weeks {
+		days = append(days, week.Days...)
+	}
+	return days
+}
+
+// groupContributionWeeks groups the days from since onwards into weeks
+// starting on Sunday, as GitHub draws its calendar
+func groupContributionWeeks(days []models.ContributionDay, since time.Time) []models.ContributionWeek {
+	var weeks []models.ContributionWeek
+	for _, day := range days {
+		date, err := time.Parse(contributionDateLayout, day.Date)
+		if err != nil || date.Before(since) {
+			continue
+		}
+
+		weekStart := date.AddDate(0, 0, -int(date.Weekday())).Format(contributionDateLayout)
+		if len(weeks) == 0 || weeks[len(weeks)-1].WeekStart != weekStart {
+			weeks = append(weeks, models.ContributionWeek{WeekStart: weekStart})
+		}
+		weeks[len(weeks)-1].Days = append(weeks[len(weeks)-1].Days, day)
+	}
+	return weeks
+}
+
+// startOfDay truncates t to midnight UTC, the timezone calendar dates use
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// contributionDays builds consecutive days starting at start with the given counts
+func contributionDays(start string, counts ...int) []models.ContributionDay {
+	date, _ := time.Parse(contributionDateLayout, start)
+	days := make([]models.ContributionDay, len(counts))
+	for i, count := range counts {
+		days[i] = models.ContributionDay{Date: date.AddDate(0, 0, i).Format(contributionDateLayout), Count: count}
+	}
+	return days
+}
+
+func TestContributionStreaks(t *testing.T) {
+	today := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		days    []models.ContributionDay
+		longest int
+		current int
+	}{
+		{"empty", nil, 0, 0},
+		{"running through today", contributionDays("2024-01-05", 0, 1, 1, 0, 2, 3), 2, 2},
+		{"today not contributed yet", contributionDays("2024-01-06", 1, 1, 1, 1, 0), 4, 4},
+		{"ended before yesterday", contributionDays("2024-01-01", 1, 1, 1, 1, 1, 0, 0, 0, 0, 0), 5, 0},
+		{"spans new year", contributionDays("2023-12-30", 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1), 12, 12},
+		// A year without contributions is missing from the data entirely
+		{"gap between years", append(contributionDays("2022-12-30", 1, 1), contributionDays("2024-01-01", 1, 1)...), 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			longest, current := contributionStreaks(tt.days, today)
+			assert.Equal(t, tt.longest, longest)
+			assert.Equal(t, tt.current, current)
+		})
+	}
+}
+
+func TestGroupContributionWeeks(t *testing.T) {
+	// 2024-01-01 is a Monday
+	days := contributionDays("2023-12-20", make([]int, 20)...)
+	weeks := groupContributionWeeks(days, time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC))
+
+	assert.Len(t, weeks, 3)
+	assert.Equal(t, "2023-12-24", weeks[0].WeekStart)
+	assert.Len(t, weeks[0].Days, 6)
+	assert.Equal(t, "2023-12-25", weeks[0].Days[0].Date)
+	assert.Equal(t, "2023-12-31", weeks[1].WeekStart)
+	assert.Len(t, weeks[1].Days, 7)
+	assert.Equal(t, "2024-01-07", weeks[2].WeekStart)
+	assert.Len(t, weeks[2].Days, 2)
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// readmeExcerptSize caps the README text stored per repository; listings
+// return it for every repository, so it is kept to a card-sized excerpt
+const readmeExcerptSize = 2000
+
+// contributorsLimit is how many top contributors are stored per repository
+const contributorsLimit = 10
+
+type enrichmentJob struct {
+	username string
+	relist   bool
+}
+
+var (
+	enrichmentQueue   = make(chan enrichmentJob, 64)
+	enrichmentMutex   sync.Mutex
+	enrichmentPending = make(map[string]bool)
+)
+
+// EnqueueEnrichment schedules the background pipeline to fill in languages,
+// READMEs and contributors of the user's repositories, relisting them from
+// GitHub first when relist is set. It never blocks; a user already queued is
+// not queued twice.
+func EnqueueEnrichment(username string, relist bool) {
+	enrichmentMutex.Lock()
+	defer enrichmentMutex.Unlock()
+
+	if enrichmentPending[username] {
+		return
+	}
+
+	select {
+	case enrichmentQueue <- enrichmentJob{username: username, relist: relist}:
+		enrichmentPending[username] = true
+	default:
+		log.Printf("Enrichment queue full, skipping %s", username)
+	}
+}
+
+// StartEnrichment processes queued enrichment jobs one at a time, so the
+// pipeline never adds concurrent load on the GitHub API
+func (gs *GitHubService) StartEnrichment() {
+	go func() {
+		for job := range enrichmentQueue {
+			enrichmentMutex.Lock()
+			delete(enrichmentPending, job.username)
+			enrichmentMutex.Unlock()
+
+			ctx, cancel := context.WithTimeout(withLowPriority(context.Background()), 10*time.Minute)
+			if err := gs.enrich(ctx, job); err != nil {
+				log.Printf("Enrichment for %s incomplete: %v", job.username, err)
+			}
+			cancel()
+		}
+	}()
+}
+
+func needsEnrichment(repos []models.GitHubRepository) bool {
+	for _, repo := range repos {
+		if repo.Enrichment.Status != models.EnrichmentComplete {
+			return true
+		}
+	}
+	return false
+}
+
+// enrich fills in missing fields repository by repository, saving and
+// invalidating the user's cache after each one so clients hydrate
+// progressively instead of waiting for the whole batch
+func (gs *GitHubService) enrich(ctx context.Context, job enrichmentJob) error {
+	repos, err := gs.storedRepositories(ctx, job.username)
+	if err != nil {
+		return err
+	}
+
+	if job.relist || len(repos) == 0 {
+		known := make(map[int64]models.GitHubRepository, len(repos))
+		for _, repo := range repos {
+			known[repo.GitHubID] = repo
+		}
+
+		repos, err = gs.listRepositories(ctx, job.username, known)
+		if err != nil {
+			return err
+		}
+		if err := gs.storeRepositories(ctx, repos); err != nil {
+			return err
+		}
+		gs.cacheService.InvalidateGitHubCache(ctx, job.username)
+	}
+
+	var firstErr error
+	for i := range repos {
+		repo := &repos[i]
+		if repo.Enrichment.Status == models.EnrichmentComplete {
+			continue
+		}
+
+		if err := gs.enrichRepository(ctx, repo); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", repo.Name, err)
+		}
+		if err := gs.storeEnrichment(ctx, repo); err != nil {
+			return err
+		}
+		gs.cacheService.InvalidateGitHubCache(ctx, job.username)
+	}
+
+	return firstErr
+}
+
+// enrichRepository fetches the fields repo is missing. Fields that fail to
+// load keep their previous value and are retried on the next run.
+func (gs *GitHubService) enrichRepository(ctx context.Context, repo *models.GitHubRepository) error {
+	var firstErr error
+	record := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if repo.NeedsLanguages() {
+		languages, err := gs.getRepositoryLanguages(ctx, repo.Owner, repo.Name)
+		if err == nil {
+			repo.Languages = languages
+			repo.Enrichment.LanguagesAt = time.Now()
+		}
+		record(err)
+	}
+
+	if repo.NeedsReadme() {
+		readme, err := gs.getRepositoryReadme(ctx, repo.Owner, repo.Name)
+		if err == nil {
+			repo.Readme = readme
+			repo.Enrichment.ReadmeAt = time.Now()
+		}
+		record(err)
+	}
+
+	if repo.NeedsContributors() {
+		contributors, err := gs.getRepositoryContributors(ctx, repo.Owner, repo.Name, contributorsLimit)
+		if err == nil {
+			repo.Contributors = contributors
+			repo.Enrichment.ContributorsAt = time.Now()
+		}
+		record(err)
+	}
+
+	repo.UpdateEnrichmentStatus()
+	return firstErr
+}
+
+func (gs *GitHubService) storeEnrichment(ctx context.Context, repo *models.GitHubRepository) error {
+	filter := bson.M{"github_id": repo.GitHubID}
+	update := bson.M{"$set": bson.M{
+		"languages":    repo.Languages,
+		"readme":       repo.Readme,
+		"contributors": repo.Contributors,
+		"enrichment":   repo.Enrichment,
+	}}
+
+	_, err := gs.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// getRepositoryReadme returns the start of the repository README, or an
+// empty string when it has none
+func (gs *GitHubService) getRepositoryReadme(ctx context.Context, username, repoName string) (string, error) {
+	url := githubAPI("/repos/%s/%s/readme", username, repoName)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if config.AppConfig.GitHubToken != "" {
+		req.Header.Set("Authorization", "token "+config.AppConfig.GitHubToken)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := gs.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch readme: %d", resp.StatusCode)
+	}
+
+	var file struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		return "", err
+	}
+	if file.Encoding != "base64" {
+		return "", fmt.Errorf("unsupported readme encoding %q", file.Encoding)
+	}
+
+	// GitHub wraps the base64 payload in newlines
+	content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return "", err
+	}
+
+	return truncateUTF8(string(content), readmeExcerptSize), nil
+}
+
+// getRepositoryContributors returns up to limit top contributors of a
+// repository (at most 100, a single page)
+func (gs *GitHubService) getRepositoryContributors(ctx context.Context, username, repoName string, limit int) ([]models.RepoContributor, error) {
+	url := githubAPI("/repos/%s/%s/contributors?per_page=%d", username, repoName, limit)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.AppConfig.GitHubToken != "" {
+		req.Header.Set("Authorization", "token "+config.AppConfig.GitHubToken)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := gs.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Empty repositories have no contributors
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch contributors: %d", resp.StatusCode)
+	}
+
+	var contributors []models.RepoContributor
+	if err := json.NewDecoder(resp.Body).Decode(&contributors); err != nil {
+		return nil, err
+	}
+
+	return contributors, nil
+}
+
+// truncateUTF8 cuts s to at most max bytes without splitting a character
+func truncateUTF8(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
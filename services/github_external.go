@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"portfolio-backend/models"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// externalSearchPages caps the search pages read per fetch; the search API
+// never returns more than ten pages of 100
+const externalSearchPages = 10
+
+type pullRequestSearch struct {
+	TotalCount int `json:"total_count"`
+	Items      []struct {
+		Number        int    `json:"number"`
+		Title         string `json:"title"`
+		HTMLURL       string `json:"html_url"`
+		RepositoryURL string `json:"repository_url"`
+		PullRequest   struct {
+			MergedAt *time.Time `json:"merged_at"`
+		} `json:"pull_request"`
+	} `json:"items"`
+}
+
+// GetExternalContributions returns the merged pull requests username authored
+// in repositories not owned by any of ownAccounts, newest first. They are
+// searched for once and then kept in MongoDB, where every sync adds new ones,
+// so the list outgrows the 1000 results the search API stops at.
+func (gs *GitHubService) GetExternalContributions(ctx context.Context, username string, ownAccounts []string) ([]models.ExternalContribution, error) {
+	// Try cache first
+	var contributions []models.ExternalContribution
+	if err := gs.cacheService.GetGitHubData(ctx, username, "external_contributions", &contributions); err == nil {
+		return contributions, nil
+	}
+
+	// Then the store
+	contributions, err := gs.storedExternalContributions(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contributions) == 0 {
+		if err := gs.SyncExternalContributions(ctx, username, ownAccounts); err != nil {
+			return nil, err
+		}
+		if contributions, err = gs.storedExternalContributions(ctx, username); err != nil {
+			return nil, err
+		}
+	}
+
+	gs.cacheService.SetGitHubData(ctx, username, "external_contributions", contributions)
+	return contributions, nil
+}
+
+// SyncExternalContributions searches for merged pull requests username
+// authored outside ownAccounts and stores the ones not seen before
+func (gs *GitHubService) SyncExternalContributions(ctx context.Context, username string, ownAccounts []string) error {
+	found, err := gs.searchExternalContributions(ctx, username, ownAccounts)
+	if err != nil {
+		return err
+	}
+	return gs.storeExternalContributions(ctx, found)
+}
+
+// externalContributionsQuery searches merged pull requests by username,
+// leaving out repositories of the given accounts
+func externalContributionsQuery(username string, ownAccounts []string) string {
+	terms := []string{"type:pr", "is:merged", "author:" + username}
+
+	seen := make(map[string]bool)
+	for _, account := range append([]string{username}, ownAccounts...) {
+		if key := strings.ToLower(account); !seen[key] {
+			seen[key] = true
+			terms = append(terms, "-user:"+account)
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+func (gs *GitHubService) searchExternalContributions(ctx context.Context, username string, ownAccounts []string) ([]models.ExternalContribution, error) {
+	query := url.QueryEscape(externalContributionsQuery(username, ownAccounts))
+
+	contributions := []models.ExternalContribution{}
+	for page := 1; page <= externalSearchPages; page++ {
+		endpoint := githubAPI("/search/issues?q=%s&sort=created&order=desc&per_page=100&page=%d", query, page)
+
+		var result pullRequestSearch
+		if err := gs.getJSON(ctx, endpoint, &result); err != nil {
+			return nil, err
+		}
+
+		for _, item := range result.Items {
+			if item.PullRequest.MergedAt == nil {
+				continue
+			}
+			contributions = append(contributions, models.ExternalContribution{
+				Username:   username,
+				Repository: repositoryFullName(item.RepositoryURL),
+				Number:     item.Number,
+				Title:      item.Title,
+				URL:        item.HTMLURL,
+				MergedAt:   *item.PullRequest.MergedAt,
+			})
+		}
+
+		if len(result.Items) < 100 || page*100 >= result.TotalCount {
+			break
+		}
+	}
+
+	return contributions, nil
+}
+
+// repositoryFullName turns https://api.github.com/repos/owner/name into
+// owner/name
+func repositoryFullName(repositoryURL string) string {
+	if i := strings.Index(repositoryURL, "/repos/"); i >= 0 {
+		return repositoryURL[i+len("/repos/"):]
+	}
+	return repositoryURL
+}
+
+func (gs *GitHubService) storedExternalContributions(ctx context.Context, username string) ([]models.ExternalContribution, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "merged_at", Value: -1}})
+	cursor, err := gs.external.Find(ctx, bson.M{"username": username}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	contributions := []models.ExternalContribution{}
+	err = cursor.All(ctx, &contributions)
+	return contributions, err
+}
+
+// storeExternalContributions upserts by pull request URL, so titles edited
+// after the merge are picked up
+func (gs *GitHubService) storeExternalContributions(ctx context.Context, contributions []models.ExternalContribution) error {
+	if len(contributions) == 0 {
+		return nil
+	}
+
+	var operations []mongo.WriteModel
+	for _, contribution := range contributions {
+		filter := bson.M{"username": contribution.Username, "url": contribution.URL}
+		update := bson.M{"$set": contribution}
+		operations = append(operations, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	_, err := gs.external.BulkWrite(ctx, operations)
+	return err
+}
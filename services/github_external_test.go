@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"portfolio-backend/config"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalContributionsQuery(t *testing.T) {
+	query := externalContributionsQuery("octocat", []string{"Octocat", "octo-org"})
+	assert.Equal(t, "type:pr is:merged author:octocat -user:octocat -user:octo-org", query)
+}
+
+func TestSearchExternalContributions(t *testing.T) {
+	useOfflineDatabase(t, &config.Config{CacheSerialization: "json"})
+
+	var queries []string
+	service := NewGitHubService()
+	service.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		queries = append(queries, req.URL.Query().Get("q"))
+
+		// A full first page, then a short second one
+		items := make([]string, 0, 100)
+		if req.URL.Query().Get("page") == "1" {
+			for i := 0; i < 100; i++ {
+				items = append(items, fmt.Sprintf(`{"number":%d,"title":"Fix %d","html_url":"https://github.com/golang/go/pull/%d",
+					"repository_url":"https://api.github.com/repos/golang/go","pull_request":{"merged_at":"2024-05-01T10:00:00Z"}}`, i, i, i))
+			}
+		} else {
+			items = append(items,
+				`{"number":7,"title":"Docs","html_url":"https://github.com/gin-gonic/gin/pull/7",
+				"repository_url":"https://api.github.com/repos/gin-gonic/gin","pull_request":{"merged_at":"2023-01-02T00:00:00Z"}}`,
+				// Search can still return closed pull requests without a merge date
+				`{"number":8,"title":"Closed","html_url":"https://github.com/gin-gonic/gin/pull/8",
+				"repository_url":"https://api.github.com/repos/gin-gonic/gin","pull_request":{"merged_at":null}}`)
+		}
+		body := fmt.Sprintf(`{"total_count":102,"items":[%s]}`, strings.Join(items, ","))
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})}
+
+	contributions, err := service.searchExternalContributions(context.Background(), "octocat", []string{"octocat"})
+	require.NoError(t, err)
+
+	assert.Len(t, queries, 2)
+	assert.Equal(t, "type:pr is:merged author:octocat -user:octocat", queries[0])
+	require.Len(t, contributions, 101)
+	assert.Equal(t, "golang/go", contributions[0].Repository)
+
+	last := contributions[100]
+	assert.Equal(t, "gin-gonic/gin", last.Repository)
+	assert.Equal(t, "Docs", last.Title)
+	assert.Equal(t, 7, last.Number)
+	assert.Equal(t, "octocat", last.Username)
+	assert.Equal(t, 2023, last.MergedAt.Year())
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/models"
+	"sort"
+	"time"
+)
+
+// gistPagesLimit caps how many pages of 100 gists are read
+const gistPagesLimit = 3
+
+type githubAPIGist struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	HTMLURL     string `json:"html_url"`
+	Public      bool   `json:"public"`
+	Files       map[string]struct {
+		Filename string `json:"filename"`
+		Type     string `json:"type"`
+		Language string `json:"language"`
+		RawURL   string `json:"raw_url"`
+		Size     int    `json:"size"`
+	} `json:"files"`
+	Comments  int       `json:"comments"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetGists returns a page of the user's public gists, most recently updated
+// first, along with how many were read
+func (gs *GitHubService) GetGists(ctx context.Context, username string, page, limit int) ([]models.Gist, int, error) {
+	gists, err := gs.listGists(ctx, username)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	start, end := pageRange(len(gists), page, limit)
+	return gists[start:end], len(gists), nil
+}
+
+func (gs *GitHubService) listGists(ctx context.Context, username string) ([]models.Gist, error) {
+	// Try cache first
+	var gists []models.Gist
+	if err := gs.cacheService.GetGitHubData(ctx, username, "gists", &gists); err == nil {
+		return gists, nil
+	}
+
+	gists = []models.Gist{}
+	for page := 1; page <= gistPagesLimit; page++ {
+		var apiGists []githubAPIGist
+		url := githubAPI("/users/%s/gists?page=%d&per_page=100", username, page)
+		if err := gs.getJSON(ctx, url, &apiGists); err != nil {
+			return nil, err
+		}
+
+		for _, gist := range apiGists {
+			// Secret gists are only listed to their owner's token
+			if gist.Public {
+				gists = append(gists, gistFromAPI(gist))
+			}
+		}
+		if len(apiGists) < 100 {
+			break
+		}
+	}
+
+	// Cache the result
+	gs.cacheService.SetGitHubData(ctx, username, "gists", gists)
+
+	return gists, nil
+}
+
+// gistFromAPI maps a gist, its files sorted by name
+func gistFromAPI(gist githubAPIGist) models.Gist {
+	mapped := models.Gist{
+		ID:          gist.ID,
+		Description: gist.Description,
+		HTMLURL:     gist.HTMLURL,
+		Files:       []models.GistFile{},
+		Comments:    gist.Comments,
+		CreatedAt:   gist.CreatedAt,
+		UpdatedAt:   gist.UpdatedAt,
+	}
+	for _, file := range gist.Files {
+		mapped.Files = append(mapped.Files, models.GistFile{
+			Filename: file.Filename,
+			Language: file.Language,
+			Type:     file.Type,
+			Size:     file.Size,
+			RawURL:   file.RawURL,
+		})
+	}
+	sort.Slice(mapped.Files, func(i, j int) bool {
+		return mapped.Files[i].Filename < mapped.Files[j].Filename
+	})
+	return mapped
+}
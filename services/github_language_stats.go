@@ -0,0 +1,62 @@
+package services
+
+import (
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"sort"
+)
+
+// languageStats sums the language bytes of public repositories, largest
+// first, with each language's share of the total. Forks only count with
+// GITHUB_LANGUAGES_INCLUDE_FORKS, archived repositories unless
+// GITHUB_LANGUAGES_INCLUDE_ARCHIVED is false. Until some breakdown has been
+// fetched, shares are by primary language and bytes stay zero.
+func languageStats(repos []models.GitHubRepository) []models.LanguageStat {
+	var counted []models.GitHubRepository
+	for _, repo := range repos {
+		if repo.Private ||
+			(repo.Fork && !config.AppConfig.GitHubLanguagesForks) ||
+			(repo.Archived && !config.AppConfig.GitHubLanguagesArchived) {
+			continue
+		}
+		counted = append(counted, repo)
+	}
+
+	bytes := make(map[string]int)
+	for _, repo := range counted {
+		for language, size := range repo.Languages {
+			bytes[language] += size
+		}
+	}
+
+	weights := bytes
+	if len(bytes) == 0 {
+		weights = make(map[string]int)
+		for _, repo := range counted {
+			if repo.Language != "" {
+				weights[repo.Language]++
+			}
+		}
+	}
+
+	total := 0
+	for _, weight := range weights {
+		total += weight
+	}
+
+	stats := make([]models.LanguageStat, 0, len(weights))
+	for name, weight := range weights {
+		stats = append(stats, models.LanguageStat{
+			Name:       name,
+			Bytes:      bytes[name],
+			Percentage: float64(weight) / float64(total) * 100,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Percentage != stats[j].Percentage {
+			return stats[i].Percentage > stats[j].Percentage
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
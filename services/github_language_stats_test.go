@@ -0,0 +1,54 @@
+package services
+
+import (
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguageStats(t *testing.T) {
+	config.AppConfig = &config.Config{GitHubLanguagesArchived: true}
+	repos := []models.GitHubRepository{
+		{Name: "api", Language: "Go", Languages: map[string]int{"Go": 6000, "Shell": 500}},
+		{Name: "site", Language: "TypeScript", Languages: map[string]int{"TypeScript": 3000, "Shell": 500}},
+		{Name: "old", Language: "Go", Archived: true, Languages: map[string]int{"Go": 2000}},
+		{Name: "linguist", Language: "Ruby", Fork: true, Languages: map[string]int{"Ruby": 90000}},
+		{Name: "secret", Language: "Rust", Private: true, Languages: map[string]int{"Rust": 90000}},
+	}
+
+	// Private repositories and forks are left out, archived ones kept
+	stats := languageStats(repos)
+	assert.Len(t, stats, 3)
+	assert.Equal(t, "Go", stats[0].Name)
+	assert.Equal(t, 8000, stats[0].Bytes)
+	assert.InDelta(t, 66.67, stats[0].Percentage, 0.01)
+	assert.Equal(t, "Shell", stats[2].Name)
+	assert.Equal(t, 1000, stats[2].Bytes)
+	assert.InDelta(t, 8.33, stats[2].Percentage, 0.01)
+
+	// Forks counted, archived repositories left out
+	config.AppConfig = &config.Config{GitHubLanguagesForks: true}
+	stats = languageStats(repos)
+	assert.Equal(t, "Ruby", stats[0].Name)
+	assert.Equal(t, 90000, stats[0].Bytes)
+	assert.InDelta(t, 90, stats[0].Percentage, 0.0001)
+	assert.Equal(t, 6000, stats[1].Bytes)
+}
+
+func TestLanguageStatsWithoutBreakdown(t *testing.T) {
+	config.AppConfig = &config.Config{GitHubLanguagesArchived: true}
+	repos := []models.GitHubRepository{
+		{Name: "api", Language: "Go"},
+		{Name: "cli", Language: "Go"},
+		{Name: "site", Language: "TypeScript"},
+		{Name: "notes"},
+	}
+
+	stats := languageStats(repos)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, "Go", stats[0].Name)
+	assert.Zero(t, stats[0].Bytes)
+	assert.InDelta(t, 66.67, stats[0].Percentage, 0.01)
+}
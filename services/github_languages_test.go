@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestFetchLanguagesConcurrently(t *testing.T) {
+	useOfflineDatabase(t, &config.Config{CacheSerialization: "json", GitHubLanguageConcurrency: 3})
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	service := NewGitHubService()
+	service.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"Go":100}`)),
+			Request:    req,
+		}, nil
+	})}
+
+	repos := make([]models.GitHubRepository, 8)
+	for i := range repos {
+		repos[i] = models.GitHubRepository{Name: string(rune('a' + i)), Language: "Go"}
+	}
+	// Already fetched since the last push
+	repos[7].Enrichment.LanguagesAt = time.Now()
+
+	fetched := service.fetchLanguages(context.Background(), "octocat", repos)
+	assert.Equal(t, 7, fetched)
+	assert.Equal(t, 3, maxInFlight)
+	for _, repo := range repos[:7] {
+		assert.Equal(t, map[string]int{"Go": 100}, repo.Languages, repo.Name)
+		assert.False(t, repo.Enrichment.LanguagesAt.IsZero())
+	}
+	assert.Nil(t, repos[7].Languages)
+}
+
+func TestFetchLanguagesKeepsStoredOnError(t *testing.T) {
+	useOfflineDatabase(t, &config.Config{CacheSerialization: "json", GitHubLanguageConcurrency: 2})
+
+	service := NewGitHubService()
+	service.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"message":"Not Found"}`)),
+			Request:    req,
+		}, nil
+	})}
+
+	// Pushed to since its languages were fetched
+	repos := []models.GitHubRepository{{
+		Name:      "api",
+		Language:  "Go",
+		Languages: map[string]int{"Go": 100},
+		PushedAt:  time.Now(),
+	}}
+	repos[0].Enrichment.LanguagesAt = time.Now().Add(-time.Hour)
+
+	assert.Equal(t, 0, service.fetchLanguages(context.Background(), "octocat", repos))
+	assert.Equal(t, map[string]int{"Go": 100}, repos[0].Languages)
+	assert.True(t, repos[0].NeedsLanguages())
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+)
+
+// orgReposLimit caps how many of an organization's most recently pushed
+// repositories are checked for the user's commits, one contributors call each
+const orgReposLimit = 100
+
+// orgContributorsLimit is the contributors page used to compute commit
+// shares; contributors beyond it are left out of the total
+const orgContributorsLimit = 100
+
+// GetOrganizationRepositories returns the public repositories of the user's
+// organizations that the user committed to, with the user's share of their
+// commits
+func (gs *GitHubService) GetOrganizationRepositories(ctx context.Context, username string) ([]models.OrgRepoContribution, error) {
+	// Try cache first
+	var contributions []models.OrgRepoContribution
+	if err := gs.cacheService.GetGitHubData(ctx, username, "org_repos", &contributions); err == nil {
+		return contributions, nil
+	}
+
+	orgs, err := gs.organizations(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	contributions = []models.OrgRepoContribution{}
+	for _, org := range orgs {
+		repos, err := gs.listOrganizationRepositories(ctx, org)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", org, err)
+		}
+
+		for _, repo := range repos {
+			if repo.Fork || repo.Private {
+				continue
+			}
+
+			contributors, err := gs.getRepositoryContributors(ctx, org, repo.Name, orgContributorsLimit)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", repo.FullName, err)
+			}
+
+			userCommits, totalCommits := commitShare(username, contributors)
+			if userCommits == 0 {
+				continue
+			}
+
+			contributions = append(contributions, models.OrgRepoContribution{
+				Organization: org,
+				Name:         repo.Name,
+				FullName:     repo.FullName,
+				HTMLURL:      repo.HTMLURL,
+				Language:     repo.Language,
+				Stars:        repo.StargazersCount,
+				Forks:        repo.ForksCount,
+				UserCommits:  userCommits,
+				TotalCommits: totalCommits,
+				Share:        float64(userCommits) / float64(totalCommits),
+			})
+		}
+	}
+
+	sort.Slice(contributions, func(i, j int) bool {
+		return contributions[i].Stars > contributions[j].Stars
+	})
+
+	// Cache the result
+	gs.cacheService.SetGitHubData(ctx, username, "org_repos", contributions)
+
+	return contributions, nil
+}
+
+// commitShare returns the user's commits and the commits of all listed
+// contributors
+func commitShare(username string, contributors []models.RepoContributor) (userCommits, totalCommits int) {
+	for _, contributor := range contributors {
+		totalCommits += contributor.Contributions
+		if strings.EqualFold(contributor.Login, username) {
+			userCommits = contributor.Contributions
+		}
+	}
+	return userCommits, totalCommits
+}
+
+// addOrganizationShare credits stats with the user's share of the stars and
+// forks of organization repositories, keeping owned and contributed totals
+// apart
+func addOrganizationShare(stats *models.GitHubStats, repos []models.OrgRepoContribution) {
+	stats.OrganizationRepos = repos
+	stats.Owned = &models.RepoTotals{Repos: stats.TotalRepos, Stars: stats.TotalStars, Forks: stats.TotalForks}
+	stats.Contributed = &models.RepoTotals{Repos: len(repos)}
+	for _, repo := range repos {
+		stats.Contributed.Stars += int(math.Round(float64(repo.Stars) * repo.Share))
+		stats.Contributed.Forks += int(math.Round(float64(repo.Forks) * repo.Share))
+	}
+	stats.TotalStars += stats.Contributed.Stars
+	stats.TotalForks += stats.Contributed.Forks
+}
+
+// includeOrganizationRepos reports whether stats credit organization
+// repositories: GITHUB_INCLUDE_ORG_REPOS, or organizations listed in
+// GITHUB_ORGANIZATIONS
+func includeOrganizationRepos() bool {
+	return config.AppConfig.GitHubIncludeOrgRepos || len(configuredOrganizations()) > 0
+}
+
+// configuredOrganizations lists the organizations of GITHUB_ORGANIZATIONS
+func configuredOrganizations() []string {
+	var orgs []string
+	for _, org := range strings.Split(config.AppConfig.GitHubOrganizations, ",") {
+		if org = strings.TrimSpace(org); org != "" {
+			orgs = append(orgs, org)
+		}
+	}
+	return orgs
+}
+
+// organizations returns the organizations checked for the user's commits:
+// those configured, or else the user's public memberships
+func (gs *GitHubService) organizations(ctx context.Context, username string) ([]string, error) {
+	if orgs := configuredOrganizations(); len(orgs) > 0 {
+		return orgs, nil
+	}
+	return gs.listOrganizations(ctx, username)
+}
+
+// listOrganizations returns the logins of the user's public organization
+// memberships
+func (gs *GitHubService) listOrganizations(ctx context.Context, username string) ([]string, error) {
+	url := githubAPI("/users/%s/orgs?per_page=100", username)
+
+	var orgs []struct {
+		Login string `json:"login"`
+	}
+	if err := gs.getJSON(ctx, url, &orgs); err != nil {
+		return nil, err
+	}
+
+	logins := make([]string, len(orgs))
+	for i, org := range orgs {
+		logins[i] = org.Login
+	}
+	return logins, nil
+}
+
+// listOrganizationRepositories returns the most recently pushed public
+// repositories of an organization
+func (gs *GitHubService) listOrganizationRepositories(ctx context.Context, org string) ([]models.GitHubAPIRepository, error) {
+	url := githubAPI("/orgs/%s/repos?type=public&sort=pushed&per_page=%d", org, orgReposLimit)
+
+	var repos []models.GitHubAPIRepository
+	if err := gs.getJSON(ctx, url, &repos); err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
+// getJSON decodes the response of a GitHub API GET request into target
+func (gs *GitHubService) getJSON(ctx context.Context, url string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if config.AppConfig.GitHubToken != "" {
+		req.Header.Set("Authorization", "token "+config.AppConfig.GitHubToken)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := gs.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitHub API error: %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
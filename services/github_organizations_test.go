@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfiguredOrganizations(t *testing.T) {
+	config.AppConfig = &config.Config{}
+	assert.False(t, includeOrganizationRepos())
+
+	config.AppConfig.GitHubIncludeOrgRepos = true
+	assert.True(t, includeOrganizationRepos())
+
+	// Configured organizations are used as they are, without listing the
+	// user's memberships
+	config.AppConfig = &config.Config{GitHubOrganizations: " acme, ,octo-org "}
+	assert.True(t, includeOrganizationRepos())
+
+	orgs, err := (&GitHubService{}).organizations(context.Background(), "octocat")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", "octo-org"}, orgs)
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"strings"
+	"time"
+)
+
+// ErrReadmeNotFound is returned for repositories without a README, or that
+// do not exist
+var ErrReadmeNotFound = errors.New("repository has no README")
+
+// GetRepositoryReadme returns the repository's README rendered by GitHub's
+// Markdown API and sanitized. With absoluteImages, relative image paths are
+// rewritten to raw.githubusercontent.com so the HTML can be embedded
+// anywhere.
+func (gs *GitHubService) GetRepositoryReadme(ctx context.Context, owner, repo string, absoluteImages bool) (*models.RepositoryReadme, error) {
+	// Try cache first
+	kind := "readme:" + strings.ToLower(repo)
+	var readme models.RepositoryReadme
+	if err := gs.cacheService.GetGitHubData(ctx, owner, kind, &readme); err != nil {
+		rendered, err := gs.renderReadme(ctx, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		readme = *rendered
+
+		// Cache the result
+		gs.cacheService.SetGitHubData(ctx, owner, kind, readme)
+	}
+
+	if absoluteImages {
+		readme.HTML = utils.SanitizeHTML(readme.HTML, readmeImageResolver(readme.RawURL, readme.Path))
+	}
+	return &readme, nil
+}
+
+func (gs *GitHubService) renderReadme(ctx context.Context, owner, repo string) (*models.RepositoryReadme, error) {
+	var file struct {
+		Path        string `json:"path"`
+		Content     string `json:"content"`
+		Encoding    string `json:"encoding"`
+		HTMLURL     string `json:"html_url"`
+		DownloadURL string `json:"download_url"`
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", githubAPI("/repos/%s/%s/readme", owner, repo), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.AppConfig.GitHubToken != "" {
+		req.Header.Set("Authorization", "token "+config.AppConfig.GitHubToken)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := gs.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrReadmeNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch readme: %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		return nil, err
+	}
+	if file.Encoding != "base64" {
+		return nil, fmt.Errorf("unsupported readme encoding %q", file.Encoding)
+	}
+
+	// GitHub wraps the base64 payload in newlines
+	markdown, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := gs.renderMarkdown(ctx, string(markdown), owner+"/"+repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RepositoryReadme{
+		Repository: owner + "/" + repo,
+		Path:       file.Path,
+		HTMLURL:    file.HTMLURL,
+		RawURL:     file.DownloadURL,
+		HTML:       utils.SanitizeHTML(rendered, nil),
+		FetchedAt:  time.Now(),
+	}, nil
+}
+
+// renderMarkdown renders GitHub Flavored Markdown with the Markdown API,
+// linking issue references to repository (owner/name)
+func (gs *GitHubService) renderMarkdown(ctx context.Context, markdown, repository string) (string, error) {
+	body, err := json.Marshal(map[string]string{"text": markdown, "mode": "gfm", "context": repository})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", githubAPI("/markdown"), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	if config.AppConfig.GitHubToken != "" {
+		req.Header.Set("Authorization", "token "+config.AppConfig.GitHubToken)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := gs.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to render markdown: %d", resp.StatusCode)
+	}
+
+	rendered, err := io.ReadAll(resp.Body)
+	return string(rendered), err
+}
+
+// readmeImageResolver resolves image paths relative to the README, whose
+// source is downloaded from rawURL, and paths starting with / relative to
+// the root of the repository
+func readmeImageResolver(rawURL, path string) func(string) string {
+	base, err := url.Parse(rawURL)
+	if err != nil || rawURL == "" {
+		return func(src string) string { return src }
+	}
+	root := strings.TrimSuffix(rawURL, path)
+
+	return func(src string) string {
+		parsed, err := url.Parse(src)
+		if err != nil || parsed.IsAbs() || parsed.Host != "" || parsed.Path == "" {
+			return src
+		}
+		if strings.HasPrefix(src, "/") {
+			return root + strings.TrimPrefix(src, "/")
+		}
+		return base.ResolveReference(parsed).String()
+	}
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadmeImageResolver(t *testing.T) {
+	resolve := readmeImageResolver("https://raw.githubusercontent.com/octocat/hello/main/docs/README.md", "docs/README.md")
+
+	assert.Equal(t, "https://raw.githubusercontent.com/octocat/hello/main/docs/img/logo.png", resolve("img/logo.png"))
+	assert.Equal(t, "https://raw.githubusercontent.com/octocat/hello/main/assets/logo.png", resolve("../assets/logo.png"))
+	assert.Equal(t, "https://raw.githubusercontent.com/octocat/hello/main/assets/logo.png", resolve("/assets/logo.png"))
+
+	// Absolute and protocol-relative images are left alone
+	assert.Equal(t, "https://camo.githubusercontent.com/abc", resolve("https://camo.githubusercontent.com/abc"))
+	assert.Equal(t, "//cdn.example/logo.png", resolve("//cdn.example/logo.png"))
+
+	// Without a download URL nothing can be resolved
+	assert.Equal(t, "img/logo.png", readmeImageResolver("", "README.md")("img/logo.png"))
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"log"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// releasesCollection keeps the releases already seen in the owner's
+// repositories
+const releasesCollection = "github_releases"
+
+// releaseDraftAuthor is recorded as the author of changelog drafts made
+// from releases
+const releaseDraftAuthor = "github-release"
+
+// githubEvent is an entry of the public events feed of a user; only
+// release events are decoded
+type githubEvent struct {
+	Type string `json:"type"`
+	Repo struct {
+		Name string `json:"name"`
+	} `json:"repo"`
+	Payload struct {
+		Action  string `json:"action"`
+		Release struct {
+			TagName     string    `json:"tag_name"`
+			Name        string    `json:"name"`
+			Body        string    `json:"body"`
+			HTMLURL     string    `json:"html_url"`
+			Draft       bool      `json:"draft"`
+			Prerelease  bool      `json:"prerelease"`
+			PublishedAt time.Time `json:"published_at"`
+		} `json:"release"`
+	} `json:"payload"`
+}
+
+// SyncReleases looks for releases published in username's own repositories
+// since the last sync, through the public events feed. Each new release is
+// announced on the github topic of the live updates and, with
+// GITHUB_RELEASE_DRAFTS, added to the changelog draft for the admin to
+// review and publish. The first sync only records the releases already out.
+func (gs *GitHubService) SyncReleases(ctx context.Context, username string) error {
+	var events []githubEvent
+	url := githubAPI("/users/%s/events/public?per_page=100", username)
+	if err := gs.getJSON(ctx, url, &events); err != nil {
+		return err
+	}
+
+	known, err := gs.releases.CountDocuments(ctx, bson.M{"username": username}, options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+
+	var found []models.GitHubRelease
+	for _, release := range releasesFromEvents(username, events) {
+		filter := bson.M{"url": release.URL}
+		update := bson.M{"$setOnInsert": release}
+		result, err := gs.releases.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+		if result.UpsertedCount > 0 {
+			found = append(found, release)
+		}
+	}
+	if known == 0 || len(found) == 0 {
+		return nil
+	}
+
+	for _, release := range found {
+		PublishLive(models.LiveTopicGitHub, models.LiveGitHubRelease, map[string]interface{}{
+			"username":     username,
+			"repository":   release.Repository,
+			"tag":          release.Tag,
+			"url":          release.URL,
+			"published_at": release.PublishedAt,
+		})
+	}
+
+	if config.AppConfig.GitHubReleaseDrafts {
+		if err := NewContentService().DraftReleaseNotes(ctx, found); err != nil {
+			log.Printf("Failed to draft the changelog for new releases of %s: %v", username, err)
+		}
+	}
+	return nil
+}
+
+// isPortfolioAccount reports whether username is one of accounts; GitHub
+// logins are case-insensitive
+func isPortfolioAccount(accounts []string, username string) bool {
+	for _, account := range accounts {
+		if strings.EqualFold(account, username) {
+			return true
+		}
+	}
+	return false
+}
+
+// releasesFromEvents returns the releases published in repositories owned by
+// username, oldest first. Drafts and prereleases are left out.
+func releasesFromEvents(username string, events []githubEvent) []models.GitHubRelease {
+	var releases []models.GitHubRelease
+	for _, event := range events {
+		release := event.Payload.Release
+		if event.Type != "ReleaseEvent" || event.Payload.Action != "published" || release.Draft || release.Prerelease {
+			continue
+		}
+		owner, _, _ := strings.Cut(event.Repo.Name, "/")
+		if !strings.EqualFold(owner, username) {
+			continue
+		}
+
+		releases = append(releases, models.GitHubRelease{
+			Username:    username,
+			Repository:  event.Repo.Name,
+			Tag:         release.TagName,
+			Name:        release.Name,
+			Body:        release.Body,
+			URL:         release.HTMLURL,
+			PublishedAt: release.PublishedAt,
+			SeenAt:      time.Now(),
+		})
+	}
+
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releases[i].PublishedAt.Before(releases[j].PublishedAt)
+	})
+	return releases
+}
+
+// DraftReleaseNotes adds an entry per release to the top of the changelog
+// draft, started from the published changelog when there is none. Releases
+// already listed are skipped.
+func (cs *ContentService) DraftReleaseNotes(ctx context.Context, releases []models.GitHubRelease) error {
+	var changelog []models.ChangelogEntry
+	draft, err := cs.GetDraft(ctx, "changelog")
+	switch {
+	case err == nil:
+		changelog, _ = draft.Data.([]models.ChangelogEntry)
+	case err == ErrDraftNotFound:
+		if changelog, err = cs.GetChangelog(ctx); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	entries := releaseEntries(releases, changelog)
+	if len(entries) == 0 {
+		return nil
+	}
+	_, err = cs.SaveDraft(ctx, "changelog", append(entries, changelog...), releaseDraftAuthor)
+	return err
+}
+
+// releaseEntries turns the releases not yet in changelog into changelog
+// entries, newest first
+func releaseEntries(releases []models.GitHubRelease, changelog []models.ChangelogEntry) []models.ChangelogEntry {
+	listed := make(map[string]bool, len(changelog))
+	for _, entry := range changelog {
+		if entry.URL != "" {
+			listed[entry.URL] = true
+		}
+	}
+
+	var entries []models.ChangelogEntry
+	for _, release := range releases {
+		if listed[release.URL] {
+			continue
+		}
+		listed[release.URL] = true
+		entries = append(entries, models.ChangelogEntry{
+			Title:      releaseTitle(release),
+			Body:       release.Body,
+			Repository: release.Repository,
+			Tag:        release.Tag,
+			URL:        release.URL,
+			Date:       release.PublishedAt,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Date.After(entries[j].Date)
+	})
+	return entries
+}
+
+// releaseTitle names a release after its repository, with its name when it
+// has one besides the tag
+func releaseTitle(release models.GitHubRelease) string {
+	_, repo, _ := strings.Cut(release.Repository, "/")
+	name := strings.TrimSpace(release.Name)
+	if name == "" || name == release.Tag {
+		return repo + " " + release.Tag
+	}
+	return repo + " " + release.Tag + ": " + name
+}
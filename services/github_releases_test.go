@@ -0,0 +1,50 @@
+package services
+
+import (
+	"encoding/json"
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleasesFromEvents(t *testing.T) {
+	var events []githubEvent
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"type": "ReleaseEvent", "repo": {"name": "octocat/hello"}, "payload": {"action": "published", "release": {"tag_name": "v1.1.0", "name": "Dark mode", "body": "Notes", "html_url": "https://github.com/octocat/hello/releases/tag/v1.1.0", "published_at": "2024-05-02T10:00:00Z"}}},
+		{"type": "ReleaseEvent", "repo": {"name": "OctoCat/hello"}, "payload": {"action": "published", "release": {"tag_name": "v1.0.0", "html_url": "https://github.com/octocat/hello/releases/tag/v1.0.0", "published_at": "2024-05-01T10:00:00Z"}}},
+		{"type": "ReleaseEvent", "repo": {"name": "octocat/hello"}, "payload": {"action": "published", "release": {"tag_name": "v2.0.0-rc1", "prerelease": true}}},
+		{"type": "ReleaseEvent", "repo": {"name": "someone/else"}, "payload": {"action": "published", "release": {"tag_name": "v3.0.0"}}},
+		{"type": "PushEvent", "repo": {"name": "octocat/hello"}, "payload": {}}
+	]`), &events))
+
+	releases := releasesFromEvents("octocat", events)
+	require.Len(t, releases, 2)
+	assert.Equal(t, "v1.0.0", releases[0].Tag, "oldest first")
+	assert.Equal(t, "OctoCat/hello", releases[0].Repository)
+	assert.Equal(t, "Dark mode", releases[1].Name)
+	assert.Equal(t, "octocat", releases[1].Username)
+}
+
+func TestReleaseEntries(t *testing.T) {
+	releases := []models.GitHubRelease{
+		{Repository: "octocat/hello", Tag: "v1.0.0", Name: "v1.0.0", URL: "https://example.com/v1.0.0", PublishedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{Repository: "octocat/hello", Tag: "v1.1.0", Name: "Dark mode", Body: "Notes", URL: "https://example.com/v1.1.0", PublishedAt: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)},
+		{Repository: "octocat/hello", Tag: "v0.9.0", URL: "https://example.com/v0.9.0"},
+	}
+	changelog := []models.ChangelogEntry{{Title: "hello v0.9.0", URL: "https://example.com/v0.9.0"}}
+
+	entries := releaseEntries(releases, changelog)
+	require.Len(t, entries, 2, "releases already in the changelog are skipped")
+	assert.Equal(t, "hello v1.1.0: Dark mode", entries[0].Title, "newest first")
+	assert.Equal(t, "Notes", entries[0].Body)
+	assert.Equal(t, "hello v1.0.0", entries[1].Title)
+	assert.Equal(t, releases[0].PublishedAt, entries[1].Date)
+}
+
+func TestIsPortfolioAccount(t *testing.T) {
+	assert.True(t, isPortfolioAccount([]string{"octocat", "my-org"}, "My-Org"))
+	assert.False(t, isPortfolioAccount([]string{"octocat"}, "someone"))
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+)
+
+// RepositorySorts are the accepted RepositoryQuery.Sort values
+var RepositorySorts = []string{"stars", "updated", "name"}
+
+// QueryRepositories filters and sorts repos as asked by query and returns
+// the requested page along with the number of matching repositories
+func QueryRepositories(repos []models.GitHubRepository, query models.RepositoryQuery) ([]models.GitHubRepository, int) {
+	matched := []models.GitHubRepository{}
+	for _, repo := range repos {
+		if repositoryMatches(repo, query) {
+			matched = append(matched, repo)
+		}
+	}
+
+	sortRepositories(matched, query.Sort, query.Order)
+
+	start, end := pageRange(len(matched), query.Page, query.Limit)
+	return matched[start:end], len(matched)
+}
+
+// pageRange is the slice of total items holding page, all of them without
+// a limit and none past the last page
+func pageRange(total, page, limit int) (int, int) {
+	if limit <= 0 {
+		return 0, total
+	}
+
+	start := (page - 1) * limit
+	if start < 0 || start >= total {
+		return total, total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+func repositoryMatches(repo models.GitHubRepository, query models.RepositoryQuery) bool {
+	if query.Language != "" && !strings.EqualFold(repo.Language, query.Language) {
+		return false
+	}
+	if query.Fork != nil && repo.Fork != *query.Fork {
+		return false
+	}
+	if query.Archived != nil && repo.Archived != *query.Archived {
+		return false
+	}
+	if query.Topic != "" {
+		for _, topic := range repo.Topics {
+			if strings.EqualFold(topic, query.Topic) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// sortRepositories sorts stars and updated newest/highest first and name
+// alphabetically, unless order says otherwise. Ties are broken by name,
+// alphabetically.
+func sortRepositories(repos []models.GitHubRepository, by, order string) {
+	if by == "" {
+		return
+	}
+
+	descending := by != "name"
+	switch order {
+	case "asc":
+		descending = false
+	case "desc":
+		descending = true
+	}
+
+	sort.SliceStable(repos, func(i, j int) bool {
+		a, b := repos[i], repos[j]
+		if descending {
+			a, b = b, a
+		}
+
+		switch by {
+		case "stars":
+			if a.StargazersCount != b.StargazersCount {
+				return a.StargazersCount < b.StargazersCount
+			}
+		case "updated":
+			if !a.UpdatedAt.Equal(b.UpdatedAt) {
+				return a.UpdatedAt.Before(b.UpdatedAt)
+			}
+		case "name":
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+		return strings.ToLower(repos[i].Name) < strings.ToLower(repos[j].Name)
+	})
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleRepositoryListing() []models.GitHubRepository {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	return []models.GitHubRepository{
+		{Name: "api", Language: "Go", StargazersCount: 10, Topics: []string{"backend"}, UpdatedAt: day(3)},
+		{Name: "web", Language: "TypeScript", StargazersCount: 25, Topics: []string{"frontend"}, UpdatedAt: day(5)},
+		{Name: "cli", Language: "go", StargazersCount: 10, Fork: true, UpdatedAt: day(1)},
+		{Name: "Blog", Language: "Go", StargazersCount: 2, Archived: true, Topics: []string{"Backend"}, UpdatedAt: day(4)},
+	}
+}
+
+func repositoryNames(repos []models.GitHubRepository) []string {
+	names := make([]string, len(repos))
+	for i, repo := range repos {
+		names[i] = repo.Name
+	}
+	return names
+}
+
+func TestQueryRepositoriesSorts(t *testing.T) {
+	tests := []struct {
+		sort, order string
+		want        []string
+	}{
+		{"", "", []string{"api", "web", "cli", "Blog"}},
+		{"stars", "", []string{"web", "api", "cli", "Blog"}},
+		{"stars", "asc", []string{"Blog", "api", "cli", "web"}},
+		{"updated", "", []string{"web", "Blog", "api", "cli"}},
+		{"name", "", []string{"api", "Blog", "cli", "web"}},
+		{"name", "desc", []string{"web", "cli", "Blog", "api"}},
+	}
+
+	for _, tt := range tests {
+		page, total := QueryRepositories(sampleRepositoryListing(), models.RepositoryQuery{Page: 1, Limit: 10, Sort: tt.sort, Order: tt.order})
+		assert.Equal(t, 4, total)
+		assert.Equal(t, tt.want, repositoryNames(page), tt.sort+" "+tt.order)
+	}
+}
+
+func TestQueryRepositoriesFilters(t *testing.T) {
+	no := false
+
+	page, total := QueryRepositories(sampleRepositoryListing(), models.RepositoryQuery{Page: 1, Limit: 10, Language: "GO", Fork: &no, Archived: &no})
+	assert.Equal(t, 1, total)
+	assert.Equal(t, []string{"api"}, repositoryNames(page))
+
+	page, total = QueryRepositories(sampleRepositoryListing(), models.RepositoryQuery{Page: 1, Limit: 10, Topic: "backend", Sort: "name"})
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []string{"api", "Blog"}, repositoryNames(page))
+}
+
+func TestQueryRepositoriesPages(t *testing.T) {
+	query := models.RepositoryQuery{Page: 2, Limit: 3, Sort: "name"}
+	page, total := QueryRepositories(sampleRepositoryListing(), query)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, []string{"web"}, repositoryNames(page))
+
+	query.Page = 3
+	page, total = QueryRepositories(sampleRepositoryListing(), query)
+	assert.Equal(t, 4, total)
+	assert.Empty(t, page)
+}
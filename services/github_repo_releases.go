@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/models"
+	"strings"
+	"time"
+)
+
+// repoReleasesLimit is how many of the latest releases and tags of a
+// repository are read, a single page of each
+const repoReleasesLimit = 30
+
+type githubAPIRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []struct {
+		Name               string `json:"name"`
+		ContentType        string `json:"content_type"`
+		Size               int64  `json:"size"`
+		DownloadCount      int    `json:"download_count"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+type githubAPITag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+// GetRepositoryReleases returns the latest releases of a repository with
+// their assets and download counts, along with its latest tags
+func (gs *GitHubService) GetRepositoryReleases(ctx context.Context, owner, repo string) (*models.RepositoryReleases, error) {
+	// Try cache first
+	kind := "releases:" + strings.ToLower(repo)
+	var releases models.RepositoryReleases
+	if err := gs.cacheService.GetGitHubData(ctx, owner, kind, &releases); err == nil {
+		return &releases, nil
+	}
+
+	var apiReleases []githubAPIRelease
+	if err := gs.getJSON(ctx, githubAPI("/repos/%s/%s/releases?per_page=%d", owner, repo, repoReleasesLimit), &apiReleases); err != nil {
+		return nil, err
+	}
+	var apiTags []githubAPITag
+	if err := gs.getJSON(ctx, githubAPI("/repos/%s/%s/tags?per_page=%d", owner, repo, repoReleasesLimit), &apiTags); err != nil {
+		return nil, err
+	}
+
+	releases = repositoryReleases(owner+"/"+repo, apiReleases, apiTags)
+
+	// Cache the result
+	gs.cacheService.SetGitHubData(ctx, owner, kind, releases)
+
+	return &releases, nil
+}
+
+// repositoryReleases maps the releases, leaving drafts out, and the tags
+func repositoryReleases(repository string, apiReleases []githubAPIRelease, apiTags []githubAPITag) models.RepositoryReleases {
+	releases := models.RepositoryReleases{
+		Repository:  repository,
+		Releases:    []models.RepositoryRelease{},
+		Tags:        []models.RepositoryTag{},
+		LastFetched: time.Now(),
+	}
+
+	for _, apiRelease := range apiReleases {
+		// Drafts are only listed to tokens that can push to the repository
+		if apiRelease.Draft {
+			continue
+		}
+
+		release := models.RepositoryRelease{
+			Tag:         apiRelease.TagName,
+			Name:        apiRelease.Name,
+			Notes:       apiRelease.Body,
+			HTMLURL:     apiRelease.HTMLURL,
+			Prerelease:  apiRelease.Prerelease,
+			PublishedAt: apiRelease.PublishedAt,
+			Assets:      []models.ReleaseAsset{},
+		}
+		if release.Name == "" {
+			release.Name = release.Tag
+		}
+		for _, asset := range apiRelease.Assets {
+			release.Assets = append(release.Assets, models.ReleaseAsset{
+				Name:          asset.Name,
+				ContentType:   asset.ContentType,
+				Size:          asset.Size,
+				DownloadCount: asset.DownloadCount,
+				DownloadURL:   asset.BrowserDownloadURL,
+			})
+			release.Downloads += asset.DownloadCount
+		}
+
+		releases.TotalDownloads += release.Downloads
+		releases.Releases = append(releases.Releases, release)
+	}
+
+	for _, tag := range apiTags {
+		releases.Tags = append(releases.Tags, models.RepositoryTag{Name: tag.Name, SHA: tag.Commit.SHA})
+	}
+
+	return releases
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/metrics"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrGitHubUnavailable is returned when GitHub keeps failing or rate limiting
+// calls, and while the circuit breaker is open after that
+var ErrGitHubUnavailable = errors.New("GitHub API unavailable")
+
+// githubRetryBaseDelay is the first backoff delay, doubled on every retry
+var githubRetryBaseDelay = 500 * time.Millisecond
+
+// Exported on /metrics
+var (
+	githubRequestDuration = metrics.NewHistogramVec("github_api_request_duration_seconds",
+		"GitHub API call latency by response status, or error when no response came back.", metrics.DefaultBuckets, "status")
+	githubRateLimitRemaining = metrics.NewGaugeVec("github_rate_limit_remaining",
+		"Calls left in the current GitHub rate limit window, by resource.", "resource")
+)
+
+// githubBreaker is shared by every GitHubService, as they all call the same API
+var githubBreaker circuitBreaker
+
+// circuitBreaker stops calling GitHub for GITHUB_BREAKER_COOLDOWN once
+// GITHUB_BREAKER_THRESHOLD calls in a row have failed. After the cooldown a
+// single call is let through; its outcome closes or reopens the breaker.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// allow reports whether a call may be made now
+func (b *circuitBreaker) allow(now time.Time) bool {
+	threshold := config.AppConfig.GitHubBreakerThreshold
+	if threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < threshold {
+		return true
+	}
+	if now.Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record counts the outcome of a call that allow let through
+func (b *circuitBreaker) record(success bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if success {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if threshold := config.AppConfig.GitHubBreakerThreshold; threshold > 0 && b.failures >= threshold {
+		b.openUntil = now.Add(config.AppConfig.GitHubBreakerCooldown)
+	}
+}
+
+// abandon lets another call probe when one let through gave up before
+// GitHub answered
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
+
+// doWithRetry sends req with send, retrying network errors, server errors
+// and rate limited responses up to GITHUB_RETRIES times. Retries back off
+// exponentially with jitter, or wait as long as GitHub asks through
+// Retry-After or X-RateLimit-Reset when that is within GITHUB_RETRY_MAX_WAIT.
+// Calls still failing count against the circuit breaker and are reported as
+// ErrGitHubUnavailable.
+func doWithRetry(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if !githubBreaker.allow(time.Now()) {
+		return nil, fmt.Errorf("%w: circuit breaker open", ErrGitHubUnavailable)
+	}
+
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		resp, err := send(req)
+		observeGitHubCall(resp, time.Since(start))
+		if err != nil && ctx.Err() != nil {
+			// The caller gave up; that says nothing about GitHub
+			githubBreaker.abandon()
+			return nil, err
+		}
+
+		wait, retryable := githubRetryDelay(resp, err, attempt, time.Now())
+		if !retryable {
+			githubBreaker.record(true, time.Now())
+			return resp, err
+		}
+
+		canRetry := attempt < config.AppConfig.GitHubRetries &&
+			wait <= config.AppConfig.GitHubRetryMaxWait &&
+			rewindBody(req)
+		if !canRetry {
+			githubBreaker.record(false, time.Now())
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrGitHubUnavailable, err)
+			}
+			resp.Body.Close()
+			return nil, fmt.Errorf("%w: status %d", ErrGitHubUnavailable, resp.StatusCode)
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			githubBreaker.abandon()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// observeGitHubCall records the latency of a single attempt and the rate
+// limit GitHub reported with it
+func observeGitHubCall(resp *http.Response, duration time.Duration) {
+	if resp == nil {
+		githubRequestDuration.Observe(duration.Seconds(), "error")
+		return
+	}
+	githubRequestDuration.Observe(duration.Seconds(), strconv.Itoa(resp.StatusCode))
+
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	resource := resp.Header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "core"
+	}
+	githubRateLimitRemaining.Set(float64(remaining), resource)
+}
+
+// githubRetryDelay tells whether the outcome of a call is worth retrying and
+// how long to wait before the given retry (from 0)
+func githubRetryDelay(resp *http.Response, err error, attempt int, now time.Time) (time.Duration, bool) {
+	if err != nil {
+		return backoffDelay(attempt), true
+	}
+
+	// Secondary rate limits and overloaded servers say how long to wait
+	if seconds, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && seconds >= 0 {
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	switch {
+	case resp.StatusCode >= 500:
+		return backoffDelay(attempt), true
+	case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests:
+		if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+			if resp.StatusCode == http.StatusTooManyRequests {
+				return backoffDelay(attempt), true
+			}
+			// A plain permission error
+			return 0, false
+		}
+		// Primary rate limit: wait for the window to reset
+		reset, convErr := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if convErr != nil {
+			return backoffDelay(attempt), true
+		}
+		wait := time.Unix(reset, 0).Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// backoffDelay doubles githubRetryBaseDelay per attempt and picks a random
+// delay in the upper half, so instances retrying together spread out
+func backoffDelay(attempt int) time.Duration {
+	delay := githubRetryBaseDelay << attempt
+	if delay <= 1 {
+		return delay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)))
+}
+
+// rewindBody prepares req to be sent again, reporting false when its body
+// cannot be replayed
+func rewindBody(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+	return true
+}
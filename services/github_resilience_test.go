@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubRetryDelay(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	response := func(status int, headers map[string]string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		for k, v := range headers {
+			resp.Header.Set(k, v)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name      string
+		resp      *http.Response
+		retryable bool
+		wait      time.Duration // checked when non-zero
+	}{
+		{"ok", response(http.StatusOK, nil), false, 0},
+		{"not found", response(http.StatusNotFound, nil), false, 0},
+		{"server error", response(http.StatusBadGateway, nil), true, 0},
+		{"secondary rate limit", response(http.StatusForbidden, map[string]string{"Retry-After": "3"}), true, 3 * time.Second},
+		{"primary rate limit", response(http.StatusForbidden, map[string]string{
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     strconv.FormatInt(now.Add(40*time.Second).Unix(), 10),
+		}), true, 40 * time.Second},
+		{"forbidden", response(http.StatusForbidden, map[string]string{"X-RateLimit-Remaining": "4999"}), false, 0},
+		{"too many requests", response(http.StatusTooManyRequests, nil), true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, retryable := githubRetryDelay(tt.resp, nil, 0, now)
+			assert.Equal(t, tt.retryable, retryable)
+			if tt.wait != 0 {
+				assert.Equal(t, tt.wait, wait)
+			}
+		})
+	}
+
+	_, retryable := githubRetryDelay(nil, context.DeadlineExceeded, 0, now)
+	assert.True(t, retryable)
+}
+
+func TestBackoffDelayGrowsWithJitter(t *testing.T) {
+	for attempt := 0; attempt < 4; attempt++ {
+		full := githubRetryBaseDelay << attempt
+		delay := backoffDelay(attempt)
+		assert.GreaterOrEqual(t, delay, full/2)
+		assert.Less(t, delay, full)
+	}
+}
+
+func TestDoWithRetryRecoversFromServerErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	config.AppConfig = &config.Config{GitHubRetries: 2, GitHubRetryMaxWait: time.Second, GitHubBreakerThreshold: 5, GitHubBreakerCooldown: time.Minute}
+	githubBreaker = circuitBreaker{}
+	original := githubRetryBaseDelay
+	githubRetryBaseDelay = time.Millisecond
+	defer func() { githubRetryBaseDelay = original }()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+	resp, err := doWithRetry(req, server.Client().Do)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, calls)
+	assert.Zero(t, githubBreaker.failures)
+}
+
+func TestDoWithRetryGivesUpOnLongRateLimits(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	config.AppConfig = &config.Config{GitHubRetries: 2, GitHubRetryMaxWait: time.Second, GitHubBreakerThreshold: 5, GitHubBreakerCooldown: time.Minute}
+	githubBreaker = circuitBreaker{}
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+	_, err = doWithRetry(req, server.Client().Do)
+	assert.ErrorIs(t, err, ErrGitHubUnavailable)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, githubBreaker.failures)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	config.AppConfig = &config.Config{GitHubBreakerThreshold: 2, GitHubBreakerCooldown: time.Minute}
+	breaker := &circuitBreaker{}
+	now := time.Now()
+
+	breaker.record(false, now)
+	assert.True(t, breaker.allow(now))
+	breaker.record(false, now)
+	assert.False(t, breaker.allow(now), "open after the threshold")
+
+	// After the cooldown a single probe goes through
+	later := now.Add(time.Minute)
+	assert.True(t, breaker.allow(later))
+	assert.False(t, breaker.allow(later))
+
+	breaker.record(false, later)
+	assert.False(t, breaker.allow(later.Add(time.Second)), "a failed probe reopens it")
+
+	breaker.abandon()
+	assert.True(t, breaker.allow(later.Add(time.Minute)))
+	breaker.record(true, later.Add(time.Minute))
+	assert.True(t, breaker.allow(later.Add(time.Minute)))
+	assert.True(t, breaker.allow(later.Add(time.Minute)), "closed after a successful probe")
+
+	config.AppConfig.GitHubBreakerThreshold = 0
+	breaker.record(false, now)
+	breaker.record(false, now)
+	assert.True(t, breaker.allow(now), "disabled")
+}
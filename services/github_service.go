@@ -3,14 +3,17 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"portfolio-backend/config"
 	"portfolio-backend/database"
+	"portfolio-backend/metrics"
 	"portfolio-backend/models"
-	"strconv"
-	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -23,20 +26,104 @@ type GitHubService struct {
 	client       *http.Client
 	cacheService *CacheService
 	collection   *mongo.Collection
+	conditional  *mongo.Collection // validators of past GET responses
+	external     *mongo.Collection // merged pull requests in other people's repositories
+	releases     *mongo.Collection // releases already seen in own repositories
+	traffic      *mongo.Collection // daily views and clones of own repositories
+
+	// enqueueEnrichment hands repositories that still miss languages,
+	// READMEs or contributors to the background enrichment pipeline
+	enqueueEnrichment func(username string, relist bool)
 }
 
 func NewGitHubService() *GitHubService {
-	return &GitHubService{
+	gs := &GitHubService{
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 		cacheService: NewCacheService(),
 		collection:   database.Database.Collection("github_data"),
+		conditional:  database.Database.Collection("github_conditional"),
+		external:     database.Database.Collection("github_external_contributions"),
+		releases:     database.Database.Collection(releasesCollection),
+		traffic:      database.Database.Collection(trafficCollection),
 	}
+	gs.enqueueEnrichment = EnqueueEnrichment
+	return gs
 }
 
-// GetProfile retrieves GitHub profile information
+// do sends a GitHub API request, spending one call of the request's
+// upstream budget (see WithUpstreamBudget). Non-urgent calls wait while the
+// rate limit runs low (see throttleGitHub). GETs are made conditional on
+// the last response for the same URL (see doConditional). Failures are
+// retried and tracked by the circuit breaker (see doWithRetry).
+func (gs *GitHubService) do(req *http.Request) (*http.Response, error) {
+	if !takeUpstreamCall(req.Context()) {
+		return nil, ErrUpstreamBudgetExhausted
+	}
+	if err := throttleGitHub(req.Context()); err != nil {
+		return nil, err
+	}
+	if req.Method == http.MethodGet {
+		return doWithRetry(req, gs.doConditional)
+	}
+	return doWithRetry(req, gs.send)
+}
+
+// send makes one GitHub API call, adding its time to the github layer of the
+// request's timings, and the rate limit GitHub reports for the token it was
+// made with. Reads made with GITHUB_TOKEN go out with the pooled
+// token that has the most calls left; one refused because its token ran out
+// is repeated with the next token, if any is left.
+func (gs *GitHubService) send(req *http.Request) (*http.Response, error) {
+	tokens := githubTokenList()
+	if !pooledRequest(req, tokens) {
+		resp, err := gs.sendOnce(req)
+		if token, ok := requestToken(req, tokens); ok && err == nil {
+			githubTokens.observe(token, resp)
+		}
+		return resp, err
+	}
+
+	for i := 0; ; i++ {
+		token, _ := githubTokens.pick(tokens, time.Now())
+		req.Header.Set("Authorization", "token "+token)
+		resp, err := gs.sendOnce(req)
+		if err != nil {
+			return nil, err
+		}
+		githubTokens.observe(token, resp)
+		if !rateLimitExhausted(resp) || i+1 >= len(tokens) {
+			return resp, nil
+		}
+		if _, available := githubTokens.pick(tokens, time.Now()); !available {
+			return resp, nil
+		}
+		resp.Body.Close()
+	}
+}
+
+func (gs *GitHubService) sendOnce(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := gs.client.Do(req)
+	metrics.ObserveLayer(req.Context(), metrics.LayerGitHub, time.Since(start))
+	return resp, err
+}
+
+// GetProfile retrieves GitHub profile information. Concurrent calls for the
+// same user share one lookup (see coalesce).
 func (gs *GitHubService) GetProfile(ctx context.Context, username string) (*models.GitHubProfile, error) {
+	result, err := coalesce(ctx, "profile:"+username, func(ctx context.Context) (interface{}, error) {
+		return gs.getProfile(ctx, username)
+	})
+	if err != nil {
+		return nil, err
+	}
+	profile := *result.(*models.GitHubProfile)
+	return &profile, nil
+}
+
+func (gs *GitHubService) getProfile(ctx context.Context, username string) (*models.GitHubProfile, error) {
 	// Try cache first
 	var profile models.GitHubProfile
 	if err := gs.cacheService.GetGitHubData(ctx, username, "profile", &profile); err == nil {
@@ -44,7 +131,7 @@ func (gs *GitHubService) GetProfile(ctx context.Context, username string) (*mode
 	}
 
 	// Fetch from GitHub API
-	url := fmt.Sprintf("https://api.github.com/users/%s", username)
+	url := githubAPI("/users/%s", username)
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
@@ -56,7 +143,13 @@ func (gs *GitHubService) GetProfile(ctx context.Context, username string) (*mode
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 
-	resp, err := gs.client.Do(req)
+	resp, err := gs.do(req)
+	if errors.Is(err, ErrGitHubUnavailable) {
+		// Serve the last stored copy while GitHub is down
+		if stored, storedErr := gs.storedProfile(ctx, username); storedErr == nil {
+			return stored, nil
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -100,21 +193,171 @@ func (gs *GitHubService) GetProfile(ctx context.Context, username string) (*mode
 	return &profile, nil
 }
 
-// GetRepositories retrieves user's public repositories
+// GetRepositories retrieves user's public repositories. It never waits on
+// enrichment: stored repositories are served as they are, and languages,
+// READMEs and contributors are filled in by the background pipeline, which
+// also relists repositories stored longer ago than GITHUB_CACHE_TTL.
+// Concurrent calls for the same user share one lookup (see coalesce).
 func (gs *GitHubService) GetRepositories(ctx context.Context, username string) ([]models.GitHubRepository, error) {
+	result, err := coalesce(ctx, "repositories:"+username, func(ctx context.Context) (interface{}, error) {
+		return gs.getRepositories(ctx, username)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Callers sort and filter in place
+	return append([]models.GitHubRepository(nil), result.([]models.GitHubRepository)...), nil
+}
+
+func (gs *GitHubService) getRepositories(ctx context.Context, username string) ([]models.GitHubRepository, error) {
 	// Try cache first
 	var repos []models.GitHubRepository
 	if err := gs.cacheService.GetGitHubData(ctx, username, "repositories", &repos); err == nil {
 		return repos, nil
 	}
 
+	// Then the store
+	repos, err := gs.storedRepositories(ctx, username)
+	if err == nil && len(repos) > 0 {
+		stale := false
+		for _, repo := range repos {
+			if time.Since(repo.LastFetched) > config.AppConfig.GitHubCacheTTL {
+				stale = true
+				break
+			}
+		}
+		if stale || needsEnrichment(repos) {
+			gs.enqueueEnrichment(username, stale)
+		}
+
+		gs.cacheService.SetGitHubData(ctx, username, "repositories", repos)
+		return repos, nil
+	}
+
+	// First request for this user: list only and enrich in the background
+	repos, err = gs.listRepositories(ctx, username, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	gs.cacheService.SetGitHubData(ctx, username, "repositories", repos)
+	gs.storeRepositories(ctx, repos)
+	gs.enqueueEnrichment(username, false)
+
+	return repos, nil
+}
+
+// fetchRepositories lists repositories from the GitHub API and fetches their
+// languages inline, several at a time (see fetchLanguages). Repositories in
+// known that were not pushed to since keep their stored enrichment; the
+// returned count is how many languages were fetched. Whatever is still
+// missing, including languages that failed or were skipped because the
+// upstream budget ran out, is queued for the enrichment pipeline.
+func (gs *GitHubService) fetchRepositories(ctx context.Context, username string, known map[int64]models.GitHubRepository) ([]models.GitHubRepository, int, error) {
+	allRepos, err := gs.listRepositories(ctx, username, known)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	languagesFetched := gs.fetchLanguages(ctx, username, allRepos)
+
+	// Cache the result
+	gs.cacheService.SetGitHubData(ctx, username, "repositories", allRepos)
+
+	// Store in database
+	gs.storeRepositories(ctx, allRepos)
+
+	if needsEnrichment(allRepos) {
+		gs.enqueueEnrichment(username, false)
+	}
+
+	return allRepos, languagesFetched, nil
+}
+
+// fetchLanguages fetches the languages of the repositories that need them
+// with GITHUB_LANGUAGE_CONCURRENCY workers, starting at most one call per
+// GITHUB_LANGUAGE_PACING. It returns how many were fetched.
+func (gs *GitHubService) fetchLanguages(ctx context.Context, username string, repos []models.GitHubRepository) int {
+	var pending []int
+	for i := range repos {
+		if repos[i].NeedsLanguages() {
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) == 0 {
+		return 0
+	}
+
+	workers := config.AppConfig.GitHubLanguageConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(pending) {
+		workers = len(pending)
+	}
+
+	var pace <-chan time.Time
+	if interval := config.AppConfig.GitHubLanguagePacing; interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		pace = ticker.C
+	}
+
+	jobs := make(chan int)
+	var fetched int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each job is a distinct index, so workers never share a repository
+			for i := range jobs {
+				repo := &repos[i]
+				languages, err := gs.getRepositoryLanguages(withLowPriority(ctx), username, repo.Name)
+				if err != nil {
+					// Keep whatever was stored; the repository still needs
+					// languages, so the pipeline retries it
+					continue
+				}
+				repo.Languages = languages
+				repo.Enrichment.LanguagesAt = time.Now()
+				repo.UpdateEnrichmentStatus()
+				atomic.AddInt64(&fetched, 1)
+			}
+		}()
+	}
+
+dispatch:
+	for n, i := range pending {
+		if n > 0 && pace != nil {
+			select {
+			case <-pace:
+			case <-ctx.Done():
+				break dispatch
+			}
+		}
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return int(fetched)
+}
+
+// listRepositories pages through the user's repositories on the GitHub API
+// without enriching them. Enriched fields are carried over from known.
+func (gs *GitHubService) listRepositories(ctx context.Context, username string, known map[int64]models.GitHubRepository) ([]models.GitHubRepository, error) {
 	// Fetch from GitHub API with pagination
 	allRepos := []models.GitHubRepository{}
 	page := 1
 	perPage := 100
 
 	for {
-		url := fmt.Sprintf("https://api.github.com/users/%s/repos?page=%d&per_page=%d&sort=updated", username, page, perPage)
+		url := githubAPI("/users/%s/repos?page=%d&per_page=%d&sort=updated", username, page, perPage)
 		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return nil, err
@@ -125,7 +368,7 @@ func (gs *GitHubService) GetRepositories(ctx context.Context, username string) (
 		}
 		req.Header.Set("Accept", "application/vnd.github.v3+json")
 
-		resp, err := gs.client.Do(req)
+		resp, err := gs.do(req)
 		if err != nil {
 			return nil, err
 		}
@@ -178,11 +421,18 @@ func (gs *GitHubService) GetRepositories(ctx context.Context, username string) (
 				Owner:           username,
 			}
 
-			// Fetch languages for each repo (with rate limiting consideration)
-			if repo.Language != "" {
-				languages, _ := gs.getRepositoryLanguages(ctx, username, repo.Name)
-				repo.Languages = languages
+			if previous, ok := known[repo.GitHubID]; ok {
+				repo.Languages = previous.Languages
+				repo.Readme = previous.Readme
+				repo.Contributors = previous.Contributors
+				repo.Enrichment = previous.Enrichment
+
+				// Repositories stored before enrichment was tracked
+				if repo.Enrichment.LanguagesAt.IsZero() && previous.Languages != nil && previous.PushedAt.Equal(repo.PushedAt) {
+					repo.Enrichment.LanguagesAt = previous.PushedAt
+				}
 			}
+			repo.UpdateEnrichmentStatus()
 
 			allRepos = append(allRepos, repo)
 		}
@@ -194,56 +444,48 @@ func (gs *GitHubService) GetRepositories(ctx context.Context, username string) (
 		page++
 	}
 
-	// Cache the result
-	gs.cacheService.SetGitHubData(ctx, username, "repositories", allRepos)
-
-	// Store in database
-	gs.storeRepositories(ctx, allRepos)
-
 	return allRepos, nil
 }
 
-// GetContributions retrieves contribution data (simplified version)
-func (gs *GitHubService) GetContributions(ctx context.Context, username string) (*models.GitHubContributions, error) {
-	// Try cache first
-	var contributions models.GitHubContributions
-	if err := gs.cacheService.GetGitHubData(ctx, username, "contributions", &contributions); err == nil {
-		return &contributions, nil
+// storedProfile loads the profile last stored by GetProfile
+func (gs *GitHubService) storedProfile(ctx context.Context, username string) (*models.GitHubProfile, error) {
+	var profile models.GitHubProfile
+	if err := gs.collection.FindOne(ctx, bson.M{"login": username}).Decode(&profile); err != nil {
+		return nil, err
 	}
+	return &profile, nil
+}
 
-	// GitHub doesn't provide a direct API for contribution graph
-	// We'll simulate based on repository activity and commits
-	repos, err := gs.GetRepositories(ctx, username)
+// storedRepositories loads the user's repositories from MongoDB, most
+// recently updated first like the GitHub listing
+func (gs *GitHubService) storedRepositories(ctx context.Context, username string) ([]models.GitHubRepository, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	cursor, err := gs.collection.Find(ctx, bson.M{"owner": username}, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate approximate contributions based on repository data
-	totalContributions := 0
-	for _, repo := range repos {
-		if !repo.Fork {
-			// Estimate contributions based on repo activity
-			totalContributions += repo.StargazersCount + repo.ForksCount + 10 // Base contribution
-		}
+	var repos []models.GitHubRepository
+	if err := cursor.All(ctx, &repos); err != nil {
+		return nil, err
 	}
+	return repos, nil
+}
 
-	contributions = models.GitHubContributions{
-		Username:           username,
-		TotalContributions: totalContributions,
-		ContributionYears:  []int{time.Now().Year(), time.Now().Year() - 1},
-		LongestStreak:      30, // Placeholder
-		CurrentStreak:      5,  // Placeholder
-		LastFetched:        time.Now(),
+// GetStats calculates aggregated GitHub statistics. Concurrent calls for the
+// same user share one calculation (see coalesce).
+func (gs *GitHubService) GetStats(ctx context.Context, username string) (*models.GitHubStats, error) {
+	result, err := coalesce(ctx, "stats:"+username, func(ctx context.Context) (interface{}, error) {
+		return gs.getStats(ctx, username)
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	// Cache the result
-	gs.cacheService.SetGitHubData(ctx, username, "contributions", contributions)
-
-	return &contributions, nil
+	stats := *result.(*models.GitHubStats)
+	return &stats, nil
 }
 
-// GetStats calculates aggregated GitHub statistics
-func (gs *GitHubService) GetStats(ctx context.Context, username string) (*models.GitHubStats, error) {
+func (gs *GitHubService) getStats(ctx context.Context, username string) (*models.GitHubStats, error) {
 	// Try cache first
 	var stats models.GitHubStats
 	if err := gs.cacheService.GetGitHubData(ctx, username, "stats", &stats); err == nil {
@@ -256,10 +498,39 @@ func (gs *GitHubService) GetStats(ctx context.Context, username string) (*models
 		return nil, err
 	}
 
+	stats = buildStats(username, repos)
+
+	// Summing commits costs a call per repository, so only what the last
+	// sync stored is used
+	var activity models.CommitActivity
+	if err := gs.cacheService.GetGitHubData(ctx, username, "commit_activity", &activity); err == nil {
+		stats.TotalCommits = activity.Total
+	}
+
+	if includeOrganizationRepos() {
+		orgRepos, err := gs.GetOrganizationRepositories(ctx, username)
+		if errors.Is(err, ErrUpstreamBudgetExhausted) || errors.Is(err, ErrGitHubUnavailable) {
+			// Left to the next sync; stats missing the share are not cached
+			return &stats, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		addOrganizationShare(&stats, orgRepos)
+	}
+
+	// Cache the result
+	gs.cacheService.SetGitHubData(ctx, username, "stats", stats)
+
+	return &stats, nil
+}
+
+// buildStats aggregates stars and forks of public, non-fork repositories, and
+// their languages as languageStats counts them
+func buildStats(username string, repos []models.GitHubRepository) models.GitHubStats {
 	// Calculate statistics
 	totalStars := 0
 	totalForks := 0
-	languageStats := make(map[string]int)
 	topRepos := []models.RepoStat{}
 
 	for _, repo := range repos {
@@ -267,11 +538,6 @@ func (gs *GitHubService) GetStats(ctx context.Context, username string) (*models
 			totalStars += repo.StargazersCount
 			totalForks += repo.ForksCount
 
-			// Count languages
-			if repo.Language != "" {
-				languageStats[repo.Language]++
-			}
-
 			// Add to top repos if it has stars
 			if repo.StargazersCount > 0 {
 				topRepos = append(topRepos, models.RepoStat{
@@ -287,62 +553,225 @@ func (gs *GitHubService) GetStats(ctx context.Context, username string) (*models
 		}
 	}
 
-	// Convert language stats to sorted list
-	var mostUsedLanguages []models.LanguageStat
-	for lang, count := range languageStats {
-		percentage := float64(count) / float64(len(repos)) * 100
-		mostUsedLanguages = append(mostUsedLanguages, models.LanguageStat{
-			Name:       lang,
-			Bytes:      count * 1000, // Approximate
-			Percentage: percentage,
-		})
-	}
-
-	stats = models.GitHubStats{
+	return models.GitHubStats{
 		Username:         username,
 		TotalRepos:       len(repos),
 		TotalStars:       totalStars,
 		TotalForks:       totalForks,
-		MostUsedLanguages: mostUsedLanguages,
+		MostUsedLanguages: languageStats(repos),
 		TopRepositories:  topRepos,
 		LastFetched:      time.Now(),
 	}
+}
 
-	// Cache the result
-	gs.cacheService.SetGitHubData(ctx, username, "stats", stats)
+// SyncData refreshes all GitHub data for a user. An incremental sync refetches
+// the profile and repository list but reuses stored languages of repositories
+// that were not pushed to since; force refetches everything. The outcome is
+// recorded as the last sync and alerted over Telegram and the notification
+// channels when it fails.
+// Syncs are never limited by an upstream budget.
+func (gs *GitHubService) SyncData(ctx context.Context, username string, force bool) (*models.SyncStatus, error) {
+	ctx = withoutUpstreamBudget(ctx)
 
-	return &stats, nil
+	status := &models.SyncStatus{
+		Username:  username,
+		Mode:      models.SyncModeIncremental,
+		StartedAt: time.Now(),
+	}
+	if force {
+		status.Mode = models.SyncModeForce
+	}
+
+	err := gs.syncData(ctx, username, force, status)
+
+	status.Success = err == nil
+	status.SyncedAt = time.Now()
+	status.DurationMs = status.SyncedAt.Sub(status.StartedAt).Milliseconds()
+	if err != nil {
+		status.Error = err.Error()
+		alert := fmt.Sprintf("⚠️ GitHub sync failed for %s: %v", username, err)
+		Notify(NotifySyncFailed, alert)
+	} else {
+		NewCDNPurgeService().ScheduleSyncPurge(username)
+	}
+	NewSettingsService().Set(ctx, SettingLastSync, status, "sync")
+	PublishLive(models.LiveTopicGitHub, models.LiveGitHubSynced, map[string]interface{}{
+		"username":  username,
+		"success":   status.Success,
+		"mode":      status.Mode,
+		"synced_at": status.SyncedAt,
+	})
+
+	return status, err
+}
+
+// EnsureSynced syncs username unless the last recorded sync of it
+// succeeded, so a restart does not refetch data already in MongoDB
+func (gs *GitHubService) EnsureSynced(ctx context.Context, username string) error {
+	var last models.SyncStatus
+	if err := NewSettingsService().Get(ctx, SettingLastSync, &last); err == nil && last.Success && last.Username == username {
+		return nil
+	}
+
+	_, err := gs.SyncData(ctx, username, false)
+	return err
 }
 
-// SyncData forces a refresh of all GitHub data for a user
-func (gs *GitHubService) SyncData(ctx context.Context, username string) error {
-	// Invalidate cache
+func (gs *GitHubService) syncData(ctx context.Context, username string, force bool, status *models.SyncStatus) error {
+	step := func(name string, fn func() error) error {
+		started := time.Now()
+		err := fn()
+
+		result := models.SyncStep{
+			Name:       name,
+			DurationMs: time.Since(started).Milliseconds(),
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		status.Steps = append(status.Steps, result)
+		return err
+	}
+
+	// Stored repositories let an incremental sync skip unchanged languages
+	known := make(map[int64]models.GitHubRepository)
+	if !force {
+		err := step("load_stored", func() error {
+			stored, err := gs.storedRepositories(ctx, username)
+			if err != nil {
+				return err
+			}
+			for _, repo := range stored {
+				known[repo.GitHubID] = repo
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Invalidate cache so every step below reads fresh data
 	gs.cacheService.InvalidateGitHubCache(ctx, username)
 
-	// Fetch fresh data
-	_, err := gs.GetProfile(ctx, username)
+	err := step("profile", func() error {
+		_, err := gs.GetProfile(ctx, username)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = gs.GetRepositories(ctx, username)
+	err = step("repositories", func() error {
+		repos, fetched, err := gs.fetchRepositories(ctx, username, known)
+		if err != nil {
+			return err
+		}
+
+		status.Repositories = len(repos)
+		status.LanguagesFetched = fetched
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = gs.GetContributions(ctx, username)
+	// The contribution calendar is only served to authenticated clients
+	if FeatureEnabled(FeatureContributions) {
+		err = step("contributions", func() error {
+			_, err := gs.GetContributions(ctx, username)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if includeOrganizationRepos() {
+		err = step("organizations", func() error {
+			_, err := gs.GetOrganizationRepositories(ctx, username)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Accounts of the portfolio are all own repositories, not contributions
+	err = step("external_contributions", func() error {
+		return gs.SyncExternalContributions(ctx, username, NewSettingsService().GetAccounts(ctx))
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = gs.GetStats(ctx, username)
-	return err
+	// Releases only feed the changelog for the portfolio's own accounts
+	if isPortfolioAccount(NewSettingsService().GetAccounts(ctx), username) {
+		err = step("releases", func() error {
+			return gs.SyncReleases(ctx, username)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Commit activity only feeds a chart and total_commits, so it never fails
+	// the sync, and repositories GitHub is still computing are left pending
+	// rather than waited for
+	err = step("commit_activity", func() error {
+		_, err := gs.RefreshCommitActivity(withoutStatsWait(ctx), username)
+		return err
+	})
+	if err != nil {
+		log.Printf("Commit activity refresh for %s failed: %v", username, err)
+	}
+
+	return step("stats", func() error {
+		_, err := gs.GetStats(ctx, username)
+		return err
+	})
+}
+
+// StreamRepositories calls fn for each stored repository of username, reading
+// from a MongoDB cursor so large exports never sit in memory as one slice.
+// Repositories are fetched from GitHub first if none have been stored yet.
+func (gs *GitHubService) StreamRepositories(ctx context.Context, username string, fn func(repo *models.GitHubRepository) error) error {
+	filter := bson.M{"owner": username}
+
+	count, err := gs.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		if _, err := gs.GetRepositories(ctx, username); err != nil {
+			return err
+		}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}}).SetBatchSize(200)
+	cursor, err := gs.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var repo models.GitHubRepository
+		if err := cursor.Decode(&repo); err != nil {
+			return err
+		}
+		if err := fn(&repo); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
 }
 
 // Helper methods
 
 func (gs *GitHubService) getRepositoryLanguages(ctx context.Context, username, repoName string) (map[string]int, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/languages", username, repoName)
+	url := githubAPI("/repos/%s/%s/languages", username, repoName)
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
@@ -352,7 +781,7 @@ func (gs *GitHubService) getRepositoryLanguages(ctx context.Context, username, r
 		req.Header.Set("Authorization", "token "+config.AppConfig.GitHubToken)
 	}
 
-	resp, err := gs.client.Do(req)
+	resp, err := gs.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -398,9 +827,12 @@ func (gs *GitHubService) storeRepositories(ctx context.Context, repos []models.G
 	return err
 }
 
+// ErrGitHubTokenRejected is returned when GitHub answers 401 to GITHUB_TOKEN
+var ErrGitHubTokenRejected = errors.New("GitHub rejected the configured token")
+
 // CheckRateLimit checks GitHub API rate limit
 func (gs *GitHubService) CheckRateLimit(ctx context.Context) (map[string]interface{}, error) {
-	url := "https://api.github.com/rate_limit"
+	url := githubAPI("/rate_limit")
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
@@ -410,12 +842,16 @@ func (gs *GitHubService) CheckRateLimit(ctx context.Context) (map[string]interfa
 		req.Header.Set("Authorization", "token "+config.AppConfig.GitHubToken)
 	}
 
-	resp, err := gs.client.Do(req)
+	resp, err := gs.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrGitHubTokenRejected
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/models"
+)
+
+// starredPagesLimit caps how many pages of 100 starred repositories are read
+const starredPagesLimit = 5
+
+// GetStarredRepositories returns a page of the repositories the user
+// starred, most recent first, along with how many were read
+func (gs *GitHubService) GetStarredRepositories(ctx context.Context, username string, page, limit int) ([]models.StarredRepository, int, error) {
+	starred, err := gs.listStarred(ctx, username)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	start, end := pageRange(len(starred), page, limit)
+	return starred[start:end], len(starred), nil
+}
+
+func (gs *GitHubService) listStarred(ctx context.Context, username string) ([]models.StarredRepository, error) {
+	// Try cache first
+	var starred []models.StarredRepository
+	if err := gs.cacheService.GetGitHubData(ctx, username, "starred", &starred); err == nil {
+		return starred, nil
+	}
+
+	starred = []models.StarredRepository{}
+	for page := 1; page <= starredPagesLimit; page++ {
+		var apiRepos []models.GitHubAPIRepository
+		url := githubAPI("/users/%s/starred?page=%d&per_page=100", username, page)
+		if err := gs.getJSON(ctx, url, &apiRepos); err != nil {
+			return nil, err
+		}
+
+		for _, repo := range apiRepos {
+			starred = append(starred, starredRepository(repo))
+		}
+		if len(apiRepos) < 100 {
+			break
+		}
+	}
+
+	// Cache the result
+	gs.cacheService.SetGitHubData(ctx, username, "starred", starred)
+
+	return starred, nil
+}
+
+func starredRepository(repo models.GitHubAPIRepository) models.StarredRepository {
+	topics := repo.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	return models.StarredRepository{
+		FullName:    repo.FullName,
+		Name:        repo.Name,
+		Owner:       repo.Owner.Login,
+		Description: repo.Description,
+		HTMLURL:     repo.HTMLURL,
+		Homepage:    repo.Homepage,
+		Language:    repo.Language,
+		Stars:       repo.StargazersCount,
+		Forks:       repo.ForksCount,
+		Topics:      topics,
+		Archived:    repo.Archived,
+		PushedAt:    repo.PushedAt,
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"portfolio-backend/config"
+	"time"
+)
+
+// ErrGitHubThrottled is returned for a non-urgent call that would have to
+// wait longer than GITHUB_THROTTLE_MAX_WAIT for quota. The work is left for
+// a later sync or enrichment run.
+var ErrGitHubThrottled = errors.New("GitHub call deferred: rate limit quota low")
+
+type lowPriorityKey struct{}
+
+// githubThrottleQueue lets one non-urgent call at a time wait for quota, so
+// the calls queued behind it are spread out rather than released together
+var githubThrottleQueue = make(chan struct{}, 1)
+
+// withLowPriority marks the GitHub calls made with ctx as non-urgent, such
+// as the per-repository fetches of a sync or of the enrichment pipeline.
+// They are throttled when the rate limit runs low (see throttleGitHub).
+func withLowPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lowPriorityKey{}, true)
+}
+
+func isLowPriority(ctx context.Context) bool {
+	low, _ := ctx.Value(lowPriorityKey{}).(bool)
+	return low
+}
+
+// throttleGitHub delays a non-urgent call while the quota left across the
+// GitHub tokens is under GITHUB_THROTTLE_THRESHOLD, spreading the calls
+// left over the time until the rate limit resets. Calls are queued behind
+// each other; one that would wait longer than GITHUB_THROTTLE_MAX_WAIT
+// returns ErrGitHubThrottled instead.
+func throttleGitHub(ctx context.Context) error {
+	if config.AppConfig.GitHubThrottleThreshold <= 0 || !isLowPriority(ctx) {
+		return nil
+	}
+
+	select {
+	case githubThrottleQueue <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-githubThrottleQueue }()
+
+	now := time.Now()
+	remaining, reset, known := githubTokens.headroom(quotaTokens(), now)
+	wait := throttleDelay(remaining, reset, known, config.AppConfig.GitHubThrottleThreshold, now)
+	if wait <= 0 {
+		return nil
+	}
+	if wait > config.AppConfig.GitHubThrottleMaxWait {
+		return ErrGitHubThrottled
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// throttleDelay is how long to hold a non-urgent call given the quota left
+// until reset: nothing above threshold, an even share of the time left
+// below it, and the whole time left once the quota is spent
+func throttleDelay(remaining int, reset time.Time, known bool, threshold int, now time.Time) time.Duration {
+	if !known || remaining >= threshold || !reset.After(now) {
+		return 0
+	}
+	left := reset.Sub(now)
+	if remaining <= 0 {
+		return left
+	}
+	return left / time.Duration(remaining)
+}
+
+// quotaTokens lists the credentials GitHub calls are made with: the tokens,
+// or the anonymous one ("") without any
+func quotaTokens() []string {
+	tokens := githubTokenList()
+	if len(tokens) == 0 {
+		return []string{""}
+	}
+	return tokens
+}
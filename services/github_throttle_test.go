@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottleDelay(t *testing.T) {
+	now := time.Now()
+	reset := now.Add(100 * time.Second)
+
+	assert.Zero(t, throttleDelay(0, reset, false, 500, now))
+	assert.Zero(t, throttleDelay(500, reset, true, 500, now))
+	assert.Zero(t, throttleDelay(10, now.Add(-time.Second), true, 500, now))
+	assert.Equal(t, 10*time.Second, throttleDelay(10, reset, true, 500, now))
+	assert.Equal(t, 100*time.Second, throttleDelay(0, reset, true, 500, now))
+}
+
+func TestTokenPoolHeadroom(t *testing.T) {
+	now := time.Now()
+	pool := &tokenPool{quotas: make(map[string]*tokenQuota)}
+	tokens := []string{"a", "b"}
+
+	pool.observe("a", rateLimitResponse(100, 5000, now.Add(time.Hour)))
+	_, _, known := pool.headroom(tokens, now)
+	assert.False(t, known)
+
+	pool.observe("b", rateLimitResponse(50, 5000, now.Add(20*time.Minute)))
+	remaining, reset, known := pool.headroom(tokens, now)
+	assert.True(t, known)
+	assert.Equal(t, 150, remaining)
+	assert.Equal(t, now.Add(20*time.Minute).Unix(), reset.Unix())
+
+	// Expired windows are unknown again
+	_, _, known = pool.headroom(tokens, now.Add(2*time.Hour))
+	assert.False(t, known)
+}
+
+func TestThrottleGitHub(t *testing.T) {
+	config.AppConfig = &config.Config{GitHubToken: "a", GitHubThrottleThreshold: 500, GitHubThrottleMaxWait: time.Second}
+	original := githubTokens
+	githubTokens = &tokenPool{quotas: make(map[string]*tokenQuota)}
+	defer func() { githubTokens = original }()
+
+	low := withLowPriority(context.Background())
+
+	// Unknown quota and plenty of quota do not hold calls
+	assert.NoError(t, throttleGitHub(low))
+	githubTokens.observe("a", rateLimitResponse(4000, 5000, time.Now().Add(time.Hour)))
+	assert.NoError(t, throttleGitHub(low))
+
+	// Spent quota defers non-urgent calls but never urgent ones
+	githubTokens.observe("a", rateLimitResponse(0, 5000, time.Now().Add(time.Hour)))
+	assert.ErrorIs(t, throttleGitHub(low), ErrGitHubThrottled)
+	assert.NoError(t, throttleGitHub(context.Background()))
+
+	// A short wait is served
+	githubTokens.observe("a", rateLimitResponse(100, 5000, time.Now().Add(2*time.Second)))
+	start := time.Now()
+	assert.NoError(t, throttleGitHub(low))
+	assert.Greater(t, time.Since(start), 5*time.Millisecond)
+
+	config.AppConfig.GitHubThrottleThreshold = 0
+	githubTokens.observe("a", rateLimitResponse(0, 5000, time.Now().Add(time.Hour)))
+	assert.NoError(t, throttleGitHub(low))
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// githubTokens is shared by every GitHubService, as the quotas belong to the
+// tokens rather than to a service
+var githubTokens = &tokenPool{quotas: make(map[string]*tokenQuota)}
+
+// tokenPool rotates GitHub reads between GITHUB_TOKEN and GITHUB_TOKENS,
+// preferring the token with the most calls left in its core rate limit and
+// skipping exhausted ones until their window resets
+type tokenPool struct {
+	mu     sync.Mutex
+	quotas map[string]*tokenQuota
+	next   int
+}
+
+// tokenQuota is the core rate limit GitHub last reported for a token
+type tokenQuota struct {
+	remaining int
+	limit     int
+	reset     time.Time
+}
+
+// githubTokenList returns GITHUB_TOKEN followed by the distinct extra tokens
+func githubTokenList() []string {
+	var tokens []string
+	seen := make(map[string]bool)
+	for _, token := range append([]string{config.AppConfig.GitHubToken}, strings.Split(config.AppConfig.GitHubTokens, ",")...) {
+		token = strings.TrimSpace(token)
+		if token == "" || seen[token] {
+			continue
+		}
+		seen[token] = true
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// pooledRequest reports whether req is a read made with GITHUB_TOKEN, or a
+// retry of one made with another pooled token, which any pooled token can
+// make instead. Writes keep GITHUB_TOKEN, the token granted write access.
+func pooledRequest(req *http.Request, tokens []string) bool {
+	if req.Method != http.MethodGet || len(tokens) < 2 {
+		return false
+	}
+	token, ok := requestToken(req, tokens)
+	return ok && token != ""
+}
+
+// requestToken returns the configured token req is made with, "" for an
+// anonymous request, and false for a request made with other credentials
+func requestToken(req *http.Request, tokens []string) (string, bool) {
+	auth := req.Header.Get("Authorization")
+	if auth == "" {
+		return "", true
+	}
+	for _, token := range tokens {
+		if auth == "token "+token {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+// pick returns the token to make the next call with: one GitHub has not
+// reported on yet, else the one with the most calls left. Ties rotate, so
+// fresh tokens share the load. With every token exhausted it returns the one
+// resetting first and false.
+func (p *tokenPool) pick(tokens []string, now time.Time) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(tokens) == 0 {
+		return "", false
+	}
+	start := p.next % len(tokens)
+	p.next++
+
+	best, bestRemaining := "", -1
+	soonest := ""
+	var soonestReset time.Time
+	for i := range tokens {
+		token := tokens[(start+i)%len(tokens)]
+		remaining := int(^uint(0) >> 1)
+		if quota, ok := p.quotas[token]; ok && now.Before(quota.reset) {
+			if quota.remaining <= 0 {
+				if soonest == "" || quota.reset.Before(soonestReset) {
+					soonest, soonestReset = token, quota.reset
+				}
+				continue
+			}
+			remaining = quota.remaining
+		}
+		if remaining > bestRemaining {
+			best, bestRemaining = token, remaining
+		}
+	}
+	if best == "" {
+		return soonest, false
+	}
+	return best, true
+}
+
+// observe records the core rate limit reported in resp for token
+func (p *tokenPool) observe(token string, resp *http.Response) {
+	if resource := resp.Header.Get("X-RateLimit-Resource"); resource != "" && resource != "core" {
+		return
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+
+	p.mu.Lock()
+	p.quotas[token] = &tokenQuota{remaining: remaining, limit: limit, reset: time.Unix(reset, 0)}
+	p.mu.Unlock()
+}
+
+// headroom sums the calls left to tokens and returns when the first of
+// their windows resets. It is unknown until GitHub reported on every token
+// in its current window.
+func (p *tokenPool) headroom(tokens []string, now time.Time) (int, time.Time, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	remaining := 0
+	var reset time.Time
+	for _, token := range tokens {
+		quota, ok := p.quotas[token]
+		if !ok || !now.Before(quota.reset) {
+			return 0, time.Time{}, false
+		}
+		if quota.remaining > 0 {
+			remaining += quota.remaining
+		}
+		if reset.IsZero() || quota.reset.Before(reset) {
+			reset = quota.reset
+		}
+	}
+	return remaining, reset, len(tokens) > 0
+}
+
+// snapshot lists the quota of each token, without the tokens themselves
+func (p *tokenPool) snapshot(tokens []string, now time.Time) []models.GitHubTokenQuota {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	quotas := []models.GitHubTokenQuota{}
+	for i, token := range tokens {
+		quota := models.GitHubTokenQuota{Token: maskToken(token), Primary: i == 0}
+		if reported, ok := p.quotas[token]; ok && now.Before(reported.reset) {
+			remaining, reset := reported.remaining, reported.reset
+			quota.Remaining = &remaining
+			quota.Limit = reported.limit
+			quota.Reset = &reset
+			quota.Exhausted = remaining <= 0
+		}
+		quotas = append(quotas, quota)
+	}
+	return quotas
+}
+
+// maskToken keeps the last four characters of a token, enough to tell the
+// configured tokens apart
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return "…"
+	}
+	return "…" + token[len(token)-4:]
+}
+
+// GetTokenQuotas returns the rate limit last reported for each configured
+// GitHub token
+func (gs *GitHubService) GetTokenQuotas() []models.GitHubTokenQuota {
+	return githubTokens.snapshot(githubTokenList(), time.Now())
+}
+
+// rateLimitExhausted reports whether resp was refused because its token ran
+// out of calls in the primary rate limit
+func rateLimitExhausted(resp *http.Response) bool {
+	return (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) &&
+		resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rateLimitResponse(remaining, limit int, reset time.Time) *http.Response {
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	header.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	return &http.Response{StatusCode: http.StatusOK, Header: header}
+}
+
+func TestGitHubTokenList(t *testing.T) {
+	config.AppConfig = &config.Config{GitHubToken: "primary", GitHubTokens: " extra1, primary,,extra2 "}
+	assert.Equal(t, []string{"primary", "extra1", "extra2"}, githubTokenList())
+
+	config.AppConfig = &config.Config{}
+	assert.Empty(t, githubTokenList())
+}
+
+func TestTokenPoolPick(t *testing.T) {
+	now := time.Now()
+	pool := &tokenPool{quotas: make(map[string]*tokenQuota)}
+	tokens := []string{"a", "b", "c"}
+
+	// Tokens GitHub has not reported on share the load
+	first, ok := pool.pick(tokens, now)
+	require.True(t, ok)
+	second, _ := pool.pick(tokens, now)
+	assert.NotEqual(t, first, second)
+
+	pool.observe("a", rateLimitResponse(4000, 5000, now.Add(time.Hour)))
+	pool.observe("b", rateLimitResponse(100, 5000, now.Add(time.Hour)))
+	pool.observe("c", rateLimitResponse(0, 5000, now.Add(10*time.Minute)))
+	for i := 0; i < 3; i++ {
+		token, ok := pool.pick(tokens, now)
+		assert.True(t, ok)
+		assert.Equal(t, "a", token)
+	}
+
+	// Exhausted tokens are skipped until they reset
+	pool.observe("a", rateLimitResponse(0, 5000, now.Add(time.Hour)))
+	token, _ := pool.pick(tokens, now)
+	assert.Equal(t, "b", token)
+
+	pool.observe("b", rateLimitResponse(0, 5000, now.Add(30*time.Minute)))
+	token, ok = pool.pick(tokens, now)
+	assert.False(t, ok)
+	assert.Equal(t, "c", token)
+
+	token, ok = pool.pick(tokens, now.Add(15*time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, "c", token)
+}
+
+func TestTokenPoolIgnoresOtherResources(t *testing.T) {
+	pool := &tokenPool{quotas: make(map[string]*tokenQuota)}
+	resp := rateLimitResponse(0, 30, time.Now().Add(time.Minute))
+	resp.Header.Set("X-RateLimit-Resource", "search")
+	pool.observe("a", resp)
+	assert.Empty(t, pool.quotas)
+}
+
+func TestTokenPoolSnapshot(t *testing.T) {
+	now := time.Now()
+	pool := &tokenPool{quotas: make(map[string]*tokenQuota)}
+	pool.observe("ghp_primary1234", rateLimitResponse(0, 5000, now.Add(time.Hour)))
+
+	quotas := pool.snapshot([]string{"ghp_primary1234", "ghp_extra5678"}, now)
+	require.Len(t, quotas, 2)
+	assert.Equal(t, "…1234", quotas[0].Token)
+	assert.True(t, quotas[0].Primary)
+	assert.True(t, quotas[0].Exhausted)
+	require.NotNil(t, quotas[0].Remaining)
+	assert.Equal(t, 5000, quotas[0].Limit)
+
+	assert.Equal(t, "…5678", quotas[1].Token)
+	assert.False(t, quotas[1].Primary)
+	assert.Nil(t, quotas[1].Remaining)
+}
+
+func TestSendRotatesExhaustedTokens(t *testing.T) {
+	reset := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	var used []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		used = append(used, auth)
+		w.Header().Set("X-RateLimit-Reset", reset)
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		if auth == "token exhausted" {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+	}))
+	defer server.Close()
+
+	config.AppConfig = &config.Config{GitHubToken: "exhausted", GitHubTokens: "fresh"}
+	original := githubTokens
+	githubTokens = &tokenPool{quotas: make(map[string]*tokenQuota)}
+	defer func() { githubTokens = original }()
+
+	gs := &GitHubService{client: server.Client()}
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest("GET", server.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "token exhausted")
+		resp, err := gs.send(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, "token fresh", used[len(used)-1])
+	// The exhausted token is tried at most once
+	exhausted := 0
+	for _, auth := range used {
+		if auth == "token exhausted" {
+			exhausted++
+		}
+	}
+	assert.Less(t, exhausted, 2)
+
+	// Writes keep GITHUB_TOKEN
+	used = nil
+	req, err := http.NewRequest("POST", server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "token exhausted")
+	resp, err := gs.send(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, []string{"token exhausted"}, used)
+}
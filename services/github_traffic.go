@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// trafficCollection keeps the daily views and clones of the owner's
+// repositories past the 14 days GitHub retains
+const trafficCollection = "github_traffic"
+
+// trafficHistoryDays is how far back traffic history is served by default
+const trafficHistoryDays = 90
+
+// githubTrafficCounts is the views or clones report of a repository, one
+// count per day
+type githubTrafficCounts struct {
+	Views  []githubTrafficCount `json:"views"`
+	Clones []githubTrafficCount `json:"clones"`
+}
+
+type githubTrafficCount struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int       `json:"count"`
+	Uniques   int       `json:"uniques"`
+}
+
+// StartTrafficCollection stores the traffic of the owner's repositories
+// right away and then every GITHUB_TRAFFIC_INTERVAL, when the traffic feature
+// was detected
+func (gs *GitHubService) StartTrafficCollection() {
+	interval := config.AppConfig.GitHubTrafficInterval
+	if interval <= 0 || !FeatureEnabled(FeatureTraffic) {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+			username := NewSettingsService().GetOwner(ctx)
+			if err := gs.CollectTraffic(ctx, username); err != nil {
+				log.Printf("Traffic collection for %s failed: %v", username, err)
+			}
+			cancel()
+			<-ticker.C
+		}
+	}()
+}
+
+// CollectTraffic stores the views and clones GitHub reports for each of the
+// user's own repositories. Repositories the token cannot push to have no
+// traffic to read and are skipped.
+func (gs *GitHubService) CollectTraffic(ctx context.Context, username string) error {
+	repos, err := gs.GetRepositories(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	// Collecting history is never urgent
+	ctx = withLowPriority(ctx)
+	for _, repo := range repos {
+		if repo.Fork {
+			continue
+		}
+
+		var views, clones githubTrafficCounts
+		err := gs.getJSON(ctx, githubAPI("/repos/%s/%s/traffic/views", username, repo.Name), &views)
+		if err == nil {
+			err = gs.getJSON(ctx, githubAPI("/repos/%s/%s/traffic/clones", username, repo.Name), &clones)
+		}
+		if errors.Is(err, ErrGitHubThrottled) || errors.Is(err, ErrGitHubUnavailable) {
+			// The remaining repositories are collected next time
+			return err
+		}
+		if err != nil {
+			log.Printf("Traffic of %s unavailable: %v", repo.FullName, err)
+			continue
+		}
+
+		for _, day := range mergeTraffic(trafficRepository(username, repo.Name), views.Views, clones.Clones) {
+			filter := bson.M{"repository": day.Repository, "date": day.Date}
+			update := bson.M{"$set": day}
+			if _, err := gs.traffic.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+				return fmt.Errorf("%s: %w", repo.FullName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// trafficRepository is how traffic is keyed: owner/name, lowercased as
+// GitHub names are case-insensitive
+func trafficRepository(owner, repo string) string {
+	return strings.ToLower(owner + "/" + repo)
+}
+
+// mergeTraffic combines daily views and clones of repository into one
+// entry per day, oldest first
+func mergeTraffic(repository string, views, clones []githubTrafficCount) []models.TrafficDay {
+	now := time.Now()
+	days := make(map[string]*models.TrafficDay)
+	day := func(timestamp time.Time) *models.TrafficDay {
+		date := timestamp.UTC().Format(contributionDateLayout)
+		if days[date] == nil {
+			days[date] = &models.TrafficDay{Repository: repository, Date: date, CollectedAt: now}
+		}
+		return days[date]
+	}
+
+	for _, count := range views {
+		entry := day(count.Timestamp)
+		entry.Views, entry.UniqueViews = count.Count, count.Uniques
+	}
+	for _, count := range clones {
+		entry := day(count.Timestamp)
+		entry.Clones, entry.UniqueClones = count.Count, count.Uniques
+	}
+
+	merged := make([]models.TrafficDay, 0, len(days))
+	for _, entry := range days {
+		merged = append(merged, *entry)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Date < merged[j].Date
+	})
+	return merged
+}
+
+// GetTraffic returns the stored traffic of one of the user's repositories
+// over the last days, 90 when not given
+func (gs *GitHubService) GetTraffic(ctx context.Context, username, repo string, days int) (*models.RepositoryTraffic, error) {
+	if days <= 0 {
+		days = trafficHistoryDays
+	}
+	repository := trafficRepository(username, repo)
+	since := time.Now().UTC().AddDate(0, 0, -(days - 1)).Format(contributionDateLayout)
+
+	filter := bson.M{"repository": repository, "date": bson.M{"$gte": since}}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+	cursor, err := gs.traffic.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	traffic := &models.RepositoryTraffic{Repository: repository, Days: []models.TrafficDay{}}
+	if err := cursor.All(ctx, &traffic.Days); err != nil {
+		return nil, err
+	}
+	for _, day := range traffic.Days {
+		traffic.Views += day.Views
+		traffic.Clones += day.Clones
+	}
+	return traffic, nil
+}
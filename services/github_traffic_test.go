@@ -0,0 +1,34 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeTraffic(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	views := []githubTrafficCount{
+		{Timestamp: day(2), Count: 10, Uniques: 4},
+		{Timestamp: day(1), Count: 3, Uniques: 1},
+	}
+	clones := []githubTrafficCount{
+		{Timestamp: day(2), Count: 2, Uniques: 2},
+		{Timestamp: day(3), Count: 1, Uniques: 1},
+	}
+
+	days := mergeTraffic(trafficRepository("OctoCat", "Hello"), views, clones)
+	require.Len(t, days, 3)
+	assert.Equal(t, "octocat/hello", days[0].Repository)
+	assert.Equal(t, "2024-05-01", days[0].Date)
+	assert.Equal(t, 3, days[0].Views)
+	assert.Zero(t, days[0].Clones)
+	assert.Equal(t, 10, days[1].Views)
+	assert.Equal(t, 4, days[1].UniqueViews)
+	assert.Equal(t, 2, days[1].Clones)
+	assert.Equal(t, "2024-05-03", days[2].Date)
+	assert.Zero(t, days[2].Views)
+	assert.Equal(t, 1, days[2].UniqueClones)
+}
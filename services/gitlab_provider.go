@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// gitlabEventPages caps the pages of events read for the contribution
+// calendar, 100 events each
+const gitlabEventPages = 20
+
+// GitLabProvider serves a GitLab user, on gitlab.com or the instance at
+// GITLAB_URL, authenticated with GITLAB_TOKEN when it is set
+type GitLabProvider struct {
+	client       *http.Client
+	baseURL      string
+	token        string
+	cacheService *CacheService
+}
+
+func NewGitLabProvider() *GitLabProvider {
+	return &GitLabProvider{
+		client:       &http.Client{Timeout: codeHostTimeout},
+		baseURL:      strings.TrimSuffix(config.AppConfig.GitLabURL, "/"),
+		token:        config.AppConfig.GitLabToken,
+		cacheService: NewCacheService(),
+	}
+}
+
+type gitlabUser struct {
+	ID           int64     `json:"id"`
+	Username     string    `json:"username"`
+	Name         string    `json:"name"`
+	AvatarURL    string    `json:"avatar_url"`
+	Bio          string    `json:"bio"`
+	Location     string    `json:"location"`
+	PublicEmail  string    `json:"public_email"`
+	WebsiteURL   string    `json:"website_url"`
+	Organization string    `json:"organization"`
+	Twitter      string    `json:"twitter"`
+	Followers    int       `json:"followers"`
+	Following    int       `json:"following"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type gitlabProject struct {
+	ID                int64     `json:"id"`
+	Name              string    `json:"name"`
+	PathWithNamespace string    `json:"path_with_namespace"`
+	Description       string    `json:"description"`
+	WebURL            string    `json:"web_url"`
+	HTTPURLToRepo     string    `json:"http_url_to_repo"`
+	Visibility        string    `json:"visibility"`
+	StarCount         int       `json:"star_count"`
+	ForksCount        int       `json:"forks_count"`
+	OpenIssuesCount   int       `json:"open_issues_count"`
+	DefaultBranch     string    `json:"default_branch"`
+	Topics            []string  `json:"topics"`
+	Archived          bool      `json:"archived"`
+	CreatedAt         time.Time `json:"created_at"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	ForkedFromProject *struct {
+		ID int64 `json:"id"`
+	} `json:"forked_from_project"`
+}
+
+type gitlabEvent struct {
+	ActionName string    `json:"action_name"`
+	TargetType string    `json:"target_type"`
+	CreatedAt  time.Time `json:"created_at"`
+	PushData   *struct {
+		CommitCount int `json:"commit_count"`
+	} `json:"push_data"`
+}
+
+// Name identifies GitLab as a CodeHostProvider
+func (gp *GitLabProvider) Name() string {
+	return CodeHostGitLab
+}
+
+// GetProfile returns the GitLab user as a profile. Public repositories are
+// counted from the user's projects.
+func (gp *GitLabProvider) GetProfile(ctx context.Context, username string) (*models.GitHubProfile, error) {
+	var profile models.GitHubProfile
+	if err := gp.cacheService.GetGitHubData(ctx, username, "gitlab_profile", &profile); err == nil {
+		return &profile, nil
+	}
+
+	user, err := gp.user(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := gp.GetRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	profile = gitlabProfile(*user, repos)
+	gp.cacheService.SetGitHubData(ctx, username, "gitlab_profile", profile)
+	return &profile, nil
+}
+
+// GetRepositories returns the projects the user owns, with the language
+// breakdown GitLab reports in percent
+func (gp *GitLabProvider) GetRepositories(ctx context.Context, username string) ([]models.GitHubRepository, error) {
+	var repos []models.GitHubRepository
+	if err := gp.cacheService.GetGitHubData(ctx, username, "gitlab_repositories", &repos); err == nil {
+		return repos, nil
+	}
+
+	user, err := gp.user(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	repos = []models.GitHubRepository{}
+	for page := 1; ; page++ {
+		var projects []gitlabProject
+		endpoint := fmt.Sprintf("%s/api/v4/users/%d/projects?per_page=100&page=%d&order_by=last_activity_at", gp.baseURL, user.ID, page)
+		if err := gp.get(ctx, endpoint, &projects); err != nil {
+			return nil, err
+		}
+		for _, project := range projects {
+			var languages map[string]float64
+			endpoint := fmt.Sprintf("%s/api/v4/projects/%d/languages", gp.baseURL, project.ID)
+			if err := gp.get(ctx, endpoint, &languages); err != nil {
+				languages = nil
+			}
+			repos = append(repos, gitlabRepository(project, username, languages))
+		}
+		if len(projects) < 100 {
+			break
+		}
+	}
+
+	gp.cacheService.SetGitHubData(ctx, username, "gitlab_repositories", repos)
+	return repos, nil
+}
+
+// GetContributions builds the last year of the calendar from the user's
+// events, as GitLab draws its own: each event counts once. Commits count
+// the commits of each push.
+func (gp *GitLabProvider) GetContributions(ctx context.Context, username string) (*models.GitHubContributions, error) {
+	var contributions models.GitHubContributions
+	if err := gp.cacheService.GetGitHubData(ctx, username, "gitlab_contributions", &contributions); err == nil {
+		return &contributions, nil
+	}
+
+	user, err := gp.user(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now()
+	after := startOfDay(today).AddDate(0, 0, -codeHostCalendarDays).Format(contributionDateLayout)
+	var events []gitlabEvent
+	for page := 1; page <= gitlabEventPages; page++ {
+		var batch []gitlabEvent
+		endpoint := fmt.Sprintf("%s/api/v4/users/%d/events?after=%s&per_page=100&page=%d", gp.baseURL, user.ID, after, page)
+		if err := gp.get(ctx, endpoint, &batch); err != nil {
+			return nil, err
+		}
+		events = append(events, batch...)
+		if len(batch) < 100 {
+			break
+		}
+	}
+
+	contributions = gitlabContributions(username, events, today)
+	gp.cacheService.SetGitHubData(ctx, username, "gitlab_contributions", contributions)
+	return &contributions, nil
+}
+
+// GetStats aggregates the user's projects like the GitHub stats
+func (gp *GitLabProvider) GetStats(ctx context.Context, username string) (*models.GitHubStats, error) {
+	repos, err := gp.GetRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	stats := buildStats(username, repos)
+	return &stats, nil
+}
+
+// user looks a GitLab user up by username, then loads their public profile
+func (gp *GitLabProvider) user(ctx context.Context, username string) (*gitlabUser, error) {
+	var matches []gitlabUser
+	if err := gp.get(ctx, gp.baseURL+"/api/v4/users?username="+url.QueryEscape(username), &matches); err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("gitlab user %s not found", username)
+	}
+
+	var user gitlabUser
+	if err := gp.get(ctx, fmt.Sprintf("%s/api/v4/users/%d", gp.baseURL, matches[0].ID), &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (gp *GitLabProvider) get(ctx context.Context, endpoint string, target interface{}) error {
+	return getHostJSON(ctx, gp.client, endpoint, func(req *http.Request) {
+		if gp.token != "" {
+			req.Header.Set("PRIVATE-TOKEN", gp.token)
+		}
+	}, target)
+}
+
+func gitlabProfile(user gitlabUser, repos []models.GitHubRepository) models.GitHubProfile {
+	public := 0
+	for _, repo := range repos {
+		if !repo.Private {
+			public++
+		}
+	}
+	return models.GitHubProfile{
+		Login:           user.Username,
+		Name:            user.Name,
+		AvatarURL:       user.AvatarURL,
+		Bio:             user.Bio,
+		Company:         user.Organization,
+		Location:        user.Location,
+		Email:           user.PublicEmail,
+		Blog:            user.WebsiteURL,
+		TwitterUsername: user.Twitter,
+		PublicRepos:     public,
+		Followers:       user.Followers,
+		Following:       user.Following,
+		CreatedAt:       user.CreatedAt,
+		LastFetched:     time.Now(),
+	}
+}
+
+// gitlabRepository maps a project. Its main language is the largest share
+// of languages, which GitLab gives in percent.
+func gitlabRepository(project gitlabProject, owner string, languages map[string]float64) models.GitHubRepository {
+	repo := models.GitHubRepository{
+		GitHubID:        project.ID,
+		Name:            project.Name,
+		FullName:        project.PathWithNamespace,
+		Description:     project.Description,
+		Private:         project.Visibility != "public",
+		Fork:            project.ForkedFromProject != nil,
+		HTMLURL:         project.WebURL,
+		CloneURL:        project.HTTPURLToRepo,
+		Languages:       map[string]int{},
+		StargazersCount: project.StarCount,
+		ForksCount:      project.ForksCount,
+		OpenIssuesCount: project.OpenIssuesCount,
+		DefaultBranch:   project.DefaultBranch,
+		Topics:          project.Topics,
+		Archived:        project.Archived,
+		PushedAt:        project.LastActivityAt,
+		CreatedAt:       project.CreatedAt,
+		UpdatedAt:       project.LastActivityAt,
+		LastFetched:     time.Now(),
+		Owner:           owner,
+	}
+	if repo.Topics == nil {
+		repo.Topics = []string{}
+	}
+
+	names := make([]string, 0, len(languages))
+	for name := range languages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	top := 0.0
+	for _, name := range names {
+		repo.Languages[name] = int(math.Round(languages[name]))
+		if languages[name] > top {
+			repo.Language, top = name, languages[name]
+		}
+	}
+	return repo
+}
+
+// gitlabContributions counts the events per day. Pushes add their commits,
+// opened merge requests and issues their own totals.
+func gitlabContributions(username string, events []gitlabEvent, today time.Time) models.GitHubContributions {
+	counts := make(map[string]int)
+	commits, mergeRequests, issues := 0, 0, 0
+	for _, event := range events {
+		counts[event.CreatedAt.UTC().Format(contributionDateLayout)]++
+		switch {
+		case event.PushData != nil:
+			commits += event.PushData.CommitCount
+		case event.ActionName == "opened" && event.TargetType == "MergeRequest":
+			mergeRequests++
+		case event.ActionName == "opened" && event.TargetType == "Issue":
+			issues++
+		}
+	}
+
+	contributions := buildCalendar(username, counts, today)
+	contributions.Commits = commits
+	contributions.PullRequests = mergeRequests
+	contributions.Issues = issues
+	return contributions
+}
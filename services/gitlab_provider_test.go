@@ -0,0 +1,66 @@
+package services
+
+import (
+	"encoding/json"
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLabRepository(t *testing.T) {
+	var project gitlabProject
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": 42,
+		"name": "portfolio",
+		"path_with_namespace": "octocat/portfolio",
+		"web_url": "https://gitlab.com/octocat/portfolio",
+		"visibility": "public",
+		"star_count": 7,
+		"forks_count": 2,
+		"last_activity_at": "2026-03-01T10:00:00Z",
+		"forked_from_project": {"id": 1}
+	}`), &project))
+
+	repo := gitlabRepository(project, "octocat", map[string]float64{"Go": 71.4, "Shell": 28.6})
+	assert.Equal(t, int64(42), repo.GitHubID)
+	assert.Equal(t, "octocat/portfolio", repo.FullName)
+	assert.False(t, repo.Private)
+	assert.True(t, repo.Fork)
+	assert.Equal(t, 7, repo.StargazersCount)
+	assert.Equal(t, "Go", repo.Language)
+	assert.Equal(t, map[string]int{"Go": 71, "Shell": 29}, repo.Languages)
+	assert.NotNil(t, repo.Topics)
+	assert.Equal(t, "octocat", repo.Owner)
+}
+
+func TestGitLabProfile(t *testing.T) {
+	user := gitlabUser{Username: "octocat", Name: "Octo Cat", Organization: "GitLab", Followers: 3}
+	repos := []models.GitHubRepository{{Name: "a"}, {Name: "b", Private: true}}
+
+	profile := gitlabProfile(user, repos)
+	assert.Equal(t, "octocat", profile.Login)
+	assert.Equal(t, "GitLab", profile.Company)
+	assert.Equal(t, 1, profile.PublicRepos)
+	assert.Equal(t, 3, profile.Followers)
+}
+
+func TestGitLabContributions(t *testing.T) {
+	today := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	var events []gitlabEvent
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"action_name": "pushed to", "created_at": "2026-03-10T09:00:00Z", "push_data": {"commit_count": 3}},
+		{"action_name": "opened", "target_type": "MergeRequest", "created_at": "2026-03-10T10:00:00Z"},
+		{"action_name": "opened", "target_type": "Issue", "created_at": "2026-03-09T10:00:00Z"},
+		{"action_name": "commented on", "target_type": "Note", "created_at": "2026-03-09T11:00:00Z"}
+	]`), &events))
+
+	contributions := gitlabContributions("octocat", events, today)
+	assert.Equal(t, 4, contributions.TotalContributions)
+	assert.Equal(t, 3, contributions.Commits)
+	assert.Equal(t, 1, contributions.PullRequests)
+	assert.Equal(t, 1, contributions.Issues)
+	assert.Equal(t, 2, contributions.CurrentStreak)
+}
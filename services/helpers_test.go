@@ -0,0 +1,29 @@
+package services
+
+import (
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// useOfflineDatabase installs cfg and a database whose client never
+// connects, so reads and writes fail fast, and restores both when the test
+// ends
+func useOfflineDatabase(t *testing.T, cfg *config.Config) {
+	t.Helper()
+
+	client, err := mongo.NewClient(options.Client().ApplyURI("mongodb://127.0.0.1:27017"))
+	require.NoError(t, err)
+
+	previousConfig, previousDatabase := config.AppConfig, database.Database
+	t.Cleanup(func() {
+		config.AppConfig, database.Database = previousConfig, previousDatabase
+	})
+
+	config.AppConfig = cfg
+	database.Database = client.Database("portfolio_test")
+}
@@ -0,0 +1,624 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// jobPollInterval is how often idle workers look for due jobs queued
+	// by other instances
+	jobPollInterval = 5 * time.Second
+	// jobMaxRetryDelay caps the backoff between attempts
+	jobMaxRetryDelay = time.Hour
+	// jobCleanupInterval is how often jobs past JOB_RETENTION are removed
+	jobCleanupInterval = time.Hour
+	// jobExportBucket is the GridFS bucket export jobs write their file to
+	jobExportBucket = "job_exports"
+	// jobTrafficDays is the range aggregated when no since is given
+	jobTrafficDays = 30
+)
+
+var (
+	// ErrUnknownJobType is returned for job types no handler runs
+	ErrUnknownJobType = errors.New("unknown job type")
+	// ErrInvalidJobParams is returned for parameters the job type rejects
+	ErrInvalidJobParams = errors.New("invalid job parameters")
+	// ErrJobNotFound is returned for unknown job IDs
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobConflict is returned when retrying or canceling a job in a
+	// status that does not allow it
+	ErrJobConflict = errors.New("job is not in a status that allows this")
+	// ErrJobNoExport is returned when downloading from a job without a file
+	ErrJobNoExport = errors.New("job has no export file")
+)
+
+// jobWake lets an enqueue on this instance start a job without waiting for
+// the next poll
+var jobWake = make(chan struct{}, 1)
+
+// jobWorkers tracks the running workers, so shutdown can wait for them
+var jobWorkers struct {
+	sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// JobHandler runs one attempt of a job and returns its result, which must
+// encode to a JSON object
+type JobHandler func(ctx context.Context, job *models.Job) (interface{}, error)
+
+type JobService struct {
+	collection *mongo.Collection
+	handlers   map[string]JobHandler
+}
+
+func NewJobService() *JobService {
+	js := &JobService{
+		collection: database.Database.Collection("jobs"),
+	}
+	js.handlers = map[string]JobHandler{
+		models.JobGitHubSync:         js.runGitHubSync,
+		models.JobCacheWarm:          js.runCacheWarm,
+		models.JobAnalyticsAggregate: js.runAnalyticsAggregate,
+		models.JobExport:             js.runExport,
+		models.JobCommitInsights:     js.runCommitInsights,
+	}
+	return js
+}
+
+// Enqueue validates and queues a job; a worker of any instance picks it up
+func (js *JobService) Enqueue(ctx context.Context, request models.JobRequest, createdBy string) (*models.Job, error) {
+	if _, ok := js.handlers[request.Type]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJobType, request.Type)
+	}
+	if err := validateJobParams(request.Type, request.Params); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job := models.Job{
+		ID:          primitive.NewObjectID(),
+		Type:        request.Type,
+		Params:      request.Params,
+		Status:      models.JobQueued,
+		MaxAttempts: max(config.AppConfig.JobMaxAttempts, 1),
+		RunAt:       now,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+	}
+	if _, err := js.collection.InsertOne(ctx, job); err != nil {
+		return nil, err
+	}
+
+	select {
+	case jobWake <- struct{}{}:
+	default:
+	}
+	return &job, nil
+}
+
+// validateJobParams checks the parameters of a job type up front, so bad
+// requests fail instead of being retried
+func validateJobParams(jobType string, params map[string]string) error {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidJobParams, fmt.Sprintf(format, args...))
+	}
+
+	for _, field := range []string{"since", "until"} {
+		if value := params[field]; value != "" {
+			if _, err := parseJobTime(value); err != nil {
+				return invalid("%s must be an RFC 3339 time or a YYYY-MM-DD date", field)
+			}
+		}
+	}
+
+	if username := params["username"]; username != "" && !utils.IsValidGitHubUsername(username) {
+		return invalid("invalid GitHub username %q", username)
+	}
+
+	switch jobType {
+	case models.JobGitHubSync:
+		if force := params["force"]; force != "" {
+			if _, err := strconv.ParseBool(force); err != nil {
+				return invalid("force must be true or false")
+			}
+		}
+	case models.JobExport:
+		if _, ok := exportCollections[params["collection"]]; !ok {
+			return invalid("unknown collection %q", params["collection"])
+		}
+		if format := params["format"]; format != "" {
+			if _, ok := ExportContentTypes[format]; !ok {
+				return invalid("format must be json, ndjson or csv")
+			}
+		}
+	}
+	return nil
+}
+
+// parseJobTime accepts an RFC 3339 time or a YYYY-MM-DD date
+func parseJobTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// GetJob returns a job by ID
+func (js *JobService) GetJob(ctx context.Context, id string) (*models.Job, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrJobNotFound
+	}
+
+	var job models.Job
+	if err := js.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&job); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListJobs returns the newest jobs, optionally filtered by status and type
+func (js *JobService) ListJobs(ctx context.Context, status, jobType string, limit int) ([]models.Job, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	if jobType != "" {
+		filter["type"] = jobType
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := js.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []models.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// RetryJob puts a dead or canceled job back in the queue with its attempts reset
+func (js *JobService) RetryJob(ctx context.Context, id string) (*models.Job, error) {
+	return js.transition(ctx, id, []string{models.JobDead, models.JobCanceled}, bson.M{
+		"$set":   bson.M{"status": models.JobQueued, "attempts": 0, "run_at": time.Now()},
+		"$unset": bson.M{"error": "", "result": "", "worker": "", "locked_until": "", "started_at": "", "finished_at": ""},
+	})
+}
+
+// CancelJob cancels a job that has not started yet
+func (js *JobService) CancelJob(ctx context.Context, id string) (*models.Job, error) {
+	return js.transition(ctx, id, []string{models.JobQueued}, bson.M{
+		"$set": bson.M{"status": models.JobCanceled, "finished_at": time.Now()},
+	})
+}
+
+// transition applies update to the job when it is in one of statuses
+func (js *JobService) transition(ctx context.Context, id string, statuses []string, update bson.M) (*models.Job, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrJobNotFound
+	}
+
+	var job models.Job
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = js.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID, "status": bson.M{"$in": statuses}}, update, opts).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		if _, err := js.GetJob(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrJobConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status == models.JobQueued {
+		select {
+		case jobWake <- struct{}{}:
+		default:
+		}
+	}
+	return &job, nil
+}
+
+// StartWorkers runs JOB_WORKERS workers taking jobs from the queue, and the
+// removal of jobs older than JOB_RETENTION. With no workers this instance
+// only enqueues jobs.
+func (js *JobService) StartWorkers() {
+	workers := config.AppConfig.JobWorkers
+	if workers <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	jobWorkers.Lock()
+	jobWorkers.cancel = cancel
+	jobWorkers.Unlock()
+
+	hostname, _ := os.Hostname()
+	for i := 1; i <= workers; i++ {
+		worker := fmt.Sprintf("%s/%d/%d", hostname, os.Getpid(), i)
+		jobWorkers.wg.Add(1)
+		go func() {
+			defer jobWorkers.wg.Done()
+			js.work(ctx, worker)
+		}()
+	}
+
+	if config.AppConfig.JobRetention > 0 {
+		go func() {
+			ticker := time.NewTicker(jobCleanupInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					cleanupCtx, cancel := context.WithTimeout(ctx, time.Minute)
+					if err := js.Cleanup(cleanupCtx, time.Now().Add(-config.AppConfig.JobRetention)); err != nil {
+						log.Printf("Job cleanup error: %v", err)
+					}
+					cancel()
+				}
+			}
+		}()
+	}
+}
+
+// StopJobWorkers interrupts the running jobs, handing them back to the
+// queue, and waits for the workers to exit
+func StopJobWorkers() {
+	jobWorkers.Lock()
+	cancel := jobWorkers.cancel
+	jobWorkers.cancel = nil
+	jobWorkers.Unlock()
+
+	if cancel != nil {
+		cancel()
+		jobWorkers.wg.Wait()
+	}
+}
+
+func (js *JobService) work(ctx context.Context, worker string) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the due jobs before waiting again
+		for ctx.Err() == nil {
+			job, err := js.claim(ctx, worker)
+			if err != nil {
+				if err != mongo.ErrNoDocuments && ctx.Err() == nil {
+					log.Printf("Job worker %s failed to claim a job: %v", worker, err)
+				}
+				break
+			}
+			js.run(ctx, job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-jobWake:
+		}
+	}
+}
+
+// claim takes the oldest due job, or a running one whose worker died, and
+// locks it for the length of an attempt
+func (js *JobService) claim(ctx context.Context, worker string) (*models.Job, error) {
+	now := time.Now()
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": models.JobQueued, "run_at": bson.M{"$lte": now}},
+		bson.M{"status": models.JobRunning, "locked_until": bson.M{"$lt": now}},
+	}}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       models.JobRunning,
+			"worker":       worker,
+			"locked_until": now.Add(config.AppConfig.JobTimeout + time.Minute),
+			"started_at":   now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job models.Job
+	if err := js.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// run makes one attempt at job and records the outcome: success, a retry
+// with exponential backoff, or the dead letter once attempts run out
+func (js *JobService) run(ctx context.Context, job *models.Job) {
+	handler, ok := js.handlers[job.Type]
+	if !ok {
+		js.finish(job, models.JobDead, nil, fmt.Errorf("%w: %s", ErrUnknownJobType, job.Type))
+		return
+	}
+	if job.Attempts > job.MaxAttempts {
+		js.finish(job, models.JobDead, nil, errors.New("worker stopped during the last attempt"))
+		return
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, config.AppConfig.JobTimeout)
+	result, err := handler(runCtx, job)
+	cancel()
+
+	switch {
+	case ctx.Err() != nil:
+		// Shutting down: the attempt does not count
+		js.release(job)
+	case err == nil:
+		js.finish(job, models.JobSucceeded, result, nil)
+	case job.Attempts >= job.MaxAttempts:
+		log.Printf("Job %s (%s) failed after %d attempts: %v", job.ID.Hex(), job.Type, job.Attempts, err)
+		js.finish(job, models.JobDead, nil, err)
+	default:
+		js.retry(job, err)
+	}
+}
+
+// jobRetryDelay is the wait after a failed attempt, doubling each time
+func jobRetryDelay(attempt int) time.Duration {
+	delay := config.AppConfig.JobRetryDelay
+	for i := 1; i < attempt && delay < jobMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, jobMaxRetryDelay)
+}
+
+func (js *JobService) finish(job *models.Job, status string, result interface{}, err error) {
+	set := bson.M{"status": status, "finished_at": time.Now()}
+	if result != nil {
+		set["result"] = jobResult(result)
+	}
+	if err != nil {
+		set["error"] = err.Error()
+	}
+	js.update(job, bson.M{"$set": set, "$unset": bson.M{"locked_until": ""}})
+}
+
+func (js *JobService) retry(job *models.Job, err error) {
+	js.update(job, bson.M{
+		"$set":   bson.M{"status": models.JobQueued, "run_at": time.Now().Add(jobRetryDelay(job.Attempts)), "error": err.Error()},
+		"$unset": bson.M{"worker": "", "locked_until": ""},
+	})
+}
+
+func (js *JobService) release(job *models.Job) {
+	js.update(job, bson.M{
+		"$set":   bson.M{"status": models.JobQueued, "run_at": time.Now()},
+		"$inc":   bson.M{"attempts": -1},
+		"$unset": bson.M{"worker": "", "locked_until": ""},
+	})
+}
+
+// update changes a job this worker still holds; a job whose lock expired
+// and was claimed again is left to its new worker
+func (js *JobService) update(job *models.Job, update bson.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": job.ID, "worker": job.Worker, "attempts": job.Attempts}
+	if _, err := js.collection.UpdateOne(ctx, filter, update); err != nil {
+		log.Printf("Failed to update job %s: %v", job.ID.Hex(), err)
+	}
+}
+
+// jobResult stores a handler result as a plain document, so it reads back
+// the way it was returned
+func jobResult(result interface{}) map[string]interface{} {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return map[string]interface{}{"error": err.Error()}
+	}
+
+	var document map[string]interface{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return map[string]interface{}{"value": json.RawMessage(raw)}
+	}
+	return document
+}
+
+// Cleanup removes jobs finished before cutoff, with their export files
+func (js *JobService) Cleanup(ctx context.Context, cutoff time.Time) error {
+	filter := bson.M{"finished_at": bson.M{"$lt": cutoff}}
+
+	cursor, err := js.collection.Find(ctx, bson.M{"finished_at": bson.M{"$lt": cutoff}, "type": models.JobExport, "result.file_id": bson.M{"$exists": true}})
+	if err != nil {
+		return err
+	}
+	var exports []models.Job
+	if err := cursor.All(ctx, &exports); err != nil {
+		return err
+	}
+
+	bucket, err := exportBucket()
+	if err != nil {
+		return err
+	}
+	for _, job := range exports {
+		if fileID, ok := exportFileID(&job); ok {
+			if err := bucket.DeleteContext(ctx, fileID); err != nil && err != gridfs.ErrFileNotFound {
+				return err
+			}
+		}
+	}
+
+	_, err = js.collection.DeleteMany(ctx, filter)
+	return err
+}
+
+func (js *JobService) runGitHubSync(ctx context.Context, job *models.Job) (interface{}, error) {
+	username := job.Params["username"]
+	if username == "" {
+		username = NewSettingsService().GetOwner(ctx)
+	}
+	force, _ := strconv.ParseBool(job.Params["force"])
+	return NewGitHubService().SyncData(ctx, username, force)
+}
+
+// runCommitInsights refreshes the commit insights of username, the owner by
+// default
+func (js *JobService) runCommitInsights(ctx context.Context, job *models.Job) (interface{}, error) {
+	username := job.Params["username"]
+	if username == "" {
+		username = NewSettingsService().GetOwner(ctx)
+	}
+	return NewGitHubService().RefreshCommitInsights(ctx, username)
+}
+
+func (js *JobService) runCacheWarm(ctx context.Context, job *models.Job) (interface{}, error) {
+	if err := NewContentService().WarmCache(ctx); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"warmed_at": time.Now()}, nil
+}
+
+// runAnalyticsAggregate aggregates the page views between since and until,
+// the last 30 days by default
+func (js *JobService) runAnalyticsAggregate(ctx context.Context, job *models.Job) (interface{}, error) {
+	until := time.Now()
+	if value := job.Params["until"]; value != "" {
+		until, _ = parseJobTime(value)
+	}
+	since := until.AddDate(0, 0, -jobTrafficDays)
+	if value := job.Params["since"]; value != "" {
+		since, _ = parseJobTime(value)
+	}
+	return NewVisitorService().GetTraffic(ctx, since, until)
+}
+
+// runExport writes a collection export to GridFS, for download from
+// /jobs/:id/download
+func (js *JobService) runExport(ctx context.Context, job *models.Job) (interface{}, error) {
+	collection := job.Params["collection"]
+	format := job.Params["format"]
+	if format == "" {
+		format = models.ExportFormatNDJSON
+	}
+
+	query := models.ExportQuery{}
+	if value := job.Params["since"]; value != "" {
+		query.Since, _ = parseJobTime(value)
+	}
+
+	bucket, err := exportBucket()
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetWriteDeadline(deadline)
+	}
+	filename := collection + "." + format
+	upload, err := bucket.OpenUploadStream(filename, options.GridFSUpload().SetMetadata(bson.M{
+		"job_id":       job.ID,
+		"content_type": ExportContentTypes[format],
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	exports, err := NewExportWriter(format, upload)
+	if err != nil {
+		upload.Abort()
+		return nil, err
+	}
+
+	documents := 0
+	err = NewExportService().Stream(ctx, collection, query, func(doc bson.D) error {
+		documents++
+		return exports.Write(doc)
+	})
+	if err == nil {
+		err = exports.Close()
+	}
+	if err != nil {
+		upload.Abort()
+		return nil, err
+	}
+	if err := upload.Close(); err != nil {
+		return nil, err
+	}
+
+	return models.JobExportResult{
+		FileID:    upload.FileID.(primitive.ObjectID),
+		Filename:  filename,
+		Documents: documents,
+	}, nil
+}
+
+func exportBucket() (*gridfs.Bucket, error) {
+	return gridfs.NewBucket(database.Database, options.GridFSBucket().SetName(jobExportBucket))
+}
+
+// exportFileID reads the file ID out of an export job's result
+func exportFileID(job *models.Job) (primitive.ObjectID, bool) {
+	hex, _ := job.Result["file_id"].(string)
+	fileID, err := primitive.ObjectIDFromHex(hex)
+	return fileID, err == nil
+}
+
+// OpenExport opens the file written by a finished export job, returning it
+// with its filename and content type
+func (js *JobService) OpenExport(ctx context.Context, id string) (*gridfs.DownloadStream, string, string, error) {
+	job, err := js.GetJob(ctx, id)
+	if err != nil {
+		return nil, "", "", err
+	}
+	fileID, ok := exportFileID(job)
+	if job.Type != models.JobExport || job.Status != models.JobSucceeded || !ok {
+		return nil, "", "", ErrJobNoExport
+	}
+
+	bucket, err := exportBucket()
+	if err != nil {
+		return nil, "", "", err
+	}
+	download, err := bucket.OpenDownloadStream(fileID)
+	if err == gridfs.ErrFileNotFound {
+		return nil, "", "", ErrJobNoExport
+	}
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	format := job.Params["format"]
+	if format == "" {
+		format = models.ExportFormatNDJSON
+	}
+	filename, _ := job.Result["filename"].(string)
+	return download, filename, ExportContentTypes[format], nil
+}
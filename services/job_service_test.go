@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateJobParams(t *testing.T) {
+	assert.NoError(t, validateJobParams(models.JobGitHubSync, nil))
+	assert.NoError(t, validateJobParams(models.JobGitHubSync, map[string]string{"username": "octocat", "force": "true"}))
+	assert.ErrorIs(t, validateJobParams(models.JobGitHubSync, map[string]string{"username": "-octocat"}), ErrInvalidJobParams)
+	assert.ErrorIs(t, validateJobParams(models.JobGitHubSync, map[string]string{"force": "yes please"}), ErrInvalidJobParams)
+
+	assert.NoError(t, validateJobParams(models.JobAnalyticsAggregate, map[string]string{"since": "2025-01-01", "until": "2025-02-01T00:00:00Z"}))
+	assert.ErrorIs(t, validateJobParams(models.JobAnalyticsAggregate, map[string]string{"since": "last week"}), ErrInvalidJobParams)
+
+	assert.NoError(t, validateJobParams(models.JobCommitInsights, map[string]string{"username": "octocat"}))
+	assert.ErrorIs(t, validateJobParams(models.JobCommitInsights, map[string]string{"username": "-octocat"}), ErrInvalidJobParams)
+
+	assert.NoError(t, validateJobParams(models.JobExport, map[string]string{"collection": "content", "format": "csv"}))
+	assert.ErrorIs(t, validateJobParams(models.JobExport, map[string]string{"collection": "settings"}), ErrInvalidJobParams)
+	assert.ErrorIs(t, validateJobParams(models.JobExport, map[string]string{"collection": "content", "format": "xml"}), ErrInvalidJobParams)
+}
+
+func TestEnqueueRejectsUnknownType(t *testing.T) {
+	useOfflineDatabase(t, &config.Config{JobMaxAttempts: 3})
+
+	_, err := NewJobService().Enqueue(context.Background(), models.JobRequest{Type: "reindex"}, "admin")
+	assert.ErrorIs(t, err, ErrUnknownJobType)
+}
+
+func TestJobRetryDelay(t *testing.T) {
+	config.AppConfig = &config.Config{JobRetryDelay: 30 * time.Second}
+
+	assert.Equal(t, 30*time.Second, jobRetryDelay(1))
+	assert.Equal(t, time.Minute, jobRetryDelay(2))
+	assert.Equal(t, 2*time.Minute, jobRetryDelay(3))
+	assert.Equal(t, jobMaxRetryDelay, jobRetryDelay(20))
+}
+
+func TestJobResult(t *testing.T) {
+	result := jobResult(models.SyncStatus{Username: "octocat"})
+	assert.Equal(t, "octocat", result["username"])
+
+	wrapped := jobResult([]int{1, 2})
+	assert.Contains(t, wrapped, "value")
+}
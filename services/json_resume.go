@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"portfolio-backend/models"
+	"strings"
+	"time"
+)
+
+// jsonResumeDate is the date layout of the jsonresume.org schema
+const jsonResumeDate = "2006-01-02"
+
+// GetJSONResume returns the published meta, skills, experience, projects and
+// education as a resume in the jsonresume.org schema, for the resume
+// renderers built around it
+func (cs *ContentService) GetJSONResume(ctx context.Context) (*models.JSONResume, error) {
+	meta, err := cs.GetMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := cs.GetSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	experience, err := cs.GetExperience(ctx)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := cs.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	education, err := cs.GetEducation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildJSONResume(*meta, *skills, experience, projects, education, time.Now()), nil
+}
+
+func buildJSONResume(meta models.Meta, skills models.Skills, experience []models.Experience, projects []models.Project, education []models.Education, now time.Time) *models.JSONResume {
+	resume := &models.JSONResume{
+		Schema:    models.JSONResumeSchema,
+		Basics:    jsonResumeBasics(meta),
+		Work:      make([]models.JSONResumeWork, 0, len(experience)),
+		Education: make([]models.JSONResumeEducation, 0, len(education)),
+		Skills:    jsonResumeSkills(skills),
+		Projects:  make([]models.JSONResumeProject, 0, len(projects)),
+		Meta: models.JSONResumeMeta{
+			Version:      "v1.0.0",
+			LastModified: now.UTC().Format(time.RFC3339),
+		},
+	}
+
+	for _, exp := range experience {
+		work := models.JSONResumeWork{
+			Name:       exp.Company,
+			Position:   exp.Position,
+			Location:   exp.Location,
+			URL:        exp.CompanyURL,
+			StartDate:  jsonResumeDateOf(&exp.StartDate),
+			Summary:    exp.Description,
+			Highlights: jsonResumeList(exp.Achievements),
+		}
+		if !exp.IsCurrent {
+			work.EndDate = jsonResumeDateOf(exp.EndDate)
+		}
+		resume.Work = append(resume.Work, work)
+	}
+
+	for _, edu := range education {
+		entry := models.JSONResumeEducation{
+			Institution: edu.Institution,
+			URL:         edu.URL,
+			Area:        edu.Field,
+			StudyType:   edu.Degree,
+			StartDate:   jsonResumeDateOf(&edu.StartDate),
+			EndDate:     jsonResumeDateOf(edu.EndDate),
+			Courses:     jsonResumeList(edu.Courses),
+		}
+		if edu.GPA > 0 {
+			entry.Score = fmt.Sprintf("%g", edu.GPA)
+		}
+		resume.Education = append(resume.Education, entry)
+	}
+
+	for _, project := range projects {
+		entry := models.JSONResumeProject{
+			Name:        project.Name,
+			Description: project.Description,
+			Highlights:  jsonResumeList(project.Highlights),
+			Keywords:    jsonResumeList(project.Technologies),
+			StartDate:   jsonResumeDateOf(&project.StartDate),
+			EndDate:     jsonResumeDateOf(project.EndDate),
+			URL:         project.LiveURL,
+			Type:        project.Category,
+		}
+		if entry.URL == "" {
+			entry.URL = project.GitHubURL
+		}
+		resume.Projects = append(resume.Projects, entry)
+	}
+	return resume
+}
+
+// jsonResumeBasics maps the meta content
+func jsonResumeBasics(meta models.Meta) models.JSONResumeBasics {
+	basics := models.JSONResumeBasics{
+		Name:     meta.Name,
+		Label:    meta.Title,
+		Email:    meta.Email,
+		URL:      meta.Website,
+		Summary:  meta.Bio,
+		Profiles: []models.JSONResumeProfile{},
+	}
+
+	if city, region := splitLocation(meta.Location); city != "" {
+		basics.Location = &models.JSONResumeLocation{City: city, Region: region}
+	}
+
+	if meta.GitHub != "" {
+		basics.Profiles = append(basics.Profiles, models.JSONResumeProfile{
+			Network:  "GitHub",
+			Username: meta.GitHub,
+			URL:      "https://github.com/" + meta.GitHub,
+		})
+	}
+	if meta.LinkedIn != "" {
+		basics.Profiles = append(basics.Profiles, models.JSONResumeProfile{
+			Network: "LinkedIn",
+			URL:     meta.LinkedIn,
+		})
+	}
+	return basics
+}
+
+// splitLocation splits a "City, Region" location at its first comma; anything
+// else is taken as the city
+func splitLocation(location string) (city, region string) {
+	city, region, _ = strings.Cut(strings.TrimSpace(location), ",")
+	return strings.TrimSpace(city), strings.TrimSpace(region)
+}
+
+// jsonResumeSkills lists a skill group per category that has any skills
+func jsonResumeSkills(skills models.Skills) []models.JSONResumeSkill {
+	groups := []struct {
+		name   string
+		skills []models.Skill
+	}{
+		{"Backend", skills.Backend},
+		{"Frontend", skills.Frontend},
+		{"Database", skills.Database},
+		{"DevOps", skills.DevOps},
+		{"Tools", skills.Tools},
+		{"Languages", skills.Languages},
+	}
+
+	result := []models.JSONResumeSkill{}
+	for _, group := range groups {
+		if len(group.skills) == 0 {
+			continue
+		}
+		keywords := make([]string, 0, len(group.skills))
+		for _, skill := range group.skills {
+			keywords = append(keywords, skill.Name)
+		}
+		result = append(result, models.JSONResumeSkill{Name: group.name, Keywords: keywords})
+	}
+	return result
+}
+
+// jsonResumeDateOf formats date, empty when unset
+func jsonResumeDateOf(date *time.Time) string {
+	if date == nil || date.IsZero() {
+		return ""
+	}
+	return date.Format(jsonResumeDate)
+}
+
+// jsonResumeList keeps empty lists as [] rather than null
+func jsonResumeList(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildJSONResume(t *testing.T) {
+	start := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+	meta := models.Meta{
+		Name:     "Ada Lovelace",
+		Title:    "Backend Engineer",
+		Location: "São Paulo, SP",
+		GitHub:   "ada",
+		LinkedIn: "https://linkedin.com/in/ada",
+		Bio:      "Builds APIs",
+	}
+	skills := models.Skills{
+		Backend: []models.Skill{{Name: "Go"}, {Name: "gRPC"}},
+		Tools:   []models.Skill{{Name: "Docker"}},
+	}
+	experience := []models.Experience{
+		{Company: "Acme", Position: "Engineer", StartDate: start, EndDate: &end, Achievements: []string{"Shipped v2"}},
+		{Company: "Initech", Position: "Lead", StartDate: end, EndDate: &end, IsCurrent: true},
+	}
+	projects := []models.Project{
+		{Name: "api", GitHubURL: "https://github.com/ada/api", Technologies: []string{"Go"}, StartDate: start},
+		{Name: "site", GitHubURL: "https://github.com/ada/site", LiveURL: "https://ada.dev"},
+	}
+	education := []models.Education{
+		{Institution: "USP", Degree: "BSc", Field: "Computer Science", StartDate: start, GPA: 3.8},
+	}
+
+	resume := buildJSONResume(meta, skills, experience, projects, education, end)
+
+	assert.Equal(t, models.JSONResumeSchema, resume.Schema)
+	assert.Equal(t, "Backend Engineer", resume.Basics.Label)
+	assert.Equal(t, &models.JSONResumeLocation{City: "São Paulo", Region: "SP"}, resume.Basics.Location)
+	assert.Equal(t, []models.JSONResumeProfile{
+		{Network: "GitHub", Username: "ada", URL: "https://github.com/ada"},
+		{Network: "LinkedIn", URL: "https://linkedin.com/in/ada"},
+	}, resume.Basics.Profiles)
+
+	assert.Equal(t, []models.JSONResumeSkill{
+		{Name: "Backend", Keywords: []string{"Go", "gRPC"}},
+		{Name: "Tools", Keywords: []string{"Docker"}},
+	}, resume.Skills, "empty skill groups are left out")
+
+	if assert.Len(t, resume.Work, 2) {
+		assert.Equal(t, "2021-03-01", resume.Work[0].StartDate)
+		assert.Equal(t, "2023-06-30", resume.Work[0].EndDate)
+		assert.Equal(t, []string{"Shipped v2"}, resume.Work[0].Highlights)
+		assert.Empty(t, resume.Work[1].EndDate, "current positions have no end date")
+		assert.NotNil(t, resume.Work[1].Highlights)
+	}
+
+	if assert.Len(t, resume.Projects, 2) {
+		assert.Equal(t, "https://github.com/ada/api", resume.Projects[0].URL)
+		assert.Equal(t, []string{"Go"}, resume.Projects[0].Keywords)
+		assert.Equal(t, "https://ada.dev", resume.Projects[1].URL, "the live URL is preferred")
+		assert.Empty(t, resume.Projects[1].StartDate)
+	}
+
+	if assert.Len(t, resume.Education, 1) {
+		assert.Equal(t, "Computer Science", resume.Education[0].Area)
+		assert.Equal(t, "BSc", resume.Education[0].StudyType)
+		assert.Equal(t, "3.8", resume.Education[0].Score)
+	}
+	assert.Equal(t, "2023-06-30T00:00:00Z", resume.Meta.LastModified)
+}
+
+func TestBuildJSONResumeEmpty(t *testing.T) {
+	resume := buildJSONResume(models.Meta{Name: "Ada"}, models.Skills{}, nil, nil, nil, time.Now())
+
+	assert.Nil(t, resume.Basics.Location)
+	assert.NotNil(t, resume.Basics.Profiles)
+	assert.NotNil(t, resume.Work)
+	assert.NotNil(t, resume.Education)
+	assert.NotNil(t, resume.Skills)
+	assert.NotNil(t, resume.Projects)
+}
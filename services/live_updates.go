@@ -0,0 +1,246 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrUnknownLiveTopic is returned for topics not in models.LiveTopics
+	ErrUnknownLiveTopic = errors.New("unknown live topic")
+	// ErrLiveConnectionLimit is returned once LIVE_MAX_CONNECTIONS clients
+	// are connected to this instance
+	ErrLiveConnectionLimit = errors.New("too many live connections")
+)
+
+// liveEventBuffer is how many events a slow connection may fall behind
+// before further events are dropped for it
+const liveEventBuffer = 32
+
+// liveListeners are in-process hooks run for every published event
+var liveListeners struct {
+	sync.RWMutex
+	hooks []func(event models.LiveMessage)
+}
+
+// liveHub fans events out to the live updates connections of this instance
+var liveHub = struct {
+	sync.RWMutex
+	subscriptions map[*LiveSubscription]bool
+}{subscriptions: make(map[*LiveSubscription]bool)}
+
+// LiveSubscription receives the events of the topics a WebSocket connection
+// subscribed to, and rate limits the messages the connection sends
+type LiveSubscription struct {
+	events chan models.LiveMessage
+	mu     sync.Mutex
+	topics map[string]bool
+	closed bool
+
+	// Token bucket refilled with LIVE_MESSAGE_LIMIT tokens per minute
+	tokens   float64
+	refilled time.Time
+}
+
+// SubscribeLive registers a connection for topics, or every topic if none
+// are given. The subscription must be closed when the connection ends.
+func SubscribeLive(topics []string) (*LiveSubscription, error) {
+	if len(topics) == 0 {
+		topics = models.LiveTopics
+	}
+	if err := validateLiveTopics(topics); err != nil {
+		return nil, err
+	}
+
+	sub := &LiveSubscription{
+		events:   make(chan models.LiveMessage, liveEventBuffer),
+		topics:   make(map[string]bool),
+		tokens:   float64(config.AppConfig.LiveMessageLimit),
+		refilled: time.Now(),
+	}
+	for _, topic := range topics {
+		sub.topics[topic] = true
+	}
+
+	liveHub.Lock()
+	defer liveHub.Unlock()
+	if max := config.AppConfig.LiveMaxConnections; max > 0 && len(liveHub.subscriptions) >= max {
+		return nil, ErrLiveConnectionLimit
+	}
+	liveHub.subscriptions[sub] = true
+	return sub, nil
+}
+
+func validateLiveTopics(topics []string) error {
+	for _, topic := range topics {
+		known := false
+		for _, live := range models.LiveTopics {
+			known = known || topic == live
+		}
+		if !known {
+			return fmt.Errorf("%w: %q", ErrUnknownLiveTopic, topic)
+		}
+	}
+	return nil
+}
+
+// Events delivers the events of the subscribed topics. It is closed when
+// the subscription is.
+func (s *LiveSubscription) Events() <-chan models.LiveMessage {
+	return s.events
+}
+
+// Subscribe adds topics to the subscription
+func (s *LiveSubscription) Subscribe(topics []string) error {
+	if err := validateLiveTopics(topics); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, topic := range topics {
+		s.topics[topic] = true
+	}
+	return nil
+}
+
+// Unsubscribe removes topics from the subscription
+func (s *LiveSubscription) Unsubscribe(topics []string) error {
+	if err := validateLiveTopics(topics); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, topic := range topics {
+		delete(s.topics, topic)
+	}
+	return nil
+}
+
+// Topics lists the subscribed topics
+func (s *LiveSubscription) Topics() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	topics := make([]string, 0, len(s.topics))
+	for topic := range s.topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// Allow takes a token for a message sent by the connection, reporting false
+// once it sends more than LIVE_MESSAGE_LIMIT messages a minute
+func (s *LiveSubscription) Allow() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	limit := float64(config.AppConfig.LiveMessageLimit)
+	if limit <= 0 {
+		return true
+	}
+
+	now := time.Now()
+	s.tokens += now.Sub(s.refilled).Minutes() * limit
+	if s.tokens > limit {
+		s.tokens = limit
+	}
+	s.refilled = now
+
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
+
+// Close unregisters the subscription and closes its events channel
+func (s *LiveSubscription) Close() {
+	liveHub.Lock()
+	delete(liveHub.subscriptions, s)
+	liveHub.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.events)
+	}
+}
+
+// deliver queues an event for the connection, dropping it when the topic is
+// not subscribed or the connection is too far behind
+func (s *LiveSubscription) deliver(event models.LiveMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || !s.topics[event.Topic] {
+		return
+	}
+
+	select {
+	case s.events <- event:
+	default:
+	}
+}
+
+// PublishLive sends an event to the live updates connections subscribed to
+// topic, then runs the hooks registered with OnLiveEvent. It never waits on
+// slow connections; events only reach clients of this instance.
+func PublishLive(topic, eventType string, data interface{}) {
+	event := models.LiveMessage{
+		Type:      eventType,
+		Topic:     topic,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+
+	liveHub.RLock()
+	for sub := range liveHub.subscriptions {
+		sub.deliver(event)
+	}
+	liveHub.RUnlock()
+
+	liveListeners.RLock()
+	hooks := liveListeners.hooks
+	liveListeners.RUnlock()
+	for _, hook := range hooks {
+		hook(event)
+	}
+}
+
+// OnLiveEvent registers hook to run, before PublishLive returns, for every
+// event published on this instance
+func OnLiveEvent(hook func(event models.LiveMessage)) {
+	liveListeners.Lock()
+	defer liveListeners.Unlock()
+	liveListeners.hooks = append(liveListeners.hooks, hook)
+}
+
+// CloseLiveSubscriptions ends every live updates connection, on shutdown
+func CloseLiveSubscriptions() {
+	liveHub.RLock()
+	subscriptions := make([]*LiveSubscription, 0, len(liveHub.subscriptions))
+	for sub := range liveHub.subscriptions {
+		subscriptions = append(subscriptions, sub)
+	}
+	liveHub.RUnlock()
+
+	for _, sub := range subscriptions {
+		sub.Close()
+	}
+}
+
+// publishContentUpdated tells live clients that contentType was saved
+func publishContentUpdated(contentType string, version int) {
+	PublishLive(models.LiveTopicContent, models.LiveContentUpdated, map[string]interface{}{
+		"content_type": contentType,
+		"version":      version,
+	})
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveSubscriptionTopics(t *testing.T) {
+	config.AppConfig = &config.Config{LiveMessageLimit: 30}
+
+	_, err := SubscribeLive([]string{"content", "weather"})
+	assert.ErrorIs(t, err, ErrUnknownLiveTopic)
+
+	sub, err := SubscribeLive([]string{models.LiveTopicContent})
+	require.NoError(t, err)
+	defer sub.Close()
+
+	PublishLive(models.LiveTopicGitHub, models.LiveGitHubSynced, nil)
+	publishContentUpdated("projects", 4)
+	event := <-sub.Events()
+	assert.Equal(t, models.LiveContentUpdated, event.Type)
+	assert.Equal(t, 4, event.Data.(map[string]interface{})["version"])
+	assert.Empty(t, sub.Events())
+
+	require.NoError(t, sub.Subscribe([]string{models.LiveTopicGitHub}))
+	require.NoError(t, sub.Unsubscribe([]string{models.LiveTopicContent}))
+	assert.Equal(t, []string{models.LiveTopicGitHub}, sub.Topics())
+	PublishLive(models.LiveTopicGitHub, models.LiveGitHubSynced, nil)
+	assert.Equal(t, models.LiveGitHubSynced, (<-sub.Events()).Type)
+
+	sub.Close()
+	_, open := <-sub.Events()
+	assert.False(t, open)
+	PublishLive(models.LiveTopicGitHub, models.LiveGitHubSynced, nil)
+}
+
+func TestLiveConnectionLimit(t *testing.T) {
+	config.AppConfig = &config.Config{LiveMaxConnections: 1}
+
+	sub, err := SubscribeLive(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"analytics", "content", "github"}, sub.Topics())
+
+	_, err = SubscribeLive(nil)
+	assert.ErrorIs(t, err, ErrLiveConnectionLimit)
+
+	sub.Close()
+	sub, err = SubscribeLive(nil)
+	require.NoError(t, err)
+	sub.Close()
+}
+
+func TestLiveSubscriptionAllow(t *testing.T) {
+	config.AppConfig = &config.Config{LiveMessageLimit: 3}
+
+	sub, err := SubscribeLive(nil)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, sub.Allow())
+	}
+	assert.False(t, sub.Allow())
+}
+
+func TestOnLiveEvent(t *testing.T) {
+	var seen []string
+	OnLiveEvent(func(event models.LiveMessage) {
+		seen = append(seen, event.Type)
+	})
+
+	PublishLive(models.LiveTopicAnalytics, models.LivePageViews, map[string]int{"count": 3})
+	assert.Equal(t, []string{models.LivePageViews}, seen)
+}
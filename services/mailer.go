@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"portfolio-backend/config"
+	"strings"
+	"time"
+)
+
+// MailMessage is a plain-text email
+type MailMessage struct {
+	To      []string
+	Subject string
+	Body    string
+	ReplyTo string
+}
+
+// Mailer delivers outbound email
+type Mailer interface {
+	Send(ctx context.Context, message MailMessage) error
+}
+
+// NewMailer returns an SMTP mailer when SMTP_HOST is configured and a
+// logging mailer otherwise, so features depending on email keep working in
+// development
+func NewMailer() Mailer {
+	if config.AppConfig.SMTPHost == "" {
+		return logMailer{}
+	}
+	return smtpMailer{
+		host:     config.AppConfig.SMTPHost,
+		port:     config.AppConfig.SMTPPort,
+		username: config.AppConfig.SMTPUsername,
+		password: config.AppConfig.SMTPPassword,
+		from:     config.AppConfig.SMTPFrom,
+	}
+}
+
+type smtpMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+func (sm smtpMailer) Send(ctx context.Context, message MailMessage) error {
+	if len(message.To) == 0 {
+		return fmt.Errorf("email has no recipients")
+	}
+
+	var auth smtp.Auth
+	if sm.username != "" {
+		auth = smtp.PlainAuth("", sm.username, sm.password, sm.host)
+	}
+
+	headers := []string{
+		"From: " + sm.from,
+		"To: " + strings.Join(message.To, ", "),
+		"Subject: " + message.Subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+	if message.ReplyTo != "" {
+		headers = append(headers, "Reply-To: "+message.ReplyTo)
+	}
+
+	body := strings.Join(headers, "\r\n") + "\r\n\r\n" + strings.ReplaceAll(message.Body, "\n", "\r\n")
+	addr := net.JoinHostPort(sm.host, fmt.Sprint(sm.port))
+
+	// net/smtp has no context support; run it so callers can still time out
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(addr, auth, sm.from, message.To, []byte(body))
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type logMailer struct{}
+
+func (logMailer) Send(ctx context.Context, message MailMessage) error {
+	log.Printf("SMTP not configured, email to %s not sent: %s", strings.Join(message.To, ", "), message.Subject)
+	return nil
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Events pushed to the notification channels, toggled with NOTIFY_EVENTS
+const (
+	NotifySyncFailed       = "sync_failed"
+	NotifyContactMessage   = "contact_message"
+	NotifyContentPublished = "content_published"
+)
+
+// notifyTimeout bounds the delivery of a notification to each channel
+const notifyTimeout = 15 * time.Second
+
+// discordMaxContent is the longest message a Discord webhook accepts
+const discordMaxContent = 2000
+
+var notifierStart sync.Once
+
+// notifyClient posts notifications; tests point channels at local servers
+var notifyClient = &http.Client{Timeout: notifyTimeout}
+
+// notificationChannel is a Slack, Discord or Telegram chat notifications are
+// posted to, as the JSON body its webhook expects
+type notificationChannel struct {
+	Name string
+	URL  string
+	Body func(text string) interface{}
+}
+
+// telegramNotifyRefresh is how long the admin bot settings are reused across
+// notifications; saving them drops them right away
+const telegramNotifyRefresh = time.Minute
+
+// telegramNotify holds the admin bot settings last read for notifications
+var telegramNotify struct {
+	sync.Mutex
+	settings *models.TelegramSettings
+	readAt   time.Time
+}
+
+// loadTelegramNotifySettings reads the Telegram bot configured in the admin
+// settings, a variable so tests need no database
+var loadTelegramNotifySettings = func(ctx context.Context) (*models.TelegramSettings, error) {
+	return (&TelegramService{settingsService: NewSettingsService()}).GetSettings(ctx)
+}
+
+// telegramNotifySettings returns the admin bot settings, read at most once
+// per telegramNotifyRefresh. A failed read counts as a disabled bot until
+// the next one.
+func telegramNotifySettings(ctx context.Context) *models.TelegramSettings {
+	telegramNotify.Lock()
+	defer telegramNotify.Unlock()
+
+	if telegramNotify.settings != nil && time.Since(telegramNotify.readAt) < telegramNotifyRefresh {
+		return telegramNotify.settings
+	}
+	settings, err := loadTelegramNotifySettings(ctx)
+	if err != nil {
+		log.Printf("Telegram settings unavailable for notifications: %v", err)
+		settings = &models.TelegramSettings{}
+	}
+	telegramNotify.settings, telegramNotify.readAt = settings, time.Now()
+	return settings
+}
+
+// forgetTelegramNotifySettings makes the next notification read the admin
+// bot settings again
+func forgetTelegramNotifySettings() {
+	telegramNotify.Lock()
+	defer telegramNotify.Unlock()
+	telegramNotify.settings = nil
+}
+
+// notificationChannels returns the channels configured in the environment.
+// The Telegram chat is the admin bot's while it is enabled.
+func notificationChannels(ctx context.Context) []notificationChannel {
+	var channels []notificationChannel
+	if webhook := config.AppConfig.SlackWebhookURL; webhook != "" {
+		channels = append(channels, notificationChannel{
+			Name: "slack",
+			URL:  webhook,
+			Body: func(text string) interface{} {
+				return map[string]string{"text": text}
+			},
+		})
+	}
+	if webhook := config.AppConfig.DiscordWebhookURL; webhook != "" {
+		channels = append(channels, notificationChannel{
+			Name: "discord",
+			URL:  webhook,
+			Body: func(text string) interface{} {
+				return map[string]string{"content": truncateRunes(text, discordMaxContent)}
+			},
+		})
+	}
+	token, chatID := config.AppConfig.NotifyTelegramToken, config.AppConfig.NotifyTelegramChatID
+	if bot := telegramNotifySettings(ctx); bot.Enabled && bot.BotToken != "" && bot.ChatID != "" {
+		token, chatID = bot.BotToken, bot.ChatID
+	}
+	if token != "" && chatID != "" {
+		channels = append(channels, notificationChannel{
+			Name: "telegram",
+			URL:  fmt.Sprintf("%s/bot%s/sendMessage", telegramAPIURL, token),
+			Body: func(text string) interface{} {
+				return map[string]string{"chat_id": chatID, "text": text}
+			},
+		})
+	}
+	return channels
+}
+
+// notifyEnabled reports whether NOTIFY_EVENTS lists event
+func notifyEnabled(event string) bool {
+	for _, enabled := range strings.Split(config.AppConfig.NotifyEvents, ",") {
+		if strings.TrimSpace(enabled) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Notify pushes text to every configured channel in the background, unless
+// event is toggled off. Failures are logged; callers never block on or fail
+// because of a channel.
+func Notify(event, text string) {
+	if !notifyEnabled(event) {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+
+		for _, channel := range notificationChannels(ctx) {
+			go func(channel notificationChannel) {
+				ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+				defer cancel()
+
+				if err := channel.send(ctx, text); err != nil {
+					log.Printf("%s notification error: %v", channel.Name, err)
+				}
+			}(channel)
+		}
+	}()
+}
+
+// StartNotifier notifies the content published on this instance
+func StartNotifier() {
+	notifierStart.Do(func() {
+		OnLiveEvent(func(event models.LiveMessage) {
+			if text := contentPublishedText(event); text != "" {
+				Notify(NotifyContentPublished, text)
+			}
+		})
+	})
+}
+
+// contentPublishedText describes a content.updated event, or is empty for
+// other events
+func contentPublishedText(event models.LiveMessage) string {
+	if event.Type != models.LiveContentUpdated {
+		return ""
+	}
+	data, ok := event.Data.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	contentType, _ := data["content_type"].(string)
+	if contentType == "" {
+		return ""
+	}
+	if version, ok := data["version"].(int); ok && version > 0 {
+		return fmt.Sprintf("📝 Content published: %s (version %d)", contentType, version)
+	}
+	return "📝 Content published: " + contentType
+}
+
+// contactMessageText announces a contact message without its body, which
+// stays in the admin API and the owner's email
+func contactMessageText(message models.ContactMessage) string {
+	text := fmt.Sprintf("📬 New contact message from %s <%s>", message.Name, message.Email)
+	if message.Subject != "" {
+		text += ": " + message.Subject
+	}
+	return text
+}
+
+func (nc notificationChannel) send(ctx context.Context, text string) error {
+	body, err := json.Marshal(nc.Body(text))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", nc.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := notifyClient.Do(req)
+	if err != nil {
+		// Webhook URLs and the Telegram endpoint embed their secret
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("%s request failed: %w", nc.Name, urlErr.Err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// truncateRunes cuts text to at most max characters
+func truncateRunes(text string, max int) string {
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	return string([]rune(text)[:max-1]) + "…"
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTelegramNotifySettings makes the notifier read settings instead of the
+// database for the rest of the test, returning how many reads it made
+func stubTelegramNotifySettings(t *testing.T, settings *models.TelegramSettings) *int {
+	reads := 0
+	original := loadTelegramNotifySettings
+	loadTelegramNotifySettings = func(context.Context) (*models.TelegramSettings, error) {
+		reads++
+		return settings, nil
+	}
+	forgetTelegramNotifySettings()
+	t.Cleanup(func() {
+		loadTelegramNotifySettings = original
+		forgetTelegramNotifySettings()
+	})
+	return &reads
+}
+
+func TestNotificationChannels(t *testing.T) {
+	ctx := context.Background()
+	stubTelegramNotifySettings(t, &models.TelegramSettings{})
+	config.AppConfig = &config.Config{}
+	assert.Empty(t, notificationChannels(ctx))
+
+	settings := &models.TelegramSettings{Enabled: true, BotToken: "123:token", ChatID: "42"}
+	reads := stubTelegramNotifySettings(t, settings)
+	config.AppConfig = &config.Config{
+		SlackWebhookURL:      "https://hooks.slack.com/services/T/B/X",
+		DiscordWebhookURL:    "https://discord.com/api/webhooks/1/abc",
+		NotifyTelegramToken:  "456:env",
+		NotifyTelegramChatID: "7",
+	}
+	channels := notificationChannels(ctx)
+	require.Len(t, channels, 3)
+
+	assert.Equal(t, "slack", channels[0].Name)
+	assert.Equal(t, map[string]string{"text": "hi"}, channels[0].Body("hi"))
+
+	assert.Equal(t, "discord", channels[1].Name)
+	long := channels[1].Body(strings.Repeat("é", 3000)).(map[string]string)["content"]
+	assert.Equal(t, discordMaxContent, len([]rune(long)))
+
+	assert.Equal(t, "telegram", channels[2].Name)
+	assert.Equal(t, telegramAPIURL+"/bot123:token/sendMessage", channels[2].URL)
+	assert.Equal(t, map[string]string{"chat_id": "42", "text": "hi"}, channels[2].Body("hi"))
+
+	// The settings are read once per telegramNotifyRefresh
+	notificationChannels(ctx)
+	assert.Equal(t, 1, *reads)
+
+	// Without an enabled bot the chat of the environment is used
+	settings.Enabled = false
+	forgetTelegramNotifySettings()
+	channels = notificationChannels(ctx)
+	require.Len(t, channels, 3)
+	assert.Equal(t, telegramAPIURL+"/bot456:env/sendMessage", channels[2].URL)
+	assert.Equal(t, map[string]string{"chat_id": "7", "text": "hi"}, channels[2].Body("hi"))
+
+	// A token without a chat is not a channel
+	config.AppConfig.NotifyTelegramChatID = ""
+	assert.Len(t, notificationChannels(ctx), 2)
+}
+
+func TestNotifyEnabled(t *testing.T) {
+	config.AppConfig = &config.Config{NotifyEvents: "sync_failed, contact_message"}
+	assert.True(t, notifyEnabled(NotifySyncFailed))
+	assert.True(t, notifyEnabled(NotifyContactMessage))
+	assert.False(t, notifyEnabled(NotifyContentPublished))
+
+	config.AppConfig.NotifyEvents = ""
+	assert.False(t, notifyEnabled(NotifySyncFailed))
+}
+
+func TestNotificationChannelSend(t *testing.T) {
+	var received map[string]string
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	stubTelegramNotifySettings(t, &models.TelegramSettings{})
+	config.AppConfig = &config.Config{DiscordWebhookURL: server.URL}
+	channel := notificationChannels(context.Background())[0]
+	require.NoError(t, channel.send(context.Background(), "sync failed"))
+	assert.Equal(t, map[string]string{"content": "sync failed"}, received)
+
+	status = http.StatusTooManyRequests
+	assert.Error(t, channel.send(context.Background(), "sync failed"))
+}
+
+func TestNotificationChannelSendRedactsURL(t *testing.T) {
+	channel := notificationChannel{
+		Name: "slack",
+		URL:  "http://127.0.0.1:1/services/secret",
+		Body: func(text string) interface{} { return map[string]string{"text": text} },
+	}
+	err := channel.send(context.Background(), "hi")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func TestNotificationTexts(t *testing.T) {
+	event := models.LiveMessage{
+		Type: models.LiveContentUpdated,
+		Data: map[string]interface{}{"content_type": "projects", "version": 4},
+	}
+	assert.Equal(t, "📝 Content published: projects (version 4)", contentPublishedText(event))
+
+	event.Data = map[string]interface{}{"content_type": "skills", "version": 0}
+	assert.Equal(t, "📝 Content published: skills", contentPublishedText(event))
+
+	assert.Empty(t, contentPublishedText(models.LiveMessage{Type: models.LiveGitHubSynced}))
+
+	message := models.ContactMessage{Name: "Ada", Email: "ada@example.com", Subject: "Hiring", Message: "private details"}
+	text := contactMessageText(message)
+	assert.Equal(t, "📬 New contact message from Ada <ada@example.com>: Hiring", text)
+	assert.NotContains(t, text, "private")
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"portfolio-backend/metrics"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Size of the social preview cards, as Open Graph and Twitter recommend
+const (
+	ogImageWidth  = 1200
+	ogImageHeight = 630
+	ogImageMargin = 80
+)
+
+// Colors of the social preview cards
+var (
+	ogBackground = color.RGBA{0x0d, 0x11, 0x17, 0xff}
+	ogTitle      = color.RGBA{0xf0, 0xf6, 0xfc, 0xff}
+	ogText       = color.RGBA{0xc9, 0xd1, 0xd9, 0xff}
+	ogMuted      = color.RGBA{0x8b, 0x94, 0x9e, 0xff}
+)
+
+// languageColors are GitHub's colors of common languages; others are grey
+var languageColors = map[string]string{
+	"C":          "#555555",
+	"C#":         "#178600",
+	"C++":        "#f34b7d",
+	"CSS":        "#563d7c",
+	"Dart":       "#00b4ab",
+	"Go":         "#00add8",
+	"HTML":       "#e34c26",
+	"Java":       "#b07219",
+	"JavaScript": "#f1e05a",
+	"Kotlin":     "#a97bff",
+	"PHP":        "#4f5d95",
+	"Python":     "#3572a5",
+	"Ruby":       "#701516",
+	"Rust":       "#dea584",
+	"Scala":      "#c22d40",
+	"Shell":      "#89e051",
+	"Swift":      "#f05138",
+	"TypeScript": "#3178c6",
+	"Vue":        "#41b883",
+}
+
+// RenderProjectOGImage renders the social preview card of a project, found
+// by its ID or by its slugified name: the owner's name, the project's name,
+// description, language and stars, as a 1200x630 PNG
+func (cs *ContentService) RenderProjectOGImage(ctx context.Context, idOrSlug string) ([]byte, error) {
+	projects, err := cs.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var project *models.Project
+	for i := range projects {
+		if projects[i].ID.Hex() == idOrSlug || utils.SlugifyString(projects[i].Name) == idOrSlug {
+			project = &projects[i]
+			break
+		}
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	owner := ""
+	if meta, err := cs.GetMeta(ctx); err == nil {
+		owner = meta.Name
+	}
+
+	start := time.Now()
+	defer func() { metrics.ObserveLayer(ctx, metrics.LayerRender, time.Since(start)) }()
+	return renderProjectOGImage(*project, owner)
+}
+
+func renderProjectOGImage(project models.Project, owner string) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, ogImageWidth, ogImageHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: ogBackground}, image.Point{}, draw.Src)
+
+	accent := parseHexColor("#6e7681")
+	if hex, ok := languageColors[project.Language]; ok {
+		accent = parseHexColor(hex)
+	}
+	draw.Draw(img, image.Rect(0, 0, 16, ogImageHeight), &image.Uniform{C: accent}, image.Point{}, draw.Src)
+
+	width := ogImageWidth - 2*ogImageMargin
+	y := ogImageMargin
+	if owner != "" {
+		drawBitmapText(img, ogImageMargin, y, owner, 4, ogMuted)
+		y += 7*4 + 40
+	}
+
+	for _, line := range bitmapWrap(project.Name, bitmapLineChars(width, 10), 2) {
+		drawBitmapText(img, ogImageMargin, y, line, 10, ogTitle)
+		y += 7*10 + 20
+	}
+	y += 10
+
+	for _, line := range bitmapWrap(project.Description, bitmapLineChars(width, 4), 4) {
+		drawBitmapText(img, ogImageMargin, y, line, 4, ogText)
+		y += 7*4 + 14
+	}
+
+	// Language and stars along the bottom
+	x, baseline := ogImageMargin, ogImageHeight-ogImageMargin-7*4
+	if project.Language != "" {
+		fillCircle(img, x+14, baseline+14, 14, accent)
+		x += 40
+		drawBitmapText(img, x, baseline, project.Language, 4, ogText)
+		x += bitmapTextWidth(project.Language, 4) + 60
+	}
+	stars := strconv.Itoa(project.Stars) + " stars"
+	if project.Stars == 1 {
+		stars = "1 star"
+	}
+	drawBitmapText(img, x, baseline, stars, 4, ogText)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// bitmapLineChars is how many characters fit in width at scale
+func bitmapLineChars(width, scale int) int {
+	return (width + scale) / (glyphAdvance * scale)
+}
+
+// bitmapWrap breaks s into at most maxLines lines of at most maxChars
+// characters, at spaces, cutting words too long for a line. Text left over
+// is replaced by an ellipsis.
+func bitmapWrap(s string, maxChars, maxLines int) []string {
+	var words [][]rune
+	for _, word := range strings.Fields(s) {
+		runes := []rune(word)
+		for len(runes) > maxChars {
+			words = append(words, runes[:maxChars])
+			runes = runes[maxChars:]
+		}
+		words = append(words, runes)
+	}
+
+	var lines [][]rune
+	for _, word := range words {
+		last := len(lines) - 1
+		if last >= 0 && len(lines[last])+1+len(word) <= maxChars {
+			lines[last] = append(append(lines[last], ' '), word...)
+			continue
+		}
+		if len(lines) == maxLines {
+			// Out of lines: end the last one with an ellipsis
+			line := lines[last]
+			if len(line)+3 > maxChars {
+				line = line[:maxChars-3]
+			}
+			lines[last] = append(line, []rune("...")...)
+			break
+		}
+		lines = append(lines, append([]rune(nil), word...))
+	}
+
+	wrapped := make([]string, len(lines))
+	for i, line := range lines {
+		wrapped[i] = string(line)
+	}
+	return wrapped
+}
+
+// fillCircle draws a disc of radius r centered on cx, cy
+func fillCircle(img *image.RGBA, cx, cy, r int, c color.RGBA) {
+	for y := cy - r; y <= cy+r; y++ {
+		for x := cx - r; x <= cx+r; x++ {
+			if dx, dy := x-cx, y-cy; dx*dx+dy*dy <= r*r {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"bytes"
+	"image/png"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlyphFor(t *testing.T) {
+	assert.Equal(t, font5x7['A'-0x20], glyphFor('A'))
+	assert.Equal(t, glyphFor('a'), glyphFor('ã'), "accented letters are drawn as their base letter")
+	assert.Equal(t, glyphFor('?'), glyphFor('世'))
+	assert.Equal(t, 0, bitmapTextWidth("", 4))
+	assert.Equal(t, (2*glyphAdvance-1)*4, bitmapTextWidth("Go", 4))
+}
+
+func TestBitmapWrap(t *testing.T) {
+	assert.Equal(t, []string{"lorem ipsum", "dolor sit", "amet"}, bitmapWrap("lorem ipsum dolor sit amet", 11, 3))
+	assert.Equal(t, []string{"lorem ipsum", "dolor si..."}, bitmapWrap("lorem ipsum dolor sit amet", 11, 2))
+	assert.Equal(t, []string{"abcd", "efgh", "ij"}, bitmapWrap("abcdefghij", 4, 3), "long words are cut")
+	assert.Empty(t, bitmapWrap("  ", 10, 2))
+}
+
+func TestRenderProjectOGImage(t *testing.T) {
+	project := models.Project{
+		Name:        "portfolio-backend",
+		Description: "Go API behind the portfolio, with GitHub sync, analytics and exports.",
+		Language:    "Go",
+		Stars:       42,
+	}
+	data, err := renderProjectOGImage(project, "Felipe Macedo")
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, ogImageWidth, img.Bounds().Dx())
+	assert.Equal(t, ogImageHeight, img.Bounds().Dy())
+	assert.Equal(t, parseHexColor(languageColors["Go"]), img.At(0, 0), "the accent bar has the language color")
+	assert.Equal(t, ogBackground, img.At(ogImageWidth-1, ogImageHeight-1))
+
+	again, err := renderProjectOGImage(project, "Felipe Macedo")
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, again), "rendering is deterministic")
+}
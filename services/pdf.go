@@ -0,0 +1,245 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+)
+
+// Fonts of pdfDocument: the standard Helvetica faces every PDF reader has,
+// so nothing needs embedding
+const (
+	pdfRegular = "F1"
+	pdfBold    = "F2"
+)
+
+// A4 in points
+const (
+	pdfPageWidth  = 595.28
+	pdfPageHeight = 841.89
+)
+
+// pdfColor is an RGB color with components from 0 to 1
+type pdfColor [3]float64
+
+// pdfDocument is a minimal PDF writer for text documents: pages of text and
+// rules in Helvetica, in the WinAnsi (Windows-1252) encoding, which covers
+// the accented letters of Western European languages
+type pdfDocument struct {
+	title string
+	pages []*bytes.Buffer
+}
+
+// addPage starts a new page and returns its content stream
+func (d *pdfDocument) addPage() *bytes.Buffer {
+	page := &bytes.Buffer{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+func (d *pdfDocument) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		return d.addPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+// text writes s with its baseline starting at x, y, measured from the bottom
+// left corner of the page
+func (d *pdfDocument) text(x, y float64, font string, size float64, color pdfColor, s string) {
+	fmt.Fprintf(d.page(), "BT %.3f %.3f %.3f rg /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n",
+		color[0], color[1], color[2], font, size, x, y, pdfEscape(pdfEncode(s)))
+}
+
+// rule draws a horizontal line from x1 to x2 at y
+func (d *pdfDocument) rule(x1, x2, y, width float64, color pdfColor) {
+	fmt.Fprintf(d.page(), "%.3f %.3f %.3f RG %.2f w %.2f %.2f m %.2f %.2f l S\n",
+		color[0], color[1], color[2], width, x1, y, x2, y)
+}
+
+// bytes assembles the document. Output depends on the content only, so the
+// same content always renders the same file.
+func (d *pdfDocument) bytes() ([]byte, error) {
+	if len(d.pages) == 0 {
+		d.addPage()
+	}
+
+	var objects [][]byte
+	add := func(object string) int {
+		objects = append(objects, []byte(object))
+		return len(objects)
+	}
+	addStream := func(data []byte) (int, error) {
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(data); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+		object := fmt.Sprintf("<< /Filter /FlateDecode /Length %d >>\nstream\n", compressed.Len())
+		objects = append(objects, append(append([]byte(object), compressed.Bytes()...), []byte("\nendstream")...))
+		return len(objects), nil
+	}
+
+	pagesID := add("") // filled in once the pages are numbered
+	catalog := add(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesID))
+	regular := add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	bold := add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	info := add(fmt.Sprintf("<< /Title (%s) /Producer (portfolio-backend) >>", pdfEscape(pdfEncode(d.title))))
+
+	kids := make([]string, 0, len(d.pages))
+	for _, page := range d.pages {
+		contents, err := addStream(page.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		id := add(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /%s %d 0 R /%s %d 0 R >> >> /Contents %d 0 R >>",
+			pagesID, pdfPageWidth, pdfPageHeight, pdfRegular, regular, pdfBold, bold, contents))
+		kids = append(kids, fmt.Sprintf("%d 0 R", id))
+	}
+	objects[pagesID-1] = []byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n", i+1)
+		out.Write(object)
+		out.WriteString("\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, catalog, info, xref)
+	return out.Bytes(), nil
+}
+
+// pdfWinAnsi maps the characters Windows-1252 places in 0x80-0x9F
+var pdfWinAnsi = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// pdfEncode converts s to WinAnsi; characters it lacks become "?"
+func pdfEncode(s string) []byte {
+	encoded := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			encoded = append(encoded, ' ')
+		case r >= 0x20 && r <= 0x7e, r >= 0xa0 && r <= 0xff:
+			encoded = append(encoded, byte(r))
+		default:
+			if b, ok := pdfWinAnsi[r]; ok {
+				encoded = append(encoded, b)
+			} else {
+				encoded = append(encoded, '?')
+			}
+		}
+	}
+	return encoded
+}
+
+// pdfEscape escapes the delimiters of a PDF literal string
+func pdfEscape(b []byte) string {
+	var escaped strings.Builder
+	for _, c := range b {
+		if c == '(' || c == ')' || c == '\\' {
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteByte(c)
+	}
+	return escaped.String()
+}
+
+// Widths of the printable ASCII characters, from space to tilde, in
+// thousandths of the font size, from the Helvetica font metrics
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// pdfAccented maps accented letters to the letter they are drawn on, which
+// has the same width
+var pdfAccented = map[byte]byte{}
+
+func init() {
+	for base, letters := range map[byte]string{
+		'A': "ÀÁÂÃÄÅ", 'C': "Ç", 'E': "ÈÉÊË", 'I': "ÌÍÎÏ", 'N': "Ñ", 'O': "ÒÓÔÕÖØ", 'U': "ÙÚÛÜ", 'Y': "Ý",
+		'a': "àáâãäå", 'c': "ç", 'e': "èéêë", 'i': "ìíîï", 'n': "ñ", 'o': "òóôõöø", 'u': "ùúûü", 'y': "ýÿ",
+	} {
+		for _, letter := range pdfEncode(letters) {
+			pdfAccented[letter] = base
+		}
+	}
+}
+
+// pdfTextWidth measures s set in font at size, in points
+func pdfTextWidth(s string, font string, size float64) float64 {
+	widths := &helveticaWidths
+	if font == pdfBold {
+		widths = &helveticaBoldWidths
+	}
+
+	total := 0
+	for _, c := range pdfEncode(s) {
+		if base, ok := pdfAccented[c]; ok {
+			c = base
+		}
+		switch {
+		case c >= 0x20 && c <= 0x7e:
+			total += widths[c-0x20]
+		case c == 0x95: // bullet
+			total += 350
+		case c == 0x97: // em dash
+			total += 1000
+		default:
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// pdfWrap breaks s into lines no wider than width, at spaces; words longer
+// than a line are left whole
+func pdfWrap(s string, font string, size, width float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line != "" && pdfTextWidth(candidate, font, size) > width {
+			lines = append(lines, line)
+			line = word
+			continue
+		}
+		line = candidate
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
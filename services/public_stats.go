@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"log"
+	"portfolio-backend/models"
+	"sort"
+	"time"
+)
+
+// publicTopLanguages is how many languages stats.json lists
+const publicTopLanguages = 10
+
+// GetPublicStats returns the stats of username in the schema of
+// github-readme-stats, for README card generators. Commit, pull request and
+// issue totals come from the contribution calendar and are left at zero
+// when contributions are disabled or unavailable.
+func (gs *GitHubService) GetPublicStats(ctx context.Context, username string) (*models.PublicStats, error) {
+	profile, err := gs.GetProfile(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := gs.GetStats(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := gs.GetRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	public := &models.PublicStats{
+		Name:       profile.Name,
+		Username:   profile.Login,
+		TotalStars: stats.TotalStars,
+		Followers:  profile.Followers,
+		TopLangs:   topLanguages(repos, publicTopLanguages),
+		UpdatedAt:  stats.LastFetched,
+	}
+	if public.Name == "" {
+		public.Name = profile.Login
+	}
+
+	if FeatureEnabled(FeatureContributions) {
+		contributions, err := gs.GetContributions(ctx, username)
+		if err != nil {
+			log.Printf("Contributions of %s unavailable: %v", username, err)
+		} else {
+			public.TotalCommits = contributions.Commits
+			public.TotalPRs = contributions.PullRequests
+			public.TotalIssues = contributions.Issues
+			public.TotalContributions = contributions.TotalContributions
+		}
+	}
+
+	if public.UpdatedAt.IsZero() {
+		public.UpdatedAt = time.Now()
+	}
+	return public, nil
+}
+
+// topLanguages sums the language bytes of public, non-fork repositories and
+// keeps the n largest. Repositories whose breakdown was never fetched count
+// their primary language only when no breakdown is known at all.
+func topLanguages(repos []models.GitHubRepository, n int) map[string]models.PublicLanguage {
+	sizes := make(map[string]int)
+	for _, repo := range repos {
+		if repo.Fork || repo.Private {
+			continue
+		}
+		for language, size := range repo.Languages {
+			sizes[language] += size
+		}
+	}
+	if len(sizes) == 0 {
+		for _, repo := range repos {
+			if !repo.Fork && !repo.Private && repo.Language != "" {
+				sizes[repo.Language]++
+			}
+		}
+	}
+
+	names := make([]string, 0, len(sizes))
+	for name := range sizes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if sizes[names[i]] != sizes[names[j]] {
+			return sizes[names[i]] > sizes[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > n {
+		names = names[:n]
+	}
+
+	languages := make(map[string]models.PublicLanguage, len(names))
+	for _, name := range names {
+		languages[name] = models.PublicLanguage{Name: name, Size: sizes[name]}
+	}
+	return languages
+}
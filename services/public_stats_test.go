@@ -0,0 +1,38 @@
+package services
+
+import (
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopLanguages(t *testing.T) {
+	repos := []models.GitHubRepository{
+		{Name: "api", Language: "Go", Languages: map[string]int{"Go": 900, "Shell": 100}},
+		{Name: "site", Language: "TypeScript", Languages: map[string]int{"TypeScript": 500, "CSS": 100}},
+		{Name: "fork", Fork: true, Languages: map[string]int{"Rust": 10000}},
+		{Name: "secret", Private: true, Languages: map[string]int{"Java": 10000}},
+	}
+
+	languages := topLanguages(repos, 3)
+	assert.Equal(t, map[string]models.PublicLanguage{
+		"Go":         {Name: "Go", Size: 900},
+		"TypeScript": {Name: "TypeScript", Size: 500},
+		"CSS":        {Name: "CSS", Size: 100},
+	}, languages, "ties are broken by name")
+}
+
+func TestTopLanguagesWithoutBreakdown(t *testing.T) {
+	repos := []models.GitHubRepository{
+		{Name: "api", Language: "Go"},
+		{Name: "cli", Language: "Go"},
+		{Name: "site", Language: "TypeScript"},
+		{Name: "notes"},
+	}
+
+	languages := topLanguages(repos, 10)
+	assert.Equal(t, 2, languages["Go"].Size)
+	assert.Equal(t, 1, languages["TypeScript"].Size)
+	assert.Len(t, languages, 2)
+}
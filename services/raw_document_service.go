@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// rawAuditCollection keeps the before/after of every raw document edit
+const rawAuditCollection = "raw_document_audit"
+
+var (
+	ErrUnknownCollection  = errors.New("unknown collection")
+	ErrReadOnlyCollection = errors.New("collection is read-only")
+	ErrInvalidDocument    = errors.New("invalid document")
+	ErrVersionConflict    = errors.New("document was modified since it was read")
+)
+
+// rawCollection describes how the raw document API may touch a collection
+type rawCollection struct {
+	readOnly  bool
+	versioned bool                   // edits must send the current version, which is then bumped
+	validate  func(doc bson.M) error // schema check before writing
+}
+
+// rawCollections lists the collections exposed by the raw document API.
+// Settings are left out on purpose: they hold credentials and have their
+// own endpoints.
+var rawCollections = map[string]rawCollection{
+	"content":          {versioned: true, validate: validateContentDocument},
+	"github_data":      {validate: validateGitHubDocument},
+	"cache":            {readOnly: true},
+	"cache_chunks":     {readOnly: true},
+	"deploy_hook_runs": {readOnly: true},
+	"digest_snapshots": {readOnly: true},
+	rawAuditCollection: {readOnly: true},
+	auditCollection:    {readOnly: true},
+}
+
+// contentModels maps content types to the models their data must decode into
+var contentModels = map[string]func() interface{}{
+	"meta":         func() interface{} { return &models.Meta{} },
+	"skills":       func() interface{} { return &models.Skills{} },
+	"experience":   func() interface{} { return &[]models.Experience{} },
+	"projects":     func() interface{} { return &[]models.Project{} },
+	"education":    func() interface{} { return &[]models.Education{} },
+	"availability": func() interface{} { return &models.Availability{} },
+	"changelog":    func() interface{} { return &[]models.ChangelogEntry{} },
+}
+
+type RawDocumentService struct {
+	cacheService *CacheService
+	deployHooks  *DeployHookService
+	cdnPurge     *CDNPurgeService
+	audit        *mongo.Collection
+	versions     *mongo.Collection
+}
+
+func NewRawDocumentService() *RawDocumentService {
+	return &RawDocumentService{
+		cacheService: NewCacheService(),
+		deployHooks:  NewDeployHookService(),
+		cdnPurge:     NewCDNPurgeService(),
+		audit:        database.Database.Collection(rawAuditCollection),
+		versions:     database.Database.Collection(contentVersionsCollection),
+	}
+}
+
+// Get returns a document as relaxed extended JSON, so ObjectIDs and dates
+// survive a round trip through PUT
+func (rs *RawDocumentService) Get(ctx context.Context, collection, id string) ([]byte, error) {
+	if _, ok := rawCollections[collection]; !ok {
+		return nil, ErrUnknownCollection
+	}
+
+	var doc bson.D
+	err := database.Database.Collection(collection).FindOne(ctx, bson.M{"_id": parseRawID(id)}).Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.MarshalExtJSON(doc, false, false)
+}
+
+// Replace validates and stores a document given as extended JSON, recording
+// the previous version in the audit collection
+func (rs *RawDocumentService) Replace(ctx context.Context, collection, id string, body []byte, editedBy string) ([]byte, error) {
+	policy, ok := rawCollections[collection]
+	if !ok {
+		return nil, ErrUnknownCollection
+	}
+	if policy.readOnly {
+		return nil, ErrReadOnlyCollection
+	}
+
+	var doc bson.M
+	if err := bson.UnmarshalExtJSON(body, false, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+
+	docID := parseRawID(id)
+	if bodyID, ok := doc["_id"]; ok && bodyID != docID {
+		return nil, fmt.Errorf("%w: _id cannot be changed", ErrInvalidDocument)
+	}
+	doc["_id"] = docID
+
+	coll := database.Database.Collection(collection)
+	var before bson.M
+	if err := coll.FindOne(ctx, bson.M{"_id": docID}).Decode(&before); err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": docID}
+	if policy.versioned {
+		current, _ := toInt64(before["version"])
+		sent, ok := toInt64(doc["version"])
+		if !ok {
+			return nil, fmt.Errorf("%w: version is required", ErrInvalidDocument)
+		}
+		if sent != current {
+			return nil, ErrVersionConflict
+		}
+
+		// Only replace the version that was read, in case of a concurrent edit
+		filter["version"] = before["version"]
+		doc["version"] = current + 1
+		doc["updated_at"] = time.Now()
+		doc["updated_by"] = editedBy
+	}
+
+	if collection == "content" && doc["type"] != before["type"] {
+		return nil, fmt.Errorf("%w: content type cannot be changed", ErrInvalidDocument)
+	}
+	if policy.validate != nil {
+		if err := policy.validate(doc); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+		}
+	}
+
+	if IsDryRun(ctx) {
+		rs.planReplace(ctx, collection, id, before, doc, policy.versioned)
+		return bson.MarshalExtJSON(doc, false, false)
+	}
+
+	result, err := coll.ReplaceOne(ctx, filter, doc)
+	if err != nil {
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, ErrVersionConflict
+	}
+
+	rs.audit.InsertOne(ctx, models.RawDocumentAudit{
+		ID:         primitive.NewObjectID(),
+		Collection: collection,
+		DocumentID: id,
+		Before:     before,
+		After:      doc,
+		EditedBy:   editedBy,
+		EditedAt:   time.Now(),
+	})
+	recordAudit(ctx, collection+"/"+id, before, doc)
+	if collection == "content" {
+		rs.archiveContent(ctx, before)
+		rs.archiveContent(ctx, doc)
+	}
+	rs.afterReplace(ctx, collection, doc)
+
+	return bson.MarshalExtJSON(doc, false, false)
+}
+
+// planReplace records the dry run of Replace
+func (rs *RawDocumentService) planReplace(ctx context.Context, collection, id string, before, doc bson.M, versioned bool) {
+	change := models.DryRunChange{
+		Kind:   models.DryRunDocument,
+		Target: collection + "/" + id,
+		Diff:   diffDocuments(before, doc),
+	}
+	if versioned {
+		change.VersionFrom, _ = toInt64(before["version"])
+		change.VersionTo, _ = toInt64(doc["version"])
+	}
+
+	switch collection {
+	case "content":
+		contentType, _ := doc["type"].(string)
+		change.Invalidations, change.SideEffects = contentChangeEffects(ctx, contentType)
+	case "github_data":
+		for _, key := range []string{"owner", "login"} {
+			if username, ok := doc[key].(string); ok {
+				change.Invalidations = append(change.Invalidations, fmt.Sprintf("github:%s:.*", username))
+			}
+		}
+	}
+	change.SideEffects = append(change.SideEffects, "audit entry in "+rawAuditCollection)
+
+	recordDryRun(ctx, change)
+}
+
+// afterReplace applies the side effects the regular write paths have
+func (rs *RawDocumentService) afterReplace(ctx context.Context, collection string, doc bson.M) {
+	switch collection {
+	case "content":
+		rs.cacheService.InvalidateContentCache(ctx)
+		if contentType, ok := doc["type"].(string); ok {
+			rs.deployHooks.ScheduleTrigger(contentType)
+			rs.cdnPurge.SchedulePurge(contentType)
+			var version int
+			switch v := doc["version"].(type) {
+			case int32:
+				version = int(v)
+			case int64:
+				version = int(v)
+			}
+			publishContentUpdated(contentType, version)
+		}
+	case "github_data":
+		for _, key := range []string{"owner", "login"} {
+			if username, ok := doc[key].(string); ok {
+				rs.cacheService.InvalidateGitHubCache(ctx, username)
+			}
+		}
+	}
+}
+
+// archiveContent keeps a content document in the version archive, as
+// UpdateContent does
+func (rs *RawDocumentService) archiveContent(ctx context.Context, doc bson.M) {
+	contentType, _ := doc["type"].(string)
+	version, _ := toInt64(doc["version"])
+
+	archived := bson.M{}
+	for key, value := range doc {
+		if key != "_id" {
+			archived[key] = value
+		}
+	}
+	archiveContentVersion(ctx, rs.versions, contentType, int(version), archived)
+}
+
+// parseRawID accepts ObjectID hex strings and falls back to plain string ids
+func parseRawID(id string) interface{} {
+	if objectID, err := primitive.ObjectIDFromHex(id); err == nil {
+		return objectID
+	}
+	return id
+}
+
+func validateContentDocument(doc bson.M) error {
+	if err := decodeStrict(doc, &models.Content{}); err != nil {
+		return err
+	}
+
+	contentType, _ := doc["type"].(string)
+	newModel, ok := contentModels[contentType]
+	if !ok {
+		return fmt.Errorf("unknown content type %q", contentType)
+	}
+
+	data := newModel()
+	if err := decodeValue(doc["data"], data); err != nil {
+		return fmt.Errorf("data: %v", err)
+	}
+	return checkKnownFields(doc["data"], reflect.TypeOf(data), "data")
+}
+
+// validateGitHubDocument checks repositories and profiles, which share the
+// github_data collection
+func validateGitHubDocument(doc bson.M) error {
+	switch {
+	case doc["github_id"] != nil:
+		return decodeStrict(doc, &models.GitHubRepository{})
+	case doc["login"] != nil:
+		return decodeStrict(doc, &models.GitHubProfile{})
+	default:
+		return errors.New("document is neither a repository nor a profile")
+	}
+}
+
+// decodeStrict decodes doc into target, rejecting type mismatches and
+// fields the model does not declare
+func decodeStrict(doc bson.M, target interface{}) error {
+	if err := decodeValue(doc, target); err != nil {
+		return err
+	}
+	return checkKnownFields(doc, reflect.TypeOf(target), "")
+}
+
+func decodeValue(value, target interface{}) error {
+	valueType, data, err := bson.MarshalValue(value)
+	if err != nil {
+		return err
+	}
+	return bson.RawValue{Type: valueType, Value: data}.Unmarshal(target)
+}
+
+// checkKnownFields walks nested documents and arrays of v alongside the Go
+// type t, so a typo deep inside content data is reported rather than dropped
+func checkKnownFields(v interface{}, t reflect.Type, path string) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch value := v.(type) {
+	case bson.M:
+		if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+			return nil
+		}
+		fields := bsonFields(t)
+		for key, child := range value {
+			fieldType, ok := fields[key]
+			if !ok {
+				return fmt.Errorf("unknown field %q", joinPath(path, key))
+			}
+			if err := checkKnownFields(child, fieldType, joinPath(path, key)); err != nil {
+				return err
+			}
+		}
+	case bson.A:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return nil
+		}
+		for i, child := range value {
+			if err := checkKnownFields(child, t.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bsonFields maps the bson keys of a struct to their field types
+func bsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("bson")
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(options, "inline") {
+			for key, fieldType := range bsonFields(field.Type) {
+				fields[key] = fieldType
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), n == float64(int64(n))
+	}
+	return 0, false
+}
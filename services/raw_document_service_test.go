@@ -0,0 +1,47 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func parseExtJSON(t *testing.T, doc string) bson.M {
+	t.Helper()
+
+	var parsed bson.M
+	require.NoError(t, bson.UnmarshalExtJSON([]byte(doc), false, &parsed))
+	return parsed
+}
+
+func TestValidateContentDocument(t *testing.T) {
+	valid := `{"type": "skills", "version": 2, "updated_by": "admin",
+		"updated_at": {"$date": "2024-01-01T00:00:00Z"},
+		"data": {"backend": [{"name": "Go", "level": 95, "category": "backend"}]}}`
+	assert.NoError(t, validateContentDocument(parseExtJSON(t, valid)))
+
+	tests := map[string]string{
+		"unknown type":        `{"type": "posts", "data": {}}`,
+		"unknown top field":   `{"type": "meta", "data": {}, "draft": true}`,
+		"unknown data field":  `{"type": "skills", "data": {"backend": [{"name": "Go", "levle": 95}]}}`,
+		"wrong data type":     `{"type": "skills", "data": {"backend": [{"name": "Go", "level": "expert"}]}}`,
+		"object for an array": `{"type": "projects", "data": {"name": "not a list"}}`,
+	}
+	for name, doc := range tests {
+		assert.Error(t, validateContentDocument(parseExtJSON(t, doc)), name)
+	}
+}
+
+func TestValidateGitHubDocument(t *testing.T) {
+	repo := `{"github_id": 1296269, "name": "Hello-World", "owner": "octocat",
+		"languages": {"Go": 100}, "enrichment": {"status": "complete"}}`
+	assert.NoError(t, validateGitHubDocument(parseExtJSON(t, repo)))
+
+	profile := `{"login": "octocat", "followers": 10}`
+	assert.NoError(t, validateGitHubDocument(parseExtJSON(t, profile)))
+
+	assert.Error(t, validateGitHubDocument(parseExtJSON(t, `{"github_id": 1, "stars": 5}`)))
+	assert.Error(t, validateGitHubDocument(parseExtJSON(t, `{"name": "orphan"}`)))
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// SettingProfileReadmeTemplate overrides defaultProfileReadmeTemplate
+const SettingProfileReadmeTemplate = "profile_readme_template"
+
+const defaultProfileReadmeTemplate = `# Hi, I'm {{or .Profile.Name .Profile.Login}} 👋
+{{with .Profile.Bio}}
+{{.}}
+{{end}}
+## 📊 GitHub Stats
+
+- ⭐ Total stars: **{{.Stats.TotalStars}}**
+- 📦 Public repositories: **{{.Stats.TotalRepos}}**
+- 🍴 Total forks: **{{.Stats.TotalForks}}**
+- 👥 Followers: **{{.Profile.Followers}}**
+{{if .TopLanguages}}
+## 🛠 Top Languages
+
+{{range .TopLanguages}}- {{.Name}} ({{printf "%.1f" .Percentage}}%)
+{{end}}{{end}}{{if .TopRepositories}}
+## 🚀 Top Projects
+
+{{range .TopRepositories}}- [{{.Name}}]({{.HTMLURL}}) ⭐ {{.Stars}}{{with .Description}} — {{.}}{{end}}
+{{end}}{{end}}{{if .FeaturedProjects}}
+## ✨ Featured
+
+{{range .FeaturedProjects}}- **{{.Name}}**{{with .Description}}: {{.}}{{end}}{{with .LiveURL}} ([live]({{.}})){{end}}
+{{end}}{{end}}
+<sub>Last updated {{.GeneratedAt.Format "2006-01-02"}}</sub>
+`
+
+type ReadmeService struct {
+	client          *http.Client
+	githubService   *GitHubService
+	contentService  *ContentService
+	settingsService *SettingsService
+}
+
+func NewReadmeService() *ReadmeService {
+	return &ReadmeService{
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		githubService:   NewGitHubService(),
+		contentService:  NewContentService(),
+		settingsService: NewSettingsService(),
+	}
+}
+
+// readmeTemplateData is the data available to profile README templates
+type readmeTemplateData struct {
+	Profile          models.GitHubProfile
+	Stats            models.GitHubStats
+	TopLanguages     []models.LanguageStat
+	TopRepositories  []models.RepoStat
+	FeaturedProjects []models.Project
+	GeneratedAt      time.Time
+}
+
+// Render builds the profile README markdown for the configured owner
+func (rs *ReadmeService) Render(ctx context.Context) (string, error) {
+	username := rs.settingsService.GetOwner(ctx)
+
+	profile, err := rs.githubService.GetProfile(ctx, username)
+	if err != nil {
+		return "", err
+	}
+
+	stats, err := rs.githubService.GetStats(ctx, username)
+	if err != nil {
+		return "", err
+	}
+
+	// Featured projects are left out when the content cannot be read
+	projects, _ := rs.contentService.GetProjects(ctx)
+
+	templateText := defaultProfileReadmeTemplate
+	var custom string
+	if err := rs.settingsService.Get(ctx, SettingProfileReadmeTemplate, &custom); err == nil && custom != "" {
+		templateText = custom
+	}
+
+	return renderProfileReadme(templateText, newReadmeTemplateData(*profile, *stats, projects, time.Now()))
+}
+
+// newReadmeTemplateData picks the five most used languages, the five most
+// starred repositories and the featured projects
+func newReadmeTemplateData(profile models.GitHubProfile, stats models.GitHubStats, projects []models.Project, generatedAt time.Time) readmeTemplateData {
+	data := readmeTemplateData{
+		Profile:     profile,
+		Stats:       stats,
+		GeneratedAt: generatedAt,
+	}
+
+	data.TopLanguages = append(data.TopLanguages, stats.MostUsedLanguages...)
+	sort.Slice(data.TopLanguages, func(i, j int) bool {
+		return data.TopLanguages[i].Percentage > data.TopLanguages[j].Percentage
+	})
+	if len(data.TopLanguages) > 5 {
+		data.TopLanguages = data.TopLanguages[:5]
+	}
+
+	data.TopRepositories = append(data.TopRepositories, stats.TopRepositories...)
+	sort.Slice(data.TopRepositories, func(i, j int) bool {
+		return data.TopRepositories[i].Stars > data.TopRepositories[j].Stars
+	})
+	if len(data.TopRepositories) > 5 {
+		data.TopRepositories = data.TopRepositories[:5]
+	}
+
+	for _, project := range projects {
+		if project.Featured {
+			data.FeaturedProjects = append(data.FeaturedProjects, project)
+		}
+	}
+	return data
+}
+
+// renderProfileReadme executes templateText, a text/template, with data
+func renderProfileReadme(templateText string, data readmeTemplateData) (string, error) {
+	tmpl, err := template.New("profile-readme").Parse(templateText)
+	if err != nil {
+		return "", fmt.Errorf("invalid profile README template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// Publish renders the README and commits it to the <username>/<username>
+// repository, skipping the commit when nothing changed
+func (rs *ReadmeService) Publish(ctx context.Context) (*models.ReadmePublishResult, error) {
+	if config.AppConfig.GitHubToken == "" {
+		return nil, fmt.Errorf("publishing the profile README requires GITHUB_TOKEN")
+	}
+
+	username := rs.settingsService.GetOwner(ctx)
+	rendered, err := rs.Render(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ReadmePublishResult{
+		Repository:  fmt.Sprintf("%s/%s", username, username),
+		Path:        "README.md",
+		PublishedAt: time.Now(),
+	}
+
+	url := githubAPI("/repos/%s/%s/contents/README.md", username, username)
+
+	// Fetch the current file to obtain its sha and skip no-op commits
+	var current struct {
+		SHA     string `json:"sha"`
+		Content string `json:"content"`
+	}
+	status, err := rs.doJSON(ctx, "GET", url, nil, &current)
+	if err != nil && status != http.StatusNotFound {
+		return nil, err
+	}
+
+	if current.Content != "" {
+		existing, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(current.Content, "\n", ""))
+		if err == nil && string(existing) == rendered {
+			return result, nil
+		}
+	}
+
+	body := map[string]interface{}{
+		"message": "Update profile README",
+		"content": base64.StdEncoding.EncodeToString([]byte(rendered)),
+	}
+	if current.SHA != "" {
+		body["sha"] = current.SHA
+	}
+
+	var updated struct {
+		Commit struct {
+			SHA     string `json:"sha"`
+			HTMLURL string `json:"html_url"`
+		} `json:"commit"`
+	}
+	if _, err := rs.doJSON(ctx, "PUT", url, body, &updated); err != nil {
+		return nil, err
+	}
+
+	result.Changed = true
+	result.CommitSHA = updated.Commit.SHA
+	result.CommitURL = updated.Commit.HTMLURL
+
+	return result, nil
+}
+
+// StartScheduler republishes the profile README every PROFILE_README_INTERVAL
+func (rs *ReadmeService) StartScheduler() {
+	interval := config.AppConfig.ProfileReadmeInterval
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			result, err := rs.Publish(ctx)
+			if err != nil {
+				log.Printf("Profile README publish error: %v", err)
+			} else if result.Changed {
+				log.Printf("Profile README updated in %s (%s)", result.Repository, result.CommitSHA)
+			}
+			cancel()
+		}
+	}()
+}
+
+// doJSON performs an authenticated GitHub API call, returning the status code
+func (rs *ReadmeService) doJSON(ctx context.Context, method, url string, body interface{}, target interface{}) (int, error) {
+	return doGitHubJSON(ctx, rs.client, method, url, body, target)
+}
+
+// doGitHubJSON performs a GitHub API call authenticated with GITHUB_TOKEN,
+// decoding the response into target and returning the status code
+func doGitHubJSON(ctx context.Context, client *http.Client, method, url string, body interface{}, target interface{}) (int, error) {
+	var reader *bytes.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(payload)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Authorization", "token "+config.AppConfig.GitHubToken)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("GitHub API error: %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, json.NewDecoder(resp.Body).Decode(target)
+}
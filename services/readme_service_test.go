@@ -0,0 +1,78 @@
+package services
+
+import (
+	"fmt"
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReadmeTemplateData(t *testing.T) {
+	stats := models.GitHubStats{}
+	for i := 1; i <= 7; i++ {
+		stats.MostUsedLanguages = append(stats.MostUsedLanguages, models.LanguageStat{Name: fmt.Sprintf("lang%d", i), Percentage: float64(i)})
+		stats.TopRepositories = append(stats.TopRepositories, models.RepoStat{Name: fmt.Sprintf("repo%d", i), Stars: i})
+	}
+	projects := []models.Project{{Name: "api", Featured: true}, {Name: "scratch"}}
+
+	data := newReadmeTemplateData(models.GitHubProfile{Login: "octocat"}, stats, projects, time.Now())
+	require.Len(t, data.TopLanguages, 5)
+	assert.Equal(t, "lang7", data.TopLanguages[0].Name)
+	assert.Equal(t, "lang3", data.TopLanguages[4].Name)
+	require.Len(t, data.TopRepositories, 5)
+	assert.Equal(t, "repo7", data.TopRepositories[0].Name)
+	require.Len(t, data.FeaturedProjects, 1)
+	assert.Equal(t, "api", data.FeaturedProjects[0].Name)
+
+	// The stats are sorted on a copy
+	assert.Equal(t, "lang1", stats.MostUsedLanguages[0].Name)
+}
+
+func TestRenderProfileReadme(t *testing.T) {
+	data := readmeTemplateData{
+		Profile:          models.GitHubProfile{Login: "octocat", Bio: "Builds things", Followers: 12},
+		Stats:            models.GitHubStats{TotalStars: 40, TotalRepos: 8, TotalForks: 3},
+		TopLanguages:     []models.LanguageStat{{Name: "Go", Percentage: 62.345}},
+		TopRepositories:  []models.RepoStat{{Name: "api", HTMLURL: "https://github.com/octocat/api", Stars: 30, Description: "REST API"}},
+		FeaturedProjects: []models.Project{{Name: "Portfolio", Description: "This site", LiveURL: "https://octocat.dev"}},
+		GeneratedAt:      time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC),
+	}
+
+	readme, err := renderProfileReadme(defaultProfileReadmeTemplate, data)
+	require.NoError(t, err)
+	assert.Contains(t, readme, "# Hi, I'm octocat 👋\n\nBuilds things\n")
+	assert.Contains(t, readme, "- ⭐ Total stars: **40**\n")
+	assert.Contains(t, readme, "- 👥 Followers: **12**\n")
+	assert.Contains(t, readme, "- Go (62.3%)\n")
+	assert.Contains(t, readme, "- [api](https://github.com/octocat/api) ⭐ 30 — REST API\n")
+	assert.Contains(t, readme, "- **Portfolio**: This site ([live](https://octocat.dev))\n")
+	assert.Contains(t, readme, "<sub>Last updated 2024-03-09</sub>")
+
+	// Empty sections are left out, and the name wins over the login
+	data.Profile.Name, data.Profile.Bio = "The Octocat", ""
+	data.TopLanguages, data.TopRepositories, data.FeaturedProjects = nil, nil, nil
+	readme, err = renderProfileReadme(defaultProfileReadmeTemplate, data)
+	require.NoError(t, err)
+	assert.Contains(t, readme, "# Hi, I'm The Octocat 👋\n\n## 📊 GitHub Stats")
+	assert.NotContains(t, readme, "Top Languages")
+	assert.NotContains(t, readme, "Top Projects")
+	assert.NotContains(t, readme, "Featured")
+}
+
+func TestRenderProfileReadmeCustomTemplate(t *testing.T) {
+	data := readmeTemplateData{Profile: models.GitHubProfile{Login: "octocat"}, Stats: models.GitHubStats{TotalStars: 5}}
+
+	readme, err := renderProfileReadme("{{.Profile.Login}} has {{.Stats.TotalStars}} stars", data)
+	require.NoError(t, err)
+	assert.Equal(t, "octocat has 5 stars", readme)
+
+	_, err = renderProfileReadme("{{.Profile.Login", data)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid profile README template")
+
+	_, err = renderProfileReadme("{{.Unknown}}", data)
+	assert.Error(t, err)
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// recruiterTokenPrefix makes recruiter tokens recognizable in logs and links
+const recruiterTokenPrefix = "rt_"
+
+var (
+	// ErrInvalidRecruiterToken is returned for unknown, expired and revoked tokens
+	ErrInvalidRecruiterToken = errors.New("invalid recruiter token")
+	// ErrRecruiterTokenNotFound is returned when revoking an unknown token
+	ErrRecruiterTokenNotFound = errors.New("recruiter token not found")
+	// ErrInvalidTokenRequest is returned when a token cannot be issued as asked
+	ErrInvalidTokenRequest = errors.New("invalid recruiter token request")
+)
+
+type RecruiterService struct {
+	tokens          *mongo.Collection
+	settingsService *SettingsService
+}
+
+func NewRecruiterService() *RecruiterService {
+	return &RecruiterService{
+		tokens:          database.Database.Collection("recruiter_tokens"),
+		settingsService: NewSettingsService(),
+	}
+}
+
+// GetPrivateDetails returns the details shared with recruiters
+func (rs *RecruiterService) GetPrivateDetails(ctx context.Context) (*models.PrivateDetails, error) {
+	details := models.PrivateDetails{ContractTypes: []string{}}
+	err := rs.settingsService.Get(ctx, SettingPrivate, &details)
+	if err != nil && err != ErrSettingNotFound {
+		return nil, err
+	}
+	return &details, nil
+}
+
+// SetPrivateDetails stores the details shared with recruiters
+func (rs *RecruiterService) SetPrivateDetails(ctx context.Context, details models.PrivateDetails, updatedBy string) error {
+	if details.ContractTypes == nil {
+		details.ContractTypes = []string{}
+	}
+	return rs.settingsService.Set(ctx, SettingPrivate, details, updatedBy)
+}
+
+// CreateToken issues a token for a recruiter. The plain token is only set on
+// the returned value; afterwards it cannot be recovered.
+func (rs *RecruiterService) CreateToken(ctx context.Context, request models.RecruiterTokenRequest, createdBy string) (*models.RecruiterToken, error) {
+	label := strings.TrimSpace(request.Label)
+	if label == "" {
+		return nil, fmt.Errorf("%w: label is required", ErrInvalidTokenRequest)
+	}
+
+	ttl, err := parseExpiresIn(request.ExpiresIn, config.AppConfig.RecruiterTokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTokenRequest, err)
+	}
+
+	plain, err := newRecruiterToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	token := models.RecruiterToken{
+		ID:        primitive.NewObjectID(),
+		Label:     label,
+		TokenHash: hashRecruiterToken(plain),
+		CreatedAt: now,
+		CreatedBy: createdBy,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	if _, err := rs.tokens.InsertOne(ctx, token); err != nil {
+		return nil, err
+	}
+
+	token.Token = plain
+	return &token, nil
+}
+
+// ListTokens returns every issued token, newest first, with its usage
+func (rs *RecruiterService) ListTokens(ctx context.Context) ([]models.RecruiterToken, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := rs.tokens.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	tokens := []models.RecruiterToken{}
+	err = cursor.All(ctx, &tokens)
+	return tokens, err
+}
+
+// RevokeToken stops a token from granting access before it expires
+func (rs *RecruiterService) RevokeToken(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrRecruiterTokenNotFound
+	}
+
+	result, err := rs.tokens.UpdateOne(ctx,
+		bson.M{"_id": objectID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		// Revoking twice is not an error
+		count, err := rs.tokens.CountDocuments(ctx, bson.M{"_id": objectID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrRecruiterTokenNotFound
+		}
+	}
+	return nil
+}
+
+// Authenticate checks a presented token and records its use
+func (rs *RecruiterService) Authenticate(ctx context.Context, plain, clientIP string) (*models.RecruiterToken, error) {
+	now := time.Now()
+	filter := bson.M{
+		"token_hash": hashRecruiterToken(plain),
+		"revoked_at": nil,
+		"expires_at": bson.M{"$gt": now},
+	}
+	update := bson.M{
+		"$inc": bson.M{"use_count": 1},
+		"$set": bson.M{"last_used_at": now, "last_used_ip": clientIP},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var token models.RecruiterToken
+	err := rs.tokens.FindOneAndUpdate(ctx, filter, update, opts).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrInvalidRecruiterToken
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// parseExpiresIn parses the expires_in of a grant, which defaults to fallback
+func parseExpiresIn(value string, fallback time.Duration) (time.Duration, error) {
+	ttl := fallback
+	if value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("expires_in: %v", err)
+		}
+		ttl = parsed
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("expires_in must be positive")
+	}
+	return ttl, nil
+}
+
+// newRecruiterToken returns a random token with 256 bits of entropy
+func newRecruiterToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return recruiterTokenPrefix + hex.EncodeToString(buf), nil
+}
+
+func hashRecruiterToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
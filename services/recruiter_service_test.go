@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecruiterToken(t *testing.T) {
+	first, err := newRecruiterToken()
+	require.NoError(t, err)
+	second, err := newRecruiterToken()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(first, recruiterTokenPrefix))
+	assert.Len(t, first, len(recruiterTokenPrefix)+64)
+	assert.NotEqual(t, first, second)
+
+	// Only the hash is stored, so it must be stable and not reveal the token
+	assert.Equal(t, hashRecruiterToken(first), hashRecruiterToken(first))
+	assert.NotEqual(t, hashRecruiterToken(first), hashRecruiterToken(second))
+	assert.NotContains(t, hashRecruiterToken(first), first[len(recruiterTokenPrefix):])
+}
+
+func TestCreateTokenRejectsInvalidRequests(t *testing.T) {
+	config.AppConfig = &config.Config{RecruiterTokenTTL: 168 * time.Hour}
+	rs := &RecruiterService{}
+
+	invalid := []models.RecruiterTokenRequest{
+		{},
+		{Label: "   "},
+		{Label: "Acme", ExpiresIn: "a week"},
+		{Label: "Acme", ExpiresIn: "-1h"},
+	}
+	for _, request := range invalid {
+		_, err := rs.CreateToken(context.Background(), request, "admin")
+		assert.ErrorIs(t, err, ErrInvalidTokenRequest)
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// repoNotificationContext names the commit status on the repositories
+const repoNotificationContext = "portfolio"
+
+// RepoNotificationService tells the repositories linked to projects that
+// their showcase page changed, through a commit status or comment on the
+// head of the default branch, for projects that opt in
+type RepoNotificationService struct {
+	client *http.Client
+}
+
+func NewRepoNotificationService() *RepoNotificationService {
+	return &RepoNotificationService{
+		client: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+	}
+}
+
+// NotifyChanges notifies, in the background, the repositories of the
+// projects in data whose page differs from before. It needs GITHUB_TOKEN.
+func (rs *RepoNotificationService) NotifyChanges(before []models.Project, data interface{}) {
+	if config.AppConfig.GitHubToken == "" {
+		return
+	}
+
+	var after []models.Project
+	if err := remarshal(data, &after); err != nil {
+		return
+	}
+
+	changed := changedShowcases(before, after)
+	if len(changed) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		for _, project := range changed {
+			if err := rs.Notify(ctx, project); err != nil {
+				log.Printf("Failed to notify %s of the portfolio update: %v", project.GitHubURL, err)
+			}
+		}
+	}()
+}
+
+// changedShowcases returns the projects of after that opted in to repository
+// notifications and are new or whose page content changed. Projects are
+// matched by slug, as they are stored without IDs.
+func changedShowcases(before, after []models.Project) []models.Project {
+	previous := make(map[string]models.Project, len(before))
+	for _, project := range before {
+		previous[utils.SlugifyString(project.Name)] = project
+	}
+
+	changed := []models.Project{}
+	for _, project := range after {
+		if project.RepoNotification != models.RepoNotifyStatus && project.RepoNotification != models.RepoNotifyComment {
+			continue
+		}
+		old, ok := previous[utils.SlugifyString(project.Name)]
+		if ok && !showcaseChanged(old, project) {
+			continue
+		}
+		changed = append(changed, project)
+	}
+	return changed
+}
+
+// showcaseChanged reports whether the case study or links shown on the
+// project's page differ; stats synced from GitHub are left out
+func showcaseChanged(a, b models.Project) bool {
+	return a.Description != b.Description ||
+		a.LongDesc != b.LongDesc ||
+		a.LiveURL != b.LiveURL ||
+		a.DemoURL != b.DemoURL ||
+		!reflect.DeepEqual(a.Highlights, b.Highlights) ||
+		!reflect.DeepEqual(a.Challenges, b.Challenges) ||
+		!reflect.DeepEqual(a.Images, b.Images) ||
+		!reflect.DeepEqual(a.Technologies, b.Technologies)
+}
+
+// parseGitHubRepo extracts owner and name from a repository URL such as
+// https://github.com/owner/name, or on the GitHub Enterprise Server instance
+// the API is served from
+func parseGitHubRepo(repoURL string) (string, string, bool) {
+	web, _ := url.Parse(githubWebURL())
+	parsed, err := url.Parse(repoURL)
+	if err != nil || !strings.EqualFold(parsed.Host, web.Host) {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), true
+}
+
+// showcaseURL is the project's page on the portfolio, or empty without
+// PORTFOLIO_URL
+func showcaseURL(project models.Project) string {
+	if config.AppConfig.PortfolioURL == "" {
+		return ""
+	}
+	return projectPageURL(config.AppConfig.PortfolioURL, project)
+}
+
+// Notify sets the commit status or adds the commit comment chosen by the
+// project on the head of its repository's default branch
+func (rs *RepoNotificationService) Notify(ctx context.Context, project models.Project) error {
+	owner, repo, ok := parseGitHubRepo(project.GitHubURL)
+	if !ok {
+		return fmt.Errorf("project %s does not link a GitHub repository", project.Name)
+	}
+	api := githubAPI("/repos/%s/%s", owner, repo)
+
+	var head struct {
+		SHA string `json:"sha"`
+	}
+	if _, err := doGitHubJSON(ctx, rs.client, "GET", api+"/commits/HEAD", nil, &head); err != nil {
+		return err
+	}
+
+	target := showcaseURL(project)
+	var created struct{}
+
+	if project.RepoNotification == models.RepoNotifyComment {
+		body := "📌 The portfolio page of this project was updated."
+		if target != "" {
+			body = fmt.Sprintf("📌 The [portfolio page](%s) of this project was updated.", target)
+		}
+		_, err := doGitHubJSON(ctx, rs.client, "POST", fmt.Sprintf("%s/commits/%s/comments", api, head.SHA), map[string]interface{}{"body": body}, &created)
+		return err
+	}
+
+	status := map[string]interface{}{
+		"state":       "success",
+		"context":     repoNotificationContext,
+		"description": "Portfolio updated " + time.Now().UTC().Format("2006-01-02"),
+	}
+	if target != "" {
+		status["target_url"] = target
+	}
+	_, err := doGitHubJSON(ctx, rs.client, "POST", fmt.Sprintf("%s/statuses/%s", api, head.SHA), status, &created)
+	return err
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedShowcases(t *testing.T) {
+	before := []models.Project{
+		{Name: "Go Portfolio", LongDesc: "v1", RepoNotification: models.RepoNotifyStatus},
+		{Name: "CLI", LongDesc: "v1", RepoNotification: models.RepoNotifyComment},
+		{Name: "Quiet", LongDesc: "v1"},
+	}
+	after := []models.Project{
+		{Name: "Go Portfolio", LongDesc: "v2", RepoNotification: models.RepoNotifyStatus},
+		{Name: "CLI", LongDesc: "v1", Stars: 40, RepoNotification: models.RepoNotifyComment},
+		{Name: "Quiet", LongDesc: "v2"},
+		{Name: "New Tool", RepoNotification: models.RepoNotifyStatus},
+	}
+
+	changed := changedShowcases(before, after)
+	require.Len(t, changed, 2)
+	assert.Equal(t, "Go Portfolio", changed[0].Name)
+	assert.Equal(t, "New Tool", changed[1].Name)
+}
+
+func TestParseGitHubRepo(t *testing.T) {
+	owner, repo, ok := parseGitHubRepo("https://github.com/felipemacedo1/go-portifolio.git")
+	assert.True(t, ok)
+	assert.Equal(t, "felipemacedo1", owner)
+	assert.Equal(t, "go-portifolio", repo)
+
+	_, _, ok = parseGitHubRepo("https://gitlab.com/felipemacedo1/go-portifolio")
+	assert.False(t, ok)
+	_, _, ok = parseGitHubRepo("https://github.com/felipemacedo1")
+	assert.False(t, ok)
+}
+
+func TestNotifySetsCommitStatus(t *testing.T) {
+	config.AppConfig = &config.Config{GitHubToken: "token", PortfolioURL: "https://portfolio.dev/"}
+
+	var status map[string]interface{}
+	service := NewRepoNotificationService()
+	service.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "token token", req.Header.Get("Authorization"))
+		switch req.URL.Path {
+		case "/repos/octocat/hello/commits/HEAD":
+			return jsonResponse(http.StatusOK, `{"sha":"abc123"}`), nil
+		case "/repos/octocat/hello/statuses/abc123":
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&status))
+			return jsonResponse(http.StatusCreated, `{}`), nil
+		}
+		t.Fatalf("unexpected request to %s", req.URL)
+		return nil, nil
+	})}
+
+	err := service.Notify(context.Background(), models.Project{
+		Name:             "Hello World",
+		GitHubURL:        "https://github.com/octocat/hello",
+		RepoNotification: models.RepoNotifyStatus,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "success", status["state"])
+	assert.Equal(t, repoNotificationContext, status["context"])
+	assert.Equal(t, "https://portfolio.dev/projects/hello-world", status["target_url"])
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"portfolio-backend/models"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCachePrefix starts the keys of the responses stored by the route
+// cache. Keys go on with the tag of the data a response is built from, e.g.
+// "response:content:/api/v1/content/search?q=go", so the events of that data
+// drop them.
+const responseCachePrefix = "response:"
+
+// Tags of cached responses, dropped by the events of their data
+const (
+	ResponseTagContent   = SurrogateKeyContent
+	ResponseTagGitHub    = SurrogateKeyGitHub
+	ResponseTagAnalytics = "analytics"
+)
+
+var responseInvalidation sync.Once
+
+// ResponseCacheKey keys the response to r under tag, by path and query. The
+// query is encoded with sorted parameters, so their order does not matter.
+func ResponseCacheKey(tag string, r *http.Request) string {
+	return responseCachePrefix + tag + ":" + r.URL.Path + "?" + r.URL.Query().Encode()
+}
+
+// GetResponse retrieves a response stored by the route cache
+func (cs *CacheService) GetResponse(ctx context.Context, key string) (*models.CachedResponse, error) {
+	var response models.CachedResponse
+	if err := cs.Get(ctx, key, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// SetResponse stores a response for the route cache. Once a response is
+// stored, published events drop the responses built from their data.
+func (cs *CacheService) SetResponse(ctx context.Context, key string, response *models.CachedResponse, ttl time.Duration) error {
+	responseInvalidation.Do(func() {
+		OnLiveEvent(invalidateResponses)
+	})
+	return cs.Set(ctx, key, response, ttl)
+}
+
+// responseTags lists the tags of the cached responses event makes stale
+func responseTags(event models.LiveMessage) []string {
+	switch event.Type {
+	case models.LiveContentUpdated:
+		return []string{ResponseTagContent}
+	case models.LiveGitHubSynced:
+		if data, ok := event.Data.(map[string]interface{}); ok {
+			if username, ok := data["username"].(string); ok {
+				return []string{ResponseTagGitHub + ":" + strings.ToLower(username), githubPortfolioKey}
+			}
+		}
+		return []string{ResponseTagGitHub}
+	case models.LivePageViews:
+		return []string{ResponseTagAnalytics}
+	}
+	return nil
+}
+
+// invalidateResponses drops the cached responses built from the data event
+// is about
+func invalidateResponses(event models.LiveMessage) {
+	dropResponses(responseTags(event)...)
+}
+
+// dropResponses deletes the cached responses under tags, for changes no
+// event is published for
+func dropResponses(tags ...string) {
+	if len(tags) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cacheService := NewCacheService()
+	for _, tag := range tags {
+		pattern := "^" + regexp.QuoteMeta(responseCachePrefix+tag) + ":"
+		if err := cacheService.DeletePattern(ctx, pattern); err != nil {
+			log.Printf("Failed to drop cached %s responses: %v", tag, err)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"net/http/httptest"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseCacheKey(t *testing.T) {
+	a := ResponseCacheKey(ResponseTagContent, httptest.NewRequest("GET", "/api/v1/content/search?type=projects&q=go", nil))
+	b := ResponseCacheKey(ResponseTagContent, httptest.NewRequest("GET", "/api/v1/content/search?q=go&type=projects", nil))
+	assert.Equal(t, "response:content:/api/v1/content/search?q=go&type=projects", a)
+	assert.Equal(t, a, b)
+}
+
+func TestResponseTags(t *testing.T) {
+	assert.Equal(t, []string{"content"}, responseTags(models.LiveMessage{Type: models.LiveContentUpdated}))
+	assert.Equal(t, []string{"github:octocat", "github:portfolio"}, responseTags(models.LiveMessage{
+		Type: models.LiveGitHubSynced,
+		Data: map[string]interface{}{"username": "OctoCat"},
+	}))
+	assert.Equal(t, []string{"analytics"}, responseTags(models.LiveMessage{Type: models.LivePageViews}))
+	assert.Empty(t, responseTags(models.LiveMessage{Type: models.LiveHeartbeat}))
+}
@@ -0,0 +1,450 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"portfolio-backend/metrics"
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sections of the PDF resume, laid out in the order of each template
+const (
+	resumeSectionSummary    = "summary"
+	resumeSectionExperience = "experience"
+	resumeSectionEducation  = "education"
+	resumeSectionSkills     = "skills"
+	resumeSectionProjects   = "projects"
+)
+
+// resumeTemplate is the look of a PDF resume
+type resumeTemplate struct {
+	Accent       pdfColor
+	Text         pdfColor
+	Muted        pdfColor
+	Margin       float64
+	NameSize     float64
+	HeadingSize  float64
+	BodySize     float64
+	Centered     bool // header centered rather than left aligned
+	Rules        bool // a rule under each section heading
+	TopProjects  int
+	Achievements int // achievements listed per position, 0 for all
+	Sections     []string
+}
+
+// resumeTemplates are the templates of GET /export/resume.pdf, by name
+var resumeTemplates = map[string]resumeTemplate{
+	"classic": {
+		Accent: pdfColor{0, 0, 0}, Text: pdfColor{0.1, 0.1, 0.1}, Muted: pdfColor{0.4, 0.4, 0.4},
+		Margin: 56, NameSize: 22, HeadingSize: 12, BodySize: 10,
+		Centered: true, Rules: true, TopProjects: 4,
+		Sections: []string{resumeSectionSummary, resumeSectionExperience, resumeSectionEducation, resumeSectionSkills, resumeSectionProjects},
+	},
+	"modern": {
+		Accent: pdfColor{0.12, 0.35, 0.71}, Text: pdfColor{0.13, 0.13, 0.13}, Muted: pdfColor{0.42, 0.45, 0.5},
+		Margin: 48, NameSize: 26, HeadingSize: 12, BodySize: 10,
+		TopProjects: 4,
+		Sections:    []string{resumeSectionSummary, resumeSectionSkills, resumeSectionExperience, resumeSectionProjects, resumeSectionEducation},
+	},
+	"compact": {
+		Accent: pdfColor{0.2, 0.2, 0.2}, Text: pdfColor{0.1, 0.1, 0.1}, Muted: pdfColor{0.45, 0.45, 0.45},
+		Margin: 36, NameSize: 18, HeadingSize: 10, BodySize: 8.5,
+		Rules: true, TopProjects: 3, Achievements: 3,
+		Sections: []string{resumeSectionExperience, resumeSectionSkills, resumeSectionProjects, resumeSectionEducation},
+	},
+}
+
+// DefaultResumeTemplate is used when no template is asked for
+const DefaultResumeTemplate = "classic"
+
+// ResumeTemplates lists the names of the PDF resume templates
+func ResumeTemplates() []string {
+	names := make([]string, 0, len(resumeTemplates))
+	for name := range resumeTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resumeStrings are the words of a PDF resume in one locale
+type resumeStrings struct {
+	Headings   map[string]string
+	Categories [6]string // in the order of skillCategories
+	Present    string
+	Months     [12]string
+}
+
+// resumeLocales are the locales of GET /export/resume.pdf, by language
+var resumeLocales = map[string]resumeStrings{
+	"en": {
+		Headings: map[string]string{
+			resumeSectionSummary: "Summary", resumeSectionExperience: "Experience", resumeSectionEducation: "Education",
+			resumeSectionSkills: "Skills", resumeSectionProjects: "Projects",
+		},
+		Categories: [6]string{"Backend", "Frontend", "Databases", "DevOps", "Tools", "Languages"},
+		Present:    "Present",
+		Months:     [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	},
+	"pt": {
+		Headings: map[string]string{
+			resumeSectionSummary: "Resumo", resumeSectionExperience: "Experiência", resumeSectionEducation: "Formação",
+			resumeSectionSkills: "Habilidades", resumeSectionProjects: "Projetos",
+		},
+		Categories: [6]string{"Backend", "Frontend", "Bancos de dados", "DevOps", "Ferramentas", "Linguagens"},
+		Present:    "Atual",
+		Months:     [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+	},
+	"es": {
+		Headings: map[string]string{
+			resumeSectionSummary: "Resumen", resumeSectionExperience: "Experiencia", resumeSectionEducation: "Educación",
+			resumeSectionSkills: "Habilidades", resumeSectionProjects: "Proyectos",
+		},
+		Categories: [6]string{"Backend", "Frontend", "Bases de datos", "DevOps", "Herramientas", "Lenguajes"},
+		Present:    "Actual",
+		Months:     [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+	},
+}
+
+// DefaultResumeLocale is used when no locale is asked for
+const DefaultResumeLocale = "en"
+
+// ResumeLocales lists the languages PDF resumes are written in
+func ResumeLocales() []string {
+	locales := make([]string, 0, len(resumeLocales))
+	for locale := range resumeLocales {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// ResumeLocaleSupported reports whether locale, e.g. "pt-BR", is in one of
+// the languages of ResumeLocales
+func ResumeLocaleSupported(locale string) bool {
+	_, ok := findResumeLocale(locale)
+	return ok
+}
+
+func findResumeLocale(locale string) (resumeStrings, bool) {
+	language, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	strs, ok := resumeLocales[language]
+	return strs, ok
+}
+
+// RenderResumePDF renders the published meta, experience, education, skills
+// and top projects as a PDF resume in templateName and locale, with the
+// skills shown by the display labels of labeler. Unknown templates and
+// locales fall back to the defaults.
+func (cs *ContentService) RenderResumePDF(ctx context.Context, templateName, locale string, labeler Labeler) ([]byte, error) {
+	meta, err := cs.GetMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := cs.GetSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	experience, err := cs.GetExperience(ctx)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := cs.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	education, err := cs.GetEducation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	labeler.Skills(skills)
+	start := time.Now()
+	defer func() { metrics.ObserveLayer(ctx, metrics.LayerRender, time.Since(start)) }()
+	return renderResumePDF(*meta, *skills, experience, projects, education, templateName, locale)
+}
+
+func renderResumePDF(meta models.Meta, skills models.Skills, experience []models.Experience, projects []models.Project, education []models.Education, templateName, locale string) ([]byte, error) {
+	template, ok := resumeTemplates[templateName]
+	if !ok {
+		template = resumeTemplates[DefaultResumeTemplate]
+	}
+	strs, ok := findResumeLocale(locale)
+	if !ok {
+		strs = resumeLocales[DefaultResumeLocale]
+	}
+
+	layout := &resumeLayout{
+		doc:      &pdfDocument{title: meta.Name},
+		template: template,
+		strs:     strs,
+	}
+	layout.newPage()
+	layout.header(meta)
+
+	for _, section := range template.Sections {
+		switch section {
+		case resumeSectionSummary:
+			if strings.TrimSpace(meta.Bio) != "" {
+				layout.heading(section)
+				layout.paragraph(meta.Bio, pdfRegular, template.BodySize, template.Text, 0)
+			}
+		case resumeSectionExperience:
+			layout.experience(experience)
+		case resumeSectionEducation:
+			layout.education(education)
+		case resumeSectionSkills:
+			layout.skills(skills)
+		case resumeSectionProjects:
+			layout.projects(topProjects(projects, template.TopProjects))
+		}
+	}
+	return layout.doc.bytes()
+}
+
+// topProjects returns the n projects shown on a resume: featured ones first,
+// then by stars, keeping the order of the content between equals
+func topProjects(projects []models.Project, n int) []models.Project {
+	top := append([]models.Project(nil), projects...)
+	sort.SliceStable(top, func(i, j int) bool {
+		if top[i].Featured != top[j].Featured {
+			return top[i].Featured
+		}
+		return top[i].Stars > top[j].Stars
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// resumeLayout lays a resume out top to bottom, starting new pages as it
+// fills them
+type resumeLayout struct {
+	doc      *pdfDocument
+	template resumeTemplate
+	strs     resumeStrings
+	y        float64
+}
+
+func (l *resumeLayout) newPage() {
+	l.doc.addPage()
+	l.y = pdfPageHeight - l.template.Margin
+}
+
+// ensure starts a new page unless height fits above the bottom margin
+func (l *resumeLayout) ensure(height float64) {
+	if l.y-height < l.template.Margin {
+		l.newPage()
+	}
+}
+
+func (l *resumeLayout) width() float64 {
+	return pdfPageWidth - 2*l.template.Margin
+}
+
+func (l *resumeLayout) lineHeight(size float64) float64 {
+	return size * 1.35
+}
+
+// line writes one line of text, centered or from indent
+func (l *resumeLayout) line(s, font string, size float64, color pdfColor, indent float64, centered bool) {
+	l.ensure(l.lineHeight(size))
+	l.y -= l.lineHeight(size)
+	x := l.template.Margin + indent
+	if centered {
+		x = (pdfPageWidth - pdfTextWidth(s, font, size)) / 2
+	}
+	l.doc.text(x, l.y, font, size, color, s)
+}
+
+// paragraph writes s wrapped to the text width, less indent
+func (l *resumeLayout) paragraph(s, font string, size float64, color pdfColor, indent float64) {
+	for _, line := range pdfWrap(s, font, size, l.width()-indent) {
+		l.line(line, font, size, color, indent, false)
+	}
+}
+
+// bullets writes a bulleted list, each item wrapped under its own text
+func (l *resumeLayout) bullets(items []string) {
+	size := l.template.BodySize
+	indent := pdfTextWidth("• ", pdfRegular, size)
+	for _, item := range items {
+		lines := pdfWrap(item, pdfRegular, size, l.width()-indent)
+		for i, line := range lines {
+			if i == 0 {
+				line = "• " + line
+				l.line(line, pdfRegular, size, l.template.Text, 0, false)
+				continue
+			}
+			l.line(line, pdfRegular, size, l.template.Text, indent, false)
+		}
+	}
+}
+
+func (l *resumeLayout) header(meta models.Meta) {
+	t := l.template
+	l.line(meta.Name, pdfBold, t.NameSize, t.Accent, 0, t.Centered)
+	if meta.Title != "" {
+		l.line(meta.Title, pdfRegular, t.HeadingSize, t.Text, 0, t.Centered)
+	}
+
+	var contacts []string
+	for _, contact := range []string{meta.Location, meta.Email, meta.Website, meta.LinkedIn} {
+		if contact = strings.TrimSpace(contact); contact != "" {
+			contacts = append(contacts, strings.TrimPrefix(strings.TrimPrefix(contact, "https://"), "http://"))
+		}
+	}
+	if meta.GitHub != "" {
+		contacts = append(contacts, "github.com/"+meta.GitHub)
+	}
+	if len(contacts) > 0 {
+		contact := strings.Join(contacts, "  •  ")
+		if t.Centered && pdfTextWidth(contact, pdfRegular, t.BodySize) <= l.width() {
+			l.line(contact, pdfRegular, t.BodySize, t.Muted, 0, true)
+		} else {
+			l.paragraph(contact, pdfRegular, t.BodySize, t.Muted, 0)
+		}
+	}
+}
+
+// heading starts a section, keeping it on the page of its first lines
+func (l *resumeLayout) heading(section string) {
+	t := l.template
+	l.ensure(l.lineHeight(t.HeadingSize) + 3*l.lineHeight(t.BodySize) + t.HeadingSize)
+	l.y -= t.HeadingSize * 0.8
+	l.line(strings.ToUpper(l.strs.Headings[section]), pdfBold, t.HeadingSize, t.Accent, 0, false)
+	if t.Rules {
+		l.y -= 3
+		l.doc.rule(t.Margin, pdfPageWidth-t.Margin, l.y, 0.6, t.Accent)
+		l.y -= 2
+	}
+}
+
+// entry writes the title line of an entry, with its dates on the right
+func (l *resumeLayout) entry(title, subtitle, dates string) {
+	t := l.template
+	l.ensure(2 * l.lineHeight(t.BodySize))
+	l.y -= t.BodySize * 0.4
+
+	datesWidth := pdfTextWidth(dates, pdfRegular, t.BodySize)
+	l.y -= l.lineHeight(t.BodySize)
+	l.doc.text(t.Margin, l.y, pdfBold, t.BodySize, t.Text, title)
+	if dates != "" {
+		l.doc.text(pdfPageWidth-t.Margin-datesWidth, l.y, pdfRegular, t.BodySize, t.Muted, dates)
+	}
+	if subtitle != "" {
+		l.paragraph(subtitle, pdfRegular, t.BodySize, t.Muted, 0)
+	}
+}
+
+func (l *resumeLayout) experience(experience []models.Experience) {
+	if len(experience) == 0 {
+		return
+	}
+	l.heading(resumeSectionExperience)
+	for _, exp := range experience {
+		var end *time.Time
+		if !exp.IsCurrent {
+			end = exp.EndDate
+		}
+		subtitle := exp.Company
+		if exp.Location != "" {
+			subtitle += " — " + exp.Location
+		}
+		l.entry(exp.Position, subtitle, l.period(exp.StartDate, end, exp.IsCurrent))
+		if exp.Description != "" {
+			l.paragraph(exp.Description, pdfRegular, l.template.BodySize, l.template.Text, 0)
+		}
+
+		achievements := exp.Achievements
+		if limit := l.template.Achievements; limit > 0 && len(achievements) > limit {
+			achievements = achievements[:limit]
+		}
+		l.bullets(achievements)
+		if len(exp.Technologies) > 0 {
+			l.paragraph(strings.Join(exp.Technologies, ", "), pdfRegular, l.template.BodySize, l.template.Muted, 0)
+		}
+	}
+}
+
+func (l *resumeLayout) education(education []models.Education) {
+	if len(education) == 0 {
+		return
+	}
+	l.heading(resumeSectionEducation)
+	for _, edu := range education {
+		title := edu.Degree
+		if edu.Field != "" {
+			title += ", " + edu.Field
+		}
+		l.entry(title, edu.Institution, l.period(edu.StartDate, edu.EndDate, false))
+	}
+}
+
+func (l *resumeLayout) skills(skills models.Skills) {
+	var lines []string
+	for i, category := range skillCategories(&skills) {
+		if len(category) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(category))
+		for _, skill := range category {
+			name := skill.Name
+			if skill.Label != "" {
+				name = skill.Label
+			}
+			names = append(names, name)
+		}
+		lines = append(lines, l.strs.Categories[i]+": "+strings.Join(names, ", "))
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	l.heading(resumeSectionSkills)
+	for _, line := range lines {
+		l.paragraph(line, pdfRegular, l.template.BodySize, l.template.Text, 0)
+	}
+}
+
+func (l *resumeLayout) projects(projects []models.Project) {
+	if len(projects) == 0 {
+		return
+	}
+	l.heading(resumeSectionProjects)
+	for _, project := range projects {
+		url := project.LiveURL
+		if url == "" {
+			url = project.GitHubURL
+		}
+		url = strings.TrimPrefix(url, "https://")
+		l.entry(project.Name, url, "")
+		if project.Description != "" {
+			l.paragraph(project.Description, pdfRegular, l.template.BodySize, l.template.Text, 0)
+		}
+		if len(project.Technologies) > 0 {
+			l.paragraph(strings.Join(project.Technologies, ", "), pdfRegular, l.template.BodySize, l.template.Muted, 0)
+		}
+	}
+}
+
+// period formats the months an entry spans, e.g. "Mar 2021 – Present"
+func (l *resumeLayout) period(start time.Time, end *time.Time, current bool) string {
+	month := func(date time.Time) string {
+		return fmt.Sprintf("%s %d", l.strs.Months[date.Month()-1], date.Year())
+	}
+
+	switch {
+	case start.IsZero():
+		return ""
+	case current:
+		return month(start) + " – " + l.strs.Present
+	case end == nil || end.IsZero():
+		return month(start)
+	}
+	return month(start) + " – " + month(*end)
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"portfolio-backend/models"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPDFEncode(t *testing.T) {
+	assert.Equal(t, []byte("Experi\xeancia \x96 S\xe3o Paulo ?"), pdfEncode("Experiência – São Paulo 世"))
+	assert.Equal(t, `a\(b\)\\c`, pdfEscape([]byte(`a(b)\c`)))
+}
+
+func TestPDFTextWidth(t *testing.T) {
+	assert.InDelta(t, 10*(667+556)/1000.0, pdfTextWidth("Ae", pdfRegular, 10), 0.001)
+	assert.Equal(t, pdfTextWidth("Ae", pdfRegular, 10), pdfTextWidth("Áé", pdfRegular, 10), "accented letters are as wide as their base letter")
+	assert.Greater(t, pdfTextWidth("Go", pdfBold, 10), pdfTextWidth("Go", pdfRegular, 10))
+}
+
+func TestPDFWrap(t *testing.T) {
+	width := pdfTextWidth("lorem ipsum", pdfRegular, 10)
+	assert.Equal(t, []string{"lorem ipsum", "dolor sit", "amet"}, pdfWrap("lorem ipsum dolor sit amet", pdfRegular, 10, width))
+	assert.Equal(t, []string{"incomprehensibilities", "a"}, pdfWrap("incomprehensibilities a", pdfRegular, 10, 20), "long words are left whole")
+	assert.Nil(t, pdfWrap("  ", pdfRegular, 10, width))
+}
+
+func TestPDFDocumentXref(t *testing.T) {
+	doc := &pdfDocument{title: "Résumé (draft)"}
+	doc.addPage()
+	doc.text(10, 10, pdfRegular, 10, pdfColor{}, "one")
+	doc.addPage()
+	doc.text(10, 10, pdfBold, 10, pdfColor{}, "two")
+
+	out, err := doc.bytes()
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), "/Title (R\xe9sum\xe9 \\(draft\\))")
+
+	// Every xref entry points at the start of its object
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, startxref)
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+}
+
+func TestFindResumeLocale(t *testing.T) {
+	assert.True(t, ResumeLocaleSupported("pt-BR"), "regional locales use their language")
+	assert.True(t, ResumeLocaleSupported("EN"))
+	assert.False(t, ResumeLocaleSupported("de"))
+
+	strs, _ := findResumeLocale("pt-BR")
+	assert.Equal(t, "Atual", strs.Present)
+}
+
+func TestTopProjects(t *testing.T) {
+	projects := []models.Project{
+		{Name: "a", Stars: 5},
+		{Name: "b", Stars: 50},
+		{Name: "c", Featured: true, Stars: 1},
+		{Name: "d", Stars: 50},
+	}
+
+	var names []string
+	for _, project := range topProjects(projects, 3) {
+		names = append(names, project.Name)
+	}
+	assert.Equal(t, []string{"c", "b", "d"}, names)
+	assert.Equal(t, "a", projects[0].Name, "the content is left in its order")
+}
+
+func TestResumePeriod(t *testing.T) {
+	start := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+	layout := &resumeLayout{strs: resumeLocales["pt"]}
+
+	assert.Equal(t, "mar 2021 – jun 2023", layout.period(start, &end, false))
+	assert.Equal(t, "mar 2021 – Atual", layout.period(start, &end, true))
+	assert.Equal(t, "mar 2021", layout.period(start, nil, false))
+	assert.Empty(t, layout.period(time.Time{}, nil, false))
+}
+
+func TestRenderResumePDF(t *testing.T) {
+	start := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	meta := models.Meta{Name: "Ada Lovelace", Title: "Backend Engineer", GitHub: "ada", Bio: "Builds APIs"}
+	skills := models.Skills{Backend: []models.Skill{{Name: "Go", Label: "Golang"}}}
+	var experience []models.Experience
+	for i := 0; i < 30; i++ {
+		experience = append(experience, models.Experience{
+			Company:      fmt.Sprintf("Company %d", i),
+			Position:     "Engineer",
+			StartDate:    start,
+			IsCurrent:    i == 0,
+			Achievements: []string{strings.Repeat("Shipped a feature ", 12)},
+		})
+	}
+
+	for _, name := range ResumeTemplates() {
+		out, err := renderResumePDF(meta, skills, experience, nil, nil, name, "pt-BR")
+		require.NoError(t, err, name)
+		assert.True(t, bytes.HasPrefix(out, []byte("%PDF-")), name)
+		assert.NotContains(t, string(out), "/Count 1 ", "%s: long resumes run over several pages", name)
+
+		again, err := renderResumePDF(meta, skills, experience, nil, nil, name, "pt-BR")
+		require.NoError(t, err)
+		assert.Equal(t, out, again, "%s: rendering is deterministic", name)
+	}
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	// ErrInvalidResumeLink is returned for badly signed, expired and revoked links
+	ErrInvalidResumeLink = errors.New("invalid resume link")
+	// ErrResumeLinkNotFound is returned when managing an unknown link
+	ErrResumeLinkNotFound = errors.New("resume link not found")
+	// ErrInvalidResumeLinkRequest is returned when a link cannot be issued as asked
+	ErrInvalidResumeLinkRequest = errors.New("invalid resume link request")
+	// ErrResumeUnavailable is returned when RESUME_PATH does not point to a file
+	ErrResumeUnavailable = errors.New("resume file unavailable")
+)
+
+type ResumeService struct {
+	links     *mongo.Collection
+	downloads *mongo.Collection
+}
+
+func NewResumeService() *ResumeService {
+	return &ResumeService{
+		links:     database.Database.Collection("resume_links"),
+		downloads: database.Database.Collection("resume_downloads"),
+	}
+}
+
+// CreateLink issues a signed download link for one recipient
+func (rs *ResumeService) CreateLink(ctx context.Context, request models.ResumeLinkRequest, createdBy string) (*models.ResumeLink, error) {
+	recipient := strings.TrimSpace(request.Recipient)
+	if recipient == "" {
+		return nil, fmt.Errorf("%w: recipient is required", ErrInvalidResumeLinkRequest)
+	}
+
+	ttl, err := parseExpiresIn(request.ExpiresIn, config.AppConfig.ResumeLinkTTL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResumeLinkRequest, err)
+	}
+
+	now := time.Now()
+	link := models.ResumeLink{
+		ID:        primitive.NewObjectID(),
+		Recipient: recipient,
+		CreatedAt: now,
+		CreatedBy: createdBy,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	if _, err := rs.links.InsertOne(ctx, link); err != nil {
+		return nil, err
+	}
+
+	link.URL = resumeLinkURL(link.ID.Hex())
+	return &link, nil
+}
+
+// ListLinks returns every issued link, newest first, with its download count
+func (rs *ResumeService) ListLinks(ctx context.Context) ([]models.ResumeLink, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := rs.links.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	links := []models.ResumeLink{}
+	if err := cursor.All(ctx, &links); err != nil {
+		return nil, err
+	}
+
+	// Links are signed rather than stored, so they can be shown again
+	for i := range links {
+		links[i].URL = resumeLinkURL(links[i].ID.Hex())
+	}
+	return links, nil
+}
+
+// GetDownloads returns the most recent downloads through a link
+func (rs *ResumeService) GetDownloads(ctx context.Context, id string, limit int) ([]models.ResumeDownload, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrResumeLinkNotFound
+	}
+
+	count, err := rs.links.CountDocuments(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrResumeLinkNotFound
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "downloaded_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := rs.downloads.Find(ctx, bson.M{"link_id": objectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	downloads := []models.ResumeDownload{}
+	err = cursor.All(ctx, &downloads)
+	return downloads, err
+}
+
+// RevokeLink stops a link from serving the resume before it expires
+func (rs *ResumeService) RevokeLink(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrResumeLinkNotFound
+	}
+
+	result, err := rs.links.UpdateOne(ctx,
+		bson.M{"_id": objectID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		// Revoking twice is not an error
+		count, err := rs.links.CountDocuments(ctx, bson.M{"_id": objectID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrResumeLinkNotFound
+		}
+	}
+	return nil
+}
+
+// ResumeFile returns the path of the resume served by the links
+func (rs *ResumeService) ResumeFile() (string, error) {
+	path := config.AppConfig.ResumePath
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return "", ErrResumeUnavailable
+	}
+	return path, nil
+}
+
+// RecordDownload checks a link and logs a download through it
+func (rs *ResumeService) RecordDownload(ctx context.Context, id, signature, clientIP, userAgent string) (*models.ResumeLink, error) {
+	if !hmac.Equal([]byte(signature), []byte(signResumeLink(id))) {
+		return nil, ErrInvalidResumeLink
+	}
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrInvalidResumeLink
+	}
+
+	now := time.Now()
+	filter := bson.M{
+		"_id":        objectID,
+		"revoked_at": nil,
+		"expires_at": bson.M{"$gt": now},
+	}
+	update := bson.M{
+		"$inc": bson.M{"downloads": 1},
+		"$set": bson.M{"last_download_at": now},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var link models.ResumeLink
+	err = rs.links.FindOneAndUpdate(ctx, filter, update, opts).Decode(&link)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrInvalidResumeLink
+		}
+		return nil, err
+	}
+
+	_, err = rs.downloads.InsertOne(ctx, models.ResumeDownload{
+		ID:           primitive.NewObjectID(),
+		LinkID:       link.ID,
+		Recipient:    link.Recipient,
+		IP:           clientIP,
+		UserAgent:    userAgent,
+		DownloadedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// signResumeLink signs a link id with JWT_SECRET, so link ids cannot be
+// guessed and rotating the secret invalidates every link
+func signResumeLink(id string) string {
+	mac := hmac.New(sha256.New, []byte(config.AppConfig.JWTSecret))
+	mac.Write([]byte("resume-link:" + id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func resumeLinkURL(id string) string {
+	return fmt.Sprintf("/api/v1/resume/%s?signature=%s", id, signResumeLink(id))
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResumeLinkSignature(t *testing.T) {
+	config.AppConfig = &config.Config{JWTSecret: "first-secret"}
+	id := "65a1f0c2e4b0a1b2c3d4e5f6"
+
+	signature := signResumeLink(id)
+	assert.Equal(t, signature, signResumeLink(id))
+	assert.NotEqual(t, signature, signResumeLink("65a1f0c2e4b0a1b2c3d4e5f7"))
+	assert.True(t, strings.HasSuffix(resumeLinkURL(id), "?signature="+signature))
+
+	// Rotating JWT_SECRET invalidates every issued link
+	config.AppConfig.JWTSecret = "second-secret"
+	assert.NotEqual(t, signature, signResumeLink(id))
+}
+
+func TestRecordDownloadRejectsForgedLinks(t *testing.T) {
+	config.AppConfig = &config.Config{JWTSecret: "secret"}
+	rs := &ResumeService{}
+
+	// No collections: a forged link must be turned away before MongoDB
+	_, err := rs.RecordDownload(context.Background(), "65a1f0c2e4b0a1b2c3d4e5f6", "forged", "10.0.0.1", "curl")
+	assert.ErrorIs(t, err, ErrInvalidResumeLink)
+
+	_, err = rs.RecordDownload(context.Background(), "not-an-id", signResumeLink("not-an-id"), "10.0.0.1", "curl")
+	assert.ErrorIs(t, err, ErrInvalidResumeLink)
+}
+
+func TestCreateLinkRejectsInvalidRequests(t *testing.T) {
+	config.AppConfig = &config.Config{ResumeLinkTTL: 720 * time.Hour}
+	rs := &ResumeService{}
+
+	invalid := []models.ResumeLinkRequest{
+		{},
+		{Recipient: "Acme", ExpiresIn: "0s"},
+	}
+	for _, request := range invalid {
+		_, err := rs.CreateLink(context.Background(), request, "admin")
+		assert.ErrorIs(t, err, ErrInvalidResumeLinkRequest)
+	}
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Setting keys
+const (
+	SettingDeployHooks = "deploy_hooks"
+	SettingTelegram    = "telegram"
+	SettingLastSync    = "last_sync"
+	SettingRateLimits  = "rate_limit_tiers"
+	SettingOwner       = "owner"
+	SettingPrivate     = "private_details"
+	SettingCDNPurge    = "cdn_purge"
+	SettingDomains     = "domains"
+	SettingSampling    = "analytics_sampling"
+	SettingBlocklist   = "endorsement_blocklist"
+	SettingLabels      = "display_labels"
+)
+
+// ErrSettingNotFound is returned when a setting has never been saved
+var ErrSettingNotFound = errors.New("setting not found")
+
+type SettingsService struct {
+	collection *mongo.Collection
+}
+
+func NewSettingsService() *SettingsService {
+	return &SettingsService{
+		collection: database.Database.Collection("settings"),
+	}
+}
+
+// settingDocument decodes the stored value lazily into the caller's type
+type settingDocument struct {
+	Key   string        `bson:"key"`
+	Value bson.RawValue `bson:"value"`
+}
+
+// Get decodes the setting stored under key into target
+func (ss *SettingsService) Get(ctx context.Context, key string, target interface{}) error {
+	var document settingDocument
+	err := ss.collection.FindOne(ctx, bson.M{"key": key}).Decode(&document)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return ErrSettingNotFound
+		}
+		return err
+	}
+
+	return document.Value.Unmarshal(target)
+}
+
+// Set stores value under key, replacing any previous value. A dry run
+// records the change instead.
+func (ss *SettingsService) Set(ctx context.Context, key string, value interface{}, updatedBy string) error {
+	if IsDryRun(ctx) {
+		return ss.planSet(ctx, key, value)
+	}
+
+	setting := models.Setting{
+		Key:       key,
+		Value:     value,
+		UpdatedAt: time.Now(),
+		UpdatedBy: updatedBy,
+	}
+
+	filter := bson.M{"key": key}
+	opts := options.Replace().SetUpsert(true)
+
+	// The value being replaced is only read when the write is audited
+	var previous struct {
+		Value interface{} `bson:"value"`
+	}
+	if auditing(ctx) {
+		ss.collection.FindOne(ctx, filter).Decode(&previous)
+	}
+
+	if _, err := ss.collection.ReplaceOne(ctx, filter, setting, opts); err != nil {
+		return err
+	}
+	recordAudit(ctx, "settings/"+key, previous.Value, value)
+	return nil
+}
+
+// planSet records the dry run of Set, reading the stored value into the
+// type of the new one so both compare alike
+func (ss *SettingsService) planSet(ctx context.Context, key string, value interface{}) error {
+	change := models.DryRunChange{Kind: models.DryRunSetting, Target: key}
+
+	var before interface{}
+	if value != nil {
+		previous := reflect.New(reflect.TypeOf(value))
+		err := ss.Get(ctx, key, previous.Interface())
+		switch {
+		case err == ErrSettingNotFound:
+			change.Create = true
+		case err != nil:
+			return err
+		default:
+			before = previous.Elem().Interface()
+		}
+	}
+
+	change.Diff = diffValues(before, value)
+	recordDryRun(ctx, change)
+	return nil
+}
+
+// GetRateLimitTiers returns the rate limit tiers, defaulting to the
+// RATE_LIMIT_* environment configuration until they are changed at runtime
+func (ss *SettingsService) GetRateLimitTiers(ctx context.Context) (*models.RateLimitTiers, error) {
+	tiers := models.RateLimitTiers{
+		Anonymous: models.RateLimitTier{
+			Requests: config.AppConfig.RateLimitReqs,
+			Window:   config.AppConfig.RateLimitWindow.String(),
+		},
+		Authenticated: models.RateLimitTier{
+			Requests: config.AppConfig.RateLimitAuthReqs,
+			Window:   config.AppConfig.RateLimitAuthWindow.String(),
+		},
+	}
+
+	err := ss.Get(ctx, SettingRateLimits, &tiers)
+	if err != nil && err != ErrSettingNotFound {
+		return nil, err
+	}
+	return &tiers, nil
+}
+
+// SetRateLimitTiers validates and stores the rate limit tiers
+func (ss *SettingsService) SetRateLimitTiers(ctx context.Context, tiers models.RateLimitTiers, updatedBy string) error {
+	for name, tier := range map[string]models.RateLimitTier{
+		models.RateLimitTierAnonymous:     tiers.Anonymous,
+		models.RateLimitTierAuthenticated: tiers.Authenticated,
+	} {
+		if tier.Requests <= 0 {
+			return fmt.Errorf("%s tier requires a positive number of requests", name)
+		}
+		if window, err := time.ParseDuration(tier.Window); err != nil || window <= 0 {
+			return fmt.Errorf("%s tier has an invalid window: %q", name, tier.Window)
+		}
+	}
+
+	return ss.Set(ctx, SettingRateLimits, tiers, updatedBy)
+}
+
+// GetAnalyticsSampling returns the traffic analytics sampling, recording
+// every request until it is changed at runtime
+func (ss *SettingsService) GetAnalyticsSampling(ctx context.Context) (*models.AnalyticsSampling, error) {
+	sampling := models.AnalyticsSampling{DefaultRate: 1, AlwaysSampleErrors: true}
+
+	err := ss.Get(ctx, SettingSampling, &sampling)
+	if err != nil && err != ErrSettingNotFound {
+		return nil, err
+	}
+	return &sampling, nil
+}
+
+// SetAnalyticsSampling validates and stores the traffic analytics sampling
+func (ss *SettingsService) SetAnalyticsSampling(ctx context.Context, sampling models.AnalyticsSampling, updatedBy string) error {
+	if sampling.DefaultRate < 0 || sampling.DefaultRate > 1 {
+		return fmt.Errorf("default rate must be between 0 and 1, got %v", sampling.DefaultRate)
+	}
+	for prefix, rate := range sampling.Groups {
+		if !strings.HasPrefix(prefix, "/") {
+			return fmt.Errorf("route group %q must be a path starting with /", prefix)
+		}
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("rate of route group %q must be between 0 and 1, got %v", prefix, rate)
+		}
+	}
+
+	return ss.Set(ctx, SettingSampling, sampling, updatedBy)
+}
+
+// GetOwnerSettings returns the portfolio owner and extra accounts of the
+// domain ctx was resolved to (see WithSite), or else the stored ones, falling
+// back to GITHUB_USERNAME and GITHUB_ACCOUNTS until they are set at runtime or
+// when settings cannot be read
+func (ss *SettingsService) GetOwnerSettings(ctx context.Context) models.OwnerSettings {
+	// Requests for a mapped domain showcase that domain's owner
+	if site := SiteFromContext(ctx); site != nil {
+		return site.Owner
+	}
+
+	var owner models.OwnerSettings
+	if err := ss.Get(ctx, SettingOwner, &owner); err != nil || owner.GitHubUsername == "" {
+		owner = models.OwnerSettings{GitHubUsername: config.AppConfig.GitHubUsername}
+		for _, account := range strings.Split(config.AppConfig.GitHubAccounts, ",") {
+			if account = strings.TrimSpace(account); account != "" {
+				owner.Accounts = append(owner.Accounts, account)
+			}
+		}
+	}
+	return owner
+}
+
+// GetOwner returns the GitHub username the portfolio showcases
+func (ss *SettingsService) GetOwner(ctx context.Context) string {
+	return ss.GetOwnerSettings(ctx).GitHubUsername
+}
+
+// GetAccounts returns the owner followed by the extra accounts, without
+// duplicates
+func (ss *SettingsService) GetAccounts(ctx context.Context) []string {
+	owner := ss.GetOwnerSettings(ctx)
+
+	seen := make(map[string]bool)
+	var accounts []string
+	for _, account := range append([]string{owner.GitHubUsername}, owner.Accounts...) {
+		// GitHub logins are case-insensitive
+		if key := strings.ToLower(account); !seen[key] {
+			seen[key] = true
+			accounts = append(accounts, account)
+		}
+	}
+	return accounts
+}
+
+// GitHubUserAllowed reports whether the public GitHub endpoints serve
+// username: the portfolio accounts always, others only when listed in
+// GITHUB_ALLOWED_USERS, or when it is "*"
+func (ss *SettingsService) GitHubUserAllowed(ctx context.Context, username string) bool {
+	for _, allowed := range strings.Split(config.AppConfig.GitHubAllowedUsers, ",") {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" || (allowed != "" && strings.EqualFold(allowed, username)) {
+			return true
+		}
+	}
+	for _, account := range ss.GetAccounts(ctx) {
+		if strings.EqualFold(account, username) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetOwner validates and stores the portfolio owner and extra accounts
+func (ss *SettingsService) SetOwner(ctx context.Context, owner models.OwnerSettings, updatedBy string) error {
+	for _, account := range append([]string{owner.GitHubUsername}, owner.Accounts...) {
+		if !utils.IsValidGitHubUsername(account) {
+			return fmt.Errorf("invalid GitHub username: %q", account)
+		}
+	}
+	return ss.Set(ctx, SettingOwner, owner, updatedBy)
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"strings"
+)
+
+type siteKey struct{}
+
+// WithSite makes ctx serve the portfolio of the given domain, so
+// GetOwnerSettings and everything built on it return the domain's owner
+func WithSite(ctx context.Context, site *models.SiteDomain) context.Context {
+	return context.WithValue(ctx, siteKey{}, site)
+}
+
+// SiteFromContext returns the domain ctx was resolved to, or nil for the
+// default portfolio
+func SiteFromContext(ctx context.Context) *models.SiteDomain {
+	site, _ := ctx.Value(siteKey{}).(*models.SiteDomain)
+	return site
+}
+
+// NormalizeHost lowercases a Host header and drops its port and trailing dot
+func NormalizeHost(host string) string {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// GetDomains returns the domain to owner mapping
+func (ss *SettingsService) GetDomains(ctx context.Context) ([]models.SiteDomain, error) {
+	domains := []models.SiteDomain{}
+	err := ss.Get(ctx, SettingDomains, &domains)
+	if err != nil && err != ErrSettingNotFound {
+		return nil, err
+	}
+	return domains, nil
+}
+
+// SetDomains validates and stores the domain to owner mapping, normalizing
+// the domain names
+func (ss *SettingsService) SetDomains(ctx context.Context, domains []models.SiteDomain, updatedBy string) error {
+	seen := make(map[string]bool)
+	for i := range domains {
+		domain := &domains[i]
+		domain.Domain = NormalizeHost(domain.Domain)
+		if err := validateSiteDomain(*domain); err != nil {
+			return err
+		}
+		if seen[domain.Domain] {
+			return fmt.Errorf("duplicate domain: %s", domain.Domain)
+		}
+		seen[domain.Domain] = true
+	}
+
+	return ss.Set(ctx, SettingDomains, domains, updatedBy)
+}
+
+func validateSiteDomain(domain models.SiteDomain) error {
+	if domain.Domain == "" || strings.ContainsAny(domain.Domain, "/: ") {
+		return fmt.Errorf("invalid domain: %q", domain.Domain)
+	}
+	for _, account := range append([]string{domain.Owner.GitHubUsername}, domain.Owner.Accounts...) {
+		if !utils.IsValidGitHubUsername(account) {
+			return fmt.Errorf("invalid GitHub username for %s: %q", domain.Domain, account)
+		}
+	}
+	for _, origin := range domain.CORSOrigins {
+		if !strings.HasPrefix(origin, "https://") && !strings.HasPrefix(origin, "http://") {
+			return fmt.Errorf("invalid CORS origin for %s: %s", domain.Domain, origin)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"portfolio-backend/utils"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrNoPortfolioURL is returned for a sitemap when neither PORTFOLIO_URL nor
+// the meta website says where the portfolio is
+var ErrNoPortfolioURL = errors.New("no portfolio URL: set PORTFOLIO_URL or the meta website")
+
+// sitemapDate is the layout of lastmod, a W3C date
+const sitemapDate = "2006-01-02"
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// GetSitemap returns the sitemap of the portfolio: the SITEMAP_SECTIONS pages
+// and the page of each project. On a mapped domain (see WithSite) it lists
+// the pages of that domain and only the projects of its owner's
+// repositories; otherwise the portfolio is at PORTFOLIO_URL, or at the meta
+// website.
+func (cs *ContentService) GetSitemap(ctx context.Context) ([]byte, error) {
+	projects, err := cs.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var base string
+	if site := SiteFromContext(ctx); site != nil {
+		base = "https://" + site.Domain
+		projects = siteProjects(site.Owner, projects)
+	} else {
+		meta, err := cs.GetMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+		base = config.AppConfig.PortfolioURL
+		if base == "" {
+			base = meta.Website
+		}
+	}
+	if base == "" {
+		return nil, ErrNoPortfolioURL
+	}
+
+	// When each content type was last published, for the sections' lastmod
+	opts := options.Find().SetProjection(bson.M{"type": 1, "updated_at": 1})
+	cursor, err := cs.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var contents []models.Content
+	if err := cursor.All(ctx, &contents); err != nil {
+		return nil, err
+	}
+	updated := make(map[string]time.Time, len(contents))
+	for _, content := range contents {
+		updated[content.Type] = content.UpdatedAt
+	}
+
+	return buildSitemap(base, strings.Split(config.AppConfig.SitemapSections, ","), updated, projects)
+}
+
+// buildSitemap lists the sections, dated by the content type named like
+// them ("/skills" by skills, "/" by the latest of all), then the projects,
+// dated by their updated_at
+func buildSitemap(base string, sections []string, updated map[string]time.Time, projects []models.Project) ([]byte, error) {
+	base = strings.TrimSuffix(base, "/")
+	set := sitemapURLSet{URLs: []sitemapURL{}}
+	seen := make(map[string]bool)
+	add := func(loc string, lastmod time.Time) {
+		if seen[loc] {
+			return
+		}
+		seen[loc] = true
+		url := sitemapURL{Loc: loc}
+		if !lastmod.IsZero() {
+			url.LastMod = lastmod.UTC().Format(sitemapDate)
+		}
+		set.URLs = append(set.URLs, url)
+	}
+
+	var latest time.Time
+	for _, at := range updated {
+		if at.After(latest) {
+			latest = at
+		}
+	}
+	for _, section := range sections {
+		section = strings.TrimSpace(section)
+		if section == "" {
+			continue
+		}
+		path := "/" + strings.Trim(section, "/")
+		lastmod := updated[strings.Trim(section, "/")]
+		if path == "/" {
+			lastmod = latest
+		}
+		add(base+path, lastmod)
+	}
+
+	for _, project := range projects {
+		if loc := projectPageURL(base, project); loc != "" {
+			add(loc, project.UpdatedAt)
+		}
+	}
+
+	body, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// siteProjects keeps the projects whose repository belongs to one of the
+// accounts of owner
+func siteProjects(owner models.OwnerSettings, projects []models.Project) []models.Project {
+	accounts := make(map[string]bool)
+	for _, account := range append([]string{owner.GitHubUsername}, owner.Accounts...) {
+		accounts[strings.ToLower(account)] = true
+	}
+
+	var kept []models.Project
+	for _, project := range projects {
+		if repoOwner, _, ok := parseGitHubRepo(project.GitHubURL); ok && accounts[strings.ToLower(repoOwner)] {
+			kept = append(kept, project)
+		}
+	}
+	return kept
+}
+
+// projectPageURL is the page of a project on the portfolio at base, under
+// the slug of its name
+func projectPageURL(base string, project models.Project) string {
+	slug := utils.SlugifyString(project.Name)
+	if slug == "" {
+		return ""
+	}
+	return strings.TrimSuffix(base, "/") + "/projects/" + slug
+}
+
+// RobotsTxt lets crawlers in but for the ROBOTS_DISALLOW paths and points
+// them at sitemapURL
+func RobotsTxt(sitemapURL string) string {
+	var robots strings.Builder
+	robots.WriteString("User-agent: *\n")
+	disallowed := false
+	for _, path := range strings.Split(config.AppConfig.RobotsDisallow, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			robots.WriteString("Disallow: " + path + "\n")
+			disallowed = true
+		}
+	}
+	if !disallowed {
+		robots.WriteString("Disallow:\n")
+	}
+	robots.WriteString("\nSitemap: " + sitemapURL + "\n")
+	return robots.String()
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"encoding/xml"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSitemap(t *testing.T) {
+	updated := map[string]time.Time{
+		"skills":   time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC),
+		"projects": time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+	projects := []models.Project{
+		{Name: "Go Portfolio", UpdatedAt: time.Date(2025, 1, 15, 23, 0, 0, 0, time.UTC)},
+		{Name: "Go Portfolio"},
+		{Name: "Notes"},
+	}
+	body, err := buildSitemap("https://felipe.dev/", []string{"/", " /skills/", "/blog", ""}, updated, projects)
+	require.NoError(t, err)
+
+	var set sitemapURLSet
+	require.NoError(t, xml.Unmarshal(body, &set))
+	assert.Equal(t, []sitemapURL{
+		{Loc: "https://felipe.dev/", LastMod: "2025-03-01"},
+		{Loc: "https://felipe.dev/skills", LastMod: "2025-02-01"},
+		{Loc: "https://felipe.dev/blog"},
+		{Loc: "https://felipe.dev/projects/go-portfolio", LastMod: "2025-01-15"},
+		{Loc: "https://felipe.dev/projects/notes"},
+	}, set.URLs)
+	assert.Contains(t, string(body), `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+}
+
+func TestRobotsTxt(t *testing.T) {
+	config.AppConfig = &config.Config{}
+	assert.Equal(t, "User-agent: *\nDisallow:\n\nSitemap: https://api.dev/sitemap.xml\n", RobotsTxt("https://api.dev/sitemap.xml"))
+
+	config.AppConfig = &config.Config{RobotsDisallow: "/admin, /drafts"}
+	assert.Equal(t, "User-agent: *\nDisallow: /admin\nDisallow: /drafts\n\nSitemap: https://api.dev/sitemap.xml\n", RobotsTxt("https://api.dev/sitemap.xml"))
+}
+
+func TestSiteProjects(t *testing.T) {
+	config.AppConfig = &config.Config{}
+	projects := []models.Project{
+		{Name: "Portfolio", GitHubURL: "https://github.com/felipemacedo1/go-portifolio"},
+		{Name: "Blog", GitHubURL: "https://github.com/Alice/blog"},
+		{Name: "Design system", GitHubURL: "https://github.com/alice-labs/ui"},
+		{Name: "Talk"},
+	}
+
+	owner := models.OwnerSettings{GitHubUsername: "alice", Accounts: []string{"alice-labs"}}
+	kept := siteProjects(owner, projects)
+	require.Len(t, kept, 2)
+	assert.Equal(t, "Blog", kept[0].Name)
+	assert.Equal(t, "Design system", kept[1].Name)
+}
@@ -0,0 +1,69 @@
+package services
+
+import "strings"
+
+// Surrogate keys tag public responses (Surrogate-Key for Fastly, Cache-Tag
+// for Cloudflare), so integrations purging by tag drop exactly the responses
+// built from data that changed and CDN TTLs can be long
+const (
+	SurrogateKeyContent = "content"
+	SurrogateKeyGitHub  = "github"
+
+	// contentAllKey tags responses combining every content type
+	contentAllKey = "content:all"
+	// githubPortfolioKey tags responses merging the portfolio accounts
+	githubPortfolioKey = "github:portfolio"
+)
+
+// ContentSurrogateKeys tags a response built from the given content type, or
+// from all of them when contentType is empty
+func ContentSurrogateKeys(contentType string) []string {
+	if contentType == "" {
+		return []string{SurrogateKeyContent, contentAllKey}
+	}
+	return []string{SurrogateKeyContent, "content:" + contentType}
+}
+
+// GitHubSurrogateKeys tags a response with the GitHub data of username, e.g.
+// "github:repos:octocat", or with the merged portfolio accounts when
+// username is empty. GitHub logins are case-insensitive, so keys are not.
+func GitHubSurrogateKeys(resource, username string) []string {
+	if username == "" {
+		return []string{SurrogateKeyGitHub, githubPortfolioKey}
+	}
+	username = strings.ToLower(username)
+	return []string{SurrogateKeyGitHub, "github:" + username, "github:" + resource + ":" + username}
+}
+
+// githubSyncKeys are purged once username's data has been synced
+func githubSyncKeys(username string) []string {
+	return []string{"github:" + strings.ToLower(username), githubPortfolioKey}
+}
+
+// purgeKeys lists the surrogate keys affected by a change of contentTypes
+// plus any extra keys; nil for both means everything
+func purgeKeys(contentTypes, keys []string) []string {
+	if contentTypes == nil && keys == nil {
+		return []string{SurrogateKeyContent, SurrogateKeyGitHub}
+	}
+
+	seen := make(map[string]bool)
+	purge := []string{}
+	add := func(key string) {
+		if !seen[key] {
+			seen[key] = true
+			purge = append(purge, key)
+		}
+	}
+
+	if len(contentTypes) > 0 {
+		add(contentAllKey)
+	}
+	for _, contentType := range contentTypes {
+		add("content:" + contentType)
+	}
+	for _, key := range keys {
+		add(key)
+	}
+	return purge
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"portfolio-backend/config"
+	"portfolio-backend/models"
+	"sync"
+	"time"
+)
+
+// syncRetryDelay is the wait before retrying a failed scheduled sync; it
+// doubles with every further attempt
+const syncRetryDelay = time.Minute
+
+var (
+	syncScheduleMutex sync.RWMutex
+	syncSchedule      models.SyncSchedule
+)
+
+// GetSyncSchedule returns the state of the scheduled sync
+func GetSyncSchedule() models.SyncSchedule {
+	syncScheduleMutex.RLock()
+	defer syncScheduleMutex.RUnlock()
+	return syncSchedule
+}
+
+func updateSyncSchedule(update func(schedule *models.SyncSchedule)) {
+	syncScheduleMutex.Lock()
+	defer syncScheduleMutex.Unlock()
+	update(&syncSchedule)
+}
+
+// StartSyncScheduler syncs the portfolio owner on GITHUB_SYNC_CRON, so data
+// stays fresh without waiting for a cache miss or a manual sync. Runs start
+// up to GITHUB_SYNC_JITTER late, so several instances do not hit GitHub at
+// the same moment.
+func (gs *GitHubService) StartSyncScheduler() {
+	spec := config.AppConfig.GitHubSyncCron
+	if spec == "" {
+		return
+	}
+
+	schedule, err := parseCron(spec)
+	if err != nil {
+		log.Printf("Scheduled GitHub sync disabled: %v", err)
+		updateSyncSchedule(func(s *models.SyncSchedule) {
+			*s = models.SyncSchedule{Cron: spec, Error: err.Error()}
+		})
+		return
+	}
+
+	go func() {
+		for {
+			next := schedule.next(time.Now())
+			if next.IsZero() {
+				log.Printf("Scheduled GitHub sync disabled: %q never runs", spec)
+				updateSyncSchedule(func(s *models.SyncSchedule) {
+					*s = models.SyncSchedule{Cron: spec, Error: "schedule never runs"}
+				})
+				return
+			}
+			next = next.Add(syncJitter(config.AppConfig.GitHubSyncJitter))
+
+			updateSyncSchedule(func(s *models.SyncSchedule) {
+				*s = models.SyncSchedule{Enabled: true, Cron: spec, NextRunAt: &next}
+			})
+
+			time.Sleep(time.Until(next))
+			gs.runScheduledSync()
+		}
+	}()
+}
+
+// runScheduledSync syncs the current owner, retrying failures up to
+// GITHUB_SYNC_RETRIES times with exponential backoff
+func (gs *GitHubService) runScheduledSync() {
+	defer updateSyncSchedule(func(s *models.SyncSchedule) {
+		s.Running = false
+		s.Attempt = 0
+	})
+
+	delay := syncRetryDelay
+	for attempt := 1; ; attempt++ {
+		updateSyncSchedule(func(s *models.SyncSchedule) {
+			s.Running = true
+			s.Attempt = attempt
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		username := NewSettingsService().GetOwner(ctx)
+		_, err := gs.SyncData(ctx, username, false)
+		cancel()
+
+		if err == nil {
+			return
+		}
+		if attempt > config.AppConfig.GitHubSyncRetries {
+			log.Printf("Scheduled GitHub sync for %s failed after %d attempts: %v", username, attempt, err)
+			return
+		}
+
+		log.Printf("Scheduled GitHub sync for %s failed, retrying in %s: %v", username, delay, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// syncJitter returns a random delay below max
+func syncJitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"portfolio-backend/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const telegramAPIURL = "https://api.telegram.org"
+
+// telegramPollTimeout is how long a getUpdates call waits for new messages
+const telegramPollTimeout = 30 * time.Second
+
+type TelegramService struct {
+	client          *http.Client
+	settingsService *SettingsService
+	githubService   *GitHubService
+}
+
+func NewTelegramService() *TelegramService {
+	return &TelegramService{
+		client: &http.Client{
+			Timeout: telegramPollTimeout + 10*time.Second,
+		},
+		settingsService: NewSettingsService(),
+		githubService:   NewGitHubService(),
+	}
+}
+
+type telegramUpdate struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		Text string `json:"text"`
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"message"`
+}
+
+// GetSettings returns the Telegram configuration
+func (ts *TelegramService) GetSettings(ctx context.Context) (*models.TelegramSettings, error) {
+	var settings models.TelegramSettings
+	err := ts.settingsService.Get(ctx, SettingTelegram, &settings)
+	if err != nil && err != ErrSettingNotFound {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SetSettings stores the Telegram configuration, keeping the current bot
+// token when none is given
+func (ts *TelegramService) SetSettings(ctx context.Context, settings models.TelegramSettings, updatedBy string) error {
+	if settings.BotToken == "" {
+		current, err := ts.GetSettings(ctx)
+		if err != nil {
+			return err
+		}
+		settings.BotToken = current.BotToken
+	}
+
+	if settings.Enabled && (settings.BotToken == "" || settings.ChatID == "") {
+		return fmt.Errorf("telegram requires a bot_token and chat_id when enabled")
+	}
+
+	if err := ts.settingsService.Set(ctx, SettingTelegram, settings, updatedBy); err != nil {
+		return err
+	}
+	forgetTelegramNotifySettings()
+	return nil
+}
+
+// Notify sends a message to the configured chat, doing nothing when the bot
+// is disabled
+func (ts *TelegramService) Notify(ctx context.Context, text string) error {
+	settings, err := ts.GetSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if !settings.Enabled {
+		return nil
+	}
+
+	return ts.sendMessage(ctx, settings, settings.ChatID, text)
+}
+
+// StartBot long-polls Telegram for commands sent from the configured chat
+func (ts *TelegramService) StartBot() {
+	go func() {
+		var offset int64
+		for {
+			settings, err := ts.GetSettings(context.Background())
+			if err != nil || !settings.Enabled {
+				time.Sleep(time.Minute)
+				continue
+			}
+
+			updates, err := ts.getUpdates(settings, offset)
+			if err != nil {
+				log.Printf("Telegram polling error: %v", err)
+				time.Sleep(10 * time.Second)
+				continue
+			}
+
+			for _, update := range updates {
+				offset = update.UpdateID + 1
+				if !telegramChatAllowed(update, settings) {
+					continue
+				}
+
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				reply := ts.handleCommand(ctx, update.Message.Text)
+				if err := ts.sendMessage(ctx, settings, settings.ChatID, reply); err != nil {
+					log.Printf("Telegram reply error: %v", err)
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
+// telegramChatAllowed reports whether update is a message from the
+// configured chat, the only one the bot answers
+func telegramChatAllowed(update telegramUpdate, settings *models.TelegramSettings) bool {
+	return update.Message != nil && settings.ChatID != "" && strconv.FormatInt(update.Message.Chat.ID, 10) == settings.ChatID
+}
+
+// telegramCommand returns the command a message starts with, if any.
+// Commands may be addressed as /stats@botname in group chats.
+func telegramCommand(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.SplitN(fields[0], "@", 2)[0]
+}
+
+// handleCommand answers a bot command using the service layer
+func (ts *TelegramService) handleCommand(ctx context.Context, text string) string {
+	username := ts.settingsService.GetOwner(ctx)
+
+	switch telegramCommand(text) {
+	case "/stats":
+		stats, err := ts.githubService.GetStats(ctx, username)
+		if err != nil {
+			return "Failed to load stats: " + err.Error()
+		}
+		profile, err := ts.githubService.GetProfile(ctx, username)
+		if err != nil {
+			return "Failed to load profile: " + err.Error()
+		}
+		return fmt.Sprintf("📊 %s\n⭐ Stars: %d\n🍴 Forks: %d\n📦 Repositories: %d\n👥 Followers: %d",
+			username, stats.TotalStars, stats.TotalForks, stats.TotalRepos, profile.Followers)
+
+	case "/lastsync":
+		var status models.SyncStatus
+		err := ts.settingsService.Get(ctx, SettingLastSync, &status)
+		if err == ErrSettingNotFound {
+			return "No sync has run yet"
+		}
+		if err != nil {
+			return "Failed to load sync status: " + err.Error()
+		}
+		if !status.Success {
+			return fmt.Sprintf("❌ Last sync for %s failed at %s: %s",
+				status.Username, status.SyncedAt.Format(time.RFC1123), status.Error)
+		}
+		return fmt.Sprintf("✅ Last sync for %s succeeded at %s",
+			status.Username, status.SyncedAt.Format(time.RFC1123))
+
+	case "/toprepo":
+		stats, err := ts.githubService.GetStats(ctx, username)
+		if err != nil {
+			return "Failed to load stats: " + err.Error()
+		}
+		if len(stats.TopRepositories) == 0 {
+			return "No starred repositories yet"
+		}
+		repo := stats.TopRepositories[0]
+		return fmt.Sprintf("🏆 %s\n⭐ %d stars, 🍴 %d forks\n%s", repo.Name, repo.Stars, repo.Forks, repo.HTMLURL)
+
+	default:
+		return "Available commands:\n/stats - GitHub totals\n/lastsync - last GitHub sync\n/toprepo - most starred repository"
+	}
+}
+
+func (ts *TelegramService) sendMessage(ctx context.Context, settings *models.TelegramSettings, chatID, text string) error {
+	body, err := json.Marshal(map[string]string{
+		"chat_id": chatID,
+		"text":    text,
+	})
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", telegramAPIURL, settings.BotToken)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ts.client.Do(req)
+	if err != nil {
+		return redactTelegramError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("telegram API returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (ts *TelegramService) getUpdates(settings *models.TelegramSettings, offset int64) ([]telegramUpdate, error) {
+	params := url.Values{}
+	params.Set("offset", strconv.FormatInt(offset, 10))
+	params.Set("timeout", strconv.Itoa(int(telegramPollTimeout.Seconds())))
+
+	endpoint := fmt.Sprintf("%s/bot%s/getUpdates?%s", telegramAPIURL, settings.BotToken, params.Encode())
+	resp, err := ts.client.Get(endpoint)
+	if err != nil {
+		return nil, redactTelegramError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("telegram API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		OK     bool             `json:"ok"`
+		Result []telegramUpdate `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// redactTelegramError drops the request URL, which embeds the bot token,
+// from transport errors before they are logged
+func redactTelegramError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("telegram request failed: %w", urlErr.Err)
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"encoding/json"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelegramCommand(t *testing.T) {
+	tests := map[string]string{
+		"/stats":                 "/stats",
+		"/stats@portfolio_bot":   "/stats",
+		"  /lastsync  now":       "/lastsync",
+		"/toprepo@bot extra":     "/toprepo",
+		"hello":                  "hello",
+		"":                       "",
+		"   ":                    "",
+		"/unknown@portfolio_bot": "/unknown",
+	}
+	for text, want := range tests {
+		assert.Equal(t, want, telegramCommand(text), "%q", text)
+	}
+}
+
+func TestTelegramChatAllowed(t *testing.T) {
+	update := func(raw string) telegramUpdate {
+		var decoded telegramUpdate
+		require.NoError(t, json.Unmarshal([]byte(raw), &decoded))
+		return decoded
+	}
+	settings := &models.TelegramSettings{Enabled: true, BotToken: "123:token", ChatID: "-100042"}
+
+	assert.True(t, telegramChatAllowed(update(`{"update_id":1,"message":{"text":"/stats","chat":{"id":-100042}}}`), settings))
+	assert.False(t, telegramChatAllowed(update(`{"update_id":2,"message":{"text":"/stats","chat":{"id":42}}}`), settings))
+	// Updates other than messages, e.g. edits, are ignored
+	assert.False(t, telegramChatAllowed(update(`{"update_id":3}`), settings))
+
+	// Without a chat configured nobody is answered
+	settings.ChatID = ""
+	assert.False(t, telegramChatAllowed(update(`{"update_id":4,"message":{"text":"/stats","chat":{"id":0}}}`), settings))
+}
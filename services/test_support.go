@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DefaultFixtureSet is seeded when a request names none
+const DefaultFixtureSet = "default"
+
+// fixtureAuthor is recorded as the author of seeded content and settings
+const fixtureAuthor = "test-fixtures"
+
+// ErrUnknownFixtureSet is returned for a fixture set that does not exist
+var ErrUnknownFixtureSet = errors.New("unknown fixture set")
+
+// fixtureFiles holds the fixture sets, one JSON file per set
+//
+//go:embed fixtures/*.json
+var fixtureFiles embed.FS
+
+// FixtureSets lists the names of the fixture sets, sorted
+func FixtureSets() []string {
+	entries, _ := fixtureFiles.ReadDir("fixtures")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadFixtureSet decodes the fixture set called name
+func LoadFixtureSet(name string) (*models.FixtureSet, error) {
+	if name != path.Base(name) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFixtureSet, name)
+	}
+	data, err := fixtureFiles.ReadFile(path.Join("fixtures", name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFixtureSet, name)
+	}
+
+	var fixture models.FixtureSet
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("fixture set %s: %w", name, err)
+	}
+	return &fixture, nil
+}
+
+// TestSupportService puts the database in known states for the frontend's
+// end-to-end suites. It wipes data, so it is only routed in test-support
+// mode, outside release builds.
+type TestSupportService struct {
+	database        *mongo.Database
+	contentService  *ContentService
+	settingsService *SettingsService
+	cacheService    *CacheService
+}
+
+func NewTestSupportService() *TestSupportService {
+	return &TestSupportService{
+		database:        database.Database,
+		contentService:  NewContentService(),
+		settingsService: NewSettingsService(),
+		cacheService:    NewCacheService(),
+	}
+}
+
+// Reset empties every collection, keeping their indexes, and the in-memory
+// cache, and returns the collections emptied
+func (ts *TestSupportService) Reset(ctx context.Context) ([]string, error) {
+	names, err := ts.database.ListCollectionNames(ctx, bson.M{"name": bson.M{"$not": bson.M{"$regex": `^system\.`}}})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, err := ts.database.Collection(name).DeleteMany(ctx, bson.M{}); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	l1.clear()
+	return names, nil
+}
+
+// Seed resets the database and loads the fixture set called name: its
+// owner, its content, published as an import would, and its GitHub data,
+// cached for GITHUB_CACHE_TTL
+func (ts *TestSupportService) Seed(ctx context.Context, name string) (*models.FixtureSeedResult, error) {
+	fixture, err := LoadFixtureSet(name)
+	if err != nil {
+		return nil, err
+	}
+
+	cleared, err := ts.Reset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.FixtureSeedResult{Fixture: name, Cleared: cleared, Content: []string{}, GitHub: []string{}}
+
+	if fixture.Owner != nil {
+		if err := ts.settingsService.SetOwner(ctx, *fixture.Owner, fixtureAuthor); err != nil {
+			return nil, fmt.Errorf("owner: %w", err)
+		}
+	}
+
+	if len(fixture.Content) > 0 {
+		imported, err := ts.contentService.ImportContent(ctx, fixture.Content, fixtureAuthor)
+		if err != nil {
+			return nil, err
+		}
+		result.Content = imported.Imported
+	}
+
+	usernames := make([]string, 0, len(fixture.GitHub))
+	for username := range fixture.GitHub {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	for _, username := range usernames {
+		if err := ts.cacheGitHubData(ctx, username, fixture.GitHub[username]); err != nil {
+			return nil, fmt.Errorf("github %s: %w", username, err)
+		}
+		result.GitHub = append(result.GitHub, username)
+	}
+	return result, nil
+}
+
+// cacheGitHubData caches the GitHub data of username where GitHubService
+// looks first
+func (ts *TestSupportService) cacheGitHubData(ctx context.Context, username string, data models.FixtureGitHub) error {
+	if data.Profile != nil {
+		if err := ts.cacheService.SetGitHubData(ctx, username, "profile", data.Profile); err != nil {
+			return err
+		}
+	}
+	if data.Repositories != nil {
+		if err := ts.cacheService.SetGitHubData(ctx, username, "repositories", data.Repositories); err != nil {
+			return err
+		}
+	}
+	if data.Stats != nil {
+		if err := ts.cacheService.SetGitHubData(ctx, username, "stats", data.Stats); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"portfolio-backend/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtureSetsAreValid(t *testing.T) {
+	sets := FixtureSets()
+	assert.Contains(t, sets, DefaultFixtureSet)
+
+	for _, name := range sets {
+		fixture, err := LoadFixtureSet(name)
+		require.NoError(t, err, name)
+		assert.NotEmpty(t, fixture.Description, name)
+
+		if fixture.Owner != nil {
+			assert.True(t, utils.IsValidGitHubUsername(fixture.Owner.GitHubUsername), name)
+		}
+
+		// Imported as POST /admin/content/import would validate them; sets
+		// with projects carry the experience they link to
+		problems, err := (&ContentService{}).ValidateImport(context.Background(), fixture.Content)
+		require.NoError(t, err, name)
+		assert.Empty(t, problems, name)
+
+		for username, github := range fixture.GitHub {
+			if github.Profile != nil {
+				assert.Equal(t, username, github.Profile.Login, name)
+			}
+			for _, repo := range github.Repositories {
+				assert.False(t, repo.NeedsReadme() || repo.NeedsContributors() || repo.NeedsLanguages(),
+					"%s: %s would be enriched from GitHub", name, repo.Name)
+			}
+		}
+	}
+}
+
+func TestLoadFixtureSetUnknown(t *testing.T) {
+	for _, name := range []string{"missing", "../fixtures/default", ""} {
+		_, err := LoadFixtureSet(name)
+		assert.ErrorIs(t, err, ErrUnknownFixtureSet, name)
+	}
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"math"
+	"portfolio-backend/config"
+	"portfolio-backend/database"
+	"portfolio-backend/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Page views are written in batches of up to visitorBatchSize, at least
+// every visitorFlushInterval
+const (
+	visitorBatchSize     = 100
+	visitorFlushInterval = 10 * time.Second
+)
+
+// trafficTopLimit caps the endpoints and referrers listed in traffic stats
+const trafficTopLimit = 10
+
+var visitorQueue = make(chan models.PageView, 1024)
+
+type VisitorService struct {
+	collection *mongo.Collection
+}
+
+func NewVisitorService() *VisitorService {
+	return &VisitorService{
+		collection: database.Database.Collection("page_views"),
+	}
+}
+
+// RecordPageView queues a page view for the next batch. It never blocks;
+// views are dropped while the queue is full.
+func RecordPageView(view models.PageView) {
+	select {
+	case visitorQueue <- view:
+	default:
+	}
+}
+
+// HashVisitor anonymizes a client IP with a keyed hash, so visitors can be
+// counted without storing their address
+func HashVisitor(ip string) string {
+	key := config.AppConfig.VisitorHashSalt
+	if key == "" {
+		key = config.AppConfig.JWTSecret
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// StartTracking writes queued page views in batches
+func (vs *VisitorService) StartTracking() {
+	go func() {
+		ticker := time.NewTicker(visitorFlushInterval)
+		defer ticker.Stop()
+
+		batch := make([]interface{}, 0, visitorBatchSize)
+		for {
+			select {
+			case view := <-visitorQueue:
+				batch = append(batch, view)
+				if len(batch) < visitorBatchSize {
+					continue
+				}
+			case <-ticker.C:
+				if len(batch) == 0 {
+					continue
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if _, err := vs.collection.InsertMany(ctx, batch); err != nil {
+				log.Printf("Failed to record %d page views: %v", len(batch), err)
+			} else {
+				PublishLive(models.LiveTopicAnalytics, models.LivePageViews, map[string]int{"count": len(batch)})
+			}
+			cancel()
+			batch = batch[:0]
+		}
+	}()
+}
+
+// viewWeight counts a sampled page view for the requests it stands for;
+// views recorded before sampling have no weight and count once
+var viewWeight = bson.M{"$ifNull": bson.A{"$weight", 1}}
+
+// GetTraffic aggregates the page views recorded between since and until.
+// Counts of sampled views are scaled back up by their weight; unique visitors
+// only count those seen in the sample.
+func (vs *VisitorService) GetTraffic(ctx context.Context, since, until time.Time) (*models.TrafficMetrics, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"timestamp": bson.M{"$gte": since, "$lt": until}}}},
+		{{Key: "$facet", Value: bson.M{
+			"views": bson.A{
+				bson.M{"$group": bson.M{"_id": nil, "count": bson.M{"$sum": viewWeight}}},
+			},
+			"visitors": bson.A{
+				bson.M{"$group": bson.M{"_id": "$visitor"}},
+				bson.M{"$count": "count"},
+			},
+			"endpoints": bson.A{
+				bson.M{"$group": bson.M{"_id": "$endpoint", "hits": bson.M{"$sum": viewWeight}, "avg_time": bson.M{"$avg": "$duration_ms"}}},
+				bson.M{"$sort": bson.D{{Key: "hits", Value: -1}, {Key: "_id", Value: 1}}},
+				bson.M{"$limit": trafficTopLimit},
+			},
+			"referrers": bson.A{
+				bson.M{"$match": bson.M{"referrer": bson.M{"$exists": true, "$ne": ""}}},
+				bson.M{"$group": bson.M{"_id": "$referrer", "views": bson.M{"$sum": viewWeight}}},
+				bson.M{"$sort": bson.D{{Key: "views", Value: -1}, {Key: "_id", Value: 1}}},
+				bson.M{"$limit": trafficTopLimit},
+			},
+			"countries": bson.A{
+				bson.M{"$group": bson.M{"_id": bson.M{"$ifNull": bson.A{"$country", "unknown"}}, "views": bson.M{"$sum": viewWeight}}},
+			},
+		}}},
+	}
+
+	cursor, err := vs.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	// Weighted sums are fractional once views are sampled
+	type count struct {
+		Count float64 `bson:"count"`
+	}
+	var results []struct {
+		Views     []count `bson:"views"`
+		Visitors  []count `bson:"visitors"`
+		Endpoints []struct {
+			Endpoint string  `bson:"_id"`
+			Hits     float64 `bson:"hits"`
+			AvgTime  float64 `bson:"avg_time"`
+		} `bson:"endpoints"`
+		Referrers []struct {
+			Referrer string  `bson:"_id"`
+			Views    float64 `bson:"views"`
+		} `bson:"referrers"`
+		Countries []struct {
+			Country string  `bson:"_id"`
+			Views   float64 `bson:"views"`
+		} `bson:"countries"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	traffic := &models.TrafficMetrics{
+		Since:          since,
+		Until:          until,
+		TopEndpoints:   []models.EndpointStat{},
+		TopReferrers:   []models.ReferrerStat{},
+		GeographicData: map[string]interface{}{},
+	}
+	if len(results) == 0 {
+		return traffic, nil
+	}
+
+	result := results[0]
+	if len(result.Views) > 0 {
+		traffic.PageViews = roundCount(result.Views[0].Count)
+	}
+	if len(result.Visitors) > 0 {
+		traffic.UniqueVisitors = roundCount(result.Visitors[0].Count)
+	}
+	for _, endpoint := range result.Endpoints {
+		traffic.TopEndpoints = append(traffic.TopEndpoints, models.EndpointStat{
+			Endpoint: endpoint.Endpoint,
+			Hits:     roundCount(endpoint.Hits),
+			AvgTime:  endpoint.AvgTime,
+		})
+	}
+	for _, referrer := range result.Referrers {
+		traffic.TopReferrers = append(traffic.TopReferrers, models.ReferrerStat{
+			Referrer: referrer.Referrer,
+			Views:    roundCount(referrer.Views),
+		})
+	}
+	for _, country := range result.Countries {
+		traffic.GeographicData[country.Country] = roundCount(country.Views)
+	}
+
+	return traffic, nil
+}
+
+func roundCount(count float64) int {
+	return int(math.Round(count))
+}
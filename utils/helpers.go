@@ -204,9 +204,9 @@ func SuccessResponse(c *gin.Context, data interface{}, message string) {
 		Message:   message,
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
-		Version:   "1.0.0",
+		Version:   models.APIVersion,
 	}
-	c.JSON(200, response)
+	JSON(c, 200, response)
 }
 
 // ErrorResponse creates a standardized error response
@@ -218,20 +218,29 @@ func ErrorResponse(c *gin.Context, statusCode int, message string, details strin
 		Timestamp: time.Now(),
 		RequestID: c.GetString("request_id"),
 	}
-	c.JSON(statusCode, response)
+	JSON(c, statusCode, response)
 }
 
-// ValidationErrorResponse creates a validation error response
-func ValidationErrorResponse(c *gin.Context, errors []string) {
-	response := models.ErrorResponse{
-		Success:   false,
-		Error:     "Validation failed",
-		Code:      "VALIDATION_ERROR",
-		Details:   strings.Join(errors, "; "),
-		Timestamp: time.Now(),
-		RequestID: c.GetString("request_id"),
+// validationErrorBody is an ErrorResponse listing every failed check
+type validationErrorBody struct {
+	models.ErrorResponse
+	Errors []ValidationError `json:"errors"`
+}
+
+// ValidationErrorResponse creates a validation error response listing each
+// error with its field, JSON pointer, code and message
+func ValidationErrorResponse(c *gin.Context, errors []ValidationError) {
+	response := validationErrorBody{
+		ErrorResponse: models.ErrorResponse{
+			Success:   false,
+			Error:     "Validation failed",
+			Code:      "VALIDATION_ERROR",
+			Timestamp: time.Now(),
+			RequestID: c.GetString("request_id"),
+		},
+		Errors: errors,
 	}
-	c.JSON(400, response)
+	JSON(c, 400, response)
 }
 
 // PaginatedResponse creates a paginated response
@@ -243,7 +252,7 @@ func PaginatedResponse(c *gin.Context, data interface{}, pagination models.Pagin
 		Timestamp:  time.Now(),
 		RequestID:  c.GetString("request_id"),
 	}
-	c.JSON(200, response)
+	JSON(c, 200, response)
 }
 
 // CalculatePagination calculates pagination metadata
@@ -254,8 +263,15 @@ func CalculatePagination(page, limit int, totalItems int64) models.Pagination {
 	if limit <= 0 {
 		limit = 10
 	}
+	if totalItems < 0 {
+		totalItems = 0
+	}
 	
-	totalPages := int((totalItems + int64(limit) - 1) / int64(limit))
+	// Divide before rounding up so large totals cannot overflow
+	totalPages := int(totalItems / int64(limit))
+	if totalItems%int64(limit) != 0 {
+		totalPages++
+	}
 	
 	return models.Pagination{
 		Page:       page,
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pageCeil computes ceil(total/limit) without overflow
+func pageCeil(total int64, limit int) *big.Int {
+	t := big.NewInt(total)
+	l := big.NewInt(int64(limit))
+	t.Add(t, l)
+	t.Sub(t, big.NewInt(1))
+	return t.Div(t, l)
+}
+
+func TestCalculatePaginationProperties(t *testing.T) {
+	property := func(page, limit int, totalItems int64) bool {
+		p := CalculatePagination(page, limit, totalItems)
+
+		if p.Page < 1 || p.Limit < 1 || p.TotalPages < 0 || p.TotalItems < 0 {
+			return false
+		}
+		if page > 0 && p.Page != page {
+			return false
+		}
+		if limit > 0 && p.Limit != limit {
+			return false
+		}
+		if p.HasNext != (p.Page < p.TotalPages) || p.HasPrev != (p.Page > 1) {
+			return false
+		}
+
+		// Every item lands on exactly one page and no page is empty
+		return big.NewInt(int64(p.TotalPages)).Cmp(pageCeil(p.TotalItems, p.Limit)) == 0
+	}
+
+	assert.NoError(t, quick.Check(property, &quick.Config{MaxCount: 5000}))
+}
+
+func TestCalculatePaginationEdgeCases(t *testing.T) {
+	cases := []struct {
+		name       string
+		page       int
+		limit      int
+		total      int64
+		totalPages int
+		hasNext    bool
+		hasPrev    bool
+	}{
+		{"empty", 1, 10, 0, 0, false, false},
+		{"negative total", 1, 10, -25, 0, false, false},
+		{"exact fit", 2, 10, 20, 2, false, true},
+		{"partial last page", 2, 10, 21, 3, true, true},
+		{"page past the end", 9, 10, 21, 3, false, true},
+		{"defaults", 0, 0, 15, 2, true, false},
+		{"huge limit", 1, math.MaxInt, math.MaxInt64, 1, false, false},
+		{"huge total", 1, 1 << 20, math.MaxInt64, int(math.MaxInt64/(1<<20)) + 1, true, false},
+	}
+
+	for _, tc := range cases {
+		p := CalculatePagination(tc.page, tc.limit, tc.total)
+		assert.Equal(t, tc.totalPages, p.TotalPages, tc.name)
+		assert.Equal(t, tc.hasNext, p.HasNext, tc.name)
+		assert.Equal(t, tc.hasPrev, p.HasPrev, tc.name)
+	}
+}
@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// allowedHTMLTags are the tags SanitizeHTML keeps, with the attributes
+// allowed on each besides allowedHTMLAttrs. It covers what GitHub renders
+// from Markdown: headings, lists, tables, code, images, task lists and
+// collapsible sections.
+var allowedHTMLTags = map[string][]string{
+	"a": {"href", "title", "name"}, "abbr": {"title"}, "b": nil, "blockquote": nil,
+	"br": nil, "code": nil, "dd": nil, "del": nil, "details": {"open"}, "div": nil,
+	"dl": nil, "dt": nil, "em": nil, "h1": nil, "h2": nil, "h3": nil, "h4": nil,
+	"h5": nil, "h6": nil, "hr": nil, "i": nil,
+	"img":   {"src", "alt", "title", "width", "height"},
+	"input": {"type", "checked", "disabled"}, "ins": nil, "kbd": nil, "li": nil,
+	"ol": {"start"}, "p": nil, "pre": nil, "s": nil, "samp": nil, "span": nil,
+	"strong": nil, "sub": nil, "summary": nil, "sup": nil, "table": nil,
+	"tbody": nil, "td": {"colspan", "rowspan"}, "tfoot": nil,
+	"th": {"colspan", "rowspan"}, "thead": nil, "tr": nil, "ul": nil,
+}
+
+// allowedHTMLAttrs are allowed on every kept tag
+var allowedHTMLAttrs = []string{"id", "class", "align", "dir", "lang"}
+
+// droppedHTMLTags are removed along with everything inside them
+var droppedHTMLTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "template": true, "textarea": true, "select": true,
+	"svg": true, "math": true, "frame": true, "frameset": true, "title": true,
+}
+
+var voidHTMLTags = map[string]bool{"br": true, "hr": true, "img": true, "input": true}
+
+// SanitizeHTML keeps only allowlisted tags and attributes of src, drops
+// scripts, styles and embedded frames, and removes links whose scheme is not
+// http, https or mailto. When resolveImage is given, the src of every image
+// is passed through it, e.g. to make relative paths absolute.
+func SanitizeHTML(src string, resolveImage func(string) string) string {
+	var out strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(src))
+	dropping := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			// io.EOF, as reading a string cannot fail
+			return out.String()
+		}
+		token := tokenizer.Token()
+
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedHTMLTags[token.Data] {
+				if tokenType == html.StartTagToken {
+					dropping++
+				}
+				continue
+			}
+			allowed, ok := allowedHTMLTags[token.Data]
+			if dropping > 0 || !ok {
+				continue
+			}
+			// Task list checkboxes are the only inputs rendered
+			if token.Data == "input" && htmlAttr(token, "type") != "checkbox" {
+				continue
+			}
+			writeStartTag(&out, token, allowed, resolveImage)
+
+		case html.EndTagToken:
+			if droppedHTMLTags[token.Data] {
+				if dropping > 0 {
+					dropping--
+				}
+				continue
+			}
+			if _, ok := allowedHTMLTags[token.Data]; dropping > 0 || !ok || voidHTMLTags[token.Data] {
+				continue
+			}
+			out.WriteString("</" + token.Data + ">")
+
+		case html.TextToken:
+			if dropping == 0 {
+				out.WriteString(html.EscapeString(token.Data))
+			}
+		}
+	}
+}
+
+func writeStartTag(out *strings.Builder, token html.Token, allowed []string, resolveImage func(string) string) {
+	out.WriteString("<" + token.Data)
+	for _, attr := range token.Attr {
+		if attr.Namespace != "" || !(Contains(allowed, attr.Key) || Contains(allowedHTMLAttrs, attr.Key)) {
+			continue
+		}
+
+		value := attr.Val
+		switch attr.Key {
+		case "href", "src":
+			if !safeHTMLURL(value) {
+				continue
+			}
+			if token.Data == "img" && attr.Key == "src" && resolveImage != nil {
+				value = resolveImage(value)
+			}
+		}
+		out.WriteString(" " + attr.Key + `="` + html.EscapeString(value) + `"`)
+	}
+	out.WriteString(">")
+}
+
+func htmlAttr(token html.Token, key string) string {
+	for _, attr := range token.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// safeHTMLURL accepts relative URLs and http, https and mailto ones
+func safeHTMLURL(value string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "", "http", "https", "mailto":
+		return true
+	default:
+		return false
+	}
+}
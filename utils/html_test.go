@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	src := `<h1 id="user-content-api" onclick="steal()">API &amp; docs</h1>
+<script>alert("x")</script><style>body{}</style>
+<p>See <a href="javascript:alert(1)">this</a>, <a href="https://go.dev" target="_blank">Go</a> and <font>old</font> tags.</p>
+<iframe src="https://evil.example"><p>inside</p></iframe>
+<ul><li><input type="checkbox" checked disabled> done</li><li><input type="text" value="x"></li></ul>
+<img src="docs/logo.png" alt="Logo" onerror="steal()"><img src="data:image/png;base64,AAAA">
+<pre><code class="language-go">if a &lt; b {}</code></pre><!-- comment -->`
+
+	sanitized := SanitizeHTML(src, nil)
+
+	assert.Contains(t, sanitized, `<h1 id="user-content-api">API &amp; docs</h1>`)
+	assert.Contains(t, sanitized, `<a>this</a>`)
+	assert.Contains(t, sanitized, `<a href="https://go.dev">Go</a>`)
+	assert.Contains(t, sanitized, ` old tags.`)
+	assert.Contains(t, sanitized, `<li><input type="checkbox" checked="" disabled=""> done</li><li></li>`)
+	assert.Contains(t, sanitized, `<img src="docs/logo.png" alt="Logo"><img>`)
+	assert.Contains(t, sanitized, `<pre><code class="language-go">if a &lt; b {}</code></pre>`)
+	for _, dropped := range []string{"script", "alert", "style", "iframe", "inside", "onclick", "onerror", "comment", "font", "data:"} {
+		assert.NotContains(t, sanitized, dropped)
+	}
+}
+
+func TestSanitizeHTMLResolvesImages(t *testing.T) {
+	sanitized := SanitizeHTML(`<img src="logo.png"><a href="logo.png">logo</a>`, func(src string) string {
+		return "https://cdn.example/" + src
+	})
+
+	assert.Equal(t, `<img src="https://cdn.example/logo.png"><a href="logo.png">logo</a>`, sanitized)
+	assert.NotContains(t, SanitizeHTML(`<svg><a href="x">x</a></svg>`, nil), "href")
+}
@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RecorderMode controls whether an HTTPRecorder replays or records traffic
+type RecorderMode int
+
+const (
+	// RecorderReplay serves responses from the cassette and fails on unknown requests
+	RecorderReplay RecorderMode = iota
+	// RecorderRecord forwards requests to the real transport and saves the responses
+	RecorderRecord
+)
+
+// recordedHeaders are the response headers kept in cassettes; everything else
+// (request ids, rate limit counters, cookies) changes on every call
+var recordedHeaders = []string{"Content-Type", "Link"}
+
+// Interaction is a single recorded request/response pair. Request holds the
+// JSON request body, so calls sharing a URL (like GraphQL queries) are told
+// apart; interactions without one match any body.
+type Interaction struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Request json.RawMessage   `json:"request,omitempty"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+// HTTPRecorder is a vcr-style http.RoundTripper that records real API
+// responses into a cassette file and replays them in tests
+type HTTPRecorder struct {
+	mode         RecorderMode
+	path         string
+	transport    http.RoundTripper
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewHTTPRecorder loads the cassette at path. In replay mode the cassette
+// must exist; in record mode it is rewritten by Stop.
+func NewHTTPRecorder(path string, mode RecorderMode) (*HTTPRecorder, error) {
+	recorder := &HTTPRecorder{
+		mode:      mode,
+		path:      path,
+		transport: http.DefaultTransport,
+	}
+
+	if mode == RecorderRecord {
+		return recorder, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &recorder.interactions); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+
+	return recorder, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *HTTPRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == RecorderRecord {
+		return r.record(req, body)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, interaction := range r.interactions {
+		if interaction.matches(req, body) {
+			header := make(http.Header)
+			for key, value := range interaction.Headers {
+				header.Set(key, value)
+			}
+
+			return &http.Response{
+				StatusCode: interaction.Status,
+				Status:     fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+				Header:     header,
+				Body:       io.NopCloser(bytes.NewReader(interaction.Body)),
+				Request:    req,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no recorded interaction for %s %s in %s", req.Method, req.URL, r.path)
+}
+
+func (i Interaction) matches(req *http.Request, body []byte) bool {
+	if i.Method != req.Method || i.URL != req.URL.String() {
+		return false
+	}
+	return len(i.Request) == 0 || bytes.Equal(compactJSON(i.Request), compactJSON(body))
+}
+
+// readRequestBody reads the body of req and puts it back for the transport
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// compactJSON strips insignificant whitespace so cassettes can be reindented
+func compactJSON(data []byte) []byte {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return data
+	}
+	return buf.Bytes()
+}
+
+func (r *HTTPRecorder) hasInteraction(req *http.Request, body []byte) bool {
+	for _, interaction := range r.interactions {
+		if interaction.matches(req, body) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *HTTPRecorder) record(req *http.Request, reqBody []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Status:  resp.StatusCode,
+		Headers: make(map[string]string),
+		Body:    body,
+	}
+	if len(reqBody) > 0 && json.Valid(reqBody) {
+		interaction.Request = reqBody
+	}
+	for _, key := range recordedHeaders {
+		if value := resp.Header.Get(key); value != "" {
+			interaction.Headers[key] = value
+		}
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("cannot record non-JSON response from %s", req.URL)
+	}
+
+	r.mu.Lock()
+	if !r.hasInteraction(req, reqBody) {
+		r.interactions = append(r.interactions, interaction)
+	}
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// Stop writes the recorded interactions to the cassette in record mode.
+// Request headers, including Authorization, are never stored.
+func (r *HTTPRecorder) Stop() error {
+	if r.mode != RecorderRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"net/http"
+	"portfolio-backend/models"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const problemContentType = "application/problem+json"
+
+// problemTypePrefix names problem types after error codes, e.g.
+// urn:portfolio:problem:validation-error for VALIDATION_ERROR
+const problemTypePrefix = "urn:portfolio:problem:"
+
+// AcceptsProblem reports whether the client asked for RFC 7807 problem
+// details instead of the ErrorResponse envelope
+func AcceptsProblem(c *gin.Context) bool {
+	if c.Request == nil {
+		return false
+	}
+	for _, accepted := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(accepted, ";", 2)[0])
+		if strings.EqualFold(mediaType, problemContentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// problemFor converts error response bodies into problem details; other
+// bodies are left alone
+func problemFor(c *gin.Context, status int, obj interface{}) (*models.Problem, bool) {
+	var response models.ErrorResponse
+	var errors []ValidationError
+	switch body := obj.(type) {
+	case models.ErrorResponse:
+		response = body
+	case *models.ErrorResponse:
+		response = *body
+	case validationErrorBody:
+		response, errors = body.ErrorResponse, body.Errors
+	default:
+		return nil, false
+	}
+
+	problem := &models.Problem{
+		Type:      "about:blank",
+		Title:     response.Error,
+		Status:    status,
+		Detail:    response.Details,
+		Instance:  response.RequestID,
+		Code:      response.Code,
+		RequestID: response.RequestID,
+		Timestamp: response.Timestamp,
+	}
+	if response.Code != "" {
+		problem.Type = problemTypePrefix + strings.ToLower(strings.ReplaceAll(response.Code, "_", "-"))
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(status)
+	}
+	// The request ID identifies the occurrence in the logs; requests that
+	// failed before getting one fall back to their path
+	if problem.Instance == "" && c.Request != nil {
+		problem.Instance = c.Request.URL.Path
+	}
+	if len(errors) > 0 {
+		problem.Errors = errors
+	}
+	return problem, true
+}
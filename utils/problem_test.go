@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"portfolio-backend/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validationRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.PUT("/content", func(c *gin.Context) {
+		skills := models.Skills{Backend: []models.Skill{{Name: "Go", Level: 90}, {Name: "", Level: 120}}}
+		validator := NewValidator().ValidateContent("skills", skills)
+		ValidationErrorResponse(c, validator.GetErrors())
+	})
+	return router
+}
+
+func TestValidationErrorResponseListsPointers(t *testing.T) {
+	w := httptest.NewRecorder()
+	validationRouter().ServeHTTP(w, httptest.NewRequest("PUT", "/content", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+	var body struct {
+		Code   string            `json:"code"`
+		Errors []ValidationError `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "VALIDATION_ERROR", body.Code)
+
+	pointers := []string{}
+	for _, e := range body.Errors {
+		pointers = append(pointers, e.Pointer)
+	}
+	assert.Contains(t, pointers, "/data/backend/1/name")
+	assert.Contains(t, pointers, "/data/backend/1/level")
+	assert.NotContains(t, pointers, "/data/backend/0/level")
+}
+
+func TestJSONNegotiatesProblemDetails(t *testing.T) {
+	req := httptest.NewRequest("PUT", "/content", nil)
+	req.Header.Set("Accept", "application/problem+json, application/json;q=0.9")
+	w := httptest.NewRecorder()
+	validationRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+	var problem map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "urn:portfolio:problem:validation-error", problem["type"])
+	assert.Equal(t, "Validation failed", problem["title"])
+	assert.Equal(t, float64(http.StatusBadRequest), problem["status"])
+	assert.Equal(t, "/content", problem["instance"])
+	assert.Len(t, problem["errors"], 3)
+	assert.NotContains(t, problem, "success")
+}
+
+func TestProblemInstanceIsRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/missing", func(c *gin.Context) {
+		c.Set("request_id", "3f2a9c1d")
+		JSON(c, http.StatusNotFound, models.ErrorResponse{
+			Success:   false,
+			RequestID: c.GetString("request_id"),
+		})
+	})
+
+	req := httptest.NewRequest("GET", "/missing", nil)
+	req.Header.Set("Accept", "application/problem+json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var problem models.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "about:blank", problem.Type)
+	assert.Equal(t, "Not Found", problem.Title)
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+	assert.Equal(t, "3f2a9c1d", problem.Instance)
+}
+
+func TestJSONKeepsSuccessEnvelopeForProblemClients(t *testing.T) {
+	router := envelopeRouter(JSON)
+	req := httptest.NewRequest("GET", "/envelope", nil)
+	req.Header.Set("Accept", "application/problem+json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+}
+
+func TestJSONPointerEscapesTokens(t *testing.T) {
+	assert.Equal(t, "/data/a~1b/m~0n/0", JSONPointer("data", "a/b", "m~n", 0))
+	assert.Equal(t, "", JSONPointer())
+}
+
+func TestValidateContentRejectsMismatchedData(t *testing.T) {
+	validator := NewValidator().ValidateContent("experience", map[string]interface{}{"company": "Acme"})
+	require.Len(t, validator.GetErrors(), 1)
+	assert.Equal(t, "/data", validator.GetErrors()[0].Pointer)
+	assert.Equal(t, "INVALID_DATA", validator.GetErrors()[0].Code)
+}
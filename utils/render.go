@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"portfolio-backend/metrics"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxPooledBufferSize keeps unusually large responses from pinning memory in the pool
+const maxPooledBufferSize = 64 << 10
+
+var jsonContentType = []string{"application/json; charset=utf-8"}
+
+type jsonEncoder struct {
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+var jsonEncoderPool = sync.Pool{
+	New: func() interface{} {
+		e := &jsonEncoder{}
+		e.enc = json.NewEncoder(&e.buf)
+		return e
+	},
+}
+
+// pooledJSON renders like gin's render.JSON but encodes into a reused buffer
+// instead of allocating a fresh byte slice for every response
+type pooledJSON struct {
+	Data interface{}
+	ctx  context.Context // times the encoding in the render layer
+}
+
+// Render implements render.Render
+func (r pooledJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+
+	e := jsonEncoderPool.Get().(*jsonEncoder)
+	defer func() {
+		if e.buf.Cap() <= maxPooledBufferSize {
+			e.buf.Reset()
+			jsonEncoderPool.Put(e)
+		}
+	}()
+
+	start := time.Now()
+	err := e.enc.Encode(r.Data)
+	metrics.ObserveLayer(r.ctx, metrics.LayerRender, time.Since(start))
+	if err != nil {
+		return err
+	}
+
+	// Encode terminates every value with a newline that gin's JSON does not send
+	body := e.buf.Bytes()
+	_, err = w.Write(body[:len(body)-1])
+	return err
+}
+
+// WriteContentType implements render.Render
+func (r pooledJSON) WriteContentType(w http.ResponseWriter) {
+	header := w.Header()
+	if val := header["Content-Type"]; len(val) == 0 {
+		header["Content-Type"] = jsonContentType
+	}
+}
+
+// JSON writes obj as the response body using pooled encode buffers; it is a
+// drop-in replacement for c.JSON on hot paths. Error responses are sent as
+// problem details to clients accepting application/problem+json.
+func JSON(c *gin.Context, code int, obj interface{}) {
+	if problem, ok := problemFor(c, code, obj); ok {
+		// Error bodies depend on Accept, so shared caches must not mix them up
+		c.Writer.Header().Add("Vary", "Accept")
+		if AcceptsProblem(c) {
+			c.Header("Content-Type", problemContentType)
+			obj = problem
+		}
+	}
+	ctx := context.Background()
+	if c.Request != nil {
+		ctx = c.Request.Context()
+	}
+	c.Render(code, pooledJSON{Data: obj, ctx: ctx})
+}
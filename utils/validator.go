@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
 	"portfolio-backend/models"
 	"reflect"
@@ -10,9 +11,12 @@ import (
 	"time"
 )
 
-// ValidationError represents a validation error
+// ValidationError represents a validation error. Pointer locates the field
+// in the request body as an RFC 6901 JSON pointer when the validator was
+// pointed into the body with At.
 type ValidationError struct {
 	Field   string `json:"field"`
+	Pointer string `json:"pointer,omitempty"`
 	Message string `json:"message"`
 	Code    string `json:"code"`
 }
@@ -25,7 +29,9 @@ type ValidationResult struct {
 
 // Validator provides validation functionality
 type Validator struct {
-	errors []ValidationError
+	errors  []ValidationError
+	pointer string
+	inBody  bool
 }
 
 // NewValidator creates a new validator instance
@@ -37,13 +43,39 @@ func NewValidator() *Validator {
 
 // AddError adds a validation error
 func (v *Validator) AddError(field, message, code string) {
-	v.errors = append(v.errors, ValidationError{
+	validationError := ValidationError{
 		Field:   field,
 		Message: message,
 		Code:    code,
-	})
+	}
+	if v.inBody {
+		validationError.Pointer = v.pointer + JSONPointer(field)
+	}
+	v.errors = append(v.errors, validationError)
+}
+
+// At points later errors into the request body below pointer, so that with
+// At("/data/backend/0") an invalid level is reported at /data/backend/0/level.
+// At("") points at the top of the body.
+func (v *Validator) At(pointer string) *Validator {
+	v.pointer = pointer
+	v.inBody = true
+	return v
+}
+
+// JSONPointer builds an RFC 6901 JSON pointer out of reference tokens, such
+// as field names and array indexes
+func JSONPointer(tokens ...interface{}) string {
+	var pointer strings.Builder
+	for _, token := range tokens {
+		pointer.WriteString("/")
+		pointer.WriteString(pointerEscaper.Replace(fmt.Sprint(token)))
+	}
+	return pointer.String()
 }
 
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
 // IsValid returns true if no validation errors exist
 func (v *Validator) IsValid() bool {
 	return len(v.errors) == 0
@@ -331,13 +363,124 @@ func (v *Validator) ValidateEducation(edu *models.Education) *Validator {
 	return v
 }
 
+// ValidateAvailability validates availability data
+func (v *Validator) ValidateAvailability(availability *models.Availability) *Validator {
+	v.Required("status", availability.Status).
+		OneOf("status", availability.Status, []string{"open", "closed"})
+
+	for _, role := range availability.PreferredRoles {
+		v.MaxLength("preferred_roles", role, 100)
+	}
+
+	// Validate time zone
+	if availability.TimeZone != "" {
+		if _, err := time.LoadLocation(availability.TimeZone); err != nil {
+			v.AddError("time_zone", "Time zone must be an IANA name such as America/Sao_Paulo", "INVALID_TIME_ZONE")
+		}
+	}
+
+	return v
+}
+
+// ValidateChangelogEntry validates a changelog entry
+func (v *Validator) ValidateChangelogEntry(entry *models.ChangelogEntry) *Validator {
+	v.Required("title", entry.Title).
+		MaxLength("title", entry.Title, 200)
+
+	v.MaxLength("body", entry.Body, 20000)
+	v.URL("url", entry.URL)
+	v.Required("date", entry.Date)
+
+	return v
+}
+
+// ValidateContent validates the data of a content update against the model
+// of its type, pointing errors below /data
+func (v *Validator) ValidateContent(contentType string, data interface{}) *Validator {
+	decode := func(target interface{}) bool {
+		raw, err := json.Marshal(data)
+		if err == nil {
+			err = json.Unmarshal(raw, target)
+		}
+		if err != nil {
+			v.At("").AddError("data", fmt.Sprintf("Does not match the %s content type", contentType), "INVALID_DATA")
+			return false
+		}
+		return true
+	}
+
+	switch contentType {
+	case "meta":
+		var meta models.Meta
+		if decode(&meta) {
+			v.At("/data").ValidateMeta(&meta)
+		}
+	case "skills":
+		var skills models.Skills
+		if decode(&skills) {
+			groups := []struct {
+				name   string
+				skills []models.Skill
+			}{
+				{"backend", skills.Backend},
+				{"frontend", skills.Frontend},
+				{"database", skills.Database},
+				{"devops", skills.DevOps},
+				{"tools", skills.Tools},
+				{"languages", skills.Languages},
+			}
+			for _, group := range groups {
+				for i := range group.skills {
+					v.At(JSONPointer("data", group.name, i)).ValidateSkill(&group.skills[i])
+				}
+			}
+		}
+	case "experience":
+		var experience []models.Experience
+		if decode(&experience) {
+			for i := range experience {
+				v.At(JSONPointer("data", i)).ValidateExperience(&experience[i])
+			}
+		}
+	case "projects":
+		var projects []models.Project
+		if decode(&projects) {
+			for i := range projects {
+				v.At(JSONPointer("data", i)).ValidateProject(&projects[i])
+			}
+		}
+	case "education":
+		var education []models.Education
+		if decode(&education) {
+			for i := range education {
+				v.At(JSONPointer("data", i)).ValidateEducation(&education[i])
+			}
+		}
+	case "availability":
+		var availability models.Availability
+		if decode(&availability) {
+			v.At("/data").ValidateAvailability(&availability)
+		}
+	case "changelog":
+		var changelog []models.ChangelogEntry
+		if decode(&changelog) {
+			for i := range changelog {
+				v.At(JSONPointer("data", i)).ValidateChangelogEntry(&changelog[i])
+			}
+		}
+	}
+
+	return v
+}
+
 // ValidateContentUpdateRequest validates content update request
 func (v *Validator) ValidateContentUpdateRequest(req *models.ContentUpdateRequest) *Validator {
-	validTypes := []string{"meta", "skills", "experience", "projects", "education"}
+	validTypes := []string{"meta", "skills", "experience", "projects", "education", "availability", "changelog"}
 	v.Required("type", req.Type).
 		OneOf("type", req.Type, validTypes)
 
 	v.Required("data", req.Data)
+	v.OneOf("state", req.State, []string{models.ContentStatePublished, models.ContentStateDraft})
 
 	return v
 }
@@ -350,6 +493,24 @@ func (v *Validator) ValidateGitHubSyncRequest(req *models.GitHubSyncRequest) *Va
 	return v
 }
 
+// ValidateContactMessageRequest validates a message sent through the contact form
+func (v *Validator) ValidateContactMessageRequest(req *models.ContactMessageRequest) *Validator {
+	v.Required("name", strings.TrimSpace(req.Name)).
+		MaxLength("name", req.Name, 100)
+
+	v.Required("email", req.Email).
+		Email("email", req.Email).
+		MaxLength("email", req.Email, 254)
+
+	v.MaxLength("subject", req.Subject, 200)
+
+	v.Required("message", strings.TrimSpace(req.Message)).
+		MinLength("message", strings.TrimSpace(req.Message), 10).
+		MaxLength("message", req.Message, 5000)
+
+	return v
+}
+
 // Helper functions
 
 // isEmptyValue checks if a value is considered empty